@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"inventory-management/backend/cmd/config"
+	"inventory-management/backend/internal/http"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/internal/repository"
+	"inventory-management/backend/internal/service"
+	"inventory-management/backend/util"
+	"log"
+	"os"
+)
+
+const usage = `Usage: ledger <command>
+
+Commands:
+  verify      walk the transaction ledger and report the first broken link
+  backfill    record transactions created before the ledger existed
+  checkpoint  sign the current head of the ledger, e.g. from a daily cron job`
+
+func main() {
+	if len(os.Args) != 2 || (os.Args[1] != "verify" && os.Args[1] != "backfill" && os.Args[1] != "checkpoint") {
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	configuration := config.New()
+
+	db, err := config.NewPostgresSQLGorm(configuration)
+	if err != nil {
+		log.Fatalln("There is something wrong with the database", err)
+	}
+
+	err = db.Use(repository.NewTenantPlugin())
+	if err != nil {
+		log.Fatalln("Cannot scope the database to tenants", err)
+	}
+
+	ledgerService := service.NewLedgerService(repository.NewLedgerRepository(db), repository.NewTransactionRepository(db), http.NewLedgerSigningKey(configuration))
+	tenants, err := findAllTenants(repository.NewTenantRepository(db))
+	if err != nil {
+		log.Fatalln("Cannot list the tenants", err)
+	}
+
+	// Every tenant has its own hash chain
+	broken := false
+	for _, tenant := range tenants {
+		ctx := util.WithTenant(context.Background(), tenant.ID)
+		fmt.Printf("Tenant %s\n", tenant.Code)
+
+		switch os.Args[1] {
+		case "verify":
+			verification, err := ledgerService.Verify(ctx)
+			if err != nil {
+				log.Fatalln("Cannot verify the ledger", err)
+			}
+
+			printJSON(verification)
+			broken = broken || !verification.Valid
+		case "backfill":
+			recorded, err := ledgerService.Backfill(ctx)
+			if err != nil {
+				log.Fatalln("Cannot backfill the ledger", err)
+			}
+
+			fmt.Printf("Recorded %d transactions\n", recorded)
+		case "checkpoint":
+			checkpoint, err := ledgerService.CreateCheckpoint(ctx)
+			if err != nil {
+				if err.Error() == response.ErrorLedgerEmpty {
+					fmt.Println("Skipped, the ledger has no entries")
+					continue
+				}
+				log.Fatalln("Cannot create a checkpoint", err)
+			}
+
+			printJSON(checkpoint)
+		}
+	}
+
+	if broken {
+		os.Exit(1)
+	}
+}
+
+func findAllTenants(tenantRepository repository.TenantRepositoryContract) ([]*model.Tenant, error) {
+	const batchSize = 100
+
+	var tenants []*model.Tenant
+	for offset := 0; ; offset += batchSize {
+		batch, err := tenantRepository.FindAll(context.Background(), offset, batchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		tenants = append(tenants, batch...)
+		if len(batch) < batchSize {
+			return tenants, nil
+		}
+	}
+}
+
+func printJSON(value interface{}) {
+	output, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	fmt.Println(string(output))
+}
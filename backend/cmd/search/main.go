@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"inventory-management/backend/cmd/config"
+	"inventory-management/backend/internal/http"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/internal/repository"
+	"inventory-management/backend/internal/service"
+	"inventory-management/backend/util"
+	"log"
+	"os"
+)
+
+const usage = `Usage: search reindex <index>
+
+Rebuilds the index of every tenant from the database into a new version and swaps the alias over
+to it. The index is one of products, product_qualities, transactions, suppliers, customers, users
+or all.`
+
+func main() {
+	if len(os.Args) != 3 || os.Args[1] != "reindex" {
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	selected := []string{os.Args[2]}
+	if os.Args[2] == "all" {
+		selected = model.SearchIndices
+	}
+
+	configuration := config.New()
+
+	db, err := config.NewPostgresSQLGorm(configuration)
+	if err != nil {
+		log.Fatalln("There is something wrong with the database", err)
+	}
+
+	err = db.Use(repository.NewTenantPlugin())
+	if err != nil {
+		log.Fatalln("Cannot scope the database to tenants", err)
+	}
+
+	searchEngine, err := http.NewSearchEngine(configuration, db)
+	if err != nil {
+		log.Fatalln("There is something wrong with the search engine", err)
+	}
+
+	searchService := service.NewSearchService(
+		searchEngine,
+		repository.NewProductRepository(db),
+		repository.NewProductQualityRepository(db),
+		repository.NewTransactionRepository(db),
+		repository.NewSupplierRepository(db),
+		repository.NewCustomerRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewSearchSynonymRepository(db),
+	)
+	tenants, err := findAllTenants(repository.NewTenantRepository(db))
+	if err != nil {
+		log.Fatalln("Cannot list the tenants", err)
+	}
+
+	// Every tenant has its own indices
+	for _, tenant := range tenants {
+		ctx := util.WithTenant(context.Background(), tenant.ID)
+		fmt.Printf("Tenant %s\n", tenant.Code)
+
+		for _, index := range selected {
+			reindex, err := searchService.Reindex(ctx, index)
+			if err != nil {
+				log.Fatalln("Cannot reindex "+index, err)
+			}
+
+			printJSON(reindex)
+		}
+	}
+}
+
+func findAllTenants(tenantRepository repository.TenantRepositoryContract) ([]*model.Tenant, error) {
+	const batchSize = 100
+
+	var tenants []*model.Tenant
+	for offset := 0; ; offset += batchSize {
+		batch, err := tenantRepository.FindAll(context.Background(), offset, batchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		tenants = append(tenants, batch...)
+		if len(batch) < batchSize {
+			return tenants, nil
+		}
+	}
+}
+
+func printJSON(value interface{}) {
+	output, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	fmt.Println(string(output))
+}
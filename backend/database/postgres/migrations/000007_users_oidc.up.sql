@@ -0,0 +1,7 @@
+ALTER TABLE users
+    ADD COLUMN role          VARCHAR(20)  NOT NULL DEFAULT 'staff',
+    ADD COLUMN oidc_issuer   VARCHAR(255),
+    ADD COLUMN oidc_subject  VARCHAR(255),
+    ADD CONSTRAINT users_oidc_identity_unique UNIQUE (oidc_issuer, oidc_subject);
+
+UPDATE users SET role = 'admin';
@@ -0,0 +1,27 @@
+ALTER TABLE users
+    ADD COLUMN email VARCHAR(255) UNIQUE;
+
+CREATE TABLE IF NOT EXISTS invitations
+(
+    id           SERIAL,
+    token_hash   VARCHAR(64)  NOT NULL UNIQUE,
+    email        VARCHAR(255) NOT NULL,
+    role         VARCHAR(20)  NOT NULL,
+    invited_by   VARCHAR(100) NOT NULL,
+    expires_at   TIMESTAMP    NOT NULL,
+    accepted_at  TIMESTAMP,
+    created_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS password_resets
+(
+    id           SERIAL,
+    user_id      INT          NOT NULL,
+    token_hash   VARCHAR(64)  NOT NULL UNIQUE,
+    expires_at   TIMESTAMP    NOT NULL,
+    used_at      TIMESTAMP,
+    created_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
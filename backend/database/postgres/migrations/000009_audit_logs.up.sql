@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS audit_logs
+(
+    id          BIGSERIAL,
+    actor       VARCHAR(100) NOT NULL,
+    request_id  VARCHAR(100),
+    entity      VARCHAR(50)  NOT NULL,
+    entity_id   VARCHAR(100) NOT NULL,
+    action      VARCHAR(20)  NOT NULL,
+    changes     JSONB        NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS audit_logs_entity_idx ON audit_logs (entity, entity_id);
+CREATE INDEX IF NOT EXISTS audit_logs_actor_idx ON audit_logs (actor);
+CREATE INDEX IF NOT EXISTS audit_logs_created_at_idx ON audit_logs (created_at);
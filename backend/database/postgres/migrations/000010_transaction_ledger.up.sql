@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS transaction_ledger
+(
+    id               BIGSERIAL,
+    sequence         BIGINT       NOT NULL UNIQUE,
+    transaction_code VARCHAR(100) NOT NULL,
+    action           VARCHAR(20)  NOT NULL,
+    payload          TEXT         NOT NULL,
+    previous_hash    CHAR(64)     NOT NULL,
+    hash             CHAR(64)     NOT NULL UNIQUE,
+    created_at       TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS transaction_ledger_transaction_code_idx ON transaction_ledger (transaction_code, sequence);
+
+CREATE TABLE IF NOT EXISTS ledger_checkpoints
+(
+    id         BIGSERIAL,
+    sequence   BIGINT    NOT NULL,
+    hash       CHAR(64)  NOT NULL,
+    signature  TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
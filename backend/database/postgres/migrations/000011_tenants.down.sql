@@ -0,0 +1,15 @@
+ALTER TABLE transaction_ledger DROP CONSTRAINT IF EXISTS transaction_ledger_tenant_id_sequence_key;
+ALTER TABLE transaction_ledger ADD CONSTRAINT transaction_ledger_sequence_key UNIQUE (sequence);
+
+ALTER TABLE ledger_checkpoints DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE transaction_ledger DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE invitations DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE customers DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE suppliers DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE product_qualities DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE products DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
+
+DROP TABLE IF EXISTS tenants;
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS tenants
+(
+    id           SERIAL,
+    code         VARCHAR(50)  NOT NULL UNIQUE,
+    name         VARCHAR(100) NOT NULL,
+    api_key_hash VARCHAR(64) UNIQUE,
+    created_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+
+-- Existing data belongs to the default tenant, which also operates the deployment
+INSERT INTO tenants (id, code, name) VALUES (1, 'default', 'Default');
+SELECT setval('tenants_id_seq', (SELECT MAX(id) FROM tenants));
+
+ALTER TABLE users ADD COLUMN tenant_id INT NOT NULL DEFAULT 1 REFERENCES tenants (id) ON UPDATE CASCADE;
+ALTER TABLE products ADD COLUMN tenant_id INT NOT NULL DEFAULT 1 REFERENCES tenants (id) ON UPDATE CASCADE;
+ALTER TABLE product_qualities ADD COLUMN tenant_id INT NOT NULL DEFAULT 1 REFERENCES tenants (id) ON UPDATE CASCADE;
+ALTER TABLE suppliers ADD COLUMN tenant_id INT NOT NULL DEFAULT 1 REFERENCES tenants (id) ON UPDATE CASCADE;
+ALTER TABLE customers ADD COLUMN tenant_id INT NOT NULL DEFAULT 1 REFERENCES tenants (id) ON UPDATE CASCADE;
+ALTER TABLE transactions ADD COLUMN tenant_id INT NOT NULL DEFAULT 1 REFERENCES tenants (id) ON UPDATE CASCADE;
+ALTER TABLE invitations ADD COLUMN tenant_id INT NOT NULL DEFAULT 1 REFERENCES tenants (id) ON UPDATE CASCADE;
+ALTER TABLE audit_logs ADD COLUMN tenant_id INT NOT NULL DEFAULT 1 REFERENCES tenants (id) ON UPDATE CASCADE;
+ALTER TABLE transaction_ledger ADD COLUMN tenant_id INT NOT NULL DEFAULT 1 REFERENCES tenants (id) ON UPDATE CASCADE;
+ALTER TABLE ledger_checkpoints ADD COLUMN tenant_id INT NOT NULL DEFAULT 1 REFERENCES tenants (id) ON UPDATE CASCADE;
+
+CREATE INDEX IF NOT EXISTS users_tenant_id_idx ON users (tenant_id);
+CREATE INDEX IF NOT EXISTS products_tenant_id_idx ON products (tenant_id);
+CREATE INDEX IF NOT EXISTS product_qualities_tenant_id_idx ON product_qualities (tenant_id);
+CREATE INDEX IF NOT EXISTS suppliers_tenant_id_idx ON suppliers (tenant_id);
+CREATE INDEX IF NOT EXISTS customers_tenant_id_idx ON customers (tenant_id);
+CREATE INDEX IF NOT EXISTS transactions_tenant_id_idx ON transactions (tenant_id);
+CREATE INDEX IF NOT EXISTS audit_logs_tenant_id_idx ON audit_logs (tenant_id);
+
+-- Every tenant has its own hash chain
+ALTER TABLE transaction_ledger DROP CONSTRAINT IF EXISTS transaction_ledger_sequence_key;
+ALTER TABLE transaction_ledger ADD CONSTRAINT transaction_ledger_tenant_id_sequence_key UNIQUE (tenant_id, sequence);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS search_outbox
+(
+    id           BIGSERIAL,
+    tenant_id    INT          NOT NULL DEFAULT 1 REFERENCES tenants (id) ON UPDATE CASCADE,
+    index_name   VARCHAR(50)  NOT NULL,
+    entity_key   VARCHAR(100) NOT NULL,
+    document_id  BIGINT       NOT NULL,
+    operation    VARCHAR(10)  NOT NULL,
+    attempts     INT          NOT NULL DEFAULT 0,
+    last_error   TEXT,
+    available_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS search_outbox_available_at_idx ON search_outbox (available_at, id);
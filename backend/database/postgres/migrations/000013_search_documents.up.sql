@@ -0,0 +1,14 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE TABLE IF NOT EXISTS search_documents
+(
+    id          BIGSERIAL,
+    tenant_id   INT         NOT NULL DEFAULT 1 REFERENCES tenants (id) ON UPDATE CASCADE,
+    index_name  VARCHAR(50) NOT NULL,
+    document_id BIGINT      NOT NULL,
+    document    JSONB       NOT NULL,
+    updated_at  TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS search_documents_document_idx ON search_documents (tenant_id, index_name, document_id);
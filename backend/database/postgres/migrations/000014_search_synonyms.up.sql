@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS search_synonyms
+(
+    id         BIGSERIAL,
+    tenant_id  INT       NOT NULL DEFAULT 1 REFERENCES tenants (id) ON UPDATE CASCADE,
+    words      TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS search_synonyms_tenant_id_idx ON search_synonyms (tenant_id);
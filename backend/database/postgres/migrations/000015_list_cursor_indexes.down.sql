@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS transactions_tenant_id_created_at_id_idx;
+DROP INDEX IF EXISTS customers_tenant_id_created_at_id_idx;
+DROP INDEX IF EXISTS suppliers_tenant_id_created_at_id_idx;
+DROP INDEX IF EXISTS products_tenant_id_created_at_id_idx;
+DROP INDEX IF EXISTS users_tenant_id_created_at_id_idx;
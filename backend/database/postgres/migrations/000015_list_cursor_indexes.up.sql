@@ -0,0 +1,6 @@
+-- Cursor pages seek through the newest-first order of a tenant instead of scanning past an offset
+CREATE INDEX IF NOT EXISTS users_tenant_id_created_at_id_idx ON users (tenant_id, created_at, id);
+CREATE INDEX IF NOT EXISTS products_tenant_id_created_at_id_idx ON products (tenant_id, created_at, id);
+CREATE INDEX IF NOT EXISTS suppliers_tenant_id_created_at_id_idx ON suppliers (tenant_id, created_at, id);
+CREATE INDEX IF NOT EXISTS customers_tenant_id_created_at_id_idx ON customers (tenant_id, created_at, id);
+CREATE INDEX IF NOT EXISTS transactions_tenant_id_created_at_id_idx ON transactions (tenant_id, created_at, id);
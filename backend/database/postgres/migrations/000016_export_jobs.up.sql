@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS export_jobs
+(
+    id           BIGSERIAL,
+    tenant_id    INT          NOT NULL DEFAULT 1 REFERENCES tenants (id) ON UPDATE CASCADE,
+    code         VARCHAR(50)  NOT NULL UNIQUE,
+    requested_by VARCHAR(100) NOT NULL,
+    entity       VARCHAR(50)  NOT NULL,
+    format       VARCHAR(10)  NOT NULL,
+    query        TEXT         NOT NULL DEFAULT '',
+    status       VARCHAR(20)  NOT NULL DEFAULT 'pending',
+    row_count    BIGINT       NOT NULL DEFAULT 0,
+    file_path    TEXT,
+    attempts     INT          NOT NULL DEFAULT 0,
+    last_error   TEXT,
+    available_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    created_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS export_jobs_tenant_id_requested_by_idx ON export_jobs (tenant_id, requested_by, created_at);
+CREATE INDEX IF NOT EXISTS export_jobs_status_available_at_idx ON export_jobs (status, available_at, id);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS branding_settings
+(
+    id           BIGSERIAL,
+    tenant_id    INT          NOT NULL DEFAULT 1 UNIQUE REFERENCES tenants (id) ON UPDATE CASCADE,
+    company_name VARCHAR(100) NOT NULL,
+    address      VARCHAR(255) NOT NULL DEFAULT '',
+    phone        VARCHAR(30)  NOT NULL DEFAULT '',
+    email        VARCHAR(100) NOT NULL DEFAULT '',
+    footer       VARCHAR(255) NOT NULL DEFAULT '',
+    logo         BYTEA,
+    logo_type    VARCHAR(10)  NOT NULL DEFAULT '',
+    created_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
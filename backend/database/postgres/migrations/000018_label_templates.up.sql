@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS label_templates
+(
+    id         BIGSERIAL,
+    tenant_id  INT         NOT NULL DEFAULT 1 REFERENCES tenants (id) ON UPDATE CASCADE,
+    label_type VARCHAR(30) NOT NULL,
+    symbology  VARCHAR(10) NOT NULL,
+    zpl        TEXT        NOT NULL,
+    created_at TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE (tenant_id, label_type)
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS product_qualities_tenant_id_gtin_idx;
+DROP INDEX IF EXISTS products_tenant_id_gtin_idx;
+
+ALTER TABLE product_qualities DROP COLUMN IF EXISTS gtin;
+ALTER TABLE products DROP COLUMN IF EXISTS gtin;
@@ -0,0 +1,5 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS gtin VARCHAR(14);
+ALTER TABLE product_qualities ADD COLUMN IF NOT EXISTS gtin VARCHAR(14);
+
+CREATE UNIQUE INDEX IF NOT EXISTS products_tenant_id_gtin_idx ON products (tenant_id, gtin) WHERE gtin IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS product_qualities_tenant_id_gtin_idx ON product_qualities (tenant_id, gtin) WHERE gtin IS NOT NULL;
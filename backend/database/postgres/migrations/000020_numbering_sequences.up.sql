@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS numbering_sequences
+(
+    id               BIGSERIAL,
+    tenant_id        INT          NOT NULL DEFAULT 1 REFERENCES tenants (id) ON UPDATE CASCADE,
+    entity           VARCHAR(30)  NOT NULL,
+    -- Empty for every type of transaction, and for the entities that have no type
+    transaction_type VARCHAR(30)  NOT NULL DEFAULT '',
+    strategy         VARCHAR(10)  NOT NULL,
+    pattern          VARCHAR(50)  NOT NULL DEFAULT '',
+    padding          INT          NOT NULL DEFAULT 0,
+    reset            VARCHAR(10)  NOT NULL DEFAULT 'never',
+    next_value       BIGINT       NOT NULL DEFAULT 1,
+    -- The year or month next_value counts in, empty when the sequence is never reset
+    period           VARCHAR(10)  NOT NULL DEFAULT '',
+    created_at       TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE (tenant_id, entity, transaction_type)
+);
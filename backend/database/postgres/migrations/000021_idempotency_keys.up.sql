@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    id           BIGSERIAL,
+    tenant_id    INT          NOT NULL DEFAULT 1 REFERENCES tenants (id) ON UPDATE CASCADE,
+    username     VARCHAR(100) NOT NULL,
+    key          VARCHAR(255) NOT NULL,
+    -- SHA-256 of the method, URL and body of the request the key was first sent with
+    fingerprint  VARCHAR(64)  NOT NULL,
+    -- 0 until the response of the request is stored
+    status_code  INT          NOT NULL DEFAULT 0,
+    content_type VARCHAR(100) NOT NULL DEFAULT '',
+    body         BYTEA,
+    created_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at   TIMESTAMP    NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (tenant_id, username, key)
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS transactions_tenant_id_reference_idx;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS reference;
@@ -0,0 +1,4 @@
+-- The document, such as a delivery note number, the transactions of a stock movement were posted for
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS reference VARCHAR(100);
+
+CREATE INDEX IF NOT EXISTS transactions_tenant_id_reference_idx ON transactions (tenant_id, reference) WHERE reference IS NOT NULL;
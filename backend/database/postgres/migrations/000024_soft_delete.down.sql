@@ -0,0 +1,21 @@
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_customer_code_fkey;
+ALTER TABLE transactions ADD CONSTRAINT transactions_customer_code_fkey
+    FOREIGN KEY (customer_code) REFERENCES customers(code) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_supplier_code_fkey;
+ALTER TABLE transactions ADD CONSTRAINT transactions_supplier_code_fkey
+    FOREIGN KEY (supplier_code) REFERENCES suppliers(code) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_product_quality_id_fkey;
+ALTER TABLE transactions ADD CONSTRAINT transactions_product_quality_id_fkey
+    FOREIGN KEY (product_quality_id) REFERENCES product_qualities(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS users_deleted_at_idx;
+DROP INDEX IF EXISTS customers_deleted_at_idx;
+DROP INDEX IF EXISTS suppliers_deleted_at_idx;
+DROP INDEX IF EXISTS product_qualities_deleted_at_idx;
+DROP INDEX IF EXISTS products_deleted_at_idx;
+
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE customers DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE suppliers DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE product_qualities DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE products DROP COLUMN IF EXISTS deleted_at;
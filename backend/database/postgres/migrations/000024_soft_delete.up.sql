@@ -0,0 +1,23 @@
+-- Deleting master data only marks it as deleted, the history referencing it stays intact
+ALTER TABLE products ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE product_qualities ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE suppliers ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE customers ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS products_deleted_at_idx ON products (deleted_at);
+CREATE INDEX IF NOT EXISTS product_qualities_deleted_at_idx ON product_qualities (deleted_at);
+CREATE INDEX IF NOT EXISTS suppliers_deleted_at_idx ON suppliers (deleted_at);
+CREATE INDEX IF NOT EXISTS customers_deleted_at_idx ON customers (deleted_at);
+CREATE INDEX IF NOT EXISTS users_deleted_at_idx ON users (deleted_at);
+
+-- Purging a record must never take its transactions along
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_product_quality_id_fkey;
+ALTER TABLE transactions ADD CONSTRAINT transactions_product_quality_id_fkey
+    FOREIGN KEY (product_quality_id) REFERENCES product_qualities(id) ON UPDATE CASCADE ON DELETE RESTRICT;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_supplier_code_fkey;
+ALTER TABLE transactions ADD CONSTRAINT transactions_supplier_code_fkey
+    FOREIGN KEY (supplier_code) REFERENCES suppliers(code) ON UPDATE CASCADE ON DELETE RESTRICT;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_customer_code_fkey;
+ALTER TABLE transactions ADD CONSTRAINT transactions_customer_code_fkey
+    FOREIGN KEY (customer_code) REFERENCES customers(code) ON UPDATE CASCADE ON DELETE RESTRICT;
@@ -0,0 +1,13 @@
+DO
+$$
+BEGIN
+    EXECUTE format('ALTER DATABASE %I RESET pg_trgm.word_similarity_threshold', current_database());
+END
+$$;
+
+DROP INDEX IF EXISTS search_documents_product_code_trgm_idx;
+DROP INDEX IF EXISTS search_documents_code_trgm_idx;
+DROP INDEX IF EXISTS search_documents_document_trgm_idx;
+DROP INDEX IF EXISTS search_documents_search_vector_idx;
+
+ALTER TABLE search_documents DROP COLUMN IF EXISTS search_vector;
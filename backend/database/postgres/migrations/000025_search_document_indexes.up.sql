@@ -0,0 +1,20 @@
+-- The words of every string and number of a document, so a search finds its candidates by index
+-- before it matches the words of the searched fields
+ALTER TABLE search_documents
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (jsonb_to_tsvector('simple', document, '["string", "numeric"]')) STORED;
+
+CREATE INDEX IF NOT EXISTS search_documents_search_vector_idx ON search_documents USING GIN (search_vector);
+
+-- Misspelled words are found by the trigrams of the whole document, then matched against the
+-- searched fields, and codes are suggested by the trigrams of their prefix
+CREATE INDEX IF NOT EXISTS search_documents_document_trgm_idx ON search_documents USING GIN ((document::text) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS search_documents_code_trgm_idx ON search_documents USING GIN ((document->>'code') gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS search_documents_product_code_trgm_idx ON search_documents USING GIN ((document->>'product_code') gin_trgm_ops);
+
+-- The <% operator finding the misspelled words matches at the similarity of the search engine
+DO
+$$
+BEGIN
+    EXECUTE format('ALTER DATABASE %I SET pg_trgm.word_similarity_threshold = 0.4', current_database());
+END
+$$;
@@ -0,0 +1,20 @@
+ALTER TABLE product_qualities DROP CONSTRAINT IF EXISTS product_qualities_product_code_fkey;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_supplier_code_fkey;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_customer_code_fkey;
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_tenant_id_code_key;
+ALTER TABLE customers DROP CONSTRAINT IF EXISTS customers_tenant_id_code_key;
+ALTER TABLE suppliers DROP CONSTRAINT IF EXISTS suppliers_tenant_id_code_key;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_tenant_id_code_key;
+
+ALTER TABLE products ADD CONSTRAINT products_code_key UNIQUE (code);
+ALTER TABLE suppliers ADD CONSTRAINT suppliers_code_key UNIQUE (code);
+ALTER TABLE customers ADD CONSTRAINT customers_code_key UNIQUE (code);
+ALTER TABLE transactions ADD CONSTRAINT transactions_code_key UNIQUE (code);
+
+ALTER TABLE product_qualities ADD CONSTRAINT product_qualities_product_code_fkey
+    FOREIGN KEY (product_code) REFERENCES products(code) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE transactions ADD CONSTRAINT transactions_supplier_code_fkey
+    FOREIGN KEY (supplier_code) REFERENCES suppliers(code) ON UPDATE CASCADE ON DELETE RESTRICT;
+ALTER TABLE transactions ADD CONSTRAINT transactions_customer_code_fkey
+    FOREIGN KEY (customer_code) REFERENCES customers(code) ON UPDATE CASCADE ON DELETE RESTRICT;
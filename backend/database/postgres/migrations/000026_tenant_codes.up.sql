@@ -0,0 +1,22 @@
+-- Every tenant numbers its records on its own, so a code is only unique within the tenant, and
+-- records reference the code of their own tenant
+ALTER TABLE product_qualities DROP CONSTRAINT IF EXISTS product_qualities_product_code_fkey;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_supplier_code_fkey;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_customer_code_fkey;
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_code_key;
+ALTER TABLE suppliers DROP CONSTRAINT IF EXISTS suppliers_code_key;
+ALTER TABLE customers DROP CONSTRAINT IF EXISTS customers_code_key;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_code_key;
+
+ALTER TABLE products ADD CONSTRAINT products_tenant_id_code_key UNIQUE (tenant_id, code);
+ALTER TABLE suppliers ADD CONSTRAINT suppliers_tenant_id_code_key UNIQUE (tenant_id, code);
+ALTER TABLE customers ADD CONSTRAINT customers_tenant_id_code_key UNIQUE (tenant_id, code);
+ALTER TABLE transactions ADD CONSTRAINT transactions_tenant_id_code_key UNIQUE (tenant_id, code);
+
+ALTER TABLE product_qualities ADD CONSTRAINT product_qualities_product_code_fkey
+    FOREIGN KEY (tenant_id, product_code) REFERENCES products(tenant_id, code) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE transactions ADD CONSTRAINT transactions_supplier_code_fkey
+    FOREIGN KEY (tenant_id, supplier_code) REFERENCES suppliers(tenant_id, code) ON UPDATE CASCADE ON DELETE RESTRICT;
+ALTER TABLE transactions ADD CONSTRAINT transactions_customer_code_fkey
+    FOREIGN KEY (tenant_id, customer_code) REFERENCES customers(tenant_id, code) ON UPDATE CASCADE ON DELETE RESTRICT;
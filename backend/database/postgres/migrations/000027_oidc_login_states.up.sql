@@ -0,0 +1,19 @@
+-- Logins started with the identity provider, until the browser that started one comes back with
+-- its state or it expires
+CREATE TABLE IF NOT EXISTS oidc_login_states
+(
+    id              BIGSERIAL,
+    -- SHA-256 of the state sent to the identity provider
+    state_hash      VARCHAR(64)  NOT NULL UNIQUE,
+    nonce           VARCHAR(64)  NOT NULL,
+    code_verifier   VARCHAR(128) NOT NULL,
+    -- The signed in user the identity is linked to, empty for logins
+    link_username   VARCHAR(100) NOT NULL DEFAULT '',
+    -- The tenant whose API key started the login, new users are created in it
+    login_tenant_id INT REFERENCES tenants (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    created_at      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at      TIMESTAMP    NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS oidc_login_states_expires_at_idx ON oidc_login_states (expires_at);
@@ -0,0 +1,3 @@
+-- Bumped whenever the password of the user changes, tokens issued before then are refused
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS token_version INT NOT NULL DEFAULT 0;
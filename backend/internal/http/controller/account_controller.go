@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/service"
+	"inventory-management/backend/util"
+)
+
+type AccountController struct {
+	AccountService service.AccountServiceContract
+}
+
+func NewAccountController(accountService service.AccountServiceContract, route fiber.Router) AccountController {
+	controller := AccountController{
+		AccountService: accountService,
+	}
+
+	me := route.Group("/me")
+	{
+		me.Get("/", controller.Me)
+		me.Patch("/", controller.UpdateMe)
+		me.Post("/password", controller.ChangePassword)
+	}
+
+	invitation := route.Group("/invitations")
+	{
+		invitation.Post("/", controller.CreateInvitation)
+	}
+
+	return controller
+}
+
+func (controller *AccountController) Me(ctx *fiber.Ctx) error {
+	username, _ := ctx.Locals("username").(string)
+
+	user, err := controller.AccountService.Me(ctx.UserContext(), username)
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", user).Build()
+}
+
+func (controller *AccountController) UpdateMe(ctx *fiber.Ctx) error {
+	var updateMeRequest request.UpdateMeRequest
+	if err := ctx.BodyParser(&updateMeRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(updateMeRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	updateMeRequest.Username, _ = ctx.Locals("username").(string)
+	user, err := controller.AccountService.UpdateMe(ctx.UserContext(), &updateMeRequest)
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "updated", user).Build()
+}
+
+func (controller *AccountController) ChangePassword(ctx *fiber.Ctx) error {
+	var changePasswordRequest request.ChangePasswordRequest
+	if err := ctx.BodyParser(&changePasswordRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(changePasswordRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	changePasswordRequest.Username, _ = ctx.Locals("username").(string)
+	err := controller.AccountService.ChangePassword(ctx.UserContext(), &changePasswordRequest)
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		if err.Error() == response.ErrorInvalidPassword {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "password changed", nil).Build()
+}
+
+func (controller *AccountController) CreateInvitation(ctx *fiber.Ctx) error {
+	var invitationRequest request.CreateInvitationRequest
+	if err := ctx.BodyParser(&invitationRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(invitationRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	invitationRequest.InvitedBy, _ = ctx.Locals("username").(string)
+	invitation, err := controller.AccountService.CreateInvitation(ctx.UserContext(), &invitationRequest)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusCreated, "created", invitation).Build()
+}
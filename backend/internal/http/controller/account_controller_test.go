@@ -0,0 +1,337 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/http/middleware"
+	"inventory-management/backend/internal/http/request"
+	response "inventory-management/backend/internal/http/response"
+	service "inventory-management/backend/internal/service/mock"
+	"inventory-management/backend/util"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newAccountTestApp(svc *service.AccountServiceMock) *fiber.App {
+	app := fiber.New(middleware.FiberConfig())
+	app.Use(func(ctx *fiber.Ctx) error {
+		ctx.Locals("username", "wdyarfn")
+		return ctx.Next()
+	})
+
+	route := app.Group("/api")
+	NewAccountController(svc, route)
+
+	return app
+}
+
+func TestAccountController_Me(t *testing.T) {
+	testCases := []struct {
+		name           string
+		expectedStatus string
+		expectedBody   *response.UserResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Current user exists",
+			expectedStatus: "OK",
+			expectedBody: &response.UserResponse{
+				ID:       1,
+				Name:     "Widdy Arfiansyah",
+				Username: "wdyarfn",
+				Role:     "staff",
+			},
+			expectedCode:  http.StatusOK,
+			expectedError: nil,
+		},
+		{
+			name:           "Current user doesnt exists",
+			expectedStatus: response.ErrorNotFound,
+			expectedBody:   nil,
+			expectedCode:   http.StatusNotFound,
+			expectedError:  errors.New(response.ErrorNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var svc service.AccountServiceMock
+			svc.On("Me", ctx, "wdyarfn").Return(tc.expectedBody, tc.expectedError)
+			app := newAccountTestApp(&svc)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/me", nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
+
+func TestAccountController_UpdateMe(t *testing.T) {
+	testCases := []struct {
+		name           string
+		request        *request.UpdateMeRequest
+		expectedStatus string
+		expectedBody   *response.UserResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name: "Update current user profile",
+			request: &request.UpdateMeRequest{
+				Name:  "Widdy Arfiansyah",
+				Email: util.ToPointerString("wdyarfn@example.com"),
+			},
+			expectedStatus: "updated",
+			expectedBody: &response.UserResponse{
+				ID:       1,
+				Name:     "Widdy Arfiansyah",
+				Username: "wdyarfn",
+				Email:    util.ToPointerString("wdyarfn@example.com"),
+			},
+			expectedCode:  http.StatusOK,
+			expectedError: nil,
+		},
+		{
+			name: "[missing] Update current user profile with invalid email",
+			request: &request.UpdateMeRequest{
+				Name:  "Widdy Arfiansyah",
+				Email: util.ToPointerString("wdyarfn"),
+			},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'email' for 'Email' field"),
+		},
+		{
+			name: "Service getting an error",
+			request: &request.UpdateMeRequest{
+				Name: "Widdy Arfiansyah",
+			},
+			expectedStatus: "getting an error",
+			expectedCode:   http.StatusInternalServerError,
+			expectedError:  errors.New("getting an error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var svc service.AccountServiceMock
+			expectedRequest := *tc.request
+			expectedRequest.Username = "wdyarfn"
+			svc.On("UpdateMe", ctx, &expectedRequest).Return(tc.expectedBody, tc.expectedError)
+			app := newAccountTestApp(&svc)
+
+			byteRequest, err := json.Marshal(tc.request)
+			assert.Nil(t, err)
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/me", bytes.NewReader(byteRequest))
+			req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			if strings.Contains(tc.name, "[missing]") {
+				var responseBody response.ErrorValidationResponse
+				err = json.NewDecoder(res.Body).Decode(&responseBody)
+				assert.Nil(t, err)
+
+				assert.Equal(t, responseBody.Code, tc.expectedCode)
+				assert.Equal(t, responseBody.Status, tc.expectedStatus)
+				assert.Equal(t, responseBody.Error[0].Value, tc.expectedError.Error())
+				return
+			}
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
+
+func TestAccountController_ChangePassword(t *testing.T) {
+	testCases := []struct {
+		name           string
+		request        *request.ChangePasswordRequest
+		expectedStatus string
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name: "Change password with the correct current password",
+			request: &request.ChangePasswordRequest{
+				CurrentPassword: "12345678910",
+				NewPassword:     "abcdefghijk",
+			},
+			expectedStatus: "password changed",
+			expectedCode:   http.StatusOK,
+			expectedError:  nil,
+		},
+		{
+			name: "[missing] Change password to the same password",
+			request: &request.ChangePasswordRequest{
+				CurrentPassword: "12345678910",
+				NewPassword:     "12345678910",
+			},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'nefield' for 'NewPassword' field"),
+		},
+		{
+			name: "Change password with a wrong current password",
+			request: &request.ChangePasswordRequest{
+				CurrentPassword: "12345678910",
+				NewPassword:     "abcdefghijk",
+			},
+			expectedStatus: response.ErrorInvalidPassword,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New(response.ErrorInvalidPassword),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var svc service.AccountServiceMock
+			expectedRequest := *tc.request
+			expectedRequest.Username = "wdyarfn"
+			svc.On("ChangePassword", ctx, &expectedRequest).Return(tc.expectedError)
+			app := newAccountTestApp(&svc)
+
+			byteRequest, err := json.Marshal(tc.request)
+			assert.Nil(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/me/password", bytes.NewReader(byteRequest))
+			req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			if strings.Contains(tc.name, "[missing]") {
+				var responseBody response.ErrorValidationResponse
+				err = json.NewDecoder(res.Body).Decode(&responseBody)
+				assert.Nil(t, err)
+
+				assert.Equal(t, responseBody.Code, tc.expectedCode)
+				assert.Equal(t, responseBody.Status, tc.expectedStatus)
+				assert.Equal(t, responseBody.Error[0].Value, tc.expectedError.Error())
+				return
+			}
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
+
+func TestAccountController_CreateInvitation(t *testing.T) {
+	testCases := []struct {
+		name           string
+		request        *request.CreateInvitationRequest
+		expectedStatus string
+		expectedBody   *response.InvitationResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name: "Create invitation with required fields",
+			request: &request.CreateInvitationRequest{
+				Email: "clerk@example.com",
+				Role:  "staff",
+			},
+			expectedStatus: "created",
+			expectedBody: &response.InvitationResponse{
+				ID:        1,
+				Email:     "clerk@example.com",
+				Role:      "staff",
+				InvitedBy: "wdyarfn",
+				Token:     "token",
+			},
+			expectedCode:  http.StatusCreated,
+			expectedError: nil,
+		},
+		{
+			name: "[missing] Create invitation with unknown role",
+			request: &request.CreateInvitationRequest{
+				Email: "clerk@example.com",
+				Role:  "owner",
+			},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'oneof' for 'Role' field"),
+		},
+		{
+			name: "Service getting an error",
+			request: &request.CreateInvitationRequest{
+				Email: "clerk@example.com",
+				Role:  "staff",
+			},
+			expectedStatus: "getting an error",
+			expectedCode:   http.StatusInternalServerError,
+			expectedError:  errors.New("getting an error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var svc service.AccountServiceMock
+			expectedRequest := *tc.request
+			expectedRequest.InvitedBy = "wdyarfn"
+			svc.On("CreateInvitation", ctx, &expectedRequest).Return(tc.expectedBody, tc.expectedError)
+			app := newAccountTestApp(&svc)
+
+			byteRequest, err := json.Marshal(tc.request)
+			assert.Nil(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/invitations", bytes.NewReader(byteRequest))
+			req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			if strings.Contains(tc.name, "[missing]") {
+				var responseBody response.ErrorValidationResponse
+				err = json.NewDecoder(res.Body).Decode(&responseBody)
+				assert.Nil(t, err)
+
+				assert.Equal(t, responseBody.Code, tc.expectedCode)
+				assert.Equal(t, responseBody.Status, tc.expectedStatus)
+				assert.Equal(t, responseBody.Error[0].Value, tc.expectedError.Error())
+				return
+			}
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/service"
+	"inventory-management/backend/util"
+)
+
+type AnalyticsController struct {
+	AnalyticsService service.AnalyticsServiceContract
+}
+
+func NewAnalyticsController(analyticsService service.AnalyticsServiceContract, route fiber.Router) AnalyticsController {
+	controller := AnalyticsController{
+		AnalyticsService: analyticsService,
+	}
+
+	analytics := route.Group("/analytics")
+	{
+		analytics.Get("/volume", controller.Volume)
+		analytics.Get("/top-customers", controller.TopCustomers)
+		analytics.Get("/top-suppliers", controller.TopSuppliers)
+		analytics.Get("/stock-by-type", controller.StockByType)
+		analytics.Get("/transfers", controller.Transfers)
+	}
+
+	return controller
+}
+
+func (controller *AnalyticsController) Volume(ctx *fiber.Ctx) error {
+	var analyticsRequest request.AnalyticsRequest
+	if err := ctx.QueryParser(&analyticsRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(analyticsRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	volumes, err := controller.AnalyticsService.Volume(ctx.UserContext(), &analyticsRequest)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", volumes).Build()
+}
+
+func (controller *AnalyticsController) TopCustomers(ctx *fiber.Ctx) error {
+	var analyticsRequest request.AnalyticsRequest
+	if err := ctx.QueryParser(&analyticsRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(analyticsRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	customers, err := controller.AnalyticsService.TopCustomers(ctx.UserContext(), &analyticsRequest)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", customers).Build()
+}
+
+func (controller *AnalyticsController) TopSuppliers(ctx *fiber.Ctx) error {
+	var analyticsRequest request.AnalyticsRequest
+	if err := ctx.QueryParser(&analyticsRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(analyticsRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	suppliers, err := controller.AnalyticsService.TopSuppliers(ctx.UserContext(), &analyticsRequest)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", suppliers).Build()
+}
+
+func (controller *AnalyticsController) StockByType(ctx *fiber.Ctx) error {
+	var analyticsRequest request.AnalyticsRequest
+	if err := ctx.QueryParser(&analyticsRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(analyticsRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	stocks, err := controller.AnalyticsService.StockByType(ctx.UserContext(), &analyticsRequest)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", stocks).Build()
+}
+
+func (controller *AnalyticsController) Transfers(ctx *fiber.Ctx) error {
+	var analyticsRequest request.AnalyticsRequest
+	if err := ctx.QueryParser(&analyticsRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(analyticsRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	transfers, err := controller.AnalyticsService.Transfers(ctx.UserContext(), &analyticsRequest)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", transfers).Build()
+}
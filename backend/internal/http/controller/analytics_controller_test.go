@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/http/middleware"
+	"inventory-management/backend/internal/http/request"
+	response "inventory-management/backend/internal/http/response"
+	service "inventory-management/backend/internal/service/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAnalyticsController_Volume(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		analytics      *request.AnalyticsRequest
+		expectedStatus string
+		expectedBody   []*response.VolumeResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Volume per week",
+			query:          "from=2021-01-01&to=2021-01-31&interval=week",
+			analytics:      &request.AnalyticsRequest{From: "2021-01-01", To: "2021-01-31", Interval: "week"},
+			expectedStatus: "OK",
+			expectedBody: []*response.VolumeResponse{
+				{Date: "2021-01-04", In: 10, Out: 3, UnitMassAcronym: "kg"},
+			},
+			expectedCode:  http.StatusOK,
+			expectedError: nil,
+		},
+		{
+			name:           "[missing] Volume per unknown interval",
+			query:          "interval=hour",
+			analytics:      &request.AnalyticsRequest{Interval: "hour"},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'oneof' for 'Interval' field"),
+		},
+		{
+			name:           "[missing] Volume from an invalid date",
+			query:          "from=01-01-2021",
+			analytics:      &request.AnalyticsRequest{From: "01-01-2021"},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'datetime' for 'From' field"),
+		},
+		{
+			name:           "Service getting an error",
+			query:          "unit_mass_acronym=g",
+			analytics:      &request.AnalyticsRequest{UnitMassAcronym: "g"},
+			expectedStatus: "getting an error",
+			expectedCode:   http.StatusInternalServerError,
+			expectedError:  errors.New("getting an error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.AnalyticsServiceMock
+			svc.On("Volume", ctx, tc.analytics).Return(tc.expectedBody, tc.expectedError)
+
+			route := app.Group("/api")
+			NewAnalyticsController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/analytics/volume?"+tc.query, nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			if strings.Contains(tc.name, "[missing]") {
+				var responseBody response.ErrorValidationResponse
+				err = json.NewDecoder(res.Body).Decode(&responseBody)
+				assert.Nil(t, err)
+
+				assert.Equal(t, responseBody.Code, tc.expectedCode)
+				assert.Equal(t, responseBody.Status, tc.expectedStatus)
+				assert.Equal(t, responseBody.Error[0].Value, tc.expectedError.Error())
+				return
+			}
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
+
+func TestAnalyticsController_TopCustomers(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		analytics      *request.AnalyticsRequest
+		expectedStatus string
+		expectedBody   []*response.PartnerVolumeResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Top customers",
+			query:          "limit=5",
+			analytics:      &request.AnalyticsRequest{Limit: 5},
+			expectedStatus: "OK",
+			expectedBody: []*response.PartnerVolumeResponse{
+				{Code: "CUSTOMER01", Name: "Widdy", Quantity: 5, UnitMassAcronym: "kg", Transactions: 2},
+			},
+			expectedCode:  http.StatusOK,
+			expectedError: nil,
+		},
+		{
+			name:           "[missing] Too many top customers",
+			query:          "limit=1000",
+			analytics:      &request.AnalyticsRequest{Limit: 1000},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'max' for 'Limit' field"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.AnalyticsServiceMock
+			svc.On("TopCustomers", ctx, tc.analytics).Return(tc.expectedBody, tc.expectedError)
+
+			route := app.Group("/api")
+			NewAnalyticsController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/analytics/top-customers?"+tc.query, nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			if strings.Contains(tc.name, "[missing]") {
+				var responseBody response.ErrorValidationResponse
+				err = json.NewDecoder(res.Body).Decode(&responseBody)
+				assert.Nil(t, err)
+
+				assert.Equal(t, responseBody.Code, tc.expectedCode)
+				assert.Equal(t, responseBody.Status, tc.expectedStatus)
+				assert.Equal(t, responseBody.Error[0].Value, tc.expectedError.Error())
+				return
+			}
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/service"
+	"inventory-management/backend/util"
+)
+
+type AuditController struct {
+	AuditService service.AuditServiceContract
+}
+
+func NewAuditController(auditService service.AuditServiceContract, route fiber.Router) AuditController {
+	controller := AuditController{
+		AuditService: auditService,
+	}
+
+	audit := route.Group("/audit")
+	{
+		audit.Get("/", controller.FindAll)
+	}
+
+	return controller
+}
+
+func (controller *AuditController) FindAll(ctx *fiber.Ctx) error {
+	var filterRequest request.AuditLogFilterRequest
+	if err := ctx.QueryParser(&filterRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(filterRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	currPage := ctx.QueryInt("page", 1)
+	if currPage <= 0 {
+		currPage = 1
+	}
+	limit, errValidate := listLimit(ctx)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	totalRecords, err := controller.AuditService.CountAll(ctx.UserContext(), &filterRequest)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	pagination := util.CreatePagination(currPage, limit, totalRecords)
+	offset := (currPage - 1) * limit
+	auditLogs, err := controller.AuditService.FindAll(ctx.UserContext(), &filterRequest, offset, limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", auditLogs).WithPagination(&pagination).Build()
+}
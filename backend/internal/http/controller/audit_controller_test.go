@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/http/middleware"
+	"inventory-management/backend/internal/http/request"
+	response "inventory-management/backend/internal/http/response"
+	service "inventory-management/backend/internal/service/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAuditController_FindAll(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		filter         *request.AuditLogFilterRequest
+		expectedStatus string
+		expectedBody   []*response.AuditLogResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Audit logs filtered by entity and actor",
+			query:          "entity=product&actor=wdyarfn",
+			filter:         &request.AuditLogFilterRequest{Entity: "product", Actor: "wdyarfn"},
+			expectedStatus: "OK",
+			expectedBody: []*response.AuditLogResponse{
+				{
+					ID:        1,
+					Actor:     "wdyarfn",
+					Entity:    "product",
+					EntityID:  "KKSJIDNA",
+					Action:    "update",
+					Changes:   json.RawMessage(`{"name":{"before":"Shrimp","after":"Shark"}}`),
+					CreatedAt: "2021-01-01 07:00:00",
+				},
+			},
+			expectedCode:  http.StatusOK,
+			expectedError: nil,
+		},
+		{
+			name:           "[missing] Audit logs filtered by unknown action",
+			query:          "action=rename",
+			filter:         &request.AuditLogFilterRequest{Action: "rename"},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'oneof' for 'Action' field"),
+		},
+		{
+			name:           "Service getting an error",
+			query:          "from=2021-01-01&to=2021-01-31",
+			filter:         &request.AuditLogFilterRequest{From: "2021-01-01", To: "2021-01-31"},
+			expectedStatus: "getting an error",
+			expectedCode:   http.StatusInternalServerError,
+			expectedError:  errors.New("getting an error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.AuditServiceMock
+			svc.On("CountAll", ctx, tc.filter).Return(int64(1), nil)
+			svc.On("FindAll", ctx, tc.filter, 0, 10).Return(tc.expectedBody, tc.expectedError)
+
+			route := app.Group("/api")
+			NewAuditController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/audit?"+tc.query, nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			if strings.Contains(tc.name, "[missing]") {
+				var responseBody response.ErrorValidationResponse
+				err = json.NewDecoder(res.Body).Decode(&responseBody)
+				assert.Nil(t, err)
+
+				assert.Equal(t, responseBody.Code, tc.expectedCode)
+				assert.Equal(t, responseBody.Status, tc.expectedStatus)
+				assert.Equal(t, responseBody.Error[0].Value, tc.expectedError.Error())
+				return
+			}
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
@@ -10,16 +10,20 @@ import (
 )
 
 type AuthController struct {
-	UserService service.UserServiceContract
+	UserService    service.UserServiceContract
+	AccountService service.AccountServiceContract
 }
 
-func NewAuthController(userService service.UserServiceContract, route fiber.Router) AuthController {
+func NewAuthController(userService service.UserServiceContract, accountService service.AccountServiceContract, route fiber.Router) AuthController {
 	controller := AuthController{
-		UserService: userService,
+		UserService:    userService,
+		AccountService: accountService,
 	}
 
 	route.Post("/login", controller.Login)
 	route.Post("/register", controller.Register)
+	route.Post("/password/forgot", controller.ForgotPassword)
+	route.Post("/password/reset", controller.ResetPassword)
 
 	return controller
 }
@@ -49,19 +53,61 @@ func (controller *AuthController) Login(ctx *fiber.Ctx) error {
 }
 
 func (controller *AuthController) Register(ctx *fiber.Ctx) error {
-	var userRequest request.CreateUserRequest
-	if err := ctx.BodyParser(&userRequest); err != nil {
+	var registerRequest request.RegisterUserRequest
+	if err := ctx.BodyParser(&registerRequest); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	if errValidate := util.ValidateStruct(userRequest); errValidate != nil {
+	if errValidate := util.ValidateStruct(registerRequest); errValidate != nil {
 		return response.ReturnErrorValidation(ctx, errValidate)
 	}
 
-	user, err := controller.UserService.Create(ctx.UserContext(), &userRequest)
+	user, err := controller.AccountService.Register(ctx.UserContext(), &registerRequest)
 	if err != nil {
+		if err.Error() == response.ErrorInvalidToken || err.Error() == response.ErrorUsernameExists {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
 	return response.ReturnJSON(ctx, fiber.StatusCreated, "created", user).Build()
 }
+
+func (controller *AuthController) ForgotPassword(ctx *fiber.Ctx) error {
+	var forgotPasswordRequest request.ForgotPasswordRequest
+	if err := ctx.BodyParser(&forgotPasswordRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(forgotPasswordRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	err := controller.AccountService.ForgotPassword(ctx.UserContext(), &forgotPasswordRequest)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusAccepted, "if the account exists, a reset link has been sent", nil).Build()
+}
+
+func (controller *AuthController) ResetPassword(ctx *fiber.Ctx) error {
+	var resetPasswordRequest request.ResetPasswordRequest
+	if err := ctx.BodyParser(&resetPasswordRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(resetPasswordRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	err := controller.AccountService.ResetPassword(ctx.UserContext(), &resetPasswordRequest)
+	if err != nil {
+		if err.Error() == response.ErrorInvalidToken {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "password has been reset", nil).Build()
+}
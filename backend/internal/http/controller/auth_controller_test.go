@@ -90,10 +90,11 @@ func TestAuthController_Login(t *testing.T) {
 			ctx := context.Background()
 
 			var svc service.UserServiceMock
+			var accountSvc service.AccountServiceMock
 			svc.On("VerifyLogin", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewAuthController(&svc, route)
+			ctrl := NewAuthController(&svc, &accountSvc, route)
 			app.Post("/api/login", ctrl.Login)
 
 			byteRequest, err := json.Marshal(tc.request)
@@ -131,7 +132,7 @@ func TestAuthController_Login(t *testing.T) {
 func TestAuthController_Register(t *testing.T) {
 	testCases := []struct {
 		name           string
-		request        *request.CreateUserRequest
+		request        *request.RegisterUserRequest
 		expectedStatus string
 		expectedBody   *response.UserResponse
 		expectedCode   int
@@ -139,10 +140,11 @@ func TestAuthController_Register(t *testing.T) {
 	}{
 		{
 			name: "Create user with required fields",
-			request: &request.CreateUserRequest{
-				Name:     "Widdy Arfiansyah",
-				Username: "wdyarfn",
-				Password: "12345678910",
+			request: &request.RegisterUserRequest{
+				InvitationToken: "invitation-token",
+				Name:            "Widdy Arfiansyah",
+				Username:        "wdyarfn",
+				Password:        "12345678910",
 			},
 			expectedStatus: "created",
 			expectedBody: &response.UserResponse{
@@ -157,9 +159,10 @@ func TestAuthController_Register(t *testing.T) {
 		},
 		{
 			name: "Create user with missing name field",
-			request: &request.CreateUserRequest{
-				Username: "wdyarfn",
-				Password: "12345678910",
+			request: &request.RegisterUserRequest{
+				InvitationToken: "invitation-token",
+				Username:        "wdyarfn",
+				Password:        "12345678910",
 			},
 			expectedStatus: response.ErrorValidation,
 			expectedBody:   nil,
@@ -168,9 +171,10 @@ func TestAuthController_Register(t *testing.T) {
 		},
 		{
 			name: "Create user with missing username field",
-			request: &request.CreateUserRequest{
-				Name:     "Widdy Arfiansyah",
-				Password: "12345678910",
+			request: &request.RegisterUserRequest{
+				InvitationToken: "invitation-token",
+				Name:            "Widdy Arfiansyah",
+				Password:        "12345678910",
 			},
 			expectedStatus: response.ErrorValidation,
 			expectedBody:   nil,
@@ -179,9 +183,10 @@ func TestAuthController_Register(t *testing.T) {
 		},
 		{
 			name: "Create user with missing password field",
-			request: &request.CreateUserRequest{
-				Name:     "Widdy Arfiansyah",
-				Username: "wdyarfn",
+			request: &request.RegisterUserRequest{
+				InvitationToken: "invitation-token",
+				Name:            "Widdy Arfiansyah",
+				Username:        "wdyarfn",
 			},
 			expectedStatus: response.ErrorValidation,
 			expectedBody:   nil,
@@ -189,12 +194,38 @@ func TestAuthController_Register(t *testing.T) {
 			expectedError:  errors.New("Error validation 'required' for 'Password' field"),
 		},
 		{
-			name: "Service getting an error",
-			request: &request.CreateUserRequest{
+			name: "Create user with missing invitation token field",
+			request: &request.RegisterUserRequest{
 				Name:     "Widdy Arfiansyah",
 				Username: "wdyarfn",
 				Password: "12345678910",
 			},
+			expectedStatus: response.ErrorValidation,
+			expectedBody:   nil,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'required' for 'InvitationToken' field"),
+		},
+		{
+			name: "Create user with an invalid invitation token",
+			request: &request.RegisterUserRequest{
+				InvitationToken: "invitation-token",
+				Name:            "Widdy Arfiansyah",
+				Username:        "wdyarfn",
+				Password:        "12345678910",
+			},
+			expectedStatus: response.ErrorInvalidToken,
+			expectedBody:   nil,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New(response.ErrorInvalidToken),
+		},
+		{
+			name: "Service getting an error",
+			request: &request.RegisterUserRequest{
+				InvitationToken: "invitation-token",
+				Name:            "Widdy Arfiansyah",
+				Username:        "wdyarfn",
+				Password:        "12345678910",
+			},
 			expectedStatus: "getting an error",
 			expectedBody:   nil,
 			expectedCode:   http.StatusInternalServerError,
@@ -208,11 +239,12 @@ func TestAuthController_Register(t *testing.T) {
 
 			ctx := context.Background()
 
-			var svc service.UserServiceMock
-			svc.On("Create", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
+			var userSvc service.UserServiceMock
+			var svc service.AccountServiceMock
+			svc.On("Register", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewAuthController(&svc, route)
+			ctrl := NewAuthController(&userSvc, &svc, route)
 			app.Post("/api/register", ctrl.Register)
 
 			byteRequest, err := json.Marshal(tc.request)
@@ -246,3 +278,158 @@ func TestAuthController_Register(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthController_ForgotPassword(t *testing.T) {
+	testCases := []struct {
+		name           string
+		request        *request.ForgotPasswordRequest
+		expectedStatus string
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Request a password reset",
+			request:        &request.ForgotPasswordRequest{Username: "wdyarfn"},
+			expectedStatus: "if the account exists, a reset link has been sent",
+			expectedCode:   http.StatusAccepted,
+			expectedError:  nil,
+		},
+		{
+			name:           "[missing] Request a password reset with missing username field",
+			request:        &request.ForgotPasswordRequest{},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'required' for 'Username' field"),
+		},
+		{
+			name:           "Service getting an error",
+			request:        &request.ForgotPasswordRequest{Username: "wdyarfn"},
+			expectedStatus: "getting an error",
+			expectedCode:   http.StatusInternalServerError,
+			expectedError:  errors.New("getting an error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var userSvc service.UserServiceMock
+			var svc service.AccountServiceMock
+			svc.On("ForgotPassword", ctx, tc.request).Return(tc.expectedError)
+
+			route := app.Group("/api")
+			NewAuthController(&userSvc, &svc, route)
+
+			byteRequest, err := json.Marshal(tc.request)
+			assert.Nil(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/password/forgot", bytes.NewReader(byteRequest))
+			req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			if strings.Contains(tc.name, "[missing]") {
+				var responseBody response.ErrorValidationResponse
+				err = json.NewDecoder(res.Body).Decode(&responseBody)
+				assert.Nil(t, err)
+
+				assert.Equal(t, responseBody.Code, tc.expectedCode)
+				assert.Equal(t, responseBody.Status, tc.expectedStatus)
+				assert.Equal(t, responseBody.Error[0].Value, tc.expectedError.Error())
+				return
+			}
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
+
+func TestAuthController_ResetPassword(t *testing.T) {
+	testCases := []struct {
+		name           string
+		request        *request.ResetPasswordRequest
+		expectedStatus string
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Reset password with a valid token",
+			request:        &request.ResetPasswordRequest{Token: "token", Password: "12345678910"},
+			expectedStatus: "password has been reset",
+			expectedCode:   http.StatusOK,
+			expectedError:  nil,
+		},
+		{
+			name:           "[missing] Reset password with a short password",
+			request:        &request.ResetPasswordRequest{Token: "token", Password: "1234"},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'min' for 'Password' field"),
+		},
+		{
+			name:           "Reset password with an invalid token",
+			request:        &request.ResetPasswordRequest{Token: "token", Password: "12345678910"},
+			expectedStatus: response.ErrorInvalidToken,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New(response.ErrorInvalidToken),
+		},
+		{
+			name:           "Service getting an error",
+			request:        &request.ResetPasswordRequest{Token: "token", Password: "12345678910"},
+			expectedStatus: "getting an error",
+			expectedCode:   http.StatusInternalServerError,
+			expectedError:  errors.New("getting an error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var userSvc service.UserServiceMock
+			var svc service.AccountServiceMock
+			svc.On("ResetPassword", ctx, tc.request).Return(tc.expectedError)
+
+			route := app.Group("/api")
+			NewAuthController(&userSvc, &svc, route)
+
+			byteRequest, err := json.Marshal(tc.request)
+			assert.Nil(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/password/reset", bytes.NewReader(byteRequest))
+			req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			if strings.Contains(tc.name, "[missing]") {
+				var responseBody response.ErrorValidationResponse
+				err = json.NewDecoder(res.Body).Decode(&responseBody)
+				assert.Nil(t, err)
+
+				assert.Equal(t, responseBody.Code, tc.expectedCode)
+				assert.Equal(t, responseBody.Status, tc.expectedStatus)
+				assert.Equal(t, responseBody.Error[0].Value, tc.expectedError.Error())
+				return
+			}
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
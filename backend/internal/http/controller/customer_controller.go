@@ -4,6 +4,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"inventory-management/backend/internal/http/request"
 	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
 	"inventory-management/backend/internal/service"
 	"inventory-management/backend/util"
 	"net/http"
@@ -11,11 +12,13 @@ import (
 
 type CustomerController struct {
 	CustomerService service.CustomerServiceContract
+	ExportService   service.ExportServiceContract
 }
 
-func NewCustomerController(customerService service.CustomerServiceContract, route fiber.Router) CustomerController {
+func NewCustomerController(customerService service.CustomerServiceContract, exportService service.ExportServiceContract, route fiber.Router) CustomerController {
 	controller := CustomerController{
 		CustomerService: customerService,
+		ExportService:   exportService,
 	}
 
 	customer := route.Group("/customers")
@@ -25,31 +28,62 @@ func NewCustomerController(customerService service.CustomerServiceContract, rout
 		customer.Post("/", controller.Create)
 		customer.Patch("/:code", controller.Update)
 		customer.Delete("/:code", controller.Delete)
+		customer.Post("/:code/restore", controller.Restore)
+		customer.Post("/:code/purge", controller.Purge)
 	}
 
 	return controller
 }
 
 func (controller *CustomerController) FindAll(ctx *fiber.Ctx) error {
+	listQuery, errValidate := parseListQuery(ctx, model.CustomerListSchema)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	if ExportRequested(ctx) {
+		return exportList(ctx, controller.ExportService, model.ExportEntityCustomers, queryValues(ctx), listQuery)
+	}
+
 	currPage := ctx.QueryInt("page", 1)
 	if currPage <= 0 {
 		currPage = 1
 	}
-	limit := ctx.QueryInt("limit", 10)
+	limit, errValidate := listLimit(ctx)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
 
-	totalRecords, err := controller.CustomerService.CountAll(ctx.UserContext())
+	if cursorPaged(ctx) {
+		customers, page, err := controller.CustomerService.FindAllByCursor(ctx.UserContext(), listQuery, ctx.Query("cursor"), limit)
+		if err != nil {
+			if err.Error() == response.ErrorInvalidCursor {
+				return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+
+		cursor, err := cursorPagination(ctx, listQuery, page, controller.CustomerService.CountAll)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+
+		return response.ReturnJSON(ctx, http.StatusOK, "OK", customers).WithCursor(cursor).WithFields(listQuery.Fields).Build()
+	}
+
+	totalRecords, err := controller.CustomerService.CountAll(ctx.UserContext(), listQuery)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
 	pagination := util.CreatePagination(currPage, limit, totalRecords)
 	offset := (currPage - 1) * limit
-	customers, err := controller.CustomerService.FindAll(ctx.UserContext(), offset, limit)
+	customers, err := controller.CustomerService.FindAll(ctx.UserContext(), listQuery, offset, limit)
 	if err != nil {
 		return fiber.NewError(http.StatusInternalServerError, err.Error())
 	}
 
-	return response.ReturnJSON(ctx, http.StatusOK, "OK", customers).WithPagination(&pagination).Build()
+	return response.ReturnJSON(ctx, http.StatusOK, "OK", customers).WithPagination(&pagination).WithFields(listQuery.Fields).Build()
 }
 
 func (controller *CustomerController) FindByCode(ctx *fiber.Ctx) error {
@@ -62,7 +96,7 @@ func (controller *CustomerController) FindByCode(ctx *fiber.Ctx) error {
 		return fiber.NewError(http.StatusInternalServerError, err.Error())
 	}
 
-	return response.ReturnJSON(ctx, http.StatusOK, "OK", customer).Build()
+	return response.ReturnJSON(ctx, http.StatusOK, "OK", customer).WithETag(util.VersionETag(customer.Version)).Build()
 }
 
 func (controller *CustomerController) Create(ctx *fiber.Ctx) error {
@@ -100,10 +134,13 @@ func (controller *CustomerController) Update(ctx *fiber.Ctx) error {
 		if err.Error() == response.ErrorNotFound {
 			return fiber.NewError(http.StatusNotFound, err.Error())
 		}
+		if err.Error() == response.ErrorVersionMismatch {
+			return preconditionFailed(ctx, controller.current(ctx, code))
+		}
 		return fiber.NewError(http.StatusInternalServerError, err.Error())
 	}
 
-	return response.ReturnJSON(ctx, http.StatusOK, "updated", customer).Build()
+	return response.ReturnJSON(ctx, http.StatusOK, "updated", customer).WithETag(util.VersionETag(customer.Version)).Build()
 }
 
 func (controller *CustomerController) Delete(ctx *fiber.Ctx) error {
@@ -113,8 +150,50 @@ func (controller *CustomerController) Delete(ctx *fiber.Ctx) error {
 		if err.Error() == response.ErrorNotFound {
 			return fiber.NewError(http.StatusNotFound, err.Error())
 		}
+		if err.Error() == response.ErrorVersionMismatch {
+			return preconditionFailed(ctx, controller.current(ctx, code))
+		}
 		return fiber.NewError(http.StatusInternalServerError, err.Error())
 	}
 
 	return response.ReturnJSON(ctx, http.StatusOK, "deleted", nil).Build()
 }
+
+func (controller *CustomerController) Restore(ctx *fiber.Ctx) error {
+	customer, err := controller.CustomerService.Restore(ctx.UserContext(), ctx.Params("code"))
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, http.StatusOK, "restored", customer).WithETag(util.VersionETag(customer.Version)).Build()
+}
+
+func (controller *CustomerController) Purge(ctx *fiber.Ctx) error {
+	err := controller.CustomerService.Purge(ctx.UserContext(), ctx.Params("code"))
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		if err.Error() == response.ErrorRecordReferenced {
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, http.StatusOK, "purged", nil).Build()
+}
+
+// current finds the customer as it is now.
+func (controller *CustomerController) current(ctx *fiber.Ctx, code string) func() (interface{}, int64, error) {
+	return func() (interface{}, int64, error) {
+		customer, err := controller.CustomerService.FindByCode(ctx.UserContext(), code)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return customer, customer.Version, nil
+	}
+}
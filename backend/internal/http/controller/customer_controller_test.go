@@ -12,6 +12,7 @@ import (
 	"inventory-management/backend/internal/http/request"
 	response "inventory-management/backend/internal/http/response"
 	service "inventory-management/backend/internal/service/mock"
+	"inventory-management/backend/util"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -71,11 +72,11 @@ func TestCustomerController_FindAll(t *testing.T) {
 			ctx := context.Background()
 
 			var svc service.CustomerServiceMock
-			svc.On("CountAll", ctx).Return(int64(2), nil)
-			svc.On("FindAll", ctx, 0, 10).Return(tc.expectedBody, tc.expectedError)
+			svc.On("CountAll", ctx, &util.ListQuery{}).Return(int64(2), nil)
+			svc.On("FindAll", ctx, &util.ListQuery{}, 0, 10).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewCustomerController(&svc, route)
+			ctrl := NewCustomerController(&svc, nil, route)
 			app.Get("/api/customers", ctrl.FindAll)
 
 			req := httptest.NewRequest(http.MethodGet, "/api/customers", nil)
@@ -145,7 +146,7 @@ func TestCustomerController_FindByCode(t *testing.T) {
 			svc.On("FindByCode", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewCustomerController(&svc, route)
+			ctrl := NewCustomerController(&svc, nil, route)
 			app.Get("/api/customers/:code", ctrl.FindByCode)
 
 			url := fmt.Sprintf("/api/customers/%s", tc.request)
@@ -240,7 +241,7 @@ func TestCustomerController_Create(t *testing.T) {
 			svc.On("Create", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewCustomerController(&svc, route)
+			ctrl := NewCustomerController(&svc, nil, route)
 			app.Post("/api/customers", ctrl.Create)
 
 			byteRequest, err := json.Marshal(tc.request)
@@ -356,7 +357,7 @@ func TestCustomerController_Update(t *testing.T) {
 			svc.On("Update", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewCustomerController(&svc, route)
+			ctrl := NewCustomerController(&svc, nil, route)
 			app.Patch("/api/customers/:code", ctrl.Update)
 
 			byteRequest, err := json.Marshal(tc.request)
@@ -433,7 +434,7 @@ func TestCustomerController_Delete(t *testing.T) {
 			svc.On("Delete", ctx, tc.request).Return(tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewCustomerController(&svc, route)
+			ctrl := NewCustomerController(&svc, nil, route)
 			app.Delete("/api/customers/:code", ctrl.Delete)
 
 			url := fmt.Sprintf("/api/customers/%s", tc.request)
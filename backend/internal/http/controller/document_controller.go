@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/service"
+	"time"
+)
+
+type DocumentController struct {
+	DocumentService service.DocumentServiceContract
+}
+
+func NewDocumentController(documentService service.DocumentServiceContract, route fiber.Router) DocumentController {
+	controller := DocumentController{
+		DocumentService: documentService,
+	}
+
+	document := route.Group("/documents")
+	{
+		document.Get("/delivery-notes/:code", controller.DeliveryNote)
+		document.Get("/goods-receipts/:code", controller.GoodsReceipt)
+		document.Get("/transfer-slips/:code", controller.TransferSlip)
+		document.Get("/stock-report", controller.StockReport)
+	}
+
+	return controller
+}
+
+func (controller *DocumentController) DeliveryNote(ctx *fiber.Ctx) error {
+	return transactionDocument(ctx, "delivery-note", controller.DocumentService.DeliveryNote)
+}
+
+func (controller *DocumentController) GoodsReceipt(ctx *fiber.Ctx) error {
+	return transactionDocument(ctx, "goods-receipt", controller.DocumentService.GoodsReceipt)
+}
+
+func (controller *DocumentController) TransferSlip(ctx *fiber.Ctx) error {
+	return transactionDocument(ctx, "transfer-slip", controller.DocumentService.TransferSlip)
+}
+
+func (controller *DocumentController) StockReport(ctx *fiber.Ctx) error {
+	document, err := controller.DocumentService.StockReport(ctx.UserContext())
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return sendPDF(ctx, "stock-report-"+time.Now().Format("2006-01-02"), document)
+}
+
+// transactionDocument renders the document of the transaction with the code of the path.
+func transactionDocument(ctx *fiber.Ctx, name string, render func(context.Context, string) ([]byte, error)) error {
+	code := ctx.Params("code")
+	document, err := render(ctx.UserContext(), code)
+	if err != nil {
+		switch err.Error() {
+		case response.ErrorNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case response.ErrorDocumentTransactionType:
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return sendPDF(ctx, name+"-"+code, document)
+}
+
+// sendPDF sends the document inline, so browsers show it and can still save it under its name.
+func sendPDF(ctx *fiber.Ctx, name string, document []byte) error {
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="%s.pdf"`, name))
+
+	return ctx.Status(fiber.StatusOK).Send(document)
+}
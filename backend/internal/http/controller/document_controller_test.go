@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/http/middleware"
+	response "inventory-management/backend/internal/http/response"
+	service "inventory-management/backend/internal/service/mock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDocumentController_DeliveryNote(t *testing.T) {
+	testCases := []struct {
+		name                string
+		document            []byte
+		expectedCode        int
+		expectedContentType string
+		expectedError       error
+	}{
+		{
+			name:                "Delivery note rendered",
+			document:            []byte("%PDF-1.3"),
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/pdf",
+		},
+		{
+			name:                "[invalid] Transaction that is not OUT",
+			expectedCode:        http.StatusBadRequest,
+			expectedContentType: fiber.MIMEApplicationJSON,
+			expectedError:       errors.New(response.ErrorDocumentTransactionType),
+		},
+		{
+			name:                "[invalid] Transaction not found",
+			expectedCode:        http.StatusNotFound,
+			expectedContentType: fiber.MIMEApplicationJSON,
+			expectedError:       errors.New(response.ErrorNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.DocumentServiceMock
+			svc.On("DeliveryNote", ctx, "TRXOUT1234").Return(tc.document, tc.expectedError)
+
+			route := app.Group("/api")
+			NewDocumentController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/documents/delivery-notes/TRXOUT1234", nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.expectedCode, res.StatusCode)
+			assert.Contains(t, res.Header.Get(fiber.HeaderContentType), tc.expectedContentType)
+			if tc.expectedError == nil {
+				body, err := io.ReadAll(res.Body)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.document, body)
+				assert.Equal(t, `inline; filename="delivery-note-TRXOUT1234.pdf"`, res.Header.Get(fiber.HeaderContentDisposition))
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/service"
+	"inventory-management/backend/util"
+	"log"
+	"net/url"
+)
+
+type ExportController struct {
+	ExportService service.ExportServiceContract
+}
+
+func NewExportController(exportService service.ExportServiceContract, route fiber.Router) ExportController {
+	controller := ExportController{
+		ExportService: exportService,
+	}
+
+	export := route.Group("/exports")
+	{
+		export.Get("/", controller.FindAll)
+		export.Get("/:code", controller.FindByCode)
+		export.Get("/:code/download", controller.Download)
+	}
+
+	return controller
+}
+
+func (controller *ExportController) FindAll(ctx *fiber.Ctx) error {
+	currPage := ctx.QueryInt("page", 1)
+	if currPage <= 0 {
+		currPage = 1
+	}
+	limit, errValidate := listLimit(ctx)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	totalRecords, err := controller.ExportService.CountAllJobs(ctx.UserContext())
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	pagination := util.CreatePagination(currPage, limit, totalRecords)
+	offset := (currPage - 1) * limit
+	jobs, err := controller.ExportService.FindAllJobs(ctx.UserContext(), offset, limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", jobs).WithPagination(&pagination).Build()
+}
+
+func (controller *ExportController) FindByCode(ctx *fiber.Ctx) error {
+	job, err := controller.ExportService.FindJobByCode(ctx.UserContext(), ctx.Params("code"))
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", job).Build()
+}
+
+func (controller *ExportController) Download(ctx *fiber.Ctx) error {
+	job, filePath, err := controller.ExportService.JobFile(ctx.UserContext(), ctx.Params("code"))
+	if err != nil {
+		switch err.Error() {
+		case response.ErrorNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case response.ErrorExportNotReady:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	err = ctx.Download(filePath, job.Entity+"."+job.Format)
+	if err != nil {
+		return err
+	}
+	ctx.Set(fiber.HeaderContentType, util.ExportContentTypes[job.Format])
+
+	return nil
+}
+
+// ExportRequested reports whether a list request asks for an export instead of a page of JSON,
+// with format= or with the Accept header.
+func ExportRequested(ctx *fiber.Ctx) bool {
+	format := ctx.Query("format")
+	if format != "" {
+		return format != "json"
+	}
+
+	accepted := ctx.Accepts(fiber.MIMEApplicationJSON, util.ExportContentTypes[util.ExportFormatCSV], util.ExportContentTypes[util.ExportFormatXLSX], util.ExportContentTypes[util.ExportFormatNDJSON])
+	return accepted != "" && accepted != fiber.MIMEApplicationJSON
+}
+
+// exportList exports every record of the list query, streamed in the response as it is read.
+// Lists too large to export within the request, or any list with async=true, are queued as an
+// export job instead and answered with 202; values is the query string the job lists with.
+func exportList(ctx *fiber.Ctx, exportService service.ExportServiceContract, entity string, values url.Values, listQuery *util.ListQuery) error {
+	format := ctx.Query("format")
+	if format == "" {
+		accepted := ctx.Accepts(util.ExportContentTypes[util.ExportFormatCSV], util.ExportContentTypes[util.ExportFormatXLSX], util.ExportContentTypes[util.ExportFormatNDJSON])
+		for exportFormat, contentType := range util.ExportContentTypes {
+			if contentType == accepted {
+				format = exportFormat
+			}
+		}
+	}
+	if _, ok := util.ExportContentTypes[format]; !ok {
+		return response.ReturnErrorValidation(ctx, []*response.ErrorResponse{{
+			FailedField: "format",
+			Tag:         "oneof",
+			Value:       "Error validation 'oneof' for 'format' field",
+		}})
+	}
+
+	background := ctx.QueryBool("async")
+	if !background {
+		var err error
+		background, err = exportService.Background(ctx.UserContext(), entity, listQuery)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	if background {
+		job, err := exportService.CreateJob(ctx.UserContext(), entity, format, values)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+
+		return response.ReturnJSON(ctx, fiber.StatusAccepted, "accepted", job).Build()
+	}
+
+	// The body is written after the handler returns, when the request context is gone
+	userCtx := ctx.UserContext()
+	ctx.Set(fiber.HeaderContentType, util.ExportContentTypes[format])
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.%s"`, entity, format))
+	conn := ctx.Context().Conn()
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// The status is sent by now, so a failed export closes the connection before the body
+		// ends, and the client sees the download fail instead of a file cut short
+		if _, err := exportService.Export(userCtx, entity, listQuery, format, w); err != nil {
+			log.Printf("Cannot export %s: %v\n", entity, err)
+			conn.Close()
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/middleware"
+	response "inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	service "inventory-management/backend/internal/service/mock"
+	"inventory-management/backend/util"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSupplierController_FindAllExport(t *testing.T) {
+	listQuery := &util.ListQuery{Filters: []*util.ListFilter{{Field: "name", Operator: util.ListOperatorLike, Values: []interface{}{"widdy"}}}}
+
+	testCases := []struct {
+		name                string
+		query               string
+		accept              string
+		format              string
+		background          bool
+		expectedCode        int
+		expectedContentType string
+	}{
+		{
+			name:                "Export streamed in the format of the query",
+			query:               "&format=csv",
+			format:              util.ExportFormatCSV,
+			expectedCode:        http.StatusOK,
+			expectedContentType: "text/csv",
+		},
+		{
+			name:                "Export streamed in the format of the Accept header",
+			accept:              "application/x-ndjson",
+			format:              util.ExportFormatNDJSON,
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+		},
+		{
+			name:         "Export queued when asked to",
+			query:        "&format=xlsx&async=true",
+			format:       util.ExportFormatXLSX,
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name:         "Export queued for a large list",
+			query:        "&format=xlsx",
+			format:       util.ExportFormatXLSX,
+			background:   true,
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name:         "[invalid] Export in an unknown format",
+			query:        "&format=pdf",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var exportSvc service.ExportServiceMock
+			exportSvc.On("Background", ctx, model.ExportEntitySuppliers, listQuery).Return(tc.background, nil)
+			exportSvc.On("Export", ctx, model.ExportEntitySuppliers, listQuery, tc.format).Return("exported", nil)
+			exportSvc.On("CreateJob", ctx, model.ExportEntitySuppliers, tc.format, mock.Anything).Return(&response.ExportJobResponse{Code: "EXPORT", Status: model.ExportStatusPending}, nil)
+
+			route := app.Group("/api")
+			NewSupplierController(&service.SupplierServiceMock{}, &exportSvc, route)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/suppliers?filter[name][like]=widdy"+tc.query, nil)
+			if tc.accept != "" {
+				req.Header.Set(fiber.HeaderAccept, tc.accept)
+			}
+
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedCode, res.StatusCode)
+
+			body, err := io.ReadAll(res.Body)
+			assert.Nil(t, err)
+			if tc.expectedCode == http.StatusOK {
+				assert.Equal(t, tc.expectedContentType, res.Header.Get(fiber.HeaderContentType))
+				assert.Equal(t, "exported", string(body))
+			}
+			if tc.expectedCode == http.StatusAccepted {
+				var responseBody struct {
+					Data response.ExportJobResponse `json:"data"`
+				}
+				assert.Nil(t, json.Unmarshal(body, &responseBody))
+				assert.Equal(t, "EXPORT", responseBody.Data.Code)
+			}
+		})
+	}
+}
+
+func TestSupplierController_FindAllExportFailure(t *testing.T) {
+	app := fiber.New(middleware.FiberConfig())
+
+	ctx := context.Background()
+
+	var exportSvc service.ExportServiceMock
+	exportSvc.On("Background", ctx, model.ExportEntitySuppliers, mock.Anything).Return(false, nil)
+	exportSvc.On("Export", ctx, model.ExportEntitySuppliers, mock.Anything, util.ExportFormatCSV).Return("code,name\n", errors.New("getting an error"))
+
+	route := app.Group("/api")
+	NewSupplierController(&service.SupplierServiceMock{}, &exportSvc, route)
+
+	// The body is streamed once the handler returns, which only a served connection shows
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	go app.Listener(listener)
+	defer app.Shutdown()
+
+	// The export runs while the status is sent, it fails before or after it but the response
+	// never ends as if it were complete
+	res, err := http.Get("http://" + listener.Addr().String() + "/api/suppliers?format=csv")
+	if err == nil {
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		_, err = io.ReadAll(res.Body)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	}
+	assert.NotNil(t, err)
+}
+
+func TestExportController_Download(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "EXPORT.csv")
+	assert.Nil(t, os.WriteFile(filePath, []byte("code\nWDWDARFSYH\n"), 0644))
+
+	testCases := []struct {
+		name          string
+		job           *response.ExportJobResponse
+		expectedCode  int
+		expectedError error
+	}{
+		{
+			name:         "Done export",
+			job:          &response.ExportJobResponse{Code: "EXPORT", Entity: model.ExportEntitySuppliers, Format: util.ExportFormatCSV},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:          "Export still running",
+			expectedCode:  http.StatusConflict,
+			expectedError: errors.New(response.ErrorExportNotReady),
+		},
+		{
+			name:          "Export of another user",
+			expectedCode:  http.StatusNotFound,
+			expectedError: errors.New(response.ErrorNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.ExportServiceMock
+			svc.On("JobFile", ctx, "EXPORT").Return(tc.job, filePath, tc.expectedError)
+
+			route := app.Group("/api")
+			NewExportController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/exports/EXPORT/download", nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedCode, res.StatusCode)
+
+			if tc.expectedError == nil {
+				body, err := io.ReadAll(res.Body)
+				assert.Nil(t, err)
+				assert.Equal(t, "code\nWDWDARFSYH\n", string(body))
+				assert.Equal(t, "text/csv", res.Header.Get(fiber.HeaderContentType))
+				assert.Equal(t, `attachment; filename="suppliers.csv"`, res.Header.Get(fiber.HeaderContentDisposition))
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/service"
+	"inventory-management/backend/util"
+	"net/http"
+)
+
+type ImportController struct {
+	ImportService service.ImportServiceContract
+}
+
+func NewImportController(importService service.ImportServiceContract, route fiber.Router) ImportController {
+	controller := ImportController{
+		ImportService: importService,
+	}
+
+	imports := route.Group("/imports")
+	{
+		imports.Post("/products", controller.ImportProducts)
+		imports.Post("/suppliers", controller.ImportSuppliers)
+		imports.Post("/customers", controller.ImportCustomers)
+		imports.Post("/opening-stock", controller.ImportOpeningStock)
+	}
+
+	return controller
+}
+
+func (controller *ImportController) ImportProducts(ctx *fiber.Ctx) error {
+	return importSheet(ctx, controller.ImportService.ImportProducts)
+}
+
+func (controller *ImportController) ImportSuppliers(ctx *fiber.Ctx) error {
+	return importSheet(ctx, controller.ImportService.ImportSuppliers)
+}
+
+func (controller *ImportController) ImportCustomers(ctx *fiber.Ctx) error {
+	return importSheet(ctx, controller.ImportService.ImportCustomers)
+}
+
+func (controller *ImportController) ImportOpeningStock(ctx *fiber.Ctx) error {
+	return importSheet(ctx, controller.ImportService.ImportOpeningStock)
+}
+
+// importSheet imports the rows of the CSV or XLSX sheet uploaded as the file field. With
+// dry_run=true the rows are only validated, and the errors of every row are reported either way.
+func importSheet(ctx *fiber.Ctx, importRows func(context.Context, []*util.SheetRow, bool) (*response.ImportResponse, error)) error {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return fiber.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return fiber.NewError(http.StatusInternalServerError, err.Error())
+	}
+	defer file.Close()
+
+	rows, err := util.ReadSheet(fileHeader.Filename, file)
+	if err != nil {
+		return fiber.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	dryRun := ctx.QueryBool("dry_run")
+	result, err := importRows(ctx.UserContext(), rows, dryRun)
+	if err != nil {
+		return fiber.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	if dryRun {
+		return response.ReturnJSON(ctx, http.StatusOK, "OK", result).Build()
+	}
+	if len(result.Errors) > 0 {
+		return response.ReturnJSON(ctx, http.StatusBadRequest, response.ErrorValidation, result).Build()
+	}
+
+	return response.ReturnJSON(ctx, http.StatusCreated, "created", result).Build()
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/http/middleware"
+	response "inventory-management/backend/internal/http/response"
+	service "inventory-management/backend/internal/service/mock"
+	"inventory-management/backend/util"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestImportController_ImportSuppliers(t *testing.T) {
+	rows := []*util.SheetRow{
+		{Number: 2, Values: map[string]string{"name": "Widdy Arfiansyah", "address": "Sukabumi", "phone": "089911182399"}},
+	}
+	rowErrors := []*response.ImportRowErrorResponse{
+		{Row: 2, Errors: []*response.ErrorResponse{{FailedField: "Phone", Tag: "min", Value: "Error validation 'min' for 'Phone' field"}}},
+	}
+
+	testCases := []struct {
+		name           string
+		filename       string
+		query          string
+		dryRun         bool
+		result         *response.ImportResponse
+		expectedStatus string
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Rows imported",
+			filename:       "suppliers.csv",
+			result:         &response.ImportResponse{TotalRows: 1, Imported: 1},
+			expectedStatus: "created",
+			expectedCode:   http.StatusCreated,
+		},
+		{
+			name:           "Rows validated by a dry run",
+			filename:       "suppliers.csv",
+			query:          "?dry_run=true",
+			dryRun:         true,
+			result:         &response.ImportResponse{DryRun: true, TotalRows: 1, Errors: rowErrors},
+			expectedStatus: "OK",
+			expectedCode:   http.StatusOK,
+		},
+		{
+			name:           "[invalid] Rows not imported",
+			filename:       "suppliers.csv",
+			result:         &response.ImportResponse{TotalRows: 1, Errors: rowErrors},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+		},
+		{
+			name:           "[invalid] File that is not a sheet",
+			filename:       "suppliers.txt",
+			expectedStatus: response.ErrorSheetFileType,
+			expectedCode:   http.StatusBadRequest,
+		},
+		{
+			name:           "Service getting an error",
+			filename:       "suppliers.csv",
+			expectedStatus: "getting an error",
+			expectedCode:   http.StatusInternalServerError,
+			expectedError:  errors.New("getting an error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.ImportServiceMock
+			svc.On("ImportSuppliers", ctx, rows, tc.dryRun).Return(tc.result, tc.expectedError)
+
+			route := app.Group("/api")
+			NewImportController(&svc, route)
+
+			var body bytes.Buffer
+			form := multipart.NewWriter(&body)
+			file, err := form.CreateFormFile("file", tc.filename)
+			assert.Nil(t, err)
+			_, err = file.Write([]byte("name,address,phone\nWiddy Arfiansyah,Sukabumi,089911182399\n"))
+			assert.Nil(t, err)
+			assert.Nil(t, form.Close())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/imports/suppliers"+tc.query, &body)
+			req.Header.Set("Content-Type", form.FormDataContentType())
+
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.expectedCode, responseBody.Code)
+			assert.Equal(t, tc.expectedStatus, responseBody.Status)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/service"
+	"inventory-management/backend/util"
+)
+
+// labelContentTypes are the content types of the label formats, ZPL being sent as is to the
+// printer.
+var labelContentTypes = map[string]string{
+	service.LabelFormatPNG: "image/png",
+	service.LabelFormatSVG: "image/svg+xml",
+	service.LabelFormatZPL: "application/zpl",
+}
+
+type LabelController struct {
+	LabelService service.LabelServiceContract
+}
+
+func NewLabelController(labelService service.LabelServiceContract, route fiber.Router) LabelController {
+	controller := LabelController{
+		LabelService: labelService,
+	}
+
+	label := route.Group("/labels")
+	{
+		label.Get("/product-qualities/:id", controller.ProductQuality)
+		label.Get("/transactions/:code", controller.Transaction)
+	}
+
+	return controller
+}
+
+func (controller *LabelController) ProductQuality(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	var labelRequest request.LabelRequest
+	if err = ctx.QueryParser(&labelRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(labelRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	label, err := controller.LabelService.ProductQuality(ctx.UserContext(), int64(id), &labelRequest)
+	return sendLabel(ctx, fmt.Sprintf("product-quality-%d", id), &labelRequest, label, err)
+}
+
+func (controller *LabelController) Transaction(ctx *fiber.Ctx) error {
+	code := ctx.Params("code")
+	var labelRequest request.LabelRequest
+	if err := ctx.QueryParser(&labelRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(labelRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	label, err := controller.LabelService.Transaction(ctx.UserContext(), code, &labelRequest)
+	return sendLabel(ctx, "transaction-"+code, &labelRequest, label, err)
+}
+
+// sendLabel sends the label rendered for the request, in the format it asked for.
+func sendLabel(ctx *fiber.Ctx, name string, labelRequest *request.LabelRequest, label []byte, err error) error {
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	format := labelRequest.Format
+	if format == "" {
+		format = service.LabelFormatPNG
+	}
+	ctx.Set(fiber.HeaderContentType, labelContentTypes[format])
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="%s.%s"`, name, format))
+
+	return ctx.Status(fiber.StatusOK).Send(label)
+}
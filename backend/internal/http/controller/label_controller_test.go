@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/http/middleware"
+	"inventory-management/backend/internal/http/request"
+	response "inventory-management/backend/internal/http/response"
+	service "inventory-management/backend/internal/service/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLabelController_Transaction(t *testing.T) {
+	testCases := []struct {
+		name                string
+		query               string
+		request             *request.LabelRequest
+		label               []byte
+		expectedCode        int
+		expectedContentType string
+		expectedError       error
+	}{
+		{
+			name:                "PNG label by default",
+			request:             &request.LabelRequest{},
+			label:               []byte("\x89PNG"),
+			expectedCode:        http.StatusOK,
+			expectedContentType: "image/png",
+		},
+		{
+			name:                "ZPL label",
+			query:               "?format=zpl&symbology=gs1-128&copies=3",
+			request:             &request.LabelRequest{Format: "zpl", Symbology: "gs1-128", Copies: 3},
+			label:               []byte("^XA^XZ"),
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/zpl",
+		},
+		{
+			name:                "[invalid] Unknown format",
+			query:               "?format=pdf",
+			expectedCode:        http.StatusBadRequest,
+			expectedContentType: fiber.MIMEApplicationJSON,
+		},
+		{
+			name:                "[invalid] Transaction not found",
+			request:             &request.LabelRequest{},
+			expectedCode:        http.StatusNotFound,
+			expectedContentType: fiber.MIMEApplicationJSON,
+			expectedError:       errors.New(response.ErrorNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.LabelServiceMock
+			svc.On("Transaction", ctx, "TRXOUT1234", tc.request).Return(tc.label, tc.expectedError)
+
+			route := app.Group("/api")
+			NewLabelController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/labels/transactions/TRXOUT1234"+tc.query, nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.expectedCode, res.StatusCode)
+			assert.Contains(t, res.Header.Get(fiber.HeaderContentType), tc.expectedContentType)
+		})
+	}
+}
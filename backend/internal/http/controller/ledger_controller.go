@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/service"
+)
+
+type LedgerController struct {
+	LedgerService service.LedgerServiceContract
+}
+
+func NewLedgerController(ledgerService service.LedgerServiceContract, route fiber.Router) LedgerController {
+	controller := LedgerController{
+		LedgerService: ledgerService,
+	}
+
+	ledger := route.Group("/ledger")
+	{
+		ledger.Get("/verify", controller.Verify)
+		ledger.Get("/checkpoints", controller.ExportCheckpoints)
+		ledger.Post("/checkpoints", controller.CreateCheckpoint)
+	}
+
+	return controller
+}
+
+func (controller *LedgerController) Verify(ctx *fiber.Ctx) error {
+	verification, err := controller.LedgerService.Verify(ctx.UserContext())
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	if !verification.Valid {
+		return response.ReturnJSON(ctx, fiber.StatusConflict, "ledger is broken", verification).Build()
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", verification).Build()
+}
+
+func (controller *LedgerController) ExportCheckpoints(ctx *fiber.Ctx) error {
+	checkpoints, err := controller.LedgerService.ExportCheckpoints(ctx.UserContext())
+	if err != nil {
+		if err.Error() == response.ErrorLedgerSigningKeyMissing {
+			return fiber.NewError(fiber.StatusNotImplemented, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", checkpoints).Build()
+}
+
+func (controller *LedgerController) CreateCheckpoint(ctx *fiber.Ctx) error {
+	checkpoint, err := controller.LedgerService.CreateCheckpoint(ctx.UserContext())
+	if err != nil {
+		if err.Error() == response.ErrorLedgerSigningKeyMissing {
+			return fiber.NewError(fiber.StatusNotImplemented, err.Error())
+		}
+		if err.Error() == response.ErrorLedgerEmpty {
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusCreated, "created", checkpoint).Build()
+}
@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/http/middleware"
+	response "inventory-management/backend/internal/http/response"
+	service "inventory-management/backend/internal/service/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLedgerController_Verify(t *testing.T) {
+	testCases := []struct {
+		name           string
+		expectedStatus string
+		expectedBody   *response.LedgerVerificationResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Ledger is intact",
+			expectedStatus: "OK",
+			expectedBody:   &response.LedgerVerificationResponse{Valid: true, CheckedEntries: 2, LastSequence: 2},
+			expectedCode:   http.StatusOK,
+			expectedError:  nil,
+		},
+		{
+			name:           "Ledger is broken",
+			expectedStatus: "ledger is broken",
+			expectedBody: &response.LedgerVerificationResponse{
+				Valid:      false,
+				BrokenLink: &response.LedgerBrokenLinkResponse{Sequence: 1, Reason: "entry content does not match its hash"},
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: nil,
+		},
+		{
+			name:           "Service getting an error",
+			expectedStatus: "getting an error",
+			expectedCode:   http.StatusInternalServerError,
+			expectedError:  errors.New("getting an error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.LedgerServiceMock
+			svc.On("Verify", ctx).Return(tc.expectedBody, tc.expectedError)
+
+			route := app.Group("/api")
+			NewLedgerController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/ledger/verify", nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
+
+func TestLedgerController_CreateCheckpoint(t *testing.T) {
+	testCases := []struct {
+		name           string
+		expectedStatus string
+		expectedBody   *response.LedgerCheckpointResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Checkpoint is signed",
+			expectedStatus: "created",
+			expectedBody:   &response.LedgerCheckpointResponse{ID: 1, Sequence: 2, Hash: "hash", Signature: "signature"},
+			expectedCode:   http.StatusCreated,
+			expectedError:  nil,
+		},
+		{
+			name:           "Signing key is not configured",
+			expectedStatus: response.ErrorLedgerSigningKeyMissing,
+			expectedCode:   http.StatusNotImplemented,
+			expectedError:  errors.New(response.ErrorLedgerSigningKeyMissing),
+		},
+		{
+			name:           "Ledger has no entries",
+			expectedStatus: response.ErrorLedgerEmpty,
+			expectedCode:   http.StatusConflict,
+			expectedError:  errors.New(response.ErrorLedgerEmpty),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.LedgerServiceMock
+			svc.On("CreateCheckpoint", ctx).Return(tc.expectedBody, tc.expectedError)
+
+			route := app.Group("/api")
+			NewLedgerController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/ledger/checkpoints", nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
+
+func TestLedgerController_ExportCheckpoints(t *testing.T) {
+	testCases := []struct {
+		name           string
+		expectedStatus string
+		expectedBody   *response.LedgerCheckpointExportResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Checkpoints are exported",
+			expectedStatus: "OK",
+			expectedBody: &response.LedgerCheckpointExportResponse{
+				Algorithm:   "ed25519",
+				PublicKey:   "public-key",
+				Checkpoints: []*response.LedgerCheckpointResponse{{ID: 1, Sequence: 2, Hash: "hash", Signature: "signature"}},
+			},
+			expectedCode:  http.StatusOK,
+			expectedError: nil,
+		},
+		{
+			name:           "Signing key is not configured",
+			expectedStatus: response.ErrorLedgerSigningKeyMissing,
+			expectedCode:   http.StatusNotImplemented,
+			expectedError:  errors.New(response.ErrorLedgerSigningKeyMissing),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.LedgerServiceMock
+			svc.On("ExportCheckpoints", ctx).Return(tc.expectedBody, tc.expectedError)
+
+			route := app.Group("/api")
+			NewLedgerController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/ledger/checkpoints", nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/util"
+	"net/url"
+)
+
+// parseListQuery parses the filters, sort, fields and includes of a list request against the
+// whitelist of the entity.
+func parseListQuery(ctx *fiber.Ctx, schema *util.ListSchema) (*util.ListQuery, []*response.ErrorResponse) {
+	return util.ParseListQuery(queryValues(ctx), schema)
+}
+
+// maxListLimit is the largest page a list request is answered with, larger limits are cut to it.
+const maxListLimit = 100
+
+// listLimit is the page size of a list request, 10 by default. Limits below 1 are refused and
+// limits above maxListLimit are cut to it.
+func listLimit(ctx *fiber.Ctx) (int, []*response.ErrorResponse) {
+	limit := ctx.QueryInt("limit", 10)
+	if limit < 1 {
+		return 0, []*response.ErrorResponse{{
+			FailedField: "limit",
+			Tag:         "min",
+			Value:       "Error validation 'min' for 'limit' field",
+		}}
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	return limit, nil
+}
+
+func queryValues(ctx *fiber.Ctx) url.Values {
+	values := url.Values{}
+	ctx.Context().QueryArgs().VisitAll(func(key []byte, value []byte) {
+		values.Add(string(key), string(value))
+	})
+
+	return values
+}
+
+// cursorPaged reports whether a list request is paged by cursor instead of by page number. An
+// empty cursor asks for the first page.
+func cursorPaged(ctx *fiber.Ctx) bool {
+	return ctx.Context().QueryArgs().Has("cursor")
+}
+
+// cursorPagination links the pages around a page paged by cursor. The records are only counted
+// with count=true, since skipping the count is much of what makes cursor pages cheap.
+func cursorPagination(ctx *fiber.Ctx, listQuery *util.ListQuery, page *util.CursorPage, countAll func(context.Context, *util.ListQuery) (int64, error)) (*response.CursorPagination, error) {
+	var totalRecords *int64
+	if ctx.QueryBool("count") {
+		count, err := countAll(ctx.UserContext(), listQuery)
+		if err != nil {
+			return nil, err
+		}
+		totalRecords = &count
+	}
+
+	pagination := util.CreateCursorPagination(ctx.Path(), queryValues(ctx), page, totalRecords)
+	return &pagination, nil
+}
@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/service"
+	"inventory-management/backend/util"
+	"time"
+)
+
+// oidcStateCookie holds the signed state of the login the browser started, so the callback is
+// only completed by that browser.
+const (
+	oidcStateCookie     = "oidc_state"
+	oidcStateCookiePath = "/api/auth/oidc"
+)
+
+type OidcController struct {
+	OidcService service.OidcServiceContract
+}
+
+func NewOidcController(oidcService service.OidcServiceContract, route fiber.Router) OidcController {
+	controller := OidcController{
+		OidcService: oidcService,
+	}
+
+	oidc := route.Group("/auth/oidc")
+	{
+		oidc.Get("/login", controller.Login)
+		oidc.Get("/callback", controller.Callback)
+	}
+
+	return controller
+}
+
+// NewOidcLinkController registers the route linking an identity to the signed in user, it
+// belongs behind the JWT middleware.
+func NewOidcLinkController(oidcService service.OidcServiceContract, route fiber.Router) OidcController {
+	controller := OidcController{
+		OidcService: oidcService,
+	}
+
+	route.Get("/auth/oidc/link", controller.Link)
+
+	return controller
+}
+
+func (controller *OidcController) Login(ctx *fiber.Ctx) error {
+	loginResponse, err := controller.OidcService.LoginURL(ctx.UserContext())
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadGateway, err.Error())
+	}
+	setOidcStateCookie(ctx, loginResponse.Cookie)
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", loginResponse).Build()
+}
+
+func (controller *OidcController) Link(ctx *fiber.Ctx) error {
+	username, _ := ctx.Locals("username").(string)
+
+	linkResponse, err := controller.OidcService.LinkURL(ctx.UserContext(), username)
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return fiber.NewError(fiber.StatusBadGateway, err.Error())
+	}
+
+	setOidcStateCookie(ctx, linkResponse.Cookie)
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", linkResponse).Build()
+}
+
+func (controller *OidcController) Callback(ctx *fiber.Ctx) error {
+	var callbackRequest request.OidcCallbackRequest
+	if err := ctx.QueryParser(&callbackRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(callbackRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	// The state is used once, whether the login succeeds or not
+	callbackRequest.Cookie = ctx.Cookies(oidcStateCookie)
+	setOidcStateCookie(ctx, "")
+
+	userResponse, err := controller.OidcService.Callback(ctx.UserContext(), &callbackRequest)
+	if err != nil {
+		if err.Error() == response.ErrorOidcInvalidState {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		if err.Error() == response.ErrorOidcAccountConflict || err.Error() == response.ErrorOidcUsernameTaken {
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		if err.Error() == response.ErrorOidcUsernameMissing {
+			return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+		}
+		if err.Error() == response.ErrorTenantMissing {
+			return fiber.NewError(fiber.StatusForbidden, err.Error())
+		}
+		return fiber.NewError(fiber.StatusUnauthorized, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", userResponse).Build()
+}
+
+// setOidcStateCookie sets the cookie of the login state, or clears it when there is none.
+func setOidcStateCookie(ctx *fiber.Ctx, value string) {
+	cookie := &fiber.Cookie{
+		Name:     oidcStateCookie,
+		Value:    value,
+		Path:     oidcStateCookiePath,
+		Secure:   ctx.Protocol() == "https",
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	}
+	if value == "" {
+		cookie.Expires = time.Unix(0, 0)
+	}
+
+	ctx.Cookie(cookie)
+}
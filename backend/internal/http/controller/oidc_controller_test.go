@@ -0,0 +1,245 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/http/middleware"
+	"inventory-management/backend/internal/http/request"
+	response "inventory-management/backend/internal/http/response"
+	service "inventory-management/backend/internal/service/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOidcController_Login(t *testing.T) {
+	testCases := []struct {
+		name           string
+		expectedStatus string
+		expectedBody   *response.OidcLoginResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Authorization URL is generated",
+			expectedStatus: "OK",
+			expectedBody: &response.OidcLoginResponse{
+				AuthorizationURL: "http://idp/authorize?state=state",
+				State:            "state",
+				Cookie:           "state.signature",
+			},
+			expectedCode:  http.StatusOK,
+			expectedError: nil,
+		},
+		{
+			name:           "Identity provider is unreachable",
+			expectedStatus: "connection refused",
+			expectedBody:   nil,
+			expectedCode:   http.StatusBadGateway,
+			expectedError:  errors.New("connection refused"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.OidcServiceMock
+			svc.On("LoginURL", ctx).Return(tc.expectedBody, tc.expectedError)
+
+			route := app.Group("/api")
+			NewOidcController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/login", nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+			if tc.expectedBody != nil {
+				assert.Contains(t, res.Header.Get("Set-Cookie"), "oidc_state=state.signature; path=/api/auth/oidc;")
+				assert.Contains(t, res.Header.Get("Set-Cookie"), "HttpOnly")
+			}
+		})
+	}
+}
+
+func TestOidcController_Link(t *testing.T) {
+	testCases := []struct {
+		name           string
+		expectedStatus string
+		expectedBody   *response.OidcLoginResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Authorization URL linking the signed in user is generated",
+			expectedStatus: "OK",
+			expectedBody: &response.OidcLoginResponse{
+				AuthorizationURL: "http://idp/authorize?state=state",
+				State:            "state",
+				Cookie:           "state.signature",
+			},
+			expectedCode:  http.StatusOK,
+			expectedError: nil,
+		},
+		{
+			name:           "Identity provider is unreachable",
+			expectedStatus: "connection refused",
+			expectedBody:   nil,
+			expectedCode:   http.StatusBadGateway,
+			expectedError:  errors.New("connection refused"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+			app.Use(func(ctx *fiber.Ctx) error {
+				ctx.Locals("username", "wdyarfn")
+				return ctx.Next()
+			})
+
+			ctx := context.Background()
+
+			var svc service.OidcServiceMock
+			svc.On("LinkURL", ctx, "wdyarfn").Return(tc.expectedBody, tc.expectedError)
+
+			route := app.Group("/api")
+			NewOidcLinkController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/link", nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+			if tc.expectedBody != nil {
+				assert.Contains(t, res.Header.Get("Set-Cookie"), "oidc_state=state.signature; path=/api/auth/oidc;")
+				assert.Contains(t, res.Header.Get("Set-Cookie"), "HttpOnly")
+			}
+		})
+	}
+}
+
+func TestOidcController_Callback(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		request        *request.OidcCallbackRequest
+		expectedStatus string
+		expectedBody   *response.UserLoginResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Callback with valid code and state",
+			query:          "code=code&state=state",
+			request:        &request.OidcCallbackRequest{Code: "code", State: "state", Cookie: "state.signature"},
+			expectedStatus: "OK",
+			expectedBody:   &response.UserLoginResponse{Token: "token"},
+			expectedCode:   http.StatusOK,
+			expectedError:  nil,
+		},
+		{
+			name:           "[missing] Callback without code",
+			query:          "state=state",
+			request:        &request.OidcCallbackRequest{State: "state", Cookie: "state.signature"},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'required' for 'Code' field"),
+		},
+		{
+			name:           "Callback with unknown state",
+			query:          "code=code&state=state",
+			request:        &request.OidcCallbackRequest{Code: "code", State: "state", Cookie: "state.signature"},
+			expectedStatus: response.ErrorOidcInvalidState,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New(response.ErrorOidcInvalidState),
+		},
+		{
+			name:           "Callback for an identity conflicting with a linked account",
+			query:          "code=code&state=state",
+			request:        &request.OidcCallbackRequest{Code: "code", State: "state", Cookie: "state.signature"},
+			expectedStatus: response.ErrorOidcAccountConflict,
+			expectedCode:   http.StatusConflict,
+			expectedError:  errors.New(response.ErrorOidcAccountConflict),
+		},
+		{
+			name:           "Callback for an identity whose username belongs to a local account",
+			query:          "code=code&state=state",
+			request:        &request.OidcCallbackRequest{Code: "code", State: "state", Cookie: "state.signature"},
+			expectedStatus: response.ErrorOidcUsernameTaken,
+			expectedCode:   http.StatusConflict,
+			expectedError:  errors.New(response.ErrorOidcUsernameTaken),
+		},
+		{
+			name:           "Callback for an identity without a username",
+			query:          "code=code&state=state",
+			request:        &request.OidcCallbackRequest{Code: "code", State: "state", Cookie: "state.signature"},
+			expectedStatus: response.ErrorOidcUsernameMissing,
+			expectedCode:   http.StatusUnprocessableEntity,
+			expectedError:  errors.New(response.ErrorOidcUsernameMissing),
+		},
+		{
+			name:           "Identity provider rejects the code",
+			query:          "code=code&state=state",
+			request:        &request.OidcCallbackRequest{Code: "code", State: "state", Cookie: "state.signature"},
+			expectedStatus: "invalid_grant",
+			expectedCode:   http.StatusUnauthorized,
+			expectedError:  errors.New("invalid_grant"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.OidcServiceMock
+			svc.On("Callback", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
+
+			route := app.Group("/api")
+			NewOidcController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/callback?"+tc.query, nil)
+			req.Header.Set("Cookie", "oidc_state=state.signature")
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			if strings.Contains(tc.name, "[missing]") {
+				var responseBody response.ErrorValidationResponse
+				err = json.NewDecoder(res.Body).Decode(&responseBody)
+				assert.Nil(t, err)
+
+				assert.Equal(t, responseBody.Code, tc.expectedCode)
+				assert.Equal(t, responseBody.Status, tc.expectedStatus)
+				assert.Equal(t, responseBody.Error[0].Value, tc.expectedError.Error())
+				return
+			}
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+			assert.Contains(t, res.Header.Get("Set-Cookie"), "oidc_state=; expires=")
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/util"
+)
+
+// preconditionFailed answers a change made against a version the record is no longer at with
+// the record as it is now, tagged with its version, so the client can redo its change on it.
+// find returns the record and its version.
+func preconditionFailed(ctx *fiber.Ctx, find func() (interface{}, int64, error)) error {
+	current, version, err := find()
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusPreconditionFailed, response.ErrorVersionMismatch, current).WithETag(util.VersionETag(version)).Build()
+}
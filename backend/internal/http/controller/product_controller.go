@@ -4,17 +4,20 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"inventory-management/backend/internal/http/request"
 	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
 	"inventory-management/backend/internal/service"
 	"inventory-management/backend/util"
 )
 
 type ProductController struct {
 	ProductService service.ProductServiceContract
+	ExportService  service.ExportServiceContract
 }
 
-func NewProductController(productService service.ProductServiceContract, route fiber.Router) ProductController {
+func NewProductController(productService service.ProductServiceContract, exportService service.ExportServiceContract, route fiber.Router) ProductController {
 	controller := ProductController{
 		ProductService: productService,
+		ExportService:  exportService,
 	}
 
 	product := route.Group("/products")
@@ -24,31 +27,62 @@ func NewProductController(productService service.ProductServiceContract, route f
 		product.Post("/", controller.Create)
 		product.Patch("/:code", controller.Update)
 		product.Delete("/:code", controller.Delete)
+		product.Post("/:code/restore", controller.Restore)
+		product.Post("/:code/purge", controller.Purge)
 	}
 
 	return controller
 }
 
 func (controller *ProductController) FindAll(ctx *fiber.Ctx) error {
+	listQuery, errValidate := parseListQuery(ctx, model.ProductListSchema)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	if ExportRequested(ctx) {
+		return exportList(ctx, controller.ExportService, model.ExportEntityProducts, queryValues(ctx), listQuery)
+	}
+
 	currPage := ctx.QueryInt("page", 1)
 	if currPage <= 0 {
 		currPage = 1
 	}
-	limit := ctx.QueryInt("limit", 10)
+	limit, errValidate := listLimit(ctx)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	if cursorPaged(ctx) {
+		products, page, err := controller.ProductService.FindAllByCursor(ctx.UserContext(), listQuery, ctx.Query("cursor"), limit)
+		if err != nil {
+			if err.Error() == response.ErrorInvalidCursor {
+				return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+
+		cursor, err := cursorPagination(ctx, listQuery, page, controller.ProductService.CountAll)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+
+		return response.ReturnJSON(ctx, fiber.StatusOK, "OK", products).WithCursor(cursor).WithFields(listQuery.Fields).Build()
+	}
 
-	totalRecords, err := controller.ProductService.CountAll(ctx.UserContext())
+	totalRecords, err := controller.ProductService.CountAll(ctx.UserContext(), listQuery)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
 	pagination := util.CreatePagination(currPage, limit, totalRecords)
 	offset := (currPage - 1) * limit
-	products, err := controller.ProductService.FindAll(ctx.UserContext(), offset, limit)
+	products, err := controller.ProductService.FindAll(ctx.UserContext(), listQuery, offset, limit)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", products).WithPagination(&pagination).Build()
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", products).WithPagination(&pagination).WithFields(listQuery.Fields).Build()
 }
 
 func (controller *ProductController) FindByCode(ctx *fiber.Ctx) error {
@@ -62,7 +96,7 @@ func (controller *ProductController) FindByCode(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", product).Build()
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", product).WithETag(util.VersionETag(product.Version)).Build()
 }
 
 func (controller *ProductController) Create(ctx *fiber.Ctx) error {
@@ -77,6 +111,9 @@ func (controller *ProductController) Create(ctx *fiber.Ctx) error {
 
 	product, err := controller.ProductService.Create(ctx.UserContext(), &productRequest)
 	if err != nil {
+		if err.Error() == response.ErrorGtinExists {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
@@ -97,13 +134,18 @@ func (controller *ProductController) Update(ctx *fiber.Ctx) error {
 	productRequest.Code = code
 	product, err := controller.ProductService.Update(ctx.UserContext(), &productRequest)
 	if err != nil {
-		if err.Error() == response.ErrorNotFound {
+		switch err.Error() {
+		case response.ErrorNotFound:
 			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case response.ErrorGtinExists:
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case response.ErrorVersionMismatch:
+			return preconditionFailed(ctx, controller.current(ctx, code))
 		}
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	return response.ReturnJSON(ctx, fiber.StatusOK, "updated", product).Build()
+	return response.ReturnJSON(ctx, fiber.StatusOK, "updated", product).WithETag(util.VersionETag(product.Version)).Build()
 }
 
 func (controller *ProductController) Delete(ctx *fiber.Ctx) error {
@@ -113,8 +155,50 @@ func (controller *ProductController) Delete(ctx *fiber.Ctx) error {
 		if err.Error() == response.ErrorNotFound {
 			return fiber.NewError(fiber.StatusNotFound, err.Error())
 		}
+		if err.Error() == response.ErrorVersionMismatch {
+			return preconditionFailed(ctx, controller.current(ctx, code))
+		}
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
 	return response.ReturnJSON(ctx, fiber.StatusOK, "deleted", nil).Build()
 }
+
+func (controller *ProductController) Restore(ctx *fiber.Ctx) error {
+	product, err := controller.ProductService.Restore(ctx.UserContext(), ctx.Params("code"))
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "restored", product).WithETag(util.VersionETag(product.Version)).Build()
+}
+
+func (controller *ProductController) Purge(ctx *fiber.Ctx) error {
+	err := controller.ProductService.Purge(ctx.UserContext(), ctx.Params("code"))
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		if err.Error() == response.ErrorRecordReferenced {
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "purged", nil).Build()
+}
+
+// current finds the product as it is now.
+func (controller *ProductController) current(ctx *fiber.Ctx, code string) func() (interface{}, int64, error) {
+	return func() (interface{}, int64, error) {
+		product, err := controller.ProductService.FindByCode(ctx.UserContext(), code)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return product, product.Version, nil
+	}
+}
@@ -8,10 +8,12 @@ import (
 	"fmt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"inventory-management/backend/internal/http/middleware"
 	request "inventory-management/backend/internal/http/request"
 	response "inventory-management/backend/internal/http/response"
 	service "inventory-management/backend/internal/service/mock"
+	"inventory-management/backend/util"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -75,11 +77,11 @@ func TestProductController_FindAll(t *testing.T) {
 			ctx := context.Background()
 
 			var svc service.ProductServiceMock
-			svc.On("CountAll", ctx).Return(int64(2), nil)
-			svc.On("FindAll", ctx, 0, 10).Return(tc.expectedBody, tc.expectedError)
+			svc.On("CountAll", ctx, &util.ListQuery{}).Return(int64(2), nil)
+			svc.On("FindAll", ctx, &util.ListQuery{}, 0, 10).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewProductController(&svc, route)
+			ctrl := NewProductController(&svc, nil, route)
 			app.Get("/api/products", ctrl.FindAll)
 
 			req := httptest.NewRequest(http.MethodGet, "/api/products", nil)
@@ -151,7 +153,7 @@ func TestProductController_FindByCode(t *testing.T) {
 			svc.On("FindByCode", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewProductController(&svc, route)
+			ctrl := NewProductController(&svc, nil, route)
 			app.Get("/api/products/:code", ctrl.FindByCode)
 
 			url := fmt.Sprintf("/api/products/%s", tc.request)
@@ -532,7 +534,7 @@ func TestProductController_Create(t *testing.T) {
 			svc.On("Create", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewProductController(&svc, route)
+			ctrl := NewProductController(&svc, nil, route)
 			app.Post("/api/products", ctrl.Create)
 
 			byteRequest, err := json.Marshal(tc.request)
@@ -963,7 +965,7 @@ func TestProductController_Update(t *testing.T) {
 			svc.On("Update", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewProductController(&svc, route)
+			ctrl := NewProductController(&svc, nil, route)
 			app.Patch("/api/products/:code", ctrl.Update)
 
 			byteRequest, err := json.Marshal(tc.request)
@@ -999,6 +1001,94 @@ func TestProductController_Update(t *testing.T) {
 	}
 }
 
+func TestProductController_UpdateIfMatch(t *testing.T) {
+	productRequest := &request.UpdateProductRequest{
+		Name:                "Shrimp",
+		UnitMassAcronym:     "kg",
+		UnitMassDescription: "kilogram",
+		ProductQualities:    []*request.UpdateProductQualityRequest{{ID: 1, Quality: "Fresh", Price: 15000, Quantity: 4.5, Type: "increase"}},
+	}
+	expectedVersion := func(version int64) interface{} {
+		return mock.MatchedBy(func(ctx context.Context) bool {
+			expected, ok := util.ExpectedVersionFromContext(ctx)
+			return ok && expected == version
+		})
+	}
+
+	testCases := []struct {
+		name           string
+		required       bool
+		ifMatch        string
+		expectedCode   int
+		expectedETag   string
+		expectedStatus string
+	}{
+		{
+			name:           "Product updated at the version of If-Match",
+			ifMatch:        `"4"`,
+			expectedCode:   http.StatusOK,
+			expectedETag:   `"5"`,
+			expectedStatus: "updated",
+		},
+		{
+			name:           "[invalid] Product changed since the version of If-Match",
+			ifMatch:        `"3"`,
+			expectedCode:   http.StatusPreconditionFailed,
+			expectedETag:   `"4"`,
+			expectedStatus: response.ErrorVersionMismatch,
+		},
+		{
+			name:           "[invalid] If-Match missing when it is required",
+			required:       true,
+			expectedCode:   http.StatusPreconditionRequired,
+			expectedStatus: response.ErrorIfMatchRequired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			var svc service.ProductServiceMock
+			svc.On("Update", expectedVersion(4), mock.Anything).Return(&response.ProductResponse{Code: "KKSJIDNA", Name: "Shrimp", Version: 5}, nil)
+			svc.On("Update", expectedVersion(3), mock.Anything).Return(nil, errors.New(response.ErrorVersionMismatch))
+			svc.On("FindByCode", mock.Anything, "KKSJIDNA").Return(&response.ProductResponse{Code: "KKSJIDNA", Name: "Shark", Version: 4}, nil)
+
+			route := app.Group("/api")
+			route.Use("/products", middleware.NewIfMatchMiddleware(tc.required))
+			NewProductController(&svc, nil, route)
+
+			byteRequest, err := json.Marshal(productRequest)
+			assert.Nil(t, err)
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/products/KKSJIDNA", bytes.NewReader(byteRequest))
+			req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			var responseBody struct {
+				Code   int                       `json:"code"`
+				Status string                    `json:"status"`
+				Data   *response.ProductResponse `json:"data"`
+			}
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.expectedCode, res.StatusCode)
+			assert.Equal(t, tc.expectedStatus, responseBody.Status)
+			assert.Equal(t, tc.expectedETag, res.Header.Get("ETag"))
+			if tc.expectedCode == http.StatusPreconditionFailed {
+				// The current product is returned to redo the change on it
+				assert.Equal(t, "Shark", responseBody.Data.Name)
+			}
+		})
+	}
+}
+
 func TestProductController_Delete(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -1040,7 +1130,7 @@ func TestProductController_Delete(t *testing.T) {
 			svc.On("Delete", ctx, tc.request).Return(tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewProductController(&svc, route)
+			ctrl := NewProductController(&svc, nil, route)
 			app.Delete("/api/products/:code", ctrl.Delete)
 
 			url := fmt.Sprintf("/api/products/%s", tc.request)
@@ -4,6 +4,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"inventory-management/backend/internal/http/response"
 	"inventory-management/backend/internal/service"
+	"inventory-management/backend/util"
 )
 
 type ProductQualityController struct {
@@ -21,6 +22,8 @@ func NewProductQualityController(productQualityService service.ProductQualitySer
 		productQuality.Get("/:id", controller.FindByID)
 		productQuality.Get("/:code/product", controller.FindAllByProductCode)
 		productQuality.Delete("/:id", controller.Delete)
+		productQuality.Post("/:id/restore", controller.Restore)
+		productQuality.Post("/:id/purge", controller.Purge)
 	}
 
 	return controller
@@ -62,7 +65,7 @@ func (controller *ProductQualityController) FindByID(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", productQuality).Build()
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", productQuality).WithETag(util.VersionETag(productQuality.Version)).Build()
 }
 
 func (controller *ProductQualityController) Delete(ctx *fiber.Ctx) error {
@@ -76,8 +79,63 @@ func (controller *ProductQualityController) Delete(ctx *fiber.Ctx) error {
 		if err.Error() == response.ErrorNotFound {
 			return fiber.NewError(fiber.StatusNotFound, err.Error())
 		}
+		if err.Error() == response.ErrorVersionMismatch {
+			return preconditionFailed(ctx, controller.current(ctx, int64(id)))
+		}
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
 	return response.ReturnJSON(ctx, fiber.StatusOK, "deleted", nil).Build()
 }
+
+func (controller *ProductQualityController) Restore(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	productQuality, err := controller.ProductQualityService.Restore(ctx.UserContext(), int64(id))
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		if err.Error() == response.ErrorProductDeleted {
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "restored", productQuality).WithETag(util.VersionETag(productQuality.Version)).Build()
+}
+
+func (controller *ProductQualityController) Purge(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	err = controller.ProductQualityService.Purge(ctx.UserContext(), int64(id))
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		if err.Error() == response.ErrorRecordReferenced {
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "purged", nil).Build()
+}
+
+// current finds the quality as it is now.
+func (controller *ProductQualityController) current(ctx *fiber.Ctx, id int64) func() (interface{}, int64, error) {
+	return func() (interface{}, int64, error) {
+		productQuality, err := controller.ProductQualityService.FindByID(ctx.UserContext(), id)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return productQuality, productQuality.Version, nil
+	}
+}
@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/service"
+	"net/url"
+)
+
+type ScanController struct {
+	ScanService service.ScanServiceContract
+}
+
+func NewScanController(scanService service.ScanServiceContract, route fiber.Router) ScanController {
+	controller := ScanController{
+		ScanService: scanService,
+	}
+
+	scan := route.Group("/scan")
+	{
+		scan.Get("/:value", controller.Scan)
+	}
+
+	return controller
+}
+
+func (controller *ScanController) Scan(ctx *fiber.Ctx) error {
+	// Scanned GS1 strings hold parentheses and group separators, which arrive escaped
+	value, err := url.PathUnescape(ctx.Params("value"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	scan, err := controller.ScanService.Scan(ctx.UserContext(), value)
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", scan).Build()
+}
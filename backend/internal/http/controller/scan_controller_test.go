@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/http/middleware"
+	response "inventory-management/backend/internal/http/response"
+	service "inventory-management/backend/internal/service/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestScanController_Scan(t *testing.T) {
+	testCases := []struct {
+		name           string
+		path           string
+		value          string
+		scan           *response.ScanResponse
+		expectedStatus string
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Code resolved",
+			path:           "TRXOUT1234",
+			value:          "TRXOUT1234",
+			scan:           &response.ScanResponse{Value: "TRXOUT1234", Type: "transaction"},
+			expectedStatus: "OK",
+			expectedCode:   http.StatusOK,
+		},
+		{
+			name:           "Escaped GS1 element string resolved",
+			path:           "%28240%29PRDCT12345%2891%297",
+			value:          "(240)PRDCT12345(91)7",
+			scan:           &response.ScanResponse{Value: "(240)PRDCT12345(91)7", Type: "product_quality"},
+			expectedStatus: "OK",
+			expectedCode:   http.StatusOK,
+		},
+		{
+			name:           "[invalid] Value identifying nothing",
+			path:           "NOTHING123",
+			value:          "NOTHING123",
+			expectedStatus: response.ErrorNotFound,
+			expectedCode:   http.StatusNotFound,
+			expectedError:  errors.New(response.ErrorNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.ScanServiceMock
+			svc.On("Scan", ctx, tc.value).Return(tc.scan, tc.expectedError)
+
+			route := app.Group("/api")
+			NewScanController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/scan/"+tc.path, nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.expectedCode, responseBody.Code)
+			assert.Equal(t, tc.expectedStatus, responseBody.Status)
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/service"
+	search "inventory-management/backend/internal/third_party/search"
+	"inventory-management/backend/util"
+)
+
+type SearchController struct {
+	SearchService service.SearchServiceContract
+}
+
+func NewSearchController(searchService service.SearchServiceContract, route fiber.Router) SearchController {
+	controller := SearchController{
+		SearchService: searchService,
+	}
+
+	route.Get("/products/suggest", controller.Suggest)
+	route.Post("/products/search", controller.SearchProducts)
+	route.Post("/product-qualities/search", controller.SearchProductQualities)
+	route.Post("/transactions/search", controller.SearchTransactions)
+	route.Post("/suppliers/search", controller.SearchSuppliers)
+	route.Post("/customers/search", controller.SearchCustomers)
+	route.Post("/search-indices/:index/reindex", controller.Reindex)
+
+	return controller
+}
+
+// searchPage is the page and limit of a search request. The limit is bounded as listLimit bounds
+// it, and pages reaching past search.MaxResultWindow are refused since no engine pages that deep.
+func searchPage(ctx *fiber.Ctx) (int, int, []*response.ErrorResponse) {
+	limit, errValidate := listLimit(ctx)
+	if errValidate != nil {
+		return 0, 0, errValidate
+	}
+
+	currPage := ctx.QueryInt("page", 1)
+	if currPage <= 0 {
+		currPage = 1
+	}
+	if currPage > search.MaxResultWindow/limit {
+		return 0, 0, []*response.ErrorResponse{{
+			FailedField: "page",
+			Tag:         "max",
+			Value:       "Error validation 'max' for 'page' field",
+		}}
+	}
+
+	return currPage, limit, nil
+}
+
+func (controller *SearchController) SearchProducts(ctx *fiber.Ctx) error {
+	var searchRequest request.ProductSearchRequest
+	if err := ctx.BodyParser(&searchRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(searchRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	currPage, limit, errValidate := searchPage(ctx)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	offset := (currPage - 1) * limit
+	searchResponse, err := controller.SearchService.SearchProducts(ctx.UserContext(), &searchRequest, offset, limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	pagination := util.CreatePagination(currPage, limit, searchResponse.Total)
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", searchResponse).WithPagination(&pagination).Build()
+}
+
+func (controller *SearchController) SearchProductQualities(ctx *fiber.Ctx) error {
+	var searchRequest request.ProductQualitySearchRequest
+	if err := ctx.BodyParser(&searchRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(searchRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	currPage, limit, errValidate := searchPage(ctx)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	offset := (currPage - 1) * limit
+	searchResponse, err := controller.SearchService.SearchProductQualities(ctx.UserContext(), &searchRequest, offset, limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	pagination := util.CreatePagination(currPage, limit, searchResponse.Total)
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", searchResponse).WithPagination(&pagination).Build()
+}
+
+func (controller *SearchController) SearchTransactions(ctx *fiber.Ctx) error {
+	var searchRequest request.TransactionSearchRequest
+	if err := ctx.BodyParser(&searchRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(searchRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	currPage, limit, errValidate := searchPage(ctx)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	offset := (currPage - 1) * limit
+	searchResponse, err := controller.SearchService.SearchTransactions(ctx.UserContext(), &searchRequest, offset, limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	pagination := util.CreatePagination(currPage, limit, searchResponse.Total)
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", searchResponse).WithPagination(&pagination).Build()
+}
+
+func (controller *SearchController) SearchSuppliers(ctx *fiber.Ctx) error {
+	var searchRequest request.SupplierSearchRequest
+	if err := ctx.BodyParser(&searchRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(searchRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	currPage, limit, errValidate := searchPage(ctx)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	offset := (currPage - 1) * limit
+	searchResponse, err := controller.SearchService.SearchSuppliers(ctx.UserContext(), &searchRequest, offset, limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	pagination := util.CreatePagination(currPage, limit, searchResponse.Total)
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", searchResponse).WithPagination(&pagination).Build()
+}
+
+func (controller *SearchController) SearchCustomers(ctx *fiber.Ctx) error {
+	var searchRequest request.CustomerSearchRequest
+	if err := ctx.BodyParser(&searchRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(searchRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	currPage, limit, errValidate := searchPage(ctx)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	offset := (currPage - 1) * limit
+	searchResponse, err := controller.SearchService.SearchCustomers(ctx.UserContext(), &searchRequest, offset, limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	pagination := util.CreatePagination(currPage, limit, searchResponse.Total)
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", searchResponse).WithPagination(&pagination).Build()
+}
+
+func (controller *SearchController) Suggest(ctx *fiber.Ctx) error {
+	var suggestRequest request.SuggestRequest
+	if err := ctx.QueryParser(&suggestRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(suggestRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	suggestResponse, err := controller.SearchService.Suggest(ctx.UserContext(), &suggestRequest)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", suggestResponse).Build()
+}
+
+func (controller *SearchController) Reindex(ctx *fiber.Ctx) error {
+	index := ctx.Params("index")
+	reindex, err := controller.SearchService.Reindex(ctx.UserContext(), index)
+	if err != nil {
+		switch err.Error() {
+		case response.ErrorSearchIndexUnknown:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case response.ErrorReindexCountMismatch:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", reindex).Build()
+}
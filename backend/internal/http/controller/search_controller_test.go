@@ -0,0 +1,287 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/http/middleware"
+	"inventory-management/backend/internal/http/request"
+	response "inventory-management/backend/internal/http/response"
+	service "inventory-management/backend/internal/service/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSearchController_SearchTransactions(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		body           string
+		offset         int
+		limit          int
+		request        *request.TransactionSearchRequest
+		expectedStatus string
+		expectedBody   *response.SearchResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Transactions matching the query and filters",
+			body:           `{"query":"kangkung","type":"IN","from":"2021-01-01"}`,
+			request:        &request.TransactionSearchRequest{Query: "kangkung", Type: "IN", From: "2021-01-01"},
+			expectedStatus: "OK",
+			expectedBody: &response.SearchResponse{
+				Total: 1,
+				Hits: []map[string]interface{}{
+					{"code": "SKSJDHUWHA", "product_name": "Kangkung"},
+				},
+				Facets: map[string][]*response.FacetBucketResponse{
+					"type": {{Value: "IN", Count: 1}},
+				},
+			},
+			expectedCode:  http.StatusOK,
+			expectedError: nil,
+		},
+		{
+			name:           "[missing] Transactions filtered by unknown type",
+			body:           `{"type":"RETURN"}`,
+			request:        &request.TransactionSearchRequest{Type: "RETURN"},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'oneof' for 'Type' field"),
+		},
+		{
+			name:           "Limit above the maximum is cut to it",
+			query:          "page=2&limit=500",
+			body:           `{"query":"kangkung"}`,
+			offset:         100,
+			limit:          100,
+			request:        &request.TransactionSearchRequest{Query: "kangkung"},
+			expectedStatus: "OK",
+			expectedBody:   &response.SearchResponse{Total: 1},
+			expectedCode:   http.StatusOK,
+		},
+		{
+			name:           "[missing] Negative limit",
+			query:          "limit=-5",
+			body:           `{"query":"kangkung"}`,
+			request:        &request.TransactionSearchRequest{Query: "kangkung"},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'min' for 'limit' field"),
+		},
+		{
+			name:           "[missing] Page past the result window",
+			query:          "page=101&limit=100",
+			body:           `{"query":"kangkung"}`,
+			request:        &request.TransactionSearchRequest{Query: "kangkung"},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'max' for 'page' field"),
+		},
+		{
+			name:           "Service getting an error",
+			body:           `{"query":"kangkung"}`,
+			request:        &request.TransactionSearchRequest{Query: "kangkung"},
+			expectedStatus: "getting an error",
+			expectedCode:   http.StatusInternalServerError,
+			expectedError:  errors.New("getting an error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			limit := tc.limit
+			if limit == 0 {
+				limit = 10
+			}
+
+			var svc service.SearchServiceMock
+			svc.On("SearchTransactions", ctx, tc.request, tc.offset, limit).Return(tc.expectedBody, tc.expectedError)
+
+			route := app.Group("/api")
+			NewSearchController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/transactions/search?"+tc.query, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			if strings.Contains(tc.name, "[missing]") {
+				var responseBody response.ErrorValidationResponse
+				err = json.NewDecoder(res.Body).Decode(&responseBody)
+				assert.Nil(t, err)
+
+				assert.Equal(t, responseBody.Code, tc.expectedCode)
+				assert.Equal(t, responseBody.Status, tc.expectedStatus)
+				assert.Equal(t, responseBody.Error[0].Value, tc.expectedError.Error())
+				return
+			}
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+			if tc.expectedCode == http.StatusOK {
+				assert.Equal(t, 1, responseBody.Pagination.TotalRecords)
+			}
+		})
+	}
+}
+
+func TestSearchController_Reindex(t *testing.T) {
+	testCases := []struct {
+		name           string
+		index          string
+		expectedStatus string
+		expectedBody   *response.ReindexResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Reindex suppliers",
+			index:          "suppliers",
+			expectedStatus: "OK",
+			expectedBody: &response.ReindexResponse{
+				Alias:          "suppliers",
+				Index:          "suppliers_v20230601120000",
+				Documents:      2,
+				DeletedIndices: []string{"suppliers_v1"},
+			},
+			expectedCode:  http.StatusOK,
+			expectedError: nil,
+		},
+		{
+			name:           "Unknown index",
+			index:          "warehouses",
+			expectedStatus: response.ErrorSearchIndexUnknown,
+			expectedCode:   http.StatusNotFound,
+			expectedError:  errors.New(response.ErrorSearchIndexUnknown),
+		},
+		{
+			name:           "Document count does not match",
+			index:          "suppliers",
+			expectedStatus: response.ErrorReindexCountMismatch,
+			expectedCode:   http.StatusConflict,
+			expectedError:  errors.New(response.ErrorReindexCountMismatch),
+		},
+		{
+			name:           "Service getting an error",
+			index:          "suppliers",
+			expectedStatus: "getting an error",
+			expectedCode:   http.StatusInternalServerError,
+			expectedError:  errors.New("getting an error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.SearchServiceMock
+			svc.On("Reindex", ctx, tc.index).Return(tc.expectedBody, tc.expectedError)
+
+			route := app.Group("/api")
+			NewSearchController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/search-indices/"+tc.index+"/reindex", nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
+
+func TestSearchController_Suggest(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		request        *request.SuggestRequest
+		expectedStatus string
+		expectedBody   *response.SuggestResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Suggestions for a partial name",
+			query:          "q=kangk&limit=3",
+			request:        &request.SuggestRequest{Query: "kangk", Limit: 3},
+			expectedStatus: "OK",
+			expectedBody: &response.SuggestResponse{
+				Products: []*response.SuggestionResponse{{ID: 1, Code: "ABCDEFGHIJ", Text: "Kangkung"}},
+			},
+			expectedCode:  http.StatusOK,
+			expectedError: nil,
+		},
+		{
+			name:           "[missing] Suggestions without a query",
+			query:          "limit=3",
+			request:        &request.SuggestRequest{Limit: 3},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'required' for 'Query' field"),
+		},
+		{
+			name:           "Service getting an error",
+			query:          "q=kangk",
+			request:        &request.SuggestRequest{Query: "kangk"},
+			expectedStatus: "getting an error",
+			expectedCode:   http.StatusInternalServerError,
+			expectedError:  errors.New("getting an error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.SearchServiceMock
+			svc.On("Suggest", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
+
+			route := app.Group("/api")
+			NewSearchController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/products/suggest?"+tc.query, nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			if strings.Contains(tc.name, "[missing]") {
+				var responseBody response.ErrorValidationResponse
+				err = json.NewDecoder(res.Body).Decode(&responseBody)
+				assert.Nil(t, err)
+
+				assert.Equal(t, responseBody.Code, tc.expectedCode)
+				assert.Equal(t, responseBody.Status, tc.expectedStatus)
+				assert.Equal(t, responseBody.Error[0].Value, tc.expectedError.Error())
+				return
+			}
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/service"
+)
+
+type SearchOutboxController struct {
+	SearchOutboxService service.SearchOutboxServiceContract
+}
+
+func NewSearchOutboxController(searchOutboxService service.SearchOutboxServiceContract, route fiber.Router) SearchOutboxController {
+	controller := SearchOutboxController{
+		SearchOutboxService: searchOutboxService,
+	}
+
+	outbox := route.Group("/search-outbox")
+	{
+		outbox.Get("/stats", controller.Stats)
+	}
+
+	return controller
+}
+
+func (controller *SearchOutboxController) Stats(ctx *fiber.Ctx) error {
+	stats, err := controller.SearchOutboxService.Stats(ctx.UserContext())
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", stats).Build()
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/http/middleware"
+	response "inventory-management/backend/internal/http/response"
+	service "inventory-management/backend/internal/service/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchOutboxController_Stats(t *testing.T) {
+	testCases := []struct {
+		name           string
+		expectedStatus string
+		expectedBody   *response.SearchOutboxStatsResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Search outbox is lagging behind",
+			expectedStatus: "OK",
+			expectedBody: &response.SearchOutboxStatsResponse{
+				Pending:    12,
+				Failing:    2,
+				LagSeconds: 42.5,
+				Relayed:    1024,
+				Failures:   7,
+			},
+			expectedCode:  http.StatusOK,
+			expectedError: nil,
+		},
+		{
+			name:           "Service getting an error",
+			expectedStatus: "getting an error",
+			expectedCode:   http.StatusInternalServerError,
+			expectedError:  errors.New("getting an error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.SearchOutboxServiceMock
+			svc.On("Stats", ctx).Return(tc.expectedBody, tc.expectedError)
+
+			route := app.Group("/api")
+			NewSearchOutboxController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/search-outbox/stats", nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/service"
+	"inventory-management/backend/util"
+)
+
+type SearchSynonymController struct {
+	SearchSynonymService service.SearchSynonymServiceContract
+}
+
+func NewSearchSynonymController(searchSynonymService service.SearchSynonymServiceContract, route fiber.Router) SearchSynonymController {
+	controller := SearchSynonymController{
+		SearchSynonymService: searchSynonymService,
+	}
+
+	synonym := route.Group("/search-synonyms")
+	{
+		synonym.Get("/", controller.FindAll)
+		synonym.Post("/", controller.Create)
+		synonym.Patch("/:id", controller.Update)
+		synonym.Delete("/:id", controller.Delete)
+	}
+
+	return controller
+}
+
+func (controller *SearchSynonymController) FindAll(ctx *fiber.Ctx) error {
+	synonyms, err := controller.SearchSynonymService.FindAll(ctx.UserContext())
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", synonyms).Build()
+}
+
+func (controller *SearchSynonymController) Create(ctx *fiber.Ctx) error {
+	var synonymRequest request.CreateSearchSynonymRequest
+	if err := ctx.BodyParser(&synonymRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(synonymRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	synonym, err := controller.SearchSynonymService.Create(ctx.UserContext(), &synonymRequest)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusCreated, "created", synonym).Build()
+}
+
+func (controller *SearchSynonymController) Update(ctx *fiber.Ctx) error {
+	var synonymRequest request.UpdateSearchSynonymRequest
+	if err := ctx.BodyParser(&synonymRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(synonymRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	synonymRequest.ID = int64(id)
+	synonym, err := controller.SearchSynonymService.Update(ctx.UserContext(), &synonymRequest)
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "updated", synonym).Build()
+}
+
+func (controller *SearchSynonymController) Delete(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	err = controller.SearchSynonymService.Delete(ctx.UserContext(), int64(id))
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "deleted", nil).Build()
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/http/middleware"
+	"inventory-management/backend/internal/http/request"
+	response "inventory-management/backend/internal/http/response"
+	service "inventory-management/backend/internal/service/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSearchSynonymController_Create(t *testing.T) {
+	testCases := []struct {
+		name           string
+		request        *request.CreateSearchSynonymRequest
+		expectedStatus string
+		expectedBody   *response.SearchSynonymResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Create a synonym group",
+			request:        &request.CreateSearchSynonymRequest{Words: []string{"kangkung", "water spinach"}},
+			expectedStatus: "created",
+			expectedBody:   &response.SearchSynonymResponse{ID: 1, Words: []string{"kangkung", "water spinach"}},
+			expectedCode:   http.StatusCreated,
+			expectedError:  nil,
+		},
+		{
+			name:           "[missing] Create a synonym group of a single word",
+			request:        &request.CreateSearchSynonymRequest{Words: []string{"kangkung"}},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'min' for 'Words' field"),
+		},
+		{
+			name:           "Service getting an error",
+			request:        &request.CreateSearchSynonymRequest{Words: []string{"kangkung", "water spinach"}},
+			expectedStatus: "getting an error",
+			expectedCode:   http.StatusInternalServerError,
+			expectedError:  errors.New("getting an error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.SearchSynonymServiceMock
+			svc.On("Create", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
+
+			route := app.Group("/api")
+			NewSearchSynonymController(&svc, route)
+
+			byteRequest, err := json.Marshal(tc.request)
+			assert.Nil(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/search-synonyms", bytes.NewReader(byteRequest))
+			req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			if strings.Contains(tc.name, "[missing]") {
+				var responseBody response.ErrorValidationResponse
+				err = json.NewDecoder(res.Body).Decode(&responseBody)
+				assert.Nil(t, err)
+
+				assert.Equal(t, responseBody.Code, tc.expectedCode)
+				assert.Equal(t, responseBody.Status, tc.expectedStatus)
+				assert.NotNil(t, responseBody.Error)
+				assert.Equal(t, responseBody.Error[0].Value, tc.expectedError.Error())
+				return
+			}
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
+
+func TestSearchSynonymController_Delete(t *testing.T) {
+	testCases := []struct {
+		name           string
+		id             string
+		expectedStatus string
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Delete a synonym group",
+			id:             "1",
+			expectedStatus: "deleted",
+			expectedCode:   http.StatusOK,
+			expectedError:  nil,
+		},
+		{
+			name:           "Synonym group does not exist",
+			id:             "1",
+			expectedStatus: response.ErrorNotFound,
+			expectedCode:   http.StatusNotFound,
+			expectedError:  errors.New(response.ErrorNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.SearchSynonymServiceMock
+			svc.On("Delete", ctx, int64(1)).Return(tc.expectedError)
+
+			route := app.Group("/api")
+			NewSearchSynonymController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/search-synonyms/"+tc.id, nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/service"
+	"inventory-management/backend/util"
+	"net/http"
+)
+
+type SettingController struct {
+	SettingService service.SettingServiceContract
+}
+
+func NewSettingController(settingService service.SettingServiceContract, route fiber.Router) SettingController {
+	controller := SettingController{
+		SettingService: settingService,
+	}
+
+	setting := route.Group("/settings")
+	{
+		setting.Get("/branding", controller.FindBranding)
+		setting.Put("/branding", controller.UpdateBranding)
+		setting.Get("/label-templates", controller.FindAllLabelTemplates)
+		setting.Put("/label-templates/:type", controller.UpdateLabelTemplate)
+		setting.Get("/numbering-sequences", controller.FindAllNumberingSequences)
+		setting.Put("/numbering-sequences/:entity", controller.UpdateNumberingSequence)
+	}
+
+	return controller
+}
+
+func (controller *SettingController) FindBranding(ctx *fiber.Ctx) error {
+	branding, err := controller.SettingService.FindBranding(ctx.UserContext())
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, http.StatusOK, "OK", branding).Build()
+}
+
+func (controller *SettingController) UpdateBranding(ctx *fiber.Ctx) error {
+	var brandingRequest request.UpdateBrandingSettingRequest
+	if err := ctx.BodyParser(&brandingRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(brandingRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	branding, err := controller.SettingService.UpdateBranding(ctx.UserContext(), &brandingRequest)
+	if err != nil {
+		if err.Error() == response.ErrorLogoImageType {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, http.StatusOK, "updated", branding).Build()
+}
+
+func (controller *SettingController) FindAllLabelTemplates(ctx *fiber.Ctx) error {
+	labelTemplates, err := controller.SettingService.FindAllLabelTemplates(ctx.UserContext())
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, http.StatusOK, "OK", labelTemplates).Build()
+}
+
+func (controller *SettingController) UpdateLabelTemplate(ctx *fiber.Ctx) error {
+	var labelTemplateRequest request.UpdateLabelTemplateRequest
+	if err := ctx.BodyParser(&labelTemplateRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(labelTemplateRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	labelTemplateRequest.LabelType = ctx.Params("type")
+	labelTemplate, err := controller.SettingService.UpdateLabelTemplate(ctx.UserContext(), &labelTemplateRequest)
+	if err != nil {
+		switch err.Error() {
+		case response.ErrorNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case response.ErrorLabelTemplateInvalid:
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, http.StatusOK, "updated", labelTemplate).Build()
+}
+
+func (controller *SettingController) FindAllNumberingSequences(ctx *fiber.Ctx) error {
+	sequences, err := controller.SettingService.FindAllNumberingSequences(ctx.UserContext())
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, http.StatusOK, "OK", sequences).Build()
+}
+
+func (controller *SettingController) UpdateNumberingSequence(ctx *fiber.Ctx) error {
+	var sequenceRequest request.UpdateNumberingSequenceRequest
+	if err := ctx.BodyParser(&sequenceRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(sequenceRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	sequenceRequest.Entity = ctx.Params("entity")
+	sequence, err := controller.SettingService.UpdateNumberingSequence(ctx.UserContext(), &sequenceRequest)
+	if err != nil {
+		switch err.Error() {
+		case response.ErrorNotFound:
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case response.ErrorNumberingSequenceInvalid:
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case response.ErrorNumberingSequenceTaken:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, http.StatusOK, "updated", sequence).Build()
+}
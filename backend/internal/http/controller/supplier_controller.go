@@ -4,6 +4,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"inventory-management/backend/internal/http/request"
 	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
 	"inventory-management/backend/internal/service"
 	"inventory-management/backend/util"
 	"net/http"
@@ -11,11 +12,13 @@ import (
 
 type SupplierController struct {
 	SupplierService service.SupplierServiceContract
+	ExportService   service.ExportServiceContract
 }
 
-func NewSupplierController(supplierService service.SupplierServiceContract, route fiber.Router) SupplierController {
+func NewSupplierController(supplierService service.SupplierServiceContract, exportService service.ExportServiceContract, route fiber.Router) SupplierController {
 	controller := SupplierController{
 		SupplierService: supplierService,
+		ExportService:   exportService,
 	}
 
 	supplier := route.Group("/suppliers")
@@ -25,31 +28,62 @@ func NewSupplierController(supplierService service.SupplierServiceContract, rout
 		supplier.Post("/", controller.Create)
 		supplier.Patch("/:code", controller.Update)
 		supplier.Delete("/:code", controller.Delete)
+		supplier.Post("/:code/restore", controller.Restore)
+		supplier.Post("/:code/purge", controller.Purge)
 	}
 
 	return controller
 }
 
 func (controller *SupplierController) FindAll(ctx *fiber.Ctx) error {
+	listQuery, errValidate := parseListQuery(ctx, model.SupplierListSchema)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	if ExportRequested(ctx) {
+		return exportList(ctx, controller.ExportService, model.ExportEntitySuppliers, queryValues(ctx), listQuery)
+	}
+
 	currPage := ctx.QueryInt("page", 1)
 	if currPage <= 0 {
 		currPage = 1
 	}
-	limit := ctx.QueryInt("limit", 10)
+	limit, errValidate := listLimit(ctx)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	if cursorPaged(ctx) {
+		suppliers, page, err := controller.SupplierService.FindAllByCursor(ctx.UserContext(), listQuery, ctx.Query("cursor"), limit)
+		if err != nil {
+			if err.Error() == response.ErrorInvalidCursor {
+				return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+
+		cursor, err := cursorPagination(ctx, listQuery, page, controller.SupplierService.CountAll)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+
+		return response.ReturnJSON(ctx, http.StatusOK, "OK", suppliers).WithCursor(cursor).WithFields(listQuery.Fields).Build()
+	}
 
-	totalRecords, err := controller.SupplierService.CountAll(ctx.UserContext())
+	totalRecords, err := controller.SupplierService.CountAll(ctx.UserContext(), listQuery)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
 	pagination := util.CreatePagination(currPage, limit, totalRecords)
 	offset := (currPage - 1) * limit
-	suppliers, err := controller.SupplierService.FindAll(ctx.UserContext(), offset, limit)
+	suppliers, err := controller.SupplierService.FindAll(ctx.UserContext(), listQuery, offset, limit)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	return response.ReturnJSON(ctx, http.StatusOK, "OK", suppliers).WithPagination(&pagination).Build()
+	return response.ReturnJSON(ctx, http.StatusOK, "OK", suppliers).WithPagination(&pagination).WithFields(listQuery.Fields).Build()
 }
 
 func (controller *SupplierController) FindByCode(ctx *fiber.Ctx) error {
@@ -62,7 +96,7 @@ func (controller *SupplierController) FindByCode(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	return response.ReturnJSON(ctx, http.StatusOK, "OK", supplier).Build()
+	return response.ReturnJSON(ctx, http.StatusOK, "OK", supplier).WithETag(util.VersionETag(supplier.Version)).Build()
 }
 
 func (controller *SupplierController) Create(ctx *fiber.Ctx) error {
@@ -100,10 +134,13 @@ func (controller *SupplierController) Update(ctx *fiber.Ctx) error {
 		if err.Error() == response.ErrorNotFound {
 			return fiber.NewError(fiber.StatusNotFound, err.Error())
 		}
+		if err.Error() == response.ErrorVersionMismatch {
+			return preconditionFailed(ctx, controller.current(ctx, code))
+		}
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	return response.ReturnJSON(ctx, http.StatusOK, "updated", supplier).Build()
+	return response.ReturnJSON(ctx, http.StatusOK, "updated", supplier).WithETag(util.VersionETag(supplier.Version)).Build()
 }
 
 func (controller *SupplierController) Delete(ctx *fiber.Ctx) error {
@@ -113,8 +150,50 @@ func (controller *SupplierController) Delete(ctx *fiber.Ctx) error {
 		if err.Error() == response.ErrorNotFound {
 			return fiber.NewError(fiber.StatusNotFound, err.Error())
 		}
+		if err.Error() == response.ErrorVersionMismatch {
+			return preconditionFailed(ctx, controller.current(ctx, code))
+		}
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
 	return response.ReturnJSON(ctx, http.StatusOK, "deleted", nil).Build()
 }
+
+func (controller *SupplierController) Restore(ctx *fiber.Ctx) error {
+	supplier, err := controller.SupplierService.Restore(ctx.UserContext(), ctx.Params("code"))
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, http.StatusOK, "restored", supplier).WithETag(util.VersionETag(supplier.Version)).Build()
+}
+
+func (controller *SupplierController) Purge(ctx *fiber.Ctx) error {
+	err := controller.SupplierService.Purge(ctx.UserContext(), ctx.Params("code"))
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		if err.Error() == response.ErrorRecordReferenced {
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, http.StatusOK, "purged", nil).Build()
+}
+
+// current finds the supplier as it is now.
+func (controller *SupplierController) current(ctx *fiber.Ctx, code string) func() (interface{}, int64, error) {
+	return func() (interface{}, int64, error) {
+		supplier, err := controller.SupplierService.FindByCode(ctx.UserContext(), code)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return supplier, supplier.Version, nil
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"inventory-management/backend/internal/http/request"
 	response "inventory-management/backend/internal/http/response"
 	service "inventory-management/backend/internal/service/mock"
+	"inventory-management/backend/util"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -75,11 +76,11 @@ func TestSupplierController_FindAll(t *testing.T) {
 			ctx := context.Background()
 
 			var svc service.SupplierServiceMock
-			svc.On("CountAll", ctx).Return(int64(2), nil)
-			svc.On("FindAll", ctx, 0, 10).Return(tc.expectedBody, tc.expectedError)
+			svc.On("CountAll", ctx, &util.ListQuery{}).Return(int64(2), nil)
+			svc.On("FindAll", ctx, &util.ListQuery{}, 0, 10).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewSupplierController(&svc, route)
+			ctrl := NewSupplierController(&svc, nil, route)
 			app.Get("/api/suppliers", ctrl.FindAll)
 
 			req := httptest.NewRequest(http.MethodGet, "/api/suppliers", nil)
@@ -98,6 +99,80 @@ func TestSupplierController_FindAll(t *testing.T) {
 	}
 }
 
+func TestSupplierController_FindAllListQuery(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		listQuery      *util.ListQuery
+		expectedStatus string
+		expectedData   interface{}
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:  "Filtered and sorted suppliers with some of their fields",
+			query: "filter[name][like]=widdy&sort=-name&fields=code,name",
+			listQuery: &util.ListQuery{
+				Filters: []*util.ListFilter{{Field: "name", Operator: util.ListOperatorLike, Values: []interface{}{"widdy"}}},
+				Sort:    []*util.ListSort{{Field: "name", Descending: true}},
+				Fields:  []string{"code", "name"},
+			},
+			expectedStatus: "OK",
+			expectedData: []interface{}{
+				map[string]interface{}{"code": "KKSJIDNA", "name": "Widdy Arfiansyah"},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:           "[missing] Suppliers filtered by a field out of the whitelist",
+			query:          "filter[tenant_id]=2",
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'oneof' for 'filter[tenant_id]' field"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.SupplierServiceMock
+			svc.On("CountAll", ctx, tc.listQuery).Return(int64(1), nil)
+			svc.On("FindAll", ctx, tc.listQuery, 0, 10).Return([]*response.SupplierResponse{
+				{ID: 1, Code: "KKSJIDNA", Name: "Widdy Arfiansyah", Address: "Bhayangkara", Phone: "089911182399"},
+			}, nil)
+
+			route := app.Group("/api")
+			NewSupplierController(&svc, nil, route)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/suppliers?"+tc.query, nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			if strings.Contains(tc.name, "[missing]") {
+				var responseBody response.ErrorValidationResponse
+				err = json.NewDecoder(res.Body).Decode(&responseBody)
+				assert.Nil(t, err)
+
+				assert.Equal(t, responseBody.Code, tc.expectedCode)
+				assert.Equal(t, responseBody.Status, tc.expectedStatus)
+				assert.Equal(t, responseBody.Error[0].Value, tc.expectedError.Error())
+				return
+			}
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+			assert.Equal(t, tc.expectedData, responseBody.Data)
+		})
+	}
+}
+
 func TestSupplierController_FindByCode(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -151,7 +226,7 @@ func TestSupplierController_FindByCode(t *testing.T) {
 			svc.On("FindByCode", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewSupplierController(&svc, route)
+			ctrl := NewSupplierController(&svc, nil, route)
 			app.Get("/api/suppliers/:code", ctrl.FindByCode)
 
 			url := fmt.Sprintf("/api/suppliers/%s", tc.request)
@@ -317,7 +392,7 @@ func TestSupplierController_Create(t *testing.T) {
 			svc.On("Create", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewSupplierController(&svc, route)
+			ctrl := NewSupplierController(&svc, nil, route)
 			app.Post("/api/suppliers", ctrl.Create)
 
 			byteRequest, err := json.Marshal(tc.request)
@@ -508,7 +583,7 @@ func TestSupplierController_Update(t *testing.T) {
 			svc.On("Update", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewSupplierController(&svc, route)
+			ctrl := NewSupplierController(&svc, nil, route)
 			app.Patch("/api/suppliers/:code", ctrl.Update)
 
 			byteRequest, err := json.Marshal(tc.request)
@@ -585,7 +660,7 @@ func TestSupplierController_Delete(t *testing.T) {
 			svc.On("Delete", ctx, tc.request).Return(tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewSupplierController(&svc, route)
+			ctrl := NewSupplierController(&svc, nil, route)
 			app.Delete("/api/suppliers/:code", ctrl.Delete)
 
 			url := fmt.Sprintf("/api/suppliers/%s", tc.request)
@@ -604,3 +679,117 @@ func TestSupplierController_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestSupplierController_Restore(t *testing.T) {
+	testCases := []struct {
+		name           string
+		request        string
+		expectedSvc    *response.SupplierResponse
+		expectedStatus string
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Deleted supplier is restored",
+			request:        "KKSJIDNA",
+			expectedSvc:    &response.SupplierResponse{ID: 1, Code: "KKSJIDNA", Name: "Widdy Arfiansyah", Version: 2},
+			expectedStatus: "restored",
+			expectedCode:   http.StatusOK,
+		},
+		{
+			name:           "No deleted supplier with given Code",
+			request:        "KKSJIDNA",
+			expectedStatus: response.ErrorNotFound,
+			expectedCode:   http.StatusNotFound,
+			expectedError:  errors.New(response.ErrorNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.SupplierServiceMock
+			svc.On("Restore", ctx, tc.request).Return(tc.expectedSvc, tc.expectedError)
+
+			route := app.Group("/api")
+			NewSupplierController(&svc, nil, route)
+
+			url := fmt.Sprintf("/api/suppliers/%s/restore", tc.request)
+			req := httptest.NewRequest(http.MethodPost, url, nil)
+
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.expectedCode, responseBody.Code)
+			assert.Contains(t, responseBody.Status, tc.expectedStatus)
+			if tc.expectedSvc != nil {
+				assert.Equal(t, util.VersionETag(tc.expectedSvc.Version), res.Header.Get(fiber.HeaderETag))
+			}
+		})
+	}
+}
+
+func TestSupplierController_Purge(t *testing.T) {
+	testCases := []struct {
+		name           string
+		request        string
+		expectedStatus string
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Supplier without transactions is purged",
+			request:        "KKSJIDNA",
+			expectedStatus: "purged",
+			expectedCode:   http.StatusOK,
+		},
+		{
+			name:           "Supplier referenced by transactions is kept",
+			request:        "KKSJIDNA",
+			expectedStatus: response.ErrorRecordReferenced,
+			expectedCode:   http.StatusConflict,
+			expectedError:  errors.New(response.ErrorRecordReferenced),
+		},
+		{
+			name:           "Supplier doesnt exists with given Code",
+			request:        "KKSJIDNA",
+			expectedStatus: response.ErrorNotFound,
+			expectedCode:   http.StatusNotFound,
+			expectedError:  errors.New(response.ErrorNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.SupplierServiceMock
+			svc.On("Purge", ctx, tc.request).Return(tc.expectedError)
+
+			route := app.Group("/api")
+			NewSupplierController(&svc, nil, route)
+
+			url := fmt.Sprintf("/api/suppliers/%s/purge", tc.request)
+			req := httptest.NewRequest(http.MethodPost, url, nil)
+
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.expectedCode, responseBody.Code)
+			assert.Contains(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
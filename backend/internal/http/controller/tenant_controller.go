@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/service"
+	"inventory-management/backend/util"
+)
+
+type TenantController struct {
+	TenantService service.TenantServiceContract
+}
+
+func NewTenantController(tenantService service.TenantServiceContract, route fiber.Router) TenantController {
+	controller := TenantController{
+		TenantService: tenantService,
+	}
+
+	tenant := route.Group("/tenants")
+	{
+		tenant.Get("/", controller.FindAll)
+		tenant.Get("/:id", controller.FindByID)
+		tenant.Post("/", controller.Create)
+		tenant.Patch("/:id", controller.Update)
+		tenant.Post("/:id/api-key", controller.RotateApiKey)
+	}
+
+	return controller
+}
+
+func (controller *TenantController) FindAll(ctx *fiber.Ctx) error {
+	currPage := ctx.QueryInt("page", 1)
+	if currPage <= 0 {
+		currPage = 1
+	}
+	limit, errValidate := listLimit(ctx)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	totalRecords, err := controller.TenantService.CountAll(ctx.UserContext())
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	pagination := util.CreatePagination(currPage, limit, totalRecords)
+	offset := (currPage - 1) * limit
+	tenants, err := controller.TenantService.FindAll(ctx.UserContext(), offset, limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", tenants).WithPagination(&pagination).Build()
+}
+
+func (controller *TenantController) FindByID(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	tenant, err := controller.TenantService.FindByID(ctx.UserContext(), int64(id))
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", tenant).Build()
+}
+
+func (controller *TenantController) Create(ctx *fiber.Ctx) error {
+	var tenantRequest request.CreateTenantRequest
+	if err := ctx.BodyParser(&tenantRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(tenantRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	tenant, err := controller.TenantService.Create(ctx.UserContext(), &tenantRequest)
+	if err != nil {
+		if err.Error() == response.ErrorTenantCodeExists || err.Error() == response.ErrorUsernameExists {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusCreated, "created", tenant).Build()
+}
+
+func (controller *TenantController) Update(ctx *fiber.Ctx) error {
+	var tenantRequest request.UpdateTenantRequest
+	if err := ctx.BodyParser(&tenantRequest); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if errValidate := util.ValidateStruct(tenantRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	tenantRequest.ID = int64(id)
+	tenant, err := controller.TenantService.Update(ctx.UserContext(), &tenantRequest)
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "updated", tenant).Build()
+}
+
+func (controller *TenantController) RotateApiKey(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	tenant, err := controller.TenantService.RotateApiKey(ctx.UserContext(), int64(id))
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "updated", tenant).Build()
+}
@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/http/middleware"
+	"inventory-management/backend/internal/http/request"
+	response "inventory-management/backend/internal/http/response"
+	service "inventory-management/backend/internal/service/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTenantController_Create(t *testing.T) {
+	testCases := []struct {
+		name           string
+		request        *request.CreateTenantRequest
+		expectedStatus string
+		expectedBody   *response.TenantResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name: "Create tenant with required fields",
+			request: &request.CreateTenantRequest{
+				Code:          "widdy",
+				Name:          "Widdy Company",
+				AdminName:     "Widdy Arfiansyah",
+				AdminUsername: "wdyarfn",
+				AdminPassword: "12345678910",
+			},
+			expectedStatus: "created",
+			expectedBody: &response.TenantResponse{
+				ID:     2,
+				Code:   "widdy",
+				Name:   "Widdy Company",
+				ApiKey: "key",
+				Admin:  &response.UserResponse{ID: 1, Username: "wdyarfn"},
+			},
+			expectedCode:  http.StatusCreated,
+			expectedError: nil,
+		},
+		{
+			name: "[missing] Create tenant with does not meet the validation requirements with the 'alphanum' tag at code field.",
+			request: &request.CreateTenantRequest{
+				Code:          "widdy company",
+				Name:          "Widdy Company",
+				AdminName:     "Widdy Arfiansyah",
+				AdminUsername: "wdyarfn",
+				AdminPassword: "12345678910",
+			},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'alphanum' for 'Code' field"),
+		},
+		{
+			name: "[missing] Create tenant with missing admin username field",
+			request: &request.CreateTenantRequest{
+				Code:          "widdy",
+				Name:          "Widdy Company",
+				AdminName:     "Widdy Arfiansyah",
+				AdminPassword: "12345678910",
+			},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'required' for 'AdminUsername' field"),
+		},
+		{
+			name: "Create tenant with an existing code",
+			request: &request.CreateTenantRequest{
+				Code:          "widdy",
+				Name:          "Widdy Company",
+				AdminName:     "Widdy Arfiansyah",
+				AdminUsername: "wdyarfn",
+				AdminPassword: "12345678910",
+			},
+			expectedStatus: response.ErrorTenantCodeExists,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New(response.ErrorTenantCodeExists),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.TenantServiceMock
+			svc.On("Create", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
+
+			route := app.Group("/api")
+			NewTenantController(&svc, route)
+
+			byteRequest, err := json.Marshal(tc.request)
+			assert.Nil(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/tenants", bytes.NewReader(byteRequest))
+			req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			if strings.Contains(tc.name, "[missing]") {
+				var responseBody response.ErrorValidationResponse
+				err = json.NewDecoder(res.Body).Decode(&responseBody)
+				assert.Nil(t, err)
+
+				assert.Equal(t, responseBody.Code, tc.expectedCode)
+				assert.Equal(t, responseBody.Status, tc.expectedStatus)
+				assert.NotNil(t, responseBody.Error)
+				assert.Equal(t, responseBody.Error[0].Value, tc.expectedError.Error())
+				return
+			}
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
+
+func TestTenantController_RotateApiKey(t *testing.T) {
+	testCases := []struct {
+		name           string
+		expectedStatus string
+		expectedBody   *response.TenantResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Rotate the API key of a tenant",
+			expectedStatus: "updated",
+			expectedBody:   &response.TenantResponse{ID: 2, Code: "widdy", ApiKey: "key"},
+			expectedCode:   http.StatusOK,
+			expectedError:  nil,
+		},
+		{
+			name:           "Rotate the API key of an unknown tenant",
+			expectedStatus: response.ErrorNotFound,
+			expectedCode:   http.StatusNotFound,
+			expectedError:  errors.New(response.ErrorNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.TenantServiceMock
+			svc.On("RotateApiKey", ctx, int64(2)).Return(tc.expectedBody, tc.expectedError)
+
+			route := app.Group("/api")
+			NewTenantController(&svc, route)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/tenants/2/api-key", nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
@@ -4,18 +4,22 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"inventory-management/backend/internal/http/request"
 	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
 	"inventory-management/backend/internal/service"
 	"inventory-management/backend/util"
 	"net/http"
+	"net/url"
 )
 
 type TransactionController struct {
 	TransactionService service.TransactionServiceContract
+	ExportService      service.ExportServiceContract
 }
 
-func NewTransactionController(transactionService service.TransactionServiceContract, route fiber.Router) TransactionController {
+func NewTransactionController(transactionService service.TransactionServiceContract, exportService service.ExportServiceContract, route fiber.Router) TransactionController {
 	controller := TransactionController{
 		TransactionService: transactionService,
+		ExportService:      exportService,
 	}
 
 	transaction := route.Group("/transactions")
@@ -28,57 +32,85 @@ func NewTransactionController(transactionService service.TransactionServiceContr
 		transaction.Get("/:code/supplier", controller.FindAllBySupplierCode)
 		transaction.Get("/:code/customer", controller.FindAllByCustomerCode)
 		transaction.Post("/transfer", controller.TransferStock)
+		transaction.Post("/batch", controller.CreateBatch)
 	}
 
 	return controller
 }
 
 func (controller *TransactionController) FindAll(ctx *fiber.Ctx) error {
+	return controller.findAll(ctx, queryValues(ctx))
+}
+
+func (controller *TransactionController) FindAllBySupplierCode(ctx *fiber.Ctx) error {
+	values := queryValues(ctx)
+	values.Add("filter[supplier_code]", ctx.Params("code"))
+	return controller.findAll(ctx, values)
+}
+
+func (controller *TransactionController) FindAllByCustomerCode(ctx *fiber.Ctx) error {
+	values := queryValues(ctx)
+	values.Add("filter[customer_code]", ctx.Params("code"))
+	return controller.findAll(ctx, values)
+}
+
+// findAll pages the transactions of the list query in values, with the totals of every
+// transaction it matches. The routes of a supplier or a customer add their filter to values, so
+// it is kept by exports too.
+func (controller *TransactionController) findAll(ctx *fiber.Ctx, values url.Values) error {
+	listQuery, errValidate := util.ParseListQuery(values, model.TransactionListSchema)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	if ExportRequested(ctx) {
+		return exportList(ctx, controller.ExportService, model.ExportEntityTransactions, values, listQuery)
+	}
+
 	currPage := ctx.QueryInt("page", 1)
 	if currPage <= 0 {
 		currPage = 1
 	}
-	limit := ctx.QueryInt("limit", 10)
-
-	totalRecords, err := controller.TransactionService.CountAll(ctx.UserContext())
-	if err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	limit, errValidate := listLimit(ctx)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
 	}
 
-	pagination := util.CreatePagination(currPage, limit, totalRecords)
-	offset := (currPage - 1) * limit
-	transactions, err := controller.TransactionService.FindAll(ctx.UserContext(), offset, limit)
+	summary, err := controller.TransactionService.Summary(ctx.UserContext(), listQuery)
 	if err != nil {
 		return fiber.NewError(http.StatusInternalServerError, err.Error())
 	}
 
-	return response.ReturnJSON(ctx, http.StatusOK, "OK", transactions).WithPagination(&pagination).Build()
-}
+	if cursorPaged(ctx) {
+		transactions, page, err := controller.TransactionService.FindAllByCursor(ctx.UserContext(), listQuery, ctx.Query("cursor"), limit)
+		if err != nil {
+			if err.Error() == response.ErrorInvalidCursor {
+				return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
 
-func (controller *TransactionController) FindAllBySupplierCode(ctx *fiber.Ctx) error {
-	code := ctx.Params("code")
-	transactions, err := controller.TransactionService.FindAllBySupplierCode(ctx.UserContext(), code)
-	if err != nil {
-		if err.Error() == response.ErrorNotFound {
-			return fiber.NewError(http.StatusNotFound, err.Error())
+		cursor, err := cursorPagination(ctx, listQuery, page, controller.TransactionService.CountAll)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 		}
-		return fiber.NewError(http.StatusInternalServerError, err.Error())
+
+		return response.ReturnJSON(ctx, http.StatusOK, "OK", transactions).WithCursor(cursor).WithSummary(summary).WithFields(listQuery.Fields).Build()
 	}
 
-	return response.ReturnJSON(ctx, http.StatusOK, "OK", transactions).Build()
-}
+	totalRecords, err := controller.TransactionService.CountAll(ctx.UserContext(), listQuery)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
 
-func (controller *TransactionController) FindAllByCustomerCode(ctx *fiber.Ctx) error {
-	code := ctx.Params("code")
-	transactions, err := controller.TransactionService.FindAllByCustomerCode(ctx.UserContext(), code)
+	pagination := util.CreatePagination(currPage, limit, totalRecords)
+	offset := (currPage - 1) * limit
+	transactions, err := controller.TransactionService.FindAll(ctx.UserContext(), listQuery, offset, limit)
 	if err != nil {
-		if err.Error() == response.ErrorNotFound {
-			return fiber.NewError(http.StatusNotFound, err.Error())
-		}
 		return fiber.NewError(http.StatusInternalServerError, err.Error())
 	}
 
-	return response.ReturnJSON(ctx, http.StatusOK, "OK", transactions).Build()
+	return response.ReturnJSON(ctx, http.StatusOK, "OK", transactions).WithPagination(&pagination).WithSummary(summary).WithFields(listQuery.Fields).Build()
 }
 
 func (controller *TransactionController) FindByCode(ctx *fiber.Ctx) error {
@@ -91,7 +123,7 @@ func (controller *TransactionController) FindByCode(ctx *fiber.Ctx) error {
 		return fiber.NewError(http.StatusInternalServerError, err.Error())
 	}
 
-	return response.ReturnJSON(ctx, http.StatusOK, "OK", transaction).Build()
+	return response.ReturnJSON(ctx, http.StatusOK, "OK", transaction).WithETag(util.VersionETag(transaction.Version)).Build()
 }
 
 func (controller *TransactionController) Create(ctx *fiber.Ctx) error {
@@ -139,10 +171,13 @@ func (controller *TransactionController) Update(ctx *fiber.Ctx) error {
 		if err.Error() == response.ErrorUpdateTransactionTypeTransfer {
 			return fiber.NewError(http.StatusBadRequest, err.Error())
 		}
+		if err.Error() == response.ErrorVersionMismatch {
+			return preconditionFailed(ctx, controller.current(ctx, code))
+		}
 		return fiber.NewError(http.StatusInternalServerError, err.Error())
 	}
 
-	return response.ReturnJSON(ctx, http.StatusOK, "updated", transaction).Build()
+	return response.ReturnJSON(ctx, http.StatusOK, "updated", transaction).WithETag(util.VersionETag(transaction.Version)).Build()
 }
 
 func (controller *TransactionController) Delete(ctx *fiber.Ctx) error {
@@ -152,6 +187,9 @@ func (controller *TransactionController) Delete(ctx *fiber.Ctx) error {
 		if err.Error() == response.ErrorNotFound {
 			return fiber.NewError(http.StatusNotFound, err.Error())
 		}
+		if err.Error() == response.ErrorVersionMismatch {
+			return preconditionFailed(ctx, controller.current(ctx, code))
+		}
 		return fiber.NewError(http.StatusInternalServerError, err.Error())
 	}
 
@@ -187,3 +225,47 @@ func (controller *TransactionController) TransferStock(ctx *fiber.Ctx) error {
 
 	return response.ReturnJSON(ctx, http.StatusCreated, "transferred", transaction).Build()
 }
+
+// CreateBatch posts the lines of a stock movement at once. The errors of every line are reported
+// when one is invalid, and no line is posted then.
+func (controller *TransactionController) CreateBatch(ctx *fiber.Ctx) error {
+	var batchRequest request.CreateTransactionBatchRequest
+	err := ctx.BodyParser(&batchRequest)
+	if err != nil {
+		return fiber.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	errValidation := util.ValidateStruct(batchRequest)
+	if errValidation != nil {
+		return response.ReturnErrorValidation(ctx, errValidation)
+	}
+
+	result, err := controller.TransactionService.CreateBatch(ctx.UserContext(), &batchRequest)
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(http.StatusNotFound, err.Error())
+		}
+		if err.Error() == response.ErrorTransferStockDifferentProduct || err.Error() == response.ErrorStockNotEnough {
+			return fiber.NewError(http.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(http.StatusInternalServerError, err.Error())
+	}
+
+	if len(result.Errors) > 0 {
+		return response.ReturnJSON(ctx, http.StatusBadRequest, response.ErrorValidation, result).Build()
+	}
+
+	return response.ReturnJSON(ctx, http.StatusCreated, "created", result).Build()
+}
+
+// current finds the transaction as it is now.
+func (controller *TransactionController) current(ctx *fiber.Ctx, code string) func() (interface{}, int64, error) {
+	return func() (interface{}, int64, error) {
+		transaction, err := controller.TransactionService.FindByCode(ctx.UserContext(), code)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return transaction, transaction.Version, nil
+	}
+}
@@ -11,12 +11,14 @@ import (
 	"inventory-management/backend/internal/http/middleware"
 	"inventory-management/backend/internal/http/request"
 	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
 	service "inventory-management/backend/internal/service/mock"
 	"inventory-management/backend/util"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestTransactionController_FindAll(t *testing.T) {
@@ -82,11 +84,12 @@ func TestTransactionController_FindAll(t *testing.T) {
 			ctx := context.Background()
 
 			var svc service.TransactionServiceMock
-			svc.On("CountAll", ctx).Return(int64(2), nil)
-			svc.On("FindAll", ctx, 0, 10).Return(tc.expectedBody, tc.expectedError)
+			svc.On("Summary", ctx, &util.ListQuery{}).Return(&response.TransactionSummaryResponse{In: 23, Out: 3, UnitMassAcronym: "kg"}, nil)
+			svc.On("CountAll", ctx, &util.ListQuery{}).Return(int64(2), nil)
+			svc.On("FindAll", ctx, &util.ListQuery{}, 0, 10).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewTransactionController(&svc, route)
+			ctrl := NewTransactionController(&svc, nil, route)
 			app.Get("/api/transactions", ctrl.FindAll)
 
 			req := httptest.NewRequest(http.MethodGet, "/api/transactions", nil)
@@ -105,6 +108,196 @@ func TestTransactionController_FindAll(t *testing.T) {
 	}
 }
 
+func TestTransactionController_FindAllFiltered(t *testing.T) {
+	app := fiber.New(middleware.FiberConfig())
+
+	ctx := context.Background()
+	from := time.Date(2021, 1, 1, 0, 0, 0, 0, time.Local)
+	listQuery := &util.ListQuery{
+		Filters: []*util.ListFilter{
+			{Field: "created_at", Operator: util.ListOperatorGte, Values: []interface{}{from}, Day: true},
+			{Field: "product_code", Operator: util.ListOperatorEq, Values: []interface{}{"PRD001"}, Expression: model.TransactionListSchema.Expressions["product_code"]},
+			{Field: "quantity", Operator: util.ListOperatorLte, Values: []interface{}{float64(10)}},
+			{Field: "type", Operator: util.ListOperatorEq, Values: []interface{}{"IN"}},
+		},
+		Sort: []*util.ListSort{{Field: "quantity", Descending: true}},
+	}
+	summary := &response.TransactionSummaryResponse{In: 23.5, UnitMassAcronym: "kg"}
+
+	var svc service.TransactionServiceMock
+	svc.On("Summary", ctx, listQuery).Return(summary, nil)
+	svc.On("CountAll", ctx, listQuery).Return(int64(12), nil)
+	svc.On("FindAll", ctx, listQuery, 10, 10).Return([]*response.TransactionResponse{
+		{ID: 1, Code: "WDWDARFSYH", ProductQualityID: 1, Quantity: 10, Type: "IN", UnitMassAcronym: "kg", CreatedAt: "2021-01-01 07:00:00", UpdatedAt: "2021-01-01 07:00:00"},
+	}, nil)
+
+	route := app.Group("/api")
+	NewTransactionController(&svc, nil, route)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/transactions?page=2&filter[created_at][gte]=2021-01-01&filter[type]=IN&filter[product_code]=PRD001&filter[quantity][lte]=10&sort=-quantity", nil)
+	res, err := app.Test(req, -1)
+	assert.Nil(t, err)
+
+	var responseBody response.ApiResponse
+	err = json.NewDecoder(res.Body).Decode(&responseBody)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusOK, responseBody.Code)
+	assert.Equal(t, &response.Pagination{TotalRecords: 12, CurrentPage: 2, TotalPages: 2}, responseBody.Pagination)
+	assert.Equal(t, map[string]interface{}{"in": 23.5, "out": float64(0), "unit_mass_acronym": "kg"}, responseBody.Summary)
+}
+
+func TestTransactionController_FindAllByCursor(t *testing.T) {
+	transactions := []*response.TransactionResponse{
+		{ID: 2, Code: "ARFNSYH", ProductQualityID: 1, Quantity: 3, Type: "OUT", UnitMassAcronym: "kg", CreatedAt: "2021-01-01 07:00:00", UpdatedAt: "2021-01-01 07:00:00"},
+	}
+
+	testCases := []struct {
+		name           string
+		query          string
+		cursor         string
+		count          bool
+		expectedStatus string
+		expectedCursor *response.CursorPagination
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "First page with a link to the next one",
+			query:          "cursor=&limit=1",
+			expectedStatus: "OK",
+			expectedCursor: &response.CursorPagination{
+				Next:       "/api/transactions?cursor=next&limit=1",
+				NextCursor: "next",
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:           "Middle page with the total counted",
+			query:          "cursor=current&limit=1&count=true",
+			cursor:         "current",
+			count:          true,
+			expectedStatus: "OK",
+			expectedCursor: &response.CursorPagination{
+				Next:         "/api/transactions?count=true&cursor=next&limit=1",
+				Prev:         "/api/transactions?count=true&cursor=prev&limit=1",
+				NextCursor:   "next",
+				PrevCursor:   "prev",
+				TotalRecords: util.ToPointerInt(2),
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:           "Cursor that does not match the sort",
+			query:          "cursor=current&limit=1",
+			cursor:         "current",
+			expectedStatus: response.ErrorInvalidCursor,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New(response.ErrorInvalidCursor),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			page := &util.CursorPage{Next: "next"}
+			if tc.cursor != "" {
+				page.Prev = "prev"
+			}
+
+			var svc service.TransactionServiceMock
+			svc.On("Summary", ctx, &util.ListQuery{}).Return(&response.TransactionSummaryResponse{In: 23, Out: 3, UnitMassAcronym: "kg"}, nil)
+			svc.On("CountAll", ctx, &util.ListQuery{}).Return(int64(2), nil)
+			if tc.expectedError != nil {
+				svc.On("FindAllByCursor", ctx, &util.ListQuery{}, tc.cursor, 1).Return(nil, nil, tc.expectedError)
+			} else {
+				svc.On("FindAllByCursor", ctx, &util.ListQuery{}, tc.cursor, 1).Return(transactions, page, nil)
+			}
+
+			route := app.Group("/api")
+			NewTransactionController(&svc, nil, route)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/transactions?"+tc.query, nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.expectedCode, responseBody.Code)
+			assert.Equal(t, tc.expectedStatus, responseBody.Status)
+			assert.Equal(t, tc.expectedCursor, responseBody.Cursor)
+			assert.Nil(t, responseBody.Pagination)
+			if !tc.count {
+				svc.AssertNotCalled(t, "CountAll", ctx, &util.ListQuery{})
+			}
+		})
+	}
+}
+
+func TestTransactionController_FindAllByCursorLimit(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		expectedLimit  int
+		expectedStatus string
+		expectedCode   int
+	}{
+		{
+			name:           "Limit above the maximum is cut to it",
+			query:          "cursor=&limit=1000",
+			expectedLimit:  100,
+			expectedStatus: "OK",
+			expectedCode:   http.StatusOK,
+		},
+		{
+			name:           "Negative limit is refused",
+			query:          "cursor=&limit=-1",
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+		},
+		{
+			name:           "Zero limit is refused",
+			query:          "limit=0",
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.TransactionServiceMock
+			svc.On("Summary", ctx, &util.ListQuery{}).Return(&response.TransactionSummaryResponse{}, nil)
+			svc.On("FindAllByCursor", ctx, &util.ListQuery{}, "", tc.expectedLimit).Return([]*response.TransactionResponse{}, &util.CursorPage{}, nil)
+
+			route := app.Group("/api")
+			NewTransactionController(&svc, nil, route)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/transactions?"+tc.query, nil)
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.expectedCode, responseBody.Code)
+			assert.Equal(t, tc.expectedStatus, responseBody.Status)
+			if tc.expectedLimit == 0 {
+				svc.AssertNotCalled(t, "FindAllByCursor", ctx, &util.ListQuery{}, "", tc.expectedLimit)
+			}
+		})
+	}
+}
+
 func TestTransactionController_FindAllBySupplierByCode(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -171,11 +364,15 @@ func TestTransactionController_FindAllBySupplierByCode(t *testing.T) {
 
 			ctx := context.Background()
 
+			listQuery := &util.ListQuery{Filters: []*util.ListFilter{{Field: "supplier_code", Operator: util.ListOperatorEq, Values: []interface{}{tc.request}}}}
+
 			var svc service.TransactionServiceMock
-			svc.On("FindAllBySupplierCode", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
+			svc.On("Summary", ctx, listQuery).Return(&response.TransactionSummaryResponse{In: 23, Out: 3, UnitMassAcronym: "kg"}, nil)
+			svc.On("CountAll", ctx, listQuery).Return(int64(2), nil)
+			svc.On("FindAll", ctx, listQuery, 0, 10).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewTransactionController(&svc, route)
+			ctrl := NewTransactionController(&svc, nil, route)
 			app.Get("/api/transactions/:code/supplier", ctrl.FindAllBySupplierCode)
 
 			url := fmt.Sprintf("/api/transactions/%s/supplier", tc.request)
@@ -261,11 +458,15 @@ func TestTransactionController_FindAllByCustomerByCode(t *testing.T) {
 
 			ctx := context.Background()
 
+			listQuery := &util.ListQuery{Filters: []*util.ListFilter{{Field: "customer_code", Operator: util.ListOperatorEq, Values: []interface{}{tc.request}}}}
+
 			var svc service.TransactionServiceMock
-			svc.On("FindAllByCustomerCode", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
+			svc.On("Summary", ctx, listQuery).Return(&response.TransactionSummaryResponse{In: 23, Out: 3, UnitMassAcronym: "kg"}, nil)
+			svc.On("CountAll", ctx, listQuery).Return(int64(2), nil)
+			svc.On("FindAll", ctx, listQuery, 0, 10).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewTransactionController(&svc, route)
+			ctrl := NewTransactionController(&svc, nil, route)
 			app.Get("/api/transactions/:code/customer", ctrl.FindAllByCustomerCode)
 
 			url := fmt.Sprintf("/api/transactions/%s/customer", tc.request)
@@ -463,7 +664,7 @@ func TestTransactionController_FindByCode(t *testing.T) {
 			svc.On("FindByCode", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewTransactionController(&svc, route)
+			ctrl := NewTransactionController(&svc, nil, route)
 			app.Get("/api/transactions/:code", ctrl.FindByCode)
 
 			url := fmt.Sprintf("/api/transactions/%s", tc.request)
@@ -717,7 +918,7 @@ func TestTransactionController_Create(t *testing.T) {
 			svc.On("Create", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewTransactionController(&svc, route)
+			ctrl := NewTransactionController(&svc, nil, route)
 			app.Post("/api/transactions", ctrl.Create)
 
 			body, err := json.Marshal(tc.request)
@@ -918,7 +1119,7 @@ func TestTransactionController_Update(t *testing.T) {
 			svc.On("Update", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewTransactionController(&svc, route)
+			ctrl := NewTransactionController(&svc, nil, route)
 			app.Patch("/api/transactions/:code", ctrl.Update)
 
 			body, err := json.Marshal(tc.request)
@@ -1073,7 +1274,7 @@ func TestTransactionController_TransferStock(t *testing.T) {
 			svc.On("TransferStock", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewTransactionController(&svc, route)
+			ctrl := NewTransactionController(&svc, nil, route)
 			app.Patch("/api/transactions/transfer", ctrl.TransferStock)
 
 			body, err := json.Marshal(tc.request)
@@ -1107,6 +1308,102 @@ func TestTransactionController_TransferStock(t *testing.T) {
 	}
 }
 
+func TestTransactionController_CreateBatch(t *testing.T) {
+	lines := []*request.TransactionBatchLineRequest{
+		{Type: "IN", ProductQualityID: 1, Quantity: 23, UnitMassAcronym: "kg"},
+		{Type: "TRANSFER", ProductQualityID: 1, ProductQualityIDTransferred: 2, Quantity: 3, UnitMassAcronym: "kg"},
+	}
+
+	testCases := []struct {
+		name           string
+		request        *request.CreateTransactionBatchRequest
+		expectedResult *response.TransactionBatchResponse
+		expectedStatus string
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:    "Batch of valid lines",
+			request: &request.CreateTransactionBatchRequest{SupplierCode: util.ToPointerString("SUP001"), Reference: util.ToPointerString("DN-0042"), Lines: lines},
+			expectedResult: &response.TransactionBatchResponse{Transactions: []*response.TransactionResponse{
+				{Code: "IN-0001", Type: "IN", Reference: util.ToPointerString("DN-0042")},
+				{Code: "TRANSFER-0001", Type: "TRANSFER", Reference: util.ToPointerString("DN-0042")},
+			}},
+			expectedStatus: "created",
+			expectedCode:   http.StatusCreated,
+		},
+		{
+			name:    "Batch with an invalid line",
+			request: &request.CreateTransactionBatchRequest{Lines: lines},
+			expectedResult: &response.TransactionBatchResponse{Errors: []*response.TransactionBatchLineErrorResponse{
+				{Line: 2, Errors: []*response.ErrorResponse{{FailedField: "Quantity", Tag: "stock"}}},
+			}},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+		},
+		{
+			name:           "Stock taken by another request meanwhile",
+			request:        &request.CreateTransactionBatchRequest{Lines: lines},
+			expectedStatus: response.ErrorStockNotEnough,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New(response.ErrorStockNotEnough),
+		},
+		{
+			name:           "[missing] Batch without lines",
+			request:        &request.CreateTransactionBatchRequest{Reference: util.ToPointerString("DN-0042")},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'required' for 'Lines' field"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.TransactionServiceMock
+			svc.On("CreateBatch", ctx, tc.request).Return(tc.expectedResult, tc.expectedError)
+
+			route := app.Group("/api")
+			NewTransactionController(&svc, nil, route)
+
+			body, err := json.Marshal(tc.request)
+			assert.Nil(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/transactions/batch", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			if strings.Contains(tc.name, "[missing]") {
+				var responseBody response.ErrorValidationResponse
+				err = json.NewDecoder(res.Body).Decode(&responseBody)
+				assert.Nil(t, err)
+
+				assert.Equal(t, tc.expectedCode, responseBody.Code)
+				assert.Equal(t, tc.expectedStatus, responseBody.Status)
+				assert.Equal(t, tc.expectedError.Error(), responseBody.Error[0].Value)
+				return
+			}
+
+			var responseBody struct {
+				Code   int                                `json:"code"`
+				Status string                             `json:"status"`
+				Data   *response.TransactionBatchResponse `json:"data"`
+			}
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.expectedCode, responseBody.Code)
+			assert.Equal(t, tc.expectedStatus, responseBody.Status)
+			assert.Equal(t, tc.expectedResult, responseBody.Data)
+		})
+	}
+}
+
 func TestTransactionController_Delete(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -1148,7 +1445,7 @@ func TestTransactionController_Delete(t *testing.T) {
 			svc.On("Delete", ctx, tc.request).Return(tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewTransactionController(&svc, route)
+			ctrl := NewTransactionController(&svc, nil, route)
 			app.Delete("/api/transactions/:code", ctrl.Delete)
 
 			url := fmt.Sprintf("/api/transactions/%s", tc.request)
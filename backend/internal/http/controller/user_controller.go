@@ -1,23 +1,23 @@
 package controller
 
 import (
-	"bytes"
-	"encoding/json"
 	"github.com/gofiber/fiber/v2"
 	"inventory-management/backend/internal/http/request"
 	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
 	"inventory-management/backend/internal/service"
 	"inventory-management/backend/util"
-	"sync"
 )
 
 type UserController struct {
-	UserService service.UserServiceContract
+	UserService   service.UserServiceContract
+	ExportService service.ExportServiceContract
 }
 
-func NewUserController(userService service.UserServiceContract, route fiber.Router) UserController {
+func NewUserController(userService service.UserServiceContract, exportService service.ExportServiceContract, route fiber.Router) UserController {
 	controller := UserController{
-		UserService: userService,
+		UserService:   userService,
+		ExportService: exportService,
 	}
 
 	user := route.Group("/users")
@@ -28,73 +28,87 @@ func NewUserController(userService service.UserServiceContract, route fiber.Rout
 		user.Post("/", controller.Create)
 		user.Patch("/:id", controller.Update)
 		user.Delete("/:id", controller.Delete)
+		user.Post("/:id/restore", controller.Restore)
+		user.Post("/:id/purge", controller.Purge)
 	}
 
 	return controller
 }
 
 func (controller *UserController) Search(ctx *fiber.Ctx) error {
-	var data bytes.Buffer
-	var query map[string]interface{}
-	err := ctx.BodyParser(&query)
-	if err != nil {
+	var searchRequest request.UserSearchRequest
+	if err := ctx.BodyParser(&searchRequest); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	if err := json.NewEncoder(&data).Encode(query); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	if errValidate := util.ValidateStruct(searchRequest); errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
 	}
 
-	currPage := ctx.QueryInt("page", 1)
-	if currPage <= 0 {
-		currPage = 1
+	currPage, limit, errValidate := searchPage(ctx)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
 	}
-	limit := ctx.QueryInt("limit", 10)
-
-	var wg sync.WaitGroup
-	var pagination response.Pagination
-	totalRecords := make(chan int64)
-
-	wg.Add(2)
-	go func() {
-		defer wg.Done()
-		pagination = util.CreatePagination(currPage, limit, <-totalRecords)
-	}()
-
-	var searchResponse map[string]interface{}
-	go func() {
-		defer wg.Done()
-		offset := (currPage - 1) * limit
-		searchResponse, err = controller.UserService.Search(ctx.UserContext(), data, offset, limit, totalRecords)
-	}()
-	wg.Wait()
+
+	offset := (currPage - 1) * limit
+	searchResponse, err := controller.UserService.Search(ctx.UserContext(), &searchRequest, offset, limit)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
+	pagination := util.CreatePagination(currPage, limit, searchResponse.Total)
 	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", searchResponse).WithPagination(&pagination).Build()
 }
 
 func (controller *UserController) FindAll(ctx *fiber.Ctx) error {
+	listQuery, errValidate := parseListQuery(ctx, model.UserListSchema)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	if ExportRequested(ctx) {
+		return exportList(ctx, controller.ExportService, model.ExportEntityUsers, queryValues(ctx), listQuery)
+	}
+
 	currPage := ctx.QueryInt("page", 1)
 	if currPage <= 0 {
 		currPage = 1
 	}
-	limit := ctx.QueryInt("limit", 10)
+	limit, errValidate := listLimit(ctx)
+	if errValidate != nil {
+		return response.ReturnErrorValidation(ctx, errValidate)
+	}
+
+	if cursorPaged(ctx) {
+		users, page, err := controller.UserService.FindAllByCursor(ctx.UserContext(), listQuery, ctx.Query("cursor"), limit)
+		if err != nil {
+			if err.Error() == response.ErrorInvalidCursor {
+				return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+
+		cursor, err := cursorPagination(ctx, listQuery, page, controller.UserService.CountAll)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
 
-	totalRecords, err := controller.UserService.CountAll(ctx.UserContext())
+		return response.ReturnJSON(ctx, fiber.StatusOK, "OK", users).WithCursor(cursor).WithFields(listQuery.Fields).Build()
+	}
+
+	totalRecords, err := controller.UserService.CountAll(ctx.UserContext(), listQuery)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
 	pagination := util.CreatePagination(currPage, limit, totalRecords)
 	offset := (currPage - 1) * limit
-	users, err := controller.UserService.FindAll(ctx.UserContext(), offset, limit)
+	users, err := controller.UserService.FindAll(ctx.UserContext(), listQuery, offset, limit)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", users).WithPagination(&pagination).Build()
+	return response.ReturnJSON(ctx, fiber.StatusOK, "OK", users).WithPagination(&pagination).WithFields(listQuery.Fields).Build()
 }
 
 func (controller *UserController) FindByID(ctx *fiber.Ctx) error {
@@ -178,3 +192,40 @@ func (controller *UserController) Delete(ctx *fiber.Ctx) error {
 
 	return response.ReturnJSON(ctx, fiber.StatusOK, "deleted", nil).Build()
 }
+
+func (controller *UserController) Restore(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	user, err := controller.UserService.Restore(ctx.UserContext(), int64(id))
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "restored", user).Build()
+}
+
+func (controller *UserController) Purge(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	err = controller.UserService.Purge(ctx.UserContext(), int64(id))
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		if err.Error() == response.ErrorRecordReferenced {
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return response.ReturnJSON(ctx, fiber.StatusOK, "purged", nil).Build()
+}
@@ -12,12 +12,96 @@ import (
 	"inventory-management/backend/internal/http/request"
 	response "inventory-management/backend/internal/http/response"
 	service "inventory-management/backend/internal/service/mock"
+	"inventory-management/backend/util"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 )
 
+func TestUserController_Search(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		request        *request.UserSearchRequest
+		expectedStatus string
+		expectedBody   *response.UserSearchResponse
+		expectedCode   int
+		expectedError  error
+	}{
+		{
+			name:           "Users matching the query",
+			body:           `{"query":"widdy","role":"admin","sort":"-created_at","facets":["role"]}`,
+			request:        &request.UserSearchRequest{Query: "widdy", Role: "admin", Sort: "-created_at", Facets: []string{"role"}},
+			expectedStatus: "OK",
+			expectedBody: &response.UserSearchResponse{
+				Total: 1,
+				Hits: []*response.UserDocument{
+					{ID: 1, Name: "Widdy Arfiansyah", Username: "wdyarfn", Role: "admin"},
+				},
+				Facets: map[string][]*response.FacetBucketResponse{
+					"role": {{Value: "admin", Count: 1}},
+				},
+			},
+			expectedCode:  http.StatusOK,
+			expectedError: nil,
+		},
+		{
+			name:           "[missing] Users sorted by a field that is not sortable",
+			body:           `{"sort":"password"}`,
+			request:        &request.UserSearchRequest{Sort: "password"},
+			expectedStatus: response.ErrorValidation,
+			expectedCode:   http.StatusBadRequest,
+			expectedError:  errors.New("Error validation 'oneof' for 'Sort' field"),
+		},
+		{
+			name:           "Service getting an error",
+			body:           `{"query":"widdy"}`,
+			request:        &request.UserSearchRequest{Query: "widdy"},
+			expectedStatus: "getting an error",
+			expectedCode:   http.StatusInternalServerError,
+			expectedError:  errors.New("getting an error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(middleware.FiberConfig())
+
+			ctx := context.Background()
+
+			var svc service.UserServiceMock
+			svc.On("Search", ctx, tc.request, 0, 10).Return(tc.expectedBody, tc.expectedError)
+
+			route := app.Group("/api")
+			NewUserController(&svc, nil, route)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/users/search", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			res, err := app.Test(req, -1)
+			assert.Nil(t, err)
+
+			if strings.Contains(tc.name, "[missing]") {
+				var responseBody response.ErrorValidationResponse
+				err = json.NewDecoder(res.Body).Decode(&responseBody)
+				assert.Nil(t, err)
+
+				assert.Equal(t, responseBody.Code, tc.expectedCode)
+				assert.Equal(t, responseBody.Status, tc.expectedStatus)
+				assert.Equal(t, responseBody.Error[0].Value, tc.expectedError.Error())
+				return
+			}
+
+			var responseBody response.ApiResponse
+			err = json.NewDecoder(res.Body).Decode(&responseBody)
+			assert.Nil(t, err)
+
+			assert.Equal(t, responseBody.Code, tc.expectedCode)
+			assert.Equal(t, responseBody.Status, tc.expectedStatus)
+		})
+	}
+}
+
 func TestUserController_FindAll(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -71,11 +155,11 @@ func TestUserController_FindAll(t *testing.T) {
 			ctx := context.Background()
 
 			var svc service.UserServiceMock
-			svc.On("CountAll", ctx).Return(int64(2), nil)
-			svc.On("FindAll", ctx, 0, 10).Return(tc.expectedBody, tc.expectedError)
+			svc.On("CountAll", ctx, &util.ListQuery{}).Return(int64(2), nil)
+			svc.On("FindAll", ctx, &util.ListQuery{}, 0, 10).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewUserController(&svc, route)
+			ctrl := NewUserController(&svc, nil, route)
 			app.Get("/api/users", ctrl.FindAll)
 
 			req := httptest.NewRequest(http.MethodGet, "/api/users", nil)
@@ -153,7 +237,7 @@ func TestUserController_FindByID(t *testing.T) {
 			svc.On("FindByID", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewUserController(&svc, route)
+			ctrl := NewUserController(&svc, nil, route)
 			app.Get("/api/users/:id", ctrl.FindByID)
 
 			url := fmt.Sprintf("/api/users/%d", tc.request)
@@ -332,7 +416,7 @@ func TestUserController_Create(t *testing.T) {
 			svc.On("Create", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewUserController(&svc, route)
+			ctrl := NewUserController(&svc, nil, route)
 			app.Post("/api/users", ctrl.Create)
 
 			byteRequest, err := json.Marshal(tc.request)
@@ -484,7 +568,7 @@ func TestUserController_Update(t *testing.T) {
 			svc.On("Update", ctx, tc.request).Return(tc.expectedBody, tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewUserController(&svc, route)
+			ctrl := NewUserController(&svc, nil, route)
 			app.Patch("/api/users/:id", ctrl.Update)
 
 			byteRequest, err := json.Marshal(tc.request)
@@ -571,7 +655,7 @@ func TestUserController_Delete(t *testing.T) {
 			svc.On("Delete", ctx, tc.request).Return(tc.expectedError)
 
 			route := app.Group("/api")
-			ctrl := NewUserController(&svc, route)
+			ctrl := NewUserController(&svc, nil, route)
 			app.Delete("/api/users/:id", ctrl.Delete)
 
 			url := fmt.Sprintf("/api/users/%d", tc.request)
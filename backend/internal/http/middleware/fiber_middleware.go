@@ -13,7 +13,11 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 	"inventory-management/backend/cmd/config"
 	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/service"
+	"inventory-management/backend/util"
+	"log"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -36,7 +40,10 @@ func FiberConfig() fiber.Config {
 	}
 }
 
-func XApiKeyMiddleware(configuration config.Config) fiber.Handler {
+// XApiKeyMiddleware accepts the deployment wide X_API_KEY or the API key of a tenant. A tenant
+// key scopes the request to its tenant before any user is authenticated, the deployment key
+// leaves it without one until the token of a user names it.
+func XApiKeyMiddleware(configuration config.Config, tenantService service.TenantServiceContract) fiber.Handler {
 	return func(ctx *fiber.Ctx) error {
 		xApiKey := ctx.Get("X-API-KEY")
 		if xApiKey == "" {
@@ -44,16 +51,29 @@ func XApiKeyMiddleware(configuration config.Config) fiber.Handler {
 			return fiber.NewError(fiber.StatusForbidden, "access denied: please provide a valid API key to access this page.")
 		}
 
-		if xApiKey != configuration.Get("X_API_KEY") {
+		if xApiKey == configuration.Get("X_API_KEY") {
+			ctx.SetUserContext(util.WithoutTenant(ctx.UserContext()))
+			return ctx.Next()
+		}
+
+		tenant, err := tenantService.ResolveApiKey(ctx.UserContext(), xApiKey)
+		if err != nil {
 			ctx.Locals("middleware", "XApiKey Middleware")
-			return fiber.NewError(fiber.StatusForbidden, "invalid key: the provided API key is incorrect. please make sure to use a valid API key to access this resource.")
+			if err.Error() == response.ErrorNotFound {
+				return fiber.NewError(fiber.StatusForbidden, "invalid key: the provided API key is incorrect. please make sure to use a valid API key to access this resource.")
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 		}
 
+		ctx.Locals("tenant_id", tenant.ID)
+		ctx.SetUserContext(util.WithTenant(ctx.UserContext(), tenant.ID))
 		return ctx.Next()
 	}
 }
 
-func NewJWTMiddleware() fiber.Handler {
+// NewJWTMiddleware authenticates the token of a user, refusing it once the password of the user
+// changed since it was issued.
+func NewJWTMiddleware(userService service.UserServiceContract) fiber.Handler {
 	return jwtware.New(jwtware.Config{
 		SigningKey: SecretKey,
 		ErrorHandler: func(ctx *fiber.Ctx, err error) error {
@@ -64,16 +84,189 @@ func NewJWTMiddleware() fiber.Handler {
 			userClaims := userContext.Claims.(jwt.MapClaims)
 
 			ctx.Locals("username", userClaims["username"])
+			ctx.Locals("role", userClaims["role"])
+
+			claim, ok := userClaims["tenant_id"].(float64)
+			if !ok || claim <= 0 {
+				return response.ReturnJSON(ctx, fiber.StatusUnauthorized, response.ErrorTenantMissing, nil).Build()
+			}
+			tenantID := int64(claim)
+
+			if apiKeyTenantID, ok := ctx.Locals("tenant_id").(int64); ok && apiKeyTenantID != tenantID {
+				ctx.Locals("middleware", "JWT Middleware")
+				return fiber.NewError(fiber.StatusForbidden, response.ErrorTenantMismatch)
+			}
+			ctx.Locals("tenant_id", tenantID)
+
+			// Services read the acting user and tenant from the request context
+			username, _ := userClaims["username"].(string)
+			userCtx := util.WithTenant(util.WithActor(ctx.UserContext(), username), tenantID)
+
+			tokenVersion, ok := userClaims["token_version"].(float64)
+			if !ok {
+				return response.ReturnJSON(ctx, fiber.StatusUnauthorized, response.ErrorInvalidToken, nil).Build()
+			}
+			err := userService.VerifyTokenVersion(userCtx, username, int64(tokenVersion))
+			if err != nil {
+				if err.Error() == response.ErrorInvalidToken {
+					return response.ReturnJSON(ctx, fiber.StatusUnauthorized, err.Error(), nil).Build()
+				}
+				ctx.Locals("middleware", "JWT Middleware")
+				return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+			}
+
+			ctx.SetUserContext(userCtx)
 			return ctx.Next()
 		},
 	})
 }
 
+// NewRequestContextMiddleware copies the request ID generated by the requestid middleware
+// into the user context, so it is available to services.
+func NewRequestContextMiddleware() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		requestID, _ := ctx.Locals("requestid").(string)
+		if requestID != "" {
+			ctx.SetUserContext(util.WithRequestID(ctx.UserContext(), requestID))
+		}
+
+		return ctx.Next()
+	}
+}
+
+func NewRoleMiddleware(roles ...string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		role, _ := ctx.Locals("role").(string)
+		for _, allowedRole := range roles {
+			if role == allowedRole {
+				return ctx.Next()
+			}
+		}
+
+		ctx.Locals("middleware", "Role Middleware")
+		return fiber.NewError(fiber.StatusForbidden, response.ErrorForbiddenRole)
+	}
+}
+
+// NewOperatorTenantMiddleware only lets users of the default tenant through, since it operates
+// the deployment and provisions the other tenants.
+func NewOperatorTenantMiddleware() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		tenantID, _ := ctx.Locals("tenant_id").(int64)
+		if tenantID == util.DefaultTenantID {
+			return ctx.Next()
+		}
+
+		ctx.Locals("middleware", "Operator Tenant Middleware")
+		return fiber.NewError(fiber.StatusForbidden, response.ErrorForbiddenTenant)
+	}
+}
+
+// idempotentMethods are the methods an Idempotency-Key makes safe to repeat.
+var idempotentMethods = map[string]bool{
+	fiber.MethodPost:   true,
+	fiber.MethodPut:    true,
+	fiber.MethodPatch:  true,
+	fiber.MethodDelete: true,
+}
+
+// NewIdempotencyMiddleware processes a request sent with an Idempotency-Key once: repeating it
+// with the key replays the stored response with an Idempotent-Replayed header. Responses of
+// server errors are not stored, so the request can be sent again with the same key.
+func NewIdempotencyMiddleware(idempotencyService service.IdempotencyServiceContract) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		key := ctx.Get("Idempotency-Key")
+		if key == "" || !idempotentMethods[ctx.Method()] {
+			return ctx.Next()
+		}
+
+		if len(key) > 255 {
+			ctx.Locals("middleware", "Idempotency Middleware")
+			return fiber.NewError(fiber.StatusBadRequest, response.ErrorIdempotencyKeyInvalid)
+		}
+
+		fingerprint := util.HashToken(ctx.Method() + " " + ctx.OriginalURL() + "\n" + string(ctx.Body()))
+		stored, err := idempotencyService.Begin(ctx.UserContext(), key, fingerprint)
+		if err != nil {
+			ctx.Locals("middleware", "Idempotency Middleware")
+			switch err.Error() {
+			case response.ErrorIdempotencyKeyReused:
+				return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+			case response.ErrorIdempotencyKeyInProgress:
+				return fiber.NewError(fiber.StatusConflict, err.Error())
+			}
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if stored != nil {
+			ctx.Set("Idempotent-Replayed", "true")
+			ctx.Set(fiber.HeaderContentType, stored.ContentType)
+			return ctx.Status(stored.StatusCode).Send(stored.Body)
+		}
+
+		// Errors only become responses once the middlewares return, the response is needed now
+		if err = ctx.Next(); err != nil {
+			if err = ctx.App().ErrorHandler(ctx, err); err != nil {
+				_ = idempotencyService.Release(ctx.UserContext(), key, fingerprint)
+				return err
+			}
+		}
+
+		if ctx.Response().StatusCode() >= fiber.StatusInternalServerError {
+			err = idempotencyService.Release(ctx.UserContext(), key, fingerprint)
+		} else {
+			err = idempotencyService.Complete(ctx.UserContext(), key, fingerprint, &response.IdempotentResponse{
+				StatusCode:  ctx.Response().StatusCode(),
+				ContentType: string(ctx.Response().Header.ContentType()),
+				Body:        append([]byte(nil), ctx.Response().Body()...),
+			})
+		}
+		if err != nil {
+			log.Println("Cannot store the response of the idempotency key", err)
+		}
+
+		return nil
+	}
+}
+
+// preconditionMethods are the methods that change a versioned record.
+var preconditionMethods = map[string]bool{
+	fiber.MethodPatch:  true,
+	fiber.MethodDelete: true,
+}
+
+// NewIfMatchMiddleware passes the version of the ETag sent in If-Match to the services, which
+// refuse to change a record that is no longer at it. A tag that is not a version matches none.
+// When required, changes sent without If-Match are refused so no client overwrites blindly.
+func NewIfMatchMiddleware(required bool) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if !preconditionMethods[ctx.Method()] {
+			return ctx.Next()
+		}
+
+		ifMatch := strings.TrimSpace(ctx.Get(fiber.HeaderIfMatch))
+		if ifMatch == "" {
+			if required {
+				ctx.Locals("middleware", "If-Match Middleware")
+				return fiber.NewError(fiber.StatusPreconditionRequired, response.ErrorIfMatchRequired)
+			}
+			return ctx.Next()
+		}
+		if ifMatch == "*" {
+			return ctx.Next()
+		}
+
+		version, _ := util.ParseVersionETag(ifMatch)
+		ctx.SetUserContext(util.WithExpectedVersion(ctx.UserContext(), version))
+		return ctx.Next()
+	}
+}
+
 func NewCORSMiddleware() fiber.Handler {
 	return cors.New(cors.Config{
 		AllowOrigins:     "*",
-		AllowHeaders:     "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-API-KEY",
+		AllowHeaders:     "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-API-KEY, Idempotency-Key, If-Match",
 		AllowMethods:     "POST, DELETE, PUT, PATCH, GET",
+		ExposeHeaders:    "ETag",
 		AllowCredentials: true,
 	})
 }
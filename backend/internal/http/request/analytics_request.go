@@ -0,0 +1,11 @@
+package request
+
+// AnalyticsRequest narrows a dashboard aggregation down to the transactions created from and to
+// the given days. Quantities are converted to UnitMassAcronym, kilograms unless it is set.
+type AnalyticsRequest struct {
+	From            string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To              string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	Interval        string `query:"interval" validate:"omitempty,oneof=day week month"`
+	Limit           int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	UnitMassAcronym string `query:"unit_mass_acronym" validate:"omitempty,oneof=ton kg hg dag g dg cg mg"`
+}
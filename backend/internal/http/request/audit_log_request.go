@@ -0,0 +1,11 @@
+package request
+
+type AuditLogFilterRequest struct {
+	Entity    string `query:"entity" validate:"omitempty,oneof=tenant user product product_quality supplier customer transaction search_synonym"`
+	EntityID  string `query:"entity_id" validate:"omitempty,max=100"`
+	Actor     string `query:"actor" validate:"omitempty,max=100"`
+	Action    string `query:"action" validate:"omitempty,oneof=create update delete"`
+	RequestID string `query:"request_id" validate:"omitempty,max=100"`
+	From      string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To        string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+}
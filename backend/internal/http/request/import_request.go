@@ -0,0 +1,10 @@
+package request
+
+// ImportOpeningStockRow is the stock a product quality starts with, posted as an IN transaction.
+type ImportOpeningStockRow struct {
+	ProductCode     string  `json:"product_code" validate:"required,max=100"`
+	Quality         string  `json:"quality" validate:"required,max=100"`
+	Quantity        float64 `json:"quantity" validate:"required,number,gt=0"`
+	UnitMassAcronym string  `json:"unit_mass_acronym" validate:"required,oneof=ton kg hg dag g dg cg mg"`
+	Description     *string `json:"description" validate:"omitempty,max=255"`
+}
@@ -0,0 +1,9 @@
+package request
+
+// LabelRequest picks how a label is rendered: as a PNG or SVG image of the barcode, or as ZPL
+// for a thermal printer. The symbology defaults to the one of the label template.
+type LabelRequest struct {
+	Format    string `query:"format" validate:"omitempty,oneof=png svg zpl"`
+	Symbology string `query:"symbology" validate:"omitempty,oneof=code128 qr gs1-128"`
+	Copies    int    `query:"copies" validate:"omitempty,min=1,max=1000"`
+}
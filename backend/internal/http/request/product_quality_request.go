@@ -5,6 +5,7 @@ type CreateProductQualityRequest struct {
 	Price    int64   `json:"price" validate:"required,number"`
 	Quantity float64 `json:"quantity" validate:"omitempty,number"`
 	Type     string  `json:"type" validate:"required,max=20"`
+	Gtin     *string `json:"gtin" validate:"omitempty,gtin"`
 }
 
 type UpdateProductQualityRequest struct {
@@ -13,4 +14,5 @@ type UpdateProductQualityRequest struct {
 	Price    int64   `json:"price" validate:"required,number"`
 	Quantity float64 `json:"quantity" validate:"omitempty,number"`
 	Type     string  `json:"type" validate:"required,max=20"`
+	Gtin     *string `json:"gtin" validate:"omitempty,gtin"`
 }
@@ -4,6 +4,7 @@ type CreateProductRequest struct {
 	Name                string                         `json:"name" validate:"required,max=100"`
 	UnitMassAcronym     string                         `json:"unit_mass_acronym" validate:"required,max=20"`
 	UnitMassDescription string                         `json:"unit_mass_description" validate:"required,max=50"`
+	Gtin                *string                        `json:"gtin" validate:"omitempty,gtin"`
 	ProductQualities    []*CreateProductQualityRequest `json:"product_qualities" validate:"required,dive"`
 }
 
@@ -12,5 +13,6 @@ type UpdateProductRequest struct {
 	Name                string                         `json:"name" validate:"required,max=100"`
 	UnitMassAcronym     string                         `json:"unit_mass_acronym" validate:"required,max=20"`
 	UnitMassDescription string                         `json:"unit_mass_description" validate:"required,max=50"`
+	Gtin                *string                        `json:"gtin" validate:"omitempty,gtin"`
 	ProductQualities    []*UpdateProductQualityRequest `json:"product_qualities" validate:"required,dive"`
 }
@@ -0,0 +1,58 @@
+package request
+
+type ProductSearchRequest struct {
+	Query           string `json:"query" validate:"omitempty,max=200"`
+	UnitMassAcronym string `json:"unit_mass_acronym" validate:"omitempty,oneof=ton kg hg dag g dg cg mg"`
+	QualityType     string `json:"quality_type" validate:"omitempty,max=100"`
+	MinPrice        int64  `json:"min_price" validate:"omitempty,min=0"`
+	MaxPrice        int64  `json:"max_price" validate:"omitempty,min=0"`
+}
+
+type ProductQualitySearchRequest struct {
+	Query       string `json:"query" validate:"omitempty,max=200"`
+	ProductCode string `json:"product_code" validate:"omitempty,max=100"`
+	Type        string `json:"type" validate:"omitempty,max=100"`
+	InStock     bool   `json:"in_stock"`
+}
+
+type TransactionSearchRequest struct {
+	Query        string `json:"query" validate:"omitempty,max=200"`
+	Type         string `json:"type" validate:"omitempty,oneof=IN OUT TRANSFER"`
+	ProductCode  string `json:"product_code" validate:"omitempty,max=100"`
+	SupplierCode string `json:"supplier_code" validate:"omitempty,max=100"`
+	CustomerCode string `json:"customer_code" validate:"omitempty,max=100"`
+	From         string `json:"from" validate:"omitempty,datetime=2006-01-02"`
+	To           string `json:"to" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type SupplierSearchRequest struct {
+	Query string `json:"query" validate:"omitempty,max=200"`
+	Phone string `json:"phone" validate:"omitempty,max=100"`
+}
+
+type CustomerSearchRequest struct {
+	Query string `json:"query" validate:"omitempty,max=200"`
+}
+
+type UserSearchRequest struct {
+	Query       string   `json:"query" validate:"omitempty,max=200"`
+	Role        string   `json:"role" validate:"omitempty,oneof=admin staff"`
+	CreatedFrom string   `json:"created_from" validate:"omitempty,datetime=2006-01-02"`
+	CreatedTo   string   `json:"created_to" validate:"omitempty,datetime=2006-01-02"`
+	Sort        string   `json:"sort" validate:"omitempty,oneof=name -name username -username created_at -created_at"`
+	Facets      []string `json:"facets" validate:"omitempty,max=2,dive,oneof=role created_month"`
+}
+
+type SuggestRequest struct {
+	Query string `query:"q" validate:"required,max=100"`
+	Limit int    `query:"limit" validate:"omitempty,min=1,max=20"`
+}
+
+type CreateSearchSynonymRequest struct {
+	Words []string `json:"words" validate:"required,min=2,max=20,dive,required,max=100"`
+}
+
+type UpdateSearchSynonymRequest struct {
+	ID    int64    `json:"id"`
+	Words []string `json:"words" validate:"required,min=2,max=20,dive,required,max=100"`
+}
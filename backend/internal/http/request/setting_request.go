@@ -0,0 +1,33 @@
+package request
+
+type UpdateBrandingSettingRequest struct {
+	CompanyName string `json:"company_name" validate:"required,max=100"`
+	Address     string `json:"address" validate:"max=255"`
+	Phone       string `json:"phone" validate:"omitempty,max=30"`
+	Email       string `json:"email" validate:"omitempty,email,max=100"`
+	Footer      string `json:"footer" validate:"max=255"`
+	// Logo is a base64 encoded PNG or JPEG image of up to 256 KB, the logo is removed without it
+	Logo *string `json:"logo" validate:"omitempty,base64,max=350000"`
+}
+
+type UpdateLabelTemplateRequest struct {
+	LabelType string
+	Symbology string `json:"symbology" validate:"required,oneof=code128 qr gs1-128"`
+	// Zpl is a Go text/template of the label, see model.LabelData for what it is executed with
+	Zpl string `json:"zpl" validate:"required,max=10000"`
+}
+
+type UpdateNumberingSequenceRequest struct {
+	Entity string
+	// TransactionType numbers one type of transaction apart from the others, it is empty for the
+	// sequence of every type and for the other entities
+	TransactionType string `json:"transaction_type" validate:"omitempty,oneof=IN OUT TRANSFER"`
+	Strategy        string `json:"strategy" validate:"required,oneof=sequence random"`
+	// Pattern is made of URL safe characters and the tokens {TYPE}, {YYYY}, {YY}, {MM}, {DD} and
+	// {SEQ}, the sequence of a transaction type holding {TYPE}. Codes are unique within a tenant.
+	Pattern string `json:"pattern" validate:"required_if=Strategy sequence,max=50"`
+	Padding int    `json:"padding" validate:"min=0,max=12"`
+	Reset   string `json:"reset" validate:"omitempty,oneof=never yearly monthly"`
+	// NextValue restarts the sequence from a number, e.g. to carry on the numbering of paper forms
+	NextValue *int64 `json:"next_value" validate:"omitempty,min=1"`
+}
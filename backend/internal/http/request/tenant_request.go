@@ -0,0 +1,15 @@
+package request
+
+type CreateTenantRequest struct {
+	Code          string `json:"code" validate:"required,alphanum,min=2,max=50"`
+	Name          string `json:"name" validate:"required,min=3,max=100"`
+	AdminName     string `json:"admin_name" validate:"required,min=3,max=100"`
+	AdminUsername string `json:"admin_username" validate:"required,min=3,max=100"`
+	AdminPassword string `json:"admin_password" validate:"required,min=8,max=255"`
+	AdminEmail    string `json:"admin_email" validate:"omitempty,email,max=255"`
+}
+
+type UpdateTenantRequest struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name" validate:"required,min=3,max=100"`
+}
@@ -5,6 +5,7 @@ type CreateTransactionRequest struct {
 	SupplierCode     *string `json:"supplier_code" validate:"omitempty,max=100"`
 	CustomerCode     *string `json:"customer_code" validate:"omitempty,max=100"`
 	Description      *string `json:"description" validate:"omitempty,max=255"`
+	Reference        *string `json:"reference" validate:"omitempty,max=100"`
 	Quantity         float64 `json:"quantity" validate:"required,number"`
 	Type             string  `json:"type" validate:"required,oneof=IN OUT"`
 	UnitMassAcronym  string  `json:"unit_mass_acronym" validate:"required,oneof=ton kg hg dag g dg cg mg"`
@@ -17,11 +18,36 @@ type UpdateTransactionRequest struct {
 	Description     *string `json:"description" validate:"omitempty,max=255"`
 	Quantity        float64 `json:"quantity" validate:"required,number"`
 	UnitMassAcronym string  `json:"unit_mass_acronym" validate:"required,oneof=ton kg hg dag g dg cg mg"`
+	// Version is the version of the transaction the update is made at
+	Version int64 `json:"-"`
 }
 
 type TransferStockTransactionRequest struct {
 	ProductQualityID            int64   `json:"product_quality_id" validate:"required,number"`
 	ProductQualityIDTransferred int64   `json:"product_quality_id_transferred" validate:"required,number"`
 	Quantity                    float64 `json:"quantity" validate:"required,number"`
+	// UnitMassAcronym is the unit of the quantity, the one of the product when it is empty
+	UnitMassAcronym string  `json:"unit_mass_acronym" validate:"omitempty,oneof=ton kg hg dag g dg cg mg"`
+	Description     *string `json:"description" validate:"omitempty,max=255"`
+	Reference       *string `json:"reference" validate:"omitempty,max=100"`
+}
+
+// CreateTransactionBatchRequest posts the lines of one stock movement, such as a delivery, with
+// the supplier or customer, reference and description of its header. A line may describe
+// itself, and transfers have no supplier or customer.
+type CreateTransactionBatchRequest struct {
+	SupplierCode *string                        `json:"supplier_code" validate:"omitempty,max=100"`
+	CustomerCode *string                        `json:"customer_code" validate:"omitempty,max=100"`
+	Reference    *string                        `json:"reference" validate:"omitempty,max=100"`
+	Description  *string                        `json:"description" validate:"omitempty,max=255"`
+	Lines        []*TransactionBatchLineRequest `json:"lines" validate:"required,min=1,max=500"`
+}
+
+type TransactionBatchLineRequest struct {
+	Type                        string  `json:"type" validate:"required,oneof=IN OUT TRANSFER"`
+	ProductQualityID            int64   `json:"product_quality_id" validate:"required,number"`
+	ProductQualityIDTransferred int64   `json:"product_quality_id_transferred" validate:"required_if=Type TRANSFER,excluded_unless=Type TRANSFER,omitempty,nefield=ProductQualityID"`
+	Quantity                    float64 `json:"quantity" validate:"required,number,gt=0"`
+	UnitMassAcronym             string  `json:"unit_mass_acronym" validate:"required,oneof=ton kg hg dag g dg cg mg"`
 	Description                 *string `json:"description" validate:"omitempty,max=255"`
 }
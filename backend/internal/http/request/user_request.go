@@ -4,15 +4,59 @@ type CreateUserRequest struct {
 	Name     string `json:"name" validate:"required,min=3,max=100"`
 	Username string `json:"username" validate:"required,min=3,max=100"`
 	Password string `json:"password" validate:"required,min=8,max=255"`
+	Email    string `json:"email" validate:"omitempty,email,max=255"`
+	Role     string `json:"role" validate:"omitempty,oneof=admin staff"`
 }
 
 type UpdateUserRequest struct {
-	ID       int64  `json:"id"`
-	Name     string `json:"name" validate:"required,min=3,max=100"`
-	Password string `json:"password" validate:"omitempty,min=8,max=255"`
+	ID       int64   `json:"id"`
+	Name     string  `json:"name" validate:"required,min=3,max=100"`
+	Password string  `json:"password" validate:"omitempty,min=8,max=255"`
+	Email    *string `json:"email" validate:"omitempty,email,max=255"`
+}
+
+type RegisterUserRequest struct {
+	InvitationToken string `json:"invitation_token" validate:"required"`
+	Name            string `json:"name" validate:"required,min=3,max=100"`
+	Username        string `json:"username" validate:"required,min=3,max=100"`
+	Password        string `json:"password" validate:"required,min=8,max=255"`
+}
+
+type UpdateMeRequest struct {
+	Username string
+	Name     string  `json:"name" validate:"required,min=3,max=100"`
+	Email    *string `json:"email" validate:"omitempty,email,max=255"`
+}
+
+type ChangePasswordRequest struct {
+	Username        string
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8,max=255,nefield=CurrentPassword"`
+}
+
+type CreateInvitationRequest struct {
+	InvitedBy string
+	Email     string `json:"email" validate:"required,email,max=255"`
+	Role      string `json:"role" validate:"required,oneof=admin staff"`
+}
+
+type ForgotPasswordRequest struct {
+	Username string `json:"username" validate:"required"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8,max=255"`
 }
 
 type LoginUserRequest struct {
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
 }
+
+type OidcCallbackRequest struct {
+	Code  string `json:"code" query:"code" validate:"required"`
+	State string `json:"state" query:"state" validate:"required"`
+	// Cookie is the signed state the browser got when it started the login
+	Cookie string `json:"-" query:"-"`
+}
@@ -0,0 +1,37 @@
+package response
+
+// VolumeResponse is the quantity that came in and went out during the day, week or month
+// starting on Date. Transfers between qualities are not counted.
+type VolumeResponse struct {
+	Date            string  `json:"date"`
+	In              float64 `json:"in"`
+	Out             float64 `json:"out"`
+	UnitMassAcronym string  `json:"unit_mass_acronym"`
+}
+
+type PartnerVolumeResponse struct {
+	Code            string  `json:"code"`
+	Name            string  `json:"name"`
+	Quantity        float64 `json:"quantity"`
+	UnitMassAcronym string  `json:"unit_mass_acronym"`
+	Transactions    int64   `json:"transactions"`
+}
+
+type StockByTypeResponse struct {
+	Type             string  `json:"type"`
+	Quantity         float64 `json:"quantity"`
+	UnitMassAcronym  string  `json:"unit_mass_acronym"`
+	ProductQualities int64   `json:"product_qualities"`
+}
+
+type TransferVolumeResponse struct {
+	ProductCode                 string  `json:"product_code"`
+	ProductName                 string  `json:"product_name"`
+	ProductQualityID            int64   `json:"product_quality_id"`
+	Quality                     string  `json:"quality"`
+	ProductQualityIDTransferred int64   `json:"product_quality_id_transferred"`
+	QualityTransferred          string  `json:"quality_transferred"`
+	Quantity                    float64 `json:"quantity"`
+	UnitMassAcronym             string  `json:"unit_mass_acronym"`
+	Transactions                int64   `json:"transactions"`
+}
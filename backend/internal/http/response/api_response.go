@@ -1,6 +1,7 @@
 package response
 
 import (
+	"encoding/json"
 	"github.com/gofiber/fiber/v2"
 	"net/http"
 )
@@ -13,6 +14,37 @@ var (
 	ErrorTransferStockDifferentProduct = "transfer stock must be the same product"
 	ErrorStockNotEnough                = "stock is not enough"
 	ErrorUpdateTransactionTypeTransfer = "transaction type cannot be changed in transfer process"
+	ErrorOidcInvalidState              = "invalid or expired login state"
+	ErrorOidcAccountConflict           = "account is already linked to another identity"
+	ErrorOidcUsernameMissing           = "the identity provider did not send a username or an email"
+	ErrorOidcUsernameTaken             = "username belongs to a local account, sign in and link the identity from the account"
+	ErrorForbiddenRole                 = "your role is not allowed to access this resource"
+	ErrorInvalidToken                  = "token is invalid or has expired"
+	ErrorLedgerSigningKeyMissing       = "ledger signing key is not configured"
+	ErrorLedgerEmpty                   = "ledger has no entries"
+	ErrorTenantCodeExists              = "tenant code already exist"
+	ErrorForbiddenTenant               = "your tenant is not allowed to access this resource"
+	ErrorTenantMismatch                = "the token does not belong to the tenant of the API key"
+	ErrorTenantMissing                 = "the request is not scoped to a tenant"
+	ErrorSearchIndexUnknown            = "search index does not exist"
+	ErrorReindexCountMismatch          = "the reindexed documents do not match the database, the alias was not swapped"
+	ErrorInvalidCursor                 = "cursor is invalid or does not match the sort"
+	ErrorSheetFileType                 = "file must be a CSV or an XLSX sheet"
+	ErrorSheetEmpty                    = "file has no header row"
+	ErrorExportNotReady                = "export is not done yet"
+	ErrorLogoImageType                 = "logo must be a PNG or a JPEG image"
+	ErrorDocumentTransactionType       = "the document cannot be issued for this type of transaction"
+	ErrorLabelTemplateInvalid          = "label template is not a valid template"
+	ErrorGtinExists                    = "gtin already exist"
+	ErrorNumberingSequenceInvalid      = "numbering sequence cannot make distinct codes"
+	ErrorNumberingSequenceTaken        = "numbering sequence would give a code that is already taken"
+	ErrorIdempotencyKeyInvalid         = "idempotency key must be at most 255 characters"
+	ErrorIdempotencyKeyReused          = "idempotency key was already sent with another request"
+	ErrorIdempotencyKeyInProgress      = "the request of the idempotency key is still being processed"
+	ErrorVersionMismatch               = "the record was changed since it was read"
+	ErrorIfMatchRequired               = "If-Match header with the ETag of the record is required"
+	ErrorRecordReferenced              = "the record is referenced by history and cannot be purged"
+	ErrorProductDeleted                = "the product of the quality is deleted"
 )
 
 type ErrorResponse struct {
@@ -41,12 +73,26 @@ type Pagination struct {
 	TotalPages   int `json:"total_pages"`
 }
 
+// CursorPagination points at the pages around a page paged by cursor. The total is only counted
+// when it is asked for.
+type CursorPagination struct {
+	Next         string `json:"next,omitempty"`
+	Prev         string `json:"prev,omitempty"`
+	NextCursor   string `json:"next_cursor,omitempty"`
+	PrevCursor   string `json:"prev_cursor,omitempty"`
+	TotalRecords *int   `json:"total_records,omitempty"`
+}
+
 type ApiResponse struct {
 	ctx        *fiber.Ctx
-	Code       int         `json:"code"`
-	Status     string      `json:"status"`
-	Data       interface{} `json:"data"`
-	Pagination *Pagination `json:"pagination,omitempty"`
+	fields     []string
+	etag       string
+	Code       int               `json:"code"`
+	Status     string            `json:"status"`
+	Data       interface{}       `json:"data"`
+	Pagination *Pagination       `json:"pagination,omitempty"`
+	Cursor     *CursorPagination `json:"cursor,omitempty"`
+	Summary    interface{}       `json:"summary,omitempty"`
 }
 
 func (r *ApiResponse) WithPagination(pagination *Pagination) *ApiResponse {
@@ -54,10 +100,88 @@ func (r *ApiResponse) WithPagination(pagination *Pagination) *ApiResponse {
 	return r
 }
 
+func (r *ApiResponse) WithCursor(cursor *CursorPagination) *ApiResponse {
+	r.Cursor = cursor
+	return r
+}
+
+// WithSummary adds totals over every record of the list, not only the ones of the page.
+func (r *ApiResponse) WithSummary(summary interface{}) *ApiResponse {
+	r.Summary = summary
+	return r
+}
+
+// WithETag tags the response with the ETag of the record it holds, such as the one of its
+// version. The etag middleware leaves tagged responses alone.
+func (r *ApiResponse) WithETag(etag string) *ApiResponse {
+	r.etag = etag
+	return r
+}
+
+// WithFields keeps only the given fields of the data, a record or a list of records. Every
+// field is kept when none is given.
+func (r *ApiResponse) WithFields(fields []string) *ApiResponse {
+	r.fields = fields
+	return r
+}
+
 func (r *ApiResponse) Build() error {
+	if len(r.fields) > 0 {
+		data, err := selectFields(r.Data, r.fields)
+		if err != nil {
+			return err
+		}
+		r.Data = data
+	}
+	if r.etag != "" {
+		r.ctx.Set(fiber.HeaderETag, r.etag)
+	}
+
 	return r.ctx.Status(r.Code).JSON(r)
 }
 
+func selectFields(data interface{}, fields []string) (interface{}, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	err = json.Unmarshal(encoded, &decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	records, ok := decoded.([]interface{})
+	if !ok {
+		records = []interface{}{decoded}
+	}
+	for _, record := range records {
+		recordMap, ok := record.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for key := range recordMap {
+			if !containsField(fields, key) {
+				delete(recordMap, key)
+			}
+		}
+	}
+
+	return decoded, nil
+}
+
+func containsField(fields []string, field string) bool {
+	for _, item := range fields {
+		if item == field {
+			return true
+		}
+	}
+
+	return false
+}
+
 func ReturnJSON(c *fiber.Ctx, code int, status string, data interface{}) *ApiResponse {
 	return &ApiResponse{
 		ctx:    c,
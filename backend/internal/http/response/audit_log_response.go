@@ -0,0 +1,14 @@
+package response
+
+import "encoding/json"
+
+type AuditLogResponse struct {
+	ID        int64           `json:"id"`
+	Actor     string          `json:"actor"`
+	RequestID *string         `json:"request_id"`
+	Entity    string          `json:"entity"`
+	EntityID  string          `json:"entity_id"`
+	Action    string          `json:"action"`
+	Changes   json.RawMessage `json:"changes"`
+	CreatedAt string          `json:"created_at"`
+}
@@ -4,7 +4,9 @@ type CustomerResponse struct {
 	ID           int64                  `json:"id"`
 	Code         string                 `json:"code"`
 	Name         string                 `json:"name"`
+	Version      int64                  `json:"version"`
 	CreatedAt    string                 `json:"created_at,omitempty"`
 	UpdatedAt    string                 `json:"updated_at,omitempty"`
+	DeletedAt    *string                `json:"deleted_at,omitempty"`
 	Transactions []*TransactionResponse `json:"transactions,omitempty"`
 }
@@ -0,0 +1,16 @@
+package response
+
+type ExportJobResponse struct {
+	Code   string `json:"code"`
+	Entity string `json:"entity"`
+	Format string `json:"format"`
+	// Query is the filters, sort, fields and includes of the exported list
+	Query  string  `json:"query"`
+	Status string  `json:"status"`
+	Rows   int64   `json:"rows"`
+	Error  *string `json:"error"`
+	// DownloadURL is set once the export is done
+	DownloadURL *string `json:"download_url"`
+	CreatedAt   string  `json:"created_at"`
+	CompletedAt *string `json:"completed_at"`
+}
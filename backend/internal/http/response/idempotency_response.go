@@ -0,0 +1,9 @@
+package response
+
+// IdempotentResponse is the response stored for an Idempotency-Key, sent as it is when the
+// request is repeated.
+type IdempotentResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
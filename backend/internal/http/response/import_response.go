@@ -0,0 +1,14 @@
+package response
+
+type ImportRowErrorResponse struct {
+	Row    int              `json:"row"`
+	Errors []*ErrorResponse `json:"errors"`
+}
+
+// ImportResponse reports an import. Nothing is imported when a row has errors or on a dry run.
+type ImportResponse struct {
+	DryRun    bool                      `json:"dry_run"`
+	TotalRows int                       `json:"total_rows"`
+	Imported  int                       `json:"imported"`
+	Errors    []*ImportRowErrorResponse `json:"errors"`
+}
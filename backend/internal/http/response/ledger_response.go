@@ -0,0 +1,29 @@
+package response
+
+type LedgerBrokenLinkResponse struct {
+	Sequence        int64  `json:"sequence,omitempty"`
+	TransactionCode string `json:"transaction_code,omitempty"`
+	Reason          string `json:"reason"`
+}
+
+type LedgerVerificationResponse struct {
+	Valid          bool                      `json:"valid"`
+	CheckedEntries int64                     `json:"checked_entries"`
+	LastSequence   int64                     `json:"last_sequence"`
+	LastHash       string                    `json:"last_hash"`
+	BrokenLink     *LedgerBrokenLinkResponse `json:"broken_link,omitempty"`
+}
+
+type LedgerCheckpointResponse struct {
+	ID        int64  `json:"id"`
+	Sequence  int64  `json:"sequence"`
+	Hash      string `json:"hash"`
+	Signature string `json:"signature"`
+	CreatedAt string `json:"created_at"`
+}
+
+type LedgerCheckpointExportResponse struct {
+	Algorithm   string                      `json:"algorithm"`
+	PublicKey   string                      `json:"public_key"`
+	Checkpoints []*LedgerCheckpointResponse `json:"checkpoints"`
+}
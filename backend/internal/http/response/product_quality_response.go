@@ -7,6 +7,9 @@ type ProductQualityResponse struct {
 	Price       int64            `json:"price"`
 	Quantity    float64          `json:"quantity"`
 	Type        string           `json:"type,omitempty"`
+	Gtin        *string          `json:"gtin,omitempty"`
+	Version     int64            `json:"version"`
+	DeletedAt   *string          `json:"deleted_at,omitempty"`
 	Product     *ProductResponse `json:"product,omitempty"`
 }
 
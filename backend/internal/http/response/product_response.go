@@ -6,7 +6,10 @@ type ProductResponse struct {
 	Name                string                    `json:"name"`
 	UnitMassAcronym     string                    `json:"unit_mass_acronym,omitempty"`
 	UnitMassDescription string                    `json:"unit_mass_description,omitempty"`
+	Gtin                *string                   `json:"gtin,omitempty"`
+	Version             int64                     `json:"version"`
 	CreatedAt           string                    `json:"created_at,omitempty"`
 	UpdatedAt           string                    `json:"updated_at,omitempty"`
+	DeletedAt           *string                   `json:"deleted_at,omitempty"`
 	ProductQualities    []*ProductQualityResponse `json:"product_qualities,omitempty"`
 }
@@ -0,0 +1,19 @@
+package response
+
+// ScanResponse is what a scanned value identifies: the type of the entity, the entity and the
+// actions a scanner can take on it next.
+type ScanResponse struct {
+	Value   string                `json:"value"`
+	Type    string                `json:"type"`
+	Data    interface{}           `json:"data"`
+	Actions []*ScanActionResponse `json:"actions"`
+}
+
+// ScanActionResponse is a request of the API. Payload prefills the body of the requests that
+// create something, with what the scan already tells.
+type ScanActionResponse struct {
+	Name    string                 `json:"name"`
+	Method  string                 `json:"method"`
+	Path    string                 `json:"path"`
+	Payload map[string]interface{} `json:"payload,omitempty"`
+}
@@ -0,0 +1,12 @@
+package response
+
+type SearchOutboxStatsResponse struct {
+	Pending int64 `json:"pending"`
+	Failing int64 `json:"failing"`
+	// LagSeconds is the age of the oldest event still waiting to be relayed
+	LagSeconds float64 `json:"lag_seconds"`
+	// Relayed and Failures count the events since the server started
+	Relayed       int64   `json:"relayed"`
+	Failures      int64   `json:"failures"`
+	LastRelayedAt *string `json:"last_relayed_at"`
+}
@@ -0,0 +1,123 @@
+package response
+
+import "time"
+
+type SearchResponse struct {
+	Total  int64                             `json:"-"`
+	Hits   []map[string]interface{}          `json:"hits"`
+	Facets map[string][]*FacetBucketResponse `json:"facets"`
+}
+
+type UserSearchResponse struct {
+	Total  int64                             `json:"-"`
+	Hits   []*UserDocument                   `json:"hits"`
+	Facets map[string][]*FacetBucketResponse `json:"facets"`
+}
+
+type ReindexResponse struct {
+	Alias     string `json:"alias"`
+	Index     string `json:"index"`
+	Documents int64  `json:"documents"`
+	// DeletedIndices are the versions the alias pointed to before the swap
+	DeletedIndices []string `json:"deleted_indices"`
+}
+
+// SuggestResponse holds the suggestions of every kind, each ranked by the search engine.
+type SuggestResponse struct {
+	Products         []*SuggestionResponse `json:"products"`
+	ProductQualities []*SuggestionResponse `json:"product_qualities"`
+	Suppliers        []*SuggestionResponse `json:"suppliers"`
+	Customers        []*SuggestionResponse `json:"customers"`
+}
+
+type SuggestionResponse struct {
+	ID   int64  `json:"id"`
+	Code string `json:"code"`
+	Text string `json:"text"`
+}
+
+type SearchSynonymResponse struct {
+	ID        int64    `json:"id"`
+	Words     []string `json:"words"`
+	CreatedAt string   `json:"created_at,omitempty"`
+	UpdatedAt string   `json:"updated_at,omitempty"`
+}
+
+type FacetBucketResponse struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type ProductDocument struct {
+	ID                  int64     `json:"id"`
+	Code                string    `json:"code"`
+	Name                string    `json:"name"`
+	UnitMassAcronym     string    `json:"unit_mass_acronym"`
+	UnitMassDescription string    `json:"unit_mass_description"`
+	Qualities           []string  `json:"qualities"`
+	QualityTypes        []string  `json:"quality_types"`
+	MinPrice            int64     `json:"min_price"`
+	MaxPrice            int64     `json:"max_price"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+type ProductQualityDocument struct {
+	ID              int64   `json:"id"`
+	ProductCode     string  `json:"product_code"`
+	ProductName     string  `json:"product_name"`
+	Quality         string  `json:"quality"`
+	Type            string  `json:"type"`
+	Price           int64   `json:"price"`
+	Quantity        float64 `json:"quantity"`
+	UnitMassAcronym string  `json:"unit_mass_acronym"`
+}
+
+type TransactionDocument struct {
+	ID                          int64     `json:"id"`
+	Code                        string    `json:"code"`
+	Type                        string    `json:"type"`
+	Quantity                    float64   `json:"quantity"`
+	UnitMassAcronym             string    `json:"unit_mass_acronym"`
+	Description                 string    `json:"description,omitempty"`
+	ProductQualityID            int64     `json:"product_quality_id"`
+	ProductCode                 string    `json:"product_code,omitempty"`
+	ProductName                 string    `json:"product_name,omitempty"`
+	Quality                     string    `json:"quality,omitempty"`
+	ProductQualityIDTransferred int64     `json:"product_quality_id_transferred,omitempty"`
+	QualityTransferred          string    `json:"quality_transferred,omitempty"`
+	SupplierCode                string    `json:"supplier_code,omitempty"`
+	SupplierName                string    `json:"supplier_name,omitempty"`
+	CustomerCode                string    `json:"customer_code,omitempty"`
+	CustomerName                string    `json:"customer_name,omitempty"`
+	CreatedAt                   time.Time `json:"created_at"`
+	UpdatedAt                   time.Time `json:"updated_at"`
+}
+
+type SupplierDocument struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	Phone     string    `json:"phone"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CustomerDocument struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type UserDocument struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Username  string    `json:"username"`
+	Email     *string   `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,36 @@
+package response
+
+type BrandingSettingResponse struct {
+	CompanyName string `json:"company_name"`
+	Address     string `json:"address"`
+	Phone       string `json:"phone"`
+	Email       string `json:"email"`
+	Footer      string `json:"footer"`
+	// Logo is the base64 encoded image
+	Logo      *string `json:"logo"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+type LabelTemplateResponse struct {
+	LabelType string `json:"label_type"`
+	Symbology string `json:"symbology"`
+	Zpl       string `json:"zpl"`
+	// Default is whether the built-in template is used, the tenant having saved none
+	Default   bool    `json:"default"`
+	UpdatedAt *string `json:"updated_at"`
+}
+
+type NumberingSequenceResponse struct {
+	Entity          string `json:"entity"`
+	TransactionType string `json:"transaction_type"`
+	Strategy        string `json:"strategy"`
+	Pattern         string `json:"pattern"`
+	Padding         int    `json:"padding"`
+	Reset           string `json:"reset"`
+	NextValue       int64  `json:"next_value"`
+	// Example is the code the next record would be given, empty for random codes
+	Example string `json:"example"`
+	// Default is whether codes are random, the tenant having saved no sequence
+	Default   bool    `json:"default"`
+	UpdatedAt *string `json:"updated_at"`
+}
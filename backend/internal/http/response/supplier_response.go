@@ -6,7 +6,9 @@ type SupplierResponse struct {
 	Name         string                 `json:"name"`
 	Address      string                 `json:"address"`
 	Phone        string                 `json:"phone"`
+	Version      int64                  `json:"version"`
 	CreatedAt    string                 `json:"created_at,omitempty"`
 	UpdatedAt    string                 `json:"updated_at,omitempty"`
+	DeletedAt    *string                `json:"deleted_at,omitempty"`
 	Transactions []*TransactionResponse `json:"transactions,omitempty"`
 }
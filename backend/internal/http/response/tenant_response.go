@@ -0,0 +1,11 @@
+package response
+
+type TenantResponse struct {
+	ID        int64         `json:"id"`
+	Code      string        `json:"code"`
+	Name      string        `json:"name"`
+	ApiKey    string        `json:"api_key,omitempty"`
+	Admin     *UserResponse `json:"admin,omitempty"`
+	CreatedAt string        `json:"created_at,omitempty"`
+	UpdatedAt string        `json:"updated_at,omitempty"`
+}
@@ -12,9 +12,30 @@ type TransactionResponse struct {
 	CustomerCode                *string                 `json:"customer_code,omitempty"`
 	Customer                    *CustomerResponse       `json:"customer,omitempty"`
 	Description                 *string                 `json:"description,omitempty"`
+	Reference                   *string                 `json:"reference,omitempty"`
 	Quantity                    float64                 `json:"quantity"`
 	Type                        string                  `json:"type"`
 	UnitMassAcronym             string                  `json:"unit_mass_acronym"`
+	Version                     int64                   `json:"version"`
 	CreatedAt                   string                  `json:"created_at,omitempty"`
 	UpdatedAt                   string                  `json:"updated_at,omitempty"`
 }
+
+// TransactionSummaryResponse totals the quantities of the listed transactions in one unit of mass.
+type TransactionSummaryResponse struct {
+	In              float64 `json:"in"`
+	Out             float64 `json:"out"`
+	UnitMassAcronym string  `json:"unit_mass_acronym"`
+}
+
+type TransactionBatchLineErrorResponse struct {
+	Line   int              `json:"line"`
+	Errors []*ErrorResponse `json:"errors"`
+}
+
+// TransactionBatchResponse reports a batch of transactions. Nothing is posted when a line has
+// errors.
+type TransactionBatchResponse struct {
+	Transactions []*TransactionResponse               `json:"transactions"`
+	Errors       []*TransactionBatchLineErrorResponse `json:"errors"`
+}
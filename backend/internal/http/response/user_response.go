@@ -1,13 +1,33 @@
 package response
 
 type UserResponse struct {
-	ID        int64  `json:"id"`
-	Name      string `json:"name"`
-	Username  string `json:"username"`
-	CreatedAt string `json:"created_at,omitempty"`
-	UpdatedAt string `json:"updated_at,omitempty"`
+	ID        int64   `json:"id"`
+	Name      string  `json:"name"`
+	Username  string  `json:"username"`
+	Email     *string `json:"email,omitempty"`
+	Role      string  `json:"role,omitempty"`
+	CreatedAt string  `json:"created_at,omitempty"`
+	UpdatedAt string  `json:"updated_at,omitempty"`
+	DeletedAt *string `json:"deleted_at,omitempty"`
 }
 
 type UserLoginResponse struct {
 	Token string `json:"token"`
 }
+
+type OidcLoginResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+	// Cookie is the signed state, set as a cookie binding the login to the browser
+	Cookie string `json:"-"`
+}
+
+type InvitationResponse struct {
+	ID        int64  `json:"id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	InvitedBy string `json:"invited_by"`
+	Token     string `json:"token,omitempty"`
+	ExpiresAt string `json:"expires_at"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
@@ -1,6 +1,11 @@
 package http
 
 import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
 	"github.com/elastic/go-elasticsearch/v7"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/etag"
@@ -11,79 +16,350 @@ import (
 	"inventory-management/backend/internal/http/controller"
 	"inventory-management/backend/internal/http/middleware"
 	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
 	"inventory-management/backend/internal/repository"
 	"inventory-management/backend/internal/service"
 	"inventory-management/backend/internal/third_party/elasticsearch"
+	notifier "inventory-management/backend/internal/third_party/notifier"
+	oidc "inventory-management/backend/internal/third_party/oidc"
+	pdf "inventory-management/backend/internal/third_party/pdf"
+	search "inventory-management/backend/internal/third_party/search"
+	"inventory-management/backend/util"
+	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 func NewInitializedRoutes(configuration config.Config, logFile *os.File) (*fiber.App, error) {
-	// Init app and middlewares
-	app := fiber.New(middleware.FiberConfig())
-	app.Use(etag.New())
-	app.Use(requestid.New())
-	app.Use(recover.New())
-	app.Use(middleware.XApiKeyMiddleware(configuration))
-	app.Use(middleware.NewCORSMiddleware())
-	app.Use(middleware.NewLoggerMiddleware(logFile))
-	app.Use(middleware.NewCSRFMiddleware(configuration))
-
 	// Init database
 	db, err := config.NewPostgresSQLGorm(configuration)
 	if err != nil {
 		return nil, err
 	}
 
-	es, err := elasticsearch.NewDefaultClient()
+	// Every repository is scoped to the tenant of the request context
+	err = db.Use(repository.NewTenantPlugin())
 	if err != nil {
 		return nil, err
 	}
 
-	// Register the routes
-	NewRoutes(db, app, es)
+	err = db.Use(repository.NewSoftDeletePlugin())
+	if err != nil {
+		return nil, err
+	}
+
+	searchEngine, err := NewSearchEngine(configuration, db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register the middlewares and routes
+	app := fiber.New(middleware.FiberConfig())
+	NewRoutes(configuration, db, app, searchEngine, logFile)
 
 	return app, nil
 }
 
-func NewRoutes(db *gorm.DB, app *fiber.App, es *elasticsearch.Client) {
+func NewRoutes(configuration config.Config, db *gorm.DB, app *fiber.App, searchEngine search.SearchEngineContract, logFile *os.File) {
 	// Init third party services
-	userElasticsearch := third_party.NewElasticsearch(es)
+	accountNotifier := NewNotifier(configuration)
+	documentRenderer := pdf.NewFpdfRenderer()
 
 	// Init repositories
+	transactor := repository.NewTransactor(db)
+	tenantRepository := repository.NewTenantRepository(db)
+	auditLogRepository := repository.NewAuditLogRepository(db)
 	userRepository := repository.NewUserRepository(db)
+	invitationRepository := repository.NewInvitationRepository(db)
+	passwordResetRepository := repository.NewPasswordResetRepository(db)
 	transactionRepository := repository.NewTransactionRepository(db)
 	customerRepository := repository.NewCustomerRepository(db)
 	productQualityRepository := repository.NewProductQualityRepository(db)
 	productRepository := repository.NewProductRepository(db)
 	supplierRepository := repository.NewSupplierRepository(db)
-	txRepository := repository.NewTxRepository(db, transactionRepository, productQualityRepository)
+	ledgerRepository := repository.NewLedgerRepository(db)
+	searchOutboxRepository := repository.NewSearchOutboxRepository(db)
+	searchSynonymRepository := repository.NewSearchSynonymRepository(db)
+	exportJobRepository := repository.NewExportJobRepository(db)
+	settingRepository := repository.NewSettingRepository(db)
+	idempotencyKeyRepository := repository.NewIdempotencyKeyRepository(db)
+	txRepository := repository.NewTxRepository(db, transactionRepository, productQualityRepository, ledgerRepository)
 
 	// Init services
-	userService := service.NewUserService(userRepository, userElasticsearch)
-	customerService := service.NewCustomerService(customerRepository)
-	productQualityService := service.NewProductQualityService(productQualityRepository, productRepository)
-	productService := service.NewProductService(productRepository)
-	supplierService := service.NewSupplierService(supplierRepository)
-	transactionService := service.NewTransactionService(transactionRepository, productQualityRepository, txRepository)
+	auditService := service.NewAuditService(auditLogRepository)
+	userService := service.NewUserService(userRepository, searchEngine, transactor, auditService)
+	accountService := service.NewAccountService(userRepository, invitationRepository, passwordResetRepository, transactor, userService, accountNotifier, configuration.Get("APP_FRONTEND_URL"))
+	searchService := service.NewSearchService(searchEngine, productRepository, productQualityRepository, transactionRepository, supplierRepository, customerRepository, userRepository, searchSynonymRepository)
+	searchSynonymService := service.NewSearchSynonymService(searchSynonymRepository, transactor, auditService)
+	searchOutboxService := service.NewSearchOutboxService(searchOutboxRepository, searchService)
+	analyticsService := service.NewAnalyticsService(searchEngine, NewAnalyticsCacheTTL(configuration))
+	customerService := service.NewCustomerService(customerRepository, transactor, auditService)
+	productQualityService := service.NewProductQualityService(productQualityRepository, productRepository, transactor, auditService)
+	productService := service.NewProductService(productRepository, productQualityRepository, transactor, auditService)
+	supplierService := service.NewSupplierService(supplierRepository, transactor, auditService)
+	transactionService := service.NewTransactionService(transactionRepository, productQualityRepository, txRepository, transactor, auditService)
+	ledgerService := service.NewLedgerService(ledgerRepository, transactionRepository, NewLedgerSigningKey(configuration))
+	tenantService := service.NewTenantService(tenantRepository, userRepository, userService, transactor, auditService)
+	importService := service.NewImportService(productRepository, productQualityRepository, supplierRepository, customerRepository, txRepository, transactor, auditService)
+	exportService := service.NewExportService(exportJobRepository, productService, supplierService, customerService, userService, transactionService, NewExportDirectory(configuration), NewExportSyncLimit(configuration), NewExportTTL(configuration))
+	settingService := service.NewSettingService(settingRepository, tenantRepository, transactor, auditService)
+	scanService := service.NewScanService(productRepository, productQualityRepository, transactionRepository, supplierRepository, customerRepository)
+	labelService := service.NewLabelService(productQualityRepository, transactionRepository, settingRepository)
+	idempotencyService := service.NewIdempotencyService(idempotencyKeyRepository, NewIdempotencyKeyTTL(configuration))
+	documentService := service.NewDocumentService(transactionRepository, productQualityRepository, settingRepository, tenantRepository, documentRenderer)
+
+	// Changes reach Elasticsearch through the search outbox
+	go searchOutboxService.Run(context.Background())
+	go exportService.Run(context.Background())
+	go idempotencyService.Run(context.Background())
+
+	// Init middlewares
+	// Exports are streamed, tagging them would read them whole
+	app.Use(etag.New(etag.Config{Next: controller.ExportRequested}))
+	app.Use(requestid.New())
+	app.Use(middleware.NewRequestContextMiddleware())
+	app.Use(recover.New())
+	app.Use(middleware.XApiKeyMiddleware(configuration, tenantService))
+	app.Use(middleware.NewCORSMiddleware())
+	app.Use(middleware.NewLoggerMiddleware(logFile))
+	app.Use(middleware.NewCSRFMiddleware(configuration))
 
 	// Init controllers and routes
 	prefix := app.Group("/api")
 	app.Get("/", WelcomeHandler)
 
-	controller.NewAuthController(userService, prefix)
+	controller.NewAuthController(userService, accountService, prefix)
 
-	app.Use(middleware.NewJWTMiddleware())
+	var oidcService service.OidcServiceContract
+	if configuration.Get("OIDC_ISSUER") != "" {
+		userOidc := oidc.NewOidc(oidc.OidcConfig{
+			Issuer:       configuration.Get("OIDC_ISSUER"),
+			ClientID:     configuration.Get("OIDC_CLIENT_ID"),
+			ClientSecret: configuration.Get("OIDC_CLIENT_SECRET"),
+			RedirectURL:  configuration.Get("OIDC_REDIRECT_URL"),
+			Scopes:       strings.Fields(configuration.Get("OIDC_SCOPES")),
+			GroupsClaim:  configuration.Get("OIDC_GROUPS_CLAIM"),
+		}, nil)
+		groupRoles := util.ParseKeyValueList(configuration.Get("OIDC_GROUP_ROLES"))
+		oidcService = service.NewOidcService(userRepository, repository.NewOidcLoginStateRepository(db), userOidc, transactor, auditService, groupRoles, configuration.Get("OIDC_DEFAULT_ROLE"), NewOidcLinkVerifiedEmail(configuration), NewOidcStateKey(configuration))
+		go oidcService.Run(context.Background())
+		controller.NewOidcController(oidcService, prefix)
+	}
+
+	app.Use(middleware.NewJWTMiddleware(userService))
+	prefix.Use("/users", middleware.NewRoleMiddleware(model.RoleAdmin))
+	prefix.Use("/invitations", middleware.NewRoleMiddleware(model.RoleAdmin))
+	prefix.Use("/audit", middleware.NewRoleMiddleware(model.RoleAdmin))
+	prefix.Use("/ledger", middleware.NewRoleMiddleware(model.RoleAdmin))
+	prefix.Use("/tenants", middleware.NewRoleMiddleware(model.RoleAdmin), middleware.NewOperatorTenantMiddleware())
+	prefix.Use("/search-indices", middleware.NewRoleMiddleware(model.RoleAdmin))
+	prefix.Use("/search-synonyms", middleware.NewRoleMiddleware(model.RoleAdmin))
+	prefix.Use("/search-outbox", middleware.NewRoleMiddleware(model.RoleAdmin), middleware.NewOperatorTenantMiddleware())
+	prefix.Use("/imports", middleware.NewRoleMiddleware(model.RoleAdmin))
+	prefix.Use("/settings", middleware.NewRoleMiddleware(model.RoleAdmin))
+	// Deleting master data for good is left to administrators, anyone else can only soft delete
+	prefix.Use([]string{"/products/:code/purge", "/product-qualities/:id/purge", "/suppliers/:code/purge", "/customers/:code/purge"}, middleware.NewRoleMiddleware(model.RoleAdmin))
+	prefix.Use(middleware.NewIdempotencyMiddleware(idempotencyService))
+	prefix.Use([]string{"/products", "/product-qualities", "/suppliers", "/customers", "/transactions"}, middleware.NewIfMatchMiddleware(NewIfMatchRequired(configuration)))
 
-	controller.NewUserController(userService, prefix)
-	controller.NewCustomerController(customerService, prefix)
+	controller.NewAccountController(accountService, prefix)
+	if oidcService != nil {
+		controller.NewOidcLinkController(oidcService, prefix)
+	}
+	controller.NewUserController(userService, exportService, prefix)
+	controller.NewAuditController(auditService, prefix)
+	controller.NewSearchController(searchService, prefix)
+	controller.NewSearchSynonymController(searchSynonymService, prefix)
+	controller.NewAnalyticsController(analyticsService, prefix)
+	controller.NewCustomerController(customerService, exportService, prefix)
 	controller.NewProductQualityController(productQualityService, prefix)
-	controller.NewProductController(productService, prefix)
-	controller.NewSupplierController(supplierService, prefix)
-	controller.NewTransactionController(transactionService, prefix)
+	controller.NewProductController(productService, exportService, prefix)
+	controller.NewSupplierController(supplierService, exportService, prefix)
+	controller.NewTransactionController(transactionService, exportService, prefix)
+	controller.NewLedgerController(ledgerService, prefix)
+	controller.NewTenantController(tenantService, prefix)
+	controller.NewSearchOutboxController(searchOutboxService, prefix)
+	controller.NewImportController(importService, prefix)
+	controller.NewExportController(exportService, prefix)
+	controller.NewSettingController(settingService, prefix)
+	controller.NewDocumentController(documentService, prefix)
+	controller.NewLabelController(labelService, prefix)
+	controller.NewScanController(scanService, prefix)
 
 	app.Get("*", NotFoundHandler)
 }
 
+// NewSearchEngine returns the search engine SEARCH_DRIVER selects: elasticsearch, the default,
+// postgres or memory. Only Elasticsearch needs a server of its own.
+func NewSearchEngine(configuration config.Config, db *gorm.DB) (search.SearchEngineContract, error) {
+	switch configuration.Get("SEARCH_DRIVER") {
+	case "", "elasticsearch":
+		es, err := elasticsearch.NewDefaultClient()
+		if err != nil {
+			return nil, err
+		}
+		return search.NewElasticsearchEngine(third_party.NewElasticsearch(es), service.SearchMappings), nil
+	case "postgres":
+		return search.NewPostgresEngine(db), nil
+	case "memory":
+		return search.NewMemoryEngine(), nil
+	default:
+		return nil, fmt.Errorf("unknown SEARCH_DRIVER %q", configuration.Get("SEARCH_DRIVER"))
+	}
+}
+
+// NewAnalyticsCacheTTL returns how long analytics results are cached, ANALYTICS_CACHE_TTL being a
+// duration such as 30s or 5m. A zero duration disables the cache.
+func NewAnalyticsCacheTTL(configuration config.Config) time.Duration {
+	if configuration.Get("ANALYTICS_CACHE_TTL") == "" {
+		return 5 * time.Minute
+	}
+
+	cacheTTL, err := time.ParseDuration(configuration.Get("ANALYTICS_CACHE_TTL"))
+	if err != nil {
+		log.Fatalln("Invalid ANALYTICS_CACHE_TTL", err)
+	}
+
+	return cacheTTL
+}
+
+// NewIdempotencyKeyTTL returns how long the response to an Idempotency-Key is replayed,
+// IDEMPOTENCY_KEY_TTL being a duration such as 1h or 24h.
+func NewIdempotencyKeyTTL(configuration config.Config) time.Duration {
+	if configuration.Get("IDEMPOTENCY_KEY_TTL") == "" {
+		return 24 * time.Hour
+	}
+
+	ttl, err := time.ParseDuration(configuration.Get("IDEMPOTENCY_KEY_TTL"))
+	if err != nil || ttl <= 0 {
+		log.Fatalln("Invalid IDEMPOTENCY_KEY_TTL", err)
+	}
+
+	return ttl
+}
+
+// NewIfMatchRequired returns whether IF_MATCH_REQUIRED makes changes of versioned records
+// without If-Match fail, so no client overwrites a change it has not read.
+func NewIfMatchRequired(configuration config.Config) bool {
+	if configuration.Get("IF_MATCH_REQUIRED") == "" {
+		return false
+	}
+
+	required, err := strconv.ParseBool(configuration.Get("IF_MATCH_REQUIRED"))
+	if err != nil {
+		log.Fatalln("Invalid IF_MATCH_REQUIRED", err)
+	}
+
+	return required
+}
+
+// NewOidcLinkVerifiedEmail returns whether OIDC_LINK_VERIFIED_EMAIL lets the first single
+// sign-on link the local account owning the verified email of the identity. Only enable it for
+// identity providers that verify emails themselves.
+func NewOidcLinkVerifiedEmail(configuration config.Config) bool {
+	if configuration.Get("OIDC_LINK_VERIFIED_EMAIL") == "" {
+		return false
+	}
+
+	link, err := strconv.ParseBool(configuration.Get("OIDC_LINK_VERIFIED_EMAIL"))
+	if err != nil {
+		log.Fatalln("Invalid OIDC_LINK_VERIFIED_EMAIL", err)
+	}
+
+	return link
+}
+
+// NewOidcStateKey returns the key OIDC_STATE_KEY encodes in base64, which signs the cookie
+// binding a single sign-on to the browser that started it. Every instance of the API needs the
+// same key; without one a random key is used, which only this instance knows.
+func NewOidcStateKey(configuration config.Config) []byte {
+	if configuration.Get("OIDC_STATE_KEY") == "" {
+		log.Println("OIDC_STATE_KEY is not set, single sign-ons can only complete on this instance")
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			log.Fatalln("Cannot generate an OIDC state key", err)
+		}
+		return key
+	}
+
+	key, err := base64.StdEncoding.DecodeString(configuration.Get("OIDC_STATE_KEY"))
+	if err != nil || len(key) < 32 {
+		log.Fatalln("Invalid OIDC_STATE_KEY, it must be at least 32 bytes encoded in base64", err)
+	}
+
+	return key
+}
+
+// NewExportDirectory returns the directory EXPORT_DIR names for the files of export jobs.
+func NewExportDirectory(configuration config.Config) string {
+	if configuration.Get("EXPORT_DIR") == "" {
+		return "./exports"
+	}
+
+	return configuration.Get("EXPORT_DIR")
+}
+
+// NewExportSyncLimit returns EXPORT_SYNC_LIMIT, the most records an export streams within the
+// request before it is queued as a background job.
+func NewExportSyncLimit(configuration config.Config) int64 {
+	if configuration.Get("EXPORT_SYNC_LIMIT") == "" {
+		return 10000
+	}
+
+	syncLimit, err := strconv.ParseInt(configuration.Get("EXPORT_SYNC_LIMIT"), 10, 64)
+	if err != nil {
+		log.Fatalln("Invalid EXPORT_SYNC_LIMIT", err)
+	}
+
+	return syncLimit
+}
+
+// NewExportTTL returns how long the file of a finished export job is kept, EXPORT_TTL being a
+// duration such as 1h or 24h.
+func NewExportTTL(configuration config.Config) time.Duration {
+	if configuration.Get("EXPORT_TTL") == "" {
+		return 24 * time.Hour
+	}
+
+	ttl, err := time.ParseDuration(configuration.Get("EXPORT_TTL"))
+	if err != nil || ttl <= 0 {
+		log.Fatalln("Invalid EXPORT_TTL", err)
+	}
+
+	return ttl
+}
+
+func NewNotifier(configuration config.Config) notifier.NotifierContract {
+	if configuration.Get("NOTIFIER_DRIVER") == "smtp" {
+		return notifier.NewSmtpNotifier(notifier.SmtpConfig{
+			Host:     configuration.Get("SMTP_HOST"),
+			Port:     configuration.Get("SMTP_PORT"),
+			Username: configuration.Get("SMTP_USERNAME"),
+			Password: configuration.Get("SMTP_PASSWORD"),
+			From:     configuration.Get("SMTP_FROM"),
+		})
+	}
+
+	return notifier.NewLogNotifier(log.Default())
+}
+
+// NewLedgerSigningKey returns the key used to sign ledger checkpoints, or nil when
+// LEDGER_SIGNING_KEY is not set and checkpoints are disabled.
+func NewLedgerSigningKey(configuration config.Config) ed25519.PrivateKey {
+	if configuration.Get("LEDGER_SIGNING_KEY") == "" {
+		return nil
+	}
+
+	signingKey, err := util.ParseSigningKey(configuration.Get("LEDGER_SIGNING_KEY"))
+	if err != nil {
+		log.Fatalln("Invalid LEDGER_SIGNING_KEY", err)
+	}
+
+	return signingKey
+}
+
 func NotFoundHandler(c *fiber.Ctx) error {
 	return response.ReturnJSON(c, fiber.StatusNotFound, "the requested resource was not found", nil).Build()
 }
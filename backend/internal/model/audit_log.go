@@ -0,0 +1,56 @@
+package model
+
+import (
+	"encoding/json"
+	"inventory-management/backend/internal/http/response"
+	"time"
+)
+
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+	AuditActionPurge   = "purge"
+)
+
+const (
+	AuditEntityTenant         = "tenant"
+	AuditEntityUser           = "user"
+	AuditEntityProduct        = "product"
+	AuditEntityProductQuality = "product_quality"
+	AuditEntitySupplier       = "supplier"
+	AuditEntityCustomer       = "customer"
+	AuditEntityTransaction    = "transaction"
+	AuditEntitySearchSynonym  = "search_synonym"
+	AuditEntitySetting        = "setting"
+)
+
+// AuditActorSystem is recorded when a change is not made on behalf of an authenticated user,
+// e.g. self registration or single sign-on provisioning.
+const AuditActorSystem = "system"
+
+type AuditLog struct {
+	ID        int64
+	TenantID  int64 `gorm:"default:1"`
+	Actor     string
+	RequestID *string
+	Entity    string
+	EntityID  string
+	Action    string
+	Changes   string `gorm:"type:jsonb"`
+	CreatedAt time.Time
+}
+
+func (a *AuditLog) ToResponse() *response.AuditLogResponse {
+	return &response.AuditLogResponse{
+		ID:        a.ID,
+		Actor:     a.Actor,
+		RequestID: a.RequestID,
+		Entity:    a.Entity,
+		EntityID:  a.EntityID,
+		Action:    a.Action,
+		Changes:   json.RawMessage(a.Changes),
+		CreatedAt: a.CreatedAt.Local().String(),
+	}
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"encoding/base64"
+	"inventory-management/backend/internal/http/response"
+	"time"
+)
+
+// BrandingSetting is the company a tenant issues its documents as.
+type BrandingSetting struct {
+	ID          int64
+	TenantID    int64 `gorm:"default:1"`
+	CompanyName string
+	Address     string
+	Phone       string
+	Email       string
+	Footer      string
+	Logo        []byte
+	// LogoType is PNG or JPG, the image types PDF documents embed
+	LogoType  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (b *BrandingSetting) ToResponse() *response.BrandingSettingResponse {
+	brandingResponse := &response.BrandingSettingResponse{
+		CompanyName: b.CompanyName,
+		Address:     b.Address,
+		Phone:       b.Phone,
+		Email:       b.Email,
+		Footer:      b.Footer,
+		UpdatedAt:   b.UpdatedAt.Local().String(),
+	}
+	if len(b.Logo) > 0 {
+		logo := base64.StdEncoding.EncodeToString(b.Logo)
+		brandingResponse.Logo = &logo
+	}
+
+	return brandingResponse
+}
@@ -9,15 +9,40 @@ import (
 
 type Customer struct {
 	ID           int64
+	TenantID     int64 `gorm:"default:1"`
 	Code         string
 	Name         string
+	Version      int64 `gorm:"default:1"`
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt
 	Transactions []*Transaction `gorm:"foreignKey:CustomerCode;references:Code"`
 }
 
+// CustomerListSchema whitelists the query of the customer list.
+var CustomerListSchema = &util.ListSchema{
+	Filters: map[string]string{
+		"id":         util.ListFieldNumber,
+		"code":       util.ListFieldText,
+		"name":       util.ListFieldText,
+		"created_at": util.ListFieldTime,
+		"updated_at": util.ListFieldTime,
+		"deleted_at": util.ListFieldTime,
+	},
+	Sorts:  []string{"id", "code", "name", "created_at", "updated_at"},
+	Fields: []string{"id", "code", "name", "version", "created_at", "updated_at", "deleted_at"},
+	Includes: map[string][]string{
+		"transactions": {"Transactions", "Transactions.ProductQuality", "Transactions.ProductQuality.Product"},
+	},
+	SoftDelete: true,
+}
+
 func (c *Customer) BeforeCreate(tx *gorm.DB) error {
-	c.Code, _ = util.GenerateRandomString(10)
+	code, err := assignCode(tx, NumberingEntityCustomer, "")
+	if err != nil {
+		return err
+	}
+	c.Code = code
 
 	return nil
 }
@@ -27,8 +52,10 @@ func (c *Customer) ToResponse() *response.CustomerResponse {
 		ID:        c.ID,
 		Code:      c.Code,
 		Name:      c.Name,
+		Version:   c.Version,
 		CreatedAt: c.CreatedAt.Local().String(),
 		UpdatedAt: c.UpdatedAt.Local().String(),
+		DeletedAt: deletedAtResponse(c.DeletedAt),
 	}
 }
 
@@ -42,8 +69,24 @@ func (c *Customer) ToResponseWithAssociations() *response.CustomerResponse {
 		ID:           c.ID,
 		Code:         c.Code,
 		Name:         c.Name,
+		Version:      c.Version,
 		CreatedAt:    c.CreatedAt.Local().String(),
 		UpdatedAt:    c.UpdatedAt.Local().String(),
+		DeletedAt:    deletedAtResponse(c.DeletedAt),
 		Transactions: transactionResponses,
 	}
 }
+
+func (c *Customer) ToSearchDocument() *response.CustomerDocument {
+	return &response.CustomerDocument{
+		ID:        c.ID,
+		Code:      c.Code,
+		Name:      c.Name,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func (c *Customer) ToSearchOutboxEvent(operation string) *SearchOutboxEvent {
+	return newSearchOutboxEvent(c.TenantID, SearchIndexCustomers, c.Code, c.ID, operation)
+}
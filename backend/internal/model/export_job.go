@@ -0,0 +1,74 @@
+package model
+
+import (
+	"gorm.io/gorm"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/util"
+	"time"
+)
+
+const (
+	ExportStatusPending = "pending"
+	ExportStatusRunning = "running"
+	ExportStatusDone    = "done"
+	ExportStatusFailed  = "failed"
+)
+
+// The lists that can be exported, named like their endpoints.
+const (
+	ExportEntityProducts     = "products"
+	ExportEntitySuppliers    = "suppliers"
+	ExportEntityCustomers    = "customers"
+	ExportEntityUsers        = "users"
+	ExportEntityTransactions = "transactions"
+)
+
+// ExportJob exports a list in the background into a file its requester downloads once it is
+// done. Query is the query string of the list request, so the job exports what the list
+// endpoint would have returned.
+type ExportJob struct {
+	ID          int64
+	TenantID    int64 `gorm:"default:1"`
+	Code        string
+	RequestedBy string
+	Entity      string
+	Format      string
+	Query       string
+	Status      string `gorm:"default:pending"`
+	RowCount    int64
+	FilePath    *string
+	Attempts    int
+	LastError   *string
+	AvailableAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	CompletedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (e *ExportJob) BeforeCreate(tx *gorm.DB) error {
+	e.Code, _ = util.GenerateRandomString(20)
+
+	return nil
+}
+
+func (e *ExportJob) ToResponse() *response.ExportJobResponse {
+	exportResponse := &response.ExportJobResponse{
+		Code:      e.Code,
+		Entity:    e.Entity,
+		Format:    e.Format,
+		Query:     e.Query,
+		Status:    e.Status,
+		Rows:      e.RowCount,
+		Error:     e.LastError,
+		CreatedAt: e.CreatedAt.Local().String(),
+	}
+	if e.Status == ExportStatusDone {
+		exportResponse.DownloadURL = util.ToPointerString("/api/exports/" + e.Code + "/download")
+	}
+	if e.CompletedAt != nil {
+		completedAt := e.CompletedAt.Local().String()
+		exportResponse.CompletedAt = &completedAt
+	}
+
+	return exportResponse
+}
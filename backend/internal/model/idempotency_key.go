@@ -0,0 +1,30 @@
+package model
+
+import (
+	"inventory-management/backend/internal/http/response"
+	"time"
+)
+
+// IdempotencyKey is an Idempotency-Key a user sent with a request, and the response it got,
+// which is sent again when the request is repeated with the key. StatusCode is 0 while the
+// request is processed.
+type IdempotencyKey struct {
+	ID          int64
+	TenantID    int64 `gorm:"default:1"`
+	Username    string
+	Key         string
+	Fingerprint string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+func (i *IdempotencyKey) ToResponse() *response.IdempotentResponse {
+	return &response.IdempotentResponse{
+		StatusCode:  i.StatusCode,
+		ContentType: i.ContentType,
+		Body:        i.Body,
+	}
+}
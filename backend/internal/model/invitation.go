@@ -0,0 +1,33 @@
+package model
+
+import (
+	"inventory-management/backend/internal/http/response"
+	"time"
+)
+
+type Invitation struct {
+	ID         int64
+	TenantID   int64 `gorm:"default:1"`
+	TokenHash  string
+	Email      string
+	Role       string
+	InvitedBy  string
+	ExpiresAt  time.Time
+	AcceptedAt *time.Time
+	CreatedAt  time.Time
+}
+
+func (i *Invitation) IsUsable() bool {
+	return i.AcceptedAt == nil && time.Now().Before(i.ExpiresAt)
+}
+
+func (i *Invitation) ToResponse() *response.InvitationResponse {
+	return &response.InvitationResponse{
+		ID:        i.ID,
+		Email:     i.Email,
+		Role:      i.Role,
+		InvitedBy: i.InvitedBy,
+		ExpiresAt: i.ExpiresAt.Local().String(),
+		CreatedAt: i.CreatedAt.Local().String(),
+	}
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/util"
+	"time"
+)
+
+const (
+	LabelTypeProductQuality = "product_quality"
+	LabelTypeTransaction    = "transaction"
+)
+
+// LabelTypes are the labels that can be printed, in the order they are listed.
+var LabelTypes = []string{LabelTypeProductQuality, LabelTypeTransaction}
+
+// LabelTemplate is how a tenant prints a type of label on its thermal printers. Zpl is a Go
+// text/template executed with LabelData, Symbology the barcode it prints unless the request
+// asks for another one.
+type LabelTemplate struct {
+	ID        int64
+	TenantID  int64 `gorm:"default:1"`
+	LabelType string
+	Symbology string
+	Zpl       string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// LabelData fills a label template. The texts are escaped for ZPL, and Barcode is the ZPL
+// barcode command with its field data, to be placed between a field origin and ^FS.
+type LabelData struct {
+	Title    string
+	Subtitle string
+	Text     string
+	Value    string
+	Barcode  string
+	Copies   int
+}
+
+// defaultLabelZpl fits a 4 by 2 inch label printed at 203 dpi.
+const defaultLabelZpl = `^XA
+^CI28
+^PW812
+^LL406
+^FO40,30^A0N,40,40^FD{{.Title}}^FS
+^FO40,80^A0N,28,28^FD{{.Subtitle}}^FS
+^FO40,130{{.Barcode}}^FS
+^FO40,360^A0N,24,24^FD{{.Text}}^FS
+^PQ{{.Copies}}
+^XZ
+`
+
+// DefaultLabelTemplate is the template a type of label is printed with until the tenant saves
+// one of its own.
+func DefaultLabelTemplate(labelType string) *LabelTemplate {
+	return &LabelTemplate{
+		LabelType: labelType,
+		Symbology: util.BarcodeCode128,
+		Zpl:       defaultLabelZpl,
+	}
+}
+
+func (l *LabelTemplate) ToResponse() *response.LabelTemplateResponse {
+	labelTemplateResponse := &response.LabelTemplateResponse{
+		LabelType: l.LabelType,
+		Symbology: l.Symbology,
+		Zpl:       l.Zpl,
+		Default:   l.ID == 0,
+	}
+	if l.ID != 0 {
+		updatedAt := l.UpdatedAt.Local().String()
+		labelTemplateResponse.UpdatedAt = &updatedAt
+	}
+
+	return labelTemplateResponse
+}
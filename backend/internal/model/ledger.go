@@ -0,0 +1,129 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"inventory-management/backend/internal/http/response"
+	"strings"
+	"time"
+)
+
+const (
+	LedgerActionCreate = "create"
+	LedgerActionUpdate = "update"
+	LedgerActionDelete = "delete"
+)
+
+// LedgerGenesisHash is the previous hash of the first entry in the chain.
+var LedgerGenesisHash = strings.Repeat("0", 64)
+
+type LedgerEntry struct {
+	ID              int64
+	TenantID        int64 `gorm:"default:1"`
+	Sequence        int64
+	TransactionCode string
+	Action          string
+	Payload         string
+	PreviousHash    string
+	Hash            string
+	CreatedAt       time.Time
+}
+
+func (LedgerEntry) TableName() string {
+	return "transaction_ledger"
+}
+
+// ComputeHash returns the hex encoded SHA-256 digest of the entry content chained to the previous hash.
+func (l *LedgerEntry) ComputeHash() string {
+	content := fmt.Sprintf("%d|%s|%s|%s|%s", l.Sequence, l.PreviousHash, l.TransactionCode, l.Action, l.Payload)
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])
+}
+
+type LedgerCheckpoint struct {
+	ID        int64
+	TenantID  int64 `gorm:"default:1"`
+	Sequence  int64
+	Hash      string
+	Signature string
+	CreatedAt time.Time
+}
+
+// SigningMessage is the content covered by the checkpoint signature.
+func (l *LedgerCheckpoint) SigningMessage() []byte {
+	return []byte(fmt.Sprintf("%d|%s", l.Sequence, l.Hash))
+}
+
+func (l *LedgerCheckpoint) ToResponse() *response.LedgerCheckpointResponse {
+	return &response.LedgerCheckpointResponse{
+		ID:        l.ID,
+		Sequence:  l.Sequence,
+		Hash:      l.Hash,
+		Signature: l.Signature,
+		CreatedAt: l.CreatedAt.Local().String(),
+	}
+}
+
+type transactionLedgerPayload struct {
+	Code                        string  `json:"code"`
+	ProductQualityID            int64   `json:"product_quality_id"`
+	ProductQualityIDTransferred *int64  `json:"product_quality_id_transferred"`
+	SupplierCode                *string `json:"supplier_code"`
+	CustomerCode                *string `json:"customer_code"`
+	Description                 *string `json:"description"`
+	Reference                   *string `json:"reference"`
+	Quantity                    float64 `json:"quantity"`
+	Type                        string  `json:"type"`
+	UnitMassAcronym             string  `json:"unit_mass_acronym"`
+	Version                     int64   `json:"version"`
+	CreatedAt                   string  `json:"created_at"`
+	UpdatedAt                   string  `json:"updated_at"`
+}
+
+// LegacyPayload reports whether the entry was recorded before its payload held every field of
+// the transaction it holds now, so the fields added since are not covered by it.
+func (l *LedgerEntry) LegacyPayload() bool {
+	var current map[string]json.RawMessage
+	encoded, _ := json.Marshal(&transactionLedgerPayload{})
+	_ = json.Unmarshal(encoded, &current)
+
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(l.Payload), &payload); err != nil {
+		return false
+	}
+	for key := range current {
+		if _, ok := payload[key]; !ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LedgerPayload returns the canonical representation of the transaction that is stored in
+// and compared against the ledger. The times are formatted without a zone because the
+// columns do not store one.
+func (t *Transaction) LedgerPayload() (string, error) {
+	payload, err := json.Marshal(&transactionLedgerPayload{
+		Code:                        t.Code,
+		ProductQualityID:            t.ProductQualityID,
+		ProductQualityIDTransferred: t.ProductQualityIDTransferred,
+		SupplierCode:                t.SupplierCode,
+		CustomerCode:                t.CustomerCode,
+		Description:                 t.Description,
+		Reference:                   t.Reference,
+		Quantity:                    t.Quantity,
+		Type:                        t.Type,
+		UnitMassAcronym:             t.UnitMassAcronym,
+		Version:                     t.Version,
+		CreatedAt:                   t.CreatedAt.Format("2006-01-02T15:04:05.000000"),
+		UpdatedAt:                   t.UpdatedAt.Format("2006-01-02T15:04:05.000000"),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(payload), nil
+}
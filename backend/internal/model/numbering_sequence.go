@@ -0,0 +1,174 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/util"
+	"time"
+)
+
+const (
+	NumberingEntityProduct     = "product"
+	NumberingEntitySupplier    = "supplier"
+	NumberingEntityCustomer    = "customer"
+	NumberingEntityTransaction = "transaction"
+)
+
+// NumberingEntities are the entities given a code when they are created, by their table.
+var NumberingEntities = map[string]string{
+	NumberingEntityProduct:     "products",
+	NumberingEntitySupplier:    "suppliers",
+	NumberingEntityCustomer:    "customers",
+	NumberingEntityTransaction: "transactions",
+}
+
+// codeAttempts is how many codes are tried before giving up on finding one that is not taken.
+const codeAttempts = 5
+
+// NumberingSequence is how a tenant numbers an entity, or one type of transaction. NextValue is
+// the number the next record is given, counted in Period when the sequence is reset yearly or
+// monthly.
+type NumberingSequence struct {
+	ID              int64
+	TenantID        int64 `gorm:"default:1"`
+	Entity          string
+	TransactionType string
+	Strategy        string
+	Pattern         string
+	Padding         int
+	Reset           string
+	NextValue       int64 `gorm:"default:1"`
+	Period          string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// DefaultNumberingSequence is how an entity is numbered until the tenant saves a sequence: with
+// random codes.
+func DefaultNumberingSequence(entity string, transactionType string) *NumberingSequence {
+	return &NumberingSequence{
+		Entity:          entity,
+		TransactionType: transactionType,
+		Strategy:        util.NumberingStrategyRandom,
+		Reset:           util.NumberingResetNever,
+		NextValue:       1,
+	}
+}
+
+// Number is the code the sequence gives the next record created at the time.
+func (n *NumberingSequence) Number(transactionType string, at time.Time) string {
+	nextValue := n.NextValue
+	if n.Period != util.NumberingPeriod(n.Reset, at) {
+		nextValue = 1
+	}
+
+	return util.FormatNumber(n.Pattern, nextValue, n.Padding, transactionType, at)
+}
+
+func (n *NumberingSequence) ToResponse() *response.NumberingSequenceResponse {
+	numberingResponse := &response.NumberingSequenceResponse{
+		Entity:          n.Entity,
+		TransactionType: n.TransactionType,
+		Strategy:        n.Strategy,
+		Pattern:         n.Pattern,
+		Padding:         n.Padding,
+		Reset:           n.Reset,
+		NextValue:       n.NextValue,
+		Default:         n.ID == 0,
+	}
+	if n.Strategy == util.NumberingStrategySequence {
+		numberingResponse.Example = n.Number(n.TransactionType, time.Now())
+	}
+	if n.ID != 0 {
+		updatedAt := n.UpdatedAt.Local().String()
+		numberingResponse.UpdatedAt = &updatedAt
+	}
+
+	return numberingResponse
+}
+
+// assignCode gives a record being created the code of its entity, taking the next number of
+// the sequence the tenant numbers it with. The number is taken by the same statement that
+// counts it, which holds the sequence row until the database transaction creating the record
+// ends: records created at the same time wait for each other, and a record that is not created
+// gives its number back, so numbers have no gaps. Numbers whose code is already taken, such as
+// after the sequence was restarted below a number in use, are skipped.
+func assignCode(tx *gorm.DB, entity string, transactionType string) (string, error) {
+	db := tx.Session(&gorm.Session{NewDB: true})
+
+	// Every tenant numbers its records on its own
+	tenantID, ok := util.TenantFromContext(db.Statement.Context)
+	if !ok {
+		return "", errors.New(response.ErrorTenantMissing)
+	}
+
+	var sequence NumberingSequence
+	err := db.Where("entity = ? AND transaction_type IN ?", entity, []string{transactionType, ""}).
+		Order("transaction_type DESC").Limit(1).Find(&sequence).Error
+	if err != nil {
+		return "", err
+	}
+	if sequence.ID == 0 || sequence.Strategy != util.NumberingStrategySequence {
+		return randomCode(db, entity, tenantID)
+	}
+
+	for i := 0; i < codeAttempts; i++ {
+		at := time.Now()
+		period := util.NumberingPeriod(sequence.Reset, at)
+		var nextValue int64
+		err = db.Raw(`UPDATE numbering_sequences
+			SET next_value = CASE WHEN period = ? THEN next_value + 1 ELSE 2 END, period = ?, updated_at = ?
+			WHERE id = ? RETURNING next_value`, period, period, at, sequence.ID).Scan(&nextValue).Error
+		if err != nil {
+			return "", err
+		}
+		if nextValue == 0 {
+			return "", fmt.Errorf("numbering sequence %d no longer exists", sequence.ID)
+		}
+
+		code := util.FormatNumber(sequence.Pattern, nextValue-1, sequence.Padding, transactionType, at)
+		taken, err := CodeTaken(db, entity, tenantID, code)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return code, nil
+		}
+	}
+
+	return "", fmt.Errorf("no free %s code after %d attempts", NumberingEntities[entity], codeAttempts)
+}
+
+// randomCode draws random codes until one is not taken.
+func randomCode(db *gorm.DB, entity string, tenantID int64) (string, error) {
+	for i := 0; i < codeAttempts; i++ {
+		code, err := util.GenerateRandomString(10)
+		if err != nil {
+			return "", err
+		}
+
+		taken, err := CodeTaken(db, entity, tenantID, code)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return code, nil
+		}
+	}
+
+	return "", fmt.Errorf("no free %s code after %d attempts", NumberingEntities[entity], codeAttempts)
+}
+
+// CodeTaken reports whether a record of the entity of the tenant has the code. Codes are unique
+// within a tenant, deleted records keeping theirs.
+func CodeTaken(db *gorm.DB, entity string, tenantID int64, code string) (bool, error) {
+	var count int64
+	err := db.Table(NumberingEntities[entity]).Where("tenant_id = ? AND code = ?", tenantID, code).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+)
+
+// OidcLoginState is a login started with the identity provider. Only the hash of its state is
+// kept, the browser that started the login holds the state in a signed cookie. LoginTenantID is
+// not named TenantID, the callback finds the state before it knows the tenant.
+type OidcLoginState struct {
+	ID            int64
+	StateHash     string
+	Nonce         string
+	CodeVerifier  string
+	LinkUsername  string
+	LoginTenantID *int64
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+}
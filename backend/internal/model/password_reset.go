@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+)
+
+type PasswordReset struct {
+	ID        int64
+	UserID    int64
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (p *PasswordReset) IsUsable() bool {
+	return p.UsedAt == nil && time.Now().Before(p.ExpiresAt)
+}
@@ -9,17 +9,48 @@ import (
 
 type Product struct {
 	ID                  int64
+	TenantID            int64 `gorm:"default:1"`
 	Code                string
 	Name                string
 	UnitMassAcronym     string
 	UnitMassDescription string
-	CreatedAt           time.Time
-	UpdatedAt           time.Time
-	ProductQualities    []*ProductQuality `gorm:"foreignKey:ProductCode;references:Code"`
+	// Gtin is the GTIN-14 of the product, when it has one
+	Gtin *string
+	// Version counts the changes of the product and of its qualities
+	Version          int64 `gorm:"default:1"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        gorm.DeletedAt
+	ProductQualities []*ProductQuality `gorm:"foreignKey:ProductCode;references:Code"`
+}
+
+// ProductListSchema whitelists the query of the product list.
+var ProductListSchema = &util.ListSchema{
+	Filters: map[string]string{
+		"id":                    util.ListFieldNumber,
+		"code":                  util.ListFieldText,
+		"name":                  util.ListFieldText,
+		"unit_mass_acronym":     util.ListFieldText,
+		"unit_mass_description": util.ListFieldText,
+		"gtin":                  util.ListFieldText,
+		"created_at":            util.ListFieldTime,
+		"updated_at":            util.ListFieldTime,
+		"deleted_at":            util.ListFieldTime,
+	},
+	Sorts:  []string{"id", "code", "name", "unit_mass_acronym", "created_at", "updated_at"},
+	Fields: []string{"id", "code", "name", "unit_mass_acronym", "unit_mass_description", "gtin", "version", "created_at", "updated_at", "deleted_at"},
+	Includes: map[string][]string{
+		"product_qualities": {"ProductQualities"},
+	},
+	SoftDelete: true,
 }
 
 func (p *Product) BeforeCreate(tx *gorm.DB) error {
-	p.Code, _ = util.GenerateRandomString(10)
+	code, err := assignCode(tx, NumberingEntityProduct, "")
+	if err != nil {
+		return err
+	}
+	p.Code = code
 
 	return nil
 }
@@ -31,8 +62,11 @@ func (p *Product) ToResponse() *response.ProductResponse {
 		Name:                p.Name,
 		UnitMassAcronym:     p.UnitMassAcronym,
 		UnitMassDescription: p.UnitMassDescription,
+		Gtin:                p.Gtin,
+		Version:             p.Version,
 		CreatedAt:           p.CreatedAt.Local().String(),
 		UpdatedAt:           p.UpdatedAt.Local().String(),
+		DeletedAt:           deletedAtResponse(p.DeletedAt),
 	}
 }
 
@@ -48,8 +82,52 @@ func (p *Product) ToResponseWithAssociations() *response.ProductResponse {
 		Name:                p.Name,
 		UnitMassAcronym:     p.UnitMassAcronym,
 		UnitMassDescription: p.UnitMassDescription,
+		Gtin:                p.Gtin,
+		Version:             p.Version,
 		CreatedAt:           p.CreatedAt.Local().String(),
 		UpdatedAt:           p.UpdatedAt.Local().String(),
+		DeletedAt:           deletedAtResponse(p.DeletedAt),
 		ProductQualities:    productQualities,
 	}
 }
+
+func (p *Product) ToSearchDocument() *response.ProductDocument {
+	document := &response.ProductDocument{
+		ID:                  p.ID,
+		Code:                p.Code,
+		Name:                p.Name,
+		UnitMassAcronym:     p.UnitMassAcronym,
+		UnitMassDescription: p.UnitMassDescription,
+		Qualities:           []string{},
+		QualityTypes:        []string{},
+		CreatedAt:           p.CreatedAt,
+		UpdatedAt:           p.UpdatedAt,
+	}
+
+	for i, productQuality := range p.ProductQualities {
+		document.Qualities = append(document.Qualities, productQuality.Quality)
+		document.QualityTypes = append(document.QualityTypes, productQuality.Type)
+		if i == 0 || productQuality.Price < document.MinPrice {
+			document.MinPrice = productQuality.Price
+		}
+		if productQuality.Price > document.MaxPrice {
+			document.MaxPrice = productQuality.Price
+		}
+	}
+
+	return document
+}
+
+func (p *Product) ToSearchOutboxEvent(operation string) *SearchOutboxEvent {
+	return newSearchOutboxEvent(p.TenantID, SearchIndexProducts, p.Code, p.ID, operation)
+}
+
+// deletedAtResponse is the time a record was soft deleted, nil while it is not.
+func deletedAtResponse(deletedAt gorm.DeletedAt) *string {
+	if !deletedAt.Valid {
+		return nil
+	}
+
+	value := deletedAt.Time.Local().String()
+	return &value
+}
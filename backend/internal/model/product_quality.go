@@ -1,17 +1,25 @@
 package model
 
 import (
+	"gorm.io/gorm"
 	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/util"
+	"strconv"
 )
 
 type ProductQuality struct {
 	ID          int64
+	TenantID    int64 `gorm:"default:1"`
 	ProductCode string
 	Quality     string
 	Price       int64
 	Quantity    float64
 	Type        string
-	Product     *Product `gorm:"foreignKey:ProductCode;references:Code"`
+	// Gtin is the GTIN-14 of the quality, when it has one
+	Gtin      *string
+	Version   int64 `gorm:"default:1"`
+	DeletedAt gorm.DeletedAt
+	Product   *Product `gorm:"foreignKey:ProductCode;references:Code"`
 }
 
 func (p *ProductQuality) ToResponse() *response.ProductQualityResponse {
@@ -22,17 +30,58 @@ func (p *ProductQuality) ToResponse() *response.ProductQualityResponse {
 		Price:       p.Price,
 		Quantity:    p.Quantity,
 		Type:        p.Type,
+		Gtin:        p.Gtin,
+		Version:     p.Version,
+		DeletedAt:   deletedAtResponse(p.DeletedAt),
 	}
 }
 
 func (p *ProductQuality) ToResponseWithAssociations() *response.ProductQualityResponse {
-	return &response.ProductQualityResponse{
+	productQualityResponse := p.ToResponse()
+	if p.Product != nil {
+		productQualityResponse.Product = p.Product.ToResponse()
+	}
+
+	return productQualityResponse
+}
+
+func (p *ProductQuality) ToSearchDocument() *response.ProductQualityDocument {
+	document := &response.ProductQualityDocument{
 		ID:          p.ID,
 		ProductCode: p.ProductCode,
 		Quality:     p.Quality,
+		Type:        p.Type,
 		Price:       p.Price,
 		Quantity:    p.Quantity,
-		Type:        p.Type,
-		Product:     p.Product.ToResponse(),
+	}
+
+	if p.Product != nil {
+		document.ProductName = p.Product.Name
+		document.UnitMassAcronym = p.Product.UnitMassAcronym
+	}
+
+	return document
+}
+
+func (p *ProductQuality) ToSearchOutboxEvent(operation string) *SearchOutboxEvent {
+	return newSearchOutboxEvent(p.TenantID, SearchIndexProductQualities, strconv.FormatInt(p.ID, 10), p.ID, operation)
+}
+
+// LabelValue identifies the quality on its labels, quality names being neither unique nor
+// barcode friendly.
+func (p *ProductQuality) LabelValue() string {
+	return p.ProductCode + "-" + strconv.FormatInt(p.ID, 10)
+}
+
+// GS1Elements are what GS1-128 labels of the quality encode: its GTIN, or without one the
+// product code and the quality as a company internal AI.
+func (p *ProductQuality) GS1Elements() []util.GS1Element {
+	if p.Gtin != nil {
+		return []util.GS1Element{{AI: "01", Data: *p.Gtin}}
+	}
+
+	return []util.GS1Element{
+		{AI: "240", Data: p.ProductCode},
+		{AI: "91", Data: strconv.FormatInt(p.ID, 10)},
 	}
 }
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+// The search indices, without the tenant prefix.
+const (
+	SearchIndexProducts         = "products"
+	SearchIndexProductQualities = "product_qualities"
+	SearchIndexTransactions     = "transactions"
+	SearchIndexSuppliers        = "suppliers"
+	SearchIndexCustomers        = "customers"
+	SearchIndexUsers            = "users"
+)
+
+var SearchIndices = []string{
+	SearchIndexProducts,
+	SearchIndexProductQualities,
+	SearchIndexTransactions,
+	SearchIndexSuppliers,
+	SearchIndexCustomers,
+	SearchIndexUsers,
+}
+
+func IsSearchIndex(index string) bool {
+	for _, searchIndex := range SearchIndices {
+		if searchIndex == index {
+			return true
+		}
+	}
+
+	return false
+}
+
+const (
+	OutboxOperationIndex  = "index"
+	OutboxOperationDelete = "delete"
+)
+
+// SearchOutboxEvent asks the relay to bring a search document in line with the database. It is
+// written in the same transaction as the change, so the index cannot miss a committed change.
+type SearchOutboxEvent struct {
+	ID        int64
+	TenantID  int64 `gorm:"default:1"`
+	IndexName string
+	// EntityKey is what the entity is loaded by when it is indexed, its code or its ID
+	EntityKey   string
+	DocumentID  int64
+	Operation   string
+	Attempts    int
+	LastError   *string
+	AvailableAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	CreatedAt   time.Time
+}
+
+func (SearchOutboxEvent) TableName() string {
+	return "search_outbox"
+}
+
+// DocumentKey identifies the search document the event is about.
+func (e *SearchOutboxEvent) DocumentKey() string {
+	return strconv.FormatInt(e.TenantID, 10) + "/" + e.IndexName + "/" + strconv.FormatInt(e.DocumentID, 10)
+}
+
+type SearchOutboxStats struct {
+	Pending         int64
+	Failing         int64
+	OldestCreatedAt *time.Time
+}
+
+func newSearchOutboxEvent(tenantID int64, indexName string, entityKey string, documentID int64, operation string) *SearchOutboxEvent {
+	return &SearchOutboxEvent{
+		TenantID:   tenantID,
+		IndexName:  indexName,
+		EntityKey:  entityKey,
+		DocumentID: documentID,
+		Operation:  operation,
+	}
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"inventory-management/backend/internal/http/response"
+	"strings"
+	"time"
+)
+
+// SearchSynonym is a group of words and phrases that mean the same product, e.g. "kangkung" and
+// "water spinach". Searching for one of them finds the others.
+type SearchSynonym struct {
+	ID       int64
+	TenantID int64 `gorm:"default:1"`
+	// Words are stored lowercase and separated by commas
+	Words     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (s *SearchSynonym) SetWords(words []string) {
+	var normalized []string
+	for _, word := range words {
+		word = strings.Join(strings.Fields(strings.ToLower(word)), " ")
+		if word != "" {
+			normalized = append(normalized, word)
+		}
+	}
+
+	s.Words = strings.Join(normalized, ", ")
+}
+
+func (s *SearchSynonym) WordList() []string {
+	var words []string
+	for _, word := range strings.Split(s.Words, ",") {
+		word = strings.TrimSpace(word)
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+
+	return words
+}
+
+func (s *SearchSynonym) ToResponse() *response.SearchSynonymResponse {
+	return &response.SearchSynonymResponse{
+		ID:        s.ID,
+		Words:     s.WordList(),
+		CreatedAt: s.CreatedAt.Local().String(),
+		UpdatedAt: s.UpdatedAt.Local().String(),
+	}
+}
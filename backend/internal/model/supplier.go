@@ -9,17 +9,44 @@ import (
 
 type Supplier struct {
 	ID           int64
+	TenantID     int64 `gorm:"default:1"`
 	Code         string
 	Name         string
 	Address      string
 	Phone        string
+	Version      int64 `gorm:"default:1"`
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt
 	Transactions []*Transaction `gorm:"foreignKey:SupplierCode;references:Code"`
 }
 
+// SupplierListSchema whitelists the query of the supplier list.
+var SupplierListSchema = &util.ListSchema{
+	Filters: map[string]string{
+		"id":         util.ListFieldNumber,
+		"code":       util.ListFieldText,
+		"name":       util.ListFieldText,
+		"address":    util.ListFieldText,
+		"phone":      util.ListFieldText,
+		"created_at": util.ListFieldTime,
+		"updated_at": util.ListFieldTime,
+		"deleted_at": util.ListFieldTime,
+	},
+	Sorts:  []string{"id", "code", "name", "created_at", "updated_at"},
+	Fields: []string{"id", "code", "name", "address", "phone", "version", "created_at", "updated_at", "deleted_at"},
+	Includes: map[string][]string{
+		"transactions": {"Transactions", "Transactions.ProductQuality", "Transactions.ProductQuality.Product"},
+	},
+	SoftDelete: true,
+}
+
 func (s *Supplier) BeforeCreate(tx *gorm.DB) error {
-	s.Code, _ = util.GenerateRandomString(10)
+	code, err := assignCode(tx, NumberingEntitySupplier, "")
+	if err != nil {
+		return err
+	}
+	s.Code = code
 
 	return nil
 }
@@ -31,8 +58,10 @@ func (s *Supplier) ToResponse() *response.SupplierResponse {
 		Name:      s.Name,
 		Address:   s.Address,
 		Phone:     s.Phone,
+		Version:   s.Version,
 		CreatedAt: s.CreatedAt.Local().String(),
 		UpdatedAt: s.UpdatedAt.Local().String(),
+		DeletedAt: deletedAtResponse(s.DeletedAt),
 	}
 }
 
@@ -48,8 +77,26 @@ func (s *Supplier) ToResponseWithAssociations() *response.SupplierResponse {
 		Name:         s.Name,
 		Address:      s.Address,
 		Phone:        s.Phone,
+		Version:      s.Version,
 		CreatedAt:    s.CreatedAt.Local().String(),
 		UpdatedAt:    s.UpdatedAt.Local().String(),
+		DeletedAt:    deletedAtResponse(s.DeletedAt),
 		Transactions: transactionResponses,
 	}
 }
+
+func (s *Supplier) ToSearchDocument() *response.SupplierDocument {
+	return &response.SupplierDocument{
+		ID:        s.ID,
+		Code:      s.Code,
+		Name:      s.Name,
+		Address:   s.Address,
+		Phone:     s.Phone,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func (s *Supplier) ToSearchOutboxEvent(operation string) *SearchOutboxEvent {
+	return newSearchOutboxEvent(s.TenantID, SearchIndexSuppliers, s.Code, s.ID, operation)
+}
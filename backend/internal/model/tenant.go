@@ -0,0 +1,25 @@
+package model
+
+import (
+	"inventory-management/backend/internal/http/response"
+	"time"
+)
+
+type Tenant struct {
+	ID         int64
+	Code       string
+	Name       string
+	ApiKeyHash *string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (t *Tenant) ToResponse() *response.TenantResponse {
+	return &response.TenantResponse{
+		ID:        t.ID,
+		Code:      t.Code,
+		Name:      t.Name,
+		CreatedAt: t.CreatedAt.Local().String(),
+		UpdatedAt: t.UpdatedAt.Local().String(),
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 type Transaction struct {
 	ID                          int64
+	TenantID                    int64 `gorm:"default:1"`
 	Code                        string
 	ProductQualityID            int64
 	ProductQuality              *ProductQuality `gorm:"foreignKey:ProductQualityID;references:ID"`
@@ -19,13 +20,56 @@ type Transaction struct {
 	CustomerCode                *string
 	Customer                    *Customer `gorm:"foreignKey:CustomerCode;references:Code"`
 	Description                 *string
+	Reference                   *string
 	Quantity                    float64
 	Type                        string
 	UnitMassAcronym             string
+	Version                     int64 `gorm:"default:1"`
 	CreatedAt                   time.Time
 	UpdatedAt                   time.Time
 }
 
+// TransactionListSchema whitelists the query of the transaction list.
+var TransactionListSchema = &util.ListSchema{
+	Filters: map[string]string{
+		"id":                             util.ListFieldNumber,
+		"code":                           util.ListFieldText,
+		"product_quality_id":             util.ListFieldNumber,
+		"product_quality_id_transferred": util.ListFieldNumber,
+		"supplier_code":                  util.ListFieldText,
+		"customer_code":                  util.ListFieldText,
+		"reference":                      util.ListFieldText,
+		"quantity":                       util.ListFieldNumber,
+		"type":                           util.ListFieldText,
+		"unit_mass_acronym":              util.ListFieldText,
+		"created_at":                     util.ListFieldTime,
+		"updated_at":                     util.ListFieldTime,
+		"product_code":                   util.ListFieldText,
+	},
+	Sorts: []string{"id", "code", "product_quality_id", "quantity", "type", "created_at", "updated_at"},
+	Fields: []string{
+		"id", "code", "product_quality_id", "product_quality_id_transferred", "supplier_code", "customer_code",
+		"description", "reference", "quantity", "type", "unit_mass_acronym", "version", "created_at", "updated_at",
+	},
+	Includes: map[string][]string{
+		"product_quality":             {"ProductQuality", "ProductQuality.Product"},
+		"product_quality_transferred": {"ProductQualityTransferred", "ProductQualityTransferred.Product"},
+		"supplier":                    {"Supplier"},
+		"customer":                    {"Customer"},
+	},
+	Expressions: map[string]string{
+		// A transfer moves stock between qualities of the same product, so the source quality is enough
+		"product_code": "(SELECT product_qualities.product_code FROM product_qualities WHERE product_qualities.id = transactions.product_quality_id)",
+	},
+}
+
+// TransactionTotal is the quantity of the transactions of a type in a unit of mass.
+type TransactionTotal struct {
+	Type            string
+	UnitMassAcronym string
+	Quantity        float64
+}
+
 func (t *Transaction) setNullable() {
 	if t.ProductQualityIDTransferred == nil || *t.ProductQualityIDTransferred == 0 {
 		t.ProductQualityIDTransferred = nil
@@ -42,10 +86,18 @@ func (t *Transaction) setNullable() {
 	if t.Description == nil || *t.Description == "" {
 		t.Description = nil
 	}
+
+	if t.Reference == nil || *t.Reference == "" {
+		t.Reference = nil
+	}
 }
 
 func (t *Transaction) BeforeCreate(tx *gorm.DB) error {
-	t.Code, _ = util.GenerateRandomString(10)
+	code, err := assignCode(tx, NumberingEntityTransaction, t.Type)
+	if err != nil {
+		return err
+	}
+	t.Code = code
 	t.setNullable()
 
 	return nil
@@ -66,15 +118,22 @@ func (t *Transaction) ToResponse() *response.TransactionResponse {
 		SupplierCode:                t.SupplierCode,
 		CustomerCode:                t.CustomerCode,
 		Description:                 t.Description,
+		Reference:                   t.Reference,
 		Quantity:                    t.Quantity,
 		Type:                        t.Type,
 		UnitMassAcronym:             t.UnitMassAcronym,
+		Version:                     t.Version,
 		CreatedAt:                   t.CreatedAt.Local().String(),
 		UpdatedAt:                   t.UpdatedAt.Local().String(),
 	}
 }
 
 func (t *Transaction) ToResponseWithAssociations() *response.TransactionResponse {
+	var productQualityResponse *response.ProductQualityResponse
+	if t.ProductQuality != nil {
+		productQualityResponse = t.ProductQuality.ToResponseWithAssociations()
+	}
+
 	var supplierResponse *response.SupplierResponse
 	if t.Supplier != nil {
 		supplierResponse = t.Supplier.ToResponse()
@@ -94,7 +153,7 @@ func (t *Transaction) ToResponseWithAssociations() *response.TransactionResponse
 		ID:                          t.ID,
 		Code:                        t.Code,
 		ProductQualityID:            t.ProductQualityID,
-		ProductQuality:              t.ProductQuality.ToResponseWithAssociations(),
+		ProductQuality:              productQualityResponse,
 		ProductQualityIDTransferred: t.ProductQualityIDTransferred,
 		ProductQualityTransferred:   productQualityTransferredResponse,
 		SupplierCode:                t.SupplierCode,
@@ -102,10 +161,73 @@ func (t *Transaction) ToResponseWithAssociations() *response.TransactionResponse
 		CustomerCode:                t.CustomerCode,
 		Customer:                    customerResponse,
 		Description:                 t.Description,
+		Reference:                   t.Reference,
 		Quantity:                    t.Quantity,
 		Type:                        t.Type,
 		UnitMassAcronym:             t.UnitMassAcronym,
+		Version:                     t.Version,
 		CreatedAt:                   t.CreatedAt.Local().String(),
 		UpdatedAt:                   t.UpdatedAt.Local().String(),
 	}
 }
+
+func (t *Transaction) ToSearchDocument() *response.TransactionDocument {
+	document := &response.TransactionDocument{
+		ID:               t.ID,
+		Code:             t.Code,
+		Type:             t.Type,
+		Quantity:         t.Quantity,
+		UnitMassAcronym:  t.UnitMassAcronym,
+		ProductQualityID: t.ProductQualityID,
+		CreatedAt:        t.CreatedAt,
+		UpdatedAt:        t.UpdatedAt,
+	}
+
+	if t.Description != nil {
+		document.Description = *t.Description
+	}
+	if t.ProductQuality != nil {
+		document.ProductCode = t.ProductQuality.ProductCode
+		document.Quality = t.ProductQuality.Quality
+		if t.ProductQuality.Product != nil {
+			document.ProductName = t.ProductQuality.Product.Name
+		}
+	}
+	if t.ProductQualityIDTransferred != nil {
+		document.ProductQualityIDTransferred = *t.ProductQualityIDTransferred
+	}
+	if t.ProductQualityTransferred != nil {
+		document.QualityTransferred = t.ProductQualityTransferred.Quality
+	}
+	if t.SupplierCode != nil {
+		document.SupplierCode = *t.SupplierCode
+	}
+	if t.Supplier != nil {
+		document.SupplierName = t.Supplier.Name
+	}
+	if t.CustomerCode != nil {
+		document.CustomerCode = *t.CustomerCode
+	}
+	if t.Customer != nil {
+		document.CustomerName = t.Customer.Name
+	}
+
+	return document
+}
+
+func (t *Transaction) ToSearchOutboxEvent(operation string) *SearchOutboxEvent {
+	return newSearchOutboxEvent(t.TenantID, SearchIndexTransactions, t.Code, t.ID, operation)
+}
+
+// GS1Elements are what GS1-128 labels of the transaction encode: the GTIN of its quality, or
+// the product code without one, and the transaction code as the batch.
+func (t *Transaction) GS1Elements() []util.GS1Element {
+	var elements []util.GS1Element
+	if t.ProductQuality != nil && t.ProductQuality.Gtin != nil {
+		elements = append(elements, util.GS1Element{AI: "01", Data: *t.ProductQuality.Gtin})
+	} else if t.ProductQuality != nil {
+		elements = append(elements, util.GS1Element{AI: "240", Data: t.ProductQuality.ProductCode})
+	}
+
+	return append(elements, util.GS1Element{AI: "10", Data: t.Code})
+}
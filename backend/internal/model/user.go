@@ -6,16 +6,49 @@ import (
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 	response "inventory-management/backend/internal/http/response"
+	"inventory-management/backend/util"
+	"strconv"
 	"time"
 )
 
+const (
+	RoleAdmin = "admin"
+	RoleStaff = "staff"
+)
+
 type User struct {
-	ID        int64
-	Name      string
-	Username  string
-	Password  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID          int64
+	TenantID    int64 `gorm:"default:1"`
+	Name        string
+	Username    string
+	Password    string
+	Email       *string
+	Role        string
+	OidcIssuer  *string
+	OidcSubject *string
+	// TokenVersion is bumped when the password changes, which revokes the tokens issued before
+	TokenVersion int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt
+}
+
+// UserListSchema whitelists the query of the user list. Passwords and linked identities are
+// never part of it.
+var UserListSchema = &util.ListSchema{
+	Filters: map[string]string{
+		"id":         util.ListFieldNumber,
+		"name":       util.ListFieldText,
+		"username":   util.ListFieldText,
+		"email":      util.ListFieldText,
+		"role":       util.ListFieldText,
+		"created_at": util.ListFieldTime,
+		"updated_at": util.ListFieldTime,
+		"deleted_at": util.ListFieldTime,
+	},
+	Sorts:      []string{"id", "name", "username", "role", "created_at", "updated_at"},
+	Fields:     []string{"id", "name", "username", "email", "role", "created_at", "updated_at", "deleted_at"},
+	SoftDelete: true,
 }
 
 func (u *User) BeforeCreate(tx *gorm.DB) error {
@@ -25,6 +58,10 @@ func (u *User) BeforeCreate(tx *gorm.DB) error {
 	}
 	u.Password = hashedPassword
 
+	if u.Role == "" {
+		u.Role = RoleStaff
+	}
+
 	return nil
 }
 
@@ -33,8 +70,23 @@ func (u *User) ToResponse() *response.UserResponse {
 		ID:        u.ID,
 		Name:      u.Name,
 		Username:  u.Username,
+		Email:     u.Email,
+		Role:      u.Role,
 		CreatedAt: u.CreatedAt.Local().String(),
 		UpdatedAt: u.UpdatedAt.Local().String(),
+		DeletedAt: deletedAtResponse(u.DeletedAt),
+	}
+}
+
+func (u *User) ToSearchDocument() *response.UserDocument {
+	return &response.UserDocument{
+		ID:        u.ID,
+		Name:      u.Name,
+		Username:  u.Username,
+		Email:     u.Email,
+		Role:      u.Role,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
 	}
 }
 
@@ -62,8 +114,11 @@ func (u *User) VerifyPassword(password string) error {
 
 func (u *User) GenerateTokenJWT() (string, error) {
 	myClaims := jwt.Claims(jwt.MapClaims{
-		"username": u.Username,
-		"exp":      time.Now().Add(time.Hour * 72).Unix(),
+		"username":      u.Username,
+		"role":          u.Role,
+		"tenant_id":     u.TenantID,
+		"token_version": u.TokenVersion,
+		"exp":           time.Now().Add(time.Hour * 72).Unix(),
 	})
 	claims := jwt.NewWithClaims(jwt.SigningMethodHS256, myClaims)
 	token, err := claims.SignedString([]byte("secret"))
@@ -73,3 +128,7 @@ func (u *User) GenerateTokenJWT() (string, error) {
 
 	return token, nil
 }
+
+func (u *User) ToSearchOutboxEvent(operation string) *SearchOutboxEvent {
+	return newSearchOutboxEvent(u.TenantID, SearchIndexUsers, strconv.FormatInt(u.ID, 10), u.ID, operation)
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/model"
+	"time"
+)
+
+type AuditLogRepository struct {
+	DB *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepositoryContract {
+	return &AuditLogRepository{
+		DB: db,
+	}
+}
+
+func (repository *AuditLogRepository) FindAll(ctx context.Context, filter *request.AuditLogFilterRequest, offset int, limit int) ([]*model.AuditLog, error) {
+	var auditLogs []*model.AuditLog
+	err := repository.filter(conn(ctx, repository.DB), filter).Offset(offset).Limit(limit).Order("created_at DESC, id DESC").Find(&auditLogs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return auditLogs, nil
+}
+
+func (repository *AuditLogRepository) CountAll(ctx context.Context, filter *request.AuditLogFilterRequest) (int64, error) {
+	var count int64
+	err := repository.filter(conn(ctx, repository.DB).Model(&model.AuditLog{}), filter).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (repository *AuditLogRepository) Create(ctx context.Context, auditLog *model.AuditLog) (*model.AuditLog, error) {
+	err := conn(ctx, repository.DB).Create(auditLog).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return auditLog, nil
+}
+
+func (repository *AuditLogRepository) filter(db *gorm.DB, filter *request.AuditLogFilterRequest) *gorm.DB {
+	if filter == nil {
+		return db
+	}
+
+	if filter.Entity != "" {
+		db = db.Where("entity = ?", filter.Entity)
+	}
+	if filter.EntityID != "" {
+		db = db.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.Actor != "" {
+		db = db.Where("actor = ?", filter.Actor)
+	}
+	if filter.Action != "" {
+		db = db.Where("action = ?", filter.Action)
+	}
+	if filter.RequestID != "" {
+		db = db.Where("request_id = ?", filter.RequestID)
+	}
+	if from, err := time.ParseInLocation("2006-01-02", filter.From, time.Local); err == nil {
+		db = db.Where("created_at >= ?", from)
+	}
+	if to, err := time.ParseInLocation("2006-01-02", filter.To, time.Local); err == nil {
+		// The upper bound is inclusive of the whole day
+		db = db.Where("created_at < ?", to.AddDate(0, 0, 1))
+	}
+
+	return db
+}
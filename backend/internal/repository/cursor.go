@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/util"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// listCursor pages the records of the list query by keyset instead of OFFSET: the page starts
+// right after the record of the cursor in the order of the query, so deep pages cost as much as
+// the first one. The id breaks ties between records sorting the same. An empty cursor starts
+// at the first page.
+func listCursor[T any](db *gorm.DB, query *util.ListQuery, listSchema *util.ListSchema, token string, limit int) ([]*T, *util.CursorPage, error) {
+	statement := &gorm.Statement{DB: db, Context: db.Statement.Context}
+	if err := statement.Parse(new(T)); err != nil {
+		return nil, nil, err
+	}
+
+	sorts := cursorSorts(query)
+	fields := make([]*schema.Field, len(sorts))
+	for i, sort := range sorts {
+		fields[i] = statement.Schema.LookUpField(sort.Field)
+		if fields[i] == nil {
+			return nil, nil, errors.New(response.ErrorInvalidCursor)
+		}
+	}
+
+	cursor := &util.Cursor{Direction: util.CursorNext}
+	if token != "" {
+		var err error
+		cursor, err = util.DecodeCursor(token)
+		if err != nil {
+			return nil, nil, err
+		}
+		if cursor.Sort != cursorSortKey(sorts) || len(cursor.Values) != len(sorts) {
+			return nil, nil, errors.New(response.ErrorInvalidCursor)
+		}
+
+		values, err := cursorValues(fields, cursor.Values)
+		if err != nil {
+			return nil, nil, err
+		}
+		db = db.Where(cursorCondition(sorts, values, cursor.Direction == util.CursorPrev))
+	}
+
+	// The previous page is read backwards from the cursor, then turned around
+	backward := cursor.Direction == util.CursorPrev
+	order := clause.OrderBy{}
+	for _, sort := range sorts {
+		order.Columns = append(order.Columns, clause.OrderByColumn{Column: clause.Column{Name: sort.Field}, Desc: sort.Descending != backward})
+	}
+
+	var records []*T
+	err := listInclude(listFilter(db, query), query, listSchema).Clauses(order).Limit(limit + 1).Find(&records).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	more := len(records) > limit
+	if more {
+		records = records[:limit]
+	}
+	if backward {
+		for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+			records[i], records[j] = records[j], records[i]
+		}
+	}
+
+	page := &util.CursorPage{}
+	if len(records) == 0 {
+		return records, page, nil
+	}
+
+	// Coming back from a later page means there is a next one, as going forward from an earlier
+	// one means there is a previous one
+	if (!backward && more) || (backward && token != "") {
+		page.Next, err = cursorToken(statement, fields, sorts, records[len(records)-1], util.CursorNext)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if (backward && more) || (!backward && token != "") {
+		page.Prev, err = cursorToken(statement, fields, sorts, records[0], util.CursorPrev)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return records, page, nil
+}
+
+// cursorSorts is the order of the list query, newest first by default, ended by the id in the
+// direction of the last field so that the order is total.
+func cursorSorts(query *util.ListQuery) []*util.ListSort {
+	sorts := []*util.ListSort{{Field: "created_at", Descending: true}}
+	if query != nil && len(query.Sort) > 0 {
+		sorts = query.Sort
+	}
+
+	for _, sort := range sorts {
+		if sort.Field == "id" {
+			return sorts
+		}
+	}
+
+	return append(append([]*util.ListSort{}, sorts...), &util.ListSort{Field: "id", Descending: sorts[len(sorts)-1].Descending})
+}
+
+func cursorSortKey(sorts []*util.ListSort) string {
+	keys := make([]string, len(sorts))
+	for i, sort := range sorts {
+		keys[i] = sort.Field
+		if sort.Descending {
+			keys[i] = "-" + sort.Field
+		}
+	}
+
+	return strings.Join(keys, ",")
+}
+
+// cursorCondition selects the records after the values in the order of the sorts, or before
+// them going backward: (a > ?) OR (a = ? AND b > ?) OR ...
+func cursorCondition(sorts []*util.ListSort, values []interface{}, backward bool) clause.Expression {
+	var conditions []clause.Expression
+	for i, sort := range sorts {
+		var condition []clause.Expression
+		for j := 0; j < i; j++ {
+			condition = append(condition, clause.Eq{Column: clause.Column{Name: sorts[j].Field}, Value: values[j]})
+		}
+
+		column := clause.Column{Name: sort.Field}
+		if sort.Descending != backward {
+			condition = append(condition, clause.Lt{Column: column, Value: values[i]})
+		} else {
+			condition = append(condition, clause.Gt{Column: column, Value: values[i]})
+		}
+		conditions = append(conditions, clause.And(condition...))
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+
+	return clause.Or(conditions...)
+}
+
+// cursorValues turns the decoded values of a cursor back into the types of their fields.
+func cursorValues(fields []*schema.Field, encoded []interface{}) ([]interface{}, error) {
+	values := make([]interface{}, len(encoded))
+	for i, value := range encoded {
+		fieldType := fields[i].IndirectFieldType
+		switch {
+		case fieldType == reflect.TypeOf(time.Time{}):
+			text, ok := value.(string)
+			if !ok {
+				return nil, errors.New(response.ErrorInvalidCursor)
+			}
+			parsed, err := time.Parse(time.RFC3339Nano, text)
+			if err != nil {
+				return nil, errors.New(response.ErrorInvalidCursor)
+			}
+			values[i] = parsed
+		case fieldType.Kind() >= reflect.Int && fieldType.Kind() <= reflect.Uint64:
+			number, ok := value.(json.Number)
+			if !ok {
+				return nil, errors.New(response.ErrorInvalidCursor)
+			}
+			parsed, err := number.Int64()
+			if err != nil {
+				return nil, errors.New(response.ErrorInvalidCursor)
+			}
+			values[i] = parsed
+		case fieldType.Kind() == reflect.Float32 || fieldType.Kind() == reflect.Float64:
+			number, ok := value.(json.Number)
+			if !ok {
+				return nil, errors.New(response.ErrorInvalidCursor)
+			}
+			parsed, err := number.Float64()
+			if err != nil {
+				return nil, errors.New(response.ErrorInvalidCursor)
+			}
+			values[i] = parsed
+		default:
+			text, ok := value.(string)
+			if !ok {
+				return nil, errors.New(response.ErrorInvalidCursor)
+			}
+			values[i] = text
+		}
+	}
+
+	return values, nil
+}
+
+func cursorToken(statement *gorm.Statement, fields []*schema.Field, sorts []*util.ListSort, record interface{}, direction string) (string, error) {
+	recordValue := reflect.ValueOf(record).Elem()
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		values[i], _ = field.ValueOf(statement.Context, recordValue)
+	}
+
+	return util.EncodeCursor(&util.Cursor{Sort: cursorSortKey(sorts), Values: values, Direction: direction})
+}
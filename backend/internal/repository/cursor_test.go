@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
+	"testing"
+	"time"
+)
+
+func TestListCursor(t *testing.T) {
+	createdAt := time.Date(2021, 1, 31, 8, 30, 0, 0, time.UTC)
+	newToken := func(cursor *util.Cursor) string {
+		token, err := util.EncodeCursor(cursor)
+		assert.Nil(t, err)
+		return token
+	}
+
+	testCases := []struct {
+		name          string
+		query         *util.ListQuery
+		cursor        string
+		expectedSQL   string
+		expectedVars  []interface{}
+		expectedError string
+	}{
+		{
+			name:        "First page, newest first",
+			expectedSQL: `SELECT * FROM "suppliers" WHERE "suppliers"."deleted_at" IS NULL ORDER BY "created_at" DESC,"id" DESC LIMIT 11`,
+		},
+		{
+			name:         "Page after the cursor",
+			cursor:       newToken(&util.Cursor{Sort: "-created_at,-id", Values: []interface{}{createdAt, 7}, Direction: util.CursorNext}),
+			expectedSQL:  `SELECT * FROM "suppliers" WHERE ("created_at" < $1 OR ("created_at" = $2 AND "id" < $3)) AND "suppliers"."deleted_at" IS NULL ORDER BY "created_at" DESC,"id" DESC LIMIT 11`,
+			expectedVars: []interface{}{createdAt, createdAt, int64(7)},
+		},
+		{
+			name: "Page before the cursor, read backwards with the filters of the query",
+			query: &util.ListQuery{
+				Filters: []*util.ListFilter{{Field: "name", Operator: util.ListOperatorEq, Values: []interface{}{"Widdy"}}},
+				Sort:    []*util.ListSort{{Field: "name"}},
+			},
+			cursor:       newToken(&util.Cursor{Sort: "name,id", Values: []interface{}{"Widdy", 7}, Direction: util.CursorPrev}),
+			expectedSQL:  `SELECT * FROM "suppliers" WHERE ("name" < $1 OR ("name" = $2 AND "id" < $3)) AND "name" = $4 AND "suppliers"."deleted_at" IS NULL ORDER BY "name" DESC,"id" DESC LIMIT 11`,
+			expectedVars: []interface{}{"Widdy", "Widdy", int64(7), "Widdy"},
+		},
+		{
+			name:         "Sort on the id alone",
+			query:        &util.ListQuery{Sort: []*util.ListSort{{Field: "id"}}},
+			cursor:       newToken(&util.Cursor{Sort: "id", Values: []interface{}{7}, Direction: util.CursorNext}),
+			expectedSQL:  `SELECT * FROM "suppliers" WHERE "id" > $1 AND "suppliers"."deleted_at" IS NULL ORDER BY "id" LIMIT 11`,
+			expectedVars: []interface{}{int64(7)},
+		},
+		{
+			name:          "Cursor of another sort",
+			query:         &util.ListQuery{Sort: []*util.ListSort{{Field: "name"}}},
+			cursor:        newToken(&util.Cursor{Sort: "-created_at,-id", Values: []interface{}{createdAt, 7}, Direction: util.CursorNext}),
+			expectedError: response.ErrorInvalidCursor,
+		},
+		{
+			name:          "Cursor with values of the wrong type",
+			cursor:        newToken(&util.Cursor{Sort: "-created_at,-id", Values: []interface{}{createdAt, "7"}, Direction: util.CursorNext}),
+			expectedError: response.ErrorInvalidCursor,
+		},
+		{
+			name:          "Garbled cursor",
+			cursor:        "not a cursor",
+			expectedError: response.ErrorInvalidCursor,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newDryRunDB(t).WithContext(util.WithoutTenant(context.Background()))
+
+			var statementSQL string
+			var statementVars []interface{}
+			db.Callback().Query().After("gorm:query").Register("test:statement", func(db *gorm.DB) {
+				statementSQL = db.Statement.SQL.String()
+				statementVars = db.Statement.Vars
+			})
+
+			suppliers, page, err := listCursor[model.Supplier](db, tc.query, model.SupplierListSchema, tc.cursor, 10)
+			if tc.expectedError != "" {
+				assert.Equal(t, tc.expectedError, err.Error())
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Empty(t, suppliers)
+			assert.Equal(t, &util.CursorPage{}, page)
+			assert.Equal(t, tc.expectedSQL, statementSQL)
+			assert.Equal(t, tc.expectedVars, statementVars)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"gorm.io/gorm"
 	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
 )
 
 type CustomerRepository struct {
@@ -16,9 +17,9 @@ func NewCustomerRepository(db *gorm.DB) CustomerRepositoryContract {
 	}
 }
 
-func (repository *CustomerRepository) FindAll(ctx context.Context, offset int, limit int) ([]*model.Customer, error) {
+func (repository *CustomerRepository) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*model.Customer, error) {
 	var customers []*model.Customer
-	err := repository.DB.WithContext(ctx).Offset(offset).Limit(limit).Order("created_at DESC").Find(&customers).Error
+	err := listQuery(conn(ctx, repository.DB), query, model.CustomerListSchema).Offset(offset).Limit(limit).Find(&customers).Error
 	if err != nil {
 		return nil, err
 	}
@@ -26,9 +27,23 @@ func (repository *CustomerRepository) FindAll(ctx context.Context, offset int, l
 	return customers, nil
 }
 
-func (repository *CustomerRepository) CountAll(ctx context.Context) (int64, error) {
+func (repository *CustomerRepository) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*model.Customer, *util.CursorPage, error) {
+	return listCursor[model.Customer](conn(ctx, repository.DB), query, model.CustomerListSchema, cursor, limit)
+}
+
+func (repository *CustomerRepository) FindAllAfterID(ctx context.Context, afterID int64, limit int) ([]*model.Customer, error) {
+	var customers []*model.Customer
+	err := conn(ctx, repository.DB).Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(&customers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return customers, nil
+}
+
+func (repository *CustomerRepository) CountAll(ctx context.Context, query *util.ListQuery) (int64, error) {
 	var count int64
-	err := repository.DB.WithContext(ctx).Model(&model.Customer{}).Count(&count).Error
+	err := listFilter(conn(ctx, repository.DB).Model(&model.Customer{}), query).Count(&count).Error
 	if err != nil {
 		return 0, err
 	}
@@ -38,9 +53,9 @@ func (repository *CustomerRepository) CountAll(ctx context.Context) (int64, erro
 
 func (repository *CustomerRepository) FindByCodeWithAssociations(ctx context.Context, code string) (*model.Customer, error) {
 	var customer model.Customer
-	err := repository.DB.WithContext(ctx).Preload("Transactions").Preload("Transactions.ProductQuality", func(tx *gorm.DB) *gorm.DB {
-		return tx.Select("id", "product_code", "quality", "price")
-	}).Preload("Transactions.ProductQuality.Product").Where("code = ?", code).First(&customer).Error
+	err := conn(ctx, repository.DB).Preload("Transactions").Preload("Transactions.ProductQuality", func(tx *gorm.DB) *gorm.DB {
+		return withDeletedReferences(tx).Select("id", "product_code", "quality", "price")
+	}).Preload("Transactions.ProductQuality.Product", withDeletedReferences).Where("code = ?", code).First(&customer).Error
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +65,7 @@ func (repository *CustomerRepository) FindByCodeWithAssociations(ctx context.Con
 
 func (repository *CustomerRepository) FindByCode(ctx context.Context, code string) (*model.Customer, error) {
 	var customer model.Customer
-	err := repository.DB.WithContext(ctx).Where("code = ?", code).First(&customer).Error
+	err := conn(ctx, repository.DB).Where("code = ?", code).First(&customer).Error
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +74,14 @@ func (repository *CustomerRepository) FindByCode(ctx context.Context, code strin
 }
 
 func (repository *CustomerRepository) Create(ctx context.Context, customer *model.Customer) (*model.Customer, error) {
-	err := repository.DB.WithContext(ctx).Create(customer).Error
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		err := tx.WithContext(ctx).Create(customer).Error
+		if err != nil {
+			return err
+		}
+
+		return enqueueSearchOutbox(ctx, tx, customer.ToSearchOutboxEvent(model.OutboxOperationIndex))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -67,8 +89,40 @@ func (repository *CustomerRepository) Create(ctx context.Context, customer *mode
 	return customer, nil
 }
 
+// CreateAll creates the customers in batches within one database transaction, so either all of
+// them are created or none is.
+func (repository *CustomerRepository) CreateAll(ctx context.Context, customers []*model.Customer) ([]*model.Customer, error) {
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		err := tx.WithContext(ctx).CreateInBatches(customers, createBatchSize).Error
+		if err != nil {
+			return err
+		}
+
+		events := make([]*model.SearchOutboxEvent, len(customers))
+		for i, customer := range customers {
+			events[i] = customer.ToSearchOutboxEvent(model.OutboxOperationIndex)
+		}
+
+		return enqueueSearchOutbox(ctx, tx, events...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return customers, nil
+}
+
 func (repository *CustomerRepository) Update(ctx context.Context, customer *model.Customer) (*model.Customer, error) {
-	err := repository.DB.WithContext(ctx).Where("code = ?", customer.Code).Updates(&customer).Error
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		version := customer.Version
+		customer.Version++
+		err := versionUpdated(tx.WithContext(ctx).Where("code = ? AND version = ?", customer.Code, version).Updates(&customer))
+		if err != nil {
+			return err
+		}
+
+		return enqueueSearchOutbox(ctx, tx, customer.ToSearchOutboxEvent(model.OutboxOperationIndex))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -77,8 +131,72 @@ func (repository *CustomerRepository) Update(ctx context.Context, customer *mode
 }
 
 func (repository *CustomerRepository) Delete(ctx context.Context, code string) error {
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		var customer model.Customer
+		err := tx.WithContext(ctx).Where("code = ?", code).First(&customer).Error
+		if err != nil {
+			return err
+		}
+
+		// The customer is only marked as deleted, its transactions keep referencing it
+		err = tx.WithContext(ctx).Delete(&customer).Error
+		if err != nil {
+			return err
+		}
+
+		return enqueueSearchOutbox(ctx, tx, customer.ToSearchOutboxEvent(model.OutboxOperationDelete))
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *CustomerRepository) Restore(ctx context.Context, code string) (*model.Customer, error) {
 	var customer model.Customer
-	err := repository.DB.WithContext(ctx).Where("code = ?", code).Delete(&customer).Error
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		err := tx.WithContext(ctx).Unscoped().Where("code = ? AND deleted_at IS NOT NULL", code).First(&customer).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.WithContext(ctx).Unscoped().Model(&customer).Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+
+		return enqueueSearchOutbox(ctx, tx, customer.ToSearchOutboxEvent(model.OutboxOperationIndex))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &customer, nil
+}
+
+// Purge deletes the customer for good, whether it was soft deleted or not, unless transactions
+// reference it.
+func (repository *CustomerRepository) Purge(ctx context.Context, code string) error {
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		var customer model.Customer
+		err := tx.WithContext(ctx).Unscoped().Where("code = ?", code).First(&customer).Error
+		if err != nil {
+			return err
+		}
+
+		err = referencedByTransactions(ctx, tx, "customer_code = ?", customer.Code)
+		if err != nil {
+			return err
+		}
+
+		err = tx.WithContext(ctx).Unscoped().Delete(&customer).Error
+		if err != nil {
+			return err
+		}
+
+		return enqueueSearchOutbox(ctx, tx, customer.ToSearchOutboxEvent(model.OutboxOperationDelete))
+	})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"inventory-management/backend/internal/model"
+	"time"
+)
+
+type ExportJobRepository struct {
+	DB *gorm.DB
+}
+
+func NewExportJobRepository(db *gorm.DB) ExportJobRepositoryContract {
+	return &ExportJobRepository{
+		DB: db,
+	}
+}
+
+func (repository *ExportJobRepository) FindAllByRequester(ctx context.Context, requestedBy string, offset int, limit int) ([]*model.ExportJob, error) {
+	var jobs []*model.ExportJob
+	err := conn(ctx, repository.DB).Where("requested_by = ?", requestedBy).Order("created_at DESC").Offset(offset).Limit(limit).Find(&jobs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (repository *ExportJobRepository) CountAllByRequester(ctx context.Context, requestedBy string) (int64, error) {
+	var count int64
+	err := conn(ctx, repository.DB).Model(&model.ExportJob{}).Where("requested_by = ?", requestedBy).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (repository *ExportJobRepository) FindByCode(ctx context.Context, code string) (*model.ExportJob, error) {
+	var job model.ExportJob
+	err := conn(ctx, repository.DB).Where("code = ?", code).First(&job).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (repository *ExportJobRepository) Create(ctx context.Context, job *model.ExportJob) (*model.ExportJob, error) {
+	err := conn(ctx, repository.DB).Create(job).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Claim leases the oldest job waiting to run, so no other worker runs it until the lease runs
+// out. A running job whose lease ran out belongs to a worker that stopped, and is run again.
+func (repository *ExportJobRepository) Claim(ctx context.Context, lease time.Duration) (*model.ExportJob, error) {
+	now := time.Now()
+
+	var jobs []*model.ExportJob
+	err := conn(ctx, repository.DB).Raw(`UPDATE export_jobs SET status = ?, attempts = attempts + 1, available_at = ?, updated_at = ? WHERE id IN (
+		SELECT id FROM export_jobs WHERE status IN (?, ?) AND available_at <= ? ORDER BY id LIMIT 1 FOR UPDATE SKIP LOCKED
+	) RETURNING *`, model.ExportStatusRunning, now.Add(lease), now, model.ExportStatusPending, model.ExportStatusRunning, now).Scan(&jobs).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	return jobs[0], nil
+}
+
+// Update saves how a job ended, or when a failed one is tried again.
+func (repository *ExportJobRepository) Update(ctx context.Context, job *model.ExportJob) error {
+	err := conn(ctx, repository.DB).Select("status", "row_count", "file_path", "last_error", "available_at", "completed_at", "updated_at").Updates(job).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// FindAllCompletedBefore returns the finished jobs that completed before the time, oldest first.
+func (repository *ExportJobRepository) FindAllCompletedBefore(ctx context.Context, before time.Time, limit int) ([]*model.ExportJob, error) {
+	var jobs []*model.ExportJob
+	err := conn(ctx, repository.DB).Where("status IN ? AND completed_at <= ?", []string{model.ExportStatusDone, model.ExportStatusFailed}, before).Order("id").Limit(limit).Find(&jobs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (repository *ExportJobRepository) Delete(ctx context.Context, ids []int64) error {
+	err := conn(ctx, repository.DB).Where("id IN ?", ids).Delete(&model.ExportJob{}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"inventory-management/backend/internal/model"
+	"time"
+)
+
+type IdempotencyKeyRepository struct {
+	DB *gorm.DB
+}
+
+func NewIdempotencyKeyRepository(db *gorm.DB) IdempotencyKeyRepositoryContract {
+	return &IdempotencyKeyRepository{
+		DB: db,
+	}
+}
+
+// Claim creates the key and reports whether it did, which is the case for one of the requests
+// sent with it at the same time. A key that expired, or whose request has been processed for
+// longer than the lease without a response, is claimed again as if it were new.
+func (repository *IdempotencyKeyRepository) Claim(ctx context.Context, idempotencyKey *model.IdempotencyKey, lease time.Duration) (bool, error) {
+	now := time.Now()
+	idempotencyKey.CreatedAt = now
+
+	result := conn(ctx, repository.DB).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "username"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"fingerprint", "status_code", "content_type", "body", "created_at", "expires_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "idempotency_keys.expires_at <= ? OR (idempotency_keys.status_code = 0 AND idempotency_keys.created_at <= ?)", Vars: []interface{}{now, now.Add(-lease)}},
+		}},
+	}).Create(idempotencyKey)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (repository *IdempotencyKeyRepository) FindByKey(ctx context.Context, username string, key string) (*model.IdempotencyKey, error) {
+	var idempotencyKey model.IdempotencyKey
+	err := conn(ctx, repository.DB).Where("username = ? AND key = ?", username, key).First(&idempotencyKey).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &idempotencyKey, nil
+}
+
+// SaveResponse stores the response of the request the key was claimed for.
+func (repository *IdempotencyKeyRepository) SaveResponse(ctx context.Context, idempotencyKey *model.IdempotencyKey) error {
+	return conn(ctx, repository.DB).Model(&model.IdempotencyKey{}).
+		Where("username = ? AND key = ? AND fingerprint = ? AND status_code = 0", idempotencyKey.Username, idempotencyKey.Key, idempotencyKey.Fingerprint).
+		Updates(map[string]interface{}{
+			"status_code":  idempotencyKey.StatusCode,
+			"content_type": idempotencyKey.ContentType,
+			"body":         idempotencyKey.Body,
+		}).Error
+}
+
+// Release deletes a key whose request got no response worth storing, so it can be sent again.
+func (repository *IdempotencyKeyRepository) Release(ctx context.Context, idempotencyKey *model.IdempotencyKey) error {
+	return conn(ctx, repository.DB).
+		Where("username = ? AND key = ? AND fingerprint = ? AND status_code = 0", idempotencyKey.Username, idempotencyKey.Key, idempotencyKey.Fingerprint).
+		Delete(&model.IdempotencyKey{}).Error
+}
+
+// DeleteExpired deletes the keys that expired before the time and reports how many there were.
+func (repository *IdempotencyKeyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result := conn(ctx, repository.DB).Where("expires_at <= ?", before).Delete(&model.IdempotencyKey{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
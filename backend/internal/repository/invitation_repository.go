@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+)
+
+type InvitationRepository struct {
+	DB *gorm.DB
+}
+
+func NewInvitationRepository(db *gorm.DB) InvitationRepositoryContract {
+	return &InvitationRepository{
+		DB: db,
+	}
+}
+
+func (repository *InvitationRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*model.Invitation, error) {
+	var invitation model.Invitation
+	err := conn(ctx, repository.DB).Where("token_hash = ?", tokenHash).First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+func (repository *InvitationRepository) Create(ctx context.Context, invitation *model.Invitation) (*model.Invitation, error) {
+	err := conn(ctx, repository.DB).Create(invitation).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// MarkAccepted consumes the invitation, unless it was accepted already or expired. Concurrent
+// registrations wait for each other on the row, so only one of them consumes it.
+func (repository *InvitationRepository) MarkAccepted(ctx context.Context, id int64) error {
+	result := conn(ctx, repository.DB).Model(&model.Invitation{}).
+		Where("id = ? AND accepted_at IS NULL AND expires_at > now()", id).
+		Update("accepted_at", gorm.Expr("now()"))
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New(response.ErrorInvalidToken)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"inventory-management/backend/internal/model"
+)
+
+// ledgerLockKey identifies the advisory locks that serialize appends to the ledger, so every
+// entry is chained to the one committed before it. Each tenant has its own chain and lock.
+const ledgerLockKey = 731947
+
+type LedgerRepository struct {
+	DB *gorm.DB
+}
+
+func NewLedgerRepository(db *gorm.DB) LedgerRepositoryContract {
+	return &LedgerRepository{
+		DB: db,
+	}
+}
+
+func (repository *LedgerRepository) Append(ctx context.Context, transaction *model.Transaction, action string, tx *gorm.DB) (*model.LedgerEntry, error) {
+	if tx == nil {
+		var entry *model.LedgerEntry
+		err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+			var err error
+			entry, err = repository.Append(ctx, transaction, action, tx)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return entry, nil
+	}
+
+	payload, err := transaction.LedgerPayload()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(?, ?)", ledgerLockKey, transaction.TenantID).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var entry model.LedgerEntry
+	entry.TenantID = transaction.TenantID
+	entry.Sequence = 1
+	entry.PreviousHash = model.LedgerGenesisHash
+
+	var last model.LedgerEntry
+	err = tx.WithContext(ctx).Where("tenant_id = ?", transaction.TenantID).Order("sequence DESC").First(&last).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err == nil {
+		entry.Sequence = last.Sequence + 1
+		entry.PreviousHash = last.Hash
+	}
+
+	entry.TransactionCode = transaction.Code
+	entry.Action = action
+	entry.Payload = payload
+	entry.Hash = entry.ComputeHash()
+
+	err = tx.WithContext(ctx).Create(&entry).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (repository *LedgerRepository) FindAll(ctx context.Context, afterSequence int64, limit int) ([]*model.LedgerEntry, error) {
+	var entries []*model.LedgerEntry
+	err := conn(ctx, repository.DB).Where("sequence > ?", afterSequence).Order("sequence ASC").Limit(limit).Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (repository *LedgerRepository) FindLast(ctx context.Context) (*model.LedgerEntry, error) {
+	var entry model.LedgerEntry
+	err := conn(ctx, repository.DB).Order("sequence DESC").First(&entry).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (repository *LedgerRepository) FindLatestByTransactionCode(ctx context.Context, transactionCode string) (*model.LedgerEntry, error) {
+	var entry model.LedgerEntry
+	err := conn(ctx, repository.DB).Where("transaction_code = ?", transactionCode).Order("sequence DESC").First(&entry).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (repository *LedgerRepository) FindUnrecordedTransactions(ctx context.Context, limit int) ([]*model.Transaction, error) {
+	var transactions []*model.Transaction
+	err := conn(ctx, repository.DB).
+		Where("NOT EXISTS (SELECT 1 FROM transaction_ledger WHERE transaction_ledger.transaction_code = transactions.code)").
+		Order("id ASC").Limit(limit).Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// FindLegacyRecordedTransactions returns the transactions whose latest ledger entry has a legacy
+// payload, one recorded before the payload held the reference and version of the transaction.
+func (repository *LedgerRepository) FindLegacyRecordedTransactions(ctx context.Context, limit int) ([]*model.Transaction, error) {
+	var transactions []*model.Transaction
+	err := conn(ctx, repository.DB).
+		Where(`EXISTS (SELECT 1 FROM transaction_ledger WHERE transaction_ledger.tenant_id = transactions.tenant_id AND transaction_ledger.transaction_code = transactions.code
+			AND transaction_ledger.sequence = (SELECT MAX(latest.sequence) FROM transaction_ledger latest WHERE latest.tenant_id = transactions.tenant_id AND latest.transaction_code = transactions.code)
+			AND transaction_ledger.action <> ? AND ((transaction_ledger.payload::jsonb -> 'reference') IS NULL OR (transaction_ledger.payload::jsonb -> 'version') IS NULL))`, model.LedgerActionDelete).
+		Order("id ASC").Limit(limit).Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (repository *LedgerRepository) CreateCheckpoint(ctx context.Context, checkpoint *model.LedgerCheckpoint) (*model.LedgerCheckpoint, error) {
+	err := conn(ctx, repository.DB).Create(checkpoint).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return checkpoint, nil
+}
+
+func (repository *LedgerRepository) FindAllCheckpoints(ctx context.Context) ([]*model.LedgerCheckpoint, error) {
+	var checkpoints []*model.LedgerCheckpoint
+	err := conn(ctx, repository.DB).Order("sequence ASC").Find(&checkpoints).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return checkpoints, nil
+}
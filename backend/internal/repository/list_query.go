@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"inventory-management/backend/util"
+	"strings"
+	"time"
+)
+
+// listFilter scopes db to the filters of the list query. The fields were whitelisted when the
+// query was parsed, so they are safe to use as column names. Soft deleted records are left out
+// unless the query asks for them.
+func listFilter(db *gorm.DB, query *util.ListQuery) *gorm.DB {
+	if query == nil {
+		return db
+	}
+
+	switch query.Deleted {
+	case util.ListDeletedInclude:
+		db = db.Unscoped()
+	case util.ListDeletedOnly:
+		db = db.Unscoped().Where("deleted_at IS NOT NULL")
+	}
+
+	for _, filter := range query.Filters {
+		db = db.Where(listFilterExpression(filter))
+	}
+
+	return db
+}
+
+// listQuery scopes db to the filters, the order and the included associations of the list
+// query. The newest records come first unless the query is sorted.
+func listQuery(db *gorm.DB, query *util.ListQuery, schema *util.ListSchema) *gorm.DB {
+	db = listFilter(db, query)
+	if query == nil {
+		return db.Order("created_at DESC")
+	}
+
+	if len(query.Sort) == 0 {
+		db = db.Order("created_at DESC")
+	} else {
+		order := clause.OrderBy{}
+		for _, sort := range query.Sort {
+			order.Columns = append(order.Columns, clause.OrderByColumn{Column: clause.Column{Name: sort.Field}, Desc: sort.Descending})
+		}
+		db = db.Clauses(order)
+	}
+
+	return listInclude(db, query, schema)
+}
+
+// listInclude preloads the associations included by the list query.
+func listInclude(db *gorm.DB, query *util.ListQuery, schema *util.ListSchema) *gorm.DB {
+	if query == nil {
+		return db
+	}
+
+	for _, include := range query.Include {
+		for _, association := range schema.Includes[include] {
+			db = db.Preload(association)
+		}
+	}
+
+	return db
+}
+
+func listFilterExpression(filter *util.ListFilter) clause.Expression {
+	column := clause.Column{Name: filter.Field}
+	if filter.Expression != "" {
+		column = clause.Column{Name: filter.Expression, Raw: true}
+	}
+	value := filter.Values[0]
+
+	// A date covers the whole day, so its bounds move to the start of the next day
+	if day, ok := value.(time.Time); ok && filter.Day {
+		nextDay := day.AddDate(0, 0, 1)
+		switch filter.Operator {
+		case util.ListOperatorEq:
+			return clause.And(clause.Gte{Column: column, Value: day}, clause.Lt{Column: column, Value: nextDay})
+		case util.ListOperatorGt:
+			return clause.Gte{Column: column, Value: nextDay}
+		case util.ListOperatorLte:
+			return clause.Lt{Column: column, Value: nextDay}
+		}
+	}
+
+	switch filter.Operator {
+	case util.ListOperatorNe:
+		return clause.Neq{Column: column, Value: value}
+	case util.ListOperatorGt:
+		return clause.Gt{Column: column, Value: value}
+	case util.ListOperatorGte:
+		return clause.Gte{Column: column, Value: value}
+	case util.ListOperatorLt:
+		return clause.Lt{Column: column, Value: value}
+	case util.ListOperatorLte:
+		return clause.Lte{Column: column, Value: value}
+	case util.ListOperatorLike:
+		return clause.Expr{SQL: "? ILIKE ?", Vars: []interface{}{column, "%" + listLikeEscaper.Replace(value.(string)) + "%"}}
+	case util.ListOperatorIn:
+		return clause.IN{Column: column, Values: filter.Values}
+	default:
+		return clause.Eq{Column: column, Value: value}
+	}
+}
+
+var listLikeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
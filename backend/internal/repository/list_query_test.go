@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
+	"testing"
+	"time"
+)
+
+func TestListQuery(t *testing.T) {
+	day := time.Date(2021, 1, 31, 0, 0, 0, 0, time.Local)
+	nextDay := time.Date(2021, 2, 1, 0, 0, 0, 0, time.Local)
+
+	testCases := []struct {
+		name         string
+		query        *util.ListQuery
+		expectedSQL  string
+		expectedVars []interface{}
+	}{
+		{
+			name:        "Newest first without a query",
+			expectedSQL: `SELECT * FROM "suppliers" WHERE "suppliers"."deleted_at" IS NULL ORDER BY created_at DESC`,
+		},
+		{
+			name: "Filters and a sort on several fields",
+			query: &util.ListQuery{
+				Filters: []*util.ListFilter{
+					{Field: "name", Operator: util.ListOperatorLike, Values: []interface{}{"50%_off"}},
+					{Field: "code", Operator: util.ListOperatorIn, Values: []interface{}{"WDWDARFSYH", "ABCDEFGHIJ"}},
+					{Field: "id", Operator: util.ListOperatorNe, Values: []interface{}{float64(3)}},
+				},
+				Sort: []*util.ListSort{{Field: "name"}, {Field: "created_at", Descending: true}},
+			},
+			expectedSQL:  `SELECT * FROM "suppliers" WHERE "name" ILIKE $1 AND "code" IN ($2,$3) AND "id" <> $4 AND "suppliers"."deleted_at" IS NULL ORDER BY "name","created_at" DESC`,
+			expectedVars: []interface{}{`%50\%\_off%`, "WDWDARFSYH", "ABCDEFGHIJ", float64(3)},
+		},
+		{
+			name: "A date covers the whole day",
+			query: &util.ListQuery{
+				Filters: []*util.ListFilter{
+					{Field: "created_at", Operator: util.ListOperatorEq, Values: []interface{}{day}, Day: true},
+					{Field: "updated_at", Operator: util.ListOperatorLte, Values: []interface{}{day}, Day: true},
+					{Field: "updated_at", Operator: util.ListOperatorGt, Values: []interface{}{day}, Day: true},
+				},
+			},
+			expectedSQL:  `SELECT * FROM "suppliers" WHERE ("created_at" >= $1 AND "created_at" < $2) AND "updated_at" < $3 AND "updated_at" >= $4 AND "suppliers"."deleted_at" IS NULL ORDER BY created_at DESC`,
+			expectedVars: []interface{}{day, nextDay, nextDay, nextDay},
+		},
+		{
+			name:        "Deleted records included",
+			query:       &util.ListQuery{Deleted: util.ListDeletedInclude},
+			expectedSQL: `SELECT * FROM "suppliers" ORDER BY created_at DESC`,
+		},
+		{
+			name:        "Deleted records only",
+			query:       &util.ListQuery{Deleted: util.ListDeletedOnly},
+			expectedSQL: `SELECT * FROM "suppliers" WHERE deleted_at IS NOT NULL ORDER BY created_at DESC`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newDryRunDB(t)
+
+			var suppliers []*model.Supplier
+			statement := listQuery(db.WithContext(util.WithoutTenant(context.Background())), tc.query, model.SupplierListSchema).Find(&suppliers).Statement
+			assert.Equal(t, tc.expectedSQL, statement.SQL.String())
+			assert.Equal(t, tc.expectedVars, statement.Vars)
+		})
+	}
+}
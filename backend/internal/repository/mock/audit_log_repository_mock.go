@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/model"
+)
+
+type AuditLogRepositoryMock struct {
+	mock.Mock
+}
+
+func (mock *AuditLogRepositoryMock) FindAll(ctx context.Context, filter *request.AuditLogFilterRequest, offset int, limit int) ([]*model.AuditLog, error) {
+	args := mock.Called(ctx, filter, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.AuditLog), args.Error(1)
+}
+
+func (mock *AuditLogRepositoryMock) CountAll(ctx context.Context, filter *request.AuditLogFilterRequest) (int64, error) {
+	args := mock.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return 0, args.Error(1)
+	}
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (mock *AuditLogRepositoryMock) Create(ctx context.Context, auditLog *model.AuditLog) (*model.AuditLog, error) {
+	args := mock.Called(ctx, auditLog)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.AuditLog), args.Error(1)
+}
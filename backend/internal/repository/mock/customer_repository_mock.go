@@ -4,14 +4,15 @@ import (
 	"context"
 	"github.com/stretchr/testify/mock"
 	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
 )
 
 type CustomerRepositoryMock struct {
 	mock.Mock
 }
 
-func (mock *CustomerRepositoryMock) FindAll(ctx context.Context, offset int, limit int) ([]*model.Customer, error) {
-	args := mock.Called(ctx, offset, limit)
+func (mock *CustomerRepositoryMock) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*model.Customer, error) {
+	args := mock.Called(ctx, query, offset, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -19,8 +20,26 @@ func (mock *CustomerRepositoryMock) FindAll(ctx context.Context, offset int, lim
 	return args.Get(0).([]*model.Customer), args.Error(1)
 }
 
-func (mock *CustomerRepositoryMock) CountAll(ctx context.Context) (int64, error) {
-	args := mock.Called(ctx)
+func (mock *CustomerRepositoryMock) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*model.Customer, *util.CursorPage, error) {
+	args := mock.Called(ctx, query, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+
+	return args.Get(0).([]*model.Customer), args.Get(1).(*util.CursorPage), args.Error(2)
+}
+
+func (mock *CustomerRepositoryMock) FindAllAfterID(ctx context.Context, afterID int64, limit int) ([]*model.Customer, error) {
+	args := mock.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.Customer), args.Error(1)
+}
+
+func (mock *CustomerRepositoryMock) CountAll(ctx context.Context, query *util.ListQuery) (int64, error) {
+	args := mock.Called(ctx, query)
 	if args.Get(0) == nil {
 		return 0, args.Error(1)
 	}
@@ -55,6 +74,15 @@ func (mock *CustomerRepositoryMock) Create(ctx context.Context, customer *model.
 	return args.Get(0).(*model.Customer), args.Error(1)
 }
 
+func (mock *CustomerRepositoryMock) CreateAll(ctx context.Context, customers []*model.Customer) ([]*model.Customer, error) {
+	args := mock.Called(ctx, customers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.Customer), args.Error(1)
+}
+
 func (mock *CustomerRepositoryMock) Update(ctx context.Context, customer *model.Customer) (*model.Customer, error) {
 	args := mock.Called(ctx, customer)
 	if args.Get(0) == nil {
@@ -68,3 +96,17 @@ func (mock *CustomerRepositoryMock) Delete(ctx context.Context, code string) err
 	args := mock.Called(ctx, code)
 	return args.Error(0)
 }
+
+func (mock *CustomerRepositoryMock) Restore(ctx context.Context, code string) (*model.Customer, error) {
+	args := mock.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.Customer), args.Error(1)
+}
+
+func (mock *CustomerRepositoryMock) Purge(ctx context.Context, code string) error {
+	args := mock.Called(ctx, code)
+	return args.Error(0)
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/model"
+	"time"
+)
+
+type ExportJobRepositoryMock struct {
+	mock.Mock
+}
+
+func (mock *ExportJobRepositoryMock) FindAllByRequester(ctx context.Context, requestedBy string, offset int, limit int) ([]*model.ExportJob, error) {
+	args := mock.Called(ctx, requestedBy, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.ExportJob), args.Error(1)
+}
+
+func (mock *ExportJobRepositoryMock) CountAllByRequester(ctx context.Context, requestedBy string) (int64, error) {
+	args := mock.Called(ctx, requestedBy)
+	if args.Get(0) == nil {
+		return 0, args.Error(1)
+	}
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (mock *ExportJobRepositoryMock) FindByCode(ctx context.Context, code string) (*model.ExportJob, error) {
+	args := mock.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.ExportJob), args.Error(1)
+}
+
+func (mock *ExportJobRepositoryMock) Create(ctx context.Context, job *model.ExportJob) (*model.ExportJob, error) {
+	args := mock.Called(ctx, job)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.ExportJob), args.Error(1)
+}
+
+func (mock *ExportJobRepositoryMock) Claim(ctx context.Context, lease time.Duration) (*model.ExportJob, error) {
+	args := mock.Called(ctx, lease)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.ExportJob), args.Error(1)
+}
+
+func (mock *ExportJobRepositoryMock) Update(ctx context.Context, job *model.ExportJob) error {
+	args := mock.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (mock *ExportJobRepositoryMock) FindAllCompletedBefore(ctx context.Context, before time.Time, limit int) ([]*model.ExportJob, error) {
+	args := mock.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.ExportJob), args.Error(1)
+}
+
+func (mock *ExportJobRepositoryMock) Delete(ctx context.Context, ids []int64) error {
+	args := mock.Called(ctx, ids)
+	return args.Error(0)
+}
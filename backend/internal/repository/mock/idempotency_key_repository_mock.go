@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/model"
+	"time"
+)
+
+type IdempotencyKeyRepositoryMock struct {
+	mock.Mock
+}
+
+func (mock *IdempotencyKeyRepositoryMock) Claim(ctx context.Context, idempotencyKey *model.IdempotencyKey, lease time.Duration) (bool, error) {
+	args := mock.Called(ctx, idempotencyKey, lease)
+	return args.Get(0).(bool), args.Error(1)
+}
+
+func (mock *IdempotencyKeyRepositoryMock) FindByKey(ctx context.Context, username string, key string) (*model.IdempotencyKey, error) {
+	args := mock.Called(ctx, username, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.IdempotencyKey), args.Error(1)
+}
+
+func (mock *IdempotencyKeyRepositoryMock) SaveResponse(ctx context.Context, idempotencyKey *model.IdempotencyKey) error {
+	args := mock.Called(ctx, idempotencyKey)
+	return args.Error(0)
+}
+
+func (mock *IdempotencyKeyRepositoryMock) Release(ctx context.Context, idempotencyKey *model.IdempotencyKey) error {
+	args := mock.Called(ctx, idempotencyKey)
+	return args.Error(0)
+}
+
+func (mock *IdempotencyKeyRepositoryMock) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	args := mock.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
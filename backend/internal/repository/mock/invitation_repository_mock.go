@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/model"
+)
+
+type InvitationRepositoryMock struct {
+	mock.Mock
+}
+
+func (mock *InvitationRepositoryMock) FindByTokenHash(ctx context.Context, tokenHash string) (*model.Invitation, error) {
+	args := mock.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.Invitation), args.Error(1)
+}
+
+func (mock *InvitationRepositoryMock) Create(ctx context.Context, invitation *model.Invitation) (*model.Invitation, error) {
+	args := mock.Called(ctx, invitation)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.Invitation), args.Error(1)
+}
+
+func (mock *InvitationRepositoryMock) MarkAccepted(ctx context.Context, id int64) error {
+	args := mock.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+	"inventory-management/backend/internal/model"
+)
+
+type LedgerRepositoryMock struct {
+	mock.Mock
+}
+
+func (mock *LedgerRepositoryMock) Append(ctx context.Context, transaction *model.Transaction, action string, tx *gorm.DB) (*model.LedgerEntry, error) {
+	args := mock.Called(ctx, transaction, action)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.LedgerEntry), args.Error(1)
+}
+
+func (mock *LedgerRepositoryMock) FindAll(ctx context.Context, afterSequence int64, limit int) ([]*model.LedgerEntry, error) {
+	args := mock.Called(ctx, afterSequence, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.LedgerEntry), args.Error(1)
+}
+
+func (mock *LedgerRepositoryMock) FindLast(ctx context.Context) (*model.LedgerEntry, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.LedgerEntry), args.Error(1)
+}
+
+func (mock *LedgerRepositoryMock) FindLatestByTransactionCode(ctx context.Context, transactionCode string) (*model.LedgerEntry, error) {
+	args := mock.Called(ctx, transactionCode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.LedgerEntry), args.Error(1)
+}
+
+func (mock *LedgerRepositoryMock) FindUnrecordedTransactions(ctx context.Context, limit int) ([]*model.Transaction, error) {
+	args := mock.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.Transaction), args.Error(1)
+}
+
+func (mock *LedgerRepositoryMock) FindLegacyRecordedTransactions(ctx context.Context, limit int) ([]*model.Transaction, error) {
+	args := mock.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.Transaction), args.Error(1)
+}
+
+func (mock *LedgerRepositoryMock) CreateCheckpoint(ctx context.Context, checkpoint *model.LedgerCheckpoint) (*model.LedgerCheckpoint, error) {
+	args := mock.Called(ctx, checkpoint)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.LedgerCheckpoint), args.Error(1)
+}
+
+func (mock *LedgerRepositoryMock) FindAllCheckpoints(ctx context.Context) ([]*model.LedgerCheckpoint, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.LedgerCheckpoint), args.Error(1)
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/model"
+	"time"
+)
+
+type OidcLoginStateRepositoryMock struct {
+	mock.Mock
+}
+
+func (mock *OidcLoginStateRepositoryMock) Create(ctx context.Context, loginState *model.OidcLoginState) (*model.OidcLoginState, error) {
+	args := mock.Called(ctx, loginState)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.OidcLoginState), args.Error(1)
+}
+
+func (mock *OidcLoginStateRepositoryMock) Consume(ctx context.Context, stateHash string) (*model.OidcLoginState, error) {
+	args := mock.Called(ctx, stateHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.OidcLoginState), args.Error(1)
+}
+
+func (mock *OidcLoginStateRepositoryMock) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	args := mock.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/model"
+)
+
+type PasswordResetRepositoryMock struct {
+	mock.Mock
+}
+
+func (mock *PasswordResetRepositoryMock) FindByTokenHash(ctx context.Context, tokenHash string) (*model.PasswordReset, error) {
+	args := mock.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.PasswordReset), args.Error(1)
+}
+
+func (mock *PasswordResetRepositoryMock) Create(ctx context.Context, passwordReset *model.PasswordReset) (*model.PasswordReset, error) {
+	args := mock.Called(ctx, passwordReset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.PasswordReset), args.Error(1)
+}
+
+func (mock *PasswordResetRepositoryMock) MarkUsed(ctx context.Context, id int64) error {
+	args := mock.Called(ctx, id)
+	return args.Error(0)
+}
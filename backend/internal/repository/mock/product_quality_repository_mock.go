@@ -30,6 +30,15 @@ func (mock *ProductQualityRepositoryMock) FindAll(ctx context.Context, tx *gorm.
 	return args.Get(0).([]*model.ProductQuality), args.Error(1)
 }
 
+func (mock *ProductQualityRepositoryMock) FindAllAfterID(ctx context.Context, afterID int64, limit int) ([]*model.ProductQuality, error) {
+	args := mock.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.ProductQuality), args.Error(1)
+}
+
 func (mock *ProductQualityRepositoryMock) FindAllByProductCode(ctx context.Context, productCode string, tx *gorm.DB) ([]*model.ProductQuality, error) {
 	args := mock.Called(ctx, productCode)
 	if args.Get(0) == nil {
@@ -61,3 +70,26 @@ func (mock *ProductQualityRepositoryMock) Delete(ctx context.Context, id int64,
 	args := mock.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (mock *ProductQualityRepositoryMock) Restore(ctx context.Context, id int64) (*model.ProductQuality, error) {
+	args := mock.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.ProductQuality), args.Error(1)
+}
+
+func (mock *ProductQualityRepositoryMock) Purge(ctx context.Context, id int64) error {
+	args := mock.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (mock *ProductQualityRepositoryMock) FindByGtinWithAssociations(ctx context.Context, gtin string) (*model.ProductQuality, error) {
+	args := mock.Called(ctx, gtin)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.ProductQuality), args.Error(1)
+}
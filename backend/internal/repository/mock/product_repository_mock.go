@@ -4,14 +4,15 @@ import (
 	"context"
 	"github.com/stretchr/testify/mock"
 	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
 )
 
 type ProductRepositoryMock struct {
 	mock.Mock
 }
 
-func (mock *ProductRepositoryMock) FindAll(ctx context.Context, offset int, limit int) ([]*model.Product, error) {
-	args := mock.Called(ctx, offset, limit)
+func (mock *ProductRepositoryMock) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*model.Product, error) {
+	args := mock.Called(ctx, query, offset, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -19,8 +20,26 @@ func (mock *ProductRepositoryMock) FindAll(ctx context.Context, offset int, limi
 	return args.Get(0).([]*model.Product), args.Error(1)
 }
 
-func (mock *ProductRepositoryMock) CountAll(ctx context.Context) (int64, error) {
-	args := mock.Called(ctx)
+func (mock *ProductRepositoryMock) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*model.Product, *util.CursorPage, error) {
+	args := mock.Called(ctx, query, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+
+	return args.Get(0).([]*model.Product), args.Get(1).(*util.CursorPage), args.Error(2)
+}
+
+func (mock *ProductRepositoryMock) FindAllAfterID(ctx context.Context, afterID int64, limit int) ([]*model.Product, error) {
+	args := mock.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.Product), args.Error(1)
+}
+
+func (mock *ProductRepositoryMock) CountAll(ctx context.Context, query *util.ListQuery) (int64, error) {
+	args := mock.Called(ctx, query)
 	if args.Get(0) == nil {
 		return 0, args.Error(1)
 	}
@@ -46,6 +65,15 @@ func (mock *ProductRepositoryMock) Create(ctx context.Context, product *model.Pr
 	return args.Get(0).(*model.Product), args.Error(1)
 }
 
+func (mock *ProductRepositoryMock) CreateAll(ctx context.Context, products []*model.Product) ([]*model.Product, error) {
+	args := mock.Called(ctx, products)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.Product), args.Error(1)
+}
+
 func (mock *ProductRepositoryMock) Update(ctx context.Context, product *model.Product) (*model.Product, error) {
 	args := mock.Called(ctx, product)
 	if args.Get(0) == nil {
@@ -59,3 +87,26 @@ func (mock *ProductRepositoryMock) Delete(ctx context.Context, code string) erro
 	args := mock.Called(ctx, code)
 	return args.Error(0)
 }
+
+func (mock *ProductRepositoryMock) Restore(ctx context.Context, code string) (*model.Product, error) {
+	args := mock.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.Product), args.Error(1)
+}
+
+func (mock *ProductRepositoryMock) Purge(ctx context.Context, code string) error {
+	args := mock.Called(ctx, code)
+	return args.Error(0)
+}
+
+func (mock *ProductRepositoryMock) FindByGtin(ctx context.Context, gtin string) (*model.Product, error) {
+	args := mock.Called(ctx, gtin)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.Product), args.Error(1)
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/model"
+	"time"
+)
+
+type SearchOutboxRepositoryMock struct {
+	mock.Mock
+}
+
+func (mock *SearchOutboxRepositoryMock) Claim(ctx context.Context, limit int, lease time.Duration) ([]*model.SearchOutboxEvent, error) {
+	args := mock.Called(ctx, limit, lease)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.SearchOutboxEvent), args.Error(1)
+}
+
+func (mock *SearchOutboxRepositoryMock) Delete(ctx context.Context, ids []int64) error {
+	args := mock.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (mock *SearchOutboxRepositoryMock) MarkFailed(ctx context.Context, ids []int64, lastError string, availableAt time.Time) error {
+	args := mock.Called(ctx, ids, lastError, availableAt)
+	return args.Error(0)
+}
+
+func (mock *SearchOutboxRepositoryMock) Stats(ctx context.Context) (*model.SearchOutboxStats, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.SearchOutboxStats), args.Error(1)
+}
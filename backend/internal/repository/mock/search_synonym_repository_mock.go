@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/model"
+)
+
+type SearchSynonymRepositoryMock struct {
+	mock.Mock
+}
+
+func (mock *SearchSynonymRepositoryMock) FindAll(ctx context.Context) ([]*model.SearchSynonym, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.SearchSynonym), args.Error(1)
+}
+
+func (mock *SearchSynonymRepositoryMock) FindByID(ctx context.Context, id int64) (*model.SearchSynonym, error) {
+	args := mock.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.SearchSynonym), args.Error(1)
+}
+
+func (mock *SearchSynonymRepositoryMock) Create(ctx context.Context, synonym *model.SearchSynonym) (*model.SearchSynonym, error) {
+	args := mock.Called(ctx, synonym)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.SearchSynonym), args.Error(1)
+}
+
+func (mock *SearchSynonymRepositoryMock) Update(ctx context.Context, synonym *model.SearchSynonym) (*model.SearchSynonym, error) {
+	args := mock.Called(ctx, synonym)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.SearchSynonym), args.Error(1)
+}
+
+func (mock *SearchSynonymRepositoryMock) Delete(ctx context.Context, id int64) error {
+	args := mock.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/model"
+)
+
+type SettingRepositoryMock struct {
+	mock.Mock
+}
+
+func (mock *SettingRepositoryMock) FindBranding(ctx context.Context) (*model.BrandingSetting, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.BrandingSetting), args.Error(1)
+}
+
+func (mock *SettingRepositoryMock) SaveBranding(ctx context.Context, branding *model.BrandingSetting) (*model.BrandingSetting, error) {
+	args := mock.Called(ctx, branding)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.BrandingSetting), args.Error(1)
+}
+
+func (mock *SettingRepositoryMock) FindAllLabelTemplates(ctx context.Context) ([]*model.LabelTemplate, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.LabelTemplate), args.Error(1)
+}
+
+func (mock *SettingRepositoryMock) FindLabelTemplate(ctx context.Context, labelType string) (*model.LabelTemplate, error) {
+	args := mock.Called(ctx, labelType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.LabelTemplate), args.Error(1)
+}
+
+func (mock *SettingRepositoryMock) SaveLabelTemplate(ctx context.Context, labelTemplate *model.LabelTemplate) (*model.LabelTemplate, error) {
+	args := mock.Called(ctx, labelTemplate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.LabelTemplate), args.Error(1)
+}
+
+func (mock *SettingRepositoryMock) FindAllNumberingSequences(ctx context.Context) ([]*model.NumberingSequence, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.NumberingSequence), args.Error(1)
+}
+
+func (mock *SettingRepositoryMock) FindNumberingSequence(ctx context.Context, entity string, transactionType string) (*model.NumberingSequence, error) {
+	args := mock.Called(ctx, entity, transactionType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.NumberingSequence), args.Error(1)
+}
+
+func (mock *SettingRepositoryMock) SaveNumberingSequence(ctx context.Context, sequence *model.NumberingSequence, restart bool) (*model.NumberingSequence, error) {
+	args := mock.Called(ctx, sequence, restart)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.NumberingSequence), args.Error(1)
+}
+
+func (mock *SettingRepositoryMock) CodeTaken(ctx context.Context, entity string, code string) (bool, error) {
+	args := mock.Called(ctx, entity, code)
+	return args.Bool(0), args.Error(1)
+}
@@ -4,14 +4,15 @@ import (
 	"context"
 	"github.com/stretchr/testify/mock"
 	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
 )
 
 type SupplierRepositoryMock struct {
 	mock.Mock
 }
 
-func (mock *SupplierRepositoryMock) FindAll(ctx context.Context, offset int, limit int) ([]*model.Supplier, error) {
-	args := mock.Called(ctx, offset, limit)
+func (mock *SupplierRepositoryMock) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*model.Supplier, error) {
+	args := mock.Called(ctx, query, offset, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -19,8 +20,26 @@ func (mock *SupplierRepositoryMock) FindAll(ctx context.Context, offset int, lim
 	return args.Get(0).([]*model.Supplier), args.Error(1)
 }
 
-func (mock *SupplierRepositoryMock) CountAll(ctx context.Context) (int64, error) {
-	args := mock.Called(ctx)
+func (mock *SupplierRepositoryMock) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*model.Supplier, *util.CursorPage, error) {
+	args := mock.Called(ctx, query, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+
+	return args.Get(0).([]*model.Supplier), args.Get(1).(*util.CursorPage), args.Error(2)
+}
+
+func (mock *SupplierRepositoryMock) FindAllAfterID(ctx context.Context, afterID int64, limit int) ([]*model.Supplier, error) {
+	args := mock.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.Supplier), args.Error(1)
+}
+
+func (mock *SupplierRepositoryMock) CountAll(ctx context.Context, query *util.ListQuery) (int64, error) {
+	args := mock.Called(ctx, query)
 	if args.Get(0) == nil {
 		return 0, args.Error(1)
 	}
@@ -55,6 +74,15 @@ func (mock *SupplierRepositoryMock) Create(ctx context.Context, supplier *model.
 	return args.Get(0).(*model.Supplier), args.Error(1)
 }
 
+func (mock *SupplierRepositoryMock) CreateAll(ctx context.Context, suppliers []*model.Supplier) ([]*model.Supplier, error) {
+	args := mock.Called(ctx, suppliers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.Supplier), args.Error(1)
+}
+
 func (mock *SupplierRepositoryMock) Update(ctx context.Context, supplier *model.Supplier) (*model.Supplier, error) {
 	args := mock.Called(ctx, supplier)
 	if args.Get(0) == nil {
@@ -68,3 +96,17 @@ func (mock *SupplierRepositoryMock) Delete(ctx context.Context, code string) err
 	args := mock.Called(ctx, code)
 	return args.Error(0)
 }
+
+func (mock *SupplierRepositoryMock) Restore(ctx context.Context, code string) (*model.Supplier, error) {
+	args := mock.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.Supplier), args.Error(1)
+}
+
+func (mock *SupplierRepositoryMock) Purge(ctx context.Context, code string) error {
+	args := mock.Called(ctx, code)
+	return args.Error(0)
+}
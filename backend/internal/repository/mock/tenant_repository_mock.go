@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/model"
+)
+
+type TenantRepositoryMock struct {
+	mock.Mock
+}
+
+func (mock *TenantRepositoryMock) FindAll(ctx context.Context, offset int, limit int) ([]*model.Tenant, error) {
+	args := mock.Called(ctx, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.Tenant), args.Error(1)
+}
+
+func (mock *TenantRepositoryMock) CountAll(ctx context.Context) (int64, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return 0, args.Error(1)
+	}
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (mock *TenantRepositoryMock) FindByID(ctx context.Context, id int64) (*model.Tenant, error) {
+	args := mock.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.Tenant), args.Error(1)
+}
+
+func (mock *TenantRepositoryMock) FindByCode(ctx context.Context, code string) (*model.Tenant, error) {
+	args := mock.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.Tenant), args.Error(1)
+}
+
+func (mock *TenantRepositoryMock) FindByApiKeyHash(ctx context.Context, apiKeyHash string) (*model.Tenant, error) {
+	args := mock.Called(ctx, apiKeyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.Tenant), args.Error(1)
+}
+
+func (mock *TenantRepositoryMock) Create(ctx context.Context, tenant *model.Tenant) (*model.Tenant, error) {
+	args := mock.Called(ctx, tenant)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.Tenant), args.Error(1)
+}
+
+func (mock *TenantRepositoryMock) Update(ctx context.Context, tenant *model.Tenant) (*model.Tenant, error) {
+	args := mock.Called(ctx, tenant)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.Tenant), args.Error(1)
+}
@@ -5,14 +5,15 @@ import (
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
 )
 
 type TransactionRepositoryMock struct {
 	mock.Mock
 }
 
-func (mock *TransactionRepositoryMock) FindAll(ctx context.Context, offset int, limit int, tx *gorm.DB) ([]*model.Transaction, error) {
-	args := mock.Called(ctx, offset, limit)
+func (mock *TransactionRepositoryMock) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int, tx *gorm.DB) ([]*model.Transaction, error) {
+	args := mock.Called(ctx, query, offset, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -20,17 +21,17 @@ func (mock *TransactionRepositoryMock) FindAll(ctx context.Context, offset int,
 	return args.Get(0).([]*model.Transaction), args.Error(1)
 }
 
-func (mock *TransactionRepositoryMock) CountAll(ctx context.Context, tx *gorm.DB) (int64, error) {
-	args := mock.Called(ctx)
+func (mock *TransactionRepositoryMock) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*model.Transaction, *util.CursorPage, error) {
+	args := mock.Called(ctx, query, cursor, limit)
 	if args.Get(0) == nil {
-		return 0, args.Error(1)
+		return nil, nil, args.Error(2)
 	}
 
-	return args.Get(0).(int64), args.Error(1)
+	return args.Get(0).([]*model.Transaction), args.Get(1).(*util.CursorPage), args.Error(2)
 }
 
-func (mock *TransactionRepositoryMock) FindAllBySupplierCode(ctx context.Context, supplierCode string, tx *gorm.DB) ([]*model.Transaction, error) {
-	args := mock.Called(ctx, supplierCode)
+func (mock *TransactionRepositoryMock) FindAllAfterID(ctx context.Context, afterID int64, limit int) ([]*model.Transaction, error) {
+	args := mock.Called(ctx, afterID, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -38,13 +39,22 @@ func (mock *TransactionRepositoryMock) FindAllBySupplierCode(ctx context.Context
 	return args.Get(0).([]*model.Transaction), args.Error(1)
 }
 
-func (mock *TransactionRepositoryMock) FindAllByCustomerCode(ctx context.Context, customerCode string, tx *gorm.DB) ([]*model.Transaction, error) {
-	args := mock.Called(ctx, customerCode)
+func (mock *TransactionRepositoryMock) CountAll(ctx context.Context, query *util.ListQuery, tx *gorm.DB) (int64, error) {
+	args := mock.Called(ctx, query)
+	if args.Get(0) == nil {
+		return 0, args.Error(1)
+	}
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (mock *TransactionRepositoryMock) TotalByType(ctx context.Context, query *util.ListQuery) ([]*model.TransactionTotal, error) {
+	args := mock.Called(ctx, query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 
-	return args.Get(0).([]*model.Transaction), args.Error(1)
+	return args.Get(0).([]*model.TransactionTotal), args.Error(1)
 }
 
 func (mock *TransactionRepositoryMock) FindByCodeWithAssociations(ctx context.Context, code string, tx *gorm.DB) (*model.Transaction, error) {
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+// TransactorMock runs the functions without a database transaction. It keeps the errors they
+// returned, each one would have rolled its transaction back.
+type TransactorMock struct {
+	mock.Mock
+	RolledBack []error
+}
+
+func (mock *TransactorMock) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+	if err != nil {
+		mock.RolledBack = append(mock.RolledBack, err)
+	}
+
+	return err
+}
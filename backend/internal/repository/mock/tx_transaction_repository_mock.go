@@ -20,6 +20,24 @@ func (mock *TxTransactionRepositoryMock) Create(ctx context.Context, request *re
 	return args.Get(0).(*model.Transaction), args.Error(1)
 }
 
+func (mock *TxTransactionRepositoryMock) CreateBatch(ctx context.Context, request *request.CreateTransactionBatchRequest) ([]*model.Transaction, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.Transaction), args.Error(1)
+}
+
+func (mock *TxTransactionRepositoryMock) CreateAll(ctx context.Context, requests []*request.CreateTransactionRequest) ([]*model.Transaction, error) {
+	args := mock.Called(ctx, requests)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.Transaction), args.Error(1)
+}
+
 func (mock *TxTransactionRepositoryMock) TransferStock(ctx context.Context, request *request.TransferStockTransactionRequest) (*model.Transaction, error) {
 	args := mock.Called(ctx, request)
 	if args.Get(0) == nil {
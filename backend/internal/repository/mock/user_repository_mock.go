@@ -4,14 +4,15 @@ import (
 	"context"
 	"github.com/stretchr/testify/mock"
 	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
 )
 
 type UserRepositoryMock struct {
 	mock.Mock
 }
 
-func (mock *UserRepositoryMock) FindAll(ctx context.Context, offset int, limit int) ([]*model.User, error) {
-	args := mock.Called(ctx, offset, limit)
+func (mock *UserRepositoryMock) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*model.User, error) {
+	args := mock.Called(ctx, query, offset, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -19,8 +20,26 @@ func (mock *UserRepositoryMock) FindAll(ctx context.Context, offset int, limit i
 	return args.Get(0).([]*model.User), args.Error(1)
 }
 
-func (mock *UserRepositoryMock) CountAll(ctx context.Context) (int64, error) {
-	args := mock.Called(ctx)
+func (mock *UserRepositoryMock) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*model.User, *util.CursorPage, error) {
+	args := mock.Called(ctx, query, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+
+	return args.Get(0).([]*model.User), args.Get(1).(*util.CursorPage), args.Error(2)
+}
+
+func (mock *UserRepositoryMock) FindAllAfterID(ctx context.Context, afterID int64, limit int) ([]*model.User, error) {
+	args := mock.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*model.User), args.Error(1)
+}
+
+func (mock *UserRepositoryMock) CountAll(ctx context.Context, query *util.ListQuery) (int64, error) {
+	args := mock.Called(ctx, query)
 	if args.Get(0) == nil {
 		return 0, args.Error(1)
 	}
@@ -46,6 +65,24 @@ func (mock *UserRepositoryMock) FindByUsername(ctx context.Context, username str
 	return args.Get(0).(*model.User), args.Error(1)
 }
 
+func (mock *UserRepositoryMock) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	args := mock.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (mock *UserRepositoryMock) FindByOidcSubject(ctx context.Context, issuer string, subject string) (*model.User, error) {
+	args := mock.Called(ctx, issuer, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
 func (mock *UserRepositoryMock) Create(ctx context.Context, user *model.User) (*model.User, error) {
 	args := mock.Called(ctx, user)
 	if args.Get(0) == nil {
@@ -68,3 +105,17 @@ func (mock *UserRepositoryMock) Delete(ctx context.Context, id int64) error {
 	args := mock.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (mock *UserRepositoryMock) Restore(ctx context.Context, id int64) (*model.User, error) {
+	args := mock.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (mock *UserRepositoryMock) Purge(ctx context.Context, id int64) error {
+	args := mock.Called(ctx, id)
+	return args.Error(0)
+}
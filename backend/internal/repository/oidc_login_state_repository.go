@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"time"
+)
+
+type OidcLoginStateRepository struct {
+	DB *gorm.DB
+}
+
+func NewOidcLoginStateRepository(db *gorm.DB) OidcLoginStateRepositoryContract {
+	return &OidcLoginStateRepository{
+		DB: db,
+	}
+}
+
+func (repository *OidcLoginStateRepository) Create(ctx context.Context, loginState *model.OidcLoginState) (*model.OidcLoginState, error) {
+	err := conn(ctx, repository.DB).Create(loginState).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return loginState, nil
+}
+
+// Consume deletes the login state and returns it, unless it expired. A state is consumed once,
+// concurrent callbacks with it wait for each other on the row.
+func (repository *OidcLoginStateRepository) Consume(ctx context.Context, stateHash string) (*model.OidcLoginState, error) {
+	var loginStates []*model.OidcLoginState
+	err := conn(ctx, repository.DB).Raw(`DELETE FROM oidc_login_states WHERE state_hash = ? AND expires_at > ? RETURNING *`, stateHash, time.Now()).
+		Scan(&loginStates).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(loginStates) == 0 {
+		return nil, errors.New(response.ErrorNotFound)
+	}
+
+	return loginStates[0], nil
+}
+
+// DeleteExpired deletes the login states that expired before the time and reports how many
+// there were.
+func (repository *OidcLoginStateRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result := conn(ctx, repository.DB).Where("expires_at <= ?", before).Delete(&model.OidcLoginState{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/joho/godotenv"
+	"gorm.io/gorm"
+	"inventory-management/backend/cmd/config"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
+	"os"
+	"testing"
+	"time"
+)
+
+// benchmarkTransactions is about the size of a transaction history that made offset pages slow.
+const benchmarkTransactions = 500000
+
+type environmentConfig struct{}
+
+func (environmentConfig) Get(key string) string {
+	return os.Getenv(key)
+}
+
+// newBenchmarkTenant seeds the transactions of a tenant of its own in the test database, which
+// is removed with everything it owns once the benchmark is done.
+func newBenchmarkTenant(b *testing.B) (*gorm.DB, context.Context) {
+	// The test database is configured like the server, in the environment or in backend/.env
+	_ = godotenv.Load("../../.env")
+	if os.Getenv("DB_HOST_TEST") == "" {
+		b.Skip("DB_HOST_TEST is not set, the pagination benchmarks need the test database")
+	}
+
+	db, err := config.NewPostgresSQLGormTest(environmentConfig{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := db.Use(NewTenantPlugin()); err != nil {
+		b.Fatal(err)
+	}
+
+	code := fmt.Sprintf("bench-%d", time.Now().UnixNano())
+	var tenantID int64
+	err = db.Raw("INSERT INTO tenants (code, name) VALUES (?, ?) RETURNING id", code, "Benchmark").Scan(&tenantID).Error
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() {
+		db.Exec("DELETE FROM products WHERE tenant_id = ?", tenantID)
+		db.Exec("DELETE FROM tenants WHERE id = ?", tenantID)
+	})
+
+	err = db.Exec("INSERT INTO products (code, name, unit_mass_acronym, unit_mass_description, tenant_id) VALUES (?, ?, 'kg', 'kilogram', ?)", code, "Benchmark", tenantID).Error
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var productQualityID int64
+	err = db.Raw("INSERT INTO product_qualities (product_code, quality, quantity, type, tenant_id) VALUES (?, 'Original', 0, 'increase', ?) RETURNING id", code, tenantID).Scan(&productQualityID).Error
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// Several transactions share a second, as they do in a busy history, so the id breaks ties
+	err = db.Exec(`INSERT INTO transactions (code, product_quality_id, quantity, type, unit_mass_acronym, created_at, updated_at, tenant_id)
+		SELECT ?::text || '-' || n, ?, 1, 'IN', 'kg', ts, ts, ?
+		FROM generate_series(1, ?) AS n, LATERAL (SELECT TIMESTAMP '2021-01-01' + (n / 4) * INTERVAL '1 second' AS ts) AS t`,
+		code, productQualityID, tenantID, benchmarkTransactions).Error
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := db.Exec("ANALYZE transactions").Error; err != nil {
+		b.Fatal(err)
+	}
+
+	return db, util.WithTenant(context.Background(), tenantID)
+}
+
+// BenchmarkTransactionPagination reads a page of the transaction history at growing depths, by
+// page number as the list endpoints do by default, with the count of every request, and by
+// cursor.
+func BenchmarkTransactionPagination(b *testing.B) {
+	db, ctx := newBenchmarkTenant(b)
+	repository := NewTransactionRepository(db)
+	limit := 10
+
+	for _, depth := range []int{0, 10000, 250000, benchmarkTransactions - limit} {
+		b.Run(fmt.Sprintf("offset/depth=%d", depth), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := repository.CountAll(ctx, nil, nil); err != nil {
+					b.Fatal(err)
+				}
+				if _, err := repository.FindAll(ctx, nil, depth, limit, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("cursor/depth=%d", depth), func(b *testing.B) {
+			cursor := ""
+			if depth > 0 {
+				// The page starts after the record at the depth in the order of the cursor
+				var transaction model.Transaction
+				err := db.WithContext(ctx).Order("created_at DESC, id DESC").Offset(depth - 1).Take(&transaction).Error
+				if err != nil {
+					b.Fatal(err)
+				}
+				cursor = benchmarkCursor(b, &transaction)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, _, err := repository.FindAllByCursor(ctx, nil, cursor, limit); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func benchmarkCursor(b *testing.B, transaction *model.Transaction) string {
+	cursor, err := util.EncodeCursor(&util.Cursor{
+		Sort:      "-created_at,-id",
+		Values:    []interface{}{transaction.CreatedAt, transaction.ID},
+		Direction: util.CursorNext,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return cursor
+}
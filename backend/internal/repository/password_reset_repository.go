@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+)
+
+type PasswordResetRepository struct {
+	DB *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepositoryContract {
+	return &PasswordResetRepository{
+		DB: db,
+	}
+}
+
+func (repository *PasswordResetRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*model.PasswordReset, error) {
+	var passwordReset model.PasswordReset
+	err := conn(ctx, repository.DB).Where("token_hash = ?", tokenHash).First(&passwordReset).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &passwordReset, nil
+}
+
+func (repository *PasswordResetRepository) Create(ctx context.Context, passwordReset *model.PasswordReset) (*model.PasswordReset, error) {
+	err := conn(ctx, repository.DB).Create(passwordReset).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return passwordReset, nil
+}
+
+// MarkUsed consumes the password reset, unless it was used already or expired. Concurrent
+// resets wait for each other on the row, so only one of them consumes it.
+func (repository *PasswordResetRepository) MarkUsed(ctx context.Context, id int64) error {
+	result := conn(ctx, repository.DB).Model(&model.PasswordReset{}).
+		Where("id = ? AND used_at IS NULL AND expires_at > now()", id).
+		Update("used_at", gorm.Expr("now()"))
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected != 1 {
+		return errors.New(response.ErrorInvalidToken)
+	}
+
+	return nil
+}
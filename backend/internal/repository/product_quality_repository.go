@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"gorm.io/gorm"
+	"inventory-management/backend/internal/http/response"
 	"inventory-management/backend/internal/model"
 )
 
@@ -17,12 +19,24 @@ func NewProductQualityRepository(db *gorm.DB) ProductQualityRepositoryContract {
 }
 
 func (repository *ProductQualityRepository) FindAll(ctx context.Context, tx *gorm.DB) ([]*model.ProductQuality, error) {
+	db := conn(ctx, repository.DB)
 	if tx != nil {
-		repository.DB = tx
+		db = tx
 	}
 
 	var productQualities []*model.ProductQuality
-	err := repository.DB.WithContext(ctx).Find(&productQualities).Error
+	err := db.WithContext(ctx).Find(&productQualities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return productQualities, nil
+}
+
+// FindAllAfterID walks the product qualities in ID order, the product is preloaded for its name.
+func (repository *ProductQualityRepository) FindAllAfterID(ctx context.Context, afterID int64, limit int) ([]*model.ProductQuality, error) {
+	var productQualities []*model.ProductQuality
+	err := conn(ctx, repository.DB).Preload("Product").Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(&productQualities).Error
 	if err != nil {
 		return nil, err
 	}
@@ -31,12 +45,13 @@ func (repository *ProductQualityRepository) FindAll(ctx context.Context, tx *gor
 }
 
 func (repository *ProductQualityRepository) FindAllByProductCode(ctx context.Context, productCode string, tx *gorm.DB) ([]*model.ProductQuality, error) {
+	db := conn(ctx, repository.DB)
 	if tx != nil {
-		repository.DB = tx
+		db = tx
 	}
 
 	var productQualities []*model.ProductQuality
-	err := repository.DB.WithContext(ctx).Where("product_code = ?", productCode).Find(&productQualities).Error
+	err := db.WithContext(ctx).Where("product_code = ?", productCode).Find(&productQualities).Error
 	if err != nil {
 		return nil, err
 	}
@@ -45,12 +60,13 @@ func (repository *ProductQualityRepository) FindAllByProductCode(ctx context.Con
 }
 
 func (repository *ProductQualityRepository) FindByID(ctx context.Context, id int64, tx *gorm.DB) (*model.ProductQuality, error) {
+	db := conn(ctx, repository.DB)
 	if tx != nil {
-		repository.DB = tx
+		db = tx
 	}
 
 	var productQuality model.ProductQuality
-	err := repository.DB.WithContext(ctx).First(&productQuality, id).Error
+	err := db.WithContext(ctx).First(&productQuality, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -59,12 +75,13 @@ func (repository *ProductQualityRepository) FindByID(ctx context.Context, id int
 }
 
 func (repository *ProductQualityRepository) FindByIDWithAssociations(ctx context.Context, id int64, tx *gorm.DB) (*model.ProductQuality, error) {
+	db := conn(ctx, repository.DB)
 	if tx != nil {
-		repository.DB = tx
+		db = tx
 	}
 
 	var productQuality model.ProductQuality
-	err := repository.DB.WithContext(ctx).Preload("Product").First(&productQuality, id).Error
+	err := db.WithContext(ctx).Preload("Product").First(&productQuality, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -73,12 +90,96 @@ func (repository *ProductQualityRepository) FindByIDWithAssociations(ctx context
 }
 
 func (repository *ProductQualityRepository) Delete(ctx context.Context, id int64, tx *gorm.DB) error {
+	db := conn(ctx, repository.DB)
 	if tx != nil {
-		repository.DB = tx
+		db = tx
 	}
 
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var productQuality model.ProductQuality
+		err := tx.WithContext(ctx).Preload("Product").First(&productQuality, id).Error
+		if err != nil {
+			return err
+		}
+
+		// The quality is only marked as deleted, its transactions keep referencing it
+		err = tx.WithContext(ctx).Delete(&productQuality).Error
+		if err != nil {
+			return err
+		}
+
+		// The product document lists the qualities of the product
+		events := []*model.SearchOutboxEvent{productQuality.ToSearchOutboxEvent(model.OutboxOperationDelete)}
+		if productQuality.Product != nil {
+			events = append(events, productQuality.Product.ToSearchOutboxEvent(model.OutboxOperationIndex))
+		}
+
+		return enqueueSearchOutbox(ctx, tx, events...)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Restore brings the quality back, which needs its product not to be deleted.
+func (repository *ProductQualityRepository) Restore(ctx context.Context, id int64) (*model.ProductQuality, error) {
 	var productQuality model.ProductQuality
-	err := repository.DB.WithContext(ctx).Delete(&productQuality, id).Error
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		err := tx.WithContext(ctx).Unscoped().Preload("Product").Where("deleted_at IS NOT NULL").First(&productQuality, id).Error
+		if err != nil {
+			return err
+		}
+
+		if productQuality.Product == nil || productQuality.Product.DeletedAt.Valid {
+			return errors.New(response.ErrorProductDeleted)
+		}
+
+		err = tx.WithContext(ctx).Unscoped().Model(&productQuality).Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+
+		return enqueueSearchOutbox(ctx, tx,
+			productQuality.ToSearchOutboxEvent(model.OutboxOperationIndex),
+			productQuality.Product.ToSearchOutboxEvent(model.OutboxOperationIndex),
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &productQuality, nil
+}
+
+// Purge deletes the quality for good, whether it was soft deleted or not, unless transactions
+// reference it.
+func (repository *ProductQualityRepository) Purge(ctx context.Context, id int64) error {
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		var productQuality model.ProductQuality
+		err := tx.WithContext(ctx).Unscoped().Preload("Product").First(&productQuality, id).Error
+		if err != nil {
+			return err
+		}
+
+		err = referencedByTransactions(ctx, tx, "product_quality_id = ? OR product_quality_id_transferred = ?", productQuality.ID, productQuality.ID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.WithContext(ctx).Unscoped().Delete(&productQuality).Error
+		if err != nil {
+			return err
+		}
+
+		events := []*model.SearchOutboxEvent{productQuality.ToSearchOutboxEvent(model.OutboxOperationDelete)}
+		if productQuality.Product != nil {
+			events = append(events, productQuality.Product.ToSearchOutboxEvent(model.OutboxOperationIndex))
+		}
+
+		return enqueueSearchOutbox(ctx, tx, events...)
+	})
 	if err != nil {
 		return err
 	}
@@ -87,18 +188,24 @@ func (repository *ProductQualityRepository) Delete(ctx context.Context, id int64
 }
 
 func (repository *ProductQualityRepository) IncreaseStock(ctx context.Context, id int64, quantity float64, tx *gorm.DB) error {
+	db := conn(ctx, repository.DB)
 	if tx != nil {
-		repository.DB = tx
+		db = tx
 	}
 
 	var productQuality model.ProductQuality
-	err := repository.DB.WithContext(ctx).First(&productQuality, id).Error
+	err := db.WithContext(ctx).First(&productQuality, id).Error
 	if err != nil {
 		return err
 	}
 
 	productQuality.Quantity += quantity
-	err = repository.DB.WithContext(ctx).Select("quantity").Updates(&productQuality).Error
+	err = updateStock(ctx, db, &productQuality)
+	if err != nil {
+		return err
+	}
+
+	err = enqueueSearchOutbox(ctx, db, productQuality.ToSearchOutboxEvent(model.OutboxOperationIndex))
 	if err != nil {
 		return err
 	}
@@ -107,21 +214,44 @@ func (repository *ProductQualityRepository) IncreaseStock(ctx context.Context, i
 }
 
 func (repository *ProductQualityRepository) DecreaseStock(ctx context.Context, id int64, quantity float64, tx *gorm.DB) error {
+	db := conn(ctx, repository.DB)
 	if tx != nil {
-		repository.DB = tx
+		db = tx
 	}
 
 	var productQuality model.ProductQuality
-	err := repository.DB.WithContext(ctx).First(&productQuality, id).Error
+	err := db.WithContext(ctx).First(&productQuality, id).Error
 	if err != nil {
 		return err
 	}
 
 	productQuality.Quantity -= quantity
-	err = tx.WithContext(ctx).Select("quantity").Updates(&productQuality).Error
+	err = updateStock(ctx, db, &productQuality)
+	if err != nil {
+		return err
+	}
+
+	err = enqueueSearchOutbox(ctx, db, productQuality.ToSearchOutboxEvent(model.OutboxOperationIndex))
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+func (repository *ProductQualityRepository) FindByGtinWithAssociations(ctx context.Context, gtin string) (*model.ProductQuality, error) {
+	var productQuality model.ProductQuality
+	err := conn(ctx, repository.DB).Preload("Product").Where("gtin = ?", gtin).First(&productQuality).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &productQuality, nil
+}
+
+// updateStock saves the quantity of the quality, moving it to its next version. The version of
+// the product only follows the edits of users, so stock moves do not fail their If-Match.
+func updateStock(ctx context.Context, db *gorm.DB, productQuality *model.ProductQuality) error {
+	productQuality.Version++
+	return db.WithContext(ctx).Select("quantity", "version").Updates(productQuality).Error
+}
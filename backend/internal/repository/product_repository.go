@@ -4,6 +4,8 @@ import (
 	"context"
 	"gorm.io/gorm"
 	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
+	"time"
 )
 
 type ProductRepository struct {
@@ -16,9 +18,9 @@ func NewProductRepository(db *gorm.DB) ProductRepositoryContract {
 	}
 }
 
-func (repository *ProductRepository) FindAll(ctx context.Context, offset int, limit int) ([]*model.Product, error) {
+func (repository *ProductRepository) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*model.Product, error) {
 	var products []*model.Product
-	err := repository.DB.WithContext(ctx).Offset(offset).Limit(limit).Order("created_at DESC").Find(&products).Error
+	err := listQuery(conn(ctx, repository.DB), query, model.ProductListSchema).Offset(offset).Limit(limit).Find(&products).Error
 	if err != nil {
 		return nil, err
 	}
@@ -26,9 +28,24 @@ func (repository *ProductRepository) FindAll(ctx context.Context, offset int, li
 	return products, nil
 }
 
-func (repository *ProductRepository) CountAll(ctx context.Context) (int64, error) {
+func (repository *ProductRepository) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*model.Product, *util.CursorPage, error) {
+	return listCursor[model.Product](conn(ctx, repository.DB), query, model.ProductListSchema, cursor, limit)
+}
+
+// FindAllAfterID walks the products in ID order together with their qualities, one batch at a time.
+func (repository *ProductRepository) FindAllAfterID(ctx context.Context, afterID int64, limit int) ([]*model.Product, error) {
+	var products []*model.Product
+	err := conn(ctx, repository.DB).Preload("ProductQualities").Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (repository *ProductRepository) CountAll(ctx context.Context, query *util.ListQuery) (int64, error) {
 	var count int64
-	err := repository.DB.WithContext(ctx).Model(&model.Product{}).Count(&count).Error
+	err := listFilter(conn(ctx, repository.DB).Model(&model.Product{}), query).Count(&count).Error
 	if err != nil {
 		return 0, err
 	}
@@ -38,7 +55,17 @@ func (repository *ProductRepository) CountAll(ctx context.Context) (int64, error
 
 func (repository *ProductRepository) FindByCodeWithAssociations(ctx context.Context, code string) (*model.Product, error) {
 	var product model.Product
-	err := repository.DB.WithContext(ctx).Preload("ProductQualities").Where("code = ?", code).First(&product).Error
+	err := conn(ctx, repository.DB).Preload("ProductQualities").Where("code = ?", code).First(&product).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+func (repository *ProductRepository) FindByGtin(ctx context.Context, gtin string) (*model.Product, error) {
+	var product model.Product
+	err := conn(ctx, repository.DB).Where("gtin = ?", gtin).First(&product).Error
 	if err != nil {
 		return nil, err
 	}
@@ -47,13 +74,14 @@ func (repository *ProductRepository) FindByCodeWithAssociations(ctx context.Cont
 }
 
 func (repository *ProductRepository) Create(ctx context.Context, product *model.Product) (*model.Product, error) {
-	err := repository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
 		err := tx.WithContext(ctx).Create(&product).Error
 		if err != nil {
 			return err
 		}
 
-		return nil
+		// Indexing the product indexes its qualities as well
+		return enqueueSearchOutbox(ctx, tx, product.ToSearchOutboxEvent(model.OutboxOperationIndex))
 	})
 	if err != nil {
 		return nil, err
@@ -62,22 +90,60 @@ func (repository *ProductRepository) Create(ctx context.Context, product *model.
 	return product, nil
 }
 
+// CreateAll creates the products in batches within one database transaction, so either all of
+// them are created or none is.
+func (repository *ProductRepository) CreateAll(ctx context.Context, products []*model.Product) ([]*model.Product, error) {
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		err := tx.WithContext(ctx).CreateInBatches(products, createBatchSize).Error
+		if err != nil {
+			return err
+		}
+
+		events := make([]*model.SearchOutboxEvent, len(products))
+		for i, product := range products {
+			events[i] = product.ToSearchOutboxEvent(model.OutboxOperationIndex)
+		}
+
+		return enqueueSearchOutbox(ctx, tx, events...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
 func (repository *ProductRepository) Update(ctx context.Context, product *model.Product) (*model.Product, error) {
-	err := repository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.WithContext(ctx).Where("code = ?", product.Code).Updates(&product).Error
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		version := product.Version
+		product.Version++
+		err := versionUpdated(tx.WithContext(ctx).Where("code = ? AND version = ?", product.Code, version).Updates(&product))
 		if err != nil {
 			return err
 		}
 
+		// The qualities kept are saved at the version they were read at, so a stock moved since
+		// is not overwritten, and only when they belong to the product
 		for _, pq := range product.ProductQualities {
 			pq.ProductCode = product.Code
-			err = tx.WithContext(ctx).Save(pq).Error
+			if pq.ID == 0 {
+				err = tx.WithContext(ctx).Create(pq).Error
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			version := pq.Version
+			pq.Version++
+			err = versionUpdated(tx.WithContext(ctx).Select("quality", "price", "quantity", "type", "gtin", "version").
+				Where("product_code = ? AND version = ?", product.Code, version).Updates(pq))
 			if err != nil {
 				return err
 			}
 		}
 
-		return nil
+		return enqueueSearchOutbox(ctx, tx, product.ToSearchOutboxEvent(model.OutboxOperationIndex))
 	})
 	if err != nil {
 		return nil, err
@@ -87,8 +153,108 @@ func (repository *ProductRepository) Update(ctx context.Context, product *model.
 }
 
 func (repository *ProductRepository) Delete(ctx context.Context, code string) error {
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		var product model.Product
+		err := tx.WithContext(ctx).Preload("ProductQualities").Where("code = ?", code).First(&product).Error
+		if err != nil {
+			return err
+		}
+
+		// The qualities are marked as deleted at the same time as the product, which tells the
+		// ones restored along with it from the ones deleted before
+		deletedAt := time.Now()
+		err = tx.WithContext(ctx).Model(&model.ProductQuality{}).Where("product_code = ?", product.Code).Update("deleted_at", deletedAt).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.WithContext(ctx).Model(&product).Update("deleted_at", deletedAt).Error
+		if err != nil {
+			return err
+		}
+
+		events := []*model.SearchOutboxEvent{product.ToSearchOutboxEvent(model.OutboxOperationDelete)}
+		for _, productQuality := range product.ProductQualities {
+			events = append(events, productQuality.ToSearchOutboxEvent(model.OutboxOperationDelete))
+		}
+
+		return enqueueSearchOutbox(ctx, tx, events...)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *ProductRepository) Restore(ctx context.Context, code string) (*model.Product, error) {
 	var product model.Product
-	err := repository.DB.WithContext(ctx).Where("code = ?", code).Delete(&product).Error
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		var deleted model.Product
+		err := tx.WithContext(ctx).Unscoped().Where("code = ? AND deleted_at IS NOT NULL", code).First(&deleted).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.WithContext(ctx).Unscoped().Model(&model.ProductQuality{}).Where("product_code = ? AND deleted_at = ?", deleted.Code, deleted.DeletedAt).Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.WithContext(ctx).Unscoped().Model(&deleted).Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.WithContext(ctx).Preload("ProductQualities").Where("code = ?", code).First(&product).Error
+		if err != nil {
+			return err
+		}
+
+		events := []*model.SearchOutboxEvent{product.ToSearchOutboxEvent(model.OutboxOperationIndex)}
+		for _, productQuality := range product.ProductQualities {
+			events = append(events, productQuality.ToSearchOutboxEvent(model.OutboxOperationIndex))
+		}
+
+		return enqueueSearchOutbox(ctx, tx, events...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+// Purge deletes the product and all of its qualities for good, whether they were soft deleted or
+// not, unless transactions reference any of the qualities.
+func (repository *ProductRepository) Purge(ctx context.Context, code string) error {
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		var product model.Product
+		err := tx.WithContext(ctx).Unscoped().Preload("ProductQualities").Where("code = ?", code).First(&product).Error
+		if err != nil {
+			return err
+		}
+
+		events := []*model.SearchOutboxEvent{product.ToSearchOutboxEvent(model.OutboxOperationDelete)}
+		productQualityIDs := []int64{}
+		for _, productQuality := range product.ProductQualities {
+			events = append(events, productQuality.ToSearchOutboxEvent(model.OutboxOperationDelete))
+			productQualityIDs = append(productQualityIDs, productQuality.ID)
+		}
+
+		err = referencedByTransactions(ctx, tx, "product_quality_id IN ? OR product_quality_id_transferred IN ?", productQualityIDs, productQualityIDs)
+		if err != nil {
+			return err
+		}
+
+		// The database deletes the qualities of the product along with it
+		err = tx.WithContext(ctx).Unscoped().Delete(&product).Error
+		if err != nil {
+			return err
+		}
+
+		return enqueueSearchOutbox(ctx, tx, events...)
+	})
 	if err != nil {
 		return err
 	}
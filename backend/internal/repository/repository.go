@@ -5,60 +5,124 @@ import (
 	"gorm.io/gorm"
 	"inventory-management/backend/internal/http/request"
 	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
+	"time"
 )
 
+// createBatchSize bounds the rows of an INSERT when many records are created at once.
+const createBatchSize = 500
+
 type (
-	UserRepositoryContract interface {
-		FindAll(ctx context.Context, offset int, limit int) ([]*model.User, error)
+	TransactorContract interface {
+		Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	}
+	TenantRepositoryContract interface {
+		FindAll(ctx context.Context, offset int, limit int) ([]*model.Tenant, error)
 		CountAll(ctx context.Context) (int64, error)
+		FindByID(ctx context.Context, id int64) (*model.Tenant, error)
+		FindByCode(ctx context.Context, code string) (*model.Tenant, error)
+		FindByApiKeyHash(ctx context.Context, apiKeyHash string) (*model.Tenant, error)
+		Create(ctx context.Context, tenant *model.Tenant) (*model.Tenant, error)
+		Update(ctx context.Context, tenant *model.Tenant) (*model.Tenant, error)
+	}
+	UserRepositoryContract interface {
+		FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*model.User, error)
+		FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*model.User, *util.CursorPage, error)
+		FindAllAfterID(ctx context.Context, afterID int64, limit int) ([]*model.User, error)
+		CountAll(ctx context.Context, query *util.ListQuery) (int64, error)
 		FindByID(ctx context.Context, id int64) (*model.User, error)
 		FindByUsername(ctx context.Context, username string) (*model.User, error)
+		FindByEmail(ctx context.Context, email string) (*model.User, error)
+		FindByOidcSubject(ctx context.Context, issuer string, subject string) (*model.User, error)
 		Create(ctx context.Context, user *model.User) (*model.User, error)
 		Update(ctx context.Context, user *model.User) (*model.User, error)
 		Delete(ctx context.Context, id int64) error
+		Restore(ctx context.Context, id int64) (*model.User, error)
+		Purge(ctx context.Context, id int64) error
+	}
+	InvitationRepositoryContract interface {
+		FindByTokenHash(ctx context.Context, tokenHash string) (*model.Invitation, error)
+		Create(ctx context.Context, invitation *model.Invitation) (*model.Invitation, error)
+		MarkAccepted(ctx context.Context, id int64) error
+	}
+	PasswordResetRepositoryContract interface {
+		FindByTokenHash(ctx context.Context, tokenHash string) (*model.PasswordReset, error)
+		Create(ctx context.Context, passwordReset *model.PasswordReset) (*model.PasswordReset, error)
+		MarkUsed(ctx context.Context, id int64) error
+	}
+	OidcLoginStateRepositoryContract interface {
+		Create(ctx context.Context, loginState *model.OidcLoginState) (*model.OidcLoginState, error)
+		Consume(ctx context.Context, stateHash string) (*model.OidcLoginState, error)
+		DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+	}
+	AuditLogRepositoryContract interface {
+		FindAll(ctx context.Context, filter *request.AuditLogFilterRequest, offset int, limit int) ([]*model.AuditLog, error)
+		CountAll(ctx context.Context, filter *request.AuditLogFilterRequest) (int64, error)
+		Create(ctx context.Context, auditLog *model.AuditLog) (*model.AuditLog, error)
 	}
 	ProductRepositoryContract interface {
-		FindAll(ctx context.Context, offset int, limit int) ([]*model.Product, error)
-		CountAll(ctx context.Context) (int64, error)
+		FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*model.Product, error)
+		FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*model.Product, *util.CursorPage, error)
+		FindAllAfterID(ctx context.Context, afterID int64, limit int) ([]*model.Product, error)
+		CountAll(ctx context.Context, query *util.ListQuery) (int64, error)
 		FindByCodeWithAssociations(ctx context.Context, code string) (*model.Product, error)
+		FindByGtin(ctx context.Context, gtin string) (*model.Product, error)
 		Create(ctx context.Context, product *model.Product) (*model.Product, error)
+		CreateAll(ctx context.Context, products []*model.Product) ([]*model.Product, error)
 		Update(ctx context.Context, product *model.Product) (*model.Product, error)
 		Delete(ctx context.Context, code string) error
+		Restore(ctx context.Context, code string) (*model.Product, error)
+		Purge(ctx context.Context, code string) error
 	}
 	ProductQualityRepositoryContract interface {
 		FindAll(ctx context.Context, tx *gorm.DB) ([]*model.ProductQuality, error)
+		FindAllAfterID(ctx context.Context, afterID int64, limit int) ([]*model.ProductQuality, error)
 		FindAllByProductCode(ctx context.Context, productCode string, tx *gorm.DB) ([]*model.ProductQuality, error)
 		FindByID(ctx context.Context, id int64, tx *gorm.DB) (*model.ProductQuality, error)
 		FindByIDWithAssociations(ctx context.Context, id int64, tx *gorm.DB) (*model.ProductQuality, error)
+		FindByGtinWithAssociations(ctx context.Context, gtin string) (*model.ProductQuality, error)
 		Delete(ctx context.Context, id int64, tx *gorm.DB) error
+		Restore(ctx context.Context, id int64) (*model.ProductQuality, error)
+		Purge(ctx context.Context, id int64) error
 		IncreaseStock(ctx context.Context, id int64, quantity float64, tx *gorm.DB) error
 		DecreaseStock(ctx context.Context, id int64, quantity float64, tx *gorm.DB) error
 	}
 	SupplierRepositoryContract interface {
-		FindAll(ctx context.Context, offset int, limit int) ([]*model.Supplier, error)
-		CountAll(ctx context.Context) (int64, error)
+		FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*model.Supplier, error)
+		FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*model.Supplier, *util.CursorPage, error)
+		FindAllAfterID(ctx context.Context, afterID int64, limit int) ([]*model.Supplier, error)
+		CountAll(ctx context.Context, query *util.ListQuery) (int64, error)
 		FindByCodeWithAssociations(ctx context.Context, code string) (*model.Supplier, error)
 		FindByCode(ctx context.Context, code string) (*model.Supplier, error)
 		Create(ctx context.Context, supplier *model.Supplier) (*model.Supplier, error)
+		CreateAll(ctx context.Context, suppliers []*model.Supplier) ([]*model.Supplier, error)
 		Update(ctx context.Context, supplier *model.Supplier) (*model.Supplier, error)
 		Delete(ctx context.Context, code string) error
+		Restore(ctx context.Context, code string) (*model.Supplier, error)
+		Purge(ctx context.Context, code string) error
 	}
 
 	CustomerRepositoryContract interface {
-		FindAll(ctx context.Context, offset int, limit int) ([]*model.Customer, error)
-		CountAll(ctx context.Context) (int64, error)
+		FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*model.Customer, error)
+		FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*model.Customer, *util.CursorPage, error)
+		FindAllAfterID(ctx context.Context, afterID int64, limit int) ([]*model.Customer, error)
+		CountAll(ctx context.Context, query *util.ListQuery) (int64, error)
 		FindByCodeWithAssociations(ctx context.Context, code string) (*model.Customer, error)
 		FindByCode(ctx context.Context, code string) (*model.Customer, error)
 		Create(ctx context.Context, customer *model.Customer) (*model.Customer, error)
+		CreateAll(ctx context.Context, customers []*model.Customer) ([]*model.Customer, error)
 		Update(ctx context.Context, customer *model.Customer) (*model.Customer, error)
 		Delete(ctx context.Context, code string) error
+		Restore(ctx context.Context, code string) (*model.Customer, error)
+		Purge(ctx context.Context, code string) error
 	}
 
 	TransactionRepositoryContract interface {
-		FindAll(ctx context.Context, offset int, limit int, tx *gorm.DB) ([]*model.Transaction, error)
-		CountAll(ctx context.Context, tx *gorm.DB) (int64, error)
-		FindAllBySupplierCode(ctx context.Context, supplierCode string, tx *gorm.DB) ([]*model.Transaction, error)
-		FindAllByCustomerCode(ctx context.Context, customerCode string, tx *gorm.DB) ([]*model.Transaction, error)
+		FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int, tx *gorm.DB) ([]*model.Transaction, error)
+		FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*model.Transaction, *util.CursorPage, error)
+		FindAllAfterID(ctx context.Context, afterID int64, limit int) ([]*model.Transaction, error)
+		CountAll(ctx context.Context, query *util.ListQuery, tx *gorm.DB) (int64, error)
+		TotalByType(ctx context.Context, query *util.ListQuery) ([]*model.TransactionTotal, error)
 		FindByCodeWithAssociations(ctx context.Context, code string, tx *gorm.DB) (*model.Transaction, error)
 		FindByCode(ctx context.Context, code string, tx *gorm.DB) (*model.Transaction, error)
 		Create(ctx context.Context, transaction *model.Transaction, tx *gorm.DB) (*model.Transaction, error)
@@ -66,10 +130,69 @@ type (
 		Delete(ctx context.Context, code string, tx *gorm.DB) error
 	}
 
+	LedgerRepositoryContract interface {
+		Append(ctx context.Context, transaction *model.Transaction, action string, tx *gorm.DB) (*model.LedgerEntry, error)
+		FindAll(ctx context.Context, afterSequence int64, limit int) ([]*model.LedgerEntry, error)
+		FindLast(ctx context.Context) (*model.LedgerEntry, error)
+		FindLatestByTransactionCode(ctx context.Context, transactionCode string) (*model.LedgerEntry, error)
+		FindUnrecordedTransactions(ctx context.Context, limit int) ([]*model.Transaction, error)
+		FindLegacyRecordedTransactions(ctx context.Context, limit int) ([]*model.Transaction, error)
+		CreateCheckpoint(ctx context.Context, checkpoint *model.LedgerCheckpoint) (*model.LedgerCheckpoint, error)
+		FindAllCheckpoints(ctx context.Context) ([]*model.LedgerCheckpoint, error)
+	}
+
+	SettingRepositoryContract interface {
+		FindBranding(ctx context.Context) (*model.BrandingSetting, error)
+		SaveBranding(ctx context.Context, branding *model.BrandingSetting) (*model.BrandingSetting, error)
+		FindAllLabelTemplates(ctx context.Context) ([]*model.LabelTemplate, error)
+		FindLabelTemplate(ctx context.Context, labelType string) (*model.LabelTemplate, error)
+		SaveLabelTemplate(ctx context.Context, labelTemplate *model.LabelTemplate) (*model.LabelTemplate, error)
+		FindAllNumberingSequences(ctx context.Context) ([]*model.NumberingSequence, error)
+		FindNumberingSequence(ctx context.Context, entity string, transactionType string) (*model.NumberingSequence, error)
+		SaveNumberingSequence(ctx context.Context, sequence *model.NumberingSequence, restart bool) (*model.NumberingSequence, error)
+		CodeTaken(ctx context.Context, entity string, code string) (bool, error)
+	}
+
+	IdempotencyKeyRepositoryContract interface {
+		Claim(ctx context.Context, idempotencyKey *model.IdempotencyKey, lease time.Duration) (bool, error)
+		FindByKey(ctx context.Context, username string, key string) (*model.IdempotencyKey, error)
+		SaveResponse(ctx context.Context, idempotencyKey *model.IdempotencyKey) error
+		Release(ctx context.Context, idempotencyKey *model.IdempotencyKey) error
+		DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+	}
+
+	ExportJobRepositoryContract interface {
+		FindAllByRequester(ctx context.Context, requestedBy string, offset int, limit int) ([]*model.ExportJob, error)
+		CountAllByRequester(ctx context.Context, requestedBy string) (int64, error)
+		FindByCode(ctx context.Context, code string) (*model.ExportJob, error)
+		Create(ctx context.Context, job *model.ExportJob) (*model.ExportJob, error)
+		Claim(ctx context.Context, lease time.Duration) (*model.ExportJob, error)
+		Update(ctx context.Context, job *model.ExportJob) error
+		FindAllCompletedBefore(ctx context.Context, before time.Time, limit int) ([]*model.ExportJob, error)
+		Delete(ctx context.Context, ids []int64) error
+	}
+
+	SearchOutboxRepositoryContract interface {
+		Claim(ctx context.Context, limit int, lease time.Duration) ([]*model.SearchOutboxEvent, error)
+		Delete(ctx context.Context, ids []int64) error
+		MarkFailed(ctx context.Context, ids []int64, lastError string, availableAt time.Time) error
+		Stats(ctx context.Context) (*model.SearchOutboxStats, error)
+	}
+
+	SearchSynonymRepositoryContract interface {
+		FindAll(ctx context.Context) ([]*model.SearchSynonym, error)
+		FindByID(ctx context.Context, id int64) (*model.SearchSynonym, error)
+		Create(ctx context.Context, synonym *model.SearchSynonym) (*model.SearchSynonym, error)
+		Update(ctx context.Context, synonym *model.SearchSynonym) (*model.SearchSynonym, error)
+		Delete(ctx context.Context, id int64) error
+	}
+
 	TxTransactionRepositoryContract interface {
 		Create(ctx context.Context, request *request.CreateTransactionRequest) (*model.Transaction, error)
+		CreateAll(ctx context.Context, requests []*request.CreateTransactionRequest) ([]*model.Transaction, error)
 		Update(ctx context.Context, request *request.UpdateTransactionRequest) (*model.Transaction, error)
 		TransferStock(ctx context.Context, request *request.TransferStockTransactionRequest) (*model.Transaction, error)
+		CreateBatch(ctx context.Context, request *request.CreateTransactionBatchRequest) ([]*model.Transaction, error)
 		Delete(ctx context.Context, code string) error
 	}
 )
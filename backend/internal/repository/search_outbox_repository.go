@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"inventory-management/backend/internal/model"
+	"sort"
+	"time"
+)
+
+type SearchOutboxRepository struct {
+	DB *gorm.DB
+}
+
+func NewSearchOutboxRepository(db *gorm.DB) SearchOutboxRepositoryContract {
+	return &SearchOutboxRepository{
+		DB: db,
+	}
+}
+
+// Claim leases the oldest available events, so they are not handed to another relay until the
+// lease runs out. Events the relay fails to apply become available again after their lease.
+func (repository *SearchOutboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*model.SearchOutboxEvent, error) {
+	now := time.Now()
+
+	var events []*model.SearchOutboxEvent
+	err := conn(ctx, repository.DB).Raw(`UPDATE search_outbox SET available_at = ? WHERE id IN (
+		SELECT id FROM search_outbox WHERE available_at <= ? ORDER BY id LIMIT ? FOR UPDATE SKIP LOCKED
+	) RETURNING *`, now.Add(lease), now, limit).Scan(&events).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].ID < events[j].ID
+	})
+
+	return events, nil
+}
+
+func (repository *SearchOutboxRepository) Delete(ctx context.Context, ids []int64) error {
+	err := conn(ctx, repository.DB).Delete(&model.SearchOutboxEvent{}, ids).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *SearchOutboxRepository) MarkFailed(ctx context.Context, ids []int64, lastError string, availableAt time.Time) error {
+	err := conn(ctx, repository.DB).Model(&model.SearchOutboxEvent{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+		"attempts":     gorm.Expr("attempts + 1"),
+		"last_error":   lastError,
+		"available_at": availableAt,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *SearchOutboxRepository) Stats(ctx context.Context) (*model.SearchOutboxStats, error) {
+	var stats model.SearchOutboxStats
+	err := conn(ctx, repository.DB).Model(&model.SearchOutboxEvent{}).
+		Select("COUNT(*) AS pending, COUNT(*) FILTER (WHERE attempts > 0) AS failing, MIN(created_at) AS oldest_created_at").
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// enqueueSearchOutbox writes the events with the given database handle, repositories pass the
+// transaction of the change the events describe.
+func enqueueSearchOutbox(ctx context.Context, tx *gorm.DB, events ...*model.SearchOutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	return tx.WithContext(ctx).CreateInBatches(events, createBatchSize).Error
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"inventory-management/backend/internal/model"
+)
+
+type SearchSynonymRepository struct {
+	DB *gorm.DB
+}
+
+func NewSearchSynonymRepository(db *gorm.DB) SearchSynonymRepositoryContract {
+	return &SearchSynonymRepository{
+		DB: db,
+	}
+}
+
+// FindAll returns every synonym group of the tenant, a tenant keeps few enough to expand each query
+// with all of them.
+func (repository *SearchSynonymRepository) FindAll(ctx context.Context) ([]*model.SearchSynonym, error) {
+	var synonyms []*model.SearchSynonym
+	err := conn(ctx, repository.DB).Order("id ASC").Find(&synonyms).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return synonyms, nil
+}
+
+func (repository *SearchSynonymRepository) FindByID(ctx context.Context, id int64) (*model.SearchSynonym, error) {
+	var synonym model.SearchSynonym
+	err := conn(ctx, repository.DB).Where("id = ?", id).First(&synonym).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &synonym, nil
+}
+
+func (repository *SearchSynonymRepository) Create(ctx context.Context, synonym *model.SearchSynonym) (*model.SearchSynonym, error) {
+	err := conn(ctx, repository.DB).Create(synonym).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return synonym, nil
+}
+
+func (repository *SearchSynonymRepository) Update(ctx context.Context, synonym *model.SearchSynonym) (*model.SearchSynonym, error) {
+	err := conn(ctx, repository.DB).Select("words", "updated_at").Updates(synonym).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return synonym, nil
+}
+
+func (repository *SearchSynonymRepository) Delete(ctx context.Context, id int64) error {
+	err := conn(ctx, repository.DB).Where("id = ?", id).Delete(&model.SearchSynonym{}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
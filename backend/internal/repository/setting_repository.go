@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
+)
+
+type SettingRepository struct {
+	DB *gorm.DB
+}
+
+func NewSettingRepository(db *gorm.DB) SettingRepositoryContract {
+	return &SettingRepository{
+		DB: db,
+	}
+}
+
+func (repository *SettingRepository) FindBranding(ctx context.Context) (*model.BrandingSetting, error) {
+	var branding model.BrandingSetting
+	err := conn(ctx, repository.DB).First(&branding).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &branding, nil
+}
+
+// SaveBranding creates the branding of the tenant, or replaces the one it has.
+func (repository *SettingRepository) SaveBranding(ctx context.Context, branding *model.BrandingSetting) (*model.BrandingSetting, error) {
+	err := conn(ctx, repository.DB).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"company_name", "address", "phone", "email", "footer", "logo", "logo_type", "updated_at"}),
+	}).Create(branding).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return branding, nil
+}
+
+func (repository *SettingRepository) FindAllLabelTemplates(ctx context.Context) ([]*model.LabelTemplate, error) {
+	var labelTemplates []*model.LabelTemplate
+	err := conn(ctx, repository.DB).Order("label_type ASC").Find(&labelTemplates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return labelTemplates, nil
+}
+
+func (repository *SettingRepository) FindLabelTemplate(ctx context.Context, labelType string) (*model.LabelTemplate, error) {
+	var labelTemplate model.LabelTemplate
+	err := conn(ctx, repository.DB).Where("label_type = ?", labelType).First(&labelTemplate).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &labelTemplate, nil
+}
+
+// SaveLabelTemplate creates the template of a type of label, or replaces the one the tenant has.
+func (repository *SettingRepository) SaveLabelTemplate(ctx context.Context, labelTemplate *model.LabelTemplate) (*model.LabelTemplate, error) {
+	err := conn(ctx, repository.DB).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "label_type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"symbology", "zpl", "updated_at"}),
+	}).Create(labelTemplate).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return labelTemplate, nil
+}
+
+func (repository *SettingRepository) FindAllNumberingSequences(ctx context.Context) ([]*model.NumberingSequence, error) {
+	var sequences []*model.NumberingSequence
+	err := conn(ctx, repository.DB).Order("entity ASC, transaction_type ASC").Find(&sequences).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return sequences, nil
+}
+
+func (repository *SettingRepository) FindNumberingSequence(ctx context.Context, entity string, transactionType string) (*model.NumberingSequence, error) {
+	var sequence model.NumberingSequence
+	err := conn(ctx, repository.DB).Where("entity = ? AND transaction_type = ?", entity, transactionType).First(&sequence).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &sequence, nil
+}
+
+// SaveNumberingSequence creates the sequence of an entity, or replaces how the one the tenant has
+// formats codes. The count is left to the records being created unless restart is set, the
+// sequence then going on from its NextValue in its Period.
+func (repository *SettingRepository) SaveNumberingSequence(ctx context.Context, sequence *model.NumberingSequence, restart bool) (*model.NumberingSequence, error) {
+	columns := []string{"strategy", "pattern", "padding", "reset", "updated_at"}
+	if restart {
+		columns = append(columns, "next_value", "period")
+	}
+
+	err := conn(ctx, repository.DB).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "entity"}, {Name: "transaction_type"}},
+		DoUpdates: clause.AssignmentColumns(columns),
+	}, clause.Returning{}).Create(sequence).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return sequence, nil
+}
+
+// CodeTaken reports whether a record of the entity of the context tenant has the code.
+func (repository *SettingRepository) CodeTaken(ctx context.Context, entity string, code string) (bool, error) {
+	tenantID, ok := util.TenantFromContext(ctx)
+	if !ok {
+		return false, errors.New(response.ErrorTenantMissing)
+	}
+
+	return model.CodeTaken(conn(ctx, repository.DB), entity, tenantID, code)
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"inventory-management/backend/util"
+)
+
+// SoftDeletePlugin lets the reads of a context marked with util.WithDeleted see soft deleted
+// records. Updates and deletes are left alone, so such a context never changes deleted records
+// by accident.
+type SoftDeletePlugin struct{}
+
+func NewSoftDeletePlugin() gorm.Plugin {
+	return &SoftDeletePlugin{}
+}
+
+func (plugin *SoftDeletePlugin) Name() string {
+	return "soft_delete"
+}
+
+func (plugin *SoftDeletePlugin) Initialize(db *gorm.DB) error {
+	err := db.Callback().Query().Before("gorm:query").Register("soft_delete:query", includeDeleted)
+	if err != nil {
+		return err
+	}
+
+	return db.Callback().Row().Before("gorm:row").Register("soft_delete:row", includeDeleted)
+}
+
+func includeDeleted(db *gorm.DB) {
+	if db.Statement.Context != nil && util.DeletedFromContext(db.Statement.Context) {
+		db.Statement.Unscoped = true
+	}
+}
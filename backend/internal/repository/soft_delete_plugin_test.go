@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
+	"testing"
+)
+
+func TestSoftDeletePlugin(t *testing.T) {
+	t.Run("Deleted records are hidden from reads", func(t *testing.T) {
+		db := newDryRunDB(t)
+
+		var users []*model.User
+		statement := db.WithContext(util.WithoutTenant(context.Background())).Where("username = ?", "widdy").Find(&users).Statement
+		assert.Equal(t, `SELECT * FROM "users" WHERE username = $1 AND "users"."deleted_at" IS NULL`, statement.SQL.String())
+	})
+
+	t.Run("Reads of a context with deleted records see them", func(t *testing.T) {
+		db := newDryRunDB(t)
+
+		var users []*model.User
+		statement := db.WithContext(util.WithDeleted(util.WithoutTenant(context.Background()))).Where("username = ?", "widdy").Find(&users).Statement
+		assert.Equal(t, `SELECT * FROM "users" WHERE username = $1`, statement.SQL.String())
+	})
+
+	t.Run("Deletes of a context with deleted records stay soft", func(t *testing.T) {
+		db := newDryRunDB(t)
+
+		statement := db.WithContext(util.WithDeleted(util.WithoutTenant(context.Background()))).Where("code = ?", "WDWDARFSYH").Delete(&model.Customer{}).Statement
+		assert.Contains(t, statement.SQL.String(), `UPDATE "customers" SET "deleted_at"=$1`)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"gorm.io/gorm"
 	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
 )
 
 type SupplierRepository struct {
@@ -16,9 +17,9 @@ func NewSupplierRepository(db *gorm.DB) SupplierRepositoryContract {
 	}
 }
 
-func (repository *SupplierRepository) FindAll(ctx context.Context, offset int, limit int) ([]*model.Supplier, error) {
+func (repository *SupplierRepository) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*model.Supplier, error) {
 	var suppliers []*model.Supplier
-	err := repository.DB.WithContext(ctx).Offset(offset).Limit(limit).Order("created_at DESC").Find(&suppliers).Error
+	err := listQuery(conn(ctx, repository.DB), query, model.SupplierListSchema).Offset(offset).Limit(limit).Find(&suppliers).Error
 	if err != nil {
 		return nil, err
 	}
@@ -26,9 +27,23 @@ func (repository *SupplierRepository) FindAll(ctx context.Context, offset int, l
 	return suppliers, nil
 }
 
-func (repository *SupplierRepository) CountAll(ctx context.Context) (int64, error) {
+func (repository *SupplierRepository) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*model.Supplier, *util.CursorPage, error) {
+	return listCursor[model.Supplier](conn(ctx, repository.DB), query, model.SupplierListSchema, cursor, limit)
+}
+
+func (repository *SupplierRepository) FindAllAfterID(ctx context.Context, afterID int64, limit int) ([]*model.Supplier, error) {
+	var suppliers []*model.Supplier
+	err := conn(ctx, repository.DB).Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(&suppliers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return suppliers, nil
+}
+
+func (repository *SupplierRepository) CountAll(ctx context.Context, query *util.ListQuery) (int64, error) {
 	var count int64
-	err := repository.DB.WithContext(ctx).Model(&model.Supplier{}).Count(&count).Error
+	err := listFilter(conn(ctx, repository.DB).Model(&model.Supplier{}), query).Count(&count).Error
 	if err != nil {
 		return 0, err
 	}
@@ -38,9 +53,9 @@ func (repository *SupplierRepository) CountAll(ctx context.Context) (int64, erro
 
 func (repository *SupplierRepository) FindByCodeWithAssociations(ctx context.Context, code string) (*model.Supplier, error) {
 	var supplier model.Supplier
-	err := repository.DB.WithContext(ctx).Preload("Transactions").Preload("Transactions.ProductQuality", func(tx *gorm.DB) *gorm.DB {
-		return tx.Select("id", "product_code", "quality", "quantity", "price")
-	}).Preload("Transactions.ProductQuality.Product").Where("code = ?", code).First(&supplier).Error
+	err := conn(ctx, repository.DB).Preload("Transactions").Preload("Transactions.ProductQuality", func(tx *gorm.DB) *gorm.DB {
+		return withDeletedReferences(tx).Select("id", "product_code", "quality", "quantity", "price")
+	}).Preload("Transactions.ProductQuality.Product", withDeletedReferences).Where("code = ?", code).First(&supplier).Error
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +65,7 @@ func (repository *SupplierRepository) FindByCodeWithAssociations(ctx context.Con
 
 func (repository *SupplierRepository) FindByCode(ctx context.Context, code string) (*model.Supplier, error) {
 	var supplier model.Supplier
-	err := repository.DB.WithContext(ctx).Where("code = ?", code).First(&supplier).Error
+	err := conn(ctx, repository.DB).Where("code = ?", code).First(&supplier).Error
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +74,14 @@ func (repository *SupplierRepository) FindByCode(ctx context.Context, code strin
 }
 
 func (repository *SupplierRepository) Create(ctx context.Context, supplier *model.Supplier) (*model.Supplier, error) {
-	err := repository.DB.WithContext(ctx).Create(supplier).Error
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		err := tx.WithContext(ctx).Create(supplier).Error
+		if err != nil {
+			return err
+		}
+
+		return enqueueSearchOutbox(ctx, tx, supplier.ToSearchOutboxEvent(model.OutboxOperationIndex))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -67,8 +89,40 @@ func (repository *SupplierRepository) Create(ctx context.Context, supplier *mode
 	return supplier, nil
 }
 
+// CreateAll creates the suppliers in batches within one database transaction, so either all of
+// them are created or none is.
+func (repository *SupplierRepository) CreateAll(ctx context.Context, suppliers []*model.Supplier) ([]*model.Supplier, error) {
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		err := tx.WithContext(ctx).CreateInBatches(suppliers, createBatchSize).Error
+		if err != nil {
+			return err
+		}
+
+		events := make([]*model.SearchOutboxEvent, len(suppliers))
+		for i, supplier := range suppliers {
+			events[i] = supplier.ToSearchOutboxEvent(model.OutboxOperationIndex)
+		}
+
+		return enqueueSearchOutbox(ctx, tx, events...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return suppliers, nil
+}
+
 func (repository *SupplierRepository) Update(ctx context.Context, supplier *model.Supplier) (*model.Supplier, error) {
-	err := repository.DB.WithContext(ctx).Where("code = ?", supplier.Code).Updates(&supplier).Error
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		version := supplier.Version
+		supplier.Version++
+		err := versionUpdated(tx.WithContext(ctx).Where("code = ? AND version = ?", supplier.Code, version).Updates(&supplier))
+		if err != nil {
+			return err
+		}
+
+		return enqueueSearchOutbox(ctx, tx, supplier.ToSearchOutboxEvent(model.OutboxOperationIndex))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -77,8 +131,72 @@ func (repository *SupplierRepository) Update(ctx context.Context, supplier *mode
 }
 
 func (repository *SupplierRepository) Delete(ctx context.Context, code string) error {
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		var supplier model.Supplier
+		err := tx.WithContext(ctx).Where("code = ?", code).First(&supplier).Error
+		if err != nil {
+			return err
+		}
+
+		// The supplier is only marked as deleted, its transactions keep referencing it
+		err = tx.WithContext(ctx).Delete(&supplier).Error
+		if err != nil {
+			return err
+		}
+
+		return enqueueSearchOutbox(ctx, tx, supplier.ToSearchOutboxEvent(model.OutboxOperationDelete))
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *SupplierRepository) Restore(ctx context.Context, code string) (*model.Supplier, error) {
 	var supplier model.Supplier
-	err := repository.DB.WithContext(ctx).Where("code = ?", code).Delete(&supplier).Error
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		err := tx.WithContext(ctx).Unscoped().Where("code = ? AND deleted_at IS NOT NULL", code).First(&supplier).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.WithContext(ctx).Unscoped().Model(&supplier).Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+
+		return enqueueSearchOutbox(ctx, tx, supplier.ToSearchOutboxEvent(model.OutboxOperationIndex))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &supplier, nil
+}
+
+// Purge deletes the supplier for good, whether it was soft deleted or not, unless transactions
+// reference it.
+func (repository *SupplierRepository) Purge(ctx context.Context, code string) error {
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		var supplier model.Supplier
+		err := tx.WithContext(ctx).Unscoped().Where("code = ?", code).First(&supplier).Error
+		if err != nil {
+			return err
+		}
+
+		err = referencedByTransactions(ctx, tx, "supplier_code = ?", supplier.Code)
+		if err != nil {
+			return err
+		}
+
+		err = tx.WithContext(ctx).Unscoped().Delete(&supplier).Error
+		if err != nil {
+			return err
+		}
+
+		return enqueueSearchOutbox(ctx, tx, supplier.ToSearchOutboxEvent(model.OutboxOperationDelete))
+	})
 	if err != nil {
 		return err
 	}
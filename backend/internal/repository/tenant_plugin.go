@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/util"
+	"reflect"
+)
+
+// TenantPlugin scopes every statement on a model with a TenantID field to the tenant of the
+// statement context: reads, updates and deletes are filtered by tenant_id and created rows are
+// assigned to the tenant. Statements whose context has no tenant fail, unless the context is
+// marked WithoutTenant, such as to check that a username is unique across tenants. Rows are then
+// only created with the tenant they are given.
+type TenantPlugin struct{}
+
+func NewTenantPlugin() gorm.Plugin {
+	return &TenantPlugin{}
+}
+
+func (plugin *TenantPlugin) Name() string {
+	return "tenant"
+}
+
+func (plugin *TenantPlugin) Initialize(db *gorm.DB) error {
+	err := db.Callback().Create().Before("gorm:create").Register("tenant:create", assignTenant)
+	if err != nil {
+		return err
+	}
+
+	err = db.Callback().Query().Before("gorm:query").Register("tenant:query", filterTenant)
+	if err != nil {
+		return err
+	}
+
+	err = db.Callback().Update().Before("gorm:update").Register("tenant:update", func(db *gorm.DB) {
+		// Save writes every column, so the tenant has to be set on the model as well
+		field, tenantID, ok := statementTenant(db)
+		if !ok {
+			return
+		}
+		setTenant(db, field, tenantID)
+		filterTenant(db)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = db.Callback().Delete().Before("gorm:delete").Register("tenant:delete", filterTenant)
+	if err != nil {
+		return err
+	}
+
+	return db.Callback().Row().Before("gorm:row").Register("tenant:row", filterTenant)
+}
+
+// statementTenant returns the TenantID field of the statement model and the tenant of the
+// statement context, ok when the statement is scoped to it. A statement on a model without the
+// field is not, and neither is one whose context has no tenant, which fails unless the context
+// is marked WithoutTenant.
+func statementTenant(db *gorm.DB) (*schema.Field, int64, bool) {
+	if db.Statement.Schema == nil {
+		return nil, 0, false
+	}
+
+	field := db.Statement.Schema.LookUpField("TenantID")
+	if field == nil {
+		return nil, 0, false
+	}
+
+	tenantID, ok := util.TenantFromContext(db.Statement.Context)
+	if !ok && !util.WithoutTenantFromContext(db.Statement.Context) {
+		_ = db.AddError(errors.New(response.ErrorTenantMissing))
+	}
+
+	return field, tenantID, ok
+}
+
+func filterTenant(db *gorm.DB) {
+	field, tenantID, ok := statementTenant(db)
+	if !ok {
+		return
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: tenantID},
+	}})
+}
+
+func assignTenant(db *gorm.DB) {
+	field, tenantID, ok := statementTenant(db)
+	if field == nil || db.Error != nil {
+		return
+	}
+	if ok {
+		setTenant(db, field, tenantID)
+		return
+	}
+
+	// Without a tenant, every row must be given its own rather than fall back to a default
+	forEachRow(db, func(row reflect.Value) {
+		if _, zero := field.ValueOf(db.Statement.Context, row); zero {
+			_ = db.AddError(errors.New(response.ErrorTenantMissing))
+		}
+	})
+}
+
+func setTenant(db *gorm.DB, field *schema.Field, tenantID int64) {
+	forEachRow(db, func(row reflect.Value) {
+		err := field.Set(db.Statement.Context, row, tenantID)
+		if err != nil {
+			_ = db.AddError(err)
+		}
+	})
+}
+
+func forEachRow(db *gorm.DB, apply func(row reflect.Value)) {
+	value := db.Statement.ReflectValue
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			apply(reflect.Indirect(value.Index(i)))
+		}
+	case reflect.Struct:
+		apply(value)
+	}
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
+	"testing"
+)
+
+func newDryRunDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	assert.Nil(t, err)
+
+	err = db.Use(NewTenantPlugin())
+	assert.Nil(t, err)
+
+	err = db.Use(NewSoftDeletePlugin())
+	assert.Nil(t, err)
+
+	return db
+}
+
+func TestTenantPlugin(t *testing.T) {
+	tenantCtx := util.WithTenant(context.Background(), 2)
+
+	t.Run("Queries are filtered by the context tenant", func(t *testing.T) {
+		db := newDryRunDB(t)
+
+		var suppliers []*model.Supplier
+		statement := db.WithContext(tenantCtx).Where("name = ?", "Widdy").Find(&suppliers).Statement
+		assert.Equal(t, `SELECT * FROM "suppliers" WHERE name = $1 AND "suppliers"."tenant_id" = $2 AND "suppliers"."deleted_at" IS NULL`, statement.SQL.String())
+		assert.Equal(t, []interface{}{"Widdy", int64(2)}, statement.Vars)
+	})
+
+	t.Run("Updates and deletes are filtered by the context tenant", func(t *testing.T) {
+		db := newDryRunDB(t)
+
+		statement := db.WithContext(tenantCtx).Model(&model.ProductQuality{}).Where("id = ?", 1).Update("quantity", 10).Statement
+		assert.Contains(t, statement.SQL.String(), `"product_qualities"."tenant_id" = $`)
+
+		statement = db.WithContext(tenantCtx).Where("code = ?", "WDWDARFSYH").Delete(&model.Customer{}).Statement
+		assert.Contains(t, statement.SQL.String(), `"customers"."tenant_id" = $`)
+	})
+
+	t.Run("Created rows are assigned to the context tenant", func(t *testing.T) {
+		db := newDryRunDB(t)
+
+		customers := []*model.Customer{{Name: "Widdy"}, {Name: "Arfiansyah"}}
+		db.WithContext(tenantCtx).Create(&customers)
+		assert.Equal(t, int64(2), customers[0].TenantID)
+		assert.Equal(t, int64(2), customers[1].TenantID)
+	})
+
+	t.Run("Statements without a tenant fail", func(t *testing.T) {
+		db := newDryRunDB(t)
+
+		var suppliers []*model.Supplier
+		err := db.WithContext(context.Background()).Find(&suppliers).Error
+		assert.Equal(t, errors.New(response.ErrorTenantMissing), err)
+
+		err = db.WithContext(context.Background()).Create(&model.AuditLog{Entity: "supplier"}).Error
+		assert.Equal(t, errors.New(response.ErrorTenantMissing), err)
+	})
+
+	t.Run("Statements without a tenant are not scoped when asked to", func(t *testing.T) {
+		db := newDryRunDB(t)
+
+		var suppliers []*model.Supplier
+		statement := db.WithContext(util.WithoutTenant(context.Background())).Find(&suppliers).Statement
+		assert.Nil(t, statement.Error)
+		assert.Equal(t, `SELECT * FROM "suppliers" WHERE "suppliers"."deleted_at" IS NULL`, statement.SQL.String())
+	})
+
+	t.Run("Rows created without a tenant keep the one they are given", func(t *testing.T) {
+		db := newDryRunDB(t)
+
+		auditLog := &model.AuditLog{TenantID: 3, Entity: "supplier"}
+		err := db.WithContext(util.WithoutTenant(context.Background())).Create(auditLog).Error
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), auditLog.TenantID)
+
+		err = db.WithContext(util.WithoutTenant(context.Background())).Create(&model.AuditLog{Entity: "supplier"}).Error
+		assert.Equal(t, errors.New(response.ErrorTenantMissing), err)
+	})
+
+	t.Run("Models without a tenant are not scoped", func(t *testing.T) {
+		db := newDryRunDB(t)
+
+		var tenants []*model.Tenant
+		statement := db.WithContext(tenantCtx).Find(&tenants).Statement
+		assert.Equal(t, `SELECT * FROM "tenants"`, statement.SQL.String())
+	})
+}
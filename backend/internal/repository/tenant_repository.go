@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"inventory-management/backend/internal/model"
+)
+
+type TenantRepository struct {
+	DB *gorm.DB
+}
+
+func NewTenantRepository(db *gorm.DB) TenantRepositoryContract {
+	return &TenantRepository{
+		DB: db,
+	}
+}
+
+func (repository *TenantRepository) FindAll(ctx context.Context, offset int, limit int) ([]*model.Tenant, error) {
+	var tenants []*model.Tenant
+	err := conn(ctx, repository.DB).Offset(offset).Limit(limit).Order("id ASC").Find(&tenants).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tenants, nil
+}
+
+func (repository *TenantRepository) CountAll(ctx context.Context) (int64, error) {
+	var count int64
+	err := conn(ctx, repository.DB).Model(&model.Tenant{}).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (repository *TenantRepository) FindByID(ctx context.Context, id int64) (*model.Tenant, error) {
+	var tenant model.Tenant
+	err := conn(ctx, repository.DB).First(&tenant, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenant, nil
+}
+
+func (repository *TenantRepository) FindByCode(ctx context.Context, code string) (*model.Tenant, error) {
+	var tenant model.Tenant
+	err := conn(ctx, repository.DB).Where("code = ?", code).First(&tenant).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenant, nil
+}
+
+func (repository *TenantRepository) FindByApiKeyHash(ctx context.Context, apiKeyHash string) (*model.Tenant, error) {
+	var tenant model.Tenant
+	err := conn(ctx, repository.DB).Where("api_key_hash = ?", apiKeyHash).First(&tenant).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenant, nil
+}
+
+func (repository *TenantRepository) Create(ctx context.Context, tenant *model.Tenant) (*model.Tenant, error) {
+	err := conn(ctx, repository.DB).Create(tenant).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tenant, nil
+}
+
+func (repository *TenantRepository) Update(ctx context.Context, tenant *model.Tenant) (*model.Tenant, error) {
+	err := conn(ctx, repository.DB).Select("name", "api_key_hash").Updates(tenant).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tenant, nil
+}
@@ -2,9 +2,12 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"inventory-management/backend/internal/http/response"
 	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
 )
 
 type TransactionRepository struct {
@@ -17,13 +20,14 @@ func NewTransactionRepository(db *gorm.DB) TransactionRepositoryContract {
 	}
 }
 
-func (repository *TransactionRepository) FindAll(ctx context.Context, offset int, limit int, tx *gorm.DB) ([]*model.Transaction, error) {
+func (repository *TransactionRepository) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int, tx *gorm.DB) ([]*model.Transaction, error) {
+	db := conn(ctx, repository.DB)
 	if tx != nil {
-		repository.DB = tx
+		db = tx
 	}
 
 	var transactions []*model.Transaction
-	err := repository.DB.WithContext(ctx).Offset(offset).Limit(limit).Order("created_at DESC").Find(&transactions).Error
+	err := listQuery(withDeletedReferences(db.WithContext(ctx)), query, model.TransactionListSchema).Offset(offset).Limit(limit).Find(&transactions).Error
 	if err != nil {
 		return nil, err
 	}
@@ -31,55 +35,56 @@ func (repository *TransactionRepository) FindAll(ctx context.Context, offset int
 	return transactions, nil
 }
 
-func (repository *TransactionRepository) CountAll(ctx context.Context, tx *gorm.DB) (int64, error) {
-	if tx != nil {
-		repository.DB = tx
-	}
+func (repository *TransactionRepository) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*model.Transaction, *util.CursorPage, error) {
+	return listCursor[model.Transaction](withDeletedReferences(conn(ctx, repository.DB)), query, model.TransactionListSchema, cursor, limit)
+}
 
-	var count int64
-	err := repository.DB.WithContext(ctx).Model(&model.Transaction{}).Count(&count).Error
+// FindAllAfterID walks the transactions in ID order with the associations their search documents
+// are built from.
+func (repository *TransactionRepository) FindAllAfterID(ctx context.Context, afterID int64, limit int) ([]*model.Transaction, error) {
+	var transactions []*model.Transaction
+	err := withDeletedReferences(conn(ctx, repository.DB)).Preload(clause.Associations).Preload("ProductQuality.Product").Preload("ProductQualityTransferred.Product").Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(&transactions).Error
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	return count, nil
+	return transactions, nil
 }
 
-func (repository *TransactionRepository) FindAllBySupplierCode(ctx context.Context, supplierCode string, tx *gorm.DB) ([]*model.Transaction, error) {
+func (repository *TransactionRepository) CountAll(ctx context.Context, query *util.ListQuery, tx *gorm.DB) (int64, error) {
+	db := conn(ctx, repository.DB)
 	if tx != nil {
-		repository.DB = tx
+		db = tx
 	}
 
-	var transactions []*model.Transaction
-	err := repository.DB.WithContext(ctx).Where("supplier_code = ?", supplierCode).Find(&transactions).Error
+	var count int64
+	err := listFilter(db.WithContext(ctx).Model(&model.Transaction{}), query).Count(&count).Error
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	return transactions, nil
+	return count, nil
 }
 
-func (repository *TransactionRepository) FindAllByCustomerCode(ctx context.Context, customerCode string, tx *gorm.DB) ([]*model.Transaction, error) {
-	if tx != nil {
-		repository.DB = tx
-	}
-
-	var transactions []*model.Transaction
-	err := repository.DB.WithContext(ctx).Where("customer_code = ?", customerCode).Find(&transactions).Error
+// TotalByType sums the quantity of the transactions of the list query by type and unit of mass.
+func (repository *TransactionRepository) TotalByType(ctx context.Context, query *util.ListQuery) ([]*model.TransactionTotal, error) {
+	var totals []*model.TransactionTotal
+	err := listFilter(conn(ctx, repository.DB).Model(&model.Transaction{}), query).Select("type, unit_mass_acronym, SUM(quantity) AS quantity").Group("type, unit_mass_acronym").Find(&totals).Error
 	if err != nil {
 		return nil, err
 	}
 
-	return transactions, nil
+	return totals, nil
 }
 
 func (repository *TransactionRepository) FindByCodeWithAssociations(ctx context.Context, code string, tx *gorm.DB) (*model.Transaction, error) {
+	db := conn(ctx, repository.DB)
 	if tx != nil {
-		repository.DB = tx
+		db = tx
 	}
 
 	var transaction model.Transaction
-	err := repository.DB.WithContext(ctx).Preload(clause.Associations).Preload("ProductQuality.Product").Preload("ProductQualityTransferred.Product").Where("code = ?", code).First(&transaction).Error
+	err := withDeletedReferences(db.WithContext(ctx)).Preload(clause.Associations).Preload("ProductQuality.Product").Preload("ProductQualityTransferred.Product").Where("code = ?", code).First(&transaction).Error
 	if err != nil {
 		return nil, err
 	}
@@ -88,12 +93,13 @@ func (repository *TransactionRepository) FindByCodeWithAssociations(ctx context.
 }
 
 func (repository *TransactionRepository) FindByCode(ctx context.Context, code string, tx *gorm.DB) (*model.Transaction, error) {
+	db := conn(ctx, repository.DB)
 	if tx != nil {
-		repository.DB = tx
+		db = tx
 	}
 
 	var transaction model.Transaction
-	err := repository.DB.WithContext(ctx).Where("code = ?", code).First(&transaction).Error
+	err := db.WithContext(ctx).Where("code = ?", code).First(&transaction).Error
 	if err != nil {
 		return nil, err
 	}
@@ -102,11 +108,17 @@ func (repository *TransactionRepository) FindByCode(ctx context.Context, code st
 }
 
 func (repository *TransactionRepository) Create(ctx context.Context, transaction *model.Transaction, tx *gorm.DB) (*model.Transaction, error) {
+	db := conn(ctx, repository.DB)
 	if tx != nil {
-		repository.DB = tx
+		db = tx
+	}
+
+	err := db.WithContext(ctx).Omit(clause.Associations).Create(&transaction).Error
+	if err != nil {
+		return nil, err
 	}
 
-	err := repository.DB.WithContext(ctx).Omit(clause.Associations).Create(&transaction).Error
+	err = enqueueSearchOutbox(ctx, db, transaction.ToSearchOutboxEvent(model.OutboxOperationIndex))
 	if err != nil {
 		return nil, err
 	}
@@ -115,11 +127,19 @@ func (repository *TransactionRepository) Create(ctx context.Context, transaction
 }
 
 func (repository *TransactionRepository) Update(ctx context.Context, transaction *model.Transaction, tx *gorm.DB) (*model.Transaction, error) {
+	db := conn(ctx, repository.DB)
 	if tx != nil {
-		repository.DB = tx
+		db = tx
 	}
 
-	err := repository.DB.WithContext(ctx).Omit(clause.Associations).Where("code = ?", transaction.Code).Updates(&transaction).Error
+	version := transaction.Version
+	transaction.Version++
+	err := versionUpdated(db.WithContext(ctx).Omit(clause.Associations).Where("code = ? AND version = ?", transaction.Code, version).Updates(&transaction))
+	if err != nil {
+		return nil, err
+	}
+
+	err = enqueueSearchOutbox(ctx, db, transaction.ToSearchOutboxEvent(model.OutboxOperationIndex))
 	if err != nil {
 		return nil, err
 	}
@@ -128,15 +148,49 @@ func (repository *TransactionRepository) Update(ctx context.Context, transaction
 }
 
 func (repository *TransactionRepository) Delete(ctx context.Context, code string, tx *gorm.DB) error {
+	db := conn(ctx, repository.DB)
 	if tx != nil {
-		repository.DB = tx
+		db = tx
 	}
 
 	var transaction model.Transaction
-	err := repository.DB.WithContext(ctx).Where("code = ?", code).Delete(&transaction).Error
+	err := db.WithContext(ctx).Where("code = ?", code).First(&transaction).Error
+	if err != nil {
+		return err
+	}
+
+	err = db.WithContext(ctx).Delete(&transaction).Error
+	if err != nil {
+		return err
+	}
+
+	err = enqueueSearchOutbox(ctx, db, transaction.ToSearchOutboxEvent(model.OutboxOperationDelete))
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// withDeletedReferences lets the transactions read through db preload the products, qualities and
+// partners they reference even once those are soft deleted, so the history stays complete.
+// Transactions themselves are never soft deleted.
+func withDeletedReferences(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+// referencedByTransactions fails when transactions reference the row the query selects them by,
+// so purging master data never takes history along.
+func referencedByTransactions(ctx context.Context, tx *gorm.DB, query string, args ...interface{}) error {
+	var count int64
+	err := tx.WithContext(ctx).Model(&model.Transaction{}).Where(query, args...).Count(&count).Error
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return errors.New(response.ErrorRecordReferenced)
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
+	"testing"
+)
+
+func TestTransactionRepository_TotalByType(t *testing.T) {
+	db := newDryRunDB(t)
+
+	var statementSQL string
+	var statementVars []interface{}
+	db.Callback().Query().After("gorm:query").Register("test:statement", func(db *gorm.DB) {
+		statementSQL = db.Statement.SQL.String()
+		statementVars = db.Statement.Vars
+	})
+
+	query := &util.ListQuery{
+		Filters: []*util.ListFilter{
+			{Field: "type", Operator: util.ListOperatorIn, Values: []interface{}{"IN", "OUT"}},
+			{Field: "product_code", Operator: util.ListOperatorEq, Values: []interface{}{"PRD001"}, Expression: model.TransactionListSchema.Expressions["product_code"]},
+		},
+	}
+
+	repository := NewTransactionRepository(db)
+	_, err := repository.TotalByType(util.WithTenant(context.Background(), 2), query)
+	assert.Nil(t, err)
+	assert.Equal(t, `SELECT type, unit_mass_acronym, SUM(quantity) AS quantity FROM "transactions" WHERE "type" IN ($1,$2) AND (SELECT product_qualities.product_code FROM product_qualities WHERE product_qualities.id = transactions.product_quality_id) = $3 AND "transactions"."tenant_id" = $4 GROUP BY type, unit_mass_acronym`, statementSQL)
+	assert.Equal(t, []interface{}{"IN", "OUT", "PRD001", int64(2)}, statementVars)
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+type transactionContextKey struct{}
+
+type Transactor struct {
+	DB *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) TransactorContract {
+	return &Transactor{
+		DB: db,
+	}
+}
+
+// Transaction runs fn within one database transaction. Repositories called with the context
+// passed to fn share the transaction, so their changes commit or roll back together, and
+// transactions they begin themselves become savepoints of it.
+func (transactor *Transactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return conn(ctx, transactor.DB).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, transactionContextKey{}, tx))
+	})
+}
+
+// conn returns the transaction the context was given by a Transactor, or db outside of one.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(transactionContextKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestConn(t *testing.T) {
+	t.Run("Outside of a transaction the database is used", func(t *testing.T) {
+		db := newDryRunDB(t)
+
+		assert.Equal(t, db.Statement.ConnPool, conn(context.Background(), db).Statement.ConnPool)
+	})
+
+	t.Run("Within a transaction the transaction of the context is used", func(t *testing.T) {
+		db := newDryRunDB(t)
+		tx := newDryRunDB(t)
+		ctx := context.WithValue(context.Background(), transactionContextKey{}, tx)
+
+		statement := conn(ctx, db).Where("id = ?", 1).Find(&[]*model.Invitation{}).Statement
+		assert.Equal(t, tx.Statement.ConnPool, statement.ConnPool)
+		assert.Equal(t, ctx, statement.Context)
+	})
+}
+
+func TestTransactor_Transaction(t *testing.T) {
+	t.Run("Product quality delete is rolled back when its audit entry fails", func(t *testing.T) {
+		recorder := &statementRecorder{
+			rows: func(query string) ([]string, [][]driver.Value) {
+				if strings.HasPrefix(query, `SELECT * FROM "product_qualities"`) {
+					return []string{"id", "product_code", "tenant_id"}, [][]driver.Value{{int64(1), "KKDJALS", int64(1)}}
+				}
+				return nil, nil
+			},
+			fail: func(query string) error {
+				if strings.HasPrefix(query, `INSERT INTO "audit_logs"`) {
+					return errors.New("audit log is unavailable")
+				}
+				return nil
+			},
+		}
+		db := newRecordingDB(t, recorder)
+		transactor := NewTransactor(db)
+		productQualityRepository := NewProductQualityRepository(db)
+		auditLogRepository := NewAuditLogRepository(db)
+
+		ctx := util.WithTenant(context.Background(), 1)
+		err := transactor.Transaction(ctx, func(ctx context.Context) error {
+			err := productQualityRepository.Delete(ctx, 1, nil)
+			if err != nil {
+				return err
+			}
+
+			_, err = auditLogRepository.Create(ctx, &model.AuditLog{Entity: model.AuditEntityProductQuality, EntityID: "1", Action: model.AuditActionDelete})
+			return err
+		})
+		assert.EqualError(t, err, "audit log is unavailable")
+
+		statements := recorder.statements()
+		assert.Equal(t, "BEGIN", statements[0])
+		assert.Equal(t, "ROLLBACK", statements[len(statements)-1])
+		assert.NotContains(t, statements, "COMMIT")
+		for _, statement := range statements[1 : len(statements)-1] {
+			assert.True(t, strings.HasPrefix(statement, "tx: "), statement)
+		}
+		assert.Condition(t, func() bool {
+			for _, statement := range statements {
+				if strings.HasPrefix(statement, `tx: UPDATE "product_qualities" SET "deleted_at"`) {
+					return true
+				}
+			}
+			return false
+		})
+	})
+}
+
+// statementRecorder is a database/sql driver that records the statements it is given, the ones
+// run within a transaction are prefixed with "tx: ". rows answers the queries and fail returns
+// the error of a statement.
+type statementRecorder struct {
+	mu       sync.Mutex
+	recorded []string
+	rows     func(query string) ([]string, [][]driver.Value)
+	fail     func(query string) error
+}
+
+func newRecordingDB(t *testing.T, recorder *statementRecorder) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(recorder)}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	assert.Nil(t, err)
+
+	err = db.Use(NewTenantPlugin())
+	assert.Nil(t, err)
+
+	err = db.Use(NewSoftDeletePlugin())
+	assert.Nil(t, err)
+
+	return db
+}
+
+func (recorder *statementRecorder) statements() []string {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	return append([]string(nil), recorder.recorded...)
+}
+
+func (recorder *statementRecorder) record(query string, inTx bool) error {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	if inTx {
+		recorder.recorded = append(recorder.recorded, "tx: "+query)
+	} else {
+		recorder.recorded = append(recorder.recorded, query)
+	}
+
+	if recorder.fail != nil {
+		return recorder.fail(query)
+	}
+	return nil
+}
+
+func (recorder *statementRecorder) Connect(context.Context) (driver.Conn, error) {
+	return &recordingConn{recorder: recorder}, nil
+}
+
+func (recorder *statementRecorder) Driver() driver.Driver {
+	return nil
+}
+
+type recordingConn struct {
+	recorder *statementRecorder
+	inTx     bool
+}
+
+func (conn *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not recorded")
+}
+
+func (conn *recordingConn) Close() error {
+	return nil
+}
+
+func (conn *recordingConn) Begin() (driver.Tx, error) {
+	conn.inTx = true
+	return conn, conn.recorder.record("BEGIN", false)
+}
+
+func (conn *recordingConn) Commit() error {
+	conn.inTx = false
+	return conn.recorder.record("COMMIT", false)
+}
+
+func (conn *recordingConn) Rollback() error {
+	conn.inTx = false
+	return conn.recorder.record("ROLLBACK", false)
+}
+
+func (conn *recordingConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	err := conn.recorder.record(query, conn.inTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+func (conn *recordingConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	err := conn.recorder.record(query, conn.inTx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := &recordingRows{}
+	if conn.recorder.rows != nil {
+		rows.columns, rows.values = conn.recorder.rows(query)
+	}
+	return rows, nil
+}
+
+type recordingRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (rows *recordingRows) Columns() []string {
+	return rows.columns
+}
+
+func (rows *recordingRows) Close() error {
+	return nil
+}
+
+func (rows *recordingRows) Next(dest []driver.Value) error {
+	if len(rows.values) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, rows.values[0])
+	rows.values = rows.values[1:]
+	return nil
+}
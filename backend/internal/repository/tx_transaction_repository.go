@@ -14,71 +14,107 @@ type TxTransactionRepository struct {
 	DB                       *gorm.DB
 	TransactionRepository    TransactionRepositoryContract
 	ProductQualityRepository ProductQualityRepositoryContract
+	LedgerRepository         LedgerRepositoryContract
 }
 
-func NewTxRepository(db *gorm.DB, transactionRepository TransactionRepositoryContract, productQualityRepository ProductQualityRepositoryContract) TxTransactionRepositoryContract {
+func NewTxRepository(db *gorm.DB, transactionRepository TransactionRepositoryContract, productQualityRepository ProductQualityRepositoryContract, ledgerRepository LedgerRepositoryContract) TxTransactionRepositoryContract {
 	return &TxTransactionRepository{
 		DB:                       db,
 		TransactionRepository:    transactionRepository,
 		ProductQualityRepository: productQualityRepository,
+		LedgerRepository:         ledgerRepository,
 	}
 }
 
 func (repository *TxTransactionRepository) Create(ctx context.Context, request *request.CreateTransactionRequest) (*model.Transaction, error) {
 	var createdTransaction *model.Transaction
-	err := repository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		productQuality, err := repository.ProductQualityRepository.FindByIDWithAssociations(ctx, request.ProductQualityID, tx)
-		if err != nil {
-			return err
-		}
-
-		quantity, err := util.CalculateUnitOfMass(productQuality.Product.UnitMassAcronym, request.UnitMassAcronym, request.Quantity)
-		if err != nil {
-			return err
-		}
-
-		var transactionRequest model.Transaction
-		transactionRequest.ProductQualityID = request.ProductQualityID
-		transactionRequest.SupplierCode = request.SupplierCode
-		transactionRequest.CustomerCode = request.CustomerCode
-		transactionRequest.Description = request.Description
-		transactionRequest.Quantity = request.Quantity
-		transactionRequest.Type = request.Type
-		transactionRequest.UnitMassAcronym = request.UnitMassAcronym
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		var err error
+		createdTransaction, err = repository.create(ctx, request, tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		trx, err := repository.TransactionRepository.Create(ctx, &transactionRequest, tx)
-		if err != nil {
-			return err
-		}
+	return createdTransaction, nil
+}
 
-		if transactionRequest.Type == "IN" {
-			err = repository.ProductQualityRepository.IncreaseStock(ctx, transactionRequest.ProductQualityID, quantity, tx)
+// CreateAll creates the transactions within one database transaction, so either all of them
+// are posted or none is.
+func (repository *TxTransactionRepository) CreateAll(ctx context.Context, requests []*request.CreateTransactionRequest) ([]*model.Transaction, error) {
+	createdTransactions := make([]*model.Transaction, 0, len(requests))
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		for _, request := range requests {
+			transaction, err := repository.create(ctx, request, tx)
 			if err != nil {
 				return err
 			}
-		}
 
-		if transactionRequest.Type == "OUT" {
-			err = repository.ProductQualityRepository.DecreaseStock(ctx, transactionRequest.ProductQualityID, quantity, tx)
-			if err != nil {
-				return err
-			}
+			createdTransactions = append(createdTransactions, transaction)
 		}
 
-		createdTransaction = trx
-
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return createdTransaction, nil
+	return createdTransactions, nil
+}
+
+// create posts the transaction and moves the stock of its product quality.
+func (repository *TxTransactionRepository) create(ctx context.Context, request *request.CreateTransactionRequest, tx *gorm.DB) (*model.Transaction, error) {
+	productQuality, err := repository.ProductQualityRepository.FindByIDWithAssociations(ctx, request.ProductQualityID, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	quantity, err := util.CalculateUnitOfMass(productQuality.Product.UnitMassAcronym, request.UnitMassAcronym, request.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactionRequest model.Transaction
+	transactionRequest.ProductQualityID = request.ProductQualityID
+	transactionRequest.SupplierCode = request.SupplierCode
+	transactionRequest.CustomerCode = request.CustomerCode
+	transactionRequest.Description = request.Description
+	transactionRequest.Reference = request.Reference
+	transactionRequest.Quantity = request.Quantity
+	transactionRequest.Type = request.Type
+	transactionRequest.UnitMassAcronym = request.UnitMassAcronym
+
+	trx, err := repository.TransactionRepository.Create(ctx, &transactionRequest, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if transactionRequest.Type == "IN" {
+		err = repository.ProductQualityRepository.IncreaseStock(ctx, transactionRequest.ProductQualityID, quantity, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if transactionRequest.Type == "OUT" {
+		err = repository.ProductQualityRepository.DecreaseStock(ctx, transactionRequest.ProductQualityID, quantity, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = repository.appendLedger(ctx, trx.Code, model.LedgerActionCreate, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return trx, nil
 }
 
 func (repository *TxTransactionRepository) Update(ctx context.Context, request *request.UpdateTransactionRequest) (*model.Transaction, error) {
 	var updatedTransaction *model.Transaction
-	err := repository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
 		transaction, err := repository.TransactionRepository.FindByCodeWithAssociations(ctx, request.Code, tx)
 		if err != nil {
 			return err
@@ -88,6 +124,10 @@ func (repository *TxTransactionRepository) Update(ctx context.Context, request *
 			return errors.New(response.ErrorUpdateTransactionTypeTransfer)
 		}
 
+		if transaction.Version != request.Version {
+			return errors.New(response.ErrorVersionMismatch)
+		}
+
 		if request.CustomerCode != nil {
 			transaction.CustomerCode = request.CustomerCode
 		}
@@ -128,6 +168,11 @@ func (repository *TxTransactionRepository) Update(ctx context.Context, request *
 			return err
 		}
 
+		err = repository.appendLedger(ctx, transaction.Code, model.LedgerActionUpdate, tx)
+		if err != nil {
+			return err
+		}
+
 		updatedTransaction = transaction
 
 		return nil
@@ -140,46 +185,91 @@ func (repository *TxTransactionRepository) Update(ctx context.Context, request *
 }
 func (repository *TxTransactionRepository) TransferStock(ctx context.Context, request *request.TransferStockTransactionRequest) (*model.Transaction, error) {
 	var transaction *model.Transaction
-	err := repository.DB.Transaction(func(tx *gorm.DB) error {
-		fromQuality, err := repository.ProductQualityRepository.FindByIDWithAssociations(ctx, request.ProductQualityID, tx)
-		if err != nil {
-			return err
-		}
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		var err error
+		transaction, err = repository.transfer(ctx, request, tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		toQuality, err := repository.ProductQualityRepository.FindByID(ctx, request.ProductQualityIDTransferred, tx)
-		if err != nil {
-			return err
-		}
+	return transaction, nil
+}
 
-		if fromQuality.ProductCode != toQuality.ProductCode {
-			return errors.New(response.ErrorTransferStockDifferentProduct)
-		}
+// transfer moves stock between two qualities of the same product and posts the TRANSFER
+// transaction, in the unit of mass of the product.
+func (repository *TxTransactionRepository) transfer(ctx context.Context, request *request.TransferStockTransactionRequest, tx *gorm.DB) (*model.Transaction, error) {
+	fromQuality, err := repository.ProductQualityRepository.FindByIDWithAssociations(ctx, request.ProductQualityID, tx)
+	if err != nil {
+		return nil, err
+	}
 
-		if fromQuality.Quantity < request.Quantity {
-			return errors.New(response.ErrorStockNotEnough)
-		}
+	toQuality, err := repository.ProductQualityRepository.FindByID(ctx, request.ProductQualityIDTransferred, tx)
+	if err != nil {
+		return nil, err
+	}
 
-		err = repository.ProductQualityRepository.DecreaseStock(ctx, fromQuality.ID, request.Quantity, tx)
-		if err != nil {
-			return err
-		}
+	if fromQuality.ProductCode != toQuality.ProductCode {
+		return nil, errors.New(response.ErrorTransferStockDifferentProduct)
+	}
 
-		err = repository.ProductQualityRepository.IncreaseStock(ctx, toQuality.ID, request.Quantity, tx)
+	quantity := request.Quantity
+	if request.UnitMassAcronym != "" {
+		quantity, err = util.CalculateUnitOfMass(fromQuality.Product.UnitMassAcronym, request.UnitMassAcronym, request.Quantity)
 		if err != nil {
-			return err
+			return nil, err
 		}
+	}
 
-		var transactionRequest model.Transaction
-		transactionRequest.ProductQualityID = request.ProductQualityID
-		transactionRequest.ProductQualityIDTransferred = &request.ProductQualityIDTransferred
-		transactionRequest.Description = request.Description
-		transactionRequest.Quantity = request.Quantity
-		transactionRequest.Type = "TRANSFER"
-		transactionRequest.UnitMassAcronym = fromQuality.Product.UnitMassAcronym
+	if fromQuality.Quantity < quantity {
+		return nil, errors.New(response.ErrorStockNotEnough)
+	}
 
-		transaction, err = repository.TransactionRepository.Create(ctx, &transactionRequest, tx)
-		if err != nil {
-			return err
+	err = repository.ProductQualityRepository.DecreaseStock(ctx, fromQuality.ID, quantity, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = repository.ProductQualityRepository.IncreaseStock(ctx, toQuality.ID, quantity, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactionRequest model.Transaction
+	transactionRequest.ProductQualityID = request.ProductQualityID
+	transactionRequest.ProductQualityIDTransferred = &request.ProductQualityIDTransferred
+	transactionRequest.Description = request.Description
+	transactionRequest.Reference = request.Reference
+	transactionRequest.Quantity = quantity
+	transactionRequest.Type = "TRANSFER"
+	transactionRequest.UnitMassAcronym = fromQuality.Product.UnitMassAcronym
+
+	transaction, err := repository.TransactionRepository.Create(ctx, &transactionRequest, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = repository.appendLedger(ctx, transaction.Code, model.LedgerActionCreate, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// CreateBatch posts the lines of a stock movement in order within one database transaction, so
+// either every line moves stock or none does.
+func (repository *TxTransactionRepository) CreateBatch(ctx context.Context, request *request.CreateTransactionBatchRequest) ([]*model.Transaction, error) {
+	createdTransactions := make([]*model.Transaction, 0, len(request.Lines))
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		for _, line := range request.Lines {
+			transaction, err := repository.createBatchLine(ctx, request, line, tx)
+			if err != nil {
+				return err
+			}
+
+			createdTransactions = append(createdTransactions, transaction)
 		}
 
 		return nil
@@ -188,11 +278,40 @@ func (repository *TxTransactionRepository) TransferStock(ctx context.Context, re
 		return nil, err
 	}
 
-	return transaction, nil
+	return createdTransactions, nil
+}
+
+func (repository *TxTransactionRepository) createBatchLine(ctx context.Context, batch *request.CreateTransactionBatchRequest, line *request.TransactionBatchLineRequest, tx *gorm.DB) (*model.Transaction, error) {
+	description := line.Description
+	if description == nil {
+		description = batch.Description
+	}
+
+	if line.Type == "TRANSFER" {
+		return repository.transfer(ctx, &request.TransferStockTransactionRequest{
+			ProductQualityID:            line.ProductQualityID,
+			ProductQualityIDTransferred: line.ProductQualityIDTransferred,
+			Quantity:                    line.Quantity,
+			UnitMassAcronym:             line.UnitMassAcronym,
+			Description:                 description,
+			Reference:                   batch.Reference,
+		}, tx)
+	}
+
+	return repository.create(ctx, &request.CreateTransactionRequest{
+		ProductQualityID: line.ProductQualityID,
+		SupplierCode:     batch.SupplierCode,
+		CustomerCode:     batch.CustomerCode,
+		Description:      description,
+		Reference:        batch.Reference,
+		Quantity:         line.Quantity,
+		Type:             line.Type,
+		UnitMassAcronym:  line.UnitMassAcronym,
+	}, tx)
 }
 
 func (repository *TxTransactionRepository) Delete(ctx context.Context, code string) error {
-	err := repository.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
 		transaction, err := repository.TransactionRepository.FindByCodeWithAssociations(ctx, code, tx)
 		if err != nil {
 			return err
@@ -203,6 +322,11 @@ func (repository *TxTransactionRepository) Delete(ctx context.Context, code stri
 			return err
 		}
 
+		_, err = repository.LedgerRepository.Append(ctx, transaction, model.LedgerActionDelete, tx)
+		if err != nil {
+			return err
+		}
+
 		quantityAfterCalculated, err := util.CalculateUnitOfMass(transaction.ProductQuality.Product.UnitMassAcronym, transaction.UnitMassAcronym, transaction.Quantity)
 		if err != nil {
 			return err
@@ -242,3 +366,19 @@ func (repository *TxTransactionRepository) Delete(ctx context.Context, code stri
 
 	return nil
 }
+
+// appendLedger records the transaction as stored in the database, since partial updates skip
+// zero values and the stored timestamps are less precise than the ones in memory.
+func (repository *TxTransactionRepository) appendLedger(ctx context.Context, code string, action string, tx *gorm.DB) error {
+	transaction, err := repository.TransactionRepository.FindByCode(ctx, code, tx)
+	if err != nil {
+		return err
+	}
+
+	_, err = repository.LedgerRepository.Append(ctx, transaction, action, tx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -2,8 +2,11 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"gorm.io/gorm"
+	"inventory-management/backend/internal/http/response"
 	"inventory-management/backend/internal/model"
+	"inventory-management/backend/util"
 )
 
 type UserRepository struct {
@@ -16,9 +19,9 @@ func NewUserRepository(db *gorm.DB) UserRepositoryContract {
 	}
 }
 
-func (repository *UserRepository) FindAll(ctx context.Context, offset int, limit int) ([]*model.User, error) {
+func (repository *UserRepository) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*model.User, error) {
 	var users []*model.User
-	err := repository.DB.WithContext(ctx).Offset(offset).Limit(limit).Order("created_at DESC").Find(&users).Error
+	err := listQuery(conn(ctx, repository.DB), query, model.UserListSchema).Offset(offset).Limit(limit).Find(&users).Error
 	if err != nil {
 		return nil, err
 	}
@@ -26,9 +29,23 @@ func (repository *UserRepository) FindAll(ctx context.Context, offset int, limit
 	return users, nil
 }
 
-func (repository *UserRepository) CountAll(ctx context.Context) (int64, error) {
+func (repository *UserRepository) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*model.User, *util.CursorPage, error) {
+	return listCursor[model.User](conn(ctx, repository.DB), query, model.UserListSchema, cursor, limit)
+}
+
+func (repository *UserRepository) FindAllAfterID(ctx context.Context, afterID int64, limit int) ([]*model.User, error) {
+	var users []*model.User
+	err := conn(ctx, repository.DB).Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (repository *UserRepository) CountAll(ctx context.Context, query *util.ListQuery) (int64, error) {
 	var count int64
-	err := repository.DB.WithContext(ctx).Model(&model.User{}).Count(&count).Error
+	err := listFilter(conn(ctx, repository.DB).Model(&model.User{}), query).Count(&count).Error
 	if err != nil {
 		return 0, err
 	}
@@ -38,7 +55,7 @@ func (repository *UserRepository) CountAll(ctx context.Context) (int64, error) {
 
 func (repository *UserRepository) FindByID(ctx context.Context, id int64) (*model.User, error) {
 	var user model.User
-	err := repository.DB.WithContext(ctx).First(&user, id).Error
+	err := conn(ctx, repository.DB).First(&user, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +65,27 @@ func (repository *UserRepository) FindByID(ctx context.Context, id int64) (*mode
 
 func (repository *UserRepository) FindByUsername(ctx context.Context, username string) (*model.User, error) {
 	var user model.User
-	err := repository.DB.WithContext(ctx).Select("username", "password").Where("username = ?", username).First(&user).Error
+	err := conn(ctx, repository.DB).Where("username = ?", username).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (repository *UserRepository) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	var user model.User
+	err := conn(ctx, repository.DB).Where("email = ?", email).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (repository *UserRepository) FindByOidcSubject(ctx context.Context, issuer string, subject string) (*model.User, error) {
+	var user model.User
+	err := conn(ctx, repository.DB).Where("oidc_issuer = ? AND oidc_subject = ?", issuer, subject).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -57,7 +94,14 @@ func (repository *UserRepository) FindByUsername(ctx context.Context, username s
 }
 
 func (repository *UserRepository) Create(ctx context.Context, user *model.User) (*model.User, error) {
-	err := repository.DB.WithContext(ctx).Create(user).Error
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		err := tx.WithContext(ctx).Create(user).Error
+		if err != nil {
+			return err
+		}
+
+		return enqueueSearchOutbox(ctx, tx, user.ToSearchOutboxEvent(model.OutboxOperationIndex))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +110,14 @@ func (repository *UserRepository) Create(ctx context.Context, user *model.User)
 }
 
 func (repository *UserRepository) Update(ctx context.Context, user *model.User) (*model.User, error) {
-	err := repository.DB.WithContext(ctx).Select("name", "password").Updates(user).Error
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		err := tx.WithContext(ctx).Select("name", "password", "email", "role", "oidc_issuer", "oidc_subject", "token_version").Updates(user).Error
+		if err != nil {
+			return err
+		}
+
+		return enqueueSearchOutbox(ctx, tx, user.ToSearchOutboxEvent(model.OutboxOperationIndex))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -75,8 +126,75 @@ func (repository *UserRepository) Update(ctx context.Context, user *model.User)
 }
 
 func (repository *UserRepository) Delete(ctx context.Context, id int64) error {
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		err := tx.WithContext(ctx).First(&user, id).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.WithContext(ctx).Delete(&user).Error
+		if err != nil {
+			return err
+		}
+
+		return enqueueSearchOutbox(ctx, tx, user.ToSearchOutboxEvent(model.OutboxOperationDelete))
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *UserRepository) Restore(ctx context.Context, id int64) (*model.User, error) {
 	var user model.User
-	err := repository.DB.WithContext(ctx).Delete(&user, id).Error
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		err := tx.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").First(&user, id).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.WithContext(ctx).Unscoped().Model(&user).Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+
+		return enqueueSearchOutbox(ctx, tx, user.ToSearchOutboxEvent(model.OutboxOperationIndex))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// Purge deletes the user for good, whether it was soft deleted or not, unless the audit log
+// records changes the user made.
+func (repository *UserRepository) Purge(ctx context.Context, id int64) error {
+	err := conn(ctx, repository.DB).Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		err := tx.WithContext(ctx).Unscoped().First(&user, id).Error
+		if err != nil {
+			return err
+		}
+
+		var count int64
+		err = tx.WithContext(ctx).Model(&model.AuditLog{}).Where("actor = ?", user.Username).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.New(response.ErrorRecordReferenced)
+		}
+
+		err = tx.WithContext(ctx).Unscoped().Delete(&user).Error
+		if err != nil {
+			return err
+		}
+
+		return enqueueSearchOutbox(ctx, tx, user.ToSearchOutboxEvent(model.OutboxOperationDelete))
+	})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"inventory-management/backend/internal/http/response"
+)
+
+// versionUpdated checks an update made only at the version the record was read at: it updates
+// nothing when the record has been changed since, and the change must not overwrite that one.
+func versionUpdated(result *gorm.DB) error {
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(response.ErrorVersionMismatch)
+	}
+
+	return nil
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/internal/repository"
+	third_party "inventory-management/backend/internal/third_party/notifier"
+	"inventory-management/backend/util"
+	"net/url"
+	"time"
+)
+
+const (
+	invitationTTL    = 7 * 24 * time.Hour
+	passwordResetTTL = time.Hour
+)
+
+type AccountService struct {
+	UserRepository          repository.UserRepositoryContract
+	InvitationRepository    repository.InvitationRepositoryContract
+	PasswordResetRepository repository.PasswordResetRepositoryContract
+	Transactor              repository.TransactorContract
+	UserService             UserServiceContract
+	Notifier                third_party.NotifierContract
+	FrontendURL             string
+}
+
+func NewAccountService(userRepository repository.UserRepositoryContract, invitationRepository repository.InvitationRepositoryContract, passwordResetRepository repository.PasswordResetRepositoryContract, transactor repository.TransactorContract, userService UserServiceContract, notifier third_party.NotifierContract, frontendURL string) AccountServiceContract {
+	return &AccountService{
+		UserRepository:          userRepository,
+		InvitationRepository:    invitationRepository,
+		PasswordResetRepository: passwordResetRepository,
+		Transactor:              transactor,
+		UserService:             userService,
+		Notifier:                notifier,
+		FrontendURL:             frontendURL,
+	}
+}
+
+func (service *AccountService) Me(ctx context.Context, username string) (*response.UserResponse, error) {
+	user, err := service.UserRepository.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.ToResponse(), nil
+}
+
+func (service *AccountService) UpdateMe(ctx context.Context, updateMeRequest *request.UpdateMeRequest) (*response.UserResponse, error) {
+	user, err := service.UserRepository.FindByUsername(ctx, updateMeRequest.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.UserService.Update(ctx, &request.UpdateUserRequest{
+		ID:    user.ID,
+		Name:  updateMeRequest.Name,
+		Email: updateMeRequest.Email,
+	})
+}
+
+func (service *AccountService) ChangePassword(ctx context.Context, changePasswordRequest *request.ChangePasswordRequest) error {
+	user, err := service.UserRepository.FindByUsername(ctx, changePasswordRequest.Username)
+	if err != nil {
+		return err
+	}
+
+	err = user.VerifyPassword(changePasswordRequest.CurrentPassword)
+	if err != nil {
+		return err
+	}
+
+	_, err = service.UserService.Update(ctx, &request.UpdateUserRequest{
+		ID:       user.ID,
+		Name:     user.Name,
+		Password: changePasswordRequest.NewPassword,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (service *AccountService) CreateInvitation(ctx context.Context, request *request.CreateInvitationRequest) (*response.InvitationResponse, error) {
+	token, err := util.GenerateRandomString(48)
+	if err != nil {
+		return nil, err
+	}
+
+	var invitationRequest model.Invitation
+	invitationRequest.TokenHash = util.HashToken(token)
+	invitationRequest.Email = request.Email
+	invitationRequest.Role = request.Role
+	invitationRequest.InvitedBy = request.InvitedBy
+	invitationRequest.ExpiresAt = time.Now().Add(invitationTTL)
+
+	invitation, err := service.InvitationRepository.Create(ctx, &invitationRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	err = service.Notifier.Send(ctx, &third_party.Message{
+		To:      invitation.Email,
+		Subject: "You have been invited to Inventory Management",
+		Body: fmt.Sprintf("%s invited you to join Inventory Management.\n\nCreate your account: %s\n\nThis invitation expires at %s.",
+			invitation.InvitedBy, service.link("/register", token), invitation.ExpiresAt.Local().String()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	invitationResponse := invitation.ToResponse()
+	invitationResponse.Token = token
+
+	return invitationResponse, nil
+}
+
+func (service *AccountService) Register(ctx context.Context, registerRequest *request.RegisterUserRequest) (*response.UserResponse, error) {
+	invitation, err := service.InvitationRepository.FindByTokenHash(ctx, util.HashToken(registerRequest.InvitationToken))
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return nil, errors.New(response.ErrorInvalidToken)
+		}
+		return nil, err
+	}
+
+	if !invitation.IsUsable() {
+		return nil, errors.New(response.ErrorInvalidToken)
+	}
+
+	// The invitation is consumed in the transaction creating the user, so it creates one user
+	// even for concurrent registrations and is not lost when the user cannot be created
+	var user *response.UserResponse
+	err = service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		err := service.InvitationRepository.MarkAccepted(ctx, invitation.ID)
+		if err != nil {
+			return err
+		}
+
+		// Registration is public, the account joins the tenant it was invited to
+		user, err = service.UserService.Create(util.WithTenant(ctx, invitation.TenantID), &request.CreateUserRequest{
+			Name:     registerRequest.Name,
+			Username: registerRequest.Username,
+			Password: registerRequest.Password,
+			Email:    invitation.Email,
+			Role:     invitation.Role,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (service *AccountService) ForgotPassword(ctx context.Context, request *request.ForgotPasswordRequest) error {
+	user, err := service.UserRepository.FindByUsername(ctx, request.Username)
+	if err != nil {
+		// Unknown usernames are not reported to avoid account enumeration
+		if err.Error() == response.ErrorNotFound {
+			return nil
+		}
+		return err
+	}
+
+	if user.Email == nil {
+		return nil
+	}
+
+	token, err := util.GenerateRandomString(48)
+	if err != nil {
+		return err
+	}
+
+	var passwordResetRequest model.PasswordReset
+	passwordResetRequest.UserID = user.ID
+	passwordResetRequest.TokenHash = util.HashToken(token)
+	passwordResetRequest.ExpiresAt = time.Now().Add(passwordResetTTL)
+
+	passwordReset, err := service.PasswordResetRepository.Create(ctx, &passwordResetRequest)
+	if err != nil {
+		return err
+	}
+
+	err = service.Notifier.Send(ctx, &third_party.Message{
+		To:      *user.Email,
+		Subject: "Reset your Inventory Management password",
+		Body: fmt.Sprintf("Hello %s,\n\nReset your password: %s\n\nThis link expires at %s. If you did not request a reset, you can ignore this message.",
+			user.Name, service.link("/reset-password", token), passwordReset.ExpiresAt.Local().String()),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (service *AccountService) ResetPassword(ctx context.Context, resetPasswordRequest *request.ResetPasswordRequest) error {
+	passwordReset, err := service.PasswordResetRepository.FindByTokenHash(ctx, util.HashToken(resetPasswordRequest.Token))
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return errors.New(response.ErrorInvalidToken)
+		}
+		return err
+	}
+
+	if !passwordReset.IsUsable() {
+		return errors.New(response.ErrorInvalidToken)
+	}
+
+	user, err := service.UserRepository.FindByID(ctx, passwordReset.UserID)
+	if err != nil {
+		return err
+	}
+
+	// The reset is consumed in the transaction updating the password, so it cannot be replayed
+	return service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		err := service.PasswordResetRepository.MarkUsed(ctx, passwordReset.ID)
+		if err != nil {
+			return err
+		}
+
+		// Password resets are public, the user is updated within its own tenant
+		_, err = service.UserService.Update(util.WithTenant(ctx, user.TenantID), &request.UpdateUserRequest{
+			ID:       user.ID,
+			Name:     user.Name,
+			Password: resetPasswordRequest.Password,
+		})
+		return err
+	})
+}
+
+func (service *AccountService) link(path string, token string) string {
+	return fmt.Sprintf("%s%s?token=%s", service.FrontendURL, path, url.QueryEscape(token))
+}
@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+	"inventory-management/backend/internal/http/request"
+	response "inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	repository "inventory-management/backend/internal/repository/mock"
+	service "inventory-management/backend/internal/service/mock"
+	third_party "inventory-management/backend/internal/third_party/notifier"
+	"inventory-management/backend/util"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAccountService_ChangePassword(t *testing.T) {
+	password, _ := bcrypt.GenerateFromPassword([]byte("12345678910"), bcrypt.DefaultCost)
+	testCases := []struct {
+		name             string
+		request          *request.ChangePasswordRequest
+		expectedSvcError error
+	}{
+		{
+			name: "Change password with the correct current password",
+			request: &request.ChangePasswordRequest{
+				Username:        "wdyarfn",
+				CurrentPassword: "12345678910",
+				NewPassword:     "abcdefghijk",
+			},
+			expectedSvcError: nil,
+		},
+		{
+			name: "Change password with a wrong current password",
+			request: &request.ChangePasswordRequest{
+				Username:        "wdyarfn",
+				CurrentPassword: "abcdefghijk",
+				NewPassword:     "abcdefghijk",
+			},
+			expectedSvcError: errors.New(response.ErrorInvalidPassword),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var userRepo repository.UserRepositoryMock
+			var invitationRepo repository.InvitationRepositoryMock
+			var passwordResetRepo repository.PasswordResetRepositoryMock
+			var userSvc service.UserServiceMock
+			var notifier third_party.NotifierMock
+			userRepo.On("FindByUsername", ctx, "wdyarfn").Return(&model.User{ID: 1, Name: "Widdy Arfiansyah", Username: "wdyarfn", Password: string(password)}, nil)
+			userSvc.On("Update", ctx, &request.UpdateUserRequest{ID: 1, Name: "Widdy Arfiansyah", Password: "abcdefghijk"}).Return(&response.UserResponse{ID: 1}, nil)
+
+			svc := NewAccountService(&userRepo, &invitationRepo, &passwordResetRepo, &repository.TransactorMock{}, &userSvc, &notifier, "http://localhost")
+			err := svc.ChangePassword(ctx, tc.request)
+			if tc.expectedSvcError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+				userSvc.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Nil(t, err)
+			userSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAccountService_CreateInvitation(t *testing.T) {
+	ctx := context.Background()
+
+	var userRepo repository.UserRepositoryMock
+	var invitationRepo repository.InvitationRepositoryMock
+	var passwordResetRepo repository.PasswordResetRepositoryMock
+	var userSvc service.UserServiceMock
+	var notifier third_party.NotifierMock
+
+	var storedInvitation *model.Invitation
+	invitationRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		storedInvitation = args.Get(1).(*model.Invitation)
+	}).Return(&model.Invitation{ID: 1, Email: "clerk@example.com", Role: model.RoleStaff, InvitedBy: "admin"}, nil)
+
+	var sentMessage *third_party.Message
+	notifier.On("Send", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		sentMessage = args.Get(1).(*third_party.Message)
+	}).Return(nil)
+
+	svc := NewAccountService(&userRepo, &invitationRepo, &passwordResetRepo, &repository.TransactorMock{}, &userSvc, &notifier, "http://localhost")
+	result, err := svc.CreateInvitation(ctx, &request.CreateInvitationRequest{
+		InvitedBy: "admin",
+		Email:     "clerk@example.com",
+		Role:      model.RoleStaff,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), result.ID)
+	assert.Equal(t, util.HashToken(result.Token), storedInvitation.TokenHash)
+	assert.Equal(t, "clerk@example.com", sentMessage.To)
+	assert.True(t, strings.Contains(sentMessage.Body, "http://localhost/register?token="+result.Token))
+}
+
+func TestAccountService_Register(t *testing.T) {
+	registerRequest := &request.RegisterUserRequest{
+		InvitationToken: "token",
+		Name:            "Widdy Arfiansyah",
+		Username:        "wdyarfn",
+		Password:        "12345678910",
+	}
+
+	testCases := []struct {
+		name                      string
+		expectedInvitation        *model.Invitation
+		expectedInvitationError   error
+		expectedSvc               *response.UserResponse
+		expectedSvcError          error
+		expectedInvitationUpdated bool
+	}{
+		{
+			name: "Register with a valid invitation",
+			expectedInvitation: &model.Invitation{
+				ID:        1,
+				TenantID:  2,
+				Email:     "clerk@example.com",
+				Role:      model.RoleStaff,
+				ExpiresAt: time.Now().Add(time.Hour),
+			},
+			expectedSvc: &response.UserResponse{
+				ID:       1,
+				Name:     "Widdy Arfiansyah",
+				Username: "wdyarfn",
+				Email:    util.ToPointerString("clerk@example.com"),
+				Role:     model.RoleStaff,
+			},
+			expectedInvitationUpdated: true,
+		},
+		{
+			name: "Register with an expired invitation",
+			expectedInvitation: &model.Invitation{
+				ID:        1,
+				Email:     "clerk@example.com",
+				Role:      model.RoleStaff,
+				ExpiresAt: time.Now().Add(-time.Hour),
+			},
+			expectedSvcError: errors.New(response.ErrorInvalidToken),
+		},
+		{
+			name: "Register with an accepted invitation",
+			expectedInvitation: &model.Invitation{
+				ID:         1,
+				Email:      "clerk@example.com",
+				Role:       model.RoleStaff,
+				ExpiresAt:  time.Now().Add(time.Hour),
+				AcceptedAt: &time.Time{},
+			},
+			expectedSvcError: errors.New(response.ErrorInvalidToken),
+		},
+		{
+			name:                    "Register with an unknown invitation",
+			expectedInvitationError: errors.New(response.ErrorNotFound),
+			expectedSvcError:        errors.New(response.ErrorInvalidToken),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var userRepo repository.UserRepositoryMock
+			var invitationRepo repository.InvitationRepositoryMock
+			var passwordResetRepo repository.PasswordResetRepositoryMock
+			var userSvc service.UserServiceMock
+			var notifier third_party.NotifierMock
+			invitationRepo.On("FindByTokenHash", ctx, util.HashToken("token")).Return(tc.expectedInvitation, tc.expectedInvitationError)
+			invitationRepo.On("MarkAccepted", ctx, int64(1)).Return(nil)
+			userSvc.On("Create", util.WithTenant(ctx, 2), &request.CreateUserRequest{
+				Name:     "Widdy Arfiansyah",
+				Username: "wdyarfn",
+				Password: "12345678910",
+				Email:    "clerk@example.com",
+				Role:     model.RoleStaff,
+			}).Return(tc.expectedSvc, nil)
+
+			svc := NewAccountService(&userRepo, &invitationRepo, &passwordResetRepo, &repository.TransactorMock{}, &userSvc, &notifier, "http://localhost")
+			result, err := svc.Register(ctx, registerRequest)
+			if tc.expectedSvcError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+			}
+
+			assert.Equal(t, tc.expectedSvc, result)
+			if tc.expectedInvitationUpdated {
+				invitationRepo.AssertCalled(t, "MarkAccepted", ctx, int64(1))
+			} else {
+				invitationRepo.AssertNotCalled(t, "MarkAccepted", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAccountService_Register_InvitationIsConsumedOnce(t *testing.T) {
+	ctx := context.Background()
+	registerRequest := &request.RegisterUserRequest{
+		InvitationToken: "token",
+		Name:            "Widdy Arfiansyah",
+		Username:        "wdyarfn",
+		Password:        "12345678910",
+	}
+
+	var userRepo repository.UserRepositoryMock
+	var invitationRepo repository.InvitationRepositoryMock
+	var passwordResetRepo repository.PasswordResetRepositoryMock
+	var userSvc service.UserServiceMock
+	var notifier third_party.NotifierMock
+	// Both registrations read the invitation before either one consumed it
+	invitationRepo.On("FindByTokenHash", ctx, util.HashToken("token")).Return(&model.Invitation{
+		ID:        1,
+		TenantID:  2,
+		Email:     "clerk@example.com",
+		Role:      model.RoleAdmin,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+	invitationRepo.On("MarkAccepted", ctx, int64(1)).Return(nil).Once()
+	invitationRepo.On("MarkAccepted", ctx, int64(1)).Return(errors.New(response.ErrorInvalidToken))
+	userSvc.On("Create", util.WithTenant(ctx, 2), mock.Anything).Return(&response.UserResponse{ID: 1, Username: "wdyarfn", Role: model.RoleAdmin}, nil)
+
+	svc := NewAccountService(&userRepo, &invitationRepo, &passwordResetRepo, &repository.TransactorMock{}, &userSvc, &notifier, "http://localhost")
+	result, err := svc.Register(ctx, registerRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), result.ID)
+
+	result, err = svc.Register(ctx, registerRequest)
+	assert.Error(t, err)
+	assert.Equal(t, response.ErrorInvalidToken, err.Error())
+	assert.Nil(t, result)
+	userSvc.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestAccountService_ForgotPassword(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		expectedUser          *model.User
+		expectedUserError     error
+		expectedNotifications int
+	}{
+		{
+			name: "User with an email receives a reset link",
+			expectedUser: &model.User{
+				ID:       1,
+				Name:     "Widdy Arfiansyah",
+				Username: "wdyarfn",
+				Email:    util.ToPointerString("wdyarfn@example.com"),
+			},
+			expectedNotifications: 1,
+		},
+		{
+			name: "User without an email is silently ignored",
+			expectedUser: &model.User{
+				ID:       1,
+				Name:     "Widdy Arfiansyah",
+				Username: "wdyarfn",
+			},
+			expectedNotifications: 0,
+		},
+		{
+			name:                  "Unknown user is silently ignored",
+			expectedUserError:     errors.New(response.ErrorNotFound),
+			expectedNotifications: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var userRepo repository.UserRepositoryMock
+			var invitationRepo repository.InvitationRepositoryMock
+			var passwordResetRepo repository.PasswordResetRepositoryMock
+			var userSvc service.UserServiceMock
+			var notifier third_party.NotifierMock
+			userRepo.On("FindByUsername", ctx, "wdyarfn").Return(tc.expectedUser, tc.expectedUserError)
+			passwordResetRepo.On("Create", ctx, mock.Anything).Return(&model.PasswordReset{ID: 1, UserID: 1}, nil)
+			notifier.On("Send", ctx, mock.Anything).Return(nil)
+
+			svc := NewAccountService(&userRepo, &invitationRepo, &passwordResetRepo, &repository.TransactorMock{}, &userSvc, &notifier, "http://localhost")
+			err := svc.ForgotPassword(ctx, &request.ForgotPasswordRequest{Username: "wdyarfn"})
+			assert.Nil(t, err)
+			notifier.AssertNumberOfCalls(t, "Send", tc.expectedNotifications)
+		})
+	}
+}
+
+func TestAccountService_ResetPassword(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		expectedPasswordReset *model.PasswordReset
+		expectedSvcError      error
+	}{
+		{
+			name: "Reset password with a valid token",
+			expectedPasswordReset: &model.PasswordReset{
+				ID:        1,
+				UserID:    1,
+				ExpiresAt: time.Now().Add(time.Hour),
+			},
+		},
+		{
+			name: "Reset password with a used token",
+			expectedPasswordReset: &model.PasswordReset{
+				ID:        1,
+				UserID:    1,
+				ExpiresAt: time.Now().Add(time.Hour),
+				UsedAt:    &time.Time{},
+			},
+			expectedSvcError: errors.New(response.ErrorInvalidToken),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var userRepo repository.UserRepositoryMock
+			var invitationRepo repository.InvitationRepositoryMock
+			var passwordResetRepo repository.PasswordResetRepositoryMock
+			var userSvc service.UserServiceMock
+			var notifier third_party.NotifierMock
+			passwordResetRepo.On("FindByTokenHash", ctx, util.HashToken("token")).Return(tc.expectedPasswordReset, nil)
+			passwordResetRepo.On("MarkUsed", ctx, int64(1)).Return(nil)
+			userRepo.On("FindByID", ctx, int64(1)).Return(&model.User{ID: 1, TenantID: 2, Name: "Widdy Arfiansyah"}, nil)
+			userSvc.On("Update", util.WithTenant(ctx, 2), &request.UpdateUserRequest{ID: 1, Name: "Widdy Arfiansyah", Password: "12345678910"}).Return(&response.UserResponse{ID: 1}, nil)
+
+			svc := NewAccountService(&userRepo, &invitationRepo, &passwordResetRepo, &repository.TransactorMock{}, &userSvc, &notifier, "http://localhost")
+			err := svc.ResetPassword(ctx, &request.ResetPasswordRequest{Token: "token", Password: "12345678910"})
+			if tc.expectedSvcError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+				passwordResetRepo.AssertNotCalled(t, "MarkUsed", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Nil(t, err)
+			passwordResetRepo.AssertCalled(t, "MarkUsed", ctx, int64(1))
+		})
+	}
+}
+
+func TestAccountService_ResetPassword_TokenIsConsumedOnce(t *testing.T) {
+	ctx := context.Background()
+	resetPasswordRequest := &request.ResetPasswordRequest{Token: "token", Password: "12345678910"}
+
+	var userRepo repository.UserRepositoryMock
+	var invitationRepo repository.InvitationRepositoryMock
+	var passwordResetRepo repository.PasswordResetRepositoryMock
+	var userSvc service.UserServiceMock
+	var notifier third_party.NotifierMock
+	// Both resets read the token before either one consumed it
+	passwordResetRepo.On("FindByTokenHash", ctx, util.HashToken("token")).Return(&model.PasswordReset{
+		ID:        1,
+		UserID:    1,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+	passwordResetRepo.On("MarkUsed", ctx, int64(1)).Return(nil).Once()
+	passwordResetRepo.On("MarkUsed", ctx, int64(1)).Return(errors.New(response.ErrorInvalidToken))
+	userRepo.On("FindByID", ctx, int64(1)).Return(&model.User{ID: 1, TenantID: 2, Name: "Widdy Arfiansyah"}, nil)
+	userSvc.On("Update", util.WithTenant(ctx, 2), mock.Anything).Return(&response.UserResponse{ID: 1}, nil)
+
+	svc := NewAccountService(&userRepo, &invitationRepo, &passwordResetRepo, &repository.TransactorMock{}, &userSvc, &notifier, "http://localhost")
+	err := svc.ResetPassword(ctx, resetPasswordRequest)
+	assert.Nil(t, err)
+
+	err = svc.ResetPassword(ctx, resetPasswordRequest)
+	assert.Error(t, err)
+	assert.Equal(t, response.ErrorInvalidToken, err.Error())
+	userSvc.AssertNumberOfCalls(t, "Update", 1)
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	search "inventory-management/backend/internal/third_party/search"
+	"inventory-management/backend/util"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const analyticsDefaultLimit = 10
+
+const analyticsDefaultUnitMassAcronym = "kg"
+
+type AnalyticsService struct {
+	SearchEngine search.SearchEngineContract
+	Cache        *util.Cache
+}
+
+// NewAnalyticsService aggregates the documents of whichever search engine is configured. The
+// results of a query are cached for cacheTTL, so a busy dashboard does not aggregate on every
+// refresh.
+func NewAnalyticsService(searchEngine search.SearchEngineContract, cacheTTL time.Duration) AnalyticsServiceContract {
+	return &AnalyticsService{
+		SearchEngine: searchEngine,
+		Cache:        util.NewCache(cacheTTL),
+	}
+}
+
+func (service *AnalyticsService) Volume(ctx context.Context, request *request.AnalyticsRequest) ([]*response.VolumeResponse, error) {
+	result, err := service.cached(ctx, "volume", request, func(unit string) (interface{}, error) {
+		interval := request.Interval
+		if interval == "" {
+			interval = search.IntervalDay
+		}
+
+		buckets, err := service.SearchEngine.Aggregate(ctx, model.SearchIndexTransactions, &search.Aggregation{
+			Filters:   analyticsDateFilters(request),
+			DateField: "created_at",
+			Interval:  interval,
+			GroupBy:   []string{"type", "unit_mass_acronym"},
+			Sum:       "quantity",
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		volumes := []*response.VolumeResponse{}
+		for _, bucket := range buckets {
+			if bucket.Keys[0] != "IN" && bucket.Keys[0] != "OUT" {
+				continue
+			}
+
+			quantity, err := util.CalculateUnitOfMass(unit, bucket.Keys[1], bucket.Sum)
+			if err != nil {
+				return nil, err
+			}
+
+			// Buckets come ordered by date
+			if len(volumes) == 0 || volumes[len(volumes)-1].Date != bucket.Date {
+				volumes = append(volumes, &response.VolumeResponse{Date: bucket.Date, UnitMassAcronym: unit})
+			}
+			volume := volumes[len(volumes)-1]
+			if bucket.Keys[0] == "IN" {
+				volume.In += quantity
+			} else {
+				volume.Out += quantity
+			}
+		}
+
+		return volumes, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]*response.VolumeResponse), nil
+}
+
+func (service *AnalyticsService) TopCustomers(ctx context.Context, request *request.AnalyticsRequest) ([]*response.PartnerVolumeResponse, error) {
+	return service.topPartners(ctx, "top-customers", request, "OUT", "customer_code", "customer_name")
+}
+
+func (service *AnalyticsService) TopSuppliers(ctx context.Context, request *request.AnalyticsRequest) ([]*response.PartnerVolumeResponse, error) {
+	return service.topPartners(ctx, "top-suppliers", request, "IN", "supplier_code", "supplier_name")
+}
+
+// StockByType sums the current stock of the product qualities of every type, so the date range
+// does not apply to it.
+func (service *AnalyticsService) StockByType(ctx context.Context, request *request.AnalyticsRequest) ([]*response.StockByTypeResponse, error) {
+	result, err := service.cached(ctx, "stock-by-type", request, func(unit string) (interface{}, error) {
+		buckets, err := service.SearchEngine.Aggregate(ctx, model.SearchIndexProductQualities, &search.Aggregation{
+			GroupBy: []string{"type", "unit_mass_acronym"},
+			Sum:     "quantity",
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		stocks := []*response.StockByTypeResponse{}
+		stocksByType := map[string]*response.StockByTypeResponse{}
+		for _, bucket := range buckets {
+			quantity, err := util.CalculateUnitOfMass(unit, bucket.Keys[1], bucket.Sum)
+			if err != nil {
+				return nil, err
+			}
+
+			stock, ok := stocksByType[bucket.Keys[0]]
+			if !ok {
+				stock = &response.StockByTypeResponse{Type: bucket.Keys[0], UnitMassAcronym: unit}
+				stocksByType[bucket.Keys[0]] = stock
+				stocks = append(stocks, stock)
+			}
+			stock.Quantity += quantity
+			stock.ProductQualities += bucket.Count
+		}
+
+		sort.SliceStable(stocks, func(i, j int) bool {
+			return stocks[i].Quantity > stocks[j].Quantity
+		})
+
+		return stocks, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]*response.StockByTypeResponse), nil
+}
+
+// Transfers returns the largest volumes moved from one quality of a product to another.
+func (service *AnalyticsService) Transfers(ctx context.Context, request *request.AnalyticsRequest) ([]*response.TransferVolumeResponse, error) {
+	result, err := service.cached(ctx, "transfers", request, func(unit string) (interface{}, error) {
+		buckets, err := service.SearchEngine.Aggregate(ctx, model.SearchIndexTransactions, &search.Aggregation{
+			Filters: append(analyticsDateFilters(request), search.TermFilter("type", "TRANSFER")),
+			GroupBy: []string{"product_quality_id", "product_quality_id_transferred", "unit_mass_acronym"},
+			Labels:  []string{"product_code", "product_name", "quality", "quality_transferred"},
+			Sum:     "quantity",
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		transfers := []*response.TransferVolumeResponse{}
+		transfersByQualities := map[string]*response.TransferVolumeResponse{}
+		for _, bucket := range buckets {
+			quantity, err := util.CalculateUnitOfMass(unit, bucket.Keys[2], bucket.Sum)
+			if err != nil {
+				return nil, err
+			}
+
+			key := bucket.Keys[0] + "-" + bucket.Keys[1]
+			transfer, ok := transfersByQualities[key]
+			if !ok {
+				productQualityID, _ := strconv.ParseInt(bucket.Keys[0], 10, 64)
+				productQualityIDTransferred, _ := strconv.ParseInt(bucket.Keys[1], 10, 64)
+				transfer = &response.TransferVolumeResponse{
+					ProductCode:                 bucket.Labels[0],
+					ProductName:                 bucket.Labels[1],
+					ProductQualityID:            productQualityID,
+					Quality:                     bucket.Labels[2],
+					ProductQualityIDTransferred: productQualityIDTransferred,
+					QualityTransferred:          bucket.Labels[3],
+					UnitMassAcronym:             unit,
+				}
+				transfersByQualities[key] = transfer
+				transfers = append(transfers, transfer)
+			}
+			transfer.Quantity += quantity
+			transfer.Transactions += bucket.Count
+		}
+
+		sort.SliceStable(transfers, func(i, j int) bool {
+			return transfers[i].Quantity > transfers[j].Quantity
+		})
+
+		return transfers[:analyticsLimit(request, len(transfers))], nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]*response.TransferVolumeResponse), nil
+}
+
+// topPartners ranks the suppliers or customers by the quantity of their transactions. Quantities
+// are grouped by unit as well and converted before ranking, so the engine cannot cut the groups.
+func (service *AnalyticsService) topPartners(ctx context.Context, name string, request *request.AnalyticsRequest, transactionType string, codeField string, nameField string) ([]*response.PartnerVolumeResponse, error) {
+	result, err := service.cached(ctx, name, request, func(unit string) (interface{}, error) {
+		buckets, err := service.SearchEngine.Aggregate(ctx, model.SearchIndexTransactions, &search.Aggregation{
+			Filters: append(analyticsDateFilters(request), search.TermFilter("type", transactionType)),
+			GroupBy: []string{codeField, "unit_mass_acronym"},
+			Labels:  []string{nameField},
+			Sum:     "quantity",
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		partners := []*response.PartnerVolumeResponse{}
+		partnersByCode := map[string]*response.PartnerVolumeResponse{}
+		for _, bucket := range buckets {
+			quantity, err := util.CalculateUnitOfMass(unit, bucket.Keys[1], bucket.Sum)
+			if err != nil {
+				return nil, err
+			}
+
+			partner, ok := partnersByCode[bucket.Keys[0]]
+			if !ok {
+				partner = &response.PartnerVolumeResponse{Code: bucket.Keys[0], Name: bucket.Labels[0], UnitMassAcronym: unit}
+				partnersByCode[bucket.Keys[0]] = partner
+				partners = append(partners, partner)
+			}
+			partner.Quantity += quantity
+			partner.Transactions += bucket.Count
+		}
+
+		sort.SliceStable(partners, func(i, j int) bool {
+			return partners[i].Quantity > partners[j].Quantity
+		})
+
+		return partners[:analyticsLimit(request, len(partners))], nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]*response.PartnerVolumeResponse), nil
+}
+
+// cached returns the result of the aggregation for the tenant of the context and the request,
+// running it only when it is not cached yet. Errors are never cached.
+func (service *AnalyticsService) cached(ctx context.Context, name string, request *request.AnalyticsRequest, aggregate func(unit string) (interface{}, error)) (interface{}, error) {
+	tenantID, _ := util.TenantFromContext(ctx)
+	key := fmt.Sprintf("%d:%s:%+v", tenantID, name, *request)
+	if result, ok := service.Cache.Get(key); ok {
+		return result, nil
+	}
+
+	unit := request.UnitMassAcronym
+	if unit == "" {
+		unit = analyticsDefaultUnitMassAcronym
+	}
+
+	result, err := aggregate(unit)
+	if err != nil {
+		return nil, err
+	}
+
+	service.Cache.Set(key, result)
+	return result, nil
+}
+
+func analyticsDateFilters(request *request.AnalyticsRequest) []*search.Filter {
+	if request.From == "" && request.To == "" {
+		return []*search.Filter{}
+	}
+
+	return []*search.Filter{search.DateRangeFilter("created_at", request.From, request.To)}
+}
+
+func analyticsLimit(request *request.AnalyticsRequest, length int) int {
+	limit := request.Limit
+	if limit == 0 {
+		limit = analyticsDefaultLimit
+	}
+	if limit > length {
+		return length
+	}
+
+	return limit
+}
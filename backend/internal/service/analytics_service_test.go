@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	search "inventory-management/backend/internal/third_party/search"
+	"inventory-management/backend/util"
+	"testing"
+	"time"
+)
+
+func TestAnalyticsService_Volume(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		request                *request.AnalyticsRequest
+		expectedAggregation    *search.Aggregation
+		expectedEngineBuckets  []*search.AggregationBucket
+		expectedEngineError    error
+		expectedEngineNumCalls int
+		expectedSvc            []*response.VolumeResponse
+		expectedSvcError       error
+	}{
+		{
+			name:    "Days in kilograms, leaving transfers out",
+			request: &request.AnalyticsRequest{From: "2021-01-04", To: "2021-01-06"},
+			expectedAggregation: &search.Aggregation{
+				Filters:   []*search.Filter{search.DateRangeFilter("created_at", "2021-01-04", "2021-01-06")},
+				DateField: "created_at",
+				Interval:  search.IntervalDay,
+				GroupBy:   []string{"type", "unit_mass_acronym"},
+				Sum:       "quantity",
+			},
+			expectedEngineBuckets: []*search.AggregationBucket{
+				{Date: "2021-01-04", Keys: []string{"IN", "kg"}, Count: 1, Sum: 10},
+				{Date: "2021-01-04", Keys: []string{"OUT", "g"}, Count: 2, Sum: 3000},
+				{Date: "2021-01-04", Keys: []string{"OUT", "kg"}, Count: 1, Sum: 1},
+				{Date: "2021-01-06", Keys: []string{"TRANSFER", "kg"}, Count: 1, Sum: 2},
+				{Date: "2021-01-06", Keys: []string{"OUT", "kg"}, Count: 1, Sum: 4},
+			},
+			expectedEngineNumCalls: 1,
+			expectedSvc: []*response.VolumeResponse{
+				{Date: "2021-01-04", In: 10, Out: 4, UnitMassAcronym: "kg"},
+				{Date: "2021-01-06", Out: 4, UnitMassAcronym: "kg"},
+			},
+		},
+		{
+			name:    "Months in grams",
+			request: &request.AnalyticsRequest{Interval: search.IntervalMonth, UnitMassAcronym: "g"},
+			expectedAggregation: &search.Aggregation{
+				Filters:   []*search.Filter{},
+				DateField: "created_at",
+				Interval:  search.IntervalMonth,
+				GroupBy:   []string{"type", "unit_mass_acronym"},
+				Sum:       "quantity",
+			},
+			expectedEngineBuckets: []*search.AggregationBucket{
+				{Date: "2021-01-01", Keys: []string{"IN", "kg"}, Count: 1, Sum: 1.5},
+			},
+			expectedEngineNumCalls: 1,
+			expectedSvc: []*response.VolumeResponse{
+				{Date: "2021-01-01", In: 1500, UnitMassAcronym: "g"},
+			},
+		},
+		{
+			name:    "Errors are not cached",
+			request: &request.AnalyticsRequest{},
+			expectedAggregation: &search.Aggregation{
+				Filters:   []*search.Filter{},
+				DateField: "created_at",
+				Interval:  search.IntervalDay,
+				GroupBy:   []string{"type", "unit_mass_acronym"},
+				Sum:       "quantity",
+			},
+			expectedEngineError:    errors.New("connection refused"),
+			expectedEngineNumCalls: 2,
+			expectedSvcError:       errors.New("connection refused"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var engine search.SearchEngineMock
+			engine.On("Aggregate", ctx, model.SearchIndexTransactions, tc.expectedAggregation).Return(tc.expectedEngineBuckets, tc.expectedEngineError)
+			svc := NewAnalyticsService(&engine, time.Minute)
+
+			// The second call is answered from the cache
+			for i := 0; i < 2; i++ {
+				result, err := svc.Volume(ctx, tc.request)
+				if tc.expectedSvcError != nil {
+					assert.Error(t, err)
+					assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+				}
+
+				assert.Equal(t, tc.expectedSvc, result)
+			}
+
+			engine.AssertNumberOfCalls(t, "Aggregate", tc.expectedEngineNumCalls)
+		})
+	}
+}
+
+func TestAnalyticsService_TopCustomers(t *testing.T) {
+	buckets := []*search.AggregationBucket{
+		{Keys: []string{"CUSTOMER02", "kg"}, Labels: []string{"Angga"}, Count: 1, Sum: 4},
+		{Keys: []string{"CUSTOMER01", "kg"}, Labels: []string{"Widdy"}, Count: 2, Sum: 3},
+		{Keys: []string{"CUSTOMER03", "kg"}, Labels: []string{"Budi"}, Count: 1, Sum: 1},
+		{Keys: []string{"CUSTOMER01", "g"}, Labels: []string{"Widdy"}, Count: 1, Sum: 2000},
+	}
+
+	testCases := []struct {
+		name        string
+		request     *request.AnalyticsRequest
+		ctx         context.Context
+		expectedSvc []*response.PartnerVolumeResponse
+	}{
+		{
+			name:    "Quantities of every unit are ranked together",
+			request: &request.AnalyticsRequest{From: "2021-01-01", Limit: 2},
+			ctx:     context.Background(),
+			expectedSvc: []*response.PartnerVolumeResponse{
+				{Code: "CUSTOMER01", Name: "Widdy", Quantity: 5, UnitMassAcronym: "kg", Transactions: 3},
+				{Code: "CUSTOMER02", Name: "Angga", Quantity: 4, UnitMassAcronym: "kg", Transactions: 1},
+			},
+		},
+		{
+			name:    "Another tenant is not answered from the cache",
+			request: &request.AnalyticsRequest{From: "2021-01-01", Limit: 2},
+			ctx:     util.WithTenant(context.Background(), 2),
+			expectedSvc: []*response.PartnerVolumeResponse{
+				{Code: "CUSTOMER01", Name: "Widdy", Quantity: 5, UnitMassAcronym: "kg", Transactions: 3},
+				{Code: "CUSTOMER02", Name: "Angga", Quantity: 4, UnitMassAcronym: "kg", Transactions: 1},
+			},
+		},
+	}
+
+	var engine search.SearchEngineMock
+	svc := NewAnalyticsService(&engine, time.Minute)
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			engine.On("Aggregate", tc.ctx, model.SearchIndexTransactions, &search.Aggregation{
+				Filters: []*search.Filter{search.DateRangeFilter("created_at", "2021-01-01", ""), search.TermFilter("type", "OUT")},
+				GroupBy: []string{"customer_code", "unit_mass_acronym"},
+				Labels:  []string{"customer_name"},
+				Sum:     "quantity",
+			}).Return(buckets, nil)
+
+			result, err := svc.TopCustomers(tc.ctx, tc.request)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedSvc, result)
+			engine.AssertNumberOfCalls(t, "Aggregate", i+1)
+		})
+	}
+}
+
+func TestAnalyticsService_StockByType(t *testing.T) {
+	ctx := context.Background()
+
+	var engine search.SearchEngineMock
+	engine.On("Aggregate", ctx, model.SearchIndexProductQualities, &search.Aggregation{
+		GroupBy: []string{"type", "unit_mass_acronym"},
+		Sum:     "quantity",
+	}).Return([]*search.AggregationBucket{
+		{Keys: []string{"fresh", "kg"}, Count: 2, Sum: 20},
+		{Keys: []string{"frozen", "kg"}, Count: 1, Sum: 25},
+		{Keys: []string{"fresh", "ton"}, Count: 1, Sum: 0.01},
+	}, nil)
+	svc := NewAnalyticsService(&engine, 0)
+
+	result, err := svc.StockByType(ctx, &request.AnalyticsRequest{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*response.StockByTypeResponse{
+		{Type: "fresh", Quantity: 30, UnitMassAcronym: "kg", ProductQualities: 3},
+		{Type: "frozen", Quantity: 25, UnitMassAcronym: "kg", ProductQualities: 1},
+	}, result)
+}
+
+func TestAnalyticsService_Transfers(t *testing.T) {
+	ctx := context.Background()
+
+	var engine search.SearchEngineMock
+	engine.On("Aggregate", ctx, model.SearchIndexTransactions, &search.Aggregation{
+		Filters: []*search.Filter{search.TermFilter("type", "TRANSFER")},
+		GroupBy: []string{"product_quality_id", "product_quality_id_transferred", "unit_mass_acronym"},
+		Labels:  []string{"product_code", "product_name", "quality", "quality_transferred"},
+		Sum:     "quantity",
+	}).Return([]*search.AggregationBucket{
+		{Keys: []string{"1", "2", "kg"}, Labels: []string{"ABCDEFGHIJ", "Kangkung", "Fresh", "Withered"}, Count: 2, Sum: 3},
+		{Keys: []string{"3", "4", "g"}, Labels: []string{"KLMNOPQRST", "Bayam", "Fresh", "Withered"}, Count: 1, Sum: 500},
+	}, nil)
+	svc := NewAnalyticsService(&engine, time.Minute)
+
+	result, err := svc.Transfers(ctx, &request.AnalyticsRequest{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*response.TransferVolumeResponse{
+		{ProductCode: "ABCDEFGHIJ", ProductName: "Kangkung", ProductQualityID: 1, Quality: "Fresh", ProductQualityIDTransferred: 2, QualityTransferred: "Withered", Quantity: 3, UnitMassAcronym: "kg", Transactions: 2},
+		{ProductCode: "KLMNOPQRST", ProductName: "Bayam", ProductQualityID: 3, Quality: "Fresh", ProductQualityIDTransferred: 4, QualityTransferred: "Withered", Quantity: 0.5, UnitMassAcronym: "kg", Transactions: 1},
+	}, result)
+}
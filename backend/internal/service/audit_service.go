@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/internal/repository"
+	"inventory-management/backend/util"
+)
+
+type AuditService struct {
+	AuditLogRepository repository.AuditLogRepositoryContract
+}
+
+func NewAuditService(auditLogRepository repository.AuditLogRepositoryContract) AuditServiceContract {
+	return &AuditService{
+		AuditLogRepository: auditLogRepository,
+	}
+}
+
+func (service *AuditService) FindAll(ctx context.Context, filter *request.AuditLogFilterRequest, offset int, limit int) ([]*response.AuditLogResponse, error) {
+	auditLogs, err := service.AuditLogRepository.FindAll(ctx, filter, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var auditLogResponses []*response.AuditLogResponse
+	for _, auditLog := range auditLogs {
+		auditLogResponses = append(auditLogResponses, auditLog.ToResponse())
+	}
+
+	return auditLogResponses, nil
+}
+
+func (service *AuditService) CountAll(ctx context.Context, filter *request.AuditLogFilterRequest) (int64, error) {
+	count, err := service.AuditLogRepository.CountAll(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Record stores who performed an action on an entity together with the field level
+// difference between the before and after snapshots. Pass nil as before for creates
+// and nil as after for deletes. Call it within the Transactor transaction making the
+// change, so the entry is stored if and only if the change is.
+func (service *AuditService) Record(ctx context.Context, entity string, entityID string, action string, before interface{}, after interface{}) error {
+	changes, err := util.DiffJSON(before, after)
+	if err != nil {
+		return err
+	}
+
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	var auditLogRequest model.AuditLog
+	auditLogRequest.Actor = util.ActorFromContext(ctx)
+	if auditLogRequest.Actor == "" {
+		auditLogRequest.Actor = model.AuditActorSystem
+	}
+	if requestID := util.RequestIDFromContext(ctx); requestID != "" {
+		auditLogRequest.RequestID = &requestID
+	}
+	auditLogRequest.Entity = entity
+	auditLogRequest.EntityID = entityID
+	auditLogRequest.Action = action
+	auditLogRequest.Changes = string(changesJSON)
+
+	_, err = service.AuditLogRepository.Create(ctx, &auditLogRequest)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
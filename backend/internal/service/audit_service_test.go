@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/request"
+	response "inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	repository "inventory-management/backend/internal/repository/mock"
+	"inventory-management/backend/util"
+	"testing"
+)
+
+func TestAuditService_FindAll(t *testing.T) {
+	filter := &request.AuditLogFilterRequest{Entity: model.AuditEntityProduct}
+
+	testCases := []struct {
+		name                      string
+		expectedAuditLogRepo      []*model.AuditLog
+		expectedSvc               []*response.AuditLogResponse
+		expectedSvcError          error
+		expectedAuditLogRepoError error
+	}{
+		{
+			name: "Audit logs exists",
+			expectedAuditLogRepo: []*model.AuditLog{
+				{
+					ID:       1,
+					Actor:    "wdyarfn",
+					Entity:   model.AuditEntityProduct,
+					EntityID: "WDWDARFSYH",
+					Action:   model.AuditActionUpdate,
+					Changes:  `{"name":{"before":"Beras","after":"Gula"}}`,
+				},
+			},
+			expectedSvc: []*response.AuditLogResponse{
+				{
+					ID:        1,
+					Actor:     "wdyarfn",
+					Entity:    model.AuditEntityProduct,
+					EntityID:  "WDWDARFSYH",
+					Action:    model.AuditActionUpdate,
+					Changes:   json.RawMessage(`{"name":{"before":"Beras","after":"Gula"}}`),
+					CreatedAt: "0001-01-01 07:00:00 +0700 +07",
+				},
+			},
+		},
+		{
+			name:                      "Repository getting an error",
+			expectedSvcError:          errors.New("getting an error"),
+			expectedAuditLogRepoError: errors.New("getting an error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var repo repository.AuditLogRepositoryMock
+			repo.On("FindAll", ctx, filter, 0, 10).Return(tc.expectedAuditLogRepo, tc.expectedAuditLogRepoError)
+
+			svc := NewAuditService(&repo)
+			result, err := svc.FindAll(ctx, filter, 0, 10)
+			if tc.expectedSvcError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+			}
+
+			assert.Equal(t, tc.expectedSvc, result)
+		})
+	}
+}
+
+func TestAuditService_Record(t *testing.T) {
+	testCases := []struct {
+		name              string
+		ctx               context.Context
+		action            string
+		before            interface{}
+		after             interface{}
+		expectedActor     string
+		expectedRequestID *string
+		expectedChanges   string
+	}{
+		{
+			name:              "Update by an authenticated user",
+			ctx:               util.WithRequestID(util.WithActor(context.Background(), "wdyarfn"), "request-id"),
+			action:            model.AuditActionUpdate,
+			before:            &response.CustomerResponse{Code: "WDWDARFSYH", Name: "Widdy", Version: 1},
+			after:             &response.CustomerResponse{Code: "WDWDARFSYH", Name: "Widdy Arfiansyah", Version: 2},
+			expectedActor:     "wdyarfn",
+			expectedRequestID: util.ToPointerString("request-id"),
+			expectedChanges:   `{"name":{"before":"Widdy","after":"Widdy Arfiansyah"},"version":{"before":1,"after":2}}`,
+		},
+		{
+			name:            "Delete without an authenticated user",
+			ctx:             context.Background(),
+			action:          model.AuditActionDelete,
+			before:          &response.CustomerResponse{Code: "WDWDARFSYH", Name: "Widdy", Version: 1},
+			after:           nil,
+			expectedActor:   model.AuditActorSystem,
+			expectedChanges: `{"code":{"before":"WDWDARFSYH","after":null},"id":{"before":0,"after":null},"name":{"before":"Widdy","after":null},"version":{"before":1,"after":null}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var repo repository.AuditLogRepositoryMock
+			var auditLog *model.AuditLog
+			repo.On("Create", tc.ctx, mock.Anything).Run(func(args mock.Arguments) {
+				auditLog = args.Get(1).(*model.AuditLog)
+			}).Return(&model.AuditLog{ID: 1}, nil)
+
+			svc := NewAuditService(&repo)
+			err := svc.Record(tc.ctx, model.AuditEntityCustomer, "WDWDARFSYH", tc.action, tc.before, tc.after)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.expectedActor, auditLog.Actor)
+			assert.Equal(t, tc.expectedRequestID, auditLog.RequestID)
+			assert.Equal(t, model.AuditEntityCustomer, auditLog.Entity)
+			assert.Equal(t, tc.action, auditLog.Action)
+			assert.JSONEq(t, tc.expectedChanges, auditLog.Changes)
+		})
+	}
+}
@@ -6,34 +6,58 @@ import (
 	"inventory-management/backend/internal/http/response"
 	"inventory-management/backend/internal/model"
 	"inventory-management/backend/internal/repository"
+	"inventory-management/backend/util"
 )
 
 type CustomerService struct {
 	CustomerRepository repository.CustomerRepositoryContract
+	Transactor         repository.TransactorContract
+	AuditService       AuditServiceContract
 }
 
-func NewCustomerService(customerRepository repository.CustomerRepositoryContract) CustomerServiceContract {
+func NewCustomerService(customerRepository repository.CustomerRepositoryContract, transactor repository.TransactorContract, auditService AuditServiceContract) CustomerServiceContract {
 	return &CustomerService{
 		CustomerRepository: customerRepository,
+		Transactor:         transactor,
+		AuditService:       auditService,
 	}
 }
 
-func (service *CustomerService) FindAll(ctx context.Context, offset int, limit int) ([]*response.CustomerResponse, error) {
-	customers, err := service.CustomerRepository.FindAll(ctx, offset, limit)
+func (service *CustomerService) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*response.CustomerResponse, error) {
+	customers, err := service.CustomerRepository.FindAll(ctx, query, offset, limit)
 	if err != nil {
 		return nil, err
 	}
 
+	return toCustomerResponses(customers, query), nil
+}
+
+func (service *CustomerService) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*response.CustomerResponse, *util.CursorPage, error) {
+	customers, page, err := service.CustomerRepository.FindAllByCursor(ctx, query, cursor, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return toCustomerResponses(customers, query), page, nil
+}
+
+func toCustomerResponses(customers []*model.Customer, query *util.ListQuery) []*response.CustomerResponse {
 	var customerResponses []*response.CustomerResponse
 	for _, customer := range customers {
+		// Included associations are the only ones loaded
+		if query != nil && len(query.Include) > 0 {
+			customerResponses = append(customerResponses, customer.ToResponseWithAssociations())
+			continue
+		}
+
 		customerResponses = append(customerResponses, customer.ToResponse())
 	}
 
-	return customerResponses, nil
+	return customerResponses
 }
 
-func (service *CustomerService) CountAll(ctx context.Context) (int64, error) {
-	count, err := service.CustomerRepository.CountAll(ctx)
+func (service *CustomerService) CountAll(ctx context.Context, query *util.ListQuery) (int64, error) {
+	count, err := service.CustomerRepository.CountAll(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -54,7 +78,16 @@ func (service *CustomerService) Create(ctx context.Context, request *request.Cre
 	var customerRequest model.Customer
 	customerRequest.Name = request.Name
 
-	customer, err := service.CustomerRepository.Create(ctx, &customerRequest)
+	var customer *model.Customer
+	err := service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		customer, err = service.CustomerRepository.Create(ctx, &customerRequest)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityCustomer, customer.Code, model.AuditActionCreate, nil, customer.ToResponse())
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -68,8 +101,24 @@ func (service *CustomerService) Update(ctx context.Context, request *request.Upd
 		return nil, err
 	}
 
+	err = checkVersion(ctx, checkCustomer.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	before := checkCustomer.ToResponse()
+
 	checkCustomer.Name = request.Name
-	customer, err := service.CustomerRepository.Update(ctx, checkCustomer)
+	var customer *model.Customer
+	err = service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		customer, err = service.CustomerRepository.Update(ctx, checkCustomer)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityCustomer, customer.Code, model.AuditActionUpdate, before, customer.ToResponse())
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -83,10 +132,52 @@ func (service *CustomerService) Delete(ctx context.Context, code string) error {
 		return err
 	}
 
-	err = service.CustomerRepository.Delete(ctx, checkCustomer.Code)
+	err = checkVersion(ctx, checkCustomer.Version)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		err := service.CustomerRepository.Delete(ctx, checkCustomer.Code)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityCustomer, checkCustomer.Code, model.AuditActionDelete, checkCustomer.ToResponse(), nil)
+	})
+}
+
+func (service *CustomerService) Restore(ctx context.Context, code string) (*response.CustomerResponse, error) {
+	var customer *model.Customer
+	err := service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		customer, err = service.CustomerRepository.Restore(ctx, code)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityCustomer, customer.Code, model.AuditActionRestore, nil, customer.ToResponse())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return customer.ToResponse(), nil
+}
+
+// Purge deletes the customer for good, which is refused while transactions reference it.
+func (service *CustomerService) Purge(ctx context.Context, code string) error {
+	checkCustomer, err := service.CustomerRepository.FindByCode(util.WithDeleted(ctx), code)
+	if err != nil {
+		return err
+	}
+
+	return service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		err := service.CustomerRepository.Purge(ctx, checkCustomer.Code)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityCustomer, checkCustomer.Code, model.AuditActionPurge, checkCustomer.ToResponse(), nil)
+	})
 }
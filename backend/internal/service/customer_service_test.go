@@ -9,6 +9,8 @@ import (
 	response "inventory-management/backend/internal/http/response"
 	"inventory-management/backend/internal/model"
 	repository "inventory-management/backend/internal/repository/mock"
+	service "inventory-management/backend/internal/service/mock"
+	"inventory-management/backend/util"
 	"testing"
 )
 
@@ -67,9 +69,10 @@ func TestCustomerService_FindAll(t *testing.T) {
 			ctx := context.Background()
 
 			var repo repository.CustomerRepositoryMock
-			repo.On("FindAll", ctx, 0, 10).Return(tc.expectedCustomerRepoFindAll, tc.expectedCustomerRepoFindAllError)
-			svc := NewCustomerService(&repo)
-			result, err := svc.FindAll(ctx, 0, 10)
+			repo.On("FindAll", ctx, (*util.ListQuery)(nil), 0, 10).Return(tc.expectedCustomerRepoFindAll, tc.expectedCustomerRepoFindAllError)
+			var audit service.AuditServiceMock
+			svc := NewCustomerService(&repo, &repository.TransactorMock{}, &audit)
+			result, err := svc.FindAll(ctx, nil, 0, 10)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
@@ -124,7 +127,8 @@ func TestCustomerService_FindByCode(t *testing.T) {
 
 			var repo repository.CustomerRepositoryMock
 			repo.On("FindByCodeWithAssociations", ctx, tc.request).Return(tc.expectedCustomerRepoFindByCode, tc.expectedCustomerRepoFindByCodeError)
-			svc := NewCustomerService(&repo)
+			var audit service.AuditServiceMock
+			svc := NewCustomerService(&repo, &repository.TransactorMock{}, &audit)
 			result, err := svc.FindByCode(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -174,7 +178,9 @@ func TestCustomerService_Create(t *testing.T) {
 
 			var repo repository.CustomerRepositoryMock
 			repo.On("Create", ctx, mock.Anything).Return(tc.expectedCustomerRepoCreate, tc.expectedCustomerRepoCreateError)
-			svc := NewCustomerService(&repo)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			svc := NewCustomerService(&repo, &repository.TransactorMock{}, &audit)
 			result, err := svc.Create(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -249,7 +255,9 @@ func TestCustomerService_Update(t *testing.T) {
 			var repo repository.CustomerRepositoryMock
 			repo.On("FindByCode", ctx, tc.requestCustomerRepoFindByCode).Return(tc.expectedCustomerRepoFindByCode, tc.expectedCustomerRepoFindByCodeError)
 			repo.On("Update", ctx, mock.Anything).Return(tc.expectedCustomerRepoUpdate, tc.expectedCustomerRepoUpdateError)
-			svc := NewCustomerService(&repo)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			svc := NewCustomerService(&repo, &repository.TransactorMock{}, &audit)
 			result, err := svc.Update(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -304,7 +312,9 @@ func TestCustomerService_Delete(t *testing.T) {
 			var repo repository.CustomerRepositoryMock
 			repo.On("FindByCode", ctx, tc.request).Return(tc.expectedCustomerRepoFindByCode, tc.expectedCustomerRepoFindByCodeError)
 			repo.On("Delete", ctx, tc.request).Return(tc.expectedCustomerRepoDeleteError)
-			svc := NewCustomerService(&repo)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			svc := NewCustomerService(&repo, &repository.TransactorMock{}, &audit)
 			err := svc.Delete(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
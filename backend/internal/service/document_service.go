@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/internal/repository"
+	pdf "inventory-management/backend/internal/third_party/pdf"
+	"strconv"
+	"time"
+)
+
+// stockReportBatchSize is how many product qualities the stock report reads at once
+const stockReportBatchSize = 500
+
+// DocumentService renders the printable documents of the inventory as PDF, issued under the
+// branding of the tenant. The documents of a transaction carry its code as a barcode, so they
+// can be scanned back to it.
+type DocumentService struct {
+	TransactionRepository    repository.TransactionRepositoryContract
+	ProductQualityRepository repository.ProductQualityRepositoryContract
+	SettingRepository        repository.SettingRepositoryContract
+	TenantRepository         repository.TenantRepositoryContract
+	Renderer                 pdf.RendererContract
+}
+
+func NewDocumentService(transactionRepository repository.TransactionRepositoryContract, productQualityRepository repository.ProductQualityRepositoryContract, settingRepository repository.SettingRepositoryContract, tenantRepository repository.TenantRepositoryContract, renderer pdf.RendererContract) DocumentServiceContract {
+	return &DocumentService{
+		TransactionRepository:    transactionRepository,
+		ProductQualityRepository: productQualityRepository,
+		SettingRepository:        settingRepository,
+		TenantRepository:         tenantRepository,
+		Renderer:                 renderer,
+	}
+}
+
+// DeliveryNote is the note an OUT transaction ships to its customer with.
+func (service *DocumentService) DeliveryNote(ctx context.Context, code string) ([]byte, error) {
+	transaction, err := service.findTransaction(ctx, code, "OUT")
+	if err != nil {
+		return nil, err
+	}
+
+	customer := "-"
+	if transaction.Customer != nil {
+		customer = transaction.Customer.Name + " (" + transaction.Customer.Code + ")"
+	}
+
+	document := transactionDocument("Delivery Note", transaction)
+	document.Fields = append([]pdf.Field{{Label: "Customer", Value: customer}}, document.Fields...)
+	document.Signatures = []string{"Issued by", "Delivered by", "Received by"}
+
+	return service.render(ctx, document)
+}
+
+// GoodsReceipt is the note an IN transaction is received from its supplier with.
+func (service *DocumentService) GoodsReceipt(ctx context.Context, code string) ([]byte, error) {
+	transaction, err := service.findTransaction(ctx, code, "IN")
+	if err != nil {
+		return nil, err
+	}
+
+	supplier := []pdf.Field{{Label: "Supplier", Value: "-"}}
+	if transaction.Supplier != nil {
+		supplier = []pdf.Field{{Label: "Supplier", Value: transaction.Supplier.Name + " (" + transaction.Supplier.Code + ")"}}
+		if transaction.Supplier.Address != "" {
+			supplier = append(supplier, pdf.Field{Label: "Address", Value: transaction.Supplier.Address})
+		}
+		if transaction.Supplier.Phone != "" {
+			supplier = append(supplier, pdf.Field{Label: "Phone", Value: transaction.Supplier.Phone})
+		}
+	}
+
+	document := transactionDocument("Goods Receipt Note", transaction)
+	document.Fields = append(supplier, document.Fields...)
+	document.Signatures = []string{"Delivered by", "Received by"}
+
+	return service.render(ctx, document)
+}
+
+// TransferSlip is the slip a TRANSFER transaction moves stock between two qualities with.
+func (service *DocumentService) TransferSlip(ctx context.Context, code string) ([]byte, error) {
+	transaction, err := service.findTransaction(ctx, code, "TRANSFER")
+	if err != nil {
+		return nil, err
+	}
+
+	quantity := formatQuantity(transaction.Quantity, transaction.UnitMassAcronym)
+	document := transactionDocument("Transfer Slip", transaction)
+	document.Table = pdf.Table{
+		Columns: []pdf.Column{
+			{Title: "Product", Width: 70, Align: "L"},
+			{Title: "From quality", Width: 40, Align: "L"},
+			{Title: "To quality", Width: 40, Align: "L"},
+			{Title: "Quantity", Width: 30, Align: "R"},
+		},
+		Rows: [][]string{{productName(transaction.ProductQuality), qualityName(transaction.ProductQuality), qualityName(transaction.ProductQualityTransferred), quantity}},
+	}
+	document.Signatures = []string{"Issued by", "Approved by"}
+
+	return service.render(ctx, document)
+}
+
+// StockReport lists the stock on hand of every product quality, with its value at the quality
+// price.
+func (service *DocumentService) StockReport(ctx context.Context) ([]byte, error) {
+	var rows [][]string
+	var total int64
+	var afterID int64
+	for {
+		productQualities, err := service.ProductQualityRepository.FindAllAfterID(ctx, afterID, stockReportBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, productQuality := range productQualities {
+			value := int64(float64(productQuality.Price) * productQuality.Quantity)
+			total += value
+
+			unit := ""
+			if productQuality.Product != nil {
+				unit = productQuality.Product.UnitMassAcronym
+			}
+			rows = append(rows, []string{
+				productQuality.ProductCode,
+				productName(productQuality),
+				productQuality.Quality,
+				formatQuantity(productQuality.Quantity, unit),
+				strconv.FormatInt(productQuality.Price, 10),
+				strconv.FormatInt(value, 10),
+			})
+			afterID = productQuality.ID
+		}
+
+		if len(productQualities) < stockReportBatchSize {
+			break
+		}
+	}
+
+	document := &pdf.Document{
+		Title: "Stock on Hand",
+		Date:  time.Now(),
+		Fields: []pdf.Field{
+			{Label: "Product qualities", Value: strconv.Itoa(len(rows))},
+			{Label: "Total value", Value: strconv.FormatInt(total, 10)},
+		},
+		Table: pdf.Table{
+			Columns: []pdf.Column{
+				{Title: "Code", Width: 25, Align: "L"},
+				{Title: "Product", Width: 55, Align: "L"},
+				{Title: "Quality", Width: 25, Align: "L"},
+				{Title: "Quantity", Width: 25, Align: "R"},
+				{Title: "Price", Width: 25, Align: "R"},
+				{Title: "Value", Width: 25, Align: "R"},
+			},
+			Rows: rows,
+		},
+	}
+
+	return service.render(ctx, document)
+}
+
+// findTransaction returns the transaction with its associations, when it is of the type the
+// document is issued for.
+func (service *DocumentService) findTransaction(ctx context.Context, code string, transactionType string) (*model.Transaction, error) {
+	transaction, err := service.TransactionRepository.FindByCodeWithAssociations(ctx, code, nil)
+	if err != nil {
+		return nil, err
+	}
+	if transaction.Type != transactionType {
+		return nil, errors.New(response.ErrorDocumentTransactionType)
+	}
+
+	return transaction, nil
+}
+
+func (service *DocumentService) render(ctx context.Context, document *pdf.Document) ([]byte, error) {
+	branding, err := findBranding(ctx, service.SettingRepository, service.TenantRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	document.Branding = pdf.Branding{
+		CompanyName: branding.CompanyName,
+		Address:     branding.Address,
+		Phone:       branding.Phone,
+		Email:       branding.Email,
+		Footer:      branding.Footer,
+		Logo:        branding.Logo,
+		LogoType:    branding.LogoType,
+	}
+
+	return service.Renderer.Render(document)
+}
+
+// transactionDocument is the document of a transaction, numbered and barcoded with its code and
+// listing the product it moves.
+func transactionDocument(title string, transaction *model.Transaction) *pdf.Document {
+	document := &pdf.Document{
+		Title:   title,
+		Number:  transaction.Code,
+		Date:    transaction.CreatedAt,
+		Barcode: transaction.Code,
+		Table: pdf.Table{
+			Columns: []pdf.Column{
+				{Title: "Product code", Width: 35, Align: "L"},
+				{Title: "Product", Width: 85, Align: "L"},
+				{Title: "Quality", Width: 30, Align: "L"},
+				{Title: "Quantity", Width: 30, Align: "R"},
+			},
+		},
+	}
+
+	productCode := ""
+	if transaction.ProductQuality != nil {
+		productCode = transaction.ProductQuality.ProductCode
+	}
+	document.Table.Rows = [][]string{{productCode, productName(transaction.ProductQuality), qualityName(transaction.ProductQuality), formatQuantity(transaction.Quantity, transaction.UnitMassAcronym)}}
+
+	if transaction.Description != nil {
+		document.Notes = *transaction.Description
+	}
+
+	return document
+}
+
+func productName(productQuality *model.ProductQuality) string {
+	if productQuality == nil || productQuality.Product == nil {
+		return "-"
+	}
+
+	return productQuality.Product.Name
+}
+
+func qualityName(productQuality *model.ProductQuality) string {
+	if productQuality == nil {
+		return "-"
+	}
+
+	return productQuality.Quality
+}
+
+func formatQuantity(quantity float64, unit string) string {
+	if unit == "" {
+		return strconv.FormatFloat(quantity, 'f', -1, 64)
+	}
+
+	return fmt.Sprintf("%s %s", strconv.FormatFloat(quantity, 'f', -1, 64), unit)
+}
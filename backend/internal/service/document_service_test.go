@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	response "inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	repository "inventory-management/backend/internal/repository/mock"
+	pdf "inventory-management/backend/internal/third_party/pdf"
+	"inventory-management/backend/util"
+	"testing"
+)
+
+func TestDocumentService_DeliveryNote(t *testing.T) {
+	customerCode := "CSTMR12345"
+	description := "Leave at the gate"
+	branding := &model.BrandingSetting{CompanyName: "Widdy Farm", Address: "Sukabumi", Footer: "Thank you"}
+
+	testCases := []struct {
+		name                  string
+		transaction           *model.Transaction
+		transactionError      error
+		expectedDocument      *pdf.Document
+		expectedSvcError      error
+		expectedRendererCalls int
+	}{
+		{
+			name: "Delivery note of an OUT transaction",
+			transaction: &model.Transaction{
+				Code:            "TRXOUT1234",
+				ProductQuality:  &model.ProductQuality{ProductCode: "PRDCT12345", Quality: "Premium", Product: &model.Product{Name: "Rice"}},
+				CustomerCode:    &customerCode,
+				Customer:        &model.Customer{Code: customerCode, Name: "Arfiansyah"},
+				Description:     &description,
+				Quantity:        12.5,
+				Type:            "OUT",
+				UnitMassAcronym: "kg",
+			},
+			expectedDocument: &pdf.Document{
+				Title:    "Delivery Note",
+				Number:   "TRXOUT1234",
+				Barcode:  "TRXOUT1234",
+				Branding: pdf.Branding{CompanyName: "Widdy Farm", Address: "Sukabumi", Footer: "Thank you"},
+				Fields:   []pdf.Field{{Label: "Customer", Value: "Arfiansyah (CSTMR12345)"}},
+				Table: pdf.Table{
+					Columns: []pdf.Column{
+						{Title: "Product code", Width: 35, Align: "L"},
+						{Title: "Product", Width: 85, Align: "L"},
+						{Title: "Quality", Width: 30, Align: "L"},
+						{Title: "Quantity", Width: 30, Align: "R"},
+					},
+					Rows: [][]string{{"PRDCT12345", "Rice", "Premium", "12.5 kg"}},
+				},
+				Notes:      "Leave at the gate",
+				Signatures: []string{"Issued by", "Delivered by", "Received by"},
+			},
+			expectedRendererCalls: 1,
+		},
+		{
+			name:             "[invalid] Delivery note of an IN transaction",
+			transaction:      &model.Transaction{Code: "TRXIN12345", Type: "IN"},
+			expectedSvcError: errors.New(response.ErrorDocumentTransactionType),
+		},
+		{
+			name:             "[invalid] Transaction not found",
+			transactionError: errors.New(response.ErrorNotFound),
+			expectedSvcError: errors.New(response.ErrorNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var transactionRepo repository.TransactionRepositoryMock
+			transactionRepo.On("FindByCodeWithAssociations", ctx, "TRXOUT1234").Return(tc.transaction, tc.transactionError)
+
+			var settingRepo repository.SettingRepositoryMock
+			settingRepo.On("FindBranding", ctx).Return(branding, nil)
+
+			var renderer pdf.RendererMock
+			renderer.On("Render", tc.expectedDocument).Return([]byte("%PDF-1.3"), nil)
+
+			svc := NewDocumentService(&transactionRepo, nil, &settingRepo, nil, &renderer)
+			document, err := svc.DeliveryNote(ctx, "TRXOUT1234")
+
+			assert.Equal(t, tc.expectedSvcError, err)
+			if tc.expectedSvcError == nil {
+				assert.Equal(t, []byte("%PDF-1.3"), document)
+			}
+			renderer.AssertNumberOfCalls(t, "Render", tc.expectedRendererCalls)
+		})
+	}
+}
+
+func TestDocumentService_GoodsReceipt(t *testing.T) {
+	t.Run("Goods receipt issued in the name of the tenant without a branding", func(t *testing.T) {
+		ctx := util.WithTenant(context.Background(), 2)
+		supplierCode := "SPPLR12345"
+
+		var transactionRepo repository.TransactionRepositoryMock
+		transactionRepo.On("FindByCodeWithAssociations", ctx, "TRXIN12345").Return(&model.Transaction{
+			Code:            "TRXIN12345",
+			ProductQuality:  &model.ProductQuality{ProductCode: "PRDCT12345", Quality: "Premium", Product: &model.Product{Name: "Rice"}},
+			SupplierCode:    &supplierCode,
+			Supplier:        &model.Supplier{Code: supplierCode, Name: "Widdy Arfiansyah", Address: "Cisaat"},
+			Quantity:        40,
+			Type:            "IN",
+			UnitMassAcronym: "kg",
+		}, nil)
+
+		var settingRepo repository.SettingRepositoryMock
+		settingRepo.On("FindBranding", ctx).Return(nil, errors.New(response.ErrorNotFound))
+
+		var tenantRepo repository.TenantRepositoryMock
+		tenantRepo.On("FindByID", ctx, int64(2)).Return(&model.Tenant{ID: 2, Name: "Sukabumi Branch"}, nil)
+
+		var renderer pdf.RendererMock
+		renderer.On("Render", mock.Anything).Return([]byte("%PDF-1.3"), nil)
+
+		svc := NewDocumentService(&transactionRepo, nil, &settingRepo, &tenantRepo, &renderer)
+		_, err := svc.GoodsReceipt(ctx, "TRXIN12345")
+		assert.Nil(t, err)
+
+		document := renderer.Calls[0].Arguments.Get(0).(*pdf.Document)
+		assert.Equal(t, "Goods Receipt Note", document.Title)
+		assert.Equal(t, "Sukabumi Branch", document.Branding.CompanyName)
+		assert.Equal(t, []pdf.Field{{Label: "Supplier", Value: "Widdy Arfiansyah (SPPLR12345)"}, {Label: "Address", Value: "Cisaat"}}, document.Fields)
+		assert.Equal(t, [][]string{{"PRDCT12345", "Rice", "Premium", "40 kg"}}, document.Table.Rows)
+	})
+}
+
+func TestDocumentService_TransferSlip(t *testing.T) {
+	t.Run("Transfer slip lists both qualities", func(t *testing.T) {
+		ctx := context.Background()
+
+		var transactionRepo repository.TransactionRepositoryMock
+		transactionRepo.On("FindByCodeWithAssociations", ctx, "TRXTRF1234").Return(&model.Transaction{
+			Code:                      "TRXTRF1234",
+			ProductQuality:            &model.ProductQuality{Quality: "Premium", Product: &model.Product{Name: "Rice"}},
+			ProductQualityTransferred: &model.ProductQuality{Quality: "Medium", Product: &model.Product{Name: "Rice"}},
+			Quantity:                  3,
+			Type:                      "TRANSFER",
+			UnitMassAcronym:           "kg",
+		}, nil)
+
+		var settingRepo repository.SettingRepositoryMock
+		settingRepo.On("FindBranding", ctx).Return(&model.BrandingSetting{CompanyName: "Widdy Farm"}, nil)
+
+		var renderer pdf.RendererMock
+		renderer.On("Render", mock.Anything).Return([]byte("%PDF-1.3"), nil)
+
+		svc := NewDocumentService(&transactionRepo, nil, &settingRepo, nil, &renderer)
+		_, err := svc.TransferSlip(ctx, "TRXTRF1234")
+		assert.Nil(t, err)
+
+		document := renderer.Calls[0].Arguments.Get(0).(*pdf.Document)
+		assert.Equal(t, "TRXTRF1234", document.Barcode)
+		assert.Equal(t, [][]string{{"Rice", "Premium", "Medium", "3 kg"}}, document.Table.Rows)
+	})
+}
+
+func TestDocumentService_StockReport(t *testing.T) {
+	t.Run("Stock report values every product quality", func(t *testing.T) {
+		ctx := context.Background()
+
+		var productQualityRepo repository.ProductQualityRepositoryMock
+		productQualityRepo.On("FindAllAfterID", ctx, int64(0), stockReportBatchSize).Return([]*model.ProductQuality{
+			{ID: 1, ProductCode: "PRDCT12345", Quality: "Premium", Price: 12000, Quantity: 2.5, Product: &model.Product{Name: "Rice", UnitMassAcronym: "kg"}},
+			{ID: 2, ProductCode: "PRDCT12345", Quality: "Medium", Price: 9000, Quantity: 10, Product: &model.Product{Name: "Rice", UnitMassAcronym: "kg"}},
+		}, nil)
+
+		var settingRepo repository.SettingRepositoryMock
+		settingRepo.On("FindBranding", ctx).Return(&model.BrandingSetting{CompanyName: "Widdy Farm"}, nil)
+
+		var renderer pdf.RendererMock
+		renderer.On("Render", mock.Anything).Return([]byte("%PDF-1.3"), nil)
+
+		svc := NewDocumentService(nil, &productQualityRepo, &settingRepo, nil, &renderer)
+		_, err := svc.StockReport(ctx)
+		assert.Nil(t, err)
+
+		document := renderer.Calls[0].Arguments.Get(0).(*pdf.Document)
+		assert.Equal(t, []pdf.Field{{Label: "Product qualities", Value: "2"}, {Label: "Total value", Value: "120000"}}, document.Fields)
+		assert.Equal(t, [][]string{
+			{"PRDCT12345", "Rice", "Premium", "2.5 kg", "12000", "30000"},
+			{"PRDCT12345", "Rice", "Medium", "10 kg", "9000", "90000"},
+		}, document.Table.Rows)
+	})
+}
@@ -0,0 +1,395 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/internal/repository"
+	"inventory-management/backend/util"
+	"io"
+	"io/fs"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// exportBatchSize is how many records an export holds at once
+	exportBatchSize     = 500
+	exportInterval      = 5 * time.Second
+	exportPurgeInterval = time.Hour
+	exportLease         = 30 * time.Minute
+	exportMaxAttempts   = 3
+)
+
+// exportSource reads a list page by page for an export, as records an ExportWriter writes.
+type exportSource struct {
+	schema          *util.ListSchema
+	countAll        func(ctx context.Context, query *util.ListQuery) (int64, error)
+	findAllByCursor func(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]interface{}, *util.CursorPage, error)
+}
+
+func newExportSource[T any](schema *util.ListSchema, countAll func(context.Context, *util.ListQuery) (int64, error), findAllByCursor func(context.Context, *util.ListQuery, string, int) ([]T, *util.CursorPage, error)) *exportSource {
+	return &exportSource{
+		schema:   schema,
+		countAll: countAll,
+		findAllByCursor: func(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]interface{}, *util.CursorPage, error) {
+			records, page, err := findAllByCursor(ctx, query, cursor, limit)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			data := make([]interface{}, len(records))
+			for i, record := range records {
+				data[i] = record
+			}
+
+			return data, page, nil
+		},
+	}
+}
+
+// ExportService exports lists in the formats of util.ExportContentTypes. An export reads its
+// list by cursor in batches and writes every batch before reading the next, so only a batch is
+// ever held in memory. Lists over the sync limit are exported by a background job into a file
+// of the export directory, which is kept for the TTL once the job finishes.
+type ExportService struct {
+	ExportJobRepository repository.ExportJobRepositoryContract
+	sources             map[string]*exportSource
+	directory           string
+	syncLimit           int64
+	ttl                 time.Duration
+}
+
+func NewExportService(exportJobRepository repository.ExportJobRepositoryContract, productService ProductServiceContract, supplierService SupplierServiceContract, customerService CustomerServiceContract, userService UserServiceContract, transactionService TransactionServiceContract, directory string, syncLimit int64, ttl time.Duration) ExportServiceContract {
+	return &ExportService{
+		ExportJobRepository: exportJobRepository,
+		sources: map[string]*exportSource{
+			model.ExportEntityProducts:     newExportSource(model.ProductListSchema, productService.CountAll, productService.FindAllByCursor),
+			model.ExportEntitySuppliers:    newExportSource(model.SupplierListSchema, supplierService.CountAll, supplierService.FindAllByCursor),
+			model.ExportEntityCustomers:    newExportSource(model.CustomerListSchema, customerService.CountAll, customerService.FindAllByCursor),
+			model.ExportEntityUsers:        newExportSource(model.UserListSchema, userService.CountAll, userService.FindAllByCursor),
+			model.ExportEntityTransactions: newExportSource(model.TransactionListSchema, transactionService.CountAll, transactionService.FindAllByCursor),
+		},
+		directory: directory,
+		syncLimit: syncLimit,
+		ttl:       ttl,
+	}
+}
+
+// Background reports whether the list has too many records to be exported within the request.
+func (service *ExportService) Background(ctx context.Context, entity string, query *util.ListQuery) (bool, error) {
+	source, err := service.source(entity)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := source.countAll(ctx, query)
+	if err != nil {
+		return false, err
+	}
+
+	return count > service.syncLimit, nil
+}
+
+// Export writes every record of the list to w and returns how many there were.
+func (service *ExportService) Export(ctx context.Context, entity string, query *util.ListQuery, format string, w io.Writer) (int64, error) {
+	source, err := service.source(entity)
+	if err != nil {
+		return 0, err
+	}
+
+	var fields []string
+	if query != nil {
+		fields = query.Fields
+	}
+
+	writer, err := util.NewExportWriter(format, w, exportColumns(source.schema, query))
+	if err != nil {
+		return 0, err
+	}
+
+	var rows int64
+	cursor := ""
+	for {
+		records, page, err := source.findAllByCursor(ctx, query, cursor, exportBatchSize)
+		if err != nil {
+			return rows, err
+		}
+
+		for _, data := range records {
+			record, err := util.ExportRecord(data, fields)
+			if err != nil {
+				return rows, err
+			}
+			if err = writer.Write(record); err != nil {
+				return rows, err
+			}
+			rows++
+		}
+
+		if page.Next == "" {
+			break
+		}
+		cursor = page.Next
+	}
+
+	return rows, writer.Close()
+}
+
+// CreateJob queues the export of the list the query string selects, for the acting user.
+func (service *ExportService) CreateJob(ctx context.Context, entity string, format string, values url.Values) (*response.ExportJobResponse, error) {
+	if _, err := service.source(entity); err != nil {
+		return nil, err
+	}
+
+	job, err := service.ExportJobRepository.Create(ctx, &model.ExportJob{
+		RequestedBy: util.ActorFromContext(ctx),
+		Entity:      entity,
+		Format:      format,
+		Query:       util.ListQueryValues(values).Encode(),
+		Status:      model.ExportStatusPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return job.ToResponse(), nil
+}
+
+// FindAllJobs returns the exports of the acting user, newest first.
+func (service *ExportService) FindAllJobs(ctx context.Context, offset int, limit int) ([]*response.ExportJobResponse, error) {
+	jobs, err := service.ExportJobRepository.FindAllByRequester(ctx, util.ActorFromContext(ctx), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobResponses []*response.ExportJobResponse
+	for _, job := range jobs {
+		jobResponses = append(jobResponses, job.ToResponse())
+	}
+
+	return jobResponses, nil
+}
+
+func (service *ExportService) CountAllJobs(ctx context.Context) (int64, error) {
+	return service.ExportJobRepository.CountAllByRequester(ctx, util.ActorFromContext(ctx))
+}
+
+func (service *ExportService) FindJobByCode(ctx context.Context, code string) (*response.ExportJobResponse, error) {
+	job, err := service.findJob(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return job.ToResponse(), nil
+}
+
+// JobFile returns the export with the path of its file, once it is done.
+func (service *ExportService) JobFile(ctx context.Context, code string) (*response.ExportJobResponse, string, error) {
+	job, err := service.findJob(ctx, code)
+	if err != nil {
+		return nil, "", err
+	}
+	if job.Status != model.ExportStatusDone || job.FilePath == nil {
+		return nil, "", errors.New(response.ErrorExportNotReady)
+	}
+
+	return job.ToResponse(), *job.FilePath, nil
+}
+
+// Run runs the queued exports and purges the expired ones until the context is done.
+func (service *ExportService) Run(ctx context.Context) {
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+	purgeTicker := time.NewTicker(exportPurgeInterval)
+	defer purgeTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-purgeTicker.C:
+			_, err := service.PurgeExpired(ctx)
+			if err != nil {
+				log.Println("Cannot purge the expired exports", err)
+			}
+		case <-ticker.C:
+			for {
+				ran, err := service.RunNext(ctx)
+				if err != nil {
+					log.Println("Cannot run the export jobs", err)
+					break
+				}
+				if !ran {
+					break
+				}
+			}
+		}
+	}
+}
+
+// RunNext runs the oldest queued export and reports whether there was one. A failed export is
+// tried again later, until it runs out of attempts.
+func (service *ExportService) RunNext(ctx context.Context) (bool, error) {
+	job, err := service.ExportJobRepository.Claim(util.WithoutTenant(ctx), exportLease)
+	if err != nil {
+		return false, err
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	// The job exports what its requester would have listed
+	jobCtx := util.WithActor(util.WithTenant(ctx, job.TenantID), job.RequestedBy)
+	rows, filePath, err := service.exportFile(jobCtx, job)
+	now := time.Now()
+	if err != nil {
+		log.Printf("Cannot export %s of job %s, attempt %d: %v\n", job.Entity, job.Code, job.Attempts, err)
+		job.LastError = util.ToPointerString(err.Error())
+		job.Status = model.ExportStatusPending
+		job.AvailableAt = now.Add(time.Duration(job.Attempts) * time.Minute)
+		if job.Attempts >= exportMaxAttempts {
+			job.Status = model.ExportStatusFailed
+			job.CompletedAt = &now
+		}
+
+		return true, service.ExportJobRepository.Update(jobCtx, job)
+	}
+
+	job.Status = model.ExportStatusDone
+	job.RowCount = rows
+	job.FilePath = &filePath
+	job.LastError = nil
+	job.CompletedAt = &now
+	return true, service.ExportJobRepository.Update(jobCtx, job)
+}
+
+// PurgeExpired deletes the exports of every tenant that finished longer than the TTL ago,
+// together with their files, and reports how many there were.
+func (service *ExportService) PurgeExpired(ctx context.Context) (int, error) {
+	ctx = util.WithoutTenant(ctx)
+	before := time.Now().Add(-service.ttl)
+
+	purged := 0
+	for {
+		jobs, err := service.ExportJobRepository.FindAllCompletedBefore(ctx, before, exportBatchSize)
+		if err != nil {
+			return purged, err
+		}
+		if len(jobs) == 0 {
+			return purged, nil
+		}
+
+		// The files go first, a job whose file could not be removed is found again next time
+		ids := make([]int64, len(jobs))
+		for i, job := range jobs {
+			if job.FilePath != nil {
+				err = os.Remove(*job.FilePath)
+				if err != nil && !errors.Is(err, fs.ErrNotExist) {
+					return purged, err
+				}
+			}
+			ids[i] = job.ID
+		}
+
+		err = service.ExportJobRepository.Delete(ctx, ids)
+		if err != nil {
+			return purged, err
+		}
+		purged += len(jobs)
+
+		if len(jobs) < exportBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// exportFile exports the list of the job into a file of the export directory. The file only
+// gets its name once it is complete.
+func (service *ExportService) exportFile(ctx context.Context, job *model.ExportJob) (int64, string, error) {
+	source, err := service.source(job.Entity)
+	if err != nil {
+		return 0, "", err
+	}
+
+	values, err := url.ParseQuery(job.Query)
+	if err != nil {
+		return 0, "", err
+	}
+	query, errValidate := util.ParseListQuery(values, source.schema)
+	if errValidate != nil {
+		return 0, "", errors.New(errValidate[0].Value)
+	}
+
+	err = os.MkdirAll(service.directory, 0755)
+	if err != nil {
+		return 0, "", err
+	}
+
+	file, err := os.CreateTemp(service.directory, job.Code+"-*.tmp")
+	if err != nil {
+		return 0, "", err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	buffered := bufio.NewWriter(file)
+	rows, err := service.Export(ctx, job.Entity, query, job.Format, buffered)
+	if err != nil {
+		return 0, "", err
+	}
+	if err = buffered.Flush(); err != nil {
+		return 0, "", err
+	}
+	if err = file.Close(); err != nil {
+		return 0, "", err
+	}
+
+	filePath := filepath.Join(service.directory, job.Code+"."+job.Format)
+	if err = os.Rename(file.Name(), filePath); err != nil {
+		return 0, "", err
+	}
+
+	return rows, filePath, nil
+}
+
+// findJob returns an export of the acting user, the exports of other users are not found.
+func (service *ExportService) findJob(ctx context.Context, code string) (*model.ExportJob, error) {
+	job, err := service.ExportJobRepository.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if job.RequestedBy != util.ActorFromContext(ctx) {
+		return nil, errors.New(response.ErrorNotFound)
+	}
+
+	return job, nil
+}
+
+func (service *ExportService) source(entity string) (*exportSource, error) {
+	source, ok := service.sources[entity]
+	if !ok {
+		return nil, fmt.Errorf("unknown export entity %q", entity)
+	}
+
+	return source, nil
+}
+
+// exportColumns are the fields the list query selects, or every field of the list with the
+// included associations.
+func exportColumns(schema *util.ListSchema, query *util.ListQuery) []string {
+	if query == nil {
+		return schema.Fields
+	}
+	if len(query.Fields) > 0 {
+		return query.Fields
+	}
+
+	return append(append([]string{}, schema.Fields...), query.Include...)
+}
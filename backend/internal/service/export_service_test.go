@@ -0,0 +1,245 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	response "inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	repository "inventory-management/backend/internal/repository/mock"
+	service "inventory-management/backend/internal/service/mock"
+	"inventory-management/backend/util"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestExportService(exportJobRepository *repository.ExportJobRepositoryMock, supplierService *service.SupplierServiceMock, directory string) ExportServiceContract {
+	return NewExportService(exportJobRepository, &service.ProductServiceMock{}, supplierService, &service.CustomerServiceMock{}, &service.UserServiceMock{}, &service.TransactionServiceMock{}, directory, 2, time.Hour)
+}
+
+// newPagedSupplierService serves the suppliers in two cursor pages.
+func newPagedSupplierService(ctx context.Context, query *util.ListQuery) *service.SupplierServiceMock {
+	var supplierService service.SupplierServiceMock
+	supplierService.On("FindAllByCursor", ctx, query, "", exportBatchSize).Return([]*response.SupplierResponse{
+		{ID: 1, Code: "WDWDARFSYH", Name: "Widdy Arfiansyah", Address: "Sukabumi", Phone: "082291832488"},
+	}, &util.CursorPage{Next: "next"}, nil)
+	supplierService.On("FindAllByCursor", ctx, query, "next", exportBatchSize).Return([]*response.SupplierResponse{
+		{ID: 2, Code: "HYSFRADWDW", Name: "Arfiansyah", Address: "Cisaat", Phone: "089922234214"},
+	}, &util.CursorPage{Prev: "prev"}, nil)
+
+	return &supplierService
+}
+
+func TestExportService_Export(t *testing.T) {
+	ctx := context.Background()
+	query := &util.ListQuery{Fields: []string{"code", "name"}}
+
+	svc := newTestExportService(nil, newPagedSupplierService(ctx, query), "")
+
+	var output bytes.Buffer
+	rows, err := svc.Export(ctx, model.ExportEntitySuppliers, query, util.ExportFormatCSV, &output)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), rows)
+	assert.Equal(t, "code,name\nWDWDARFSYH,Widdy Arfiansyah\nHYSFRADWDW,Arfiansyah\n", output.String())
+}
+
+func TestExportService_Background(t *testing.T) {
+	testCases := []struct {
+		name     string
+		count    int64
+		expected bool
+	}{
+		{name: "List within the sync limit", count: 2, expected: false},
+		{name: "List over the sync limit", count: 3, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var supplierService service.SupplierServiceMock
+			supplierService.On("CountAll", ctx, (*util.ListQuery)(nil)).Return(tc.count, nil)
+
+			svc := newTestExportService(nil, &supplierService, "")
+			background, err := svc.Background(ctx, model.ExportEntitySuppliers, nil)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, background)
+		})
+	}
+}
+
+func TestExportService_CreateJob(t *testing.T) {
+	ctx := util.WithActor(context.Background(), "widdy")
+
+	var repo repository.ExportJobRepositoryMock
+	repo.On("Create", ctx, &model.ExportJob{
+		RequestedBy: "widdy",
+		Entity:      model.ExportEntitySuppliers,
+		Format:      util.ExportFormatXLSX,
+		Query:       "filter%5Bname%5D%5Blike%5D=widdy",
+		Status:      model.ExportStatusPending,
+	}).Return(&model.ExportJob{Code: "EXPORT", Entity: model.ExportEntitySuppliers, Format: util.ExportFormatXLSX, Status: model.ExportStatusPending}, nil)
+
+	svc := newTestExportService(&repo, &service.SupplierServiceMock{}, "")
+	job, err := svc.CreateJob(ctx, model.ExportEntitySuppliers, util.ExportFormatXLSX, url.Values{
+		"filter[name][like]": {"widdy"},
+		"format":             {"xlsx"},
+		"async":              {"true"},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "EXPORT", job.Code)
+	assert.Nil(t, job.DownloadURL)
+}
+
+func TestExportService_JobFile(t *testing.T) {
+	testCases := []struct {
+		name          string
+		job           *model.ExportJob
+		expectedPath  string
+		expectedError error
+	}{
+		{
+			name:         "Done export of the user",
+			job:          &model.ExportJob{Code: "EXPORT", RequestedBy: "widdy", Status: model.ExportStatusDone, FilePath: util.ToPointerString("exports/EXPORT.csv")},
+			expectedPath: "exports/EXPORT.csv",
+		},
+		{
+			name:          "Export still running",
+			job:           &model.ExportJob{Code: "EXPORT", RequestedBy: "widdy", Status: model.ExportStatusRunning},
+			expectedError: errors.New(response.ErrorExportNotReady),
+		},
+		{
+			name:          "Export of another user",
+			job:           &model.ExportJob{Code: "EXPORT", RequestedBy: "arfiansyah", Status: model.ExportStatusDone, FilePath: util.ToPointerString("exports/EXPORT.csv")},
+			expectedError: errors.New(response.ErrorNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := util.WithActor(context.Background(), "widdy")
+
+			var repo repository.ExportJobRepositoryMock
+			repo.On("FindByCode", ctx, "EXPORT").Return(tc.job, nil)
+
+			svc := newTestExportService(&repo, &service.SupplierServiceMock{}, "")
+			_, filePath, err := svc.JobFile(ctx, "EXPORT")
+
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedPath, filePath)
+		})
+	}
+}
+
+func TestExportService_RunNext(t *testing.T) {
+	t.Run("Export written into the export directory", func(t *testing.T) {
+		ctx := context.Background()
+		directory := t.TempDir()
+		job := &model.ExportJob{ID: 1, TenantID: 2, Code: "EXPORT", RequestedBy: "widdy", Entity: model.ExportEntitySuppliers, Format: util.ExportFormatCSV, Query: "fields=code", Attempts: 1}
+		jobCtx := util.WithActor(util.WithTenant(ctx, 2), "widdy")
+
+		var repo repository.ExportJobRepositoryMock
+		repo.On("Claim", util.WithoutTenant(ctx), exportLease).Return(job, nil)
+		repo.On("Update", jobCtx, mock.Anything).Return(nil)
+
+		svc := newTestExportService(&repo, newPagedSupplierService(jobCtx, &util.ListQuery{Fields: []string{"code"}}), directory)
+		ran, err := svc.RunNext(ctx)
+
+		assert.Nil(t, err)
+		assert.True(t, ran)
+		assert.Equal(t, model.ExportStatusDone, job.Status)
+		assert.Equal(t, int64(2), job.RowCount)
+		assert.Equal(t, filepath.Join(directory, "EXPORT.csv"), *job.FilePath)
+
+		content, err := os.ReadFile(*job.FilePath)
+		assert.Nil(t, err)
+		assert.Equal(t, "code\nWDWDARFSYH\nHYSFRADWDW\n", string(content))
+
+		files, err := os.ReadDir(directory)
+		assert.Nil(t, err)
+		assert.Len(t, files, 1)
+	})
+
+	t.Run("Failed export out of attempts", func(t *testing.T) {
+		ctx := context.Background()
+		job := &model.ExportJob{ID: 1, TenantID: 2, Code: "EXPORT", RequestedBy: "widdy", Entity: model.ExportEntitySuppliers, Format: util.ExportFormatCSV, Attempts: exportMaxAttempts}
+		jobCtx := util.WithActor(util.WithTenant(ctx, 2), "widdy")
+
+		var repo repository.ExportJobRepositoryMock
+		repo.On("Claim", util.WithoutTenant(ctx), exportLease).Return(job, nil)
+		repo.On("Update", jobCtx, mock.Anything).Return(nil)
+		var supplierService service.SupplierServiceMock
+		supplierService.On("FindAllByCursor", jobCtx, &util.ListQuery{}, "", exportBatchSize).Return(nil, nil, errors.New("getting an error"))
+
+		svc := newTestExportService(&repo, &supplierService, t.TempDir())
+		ran, err := svc.RunNext(ctx)
+
+		assert.Nil(t, err)
+		assert.True(t, ran)
+		assert.Equal(t, model.ExportStatusFailed, job.Status)
+		assert.Equal(t, "getting an error", *job.LastError)
+		assert.NotNil(t, job.CompletedAt)
+	})
+
+	t.Run("No export queued", func(t *testing.T) {
+		ctx := context.Background()
+
+		var repo repository.ExportJobRepositoryMock
+		repo.On("Claim", util.WithoutTenant(ctx), exportLease).Return(nil, nil)
+
+		svc := newTestExportService(&repo, &service.SupplierServiceMock{}, "")
+		ran, err := svc.RunNext(ctx)
+
+		assert.Nil(t, err)
+		assert.False(t, ran)
+	})
+}
+
+func TestExportService_PurgeExpired(t *testing.T) {
+	t.Run("Expired exports are deleted with their files", func(t *testing.T) {
+		ctx := context.Background()
+		directory := t.TempDir()
+		filePath := filepath.Join(directory, "EXPORT.csv")
+		assert.Nil(t, os.WriteFile(filePath, []byte("code\n"), 0644))
+
+		var repo repository.ExportJobRepositoryMock
+		repo.On("FindAllCompletedBefore", util.WithoutTenant(ctx), mock.MatchedBy(func(before time.Time) bool {
+			return before.Before(time.Now().Add(-time.Hour + time.Minute))
+		}), exportBatchSize).Return([]*model.ExportJob{
+			{ID: 1, Code: "EXPORT", Status: model.ExportStatusDone, FilePath: &filePath},
+			{ID: 2, Code: "FAILED", Status: model.ExportStatusFailed},
+			{ID: 3, Code: "GONE", Status: model.ExportStatusDone, FilePath: util.ToPointerString(filepath.Join(directory, "GONE.csv"))},
+		}, nil)
+		repo.On("Delete", util.WithoutTenant(ctx), []int64{1, 2, 3}).Return(nil)
+
+		svc := newTestExportService(&repo, &service.SupplierServiceMock{}, directory)
+		purged, err := svc.PurgeExpired(ctx)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3, purged)
+		_, err = os.Stat(filePath)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("Nothing expired", func(t *testing.T) {
+		ctx := context.Background()
+
+		var repo repository.ExportJobRepositoryMock
+		repo.On("FindAllCompletedBefore", util.WithoutTenant(ctx), mock.Anything, exportBatchSize).Return([]*model.ExportJob{}, nil)
+
+		svc := newTestExportService(&repo, &service.SupplierServiceMock{}, t.TempDir())
+		purged, err := svc.PurgeExpired(ctx)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, purged)
+		repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/internal/repository"
+	"inventory-management/backend/util"
+	"log"
+	"time"
+)
+
+const (
+	// idempotencyLease is how long a request may be processed before its key is taken for one
+	// that crashed, and can be sent again
+	idempotencyLease = 5 * time.Minute
+	// idempotencyPurgeInterval is how often expired keys are deleted
+	idempotencyPurgeInterval = time.Hour
+)
+
+type IdempotencyService struct {
+	IdempotencyKeyRepository repository.IdempotencyKeyRepositoryContract
+	TTL                      time.Duration
+}
+
+func NewIdempotencyService(idempotencyKeyRepository repository.IdempotencyKeyRepositoryContract, ttl time.Duration) IdempotencyServiceContract {
+	return &IdempotencyService{
+		IdempotencyKeyRepository: idempotencyKeyRepository,
+		TTL:                      ttl,
+	}
+}
+
+// Begin claims the key of the acting user for a request, the fingerprint telling requests
+// apart. It returns no response when the request is to be processed, and the stored response
+// when it already was. A key that is sent with another request, or while its request is still
+// processed, is refused.
+func (service *IdempotencyService) Begin(ctx context.Context, key string, fingerprint string) (*response.IdempotentResponse, error) {
+	idempotencyKey := &model.IdempotencyKey{
+		Username:    util.ActorFromContext(ctx),
+		Key:         key,
+		Fingerprint: fingerprint,
+		ExpiresAt:   time.Now().Add(service.TTL),
+	}
+	claimed, err := service.IdempotencyKeyRepository.Claim(ctx, idempotencyKey, idempotencyLease)
+	if err != nil {
+		return nil, err
+	}
+	if claimed {
+		return nil, nil
+	}
+
+	stored, err := service.IdempotencyKeyRepository.FindByKey(ctx, idempotencyKey.Username, key)
+	if err != nil {
+		// The key was released in between, its request having failed
+		if err.Error() == response.ErrorNotFound {
+			return nil, errors.New(response.ErrorIdempotencyKeyInProgress)
+		}
+		return nil, err
+	}
+	if stored.Fingerprint != fingerprint {
+		return nil, errors.New(response.ErrorIdempotencyKeyReused)
+	}
+	if stored.StatusCode == 0 {
+		return nil, errors.New(response.ErrorIdempotencyKeyInProgress)
+	}
+
+	return stored.ToResponse(), nil
+}
+
+// Complete stores the response of the request the key was claimed for.
+func (service *IdempotencyService) Complete(ctx context.Context, key string, fingerprint string, idempotentResponse *response.IdempotentResponse) error {
+	return service.IdempotencyKeyRepository.SaveResponse(ctx, &model.IdempotencyKey{
+		Username:    util.ActorFromContext(ctx),
+		Key:         key,
+		Fingerprint: fingerprint,
+		StatusCode:  idempotentResponse.StatusCode,
+		ContentType: idempotentResponse.ContentType,
+		Body:        idempotentResponse.Body,
+	})
+}
+
+// Release gives the key back when its request failed, so the request can be sent again.
+func (service *IdempotencyService) Release(ctx context.Context, key string, fingerprint string) error {
+	return service.IdempotencyKeyRepository.Release(ctx, &model.IdempotencyKey{
+		Username:    util.ActorFromContext(ctx),
+		Key:         key,
+		Fingerprint: fingerprint,
+	})
+}
+
+// Run deletes the expired keys of every tenant until the context is done.
+func (service *IdempotencyService) Run(ctx context.Context) {
+	ticker := time.NewTicker(idempotencyPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := service.IdempotencyKeyRepository.DeleteExpired(util.WithoutTenant(ctx), time.Now())
+			if err != nil {
+				log.Println("Cannot delete the expired idempotency keys", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	repository "inventory-management/backend/internal/repository/mock"
+	"inventory-management/backend/util"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyService_Begin(t *testing.T) {
+	stored := &model.IdempotencyKey{Username: "widdy", Key: "retry-1", Fingerprint: "abc", StatusCode: 201, ContentType: "application/json", Body: []byte(`{"code":201}`)}
+
+	testCases := []struct {
+		name             string
+		claimed          bool
+		stored           *model.IdempotencyKey
+		fingerprint      string
+		expected         *response.IdempotentResponse
+		expectedSvcError error
+	}{
+		{
+			name:        "First request is processed",
+			claimed:     true,
+			fingerprint: "abc",
+		},
+		{
+			name:        "Repeated request replays the response",
+			stored:      stored,
+			fingerprint: "abc",
+			expected:    stored.ToResponse(),
+		},
+		{
+			name:             "[invalid] Key sent with another request",
+			stored:           stored,
+			fingerprint:      "def",
+			expectedSvcError: errors.New(response.ErrorIdempotencyKeyReused),
+		},
+		{
+			name:             "[invalid] Key of a request still processed",
+			stored:           &model.IdempotencyKey{Username: "widdy", Key: "retry-1", Fingerprint: "abc"},
+			fingerprint:      "abc",
+			expectedSvcError: errors.New(response.ErrorIdempotencyKeyInProgress),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := util.WithActor(context.Background(), "widdy")
+
+			var idempotencyKeyRepo repository.IdempotencyKeyRepositoryMock
+			idempotencyKeyRepo.On("Claim", ctx, mock.MatchedBy(func(idempotencyKey *model.IdempotencyKey) bool {
+				return idempotencyKey.Username == "widdy" && idempotencyKey.Key == "retry-1" && idempotencyKey.Fingerprint == tc.fingerprint &&
+					idempotencyKey.ExpiresAt.After(time.Now().Add(time.Hour-time.Minute))
+			}), idempotencyLease).Return(tc.claimed, nil)
+			idempotencyKeyRepo.On("FindByKey", ctx, "widdy", "retry-1").Return(tc.stored, nil)
+
+			svc := NewIdempotencyService(&idempotencyKeyRepo, time.Hour)
+			idempotentResponse, err := svc.Begin(ctx, "retry-1", tc.fingerprint)
+
+			assert.Equal(t, tc.expectedSvcError, err)
+			assert.Equal(t, tc.expected, idempotentResponse)
+			if tc.claimed {
+				idempotencyKeyRepo.AssertNotCalled(t, "FindByKey", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestIdempotencyService_Complete(t *testing.T) {
+	ctx := util.WithActor(context.Background(), "widdy")
+
+	var idempotencyKeyRepo repository.IdempotencyKeyRepositoryMock
+	idempotencyKeyRepo.On("SaveResponse", ctx, &model.IdempotencyKey{Username: "widdy", Key: "retry-1", Fingerprint: "abc", StatusCode: 201, ContentType: "application/json", Body: []byte("{}")}).Return(nil)
+
+	svc := NewIdempotencyService(&idempotencyKeyRepo, time.Hour)
+	err := svc.Complete(ctx, "retry-1", "abc", &response.IdempotentResponse{StatusCode: 201, ContentType: "application/json", Body: []byte("{}")})
+
+	assert.Nil(t, err)
+	idempotencyKeyRepo.AssertExpectations(t)
+}
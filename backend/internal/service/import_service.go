@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/internal/repository"
+	"inventory-management/backend/util"
+	"strings"
+)
+
+const importOpeningStockDescription = "Opening stock"
+
+type ImportService struct {
+	ProductRepository        repository.ProductRepositoryContract
+	ProductQualityRepository repository.ProductQualityRepositoryContract
+	SupplierRepository       repository.SupplierRepositoryContract
+	CustomerRepository       repository.CustomerRepositoryContract
+	TxTransactionRepository  repository.TxTransactionRepositoryContract
+	Transactor               repository.TransactorContract
+	AuditService             AuditServiceContract
+}
+
+// NewImportService imports the rows of uploaded sheets. Every row is validated with the rules of
+// the request creating one record, and the records are only created when every row is valid.
+func NewImportService(productRepository repository.ProductRepositoryContract, productQualityRepository repository.ProductQualityRepositoryContract, supplierRepository repository.SupplierRepositoryContract, customerRepository repository.CustomerRepositoryContract, txTransactionRepository repository.TxTransactionRepositoryContract, transactor repository.TransactorContract, auditService AuditServiceContract) ImportServiceContract {
+	return &ImportService{
+		ProductRepository:        productRepository,
+		ProductQualityRepository: productQualityRepository,
+		SupplierRepository:       supplierRepository,
+		CustomerRepository:       customerRepository,
+		TxTransactionRepository:  txTransactionRepository,
+		Transactor:               transactor,
+		AuditService:             auditService,
+	}
+}
+
+// ImportProducts creates a product quality per row. Rows with the same product name make one
+// product, whose unit of mass is the one of its first row.
+func (service *ImportService) ImportProducts(ctx context.Context, rows []*util.SheetRow, dryRun bool) (*response.ImportResponse, error) {
+	result := &response.ImportResponse{DryRun: dryRun, TotalRows: len(rows)}
+
+	var products []*model.Product
+	productsByName := map[string]*model.Product{}
+	for _, row := range rows {
+		var productRequest request.CreateProductRequest
+		var productQualityRequest request.CreateProductQualityRequest
+		errBind := append(util.BindSheetRow(row, &productRequest), util.BindSheetRow(row, &productQualityRequest)...)
+		productRequest.ProductQualities = []*request.CreateProductQualityRequest{&productQualityRequest}
+		if !validateImportRow(result, row, &productRequest, errBind) {
+			continue
+		}
+
+		product, ok := productsByName[productRequest.Name]
+		if !ok {
+			product = &model.Product{
+				Name:                productRequest.Name,
+				UnitMassAcronym:     productRequest.UnitMassAcronym,
+				UnitMassDescription: productRequest.UnitMassDescription,
+			}
+			productsByName[product.Name] = product
+			products = append(products, product)
+		}
+
+		product.ProductQualities = append(product.ProductQualities, &model.ProductQuality{
+			Quality:  productQualityRequest.Quality,
+			Price:    productQualityRequest.Price,
+			Quantity: productQualityRequest.Quantity,
+			Type:     productQualityRequest.Type,
+		})
+	}
+
+	if dryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	err := service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		products, err = service.ProductRepository.CreateAll(ctx, products)
+		if err != nil {
+			return err
+		}
+
+		for _, product := range products {
+			err = service.AuditService.Record(ctx, model.AuditEntityProduct, product.Code, model.AuditActionCreate, nil, product.ToResponseWithAssociations())
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Imported = len(rows)
+	return result, nil
+}
+
+func (service *ImportService) ImportSuppliers(ctx context.Context, rows []*util.SheetRow, dryRun bool) (*response.ImportResponse, error) {
+	result := &response.ImportResponse{DryRun: dryRun, TotalRows: len(rows)}
+
+	var suppliers []*model.Supplier
+	for _, row := range rows {
+		var supplierRequest request.CreateSupplierRequest
+		if !validateImportRow(result, row, &supplierRequest, util.BindSheetRow(row, &supplierRequest)) {
+			continue
+		}
+
+		suppliers = append(suppliers, &model.Supplier{
+			Name:    supplierRequest.Name,
+			Address: supplierRequest.Address,
+			Phone:   supplierRequest.Phone,
+		})
+	}
+
+	if dryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	err := service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		suppliers, err = service.SupplierRepository.CreateAll(ctx, suppliers)
+		if err != nil {
+			return err
+		}
+
+		for _, supplier := range suppliers {
+			err = service.AuditService.Record(ctx, model.AuditEntitySupplier, supplier.Code, model.AuditActionCreate, nil, supplier.ToResponse())
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Imported = len(suppliers)
+	return result, nil
+}
+
+func (service *ImportService) ImportCustomers(ctx context.Context, rows []*util.SheetRow, dryRun bool) (*response.ImportResponse, error) {
+	result := &response.ImportResponse{DryRun: dryRun, TotalRows: len(rows)}
+
+	var customers []*model.Customer
+	for _, row := range rows {
+		var customerRequest request.CreateCustomerRequest
+		if !validateImportRow(result, row, &customerRequest, util.BindSheetRow(row, &customerRequest)) {
+			continue
+		}
+
+		customers = append(customers, &model.Customer{Name: customerRequest.Name})
+	}
+
+	if dryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	err := service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		customers, err = service.CustomerRepository.CreateAll(ctx, customers)
+		if err != nil {
+			return err
+		}
+
+		for _, customer := range customers {
+			err = service.AuditService.Record(ctx, model.AuditEntityCustomer, customer.Code, model.AuditActionCreate, nil, customer.ToResponse())
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Imported = len(customers)
+	return result, nil
+}
+
+// ImportOpeningStock posts the stock of every row as an IN transaction of the product quality
+// named by its product code and quality.
+func (service *ImportService) ImportOpeningStock(ctx context.Context, rows []*util.SheetRow, dryRun bool) (*response.ImportResponse, error) {
+	result := &response.ImportResponse{DryRun: dryRun, TotalRows: len(rows)}
+
+	var transactionRequests []*request.CreateTransactionRequest
+	productQualities := map[string][]*model.ProductQuality{}
+	for _, row := range rows {
+		var openingStock request.ImportOpeningStockRow
+		if !validateImportRow(result, row, &openingStock, util.BindSheetRow(row, &openingStock)) {
+			continue
+		}
+
+		qualities, ok := productQualities[openingStock.ProductCode]
+		if !ok {
+			var err error
+			qualities, err = service.ProductQualityRepository.FindAllByProductCode(ctx, openingStock.ProductCode, nil)
+			if err != nil {
+				return nil, err
+			}
+			productQualities[openingStock.ProductCode] = qualities
+		}
+
+		var productQualityID int64
+		for _, productQuality := range qualities {
+			if strings.EqualFold(productQuality.Quality, openingStock.Quality) {
+				productQualityID = productQuality.ID
+				break
+			}
+		}
+		if productQualityID == 0 {
+			failedField := "Quality"
+			if len(qualities) == 0 {
+				failedField = "ProductCode"
+			}
+			result.Errors = append(result.Errors, &response.ImportRowErrorResponse{
+				Row: row.Number,
+				Errors: []*response.ErrorResponse{{
+					FailedField: failedField,
+					Tag:         "exists",
+					Value:       fmt.Sprintf("Error validation '%s' for '%s' field", "exists", failedField),
+				}},
+			})
+			continue
+		}
+
+		description := openingStock.Description
+		if description == nil {
+			description = util.ToPointerString(importOpeningStockDescription)
+		}
+		transactionRequests = append(transactionRequests, &request.CreateTransactionRequest{
+			ProductQualityID: productQualityID,
+			Description:      description,
+			Quantity:         openingStock.Quantity,
+			Type:             "IN",
+			UnitMassAcronym:  openingStock.UnitMassAcronym,
+		})
+	}
+
+	if dryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	var transactions []*model.Transaction
+	err := service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		transactions, err = service.TxTransactionRepository.CreateAll(ctx, transactionRequests)
+		if err != nil {
+			return err
+		}
+
+		for _, transaction := range transactions {
+			err = service.AuditService.Record(ctx, model.AuditEntityTransaction, transaction.Code, model.AuditActionCreate, nil, transaction.ToResponse())
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Imported = len(transactions)
+	return result, nil
+}
+
+// validateImportRow validates a row bound to its request and records its errors, including the
+// ones of binding it. It reports whether the row is valid.
+func validateImportRow(result *response.ImportResponse, row *util.SheetRow, rowRequest interface{}, errBind []*response.ErrorResponse) bool {
+	errValidate := errBind
+	if len(errValidate) == 0 {
+		errValidate = util.ValidateStruct(rowRequest)
+	}
+	if len(errValidate) == 0 {
+		return true
+	}
+
+	result.Errors = append(result.Errors, &response.ImportRowErrorResponse{Row: row.Number, Errors: errValidate})
+	return false
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/request"
+	response "inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	repository "inventory-management/backend/internal/repository/mock"
+	service "inventory-management/backend/internal/service/mock"
+	"inventory-management/backend/util"
+	"testing"
+)
+
+func TestImportService_ImportSuppliers(t *testing.T) {
+	validRow := &util.SheetRow{Number: 2, Values: map[string]string{"name": "Widdy Arfiansyah", "address": "Sukabumi", "phone": "082291832488"}}
+	invalidRow := &util.SheetRow{Number: 3, Values: map[string]string{"name": "Arfiansyah", "address": "Cisaat", "phone": "0899"}}
+
+	testCases := []struct {
+		name           string
+		rows           []*util.SheetRow
+		dryRun         bool
+		expectedCreate bool
+		expectedSvc    *response.ImportResponse
+	}{
+		{
+			name:           "Every row is valid",
+			rows:           []*util.SheetRow{validRow},
+			expectedCreate: true,
+			expectedSvc:    &response.ImportResponse{TotalRows: 1, Imported: 1},
+		},
+		{
+			name:        "Dry run of valid rows",
+			rows:        []*util.SheetRow{validRow},
+			dryRun:      true,
+			expectedSvc: &response.ImportResponse{DryRun: true, TotalRows: 1},
+		},
+		{
+			name: "A row is invalid",
+			rows: []*util.SheetRow{validRow, invalidRow},
+			expectedSvc: &response.ImportResponse{
+				TotalRows: 2,
+				Errors: []*response.ImportRowErrorResponse{
+					{
+						Row: 3,
+						Errors: []*response.ErrorResponse{
+							{FailedField: "Phone", Tag: "min", Value: "Error validation 'min' for 'Phone' field"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var repo repository.SupplierRepositoryMock
+			repo.On("CreateAll", ctx, mock.Anything).Return([]*model.Supplier{
+				{ID: 1, Code: "WDWDARFSYH", Name: "Widdy Arfiansyah", Address: "Sukabumi", Phone: "082291832488"},
+			}, nil)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			svc := NewImportService(nil, nil, &repo, nil, nil, &repository.TransactorMock{}, &audit)
+			result, err := svc.ImportSuppliers(ctx, tc.rows, tc.dryRun)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedSvc, result)
+			if tc.expectedCreate {
+				repo.AssertCalled(t, "CreateAll", ctx, []*model.Supplier{
+					{Name: "Widdy Arfiansyah", Address: "Sukabumi", Phone: "082291832488"},
+				})
+				audit.AssertCalled(t, "Record", ctx, model.AuditEntitySupplier, "WDWDARFSYH", model.AuditActionCreate, nil, mock.Anything)
+			} else {
+				repo.AssertNotCalled(t, "CreateAll", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestImportService_ImportProducts(t *testing.T) {
+	ctx := context.Background()
+	rows := []*util.SheetRow{
+		{Number: 2, Values: map[string]string{"name": "Kangkung", "unit_mass_acronym": "kg", "unit_mass_description": "kilogram", "quality": "Original", "price": "15000", "type": "increase"}},
+		{Number: 3, Values: map[string]string{"name": "Kangkung", "unit_mass_acronym": "kg", "unit_mass_description": "kilo", "quality": "Premium", "price": "20000", "quantity": "2.5", "type": "increase"}},
+	}
+
+	var repo repository.ProductRepositoryMock
+	repo.On("CreateAll", ctx, mock.Anything).Return([]*model.Product{{ID: 1, Code: "KGKG", Name: "Kangkung"}}, nil)
+	var audit service.AuditServiceMock
+	audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	svc := NewImportService(&repo, nil, nil, nil, nil, &repository.TransactorMock{}, &audit)
+	result, err := svc.ImportProducts(ctx, rows, false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &response.ImportResponse{TotalRows: 2, Imported: 2}, result)
+	repo.AssertCalled(t, "CreateAll", ctx, []*model.Product{
+		{
+			Name:                "Kangkung",
+			UnitMassAcronym:     "kg",
+			UnitMassDescription: "kilogram",
+			ProductQualities: []*model.ProductQuality{
+				{Quality: "Original", Price: 15000, Type: "increase"},
+				{Quality: "Premium", Price: 20000, Quantity: 2.5, Type: "increase"},
+			},
+		},
+	})
+	audit.AssertNumberOfCalls(t, "Record", 1)
+}
+
+func TestImportService_ImportOpeningStock(t *testing.T) {
+	ctx := context.Background()
+	rows := []*util.SheetRow{
+		{Number: 2, Values: map[string]string{"product_code": "KGKG", "quality": "original", "quantity": "10", "unit_mass_acronym": "kg"}},
+		{Number: 3, Values: map[string]string{"product_code": "KGKG", "quality": "Rotten", "quantity": "1", "unit_mass_acronym": "kg"}},
+		{Number: 4, Values: map[string]string{"product_code": "BYAM", "quality": "Original", "quantity": "1", "unit_mass_acronym": "kg"}},
+	}
+
+	t.Run("Rows of unknown product qualities", func(t *testing.T) {
+		var qualityRepo repository.ProductQualityRepositoryMock
+		qualityRepo.On("FindAllByProductCode", ctx, "KGKG").Return([]*model.ProductQuality{{ID: 7, Quality: "Original"}}, nil)
+		qualityRepo.On("FindAllByProductCode", ctx, "BYAM").Return(nil, nil)
+		var txRepo repository.TxTransactionRepositoryMock
+
+		svc := NewImportService(nil, &qualityRepo, nil, nil, &txRepo, &repository.TransactorMock{}, nil)
+		result, err := svc.ImportOpeningStock(ctx, rows, false)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &response.ImportResponse{
+			TotalRows: 3,
+			Errors: []*response.ImportRowErrorResponse{
+				{Row: 3, Errors: []*response.ErrorResponse{{FailedField: "Quality", Tag: "exists", Value: "Error validation 'exists' for 'Quality' field"}}},
+				{Row: 4, Errors: []*response.ErrorResponse{{FailedField: "ProductCode", Tag: "exists", Value: "Error validation 'exists' for 'ProductCode' field"}}},
+			},
+		}, result)
+		qualityRepo.AssertNumberOfCalls(t, "FindAllByProductCode", 2)
+		txRepo.AssertNotCalled(t, "CreateAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Rows posted as IN transactions", func(t *testing.T) {
+		var qualityRepo repository.ProductQualityRepositoryMock
+		qualityRepo.On("FindAllByProductCode", ctx, "KGKG").Return([]*model.ProductQuality{{ID: 7, Quality: "Original"}}, nil)
+		var txRepo repository.TxTransactionRepositoryMock
+		txRepo.On("CreateAll", ctx, mock.Anything).Return([]*model.Transaction{{ID: 1, Code: "TX-1"}}, nil)
+		var audit service.AuditServiceMock
+		audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		svc := NewImportService(nil, &qualityRepo, nil, nil, &txRepo, &repository.TransactorMock{}, &audit)
+		result, err := svc.ImportOpeningStock(ctx, rows[:1], false)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &response.ImportResponse{TotalRows: 1, Imported: 1}, result)
+		txRepo.AssertCalled(t, "CreateAll", ctx, []*request.CreateTransactionRequest{
+			{
+				ProductQualityID: 7,
+				Description:      util.ToPointerString("Opening stock"),
+				Quantity:         10,
+				Type:             "IN",
+				UnitMassAcronym:  "kg",
+			},
+		})
+		audit.AssertCalled(t, "Record", ctx, model.AuditEntityTransaction, "TX-1", model.AuditActionCreate, nil, mock.Anything)
+	})
+}
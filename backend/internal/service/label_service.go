@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/internal/repository"
+	"inventory-management/backend/util"
+	"strconv"
+)
+
+const (
+	LabelFormatPNG = "png"
+	LabelFormatSVG = "svg"
+	LabelFormatZPL = "zpl"
+)
+
+const (
+	// labelBarcodeHeight is the height of linear barcodes in pixels, SVG units and printer dots
+	labelBarcodeHeight = 200
+	labelBarcodeScale  = 3
+)
+
+// LabelService renders the labels of product qualities and transactions, as a PNG or SVG image
+// of their barcode or as ZPL from the label template of the tenant.
+type LabelService struct {
+	ProductQualityRepository repository.ProductQualityRepositoryContract
+	TransactionRepository    repository.TransactionRepositoryContract
+	SettingRepository        repository.SettingRepositoryContract
+}
+
+func NewLabelService(productQualityRepository repository.ProductQualityRepositoryContract, transactionRepository repository.TransactionRepositoryContract, settingRepository repository.SettingRepositoryContract) LabelServiceContract {
+	return &LabelService{
+		ProductQualityRepository: productQualityRepository,
+		TransactionRepository:    transactionRepository,
+		SettingRepository:        settingRepository,
+	}
+}
+
+func (service *LabelService) ProductQuality(ctx context.Context, id int64, request *request.LabelRequest) ([]byte, error) {
+	productQuality, err := service.ProductQualityRepository.FindByIDWithAssociations(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	title := productQuality.ProductCode
+	if productQuality.Product != nil {
+		title = productQuality.Product.Name
+	}
+
+	return service.render(ctx, model.LabelTypeProductQuality, request, productQuality.LabelValue(), util.GS1Text(productQuality.GS1Elements()), &model.LabelData{
+		Title:    title,
+		Subtitle: "Quality " + productQuality.Quality,
+		Text:     productQuality.LabelValue(),
+	})
+}
+
+func (service *LabelService) Transaction(ctx context.Context, code string, request *request.LabelRequest) ([]byte, error) {
+	transaction, err := service.TransactionRepository.FindByCodeWithAssociations(ctx, code, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	title := ""
+	if transaction.ProductQuality != nil {
+		title = transaction.ProductQuality.ProductCode
+		if transaction.ProductQuality.Product != nil {
+			title = transaction.ProductQuality.Product.Name
+		}
+		title += " " + transaction.ProductQuality.Quality
+	}
+
+	return service.render(ctx, model.LabelTypeTransaction, request, transaction.Code, util.GS1Text(transaction.GS1Elements()), &model.LabelData{
+		Title:    title,
+		Subtitle: transaction.Type + " " + strconv.FormatFloat(transaction.Quantity, 'f', -1, 64) + " " + transaction.UnitMassAcronym,
+		Text:     transaction.Code + " " + transaction.CreatedAt.Local().Format("2006-01-02 15:04"),
+	})
+}
+
+// render encodes the label value in the symbology of the request or of the label template,
+// GS1-128 encoding the GS1 form of the value instead.
+func (service *LabelService) render(ctx context.Context, labelType string, request *request.LabelRequest, value string, gs1Value string, data *model.LabelData) ([]byte, error) {
+	labelTemplate, err := findLabelTemplate(ctx, service.SettingRepository, labelType)
+	if err != nil {
+		return nil, err
+	}
+
+	symbology := request.Symbology
+	if symbology == "" {
+		symbology = labelTemplate.Symbology
+	}
+	if symbology == util.BarcodeGS1128 {
+		value = gs1Value
+	}
+
+	switch request.Format {
+	case LabelFormatZPL:
+		data.Value = value
+		data.Barcode, err = util.ZPLBarcode(symbology, value, labelBarcodeHeight/2)
+		if err != nil {
+			return nil, err
+		}
+		data.Title = util.ZPLText(data.Title)
+		data.Subtitle = util.ZPLText(data.Subtitle)
+		data.Text = util.ZPLText(data.Text)
+		data.Copies = request.Copies
+		if data.Copies == 0 {
+			data.Copies = 1
+		}
+
+		return executeLabelTemplate(labelTemplate.Zpl, data)
+	case LabelFormatSVG:
+		code, err := util.EncodeBarcode(symbology, value)
+		if err != nil {
+			return nil, err
+		}
+
+		return util.BarcodeSVG(code, labelBarcodeHeight/labelBarcodeScale), nil
+	case "", LabelFormatPNG:
+		code, err := util.EncodeBarcode(symbology, value)
+		if err != nil {
+			return nil, err
+		}
+
+		return util.BarcodePNG(code, labelBarcodeScale, labelBarcodeHeight)
+	default:
+		return nil, errors.New("unknown label format " + request.Format)
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/http/request"
+	response "inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	repository "inventory-management/backend/internal/repository/mock"
+	"strings"
+	"testing"
+)
+
+func TestLabelService_ProductQuality(t *testing.T) {
+	productQuality := &model.ProductQuality{ID: 7, ProductCode: "PRDCT12345", Quality: "Premium", Product: &model.Product{Name: "Rice"}}
+	savedTemplate := &model.LabelTemplate{ID: 1, LabelType: model.LabelTypeProductQuality, Symbology: "qr", Zpl: "^XA{{.Barcode}}^FS^FD{{.Title}}^FS^PQ{{.Copies}}^XZ"}
+
+	testCases := []struct {
+		name             string
+		request          *request.LabelRequest
+		labelTemplate    *model.LabelTemplate
+		templateError    error
+		expectedLabel    string
+		expectedPrefix   string
+		expectedSvcError error
+	}{
+		{
+			name:          "ZPL from the default template",
+			request:       &request.LabelRequest{Format: "zpl", Copies: 2},
+			templateError: errors.New(response.ErrorNotFound),
+			expectedLabel: "^XA\n^CI28\n^PW812\n^LL406\n^FO40,30^A0N,40,40^FDRice^FS\n^FO40,80^A0N,28,28^FDQuality Premium^FS\n" +
+				"^FO40,130^BCN,100,Y,N,N^FD>:PRDCT12345-7^FS\n^FO40,360^A0N,24,24^FDPRDCT12345-7^FS\n^PQ2\n^XZ\n",
+		},
+		{
+			name:          "ZPL in the symbology of the saved template",
+			request:       &request.LabelRequest{Format: "zpl"},
+			labelTemplate: savedTemplate,
+			expectedLabel: "^XA^BQN,2,4^FDMA,PRDCT12345-7^FS^FDRice^FS^PQ1^XZ",
+		},
+		{
+			name:          "ZPL in the symbology of the request",
+			request:       &request.LabelRequest{Format: "zpl", Symbology: "gs1-128"},
+			labelTemplate: savedTemplate,
+			expectedLabel: "^XA^BCN,100,Y,N,N,D^FD(240)PRDCT12345(91)7^FS^FDRice^FS^PQ1^XZ",
+		},
+		{
+			name:           "PNG image of the barcode",
+			request:        &request.LabelRequest{},
+			labelTemplate:  savedTemplate,
+			expectedPrefix: "\x89PNG",
+		},
+		{
+			name:           "SVG image of the barcode",
+			request:        &request.LabelRequest{Format: "svg", Symbology: "code128"},
+			labelTemplate:  savedTemplate,
+			expectedPrefix: "<svg ",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var productQualityRepo repository.ProductQualityRepositoryMock
+			productQualityRepo.On("FindByIDWithAssociations", ctx, int64(7)).Return(productQuality, nil)
+
+			var settingRepo repository.SettingRepositoryMock
+			settingRepo.On("FindLabelTemplate", ctx, model.LabelTypeProductQuality).Return(tc.labelTemplate, tc.templateError)
+
+			svc := NewLabelService(&productQualityRepo, nil, &settingRepo)
+			label, err := svc.ProductQuality(ctx, 7, tc.request)
+
+			assert.Equal(t, tc.expectedSvcError, err)
+			if tc.expectedLabel != "" {
+				assert.Equal(t, tc.expectedLabel, string(label))
+			}
+			assert.True(t, strings.HasPrefix(string(label), tc.expectedPrefix))
+		})
+	}
+}
+
+func TestLabelService_Transaction(t *testing.T) {
+	t.Run("[invalid] Transaction not found", func(t *testing.T) {
+		ctx := context.Background()
+
+		var transactionRepo repository.TransactionRepositoryMock
+		transactionRepo.On("FindByCodeWithAssociations", ctx, "TRXOUT1234").Return(nil, errors.New(response.ErrorNotFound))
+
+		svc := NewLabelService(nil, &transactionRepo, nil)
+		_, err := svc.Transaction(ctx, "TRXOUT1234", &request.LabelRequest{})
+
+		assert.Equal(t, errors.New(response.ErrorNotFound), err)
+	})
+}
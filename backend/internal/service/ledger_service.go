@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/internal/repository"
+	"sort"
+)
+
+const ledgerBatchSize = 500
+
+type LedgerService struct {
+	LedgerRepository      repository.LedgerRepositoryContract
+	TransactionRepository repository.TransactionRepositoryContract
+	SigningKey            ed25519.PrivateKey
+	BatchSize             int
+}
+
+func NewLedgerService(ledgerRepository repository.LedgerRepositoryContract, transactionRepository repository.TransactionRepositoryContract, signingKey ed25519.PrivateKey) LedgerServiceContract {
+	return &LedgerService{
+		LedgerRepository:      ledgerRepository,
+		TransactionRepository: transactionRepository,
+		SigningKey:            signingKey,
+		BatchSize:             ledgerBatchSize,
+	}
+}
+
+// Verify walks the hash chain from the first entry, then compares every stored transaction
+// with its latest ledger entry. It reports the first broken link it finds.
+func (service *LedgerService) Verify(ctx context.Context) (*response.LedgerVerificationResponse, error) {
+	checkpoints, err := service.LedgerRepository.FindAllCheckpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpointsBySequence := map[int64]*model.LedgerCheckpoint{}
+	for _, checkpoint := range checkpoints {
+		checkpointsBySequence[checkpoint.Sequence] = checkpoint
+	}
+
+	result := &response.LedgerVerificationResponse{LastHash: model.LedgerGenesisHash}
+	latestEntries := map[string]*model.LedgerEntry{}
+	for {
+		entries, err := service.LedgerRepository.FindAll(ctx, result.LastSequence, service.BatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			reason := service.verifyEntry(entry, result, checkpointsBySequence[entry.Sequence])
+			if reason != "" {
+				result.BrokenLink = &response.LedgerBrokenLinkResponse{
+					Sequence:        entry.Sequence,
+					TransactionCode: entry.TransactionCode,
+					Reason:          reason,
+				}
+				return result, nil
+			}
+
+			result.CheckedEntries++
+			result.LastSequence = entry.Sequence
+			result.LastHash = entry.Hash
+			latestEntries[entry.TransactionCode] = entry
+		}
+
+		if len(entries) < service.BatchSize {
+			break
+		}
+	}
+
+	for _, checkpoint := range checkpoints {
+		if checkpoint.Sequence > result.LastSequence {
+			result.BrokenLink = &response.LedgerBrokenLinkResponse{
+				Sequence: checkpoint.Sequence,
+				Reason:   "ledger ends before a signed checkpoint",
+			}
+			return result, nil
+		}
+	}
+
+	brokenLink, err := service.verifyTransactions(ctx, latestEntries, result.LastSequence)
+	if err != nil {
+		return nil, err
+	}
+
+	result.BrokenLink = brokenLink
+	result.Valid = brokenLink == nil
+
+	return result, nil
+}
+
+func (service *LedgerService) verifyEntry(entry *model.LedgerEntry, previous *response.LedgerVerificationResponse, checkpoint *model.LedgerCheckpoint) string {
+	if entry.Sequence != previous.LastSequence+1 {
+		return "sequence is not contiguous"
+	}
+
+	if entry.PreviousHash != previous.LastHash {
+		return "previous hash does not match the preceding entry"
+	}
+
+	if entry.ComputeHash() != entry.Hash {
+		return "entry content does not match its hash"
+	}
+
+	if checkpoint != nil {
+		if checkpoint.Hash != entry.Hash {
+			return "entry hash does not match the signed checkpoint"
+		}
+
+		if !service.verifySignature(checkpoint) {
+			return "checkpoint signature is invalid"
+		}
+	}
+
+	return ""
+}
+
+// verifyTransactions compares the stored transactions with the latest ledger entry of each one.
+// Transactions written after the chain was walked are skipped, so verification can run while
+// the application keeps accepting writes.
+func (service *LedgerService) verifyTransactions(ctx context.Context, latestEntries map[string]*model.LedgerEntry, lastSequence int64) (*response.LedgerBrokenLinkResponse, error) {
+	// Concurrent inserts shift the pages, so a transaction can be returned twice
+	seen := map[string]bool{}
+	for offset := 0; ; offset += service.BatchSize {
+		transactions, err := service.TransactionRepository.FindAll(ctx, nil, offset, service.BatchSize, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, transaction := range transactions {
+			if seen[transaction.Code] {
+				continue
+			}
+			seen[transaction.Code] = true
+
+			payload, err := transaction.LedgerPayload()
+			if err != nil {
+				return nil, err
+			}
+
+			entry, ok := latestEntries[transaction.Code]
+			delete(latestEntries, transaction.Code)
+			if ok && entry.Action != model.LedgerActionDelete && entry.Payload == payload {
+				continue
+			}
+
+			writtenLater, err := service.writtenAfter(ctx, transaction.Code, lastSequence)
+			if err != nil {
+				return nil, err
+			}
+			if writtenLater {
+				continue
+			}
+
+			if !ok || entry.Action == model.LedgerActionDelete {
+				return &response.LedgerBrokenLinkResponse{
+					TransactionCode: transaction.Code,
+					Reason:          "transaction is not recorded in the ledger",
+				}, nil
+			}
+
+			if entry.LegacyPayload() {
+				return &response.LedgerBrokenLinkResponse{
+					Sequence:        entry.Sequence,
+					TransactionCode: transaction.Code,
+					Reason:          "transaction is recorded with a legacy payload, the backfill records it again",
+				}, nil
+			}
+
+			return &response.LedgerBrokenLinkResponse{
+				Sequence:        entry.Sequence,
+				TransactionCode: transaction.Code,
+				Reason:          "transaction differs from its latest ledger entry",
+			}, nil
+		}
+
+		if len(transactions) < service.BatchSize {
+			break
+		}
+	}
+
+	// Whatever is left was recorded in the ledger but no longer exists
+	var missing []*model.LedgerEntry
+	for _, entry := range latestEntries {
+		if entry.Action != model.LedgerActionDelete {
+			missing = append(missing, entry)
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i].Sequence < missing[j].Sequence
+	})
+
+	for _, entry := range missing {
+		writtenLater, err := service.writtenAfter(ctx, entry.TransactionCode, lastSequence)
+		if err != nil {
+			return nil, err
+		}
+		if writtenLater {
+			continue
+		}
+
+		return &response.LedgerBrokenLinkResponse{
+			Sequence:        entry.Sequence,
+			TransactionCode: entry.TransactionCode,
+			Reason:          "transaction recorded in the ledger is missing",
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func (service *LedgerService) writtenAfter(ctx context.Context, transactionCode string, lastSequence int64) (bool, error) {
+	entry, err := service.LedgerRepository.FindLatestByTransactionCode(ctx, transactionCode)
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return entry.Sequence > lastSequence, nil
+}
+
+// Backfill records transactions created before the ledger existed, oldest first, then records
+// again the transactions whose latest entry has a legacy payload, so the ledger covers every
+// field of them.
+func (service *LedgerService) Backfill(ctx context.Context) (int64, error) {
+	recorded, err := service.backfill(ctx, service.LedgerRepository.FindUnrecordedTransactions, model.LedgerActionCreate)
+	if err != nil {
+		return recorded, err
+	}
+
+	updated, err := service.backfill(ctx, service.LedgerRepository.FindLegacyRecordedTransactions, model.LedgerActionUpdate)
+	return recorded + updated, err
+}
+
+func (service *LedgerService) backfill(ctx context.Context, find func(ctx context.Context, limit int) ([]*model.Transaction, error), action string) (int64, error) {
+	var recorded int64
+	for {
+		transactions, err := find(ctx, service.BatchSize)
+		if err != nil {
+			return recorded, err
+		}
+
+		for _, transaction := range transactions {
+			_, err = service.LedgerRepository.Append(ctx, transaction, action, nil)
+			if err != nil {
+				return recorded, err
+			}
+			recorded++
+		}
+
+		if len(transactions) < service.BatchSize {
+			return recorded, nil
+		}
+	}
+}
+
+func (service *LedgerService) CreateCheckpoint(ctx context.Context) (*response.LedgerCheckpointResponse, error) {
+	if service.SigningKey == nil {
+		return nil, errors.New(response.ErrorLedgerSigningKeyMissing)
+	}
+
+	last, err := service.LedgerRepository.FindLast(ctx)
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return nil, errors.New(response.ErrorLedgerEmpty)
+		}
+		return nil, err
+	}
+
+	var checkpointRequest model.LedgerCheckpoint
+	checkpointRequest.Sequence = last.Sequence
+	checkpointRequest.Hash = last.Hash
+	checkpointRequest.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(service.SigningKey, checkpointRequest.SigningMessage()))
+
+	checkpoint, err := service.LedgerRepository.CreateCheckpoint(ctx, &checkpointRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkpoint.ToResponse(), nil
+}
+
+func (service *LedgerService) ExportCheckpoints(ctx context.Context) (*response.LedgerCheckpointExportResponse, error) {
+	if service.SigningKey == nil {
+		return nil, errors.New(response.ErrorLedgerSigningKeyMissing)
+	}
+
+	checkpoints, err := service.LedgerRepository.FindAllCheckpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpointResponses := []*response.LedgerCheckpointResponse{}
+	for _, checkpoint := range checkpoints {
+		checkpointResponses = append(checkpointResponses, checkpoint.ToResponse())
+	}
+
+	return &response.LedgerCheckpointExportResponse{
+		Algorithm:   "ed25519",
+		PublicKey:   base64.StdEncoding.EncodeToString(service.SigningKey.Public().(ed25519.PublicKey)),
+		Checkpoints: checkpointResponses,
+	}, nil
+}
+
+// verifySignature checks the checkpoint signature when a signing key is configured. Without
+// a key only the checkpoint hash can be compared.
+func (service *LedgerService) verifySignature(checkpoint *model.LedgerCheckpoint) bool {
+	if service.SigningKey == nil {
+		return true
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(checkpoint.Signature)
+	if err != nil {
+		return false
+	}
+
+	return ed25519.Verify(service.SigningKey.Public().(ed25519.PublicKey), checkpoint.SigningMessage(), signature)
+}
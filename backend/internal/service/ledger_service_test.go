@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	response "inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	repository "inventory-management/backend/internal/repository/mock"
+	"inventory-management/backend/util"
+	"strings"
+	"testing"
+)
+
+func newLedgerChain(t *testing.T, transactions []*model.Transaction) []*model.LedgerEntry {
+	var entries []*model.LedgerEntry
+	previousHash := model.LedgerGenesisHash
+	for i, transaction := range transactions {
+		payload, err := transaction.LedgerPayload()
+		assert.Nil(t, err)
+
+		entry := &model.LedgerEntry{
+			Sequence:        int64(i + 1),
+			TransactionCode: transaction.Code,
+			Action:          model.LedgerActionCreate,
+			Payload:         payload,
+			PreviousHash:    previousHash,
+		}
+		entry.Hash = entry.ComputeHash()
+		previousHash = entry.Hash
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func TestLedgerService_Verify(t *testing.T) {
+	newTransactions := func() []*model.Transaction {
+		return []*model.Transaction{
+			{ID: 1, Code: "WDWDARFSYH", ProductQualityID: 1, Reference: util.ToPointerString("DN-2021-001"), Quantity: 10, Type: "IN", UnitMassAcronym: "kg"},
+			{ID: 2, Code: "KKSJIDNAAA", ProductQualityID: 1, Quantity: 4, Type: "OUT", UnitMassAcronym: "kg"},
+		}
+	}
+
+	testCases := []struct {
+		name               string
+		tamper             func(entries []*model.LedgerEntry, transactions []*model.Transaction) []*model.Transaction
+		expectedValid      bool
+		expectedBrokenLink *response.LedgerBrokenLinkResponse
+	}{
+		{
+			name: "Untouched ledger",
+			tamper: func(entries []*model.LedgerEntry, transactions []*model.Transaction) []*model.Transaction {
+				return transactions
+			},
+			expectedValid: true,
+		},
+		{
+			name: "Ledger entry edited in the database",
+			tamper: func(entries []*model.LedgerEntry, transactions []*model.Transaction) []*model.Transaction {
+				entries[0].Payload = strings.Replace(entries[0].Payload, `"quantity":10`, `"quantity":100`, 1)
+				return transactions
+			},
+			expectedBrokenLink: &response.LedgerBrokenLinkResponse{
+				Sequence:        1,
+				TransactionCode: "WDWDARFSYH",
+				Reason:          "entry content does not match its hash",
+			},
+		},
+		{
+			name: "Ledger entry rehashed without updating the chain",
+			tamper: func(entries []*model.LedgerEntry, transactions []*model.Transaction) []*model.Transaction {
+				entries[0].Payload = strings.Replace(entries[0].Payload, `"quantity":10`, `"quantity":100`, 1)
+				entries[0].Hash = entries[0].ComputeHash()
+				return transactions
+			},
+			expectedBrokenLink: &response.LedgerBrokenLinkResponse{
+				Sequence:        2,
+				TransactionCode: "KKSJIDNAAA",
+				Reason:          "previous hash does not match the preceding entry",
+			},
+		},
+		{
+			name: "Transaction edited in the database",
+			tamper: func(entries []*model.LedgerEntry, transactions []*model.Transaction) []*model.Transaction {
+				transactions[1].Quantity = 1
+				return transactions
+			},
+			expectedBrokenLink: &response.LedgerBrokenLinkResponse{
+				Sequence:        2,
+				TransactionCode: "KKSJIDNAAA",
+				Reason:          "transaction differs from its latest ledger entry",
+			},
+		},
+		{
+			name: "Transaction reference edited in the database",
+			tamper: func(entries []*model.LedgerEntry, transactions []*model.Transaction) []*model.Transaction {
+				transactions[0].Reference = util.ToPointerString("DN-2021-002")
+				return transactions
+			},
+			expectedBrokenLink: &response.LedgerBrokenLinkResponse{
+				Sequence:        1,
+				TransactionCode: "WDWDARFSYH",
+				Reason:          "transaction differs from its latest ledger entry",
+			},
+		},
+		{
+			name: "Transaction version edited in the database",
+			tamper: func(entries []*model.LedgerEntry, transactions []*model.Transaction) []*model.Transaction {
+				transactions[1].Version = 5
+				return transactions
+			},
+			expectedBrokenLink: &response.LedgerBrokenLinkResponse{
+				Sequence:        2,
+				TransactionCode: "KKSJIDNAAA",
+				Reason:          "transaction differs from its latest ledger entry",
+			},
+		},
+		{
+			name: "Transaction recorded with a legacy payload",
+			tamper: func(entries []*model.LedgerEntry, transactions []*model.Transaction) []*model.Transaction {
+				var payload map[string]interface{}
+				assert.Nil(t, json.Unmarshal([]byte(entries[1].Payload), &payload))
+				delete(payload, "reference")
+				delete(payload, "version")
+				delete(payload, "updated_at")
+				legacyPayload, err := json.Marshal(payload)
+				assert.Nil(t, err)
+
+				entries[1].Payload = string(legacyPayload)
+				entries[1].Hash = entries[1].ComputeHash()
+				return transactions
+			},
+			expectedBrokenLink: &response.LedgerBrokenLinkResponse{
+				Sequence:        2,
+				TransactionCode: "KKSJIDNAAA",
+				Reason:          "transaction is recorded with a legacy payload, the backfill records it again",
+			},
+		},
+		{
+			name: "Transaction deleted in the database",
+			tamper: func(entries []*model.LedgerEntry, transactions []*model.Transaction) []*model.Transaction {
+				return transactions[1:]
+			},
+			expectedBrokenLink: &response.LedgerBrokenLinkResponse{
+				Sequence:        1,
+				TransactionCode: "WDWDARFSYH",
+				Reason:          "transaction recorded in the ledger is missing",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			transactions := newTransactions()
+			entries := newLedgerChain(t, transactions)
+			storedTransactions := tc.tamper(entries, newTransactions())
+
+			var ledgerRepo repository.LedgerRepositoryMock
+			var transactionRepo repository.TransactionRepositoryMock
+			ledgerRepo.On("FindAllCheckpoints", ctx).Return([]*model.LedgerCheckpoint{}, nil)
+			ledgerRepo.On("FindAll", ctx, int64(0), ledgerBatchSize).Return(entries, nil)
+			ledgerRepo.On("FindLatestByTransactionCode", ctx, mock.Anything).Return(nil, errors.New(response.ErrorNotFound))
+			transactionRepo.On("FindAll", ctx, (*util.ListQuery)(nil), 0, ledgerBatchSize).Return(storedTransactions, nil)
+
+			svc := NewLedgerService(&ledgerRepo, &transactionRepo, nil)
+			result, err := svc.Verify(ctx)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedValid, result.Valid)
+			assert.Equal(t, tc.expectedBrokenLink, result.BrokenLink)
+		})
+	}
+}
+
+func TestLedgerService_CreateCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	signingKey := ed25519.NewKeyFromSeed([]byte(strings.Repeat("a", ed25519.SeedSize)))
+
+	entries := newLedgerChain(t, []*model.Transaction{
+		{ID: 1, Code: "WDWDARFSYH", ProductQualityID: 1, Quantity: 10, Type: "IN", UnitMassAcronym: "kg"},
+	})
+
+	var ledgerRepo repository.LedgerRepositoryMock
+	var transactionRepo repository.TransactionRepositoryMock
+	var storedCheckpoint *model.LedgerCheckpoint
+	ledgerRepo.On("FindLast", ctx).Return(entries[0], nil)
+	ledgerRepo.On("CreateCheckpoint", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		storedCheckpoint = args.Get(1).(*model.LedgerCheckpoint)
+	}).Return(&model.LedgerCheckpoint{ID: 1, Sequence: 1, Hash: entries[0].Hash}, nil)
+
+	svc := NewLedgerService(&ledgerRepo, &transactionRepo, signingKey)
+	result, err := svc.CreateCheckpoint(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, entries[0].Hash, result.Hash)
+
+	signature, err := base64.StdEncoding.DecodeString(storedCheckpoint.Signature)
+	assert.Nil(t, err)
+	assert.True(t, ed25519.Verify(signingKey.Public().(ed25519.PublicKey), storedCheckpoint.SigningMessage(), signature))
+
+	t.Run("Checkpoint without a signing key", func(t *testing.T) {
+		svc := NewLedgerService(&ledgerRepo, &transactionRepo, nil)
+		_, err := svc.CreateCheckpoint(ctx)
+		assert.Equal(t, response.ErrorLedgerSigningKeyMissing, err.Error())
+	})
+
+	t.Run("Forged checkpoint fails verification", func(t *testing.T) {
+		var ledgerRepo repository.LedgerRepositoryMock
+		ledgerRepo.On("FindAllCheckpoints", ctx).Return([]*model.LedgerCheckpoint{
+			{ID: 1, Sequence: 1, Hash: entries[0].Hash, Signature: base64.StdEncoding.EncodeToString([]byte("forged"))},
+		}, nil)
+		ledgerRepo.On("FindAll", ctx, int64(0), ledgerBatchSize).Return(entries, nil)
+
+		svc := NewLedgerService(&ledgerRepo, &transactionRepo, signingKey)
+		result, err := svc.Verify(ctx)
+		assert.Nil(t, err)
+		assert.False(t, result.Valid)
+		assert.Equal(t, "checkpoint signature is invalid", result.BrokenLink.Reason)
+	})
+}
+
+func TestLedgerService_Backfill(t *testing.T) {
+	ctx := context.Background()
+	transactions := []*model.Transaction{
+		{ID: 1, Code: "WDWDARFSYH"},
+		{ID: 2, Code: "KKSJIDNAAA"},
+	}
+
+	var ledgerRepo repository.LedgerRepositoryMock
+	var transactionRepo repository.TransactionRepositoryMock
+	ledgerRepo.On("FindUnrecordedTransactions", ctx, ledgerBatchSize).Return(transactions, nil)
+	ledgerRepo.On("FindLegacyRecordedTransactions", ctx, ledgerBatchSize).Return([]*model.Transaction{{ID: 3, Code: "HSUANDKAIS"}}, nil)
+	ledgerRepo.On("Append", ctx, mock.Anything, model.LedgerActionCreate).Return(&model.LedgerEntry{}, nil)
+	ledgerRepo.On("Append", ctx, mock.Anything, model.LedgerActionUpdate).Return(&model.LedgerEntry{}, nil)
+
+	svc := NewLedgerService(&ledgerRepo, &transactionRepo, nil)
+	recorded, err := svc.Backfill(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), recorded)
+	ledgerRepo.AssertCalled(t, "Append", ctx, transactions[0], model.LedgerActionCreate)
+	ledgerRepo.AssertCalled(t, "Append", ctx, transactions[1], model.LedgerActionCreate)
+	ledgerRepo.AssertCalled(t, "Append", ctx, &model.Transaction{ID: 3, Code: "HSUANDKAIS"}, model.LedgerActionUpdate)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+)
+
+type AccountServiceMock struct {
+	mock.Mock
+}
+
+func (mock *AccountServiceMock) Me(ctx context.Context, username string) (*response.UserResponse, error) {
+	args := mock.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.UserResponse), args.Error(1)
+}
+
+func (mock *AccountServiceMock) UpdateMe(ctx context.Context, request *request.UpdateMeRequest) (*response.UserResponse, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.UserResponse), args.Error(1)
+}
+
+func (mock *AccountServiceMock) ChangePassword(ctx context.Context, request *request.ChangePasswordRequest) error {
+	args := mock.Called(ctx, request)
+	return args.Error(0)
+}
+
+func (mock *AccountServiceMock) CreateInvitation(ctx context.Context, request *request.CreateInvitationRequest) (*response.InvitationResponse, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.InvitationResponse), args.Error(1)
+}
+
+func (mock *AccountServiceMock) Register(ctx context.Context, request *request.RegisterUserRequest) (*response.UserResponse, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.UserResponse), args.Error(1)
+}
+
+func (mock *AccountServiceMock) ForgotPassword(ctx context.Context, request *request.ForgotPasswordRequest) error {
+	args := mock.Called(ctx, request)
+	return args.Error(0)
+}
+
+func (mock *AccountServiceMock) ResetPassword(ctx context.Context, request *request.ResetPasswordRequest) error {
+	args := mock.Called(ctx, request)
+	return args.Error(0)
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+)
+
+type AnalyticsServiceMock struct {
+	mock.Mock
+}
+
+func (mock *AnalyticsServiceMock) Volume(ctx context.Context, request *request.AnalyticsRequest) ([]*response.VolumeResponse, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*response.VolumeResponse), args.Error(1)
+}
+
+func (mock *AnalyticsServiceMock) TopCustomers(ctx context.Context, request *request.AnalyticsRequest) ([]*response.PartnerVolumeResponse, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*response.PartnerVolumeResponse), args.Error(1)
+}
+
+func (mock *AnalyticsServiceMock) TopSuppliers(ctx context.Context, request *request.AnalyticsRequest) ([]*response.PartnerVolumeResponse, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*response.PartnerVolumeResponse), args.Error(1)
+}
+
+func (mock *AnalyticsServiceMock) StockByType(ctx context.Context, request *request.AnalyticsRequest) ([]*response.StockByTypeResponse, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*response.StockByTypeResponse), args.Error(1)
+}
+
+func (mock *AnalyticsServiceMock) Transfers(ctx context.Context, request *request.AnalyticsRequest) ([]*response.TransferVolumeResponse, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*response.TransferVolumeResponse), args.Error(1)
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+)
+
+type AuditServiceMock struct {
+	mock.Mock
+}
+
+func (mock *AuditServiceMock) FindAll(ctx context.Context, filter *request.AuditLogFilterRequest, offset int, limit int) ([]*response.AuditLogResponse, error) {
+	args := mock.Called(ctx, filter, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*response.AuditLogResponse), args.Error(1)
+}
+
+func (mock *AuditServiceMock) CountAll(ctx context.Context, filter *request.AuditLogFilterRequest) (int64, error) {
+	args := mock.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return 0, args.Error(1)
+	}
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (mock *AuditServiceMock) Record(ctx context.Context, entity string, entityID string, action string, before interface{}, after interface{}) error {
+	args := mock.Called(ctx, entity, entityID, action, before, after)
+	return args.Error(0)
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+type DocumentServiceMock struct {
+	mock.Mock
+}
+
+func (mock *DocumentServiceMock) DeliveryNote(ctx context.Context, code string) ([]byte, error) {
+	args := mock.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (mock *DocumentServiceMock) GoodsReceipt(ctx context.Context, code string) ([]byte, error) {
+	args := mock.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (mock *DocumentServiceMock) TransferSlip(ctx context.Context, code string) ([]byte, error) {
+	args := mock.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (mock *DocumentServiceMock) StockReport(ctx context.Context) ([]byte, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]byte), args.Error(1)
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/util"
+	"io"
+	"net/url"
+)
+
+type ExportServiceMock struct {
+	mock.Mock
+}
+
+func (mock *ExportServiceMock) Background(ctx context.Context, entity string, query *util.ListQuery) (bool, error) {
+	args := mock.Called(ctx, entity, query)
+	return args.Bool(0), args.Error(1)
+}
+
+// Export writes the string given as its first return value, as the export of the list, then
+// fails with the error given as the second.
+func (mock *ExportServiceMock) Export(ctx context.Context, entity string, query *util.ListQuery, format string, w io.Writer) (int64, error) {
+	args := mock.Called(ctx, entity, query, format)
+
+	_, err := io.WriteString(w, args.String(0))
+	if err != nil {
+		return 0, err
+	}
+	if args.Error(1) != nil {
+		return 0, args.Error(1)
+	}
+
+	return 1, nil
+}
+
+func (mock *ExportServiceMock) CreateJob(ctx context.Context, entity string, format string, values url.Values) (*response.ExportJobResponse, error) {
+	args := mock.Called(ctx, entity, format, values)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.ExportJobResponse), args.Error(1)
+}
+
+func (mock *ExportServiceMock) FindAllJobs(ctx context.Context, offset int, limit int) ([]*response.ExportJobResponse, error) {
+	args := mock.Called(ctx, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*response.ExportJobResponse), args.Error(1)
+}
+
+func (mock *ExportServiceMock) CountAllJobs(ctx context.Context) (int64, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return 0, args.Error(1)
+	}
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (mock *ExportServiceMock) FindJobByCode(ctx context.Context, code string) (*response.ExportJobResponse, error) {
+	args := mock.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.ExportJobResponse), args.Error(1)
+}
+
+func (mock *ExportServiceMock) JobFile(ctx context.Context, code string) (*response.ExportJobResponse, string, error) {
+	args := mock.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+
+	return args.Get(0).(*response.ExportJobResponse), args.String(1), args.Error(2)
+}
+
+func (mock *ExportServiceMock) Run(ctx context.Context) {
+	mock.Called(ctx)
+}
+
+func (mock *ExportServiceMock) RunNext(ctx context.Context) (bool, error) {
+	args := mock.Called(ctx)
+	return args.Bool(0), args.Error(1)
+}
+
+func (mock *ExportServiceMock) PurgeExpired(ctx context.Context) (int, error) {
+	args := mock.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/response"
+)
+
+type IdempotencyServiceMock struct {
+	mock.Mock
+}
+
+func (mock *IdempotencyServiceMock) Begin(ctx context.Context, key string, fingerprint string) (*response.IdempotentResponse, error) {
+	args := mock.Called(ctx, key, fingerprint)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.IdempotentResponse), args.Error(1)
+}
+
+func (mock *IdempotencyServiceMock) Complete(ctx context.Context, key string, fingerprint string, idempotentResponse *response.IdempotentResponse) error {
+	args := mock.Called(ctx, key, fingerprint, idempotentResponse)
+	return args.Error(0)
+}
+
+func (mock *IdempotencyServiceMock) Release(ctx context.Context, key string, fingerprint string) error {
+	args := mock.Called(ctx, key, fingerprint)
+	return args.Error(0)
+}
+
+func (mock *IdempotencyServiceMock) Run(ctx context.Context) {
+	mock.Called(ctx)
+}
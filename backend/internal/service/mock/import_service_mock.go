@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/util"
+)
+
+type ImportServiceMock struct {
+	mock.Mock
+}
+
+func (mock *ImportServiceMock) ImportProducts(ctx context.Context, rows []*util.SheetRow, dryRun bool) (*response.ImportResponse, error) {
+	args := mock.Called(ctx, rows, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.ImportResponse), args.Error(1)
+}
+
+func (mock *ImportServiceMock) ImportSuppliers(ctx context.Context, rows []*util.SheetRow, dryRun bool) (*response.ImportResponse, error) {
+	args := mock.Called(ctx, rows, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.ImportResponse), args.Error(1)
+}
+
+func (mock *ImportServiceMock) ImportCustomers(ctx context.Context, rows []*util.SheetRow, dryRun bool) (*response.ImportResponse, error) {
+	args := mock.Called(ctx, rows, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.ImportResponse), args.Error(1)
+}
+
+func (mock *ImportServiceMock) ImportOpeningStock(ctx context.Context, rows []*util.SheetRow, dryRun bool) (*response.ImportResponse, error) {
+	args := mock.Called(ctx, rows, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.ImportResponse), args.Error(1)
+}
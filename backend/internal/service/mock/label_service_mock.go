@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/request"
+)
+
+type LabelServiceMock struct {
+	mock.Mock
+}
+
+func (mock *LabelServiceMock) ProductQuality(ctx context.Context, id int64, request *request.LabelRequest) ([]byte, error) {
+	args := mock.Called(ctx, id, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (mock *LabelServiceMock) Transaction(ctx context.Context, code string, request *request.LabelRequest) ([]byte, error) {
+	args := mock.Called(ctx, code, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]byte), args.Error(1)
+}
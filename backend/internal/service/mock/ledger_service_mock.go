@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/response"
+)
+
+type LedgerServiceMock struct {
+	mock.Mock
+}
+
+func (mock *LedgerServiceMock) Verify(ctx context.Context) (*response.LedgerVerificationResponse, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.LedgerVerificationResponse), args.Error(1)
+}
+
+func (mock *LedgerServiceMock) Backfill(ctx context.Context) (int64, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return 0, args.Error(1)
+	}
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (mock *LedgerServiceMock) CreateCheckpoint(ctx context.Context) (*response.LedgerCheckpointResponse, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.LedgerCheckpointResponse), args.Error(1)
+}
+
+func (mock *LedgerServiceMock) ExportCheckpoints(ctx context.Context) (*response.LedgerCheckpointExportResponse, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.LedgerCheckpointExportResponse), args.Error(1)
+}
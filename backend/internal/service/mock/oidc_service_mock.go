@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+)
+
+type OidcServiceMock struct {
+	mock.Mock
+}
+
+func (mock *OidcServiceMock) LoginURL(ctx context.Context) (*response.OidcLoginResponse, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.OidcLoginResponse), args.Error(1)
+}
+
+func (mock *OidcServiceMock) LinkURL(ctx context.Context, username string) (*response.OidcLoginResponse, error) {
+	args := mock.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.OidcLoginResponse), args.Error(1)
+}
+
+func (mock *OidcServiceMock) Callback(ctx context.Context, request *request.OidcCallbackRequest) (*response.UserLoginResponse, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.UserLoginResponse), args.Error(1)
+}
+
+func (mock *OidcServiceMock) Run(ctx context.Context) {
+	mock.Called(ctx)
+}
@@ -41,3 +41,17 @@ func (mock *ProductQualityServiceMock) Delete(ctx context.Context, id int64) err
 	args := mock.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (mock *ProductQualityServiceMock) Restore(ctx context.Context, id int64) (*response.ProductQualityResponse, error) {
+	args := mock.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.ProductQualityResponse), args.Error(1)
+}
+
+func (mock *ProductQualityServiceMock) Purge(ctx context.Context, id int64) error {
+	args := mock.Called(ctx, id)
+	return args.Error(0)
+}
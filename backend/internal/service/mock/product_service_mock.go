@@ -5,14 +5,15 @@ import (
 	"github.com/stretchr/testify/mock"
 	"inventory-management/backend/internal/http/request"
 	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/util"
 )
 
 type ProductServiceMock struct {
 	mock.Mock
 }
 
-func (mock *ProductServiceMock) FindAll(ctx context.Context, offset int, limit int) ([]*response.ProductResponse, error) {
-	args := mock.Called(ctx, offset, limit)
+func (mock *ProductServiceMock) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*response.ProductResponse, error) {
+	args := mock.Called(ctx, query, offset, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -20,8 +21,17 @@ func (mock *ProductServiceMock) FindAll(ctx context.Context, offset int, limit i
 	return args.Get(0).([]*response.ProductResponse), args.Error(1)
 }
 
-func (mock *ProductServiceMock) CountAll(ctx context.Context) (int64, error) {
-	args := mock.Called(ctx)
+func (mock *ProductServiceMock) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*response.ProductResponse, *util.CursorPage, error) {
+	args := mock.Called(ctx, query, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+
+	return args.Get(0).([]*response.ProductResponse), args.Get(1).(*util.CursorPage), args.Error(2)
+}
+
+func (mock *ProductServiceMock) CountAll(ctx context.Context, query *util.ListQuery) (int64, error) {
+	args := mock.Called(ctx, query)
 	if args.Get(0) == nil {
 		return 0, args.Error(1)
 	}
@@ -60,3 +70,17 @@ func (mock *ProductServiceMock) Delete(ctx context.Context, code string) error {
 	args := mock.Called(ctx, code)
 	return args.Error(0)
 }
+
+func (mock *ProductServiceMock) Restore(ctx context.Context, code string) (*response.ProductResponse, error) {
+	args := mock.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.ProductResponse), args.Error(1)
+}
+
+func (mock *ProductServiceMock) Purge(ctx context.Context, code string) error {
+	args := mock.Called(ctx, code)
+	return args.Error(0)
+}
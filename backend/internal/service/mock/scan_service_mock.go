@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/response"
+)
+
+type ScanServiceMock struct {
+	mock.Mock
+}
+
+func (mock *ScanServiceMock) Scan(ctx context.Context, value string) (*response.ScanResponse, error) {
+	args := mock.Called(ctx, value)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.ScanResponse), args.Error(1)
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/response"
+)
+
+type SearchOutboxServiceMock struct {
+	mock.Mock
+}
+
+func (mock *SearchOutboxServiceMock) Run(ctx context.Context) {
+	mock.Called(ctx)
+}
+
+func (mock *SearchOutboxServiceMock) Relay(ctx context.Context) (int, error) {
+	args := mock.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (mock *SearchOutboxServiceMock) Stats(ctx context.Context) (*response.SearchOutboxStatsResponse, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.SearchOutboxStatsResponse), args.Error(1)
+}
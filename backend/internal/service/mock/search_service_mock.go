@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+)
+
+type SearchServiceMock struct {
+	mock.Mock
+}
+
+func (mock *SearchServiceMock) IndexProduct(ctx context.Context, code string) error {
+	args := mock.Called(ctx, code)
+	return args.Error(0)
+}
+
+func (mock *SearchServiceMock) IndexProductQuality(ctx context.Context, id int64) error {
+	args := mock.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (mock *SearchServiceMock) IndexTransaction(ctx context.Context, code string) error {
+	args := mock.Called(ctx, code)
+	return args.Error(0)
+}
+
+func (mock *SearchServiceMock) IndexSupplier(ctx context.Context, code string) error {
+	args := mock.Called(ctx, code)
+	return args.Error(0)
+}
+
+func (mock *SearchServiceMock) IndexCustomer(ctx context.Context, code string) error {
+	args := mock.Called(ctx, code)
+	return args.Error(0)
+}
+
+func (mock *SearchServiceMock) IndexUser(ctx context.Context, id int64) error {
+	args := mock.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (mock *SearchServiceMock) Remove(ctx context.Context, index string, id int64) error {
+	args := mock.Called(ctx, index, id)
+	return args.Error(0)
+}
+
+func (mock *SearchServiceMock) Reindex(ctx context.Context, index string) (*response.ReindexResponse, error) {
+	args := mock.Called(ctx, index)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.ReindexResponse), args.Error(1)
+}
+
+func (mock *SearchServiceMock) SearchProducts(ctx context.Context, request *request.ProductSearchRequest, offset int, limit int) (*response.SearchResponse, error) {
+	args := mock.Called(ctx, request, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.SearchResponse), args.Error(1)
+}
+
+func (mock *SearchServiceMock) SearchProductQualities(ctx context.Context, request *request.ProductQualitySearchRequest, offset int, limit int) (*response.SearchResponse, error) {
+	args := mock.Called(ctx, request, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.SearchResponse), args.Error(1)
+}
+
+func (mock *SearchServiceMock) SearchTransactions(ctx context.Context, request *request.TransactionSearchRequest, offset int, limit int) (*response.SearchResponse, error) {
+	args := mock.Called(ctx, request, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.SearchResponse), args.Error(1)
+}
+
+func (mock *SearchServiceMock) SearchSuppliers(ctx context.Context, request *request.SupplierSearchRequest, offset int, limit int) (*response.SearchResponse, error) {
+	args := mock.Called(ctx, request, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.SearchResponse), args.Error(1)
+}
+
+func (mock *SearchServiceMock) SearchCustomers(ctx context.Context, request *request.CustomerSearchRequest, offset int, limit int) (*response.SearchResponse, error) {
+	args := mock.Called(ctx, request, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.SearchResponse), args.Error(1)
+}
+
+func (mock *SearchServiceMock) Suggest(ctx context.Context, request *request.SuggestRequest) (*response.SuggestResponse, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.SuggestResponse), args.Error(1)
+}
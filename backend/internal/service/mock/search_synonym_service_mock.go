@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+)
+
+type SearchSynonymServiceMock struct {
+	mock.Mock
+}
+
+func (mock *SearchSynonymServiceMock) FindAll(ctx context.Context) ([]*response.SearchSynonymResponse, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*response.SearchSynonymResponse), args.Error(1)
+}
+
+func (mock *SearchSynonymServiceMock) Create(ctx context.Context, request *request.CreateSearchSynonymRequest) (*response.SearchSynonymResponse, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.SearchSynonymResponse), args.Error(1)
+}
+
+func (mock *SearchSynonymServiceMock) Update(ctx context.Context, request *request.UpdateSearchSynonymRequest) (*response.SearchSynonymResponse, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.SearchSynonymResponse), args.Error(1)
+}
+
+func (mock *SearchSynonymServiceMock) Delete(ctx context.Context, id int64) error {
+	args := mock.Called(ctx, id)
+	return args.Error(0)
+}
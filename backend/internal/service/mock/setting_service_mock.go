@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+)
+
+type SettingServiceMock struct {
+	mock.Mock
+}
+
+func (mock *SettingServiceMock) FindBranding(ctx context.Context) (*response.BrandingSettingResponse, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.BrandingSettingResponse), args.Error(1)
+}
+
+func (mock *SettingServiceMock) UpdateBranding(ctx context.Context, request *request.UpdateBrandingSettingRequest) (*response.BrandingSettingResponse, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.BrandingSettingResponse), args.Error(1)
+}
+
+func (mock *SettingServiceMock) FindAllLabelTemplates(ctx context.Context) ([]*response.LabelTemplateResponse, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*response.LabelTemplateResponse), args.Error(1)
+}
+
+func (mock *SettingServiceMock) UpdateLabelTemplate(ctx context.Context, request *request.UpdateLabelTemplateRequest) (*response.LabelTemplateResponse, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.LabelTemplateResponse), args.Error(1)
+}
+
+func (mock *SettingServiceMock) FindAllNumberingSequences(ctx context.Context) ([]*response.NumberingSequenceResponse, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*response.NumberingSequenceResponse), args.Error(1)
+}
+
+func (mock *SettingServiceMock) UpdateNumberingSequence(ctx context.Context, request *request.UpdateNumberingSequenceRequest) (*response.NumberingSequenceResponse, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.NumberingSequenceResponse), args.Error(1)
+}
@@ -5,14 +5,15 @@ import (
 	"github.com/stretchr/testify/mock"
 	"inventory-management/backend/internal/http/request"
 	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/util"
 )
 
 type SupplierServiceMock struct {
 	mock.Mock
 }
 
-func (mock *SupplierServiceMock) FindAll(ctx context.Context, offset int, limit int) ([]*response.SupplierResponse, error) {
-	args := mock.Called(ctx, offset, limit)
+func (mock *SupplierServiceMock) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*response.SupplierResponse, error) {
+	args := mock.Called(ctx, query, offset, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -20,8 +21,17 @@ func (mock *SupplierServiceMock) FindAll(ctx context.Context, offset int, limit
 	return args.Get(0).([]*response.SupplierResponse), args.Error(1)
 }
 
-func (mock *SupplierServiceMock) CountAll(ctx context.Context) (int64, error) {
-	args := mock.Called(ctx)
+func (mock *SupplierServiceMock) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*response.SupplierResponse, *util.CursorPage, error) {
+	args := mock.Called(ctx, query, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+
+	return args.Get(0).([]*response.SupplierResponse), args.Get(1).(*util.CursorPage), args.Error(2)
+}
+
+func (mock *SupplierServiceMock) CountAll(ctx context.Context, query *util.ListQuery) (int64, error) {
+	args := mock.Called(ctx, query)
 	if args.Get(0) == nil {
 		return 0, args.Error(1)
 	}
@@ -60,3 +70,17 @@ func (mock *SupplierServiceMock) Delete(ctx context.Context, code string) error
 	args := mock.Called(ctx, code)
 	return args.Error(0)
 }
+
+func (mock *SupplierServiceMock) Restore(ctx context.Context, code string) (*response.SupplierResponse, error) {
+	args := mock.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.SupplierResponse), args.Error(1)
+}
+
+func (mock *SupplierServiceMock) Purge(ctx context.Context, code string) error {
+	args := mock.Called(ctx, code)
+	return args.Error(0)
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+)
+
+type TenantServiceMock struct {
+	mock.Mock
+}
+
+func (mock *TenantServiceMock) FindAll(ctx context.Context, offset int, limit int) ([]*response.TenantResponse, error) {
+	args := mock.Called(ctx, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*response.TenantResponse), args.Error(1)
+}
+
+func (mock *TenantServiceMock) CountAll(ctx context.Context) (int64, error) {
+	args := mock.Called(ctx)
+	if args.Get(0) == nil {
+		return 0, args.Error(1)
+	}
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (mock *TenantServiceMock) FindByID(ctx context.Context, id int64) (*response.TenantResponse, error) {
+	args := mock.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.TenantResponse), args.Error(1)
+}
+
+func (mock *TenantServiceMock) Create(ctx context.Context, request *request.CreateTenantRequest) (*response.TenantResponse, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.TenantResponse), args.Error(1)
+}
+
+func (mock *TenantServiceMock) Update(ctx context.Context, request *request.UpdateTenantRequest) (*response.TenantResponse, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.TenantResponse), args.Error(1)
+}
+
+func (mock *TenantServiceMock) RotateApiKey(ctx context.Context, id int64) (*response.TenantResponse, error) {
+	args := mock.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.TenantResponse), args.Error(1)
+}
+
+func (mock *TenantServiceMock) ResolveApiKey(ctx context.Context, apiKey string) (*response.TenantResponse, error) {
+	args := mock.Called(ctx, apiKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.TenantResponse), args.Error(1)
+}
@@ -5,14 +5,15 @@ import (
 	"github.com/stretchr/testify/mock"
 	"inventory-management/backend/internal/http/request"
 	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/util"
 )
 
 type TransactionServiceMock struct {
 	mock.Mock
 }
 
-func (mock *TransactionServiceMock) FindAll(ctx context.Context, offset int, limit int) ([]*response.TransactionResponse, error) {
-	args := mock.Called(ctx, offset, limit)
+func (mock *TransactionServiceMock) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*response.TransactionResponse, error) {
+	args := mock.Called(ctx, query, offset, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -20,31 +21,31 @@ func (mock *TransactionServiceMock) FindAll(ctx context.Context, offset int, lim
 	return args.Get(0).([]*response.TransactionResponse), args.Error(1)
 }
 
-func (mock *TransactionServiceMock) CountAll(ctx context.Context) (int64, error) {
-	args := mock.Called(ctx)
+func (mock *TransactionServiceMock) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*response.TransactionResponse, *util.CursorPage, error) {
+	args := mock.Called(ctx, query, cursor, limit)
 	if args.Get(0) == nil {
-		return 0, args.Error(1)
+		return nil, nil, args.Error(2)
 	}
 
-	return args.Get(0).(int64), args.Error(1)
+	return args.Get(0).([]*response.TransactionResponse), args.Get(1).(*util.CursorPage), args.Error(2)
 }
 
-func (mock *TransactionServiceMock) FindAllBySupplierCode(ctx context.Context, supplierCode string) ([]*response.TransactionResponse, error) {
-	args := mock.Called(ctx, supplierCode)
+func (mock *TransactionServiceMock) CountAll(ctx context.Context, query *util.ListQuery) (int64, error) {
+	args := mock.Called(ctx, query)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return 0, args.Error(1)
 	}
 
-	return args.Get(0).([]*response.TransactionResponse), args.Error(1)
+	return args.Get(0).(int64), args.Error(1)
 }
 
-func (mock *TransactionServiceMock) FindAllByCustomerCode(ctx context.Context, customerCode string) ([]*response.TransactionResponse, error) {
-	args := mock.Called(ctx, customerCode)
+func (mock *TransactionServiceMock) Summary(ctx context.Context, query *util.ListQuery) (*response.TransactionSummaryResponse, error) {
+	args := mock.Called(ctx, query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 
-	return args.Get(0).([]*response.TransactionResponse), args.Error(1)
+	return args.Get(0).(*response.TransactionSummaryResponse), args.Error(1)
 }
 
 func (mock *TransactionServiceMock) FindByCode(ctx context.Context, code string) (*response.TransactionResponse, error) {
@@ -87,3 +88,12 @@ func (mock *TransactionServiceMock) Delete(ctx context.Context, code string) err
 	args := mock.Called(ctx, code)
 	return args.Error(0)
 }
+
+func (mock *TransactionServiceMock) CreateBatch(ctx context.Context, request *request.CreateTransactionBatchRequest) (*response.TransactionBatchResponse, error) {
+	args := mock.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.TransactionBatchResponse), args.Error(1)
+}
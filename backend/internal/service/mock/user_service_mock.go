@@ -1,28 +1,28 @@
 package service
 
 import (
-	"bytes"
 	"context"
 	"github.com/stretchr/testify/mock"
 	"inventory-management/backend/internal/http/request"
 	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/util"
 )
 
 type UserServiceMock struct {
 	mock.Mock
 }
 
-func (mock *UserServiceMock) Search(ctx context.Context, data bytes.Buffer, offset int, limit int, totalRecord chan<- int64) (map[string]interface{}, error) {
-	args := mock.Called(ctx, data, offset, limit, totalRecord)
+func (mock *UserServiceMock) Search(ctx context.Context, request *request.UserSearchRequest, offset int, limit int) (*response.UserSearchResponse, error) {
+	args := mock.Called(ctx, request, offset, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 
-	return args.Get(0).(map[string]interface{}), args.Error(1)
+	return args.Get(0).(*response.UserSearchResponse), args.Error(1)
 }
 
-func (mock *UserServiceMock) FindAll(ctx context.Context, offset int, limit int) ([]*response.UserResponse, error) {
-	args := mock.Called(ctx, offset, limit)
+func (mock *UserServiceMock) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*response.UserResponse, error) {
+	args := mock.Called(ctx, query, offset, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -30,8 +30,17 @@ func (mock *UserServiceMock) FindAll(ctx context.Context, offset int, limit int)
 	return args.Get(0).([]*response.UserResponse), args.Error(1)
 }
 
-func (mock *UserServiceMock) CountAll(ctx context.Context) (int64, error) {
-	args := mock.Called(ctx)
+func (mock *UserServiceMock) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*response.UserResponse, *util.CursorPage, error) {
+	args := mock.Called(ctx, query, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+
+	return args.Get(0).([]*response.UserResponse), args.Get(1).(*util.CursorPage), args.Error(2)
+}
+
+func (mock *UserServiceMock) CountAll(ctx context.Context, query *util.ListQuery) (int64, error) {
+	args := mock.Called(ctx, query)
 	if args.Get(0) == nil {
 		return 0, args.Error(1)
 	}
@@ -57,6 +66,11 @@ func (mock *UserServiceMock) VerifyLogin(ctx context.Context, request *request.L
 	return args.Get(0).(*response.UserLoginResponse), args.Error(1)
 }
 
+func (mock *UserServiceMock) VerifyTokenVersion(ctx context.Context, username string, tokenVersion int64) error {
+	args := mock.Called(ctx, username, tokenVersion)
+	return args.Error(0)
+}
+
 func (mock *UserServiceMock) Create(ctx context.Context, request *request.CreateUserRequest) (*response.UserResponse, error) {
 	args := mock.Called(ctx, request)
 	if args.Get(0) == nil {
@@ -79,3 +93,17 @@ func (mock *UserServiceMock) Delete(ctx context.Context, id int64) error {
 	args := mock.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (mock *UserServiceMock) Restore(ctx context.Context, id int64) (*response.UserResponse, error) {
+	args := mock.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*response.UserResponse), args.Error(1)
+}
+
+func (mock *UserServiceMock) Purge(ctx context.Context, id int64) error {
+	args := mock.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,352 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/internal/repository"
+	third_party "inventory-management/backend/internal/third_party/oidc"
+	"inventory-management/backend/util"
+	"log"
+	"strconv"
+	"time"
+)
+
+// oidcPurgeInterval is how often the expired login states are deleted.
+const oidcPurgeInterval = time.Hour
+
+type OidcService struct {
+	UserRepository           repository.UserRepositoryContract
+	OidcLoginStateRepository repository.OidcLoginStateRepositoryContract
+	Oidc                     third_party.OidcContract
+	Transactor               repository.TransactorContract
+	AuditService             AuditServiceContract
+	// GroupRoles maps the groups of the identity provider to roles. Without it the identity
+	// provider does not manage roles: new users get DefaultRole and linked users keep theirs
+	GroupRoles  map[string]string
+	DefaultRole string
+	// LinkVerifiedEmail lets the first login link the local account owning the email, when
+	// the identity provider verified it
+	LinkVerifiedEmail bool
+	// StateKey signs the cookie binding a login to the browser that started it
+	StateKey []byte
+	StateTTL time.Duration
+}
+
+func NewOidcService(userRepository repository.UserRepositoryContract, oidcLoginStateRepository repository.OidcLoginStateRepositoryContract, oidc third_party.OidcContract, transactor repository.TransactorContract, auditService AuditServiceContract, groupRoles map[string]string, defaultRole string, linkVerifiedEmail bool, stateKey []byte) OidcServiceContract {
+	if defaultRole == "" {
+		defaultRole = model.RoleStaff
+	}
+
+	return &OidcService{
+		UserRepository:           userRepository,
+		OidcLoginStateRepository: oidcLoginStateRepository,
+		Oidc:                     oidc,
+		Transactor:               transactor,
+		AuditService:             auditService,
+		GroupRoles:               groupRoles,
+		DefaultRole:              defaultRole,
+		LinkVerifiedEmail:        linkVerifiedEmail,
+		StateKey:                 stateKey,
+		StateTTL:                 10 * time.Minute,
+	}
+}
+
+func (service *OidcService) LoginURL(ctx context.Context) (*response.OidcLoginResponse, error) {
+	return service.authorizationURL(ctx, "")
+}
+
+// LinkURL starts a login whose identity is linked to the signed in user, which is the way an
+// existing local account gets single sign-on.
+func (service *OidcService) LinkURL(ctx context.Context, username string) (*response.OidcLoginResponse, error) {
+	if username == "" {
+		return nil, errors.New(response.ErrorNotFound)
+	}
+
+	return service.authorizationURL(ctx, username)
+}
+
+func (service *OidcService) authorizationURL(ctx context.Context, linkUsername string) (*response.OidcLoginResponse, error) {
+	state, err := util.GenerateRandomString(32)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := util.GenerateRandomString(32)
+	if err != nil {
+		return nil, err
+	}
+
+	codeVerifier, err := third_party.GeneratePkceVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	authorizationURL, err := service.Oidc.AuthCodeURL(ctx, state, nonce, third_party.PkceChallenge(codeVerifier))
+	if err != nil {
+		return nil, err
+	}
+
+	loginState := &model.OidcLoginState{
+		StateHash:    util.HashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		LinkUsername: linkUsername,
+		ExpiresAt:    time.Now().Add(service.StateTTL),
+	}
+	if tenantID, ok := util.TenantFromContext(ctx); ok {
+		loginState.LoginTenantID = &tenantID
+	}
+
+	_, err = service.OidcLoginStateRepository.Create(ctx, loginState)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.OidcLoginResponse{
+		AuthorizationURL: authorizationURL,
+		State:            state,
+		Cookie:           util.SignValue(service.StateKey, state),
+	}, nil
+}
+
+func (service *OidcService) Callback(ctx context.Context, request *request.OidcCallbackRequest) (*response.UserLoginResponse, error) {
+	// The state must come back to the browser that started the login, whose cookie holds it
+	cookieState, ok := util.VerifySignedValue(service.StateKey, request.Cookie)
+	if !ok || subtle.ConstantTimeCompare([]byte(cookieState), []byte(request.State)) != 1 {
+		return nil, errors.New(response.ErrorOidcInvalidState)
+	}
+
+	loginState, err := service.OidcLoginStateRepository.Consume(ctx, util.HashToken(request.State))
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return nil, errors.New(response.ErrorOidcInvalidState)
+		}
+		return nil, err
+	}
+
+	claims, err := service.Oidc.Exchange(ctx, request.Code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	// The login goes on in the tenant it was started in
+	if loginState.LoginTenantID != nil {
+		ctx = withTenant(ctx, *loginState.LoginTenantID)
+	}
+
+	var user *model.User
+	if loginState.LinkUsername != "" {
+		user, err = service.linkUser(ctx, loginState.LinkUsername, claims)
+	} else {
+		user, err = service.provisionUser(ctx, claims)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := user.GenerateTokenJWT()
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.UserLoginResponse{
+		Token: token,
+	}, nil
+}
+
+// provisionUser returns the user linked to the identity, creating one on first login. The
+// username and email claims are chosen by the user at the identity provider, so they never
+// link an existing local account, except for a verified email when LinkVerifiedEmail is set.
+func (service *OidcService) provisionUser(ctx context.Context, claims *third_party.OidcClaims) (*model.User, error) {
+	user, err := service.UserRepository.FindByOidcSubject(ctx, claims.Issuer, claims.Subject)
+	if err != nil && err.Error() != response.ErrorNotFound {
+		return nil, err
+	}
+
+	if user == nil && service.LinkVerifiedEmail && claims.EmailVerified && claims.Email != "" {
+		user, err = service.UserRepository.FindByEmail(util.WithoutTenant(ctx), claims.Email)
+		if err != nil && err.Error() != response.ErrorNotFound {
+			return nil, err
+		}
+
+		if user != nil {
+			if user.OidcSubject != nil {
+				return nil, errors.New(response.ErrorOidcAccountConflict)
+			}
+
+			user.OidcIssuer = &claims.Issuer
+			user.OidcSubject = &claims.Subject
+		}
+	}
+
+	if user == nil {
+		return service.createUser(ctx, claims)
+	}
+
+	return service.syncUser(withTenant(ctx, user.TenantID), user, claims)
+}
+
+// linkUser links the identity to the signed in user who started the login.
+func (service *OidcService) linkUser(ctx context.Context, username string, claims *third_party.OidcClaims) (*model.User, error) {
+	user, err := service.UserRepository.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	linkedUser, err := service.UserRepository.FindByOidcSubject(ctx, claims.Issuer, claims.Subject)
+	if err != nil && err.Error() != response.ErrorNotFound {
+		return nil, err
+	}
+
+	if linkedUser != nil && linkedUser.ID != user.ID {
+		return nil, errors.New(response.ErrorOidcAccountConflict)
+	}
+
+	if user.OidcSubject != nil && (*user.OidcIssuer != claims.Issuer || *user.OidcSubject != claims.Subject) {
+		return nil, errors.New(response.ErrorOidcAccountConflict)
+	}
+
+	user.OidcIssuer = &claims.Issuer
+	user.OidcSubject = &claims.Subject
+
+	return service.syncUser(ctx, user, claims)
+}
+
+func (service *OidcService) createUser(ctx context.Context, claims *third_party.OidcClaims) (*model.User, error) {
+	username := claims.PreferredUsername
+	if username == "" {
+		username = claims.Email
+	}
+
+	if username == "" {
+		return nil, errors.New(response.ErrorOidcUsernameMissing)
+	}
+
+	// New users are created in the tenant whose API key started the login, never in a default one
+	if _, ok := util.TenantFromContext(ctx); !ok {
+		return nil, errors.New(response.ErrorTenantMissing)
+	}
+
+	// Usernames are unique across tenants and deleted users keep theirs
+	_, err := service.UserRepository.FindByUsername(util.WithDeleted(util.WithoutTenant(ctx)), username)
+	if err == nil {
+		return nil, errors.New(response.ErrorOidcUsernameTaken)
+	}
+	if err.Error() != response.ErrorNotFound {
+		return nil, err
+	}
+
+	password, err := util.GenerateRandomString(32)
+	if err != nil {
+		return nil, err
+	}
+
+	var userRequest model.User
+	userRequest.Name = claims.Name
+	if userRequest.Name == "" {
+		userRequest.Name = username
+	}
+	userRequest.Username = username
+	userRequest.Password = password
+	userRequest.Role = service.mapRole(claims.Groups)
+	userRequest.OidcIssuer = &claims.Issuer
+	userRequest.OidcSubject = &claims.Subject
+
+	var user *model.User
+	err = service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = service.UserRepository.Create(ctx, &userRequest)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityUser, strconv.FormatInt(user.ID, 10), model.AuditActionCreate, nil, user.ToResponse())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// syncUser updates the name of a linked user from the claims, and the role when the identity
+// provider manages roles through GroupRoles.
+func (service *OidcService) syncUser(ctx context.Context, user *model.User, claims *third_party.OidcClaims) (*model.User, error) {
+	before := user.ToResponse()
+	if claims.Name != "" {
+		user.Name = claims.Name
+	}
+	if len(service.GroupRoles) > 0 {
+		user.Role = service.mapRole(claims.Groups)
+	}
+
+	err := service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = service.UserRepository.Update(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityUser, strconv.FormatInt(user.ID, 10), model.AuditActionUpdate, before, user.ToResponse())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (service *OidcService) mapRole(groups []string) string {
+	role := ""
+	for _, group := range groups {
+		mappedRole, ok := service.GroupRoles[group]
+		if !ok {
+			continue
+		}
+
+		if mappedRole == model.RoleAdmin {
+			return mappedRole
+		}
+
+		if role == "" {
+			role = mappedRole
+		}
+	}
+
+	if role == "" {
+		return service.DefaultRole
+	}
+
+	return role
+}
+
+// Run deletes the expired login states until the context is done.
+func (service *OidcService) Run(ctx context.Context) {
+	ticker := time.NewTicker(oidcPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := service.OidcLoginStateRepository.DeleteExpired(ctx, time.Now())
+			if err != nil {
+				log.Println("Cannot delete the expired OIDC login states", err)
+			}
+		}
+	}
+}
+
+// withTenant scopes the context to the tenant, keeping it as it is when it already is.
+func withTenant(ctx context.Context, tenantID int64) context.Context {
+	if current, ok := util.TenantFromContext(ctx); ok && current == tenantID {
+		return ctx
+	}
+
+	return util.WithTenant(ctx, tenantID)
+}
@@ -0,0 +1,403 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/request"
+	response "inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	repository "inventory-management/backend/internal/repository/mock"
+	service "inventory-management/backend/internal/service/mock"
+	third_party "inventory-management/backend/internal/third_party/oidc"
+	"inventory-management/backend/util"
+	"testing"
+	"time"
+)
+
+func TestOidcService_LoginURL(t *testing.T) {
+	ctx := context.Background()
+
+	var repo repository.UserRepositoryMock
+	var oidc third_party.OidcMock
+	oidc.On("AuthCodeURL", ctx, mock.Anything, mock.Anything, mock.Anything).Return("http://idp/authorize", nil)
+
+	var stateRepo repository.OidcLoginStateRepositoryMock
+	stateRepo.On("Create", ctx, mock.Anything).Return(&model.OidcLoginState{}, nil)
+
+	var audit service.AuditServiceMock
+	svc := NewOidcService(&repo, &stateRepo, &oidc, &repository.TransactorMock{}, &audit, nil, "", false, oidcStateKey)
+	result, err := svc.LoginURL(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "http://idp/authorize", result.AuthorizationURL)
+	assert.Len(t, result.State, 32)
+	assert.Equal(t, util.SignValue(oidcStateKey, result.State), result.Cookie)
+
+	saved := stateRepo.Calls[0].Arguments.Get(1).(*model.OidcLoginState)
+	assert.Equal(t, util.HashToken(result.State), saved.StateHash)
+	assert.NotEmpty(t, saved.Nonce)
+	assert.NotEmpty(t, saved.CodeVerifier)
+	assert.True(t, saved.ExpiresAt.After(time.Now()))
+}
+
+var oidcStateKey = []byte("0123456789abcdef0123456789abcdef")
+
+// newOidcLoginStateRepository keeps the login state the service creates and gives it back once,
+// as the database does.
+func newOidcLoginStateRepository(ctx context.Context) *repository.OidcLoginStateRepositoryMock {
+	var loginState model.OidcLoginState
+	var stateRepo repository.OidcLoginStateRepositoryMock
+	stateRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		loginState = *args.Get(1).(*model.OidcLoginState)
+	}).Return(&loginState, nil)
+	stateRepo.On("Consume", mock.Anything, mock.MatchedBy(func(stateHash string) bool {
+		return stateHash == loginState.StateHash
+	})).Return(&loginState, nil).Once()
+	stateRepo.On("Consume", mock.Anything, mock.Anything).Return(nil, errors.New(response.ErrorNotFound))
+
+	return &stateRepo
+}
+
+func TestOidcService_Callback(t *testing.T) {
+	testCases := []struct {
+		name                           string
+		unknownState                   bool
+		otherCookie                    bool
+		withoutGroupRoles              bool
+		withoutTenant                  bool
+		linkVerifiedEmail              bool
+		emailVerified                  bool
+		preferredUsername              string
+		email                          string
+		expectedOidcExchangeError      error
+		expectedFindByOidcSubject      *model.User
+		expectedFindByEmail            *model.User
+		expectedFindByUsername         *model.User
+		expectedRepoCreateOrUpdateCall string
+		expectedRole                   string
+		expectedSvcError               error
+	}{
+		{
+			name:                           "First login provisions a new user",
+			preferredUsername:              "wdyarfn",
+			expectedRepoCreateOrUpdateCall: "Create",
+			expectedRole:                   model.RoleAdmin,
+		},
+		{
+			name:              "First login does not link the local user with the same username",
+			preferredUsername: "wdyarfn",
+			expectedFindByUsername: &model.User{
+				ID:       1,
+				TenantID: 2,
+				Name:     "Widdy",
+				Username: "wdyarfn",
+				Role:     model.RoleAdmin,
+			},
+			expectedSvcError: errors.New(response.ErrorOidcUsernameTaken),
+		},
+		{
+			name:              "Returning login updates the linked user",
+			preferredUsername: "wdyarfn",
+			expectedFindByOidcSubject: &model.User{
+				ID:          1,
+				TenantID:    2,
+				Name:        "Widdy",
+				Username:    "wdyarfn",
+				Role:        model.RoleStaff,
+				OidcIssuer:  util.ToPointerString("http://idp"),
+				OidcSubject: util.ToPointerString("user-123"),
+			},
+			expectedRepoCreateOrUpdateCall: "Update",
+			expectedRole:                   model.RoleAdmin,
+		},
+		{
+			name:              "Returning login keeps the role without a group mapping",
+			withoutGroupRoles: true,
+			preferredUsername: "wdyarfn",
+			expectedFindByOidcSubject: &model.User{
+				ID:          1,
+				TenantID:    2,
+				Name:        "Widdy",
+				Username:    "wdyarfn",
+				Role:        model.RoleStaff,
+				OidcIssuer:  util.ToPointerString("http://idp"),
+				OidcSubject: util.ToPointerString("user-123"),
+			},
+			expectedRepoCreateOrUpdateCall: "Update",
+			expectedRole:                   model.RoleStaff,
+		},
+		{
+			name:              "First login links the local user owning the verified email when enabled",
+			linkVerifiedEmail: true,
+			emailVerified:     true,
+			preferredUsername: "widdy",
+			email:             "wdyarfn@example.com",
+			expectedFindByEmail: &model.User{
+				ID:       1,
+				TenantID: 2,
+				Name:     "Widdy",
+				Username: "wdyarfn",
+				Email:    util.ToPointerString("wdyarfn@example.com"),
+				Role:     model.RoleStaff,
+			},
+			expectedRepoCreateOrUpdateCall: "Update",
+			expectedRole:                   model.RoleAdmin,
+		},
+		{
+			name:              "First login does not link by an unverified email",
+			linkVerifiedEmail: true,
+			preferredUsername: "widdy",
+			email:             "wdyarfn@example.com",
+			expectedFindByEmail: &model.User{
+				ID:       1,
+				TenantID: 2,
+				Username: "wdyarfn",
+				Email:    util.ToPointerString("wdyarfn@example.com"),
+			},
+			expectedRepoCreateOrUpdateCall: "Create",
+			expectedRole:                   model.RoleAdmin,
+		},
+		{
+			name:              "First login does not link by a verified email when disabled",
+			emailVerified:     true,
+			preferredUsername: "widdy",
+			email:             "wdyarfn@example.com",
+			expectedFindByEmail: &model.User{
+				ID:       1,
+				TenantID: 2,
+				Username: "wdyarfn",
+				Email:    util.ToPointerString("wdyarfn@example.com"),
+			},
+			expectedRepoCreateOrUpdateCall: "Create",
+			expectedRole:                   model.RoleAdmin,
+		},
+		{
+			name:              "Local user owning the verified email is already linked to another identity",
+			linkVerifiedEmail: true,
+			emailVerified:     true,
+			email:             "wdyarfn@example.com",
+			expectedFindByEmail: &model.User{
+				ID:          1,
+				TenantID:    2,
+				Username:    "wdyarfn",
+				Email:       util.ToPointerString("wdyarfn@example.com"),
+				OidcIssuer:  util.ToPointerString("http://idp"),
+				OidcSubject: util.ToPointerString("user-456"),
+			},
+			expectedSvcError: errors.New(response.ErrorOidcAccountConflict),
+		},
+		{
+			name:              "First login without the API key of a tenant",
+			withoutTenant:     true,
+			preferredUsername: "wdyarfn",
+			expectedSvcError:  errors.New(response.ErrorTenantMissing),
+		},
+		{
+			name:             "Identity without a username or an email",
+			expectedSvcError: errors.New(response.ErrorOidcUsernameMissing),
+		},
+		{
+			name:             "Unknown login state",
+			unknownState:     true,
+			expectedSvcError: errors.New(response.ErrorOidcInvalidState),
+		},
+		{
+			name:             "Login state of another browser",
+			otherCookie:      true,
+			expectedSvcError: errors.New(response.ErrorOidcInvalidState),
+		},
+		{
+			name:                      "Provider rejects the authorization code",
+			expectedOidcExchangeError: errors.New("invalid_grant"),
+			expectedSvcError:          errors.New("invalid_grant"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := util.WithTenant(context.Background(), 2)
+			if tc.withoutTenant {
+				ctx = util.WithoutTenant(context.Background())
+			}
+
+			claims := &third_party.OidcClaims{
+				Issuer:            "http://idp",
+				Subject:           "user-123",
+				Email:             tc.email,
+				EmailVerified:     tc.emailVerified,
+				Name:              "Widdy Arfiansyah",
+				PreferredUsername: tc.preferredUsername,
+				Groups:            []string{"clerks", "warehouse-admins"},
+			}
+
+			var repo repository.UserRepositoryMock
+			var oidc third_party.OidcMock
+			oidc.On("AuthCodeURL", ctx, mock.Anything, mock.Anything, mock.Anything).Return("http://idp/authorize", nil)
+			oidc.On("Exchange", ctx, "code", mock.Anything, mock.Anything).Return(claims, tc.expectedOidcExchangeError)
+
+			if tc.expectedFindByOidcSubject != nil {
+				repo.On("FindByOidcSubject", ctx, claims.Issuer, claims.Subject).Return(tc.expectedFindByOidcSubject, nil)
+			} else {
+				repo.On("FindByOidcSubject", ctx, claims.Issuer, claims.Subject).Return(nil, errors.New(response.ErrorNotFound))
+			}
+
+			if tc.expectedFindByEmail != nil {
+				repo.On("FindByEmail", mock.Anything, tc.email).Return(tc.expectedFindByEmail, nil)
+			} else {
+				repo.On("FindByEmail", mock.Anything, mock.Anything).Return(nil, errors.New(response.ErrorNotFound))
+			}
+
+			if tc.expectedFindByUsername != nil {
+				repo.On("FindByUsername", mock.Anything, tc.preferredUsername).Return(tc.expectedFindByUsername, nil)
+			} else {
+				repo.On("FindByUsername", mock.Anything, mock.Anything).Return(nil, errors.New(response.ErrorNotFound))
+			}
+
+			var savedUser *model.User
+			saveUser := func(args mock.Arguments) {
+				savedUser = args.Get(1).(*model.User)
+			}
+			repo.On("Create", ctx, mock.Anything).Run(saveUser).Return(&model.User{Username: "wdyarfn", Role: model.RoleAdmin}, nil)
+			repo.On("Update", ctx, mock.Anything).Run(saveUser).Return(&model.User{Username: "wdyarfn", Role: model.RoleAdmin}, nil)
+
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			groupRoles := map[string]string{"warehouse-admins": model.RoleAdmin, "clerks": model.RoleStaff}
+			if tc.withoutGroupRoles {
+				groupRoles = nil
+			}
+			svc := NewOidcService(&repo, newOidcLoginStateRepository(ctx), &oidc, &repository.TransactorMock{}, &audit, groupRoles, "", tc.linkVerifiedEmail, oidcStateKey)
+			login, err := svc.LoginURL(ctx)
+			assert.Nil(t, err)
+
+			state := login.State
+			cookie := login.Cookie
+			if tc.unknownState {
+				state = "unknown"
+				cookie = util.SignValue(oidcStateKey, state)
+			}
+			if tc.otherCookie {
+				cookie = util.SignValue(oidcStateKey, "other")
+			}
+
+			result, err := svc.Callback(ctx, &request.OidcCallbackRequest{Code: "code", State: state, Cookie: cookie})
+			if tc.expectedSvcError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+				assert.Nil(t, result)
+				repo.AssertNotCalled(t, "Create", ctx, mock.Anything)
+				repo.AssertNotCalled(t, "Update", ctx, mock.Anything)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.NotEmpty(t, result.Token)
+			repo.AssertCalled(t, tc.expectedRepoCreateOrUpdateCall, ctx, mock.Anything)
+			assert.Equal(t, tc.expectedRole, savedUser.Role)
+			assert.Equal(t, claims.Subject, *savedUser.OidcSubject)
+			if tc.expectedFindByEmail != nil && tc.expectedRepoCreateOrUpdateCall == "Create" {
+				assert.Equal(t, tc.preferredUsername, savedUser.Username)
+			}
+
+			// The login state can only be used once
+			_, err = svc.Callback(ctx, &request.OidcCallbackRequest{Code: "code", State: state, Cookie: cookie})
+			assert.Equal(t, response.ErrorOidcInvalidState, err.Error())
+		})
+	}
+}
+
+func TestOidcService_LinkCallback(t *testing.T) {
+	claims := &third_party.OidcClaims{
+		Issuer:            "http://idp",
+		Subject:           "user-123",
+		Name:              "Widdy Arfiansyah",
+		PreferredUsername: "someone-else",
+		Groups:            []string{"clerks"},
+	}
+
+	testCases := []struct {
+		name                      string
+		expectedFindByOidcSubject *model.User
+		expectedFindByUsername    *model.User
+		expectedSvcError          error
+	}{
+		{
+			name: "Identity is linked to the signed in user",
+			expectedFindByUsername: &model.User{
+				ID:       1,
+				Name:     "Widdy",
+				Username: "wdyarfn",
+				Role:     model.RoleAdmin,
+			},
+		},
+		{
+			name: "Identity is already linked to another user",
+			expectedFindByOidcSubject: &model.User{
+				ID:          2,
+				Username:    "someone-else",
+				OidcIssuer:  util.ToPointerString("http://idp"),
+				OidcSubject: util.ToPointerString("user-123"),
+			},
+			expectedFindByUsername: &model.User{
+				ID:       1,
+				Username: "wdyarfn",
+			},
+			expectedSvcError: errors.New(response.ErrorOidcAccountConflict),
+		},
+		{
+			name: "Signed in user is already linked to another identity",
+			expectedFindByUsername: &model.User{
+				ID:          1,
+				Username:    "wdyarfn",
+				OidcIssuer:  util.ToPointerString("http://idp"),
+				OidcSubject: util.ToPointerString("user-456"),
+			},
+			expectedSvcError: errors.New(response.ErrorOidcAccountConflict),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := util.WithTenant(context.Background(), 2)
+
+			var repo repository.UserRepositoryMock
+			var oidc third_party.OidcMock
+			oidc.On("AuthCodeURL", ctx, mock.Anything, mock.Anything, mock.Anything).Return("http://idp/authorize", nil)
+			oidc.On("Exchange", ctx, "code", mock.Anything, mock.Anything).Return(claims, nil)
+
+			if tc.expectedFindByOidcSubject != nil {
+				repo.On("FindByOidcSubject", ctx, claims.Issuer, claims.Subject).Return(tc.expectedFindByOidcSubject, nil)
+			} else {
+				repo.On("FindByOidcSubject", ctx, claims.Issuer, claims.Subject).Return(nil, errors.New(response.ErrorNotFound))
+			}
+			repo.On("FindByUsername", ctx, "wdyarfn").Return(tc.expectedFindByUsername, nil)
+
+			var savedUser *model.User
+			repo.On("Update", ctx, mock.Anything).Run(func(args mock.Arguments) {
+				savedUser = args.Get(1).(*model.User)
+			}).Return(&model.User{Username: "wdyarfn", Role: model.RoleStaff}, nil)
+
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			svc := NewOidcService(&repo, newOidcLoginStateRepository(ctx), &oidc, &repository.TransactorMock{}, &audit, map[string]string{"clerks": model.RoleStaff}, "", false, oidcStateKey)
+			link, err := svc.LinkURL(ctx, "wdyarfn")
+			assert.Nil(t, err)
+
+			result, err := svc.Callback(ctx, &request.OidcCallbackRequest{Code: "code", State: link.State, Cookie: link.Cookie})
+			if tc.expectedSvcError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+				assert.Nil(t, result)
+				repo.AssertNotCalled(t, "Update", ctx, mock.Anything)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.NotEmpty(t, result.Token)
+			assert.Equal(t, "wdyarfn", savedUser.Username)
+			assert.Equal(t, claims.Subject, *savedUser.OidcSubject)
+			assert.Equal(t, model.RoleStaff, savedUser.Role)
+		})
+	}
+}
@@ -3,19 +3,26 @@ package service
 import (
 	"context"
 	response "inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
 	"inventory-management/backend/internal/repository"
+	"inventory-management/backend/util"
+	"strconv"
 	"sync"
 )
 
 type ProductQualityService struct {
 	ProductQualityRepository repository.ProductQualityRepositoryContract
 	ProductRepository        repository.ProductRepositoryContract
+	Transactor               repository.TransactorContract
+	AuditService             AuditServiceContract
 }
 
-func NewProductQualityService(productQualityRepository repository.ProductQualityRepositoryContract, productRepository repository.ProductRepositoryContract) ProductQualityServiceContract {
+func NewProductQualityService(productQualityRepository repository.ProductQualityRepositoryContract, productRepository repository.ProductRepositoryContract, transactor repository.TransactorContract, auditService AuditServiceContract) ProductQualityServiceContract {
 	return &ProductQualityService{
 		ProductQualityRepository: productQualityRepository,
 		ProductRepository:        productRepository,
+		Transactor:               transactor,
+		AuditService:             auditService,
 	}
 }
 
@@ -94,10 +101,52 @@ func (service *ProductQualityService) Delete(ctx context.Context, id int64) erro
 		return err
 	}
 
-	err = service.ProductQualityRepository.Delete(ctx, checkProductQuality.ID, nil)
+	err = checkVersion(ctx, checkProductQuality.Version)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		err := service.ProductQualityRepository.Delete(ctx, checkProductQuality.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityProductQuality, strconv.FormatInt(checkProductQuality.ID, 10), model.AuditActionDelete, checkProductQuality.ToResponse(), nil)
+	})
+}
+
+func (service *ProductQualityService) Restore(ctx context.Context, id int64) (*response.ProductQualityResponse, error) {
+	var productQuality *model.ProductQuality
+	err := service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		productQuality, err = service.ProductQualityRepository.Restore(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityProductQuality, strconv.FormatInt(productQuality.ID, 10), model.AuditActionRestore, nil, productQuality.ToResponse())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return productQuality.ToResponse(), nil
+}
+
+// Purge deletes the quality for good, which is refused while transactions reference it.
+func (service *ProductQualityService) Purge(ctx context.Context, id int64) error {
+	checkProductQuality, err := service.ProductQualityRepository.FindByID(util.WithDeleted(ctx), id, nil)
+	if err != nil {
+		return err
+	}
+
+	return service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		err := service.ProductQualityRepository.Purge(ctx, checkProductQuality.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityProductQuality, strconv.FormatInt(checkProductQuality.ID, 10), model.AuditActionPurge, checkProductQuality.ToResponse(), nil)
+	})
 }
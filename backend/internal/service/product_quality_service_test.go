@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	response "inventory-management/backend/internal/http/response"
 	"inventory-management/backend/internal/model"
 	repository "inventory-management/backend/internal/repository/mock"
+	service "inventory-management/backend/internal/service/mock"
 	"testing"
 )
 
@@ -75,7 +77,8 @@ func TestProductQualityService_FindAll(t *testing.T) {
 			var repoPQ repository.ProductQualityRepositoryMock
 			var repP repository.ProductRepositoryMock
 			repoPQ.On("FindAll", ctx).Return(tc.expectedProductQualityRepoFindAll, tc.expectedProductQualityRepoFindAllError)
-			svc := NewProductQualityService(&repoPQ, &repP)
+			var audit service.AuditServiceMock
+			svc := NewProductQualityService(&repoPQ, &repP, &repository.TransactorMock{}, &audit)
 			result, err := svc.FindAll(ctx)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -180,7 +183,8 @@ func TestProductQualityService_FindAllByProductCode(t *testing.T) {
 			var repP repository.ProductRepositoryMock
 			repP.On("FindByCodeWithAssociations", ctx, tc.request).Return(tc.expectedProductRepoFindByCode, tc.expectedProductRepoFindByCodeError)
 			repoPQ.On("FindAllByProductCode", ctx, tc.request).Return(tc.expectedProductQualityRepoFindAllByProductCode, tc.expectedProductQualityRepoFindAllByProductCodeError)
-			svc := NewProductQualityService(&repoPQ, &repP)
+			var audit service.AuditServiceMock
+			svc := NewProductQualityService(&repoPQ, &repP, &repository.TransactorMock{}, &audit)
 			result, err := svc.FindAllByProductCode(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -256,7 +260,8 @@ func TestProductQualityService_FindByID(t *testing.T) {
 			var repoPQ repository.ProductQualityRepositoryMock
 			var repP repository.ProductRepositoryMock
 			repoPQ.On("FindByIDWithAssociations", ctx, tc.request).Return(tc.expectedProductQualityRepoFindByID, tc.expectedProductQualityRepoFindByIDError)
-			svc := NewProductQualityService(&repoPQ, &repP)
+			var audit service.AuditServiceMock
+			svc := NewProductQualityService(&repoPQ, &repP, &repository.TransactorMock{}, &audit)
 			result, err := svc.FindByID(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -310,7 +315,9 @@ func TestProductQualityService_Delete(t *testing.T) {
 			var repP repository.ProductRepositoryMock
 			repoPQ.On("FindByID", ctx, tc.request).Return(tc.expectedProductQualityRepoFindByID, tc.expectedProductQualityRepoFindByIDError)
 			repoPQ.On("Delete", ctx, tc.request).Return(tc.expectedProductQualityRepoDeleteError)
-			svc := NewProductQualityService(&repoPQ, &repP)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			svc := NewProductQualityService(&repoPQ, &repP, &repository.TransactorMock{}, &audit)
 			err := svc.Delete(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
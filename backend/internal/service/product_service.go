@@ -2,38 +2,65 @@ package service
 
 import (
 	"context"
+	"errors"
 	"inventory-management/backend/internal/http/request"
 	"inventory-management/backend/internal/http/response"
 	"inventory-management/backend/internal/model"
 	"inventory-management/backend/internal/repository"
+	"inventory-management/backend/util"
 )
 
 type ProductService struct {
-	ProductRepository repository.ProductRepositoryContract
+	ProductRepository        repository.ProductRepositoryContract
+	ProductQualityRepository repository.ProductQualityRepositoryContract
+	Transactor               repository.TransactorContract
+	AuditService             AuditServiceContract
 }
 
-func NewProductService(productRepository repository.ProductRepositoryContract) ProductServiceContract {
+func NewProductService(productRepository repository.ProductRepositoryContract, productQualityRepository repository.ProductQualityRepositoryContract, transactor repository.TransactorContract, auditService AuditServiceContract) ProductServiceContract {
 	return &ProductService{
-		ProductRepository: productRepository,
+		ProductRepository:        productRepository,
+		ProductQualityRepository: productQualityRepository,
+		Transactor:               transactor,
+		AuditService:             auditService,
 	}
 }
 
-func (service *ProductService) FindAll(ctx context.Context, offset int, limit int) ([]*response.ProductResponse, error) {
-	products, err := service.ProductRepository.FindAll(ctx, offset, limit)
+func (service *ProductService) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*response.ProductResponse, error) {
+	products, err := service.ProductRepository.FindAll(ctx, query, offset, limit)
 	if err != nil {
 		return nil, err
 	}
 
+	return toProductResponses(products, query), nil
+}
+
+func (service *ProductService) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*response.ProductResponse, *util.CursorPage, error) {
+	products, page, err := service.ProductRepository.FindAllByCursor(ctx, query, cursor, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return toProductResponses(products, query), page, nil
+}
+
+func toProductResponses(products []*model.Product, query *util.ListQuery) []*response.ProductResponse {
 	var productResponses []*response.ProductResponse
 	for _, product := range products {
+		// Included associations are the only ones loaded
+		if query != nil && len(query.Include) > 0 {
+			productResponses = append(productResponses, product.ToResponseWithAssociations())
+			continue
+		}
+
 		productResponses = append(productResponses, product.ToResponse())
 	}
 
-	return productResponses, nil
+	return productResponses
 }
 
-func (service *ProductService) CountAll(ctx context.Context) (int64, error) {
-	count, err := service.ProductRepository.CountAll(ctx)
+func (service *ProductService) CountAll(ctx context.Context, query *util.ListQuery) (int64, error) {
+	count, err := service.ProductRepository.CountAll(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -58,6 +85,7 @@ func (service *ProductService) Create(ctx context.Context, request *request.Crea
 		productQualityRequest.Price = productQuality.Price
 		productQualityRequest.Quantity = productQuality.Quantity
 		productQualityRequest.Type = productQuality.Type
+		productQualityRequest.Gtin = util.NormalizeGTINPointer(productQuality.Gtin)
 
 		productQualities = append(productQualities, &productQualityRequest)
 	}
@@ -66,9 +94,24 @@ func (service *ProductService) Create(ctx context.Context, request *request.Crea
 	productRequest.Name = request.Name
 	productRequest.UnitMassAcronym = request.UnitMassAcronym
 	productRequest.UnitMassDescription = request.UnitMassDescription
+	productRequest.Gtin = util.NormalizeGTINPointer(request.Gtin)
 	productRequest.ProductQualities = productQualities
 
-	product, err := service.ProductRepository.Create(ctx, &productRequest)
+	err := service.checkGtins(ctx, &productRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	var product *model.Product
+	err = service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		product, err = service.ProductRepository.Create(ctx, &productRequest)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityProduct, product.Code, model.AuditActionCreate, nil, product.ToResponseWithAssociations())
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +128,7 @@ func (service *ProductService) Update(ctx context.Context, request *request.Upda
 		productQualityRequest.Price = productQuality.Price
 		productQualityRequest.Quantity = productQuality.Quantity
 		productQualityRequest.Type = productQuality.Type
+		productQualityRequest.Gtin = util.NormalizeGTINPointer(productQuality.Gtin)
 
 		productQualities = append(productQualities, &productQualityRequest)
 	}
@@ -94,12 +138,53 @@ func (service *ProductService) Update(ctx context.Context, request *request.Upda
 		return nil, err
 	}
 
+	err = checkVersion(ctx, checkProduct.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	// The qualities kept are saved at the version they were read at, and must be qualities of
+	// the product
+	for _, productQuality := range productQualities {
+		if productQuality.ID == 0 {
+			continue
+		}
+
+		found := false
+		for _, existing := range checkProduct.ProductQualities {
+			if existing.ID == productQuality.ID {
+				productQuality.Version = existing.Version
+				found = true
+			}
+		}
+		if !found {
+			return nil, errors.New(response.ErrorNotFound)
+		}
+	}
+
+	before := checkProduct.ToResponseWithAssociations()
+
 	checkProduct.Name = request.Name
 	checkProduct.UnitMassAcronym = request.UnitMassAcronym
 	checkProduct.UnitMassDescription = request.UnitMassDescription
+	checkProduct.Gtin = util.NormalizeGTINPointer(request.Gtin)
 	checkProduct.ProductQualities = productQualities
 
-	product, err := service.ProductRepository.Update(ctx, checkProduct)
+	err = service.checkGtins(ctx, checkProduct)
+	if err != nil {
+		return nil, err
+	}
+
+	var product *model.Product
+	err = service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		product, err = service.ProductRepository.Update(ctx, checkProduct)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityProduct, product.Code, model.AuditActionUpdate, before, product.ToResponseWithAssociations())
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -113,10 +198,97 @@ func (service *ProductService) Delete(ctx context.Context, code string) error {
 		return err
 	}
 
-	err = service.ProductRepository.Delete(ctx, checkProduct.Code)
+	err = checkVersion(ctx, checkProduct.Version)
+	if err != nil {
+		return err
+	}
+
+	return service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		err := service.ProductRepository.Delete(ctx, checkProduct.Code)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityProduct, checkProduct.Code, model.AuditActionDelete, checkProduct.ToResponseWithAssociations(), nil)
+	})
+}
+
+// Restore brings back a deleted product along with the qualities deleted with it.
+func (service *ProductService) Restore(ctx context.Context, code string) (*response.ProductResponse, error) {
+	var product *model.Product
+	err := service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		product, err = service.ProductRepository.Restore(ctx, code)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityProduct, product.Code, model.AuditActionRestore, nil, product.ToResponseWithAssociations())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return product.ToResponseWithAssociations(), nil
+}
+
+// Purge deletes the product and its qualities for good, which is refused while transactions
+// reference any of the qualities.
+func (service *ProductService) Purge(ctx context.Context, code string) error {
+	checkProduct, err := service.ProductRepository.FindByCodeWithAssociations(util.WithDeleted(ctx), code)
 	if err != nil {
 		return err
 	}
 
+	return service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		err := service.ProductRepository.Purge(ctx, checkProduct.Code)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityProduct, checkProduct.Code, model.AuditActionPurge, checkProduct.ToResponseWithAssociations(), nil)
+	})
+}
+
+// checkGtins makes sure the GTINs of the product and of its qualities identify nothing else,
+// since a scanned GTIN must resolve to a single product or quality. Deleted records keep their
+// GTIN until they are purged.
+func (service *ProductService) checkGtins(ctx context.Context, product *model.Product) error {
+	ctx = util.WithDeleted(ctx)
+	if product.Gtin != nil {
+		existing, err := service.ProductRepository.FindByGtin(ctx, *product.Gtin)
+		if err == nil && existing.Code != product.Code {
+			return errors.New(response.ErrorGtinExists)
+		}
+		if err != nil && err.Error() != response.ErrorNotFound {
+			return err
+		}
+	}
+
+	for _, productQuality := range product.ProductQualities {
+		if productQuality.Gtin == nil {
+			continue
+		}
+
+		existing, err := service.ProductQualityRepository.FindByGtinWithAssociations(ctx, *productQuality.Gtin)
+		if err == nil && existing.ID != productQuality.ID {
+			return errors.New(response.ErrorGtinExists)
+		}
+		if err != nil && err.Error() != response.ErrorNotFound {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkVersion makes sure a record is still at the version the request was made against, when
+// the request says which one it was.
+func checkVersion(ctx context.Context, version int64) error {
+	expected, ok := util.ExpectedVersionFromContext(ctx)
+	if ok && expected != version {
+		return errors.New(response.ErrorVersionMismatch)
+	}
+
 	return nil
 }
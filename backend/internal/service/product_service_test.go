@@ -9,6 +9,8 @@ import (
 	response "inventory-management/backend/internal/http/response"
 	"inventory-management/backend/internal/model"
 	repository "inventory-management/backend/internal/repository/mock"
+	service "inventory-management/backend/internal/service/mock"
+	"inventory-management/backend/util"
 	"testing"
 )
 
@@ -75,9 +77,10 @@ func TestProductService_FindAll(t *testing.T) {
 			ctx := context.Background()
 
 			var repo repository.ProductRepositoryMock
-			repo.On("FindAll", ctx, 0, 10).Return(tc.expectedProductRepoFindAll, tc.expectedProductRepoFindAllError)
-			svc := NewProductService(&repo)
-			result, err := svc.FindAll(ctx, 0, 10)
+			repo.On("FindAll", ctx, (*util.ListQuery)(nil), 0, 10).Return(tc.expectedProductRepoFindAll, tc.expectedProductRepoFindAllError)
+			var audit service.AuditServiceMock
+			svc := NewProductService(&repo, nil, &repository.TransactorMock{}, &audit)
+			result, err := svc.FindAll(ctx, nil, 0, 10)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
@@ -136,7 +139,8 @@ func TestProductService_FindByCode(t *testing.T) {
 
 			var repo repository.ProductRepositoryMock
 			repo.On("FindByCodeWithAssociations", ctx, tc.request).Return(tc.expectedProductRepoFindByCode, tc.expectedProductRepoFindByCodeError)
-			svc := NewProductService(&repo)
+			var audit service.AuditServiceMock
+			svc := NewProductService(&repo, nil, &repository.TransactorMock{}, &audit)
 			result, err := svc.FindByCode(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -154,6 +158,8 @@ func TestProductService_Create(t *testing.T) {
 		request                        *request.CreateProductRequest
 		expectedProductRepoCreate      *model.Product
 		expectedProductRepoCreateError error
+		productWithGtin                *model.Product
+		productQualityWithGtin         *model.ProductQuality
 		expectedSvc                    *response.ProductResponse
 		expectedSvcError               error
 	}{
@@ -183,6 +189,45 @@ func TestProductService_Create(t *testing.T) {
 			expectedProductRepoCreateError: nil,
 			expectedSvcError:               nil,
 		},
+		{
+			name: "Create product with GTINs stored as GTIN-14",
+			request: &request.CreateProductRequest{
+				Name:                "Shark",
+				UnitMassAcronym:     "kg",
+				UnitMassDescription: "kilogram",
+				Gtin:                util.ToPointerString("4006381333931"),
+				ProductQualities: []*request.CreateProductQualityRequest{
+					{Quality: "Fresh", Price: 250000, Type: "Increase", Gtin: util.ToPointerString("96385074")},
+				},
+			},
+			expectedProductRepoCreate: &model.Product{
+				ID:   1,
+				Code: "KKDJALS",
+				Name: "Shark",
+				Gtin: util.ToPointerString("04006381333931"),
+			},
+			expectedSvc: &response.ProductResponse{
+				ID:        1,
+				Code:      "KKDJALS",
+				Name:      "Shark",
+				Gtin:      util.ToPointerString("04006381333931"),
+				CreatedAt: "0001-01-01 07:00:00 +0700 +07",
+				UpdatedAt: "0001-01-01 07:00:00 +0700 +07",
+			},
+		},
+		{
+			name: "[invalid] GTIN of another product quality",
+			request: &request.CreateProductRequest{
+				Name:                "Shark",
+				UnitMassAcronym:     "kg",
+				UnitMassDescription: "kilogram",
+				ProductQualities: []*request.CreateProductQualityRequest{
+					{Quality: "Fresh", Price: 250000, Type: "Increase", Gtin: util.ToPointerString("96385074")},
+				},
+			},
+			productQualityWithGtin: &model.ProductQuality{ID: 3, ProductCode: "JSKADKA", Gtin: util.ToPointerString("00000096385074")},
+			expectedSvcError:       errors.New(response.ErrorGtinExists),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -191,7 +236,20 @@ func TestProductService_Create(t *testing.T) {
 
 			var repo repository.ProductRepositoryMock
 			repo.On("Create", ctx, mock.Anything).Return(tc.expectedProductRepoCreate, tc.expectedProductRepoCreateError)
-			svc := NewProductService(&repo)
+			if tc.productWithGtin != nil {
+				repo.On("FindByGtin", util.WithDeleted(ctx), mock.Anything).Return(tc.productWithGtin, nil)
+			} else {
+				repo.On("FindByGtin", util.WithDeleted(ctx), mock.Anything).Return(nil, errors.New(response.ErrorNotFound))
+			}
+			var productQualityRepo repository.ProductQualityRepositoryMock
+			if tc.productQualityWithGtin != nil {
+				productQualityRepo.On("FindByGtinWithAssociations", util.WithDeleted(ctx), "00000096385074").Return(tc.productQualityWithGtin, nil)
+			} else {
+				productQualityRepo.On("FindByGtinWithAssociations", util.WithDeleted(ctx), "00000096385074").Return(nil, errors.New(response.ErrorNotFound))
+			}
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			svc := NewProductService(&repo, &productQualityRepo, &repository.TransactorMock{}, &audit)
 			result, err := svc.Create(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -303,7 +361,9 @@ func TestProductService_Update(t *testing.T) {
 			var repo repository.ProductRepositoryMock
 			repo.On("FindByCodeWithAssociations", ctx, tc.requestProductRepoFindByCode).Return(tc.expectedProductRepoFindByCode, tc.expectedProductRepoFindByCodeError)
 			repo.On("Update", ctx, mock.Anything).Return(tc.expectedProductRepoUpdate, tc.expectedProductRepoUpdateError)
-			svc := NewProductService(&repo)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			svc := NewProductService(&repo, nil, &repository.TransactorMock{}, &audit)
 			result, err := svc.Update(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -320,6 +380,97 @@ func TestProductService_Update(t *testing.T) {
 	}
 }
 
+func TestProductService_UpdateVersion(t *testing.T) {
+	checkProduct := func() *model.Product {
+		return &model.Product{
+			ID:      1,
+			Code:    "KKJANSM",
+			Name:    "Shark",
+			Version: 4,
+			ProductQualities: []*model.ProductQuality{
+				{ID: 1, Quality: "Very Fresh", Price: 150000, Quantity: 3.5, Version: 7},
+			},
+		}
+	}
+	updateRequest := &request.UpdateProductRequest{
+		Code:            "KKJANSM",
+		Name:            "Shrimp",
+		UnitMassAcronym: "kg",
+		ProductQualities: []*request.UpdateProductQualityRequest{
+			{ID: 1, Quality: "Fresh", Price: 250000, Quantity: 3.5},
+			{Quality: "Frozen", Price: 100000, Quantity: 1},
+		},
+	}
+
+	t.Run("Product updated at the version of If-Match", func(t *testing.T) {
+		ctx := util.WithExpectedVersion(context.Background(), 4)
+
+		var repo repository.ProductRepositoryMock
+		repo.On("FindByCodeWithAssociations", ctx, "KKJANSM").Return(checkProduct(), nil)
+		repo.On("Update", ctx, mock.MatchedBy(func(product *model.Product) bool {
+			// Kept qualities are saved at the version they were read at, new ones have none yet
+			return product.Version == 4 && product.ProductQualities[0].Version == 7 && product.ProductQualities[1].Version == 0
+		})).Return(&model.Product{ID: 1, Code: "KKJANSM", Name: "Shrimp", Version: 5}, nil)
+		var audit service.AuditServiceMock
+		audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		svc := NewProductService(&repo, nil, &repository.TransactorMock{}, &audit)
+		result, err := svc.Update(ctx, updateRequest)
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(5), result.Version)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("[invalid] Product changed since the version of If-Match", func(t *testing.T) {
+		ctx := util.WithExpectedVersion(context.Background(), 3)
+
+		var repo repository.ProductRepositoryMock
+		repo.On("FindByCodeWithAssociations", ctx, "KKJANSM").Return(checkProduct(), nil)
+
+		svc := NewProductService(&repo, nil, &repository.TransactorMock{}, nil)
+		result, err := svc.Update(ctx, updateRequest)
+
+		assert.Nil(t, result)
+		assert.Equal(t, errors.New(response.ErrorVersionMismatch), err)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("[invalid] Quality of another product", func(t *testing.T) {
+		ctx := context.Background()
+
+		var repo repository.ProductRepositoryMock
+		repo.On("FindByCodeWithAssociations", ctx, "KKJANSM").Return(checkProduct(), nil)
+
+		svc := NewProductService(&repo, nil, &repository.TransactorMock{}, nil)
+		result, err := svc.Update(ctx, &request.UpdateProductRequest{
+			Code:            "KKJANSM",
+			Name:            "Shrimp",
+			UnitMassAcronym: "kg",
+			ProductQualities: []*request.UpdateProductQualityRequest{
+				{ID: 2, Quality: "Fresh", Price: 250000, Quantity: 3.5},
+			},
+		})
+
+		assert.Nil(t, result)
+		assert.Equal(t, errors.New(response.ErrorNotFound), err)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("[invalid] Product deleted at a version it is no longer at", func(t *testing.T) {
+		ctx := util.WithExpectedVersion(context.Background(), 3)
+
+		var repo repository.ProductRepositoryMock
+		repo.On("FindByCodeWithAssociations", ctx, "KKJANSM").Return(checkProduct(), nil)
+
+		svc := NewProductService(&repo, nil, &repository.TransactorMock{}, nil)
+		err := svc.Delete(ctx, "KKJANSM")
+
+		assert.Equal(t, errors.New(response.ErrorVersionMismatch), err)
+		repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
 func TestProductService_Delete(t *testing.T) {
 	testCases := []struct {
 		name                               string
@@ -360,7 +511,9 @@ func TestProductService_Delete(t *testing.T) {
 			var repo repository.ProductRepositoryMock
 			repo.On("FindByCodeWithAssociations", ctx, tc.request).Return(tc.expectedProductRepoFindByCode, tc.expectedProductRepoFindByCodeError)
 			repo.On("Delete", ctx, tc.request).Return(tc.expectedProductRepoDeleteError)
-			svc := NewProductService(&repo)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			svc := NewProductService(&repo, nil, &repository.TransactorMock{}, &audit)
 			err := svc.Delete(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
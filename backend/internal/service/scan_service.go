@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/internal/repository"
+	"inventory-management/backend/util"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	ScanTypeProduct        = "product"
+	ScanTypeProductQuality = "product_quality"
+	ScanTypeTransaction    = "transaction"
+	ScanTypeSupplier       = "supplier"
+	ScanTypeCustomer       = "customer"
+)
+
+// ScanService resolves what handheld scanners read to the entity it identifies.
+type ScanService struct {
+	ProductRepository        repository.ProductRepositoryContract
+	ProductQualityRepository repository.ProductQualityRepositoryContract
+	TransactionRepository    repository.TransactionRepositoryContract
+	SupplierRepository       repository.SupplierRepositoryContract
+	CustomerRepository       repository.CustomerRepositoryContract
+}
+
+func NewScanService(productRepository repository.ProductRepositoryContract, productQualityRepository repository.ProductQualityRepositoryContract, transactionRepository repository.TransactionRepositoryContract, supplierRepository repository.SupplierRepositoryContract, customerRepository repository.CustomerRepositoryContract) ScanServiceContract {
+	return &ScanService{
+		ProductRepository:        productRepository,
+		ProductQualityRepository: productQualityRepository,
+		TransactionRepository:    transactionRepository,
+		SupplierRepository:       supplierRepository,
+		CustomerRepository:       customerRepository,
+	}
+}
+
+// Scan resolves the value, first as the GS1 element string of a GS1-128 label, then as a GTIN,
+// as the label value of a product quality and last as the code of a product, a transaction, a
+// supplier or a customer, in that order. Values identifying nothing are not found.
+func (service *ScanService) Scan(ctx context.Context, value string) (*response.ScanResponse, error) {
+	value = strings.TrimSpace(value)
+
+	if elements, ok := util.ParseGS1(value); ok {
+		return service.scanGS1(ctx, value, elements)
+	}
+
+	if gtin, ok := util.NormalizeGTIN(value); ok {
+		scan, err := service.scanGtin(ctx, value, gtin)
+		if err == nil || err.Error() != response.ErrorNotFound {
+			return scan, err
+		}
+	}
+
+	// Product quality labels are the product code and the quality id
+	if productCode, id, ok := strings.Cut(value, "-"); ok {
+		if productQualityID, err := strconv.ParseInt(id, 10, 64); err == nil {
+			scan, err := service.scanProductQuality(ctx, value, productCode, productQualityID)
+			if err == nil || err.Error() != response.ErrorNotFound {
+				return scan, err
+			}
+		}
+	}
+
+	return service.scanCode(ctx, value)
+}
+
+// scanGS1 resolves the elements of the GS1-128 labels this inventory prints: the batch of a
+// transaction label, then the product code and quality id of a quality label, then the GTIN
+// and last the product code.
+func (service *ScanService) scanGS1(ctx context.Context, value string, elements []util.GS1Element) (*response.ScanResponse, error) {
+	data := map[string]string{}
+	for _, element := range elements {
+		data[element.AI] = element.Data
+	}
+
+	if batch, ok := data["10"]; ok {
+		transaction, err := service.TransactionRepository.FindByCodeWithAssociations(ctx, batch, nil)
+		if err == nil {
+			return transactionScan(value, transaction), nil
+		}
+		if err.Error() != response.ErrorNotFound {
+			return nil, err
+		}
+	}
+
+	if id, ok := data["91"]; ok {
+		if productQualityID, err := strconv.ParseInt(id, 10, 64); err == nil {
+			scan, err := service.scanProductQuality(ctx, value, data["240"], productQualityID)
+			if err == nil || err.Error() != response.ErrorNotFound {
+				return scan, err
+			}
+		}
+	}
+
+	if gtin, ok := util.NormalizeGTIN(data["01"]); ok {
+		scan, err := service.scanGtin(ctx, value, gtin)
+		if err == nil || err.Error() != response.ErrorNotFound {
+			return scan, err
+		}
+	}
+
+	if productCode, ok := data["240"]; ok {
+		product, err := service.ProductRepository.FindByCodeWithAssociations(ctx, productCode)
+		if err != nil {
+			return nil, err
+		}
+		return productScan(value, product), nil
+	}
+
+	return nil, errors.New(response.ErrorNotFound)
+}
+
+// scanGtin resolves a GTIN to the quality it is mapped to, or else to the product.
+func (service *ScanService) scanGtin(ctx context.Context, value string, gtin string) (*response.ScanResponse, error) {
+	productQuality, err := service.ProductQualityRepository.FindByGtinWithAssociations(ctx, gtin)
+	if err == nil {
+		return productQualityScan(value, productQuality), nil
+	}
+	if err.Error() != response.ErrorNotFound {
+		return nil, err
+	}
+
+	product, err := service.ProductRepository.FindByGtin(ctx, gtin)
+	if err != nil {
+		return nil, err
+	}
+
+	return productScan(value, product), nil
+}
+
+// scanProductQuality resolves a quality id, when the quality is one of the product with the code
+// given along with it.
+func (service *ScanService) scanProductQuality(ctx context.Context, value string, productCode string, id int64) (*response.ScanResponse, error) {
+	productQuality, err := service.ProductQualityRepository.FindByIDWithAssociations(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+	if productQuality.ProductCode != productCode {
+		return nil, errors.New(response.ErrorNotFound)
+	}
+
+	return productQualityScan(value, productQuality), nil
+}
+
+func (service *ScanService) scanCode(ctx context.Context, code string) (*response.ScanResponse, error) {
+	product, err := service.ProductRepository.FindByCodeWithAssociations(ctx, code)
+	if err == nil {
+		return productScan(code, product), nil
+	}
+	if err.Error() != response.ErrorNotFound {
+		return nil, err
+	}
+
+	transaction, err := service.TransactionRepository.FindByCodeWithAssociations(ctx, code, nil)
+	if err == nil {
+		return transactionScan(code, transaction), nil
+	}
+	if err.Error() != response.ErrorNotFound {
+		return nil, err
+	}
+
+	supplier, err := service.SupplierRepository.FindByCode(ctx, code)
+	if err == nil {
+		return &response.ScanResponse{Value: code, Type: ScanTypeSupplier, Data: supplier.ToResponse(), Actions: []*response.ScanActionResponse{
+			{Name: "view", Method: http.MethodGet, Path: "/api/suppliers/" + supplier.Code},
+			{Name: "transactions", Method: http.MethodGet, Path: "/api/transactions/" + supplier.Code + "/supplier"},
+		}}, nil
+	}
+	if err.Error() != response.ErrorNotFound {
+		return nil, err
+	}
+
+	customer, err := service.CustomerRepository.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.ScanResponse{Value: code, Type: ScanTypeCustomer, Data: customer.ToResponse(), Actions: []*response.ScanActionResponse{
+		{Name: "view", Method: http.MethodGet, Path: "/api/customers/" + customer.Code},
+		{Name: "transactions", Method: http.MethodGet, Path: "/api/transactions/" + customer.Code + "/customer"},
+	}}, nil
+}
+
+func productScan(value string, product *model.Product) *response.ScanResponse {
+	return &response.ScanResponse{Value: value, Type: ScanTypeProduct, Data: product.ToResponseWithAssociations(), Actions: []*response.ScanActionResponse{
+		{Name: "view", Method: http.MethodGet, Path: "/api/products/" + product.Code},
+		{Name: "edit", Method: http.MethodPut, Path: "/api/products/" + product.Code},
+	}}
+}
+
+// productQualityScan offers to take stock out or transfer it only while the quality has some.
+func productQualityScan(value string, productQuality *model.ProductQuality) *response.ScanResponse {
+	id := strconv.FormatInt(productQuality.ID, 10)
+	unitMassAcronym := ""
+	if productQuality.Product != nil {
+		unitMassAcronym = productQuality.Product.UnitMassAcronym
+	}
+
+	actions := []*response.ScanActionResponse{
+		{Name: "view", Method: http.MethodGet, Path: "/api/product-qualities/" + id},
+		{Name: "stock_in", Method: http.MethodPost, Path: "/api/transactions", Payload: map[string]interface{}{
+			"type": "IN", "product_quality_id": productQuality.ID, "unit_mass_acronym": unitMassAcronym,
+		}},
+	}
+	if productQuality.Quantity > 0 {
+		actions = append(actions,
+			&response.ScanActionResponse{Name: "stock_out", Method: http.MethodPost, Path: "/api/transactions", Payload: map[string]interface{}{
+				"type": "OUT", "product_quality_id": productQuality.ID, "unit_mass_acronym": unitMassAcronym,
+			}},
+			&response.ScanActionResponse{Name: "transfer", Method: http.MethodPost, Path: "/api/transactions/transfer", Payload: map[string]interface{}{
+				"product_quality_id": productQuality.ID,
+			}},
+		)
+	}
+	actions = append(actions, &response.ScanActionResponse{Name: "print_label", Method: http.MethodGet, Path: "/api/labels/product-qualities/" + id})
+
+	return &response.ScanResponse{Value: value, Type: ScanTypeProductQuality, Data: productQuality.ToResponseWithAssociations(), Actions: actions}
+}
+
+// transactionScan offers the document of the type of the transaction. Transfers cannot be
+// edited, only deleted.
+func transactionScan(value string, transaction *model.Transaction) *response.ScanResponse {
+	path := "/api/transactions/" + transaction.Code
+	actions := []*response.ScanActionResponse{{Name: "view", Method: http.MethodGet, Path: path}}
+	switch transaction.Type {
+	case "IN":
+		actions = append(actions, &response.ScanActionResponse{Name: "goods_receipt", Method: http.MethodGet, Path: "/api/documents/goods-receipts/" + transaction.Code})
+	case "OUT":
+		actions = append(actions, &response.ScanActionResponse{Name: "delivery_note", Method: http.MethodGet, Path: "/api/documents/delivery-notes/" + transaction.Code})
+	case "TRANSFER":
+		actions = append(actions, &response.ScanActionResponse{Name: "transfer_slip", Method: http.MethodGet, Path: "/api/documents/transfer-slips/" + transaction.Code})
+	}
+	actions = append(actions, &response.ScanActionResponse{Name: "print_label", Method: http.MethodGet, Path: "/api/labels/transactions/" + transaction.Code})
+	if transaction.Type != "TRANSFER" {
+		actions = append(actions, &response.ScanActionResponse{Name: "edit", Method: http.MethodPatch, Path: path})
+	}
+	actions = append(actions, &response.ScanActionResponse{Name: "delete", Method: http.MethodDelete, Path: path})
+
+	return &response.ScanResponse{Value: value, Type: ScanTypeTransaction, Data: transaction.ToResponseWithAssociations(), Actions: actions}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	response "inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	repository "inventory-management/backend/internal/repository/mock"
+	"inventory-management/backend/util"
+	"testing"
+)
+
+func TestScanService_Scan(t *testing.T) {
+	product := &model.Product{ID: 1, Code: "PRDCT12345", Name: "Rice", UnitMassAcronym: "kg", Gtin: util.ToPointerString("04006381333931")}
+	inStock := &model.ProductQuality{ID: 7, ProductCode: "PRDCT12345", Quality: "Premium", Quantity: 12, Product: product, Gtin: util.ToPointerString("00000096385074")}
+	outOfStock := &model.ProductQuality{ID: 8, ProductCode: "PRDCT12345", Quality: "Medium", Product: product}
+	transaction := &model.Transaction{Code: "TRXOUT1234", Type: "OUT", ProductQuality: inStock}
+	customer := &model.Customer{ID: 2, Code: "CSTMR12345", Name: "Arfiansyah"}
+
+	testCases := []struct {
+		name             string
+		value            string
+		expectedType     string
+		expectedActions  []string
+		expectedSvcError error
+	}{
+		{
+			name:            "GTIN of a product quality",
+			value:           "96385074",
+			expectedType:    ScanTypeProductQuality,
+			expectedActions: []string{"view", "stock_in", "stock_out", "transfer", "print_label"},
+		},
+		{
+			name:            "GTIN of a product",
+			value:           "4006381333931",
+			expectedType:    ScanTypeProduct,
+			expectedActions: []string{"view", "edit"},
+		},
+		{
+			name:            "Label of a product quality out of stock",
+			value:           "PRDCT12345-8",
+			expectedType:    ScanTypeProductQuality,
+			expectedActions: []string{"view", "stock_in", "print_label"},
+		},
+		{
+			name:            "Code of a transaction",
+			value:           "TRXOUT1234",
+			expectedType:    ScanTypeTransaction,
+			expectedActions: []string{"view", "delivery_note", "print_label", "edit", "delete"},
+		},
+		{
+			name:            "GS1-128 label of a transaction",
+			value:           "]C124000000096385074\x1d10TRXOUT1234",
+			expectedType:    ScanTypeTransaction,
+			expectedActions: []string{"view", "delivery_note", "print_label", "edit", "delete"},
+		},
+		{
+			name:            "Code of a customer",
+			value:           " CSTMR12345 ",
+			expectedType:    ScanTypeCustomer,
+			expectedActions: []string{"view", "transactions"},
+		},
+		{
+			name:             "[invalid] Value identifying nothing",
+			value:            "NOTHING123",
+			expectedSvcError: errors.New(response.ErrorNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			notFound := errors.New(response.ErrorNotFound)
+
+			var productRepo repository.ProductRepositoryMock
+			productRepo.On("FindByGtin", ctx, "04006381333931").Return(product, nil)
+			productRepo.On("FindByGtin", ctx, mock.Anything).Return(nil, notFound)
+			productRepo.On("FindByCodeWithAssociations", ctx, "PRDCT12345").Return(product, nil)
+			productRepo.On("FindByCodeWithAssociations", ctx, mock.Anything).Return(nil, notFound)
+
+			var productQualityRepo repository.ProductQualityRepositoryMock
+			productQualityRepo.On("FindByGtinWithAssociations", ctx, "00000096385074").Return(inStock, nil)
+			productQualityRepo.On("FindByGtinWithAssociations", ctx, mock.Anything).Return(nil, notFound)
+			productQualityRepo.On("FindByIDWithAssociations", ctx, int64(8)).Return(outOfStock, nil)
+
+			var transactionRepo repository.TransactionRepositoryMock
+			transactionRepo.On("FindByCodeWithAssociations", ctx, "TRXOUT1234").Return(transaction, nil)
+			transactionRepo.On("FindByCodeWithAssociations", ctx, mock.Anything).Return(nil, notFound)
+
+			var supplierRepo repository.SupplierRepositoryMock
+			supplierRepo.On("FindByCode", ctx, mock.Anything).Return(nil, notFound)
+
+			var customerRepo repository.CustomerRepositoryMock
+			customerRepo.On("FindByCode", ctx, "CSTMR12345").Return(customer, nil)
+			customerRepo.On("FindByCode", ctx, mock.Anything).Return(nil, notFound)
+
+			svc := NewScanService(&productRepo, &productQualityRepo, &transactionRepo, &supplierRepo, &customerRepo)
+			scan, err := svc.Scan(ctx, tc.value)
+
+			assert.Equal(t, tc.expectedSvcError, err)
+			if tc.expectedSvcError != nil {
+				return
+			}
+
+			assert.Equal(t, tc.expectedType, scan.Type)
+			var actions []string
+			for _, action := range scan.Actions {
+				actions = append(actions, action.Name)
+			}
+			assert.Equal(t, tc.expectedActions, actions)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package service
+
+import "inventory-management/backend/internal/model"
+
+// searchSettings defines the analyzer used for names: it is case and accent insensitive, and
+// the edge n-grams let a partial word match while typing.
+const searchSettings = `"settings": {
+    "analysis": {
+      "filter": {
+        "name_edge_ngram": {"type": "edge_ngram", "min_gram": 2, "max_gram": 20}
+      },
+      "analyzer": {
+        "name": {"type": "custom", "tokenizer": "standard", "filter": ["lowercase", "asciifolding", "name_edge_ngram"]},
+        "name_search": {"type": "custom", "tokenizer": "standard", "filter": ["lowercase", "asciifolding"]}
+      }
+    }
+  }`
+
+const searchNameField = `{"type": "text", "analyzer": "name", "search_analyzer": "name_search", "fields": {"keyword": {"type": "keyword"}}}`
+
+// SearchMappings holds the settings and mappings every Elasticsearch index is created with. Dynamic
+// mapping is disabled so a new document field never changes how an index is searched.
+var SearchMappings = map[string]string{
+	model.SearchIndexProducts: `{
+  ` + searchSettings + `,
+  "mappings": {
+    "dynamic": "strict",
+    "properties": {
+      "id": {"type": "long"},
+      "code": {"type": "keyword"},
+      "name": ` + searchNameField + `,
+      "unit_mass_acronym": {"type": "keyword"},
+      "unit_mass_description": {"type": "text"},
+      "qualities": ` + searchNameField + `,
+      "quality_types": {"type": "keyword"},
+      "min_price": {"type": "long"},
+      "max_price": {"type": "long"},
+      "created_at": {"type": "date"},
+      "updated_at": {"type": "date"}
+    }
+  }
+}`,
+	model.SearchIndexProductQualities: `{
+  ` + searchSettings + `,
+  "mappings": {
+    "dynamic": "strict",
+    "properties": {
+      "id": {"type": "long"},
+      "product_code": {"type": "keyword"},
+      "product_name": ` + searchNameField + `,
+      "quality": ` + searchNameField + `,
+      "type": {"type": "keyword"},
+      "price": {"type": "long"},
+      "quantity": {"type": "double"},
+      "unit_mass_acronym": {"type": "keyword"}
+    }
+  }
+}`,
+	model.SearchIndexTransactions: `{
+  ` + searchSettings + `,
+  "mappings": {
+    "dynamic": "strict",
+    "properties": {
+      "id": {"type": "long"},
+      "code": {"type": "keyword"},
+      "type": {"type": "keyword"},
+      "quantity": {"type": "double"},
+      "unit_mass_acronym": {"type": "keyword"},
+      "description": {"type": "text"},
+      "product_quality_id": {"type": "long"},
+      "product_code": {"type": "keyword"},
+      "product_name": ` + searchNameField + `,
+      "quality": ` + searchNameField + `,
+      "product_quality_id_transferred": {"type": "long"},
+      "quality_transferred": ` + searchNameField + `,
+      "supplier_code": {"type": "keyword"},
+      "supplier_name": ` + searchNameField + `,
+      "customer_code": {"type": "keyword"},
+      "customer_name": ` + searchNameField + `,
+      "created_at": {"type": "date"},
+      "updated_at": {"type": "date"}
+    }
+  }
+}`,
+	model.SearchIndexSuppliers: `{
+  ` + searchSettings + `,
+  "mappings": {
+    "dynamic": "strict",
+    "properties": {
+      "id": {"type": "long"},
+      "code": {"type": "keyword"},
+      "name": ` + searchNameField + `,
+      "address": {"type": "text"},
+      "phone": {"type": "keyword"},
+      "created_at": {"type": "date"},
+      "updated_at": {"type": "date"}
+    }
+  }
+}`,
+	model.SearchIndexCustomers: `{
+  ` + searchSettings + `,
+  "mappings": {
+    "dynamic": "strict",
+    "properties": {
+      "id": {"type": "long"},
+      "code": {"type": "keyword"},
+      "name": ` + searchNameField + `,
+      "created_at": {"type": "date"},
+      "updated_at": {"type": "date"}
+    }
+  }
+}`,
+	model.SearchIndexUsers: `{
+  ` + searchSettings + `,
+  "mappings": {
+    "dynamic": "strict",
+    "properties": {
+      "id": {"type": "long"},
+      "name": ` + searchNameField + `,
+      "username": ` + searchNameField + `,
+      "email": {"type": "keyword"},
+      "role": {"type": "keyword"},
+      "created_at": {"type": "date"},
+      "updated_at": {"type": "date"}
+    }
+  }
+}`,
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/internal/repository"
+	"inventory-management/backend/util"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	searchOutboxBatchSize  = 100
+	searchOutboxInterval   = time.Second
+	searchOutboxLease      = time.Minute
+	searchOutboxMaxBackoff = 5 * time.Minute
+)
+
+// SearchOutboxService relays the search outbox to Elasticsearch. Every event asks for a document
+// to match the database, so the relay indexes the current state of the entity and an entity that
+// no longer exists is removed from the index.
+type SearchOutboxService struct {
+	SearchOutboxRepository repository.SearchOutboxRepositoryContract
+	SearchService          SearchServiceContract
+	mutex                  sync.Mutex
+	relayed                int64
+	failures               int64
+	lastRelayedAt          *time.Time
+}
+
+func NewSearchOutboxService(searchOutboxRepository repository.SearchOutboxRepositoryContract, searchService SearchServiceContract) SearchOutboxServiceContract {
+	return &SearchOutboxService{
+		SearchOutboxRepository: searchOutboxRepository,
+		SearchService:          searchService,
+	}
+}
+
+// Run relays the outbox until the context is done.
+func (service *SearchOutboxService) Run(ctx context.Context) {
+	ticker := time.NewTicker(searchOutboxInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// A full batch means there is more waiting
+			for {
+				relayed, err := service.Relay(ctx)
+				if err != nil {
+					log.Println("Cannot relay the search outbox", err)
+					break
+				}
+				if relayed < searchOutboxBatchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// Relay applies one batch of events and returns how many events it claimed. Events about the
+// same document are applied once, in the order of their latest event.
+func (service *SearchOutboxService) Relay(ctx context.Context) (int, error) {
+	events, err := service.SearchOutboxRepository.Claim(util.WithoutTenant(ctx), searchOutboxBatchSize, searchOutboxLease)
+	if err != nil {
+		return 0, err
+	}
+
+	var documentKeys []string
+	latestEvents := map[string]*model.SearchOutboxEvent{}
+	eventIDs := map[string][]int64{}
+	for _, event := range events {
+		documentKey := event.DocumentKey()
+		if _, ok := latestEvents[documentKey]; ok {
+			documentKeys = removeString(documentKeys, documentKey)
+		}
+		documentKeys = append(documentKeys, documentKey)
+		latestEvents[documentKey] = event
+		eventIDs[documentKey] = append(eventIDs[documentKey], event.ID)
+	}
+
+	for _, documentKey := range documentKeys {
+		event := latestEvents[documentKey]
+		err = service.apply(util.WithTenant(ctx, event.TenantID), event)
+		if err != nil {
+			service.fail(ctx, event, eventIDs[documentKey], err)
+			continue
+		}
+
+		err = service.SearchOutboxRepository.Delete(util.WithoutTenant(ctx), eventIDs[documentKey])
+		if err != nil {
+			return len(events), err
+		}
+		service.count(int64(len(eventIDs[documentKey])), 0)
+	}
+
+	return len(events), nil
+}
+
+func (service *SearchOutboxService) Stats(ctx context.Context) (*response.SearchOutboxStatsResponse, error) {
+	stats, err := service.SearchOutboxRepository.Stats(util.WithoutTenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	statsResponse := &response.SearchOutboxStatsResponse{
+		Pending:  stats.Pending,
+		Failing:  stats.Failing,
+		Relayed:  service.relayed,
+		Failures: service.failures,
+	}
+	if stats.OldestCreatedAt != nil {
+		statsResponse.LagSeconds = time.Since(*stats.OldestCreatedAt).Seconds()
+	}
+	if service.lastRelayedAt != nil {
+		lastRelayedAt := service.lastRelayedAt.Local().String()
+		statsResponse.LastRelayedAt = &lastRelayedAt
+	}
+
+	return statsResponse, nil
+}
+
+func (service *SearchOutboxService) apply(ctx context.Context, event *model.SearchOutboxEvent) error {
+	if event.Operation == model.OutboxOperationDelete {
+		return service.SearchService.Remove(ctx, event.IndexName, event.DocumentID)
+	}
+
+	err := service.index(ctx, event)
+	if err != nil && err.Error() == response.ErrorNotFound {
+		// Deleted after the event was written, its delete event follows
+		return service.SearchService.Remove(ctx, event.IndexName, event.DocumentID)
+	}
+
+	return err
+}
+
+func (service *SearchOutboxService) index(ctx context.Context, event *model.SearchOutboxEvent) error {
+	switch event.IndexName {
+	case model.SearchIndexProducts:
+		return service.SearchService.IndexProduct(ctx, event.EntityKey)
+	case model.SearchIndexTransactions:
+		return service.SearchService.IndexTransaction(ctx, event.EntityKey)
+	case model.SearchIndexSuppliers:
+		return service.SearchService.IndexSupplier(ctx, event.EntityKey)
+	case model.SearchIndexCustomers:
+		return service.SearchService.IndexCustomer(ctx, event.EntityKey)
+	}
+
+	id, err := strconv.ParseInt(event.EntityKey, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	switch event.IndexName {
+	case model.SearchIndexProductQualities:
+		return service.SearchService.IndexProductQuality(ctx, id)
+	case model.SearchIndexUsers:
+		return service.SearchService.IndexUser(ctx, id)
+	}
+
+	return fmt.Errorf("unknown search index %s", event.IndexName)
+}
+
+// fail makes the events available again after a backoff that doubles with every attempt.
+func (service *SearchOutboxService) fail(ctx context.Context, event *model.SearchOutboxEvent, eventIDs []int64, cause error) {
+	backoff := searchOutboxMaxBackoff
+	if event.Attempts < 9 {
+		backoff = time.Duration(1<<event.Attempts) * time.Second
+	}
+	if backoff > searchOutboxMaxBackoff {
+		backoff = searchOutboxMaxBackoff
+	}
+
+	log.Printf("Cannot relay search event %d for %s, retrying in %s: %v\n", event.ID, event.DocumentKey(), backoff, cause)
+	err := service.SearchOutboxRepository.MarkFailed(util.WithoutTenant(ctx), eventIDs, cause.Error(), time.Now().Add(backoff))
+	if err != nil {
+		log.Println("Cannot mark the search event as failed", err)
+	}
+	service.count(0, int64(len(eventIDs)))
+}
+
+func (service *SearchOutboxService) count(relayed int64, failures int64) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	service.relayed += relayed
+	service.failures += failures
+	if relayed > 0 {
+		now := time.Now()
+		service.lastRelayedAt = &now
+	}
+}
+
+func removeString(values []string, value string) []string {
+	for i, v := range values {
+		if v == value {
+			return append(values[:i], values[i+1:]...)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	repository "inventory-management/backend/internal/repository/mock"
+	service "inventory-management/backend/internal/service/mock"
+	"inventory-management/backend/util"
+	"testing"
+	"time"
+)
+
+func TestSearchOutboxService_Relay(t *testing.T) {
+	testCases := []struct {
+		name                     string
+		expectedOutboxClaim      []*model.SearchOutboxEvent
+		expectedIndexSupplier    error
+		expectedDeletedIDs       [][]int64
+		expectedFailedIDs        []int64
+		expectedIndexed          int
+		expectedRelayed          int64
+		expectedFailures         int64
+		expectedSvc              int
+		expectedSvcError         error
+		expectedOutboxClaimError error
+	}{
+		{
+			name: "Events about the same document are applied once",
+			expectedOutboxClaim: []*model.SearchOutboxEvent{
+				{ID: 1, TenantID: 1, IndexName: model.SearchIndexSuppliers, EntityKey: "WDWDARFSYH", DocumentID: 1, Operation: model.OutboxOperationIndex},
+				{ID: 2, TenantID: 1, IndexName: model.SearchIndexCustomers, EntityKey: "HYSFRADWDW", DocumentID: 1, Operation: model.OutboxOperationIndex},
+				{ID: 3, TenantID: 1, IndexName: model.SearchIndexCustomers, EntityKey: "HYSFRADWDW", DocumentID: 1, Operation: model.OutboxOperationDelete},
+			},
+			expectedDeletedIDs: [][]int64{{1}, {2, 3}},
+			expectedIndexed:    1,
+			expectedRelayed:    3,
+			expectedSvc:        3,
+		},
+		{
+			name: "Entity deleted after the event was written",
+			expectedOutboxClaim: []*model.SearchOutboxEvent{
+				{ID: 1, TenantID: 2, IndexName: model.SearchIndexSuppliers, EntityKey: "WDWDARFSYH", DocumentID: 1, Operation: model.OutboxOperationIndex},
+			},
+			expectedIndexSupplier: errors.New(response.ErrorNotFound),
+			expectedDeletedIDs:    [][]int64{{1}},
+			expectedIndexed:       1,
+			expectedRelayed:       1,
+			expectedSvc:           1,
+		},
+		{
+			name: "Elasticsearch is unreachable",
+			expectedOutboxClaim: []*model.SearchOutboxEvent{
+				{ID: 1, TenantID: 1, IndexName: model.SearchIndexSuppliers, EntityKey: "WDWDARFSYH", DocumentID: 1, Operation: model.OutboxOperationIndex, Attempts: 2},
+			},
+			expectedIndexSupplier: errors.New("connection refused"),
+			expectedFailedIDs:     []int64{1},
+			expectedIndexed:       1,
+			expectedFailures:      1,
+			expectedSvc:           1,
+		},
+		{
+			name:                     "Outbox cannot be claimed",
+			expectedOutboxClaimError: errors.New("getting an error"),
+			expectedSvcError:         errors.New("getting an error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var repo repository.SearchOutboxRepositoryMock
+			repo.On("Claim", util.WithoutTenant(ctx), 100, time.Minute).Return(tc.expectedOutboxClaim, tc.expectedOutboxClaimError)
+			repo.On("Delete", util.WithoutTenant(ctx), mock.Anything).Return(nil)
+			repo.On("MarkFailed", util.WithoutTenant(ctx), tc.expectedFailedIDs, mock.Anything, mock.Anything).Return(nil)
+			var search service.SearchServiceMock
+			search.On("IndexSupplier", mock.Anything, "WDWDARFSYH").Return(tc.expectedIndexSupplier)
+			search.On("Remove", mock.Anything, mock.Anything, int64(1)).Return(nil)
+			svc := NewSearchOutboxService(&repo, &search)
+			result, err := svc.Relay(ctx)
+			if tc.expectedSvcError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+			}
+
+			assert.Equal(t, tc.expectedSvc, result)
+			for _, ids := range tc.expectedDeletedIDs {
+				repo.AssertCalled(t, "Delete", util.WithoutTenant(ctx), ids)
+			}
+			repo.AssertNumberOfCalls(t, "Delete", len(tc.expectedDeletedIDs))
+			if tc.expectedFailedIDs != nil {
+				// The third attempt waits four seconds
+				availableAt := repo.Calls[len(repo.Calls)-1].Arguments.Get(3).(time.Time)
+				assert.WithinDuration(t, time.Now().Add(4*time.Second), availableAt, time.Second)
+			}
+			search.AssertNumberOfCalls(t, "IndexSupplier", tc.expectedIndexed)
+
+			stats := svc.(*SearchOutboxService)
+			assert.Equal(t, tc.expectedRelayed, stats.relayed)
+			assert.Equal(t, tc.expectedFailures, stats.failures)
+		})
+	}
+}
+
+func TestSearchOutboxService_Stats(t *testing.T) {
+	oldest := time.Now().Add(-time.Minute)
+	ctx := context.Background()
+
+	var repo repository.SearchOutboxRepositoryMock
+	repo.On("Stats", util.WithoutTenant(ctx)).Return(&model.SearchOutboxStats{Pending: 3, Failing: 1, OldestCreatedAt: &oldest}, nil)
+	var search service.SearchServiceMock
+	svc := NewSearchOutboxService(&repo, &search)
+	result, err := svc.Stats(ctx)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(3), result.Pending)
+	assert.Equal(t, int64(1), result.Failing)
+	assert.InDelta(t, 60, result.LagSeconds, 1)
+	assert.Nil(t, result.LastRelayedAt)
+}
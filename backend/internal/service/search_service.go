@@ -0,0 +1,444 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/internal/repository"
+	search "inventory-management/backend/internal/third_party/search"
+	"strings"
+)
+
+const searchReindexBatchSize = 500
+
+const searchSuggestLimit = 5
+
+type SearchService struct {
+	SearchEngine             search.SearchEngineContract
+	ProductRepository        repository.ProductRepositoryContract
+	ProductQualityRepository repository.ProductQualityRepositoryContract
+	TransactionRepository    repository.TransactionRepositoryContract
+	SupplierRepository       repository.SupplierRepositoryContract
+	CustomerRepository       repository.CustomerRepositoryContract
+	UserRepository           repository.UserRepositoryContract
+	SearchSynonymRepository  repository.SearchSynonymRepositoryContract
+}
+
+func NewSearchService(searchEngine search.SearchEngineContract, productRepository repository.ProductRepositoryContract, productQualityRepository repository.ProductQualityRepositoryContract, transactionRepository repository.TransactionRepositoryContract, supplierRepository repository.SupplierRepositoryContract, customerRepository repository.CustomerRepositoryContract, userRepository repository.UserRepositoryContract, searchSynonymRepository repository.SearchSynonymRepositoryContract) SearchServiceContract {
+	return &SearchService{
+		SearchEngine:             searchEngine,
+		ProductRepository:        productRepository,
+		ProductQualityRepository: productQualityRepository,
+		TransactionRepository:    transactionRepository,
+		SupplierRepository:       supplierRepository,
+		CustomerRepository:       customerRepository,
+		UserRepository:           userRepository,
+		SearchSynonymRepository:  searchSynonymRepository,
+	}
+}
+
+// IndexProduct indexes the product together with its qualities, since quality documents carry
+// the product name.
+func (service *SearchService) IndexProduct(ctx context.Context, code string) error {
+	product, err := service.ProductRepository.FindByCodeWithAssociations(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	err = service.SearchEngine.Index(ctx, model.SearchIndexProducts, product.ID, product.ToSearchDocument())
+	if err != nil {
+		return err
+	}
+
+	for _, productQuality := range product.ProductQualities {
+		productQuality.Product = product
+		err = service.SearchEngine.Index(ctx, model.SearchIndexProductQualities, productQuality.ID, productQuality.ToSearchDocument())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (service *SearchService) IndexProductQuality(ctx context.Context, id int64) error {
+	productQuality, err := service.ProductQualityRepository.FindByIDWithAssociations(ctx, id, nil)
+	if err != nil {
+		return err
+	}
+
+	return service.SearchEngine.Index(ctx, model.SearchIndexProductQualities, productQuality.ID, productQuality.ToSearchDocument())
+}
+
+func (service *SearchService) IndexTransaction(ctx context.Context, code string) error {
+	transaction, err := service.TransactionRepository.FindByCodeWithAssociations(ctx, code, nil)
+	if err != nil {
+		return err
+	}
+
+	return service.SearchEngine.Index(ctx, model.SearchIndexTransactions, transaction.ID, transaction.ToSearchDocument())
+}
+
+func (service *SearchService) IndexSupplier(ctx context.Context, code string) error {
+	supplier, err := service.SupplierRepository.FindByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	return service.SearchEngine.Index(ctx, model.SearchIndexSuppliers, supplier.ID, supplier.ToSearchDocument())
+}
+
+func (service *SearchService) IndexCustomer(ctx context.Context, code string) error {
+	customer, err := service.CustomerRepository.FindByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	return service.SearchEngine.Index(ctx, model.SearchIndexCustomers, customer.ID, customer.ToSearchDocument())
+}
+
+func (service *SearchService) IndexUser(ctx context.Context, id int64) error {
+	user, err := service.UserRepository.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return service.SearchEngine.Index(ctx, model.SearchIndexUsers, user.ID, user.ToSearchDocument())
+}
+
+func (service *SearchService) Remove(ctx context.Context, index string, id int64) error {
+	return service.SearchEngine.Remove(ctx, index, id)
+}
+
+// Reindex rebuilds the index of the context tenant from Postgres.
+func (service *SearchService) Reindex(ctx context.Context, index string) (*response.ReindexResponse, error) {
+	if !model.IsSearchIndex(index) {
+		return nil, errors.New(response.ErrorSearchIndexUnknown)
+	}
+
+	rebuild, err := service.SearchEngine.Rebuild(ctx, index, func(ctx context.Context, afterID int64) ([]*search.Document, error) {
+		return service.findDocuments(ctx, index, afterID)
+	})
+	if err != nil {
+		if err.Error() == search.ErrorRebuildCountMismatch {
+			return nil, errors.New(response.ErrorReindexCountMismatch)
+		}
+		return nil, err
+	}
+
+	return &response.ReindexResponse{
+		Alias:          rebuild.Alias,
+		Index:          rebuild.Index,
+		Documents:      rebuild.Documents,
+		DeletedIndices: rebuild.DeletedIndices,
+	}, nil
+}
+
+// findDocuments reads the next batch of documents of the index from Postgres.
+func (service *SearchService) findDocuments(ctx context.Context, index string, afterID int64) ([]*search.Document, error) {
+	var documents []*search.Document
+	switch index {
+	case model.SearchIndexProducts:
+		products, err := service.ProductRepository.FindAllAfterID(ctx, afterID, searchReindexBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, product := range products {
+			documents = append(documents, &search.Document{ID: product.ID, Body: product.ToSearchDocument()})
+		}
+	case model.SearchIndexProductQualities:
+		productQualities, err := service.ProductQualityRepository.FindAllAfterID(ctx, afterID, searchReindexBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, productQuality := range productQualities {
+			documents = append(documents, &search.Document{ID: productQuality.ID, Body: productQuality.ToSearchDocument()})
+		}
+	case model.SearchIndexTransactions:
+		transactions, err := service.TransactionRepository.FindAllAfterID(ctx, afterID, searchReindexBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, transaction := range transactions {
+			documents = append(documents, &search.Document{ID: transaction.ID, Body: transaction.ToSearchDocument()})
+		}
+	case model.SearchIndexSuppliers:
+		suppliers, err := service.SupplierRepository.FindAllAfterID(ctx, afterID, searchReindexBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, supplier := range suppliers {
+			documents = append(documents, &search.Document{ID: supplier.ID, Body: supplier.ToSearchDocument()})
+		}
+	case model.SearchIndexCustomers:
+		customers, err := service.CustomerRepository.FindAllAfterID(ctx, afterID, searchReindexBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, customer := range customers {
+			documents = append(documents, &search.Document{ID: customer.ID, Body: customer.ToSearchDocument()})
+		}
+	case model.SearchIndexUsers:
+		users, err := service.UserRepository.FindAllAfterID(ctx, afterID, searchReindexBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			documents = append(documents, &search.Document{ID: user.ID, Body: user.ToSearchDocument()})
+		}
+	}
+
+	return documents, nil
+}
+
+func (service *SearchService) SearchProducts(ctx context.Context, searchRequest *request.ProductSearchRequest, offset int, limit int) (*response.SearchResponse, error) {
+	var filters []*search.Filter
+	if searchRequest.UnitMassAcronym != "" {
+		filters = append(filters, search.TermFilter("unit_mass_acronym", searchRequest.UnitMassAcronym))
+	}
+	if searchRequest.QualityType != "" {
+		filters = append(filters, search.TermFilter("quality_types", searchRequest.QualityType))
+	}
+	if searchRequest.MinPrice > 0 {
+		filters = append(filters, search.RangeFilter("max_price", search.FilterGTE, float64(searchRequest.MinPrice)))
+	}
+	if searchRequest.MaxPrice > 0 {
+		filters = append(filters, search.RangeFilter("min_price", search.FilterLTE, float64(searchRequest.MaxPrice)))
+	}
+
+	return service.search(ctx, model.SearchIndexProducts, &search.Query{
+		Text:    searchRequest.Query,
+		Fields:  []string{"name^3", "qualities", "code", "unit_mass_description"},
+		Filters: filters,
+		Facets: []*search.Facet{
+			search.TermsFacet("unit_mass_acronym", "unit_mass_acronym"),
+			search.TermsFacet("quality_type", "quality_types"),
+		},
+		DefaultSort: "created_at",
+	}, offset, limit)
+}
+
+func (service *SearchService) SearchProductQualities(ctx context.Context, searchRequest *request.ProductQualitySearchRequest, offset int, limit int) (*response.SearchResponse, error) {
+	var filters []*search.Filter
+	if searchRequest.ProductCode != "" {
+		filters = append(filters, search.TermFilter("product_code", searchRequest.ProductCode))
+	}
+	if searchRequest.Type != "" {
+		filters = append(filters, search.TermFilter("type", searchRequest.Type))
+	}
+	if searchRequest.InStock {
+		filters = append(filters, search.RangeFilter("quantity", search.FilterGT, 0))
+	}
+
+	return service.search(ctx, model.SearchIndexProductQualities, &search.Query{
+		Text:    searchRequest.Query,
+		Fields:  []string{"product_name^3", "quality^2", "product_code"},
+		Filters: filters,
+		Facets: []*search.Facet{
+			search.TermsFacet("type", "type"),
+			search.TermsFacet("product_code", "product_code"),
+		},
+		DefaultSort: "id",
+	}, offset, limit)
+}
+
+func (service *SearchService) SearchTransactions(ctx context.Context, searchRequest *request.TransactionSearchRequest, offset int, limit int) (*response.SearchResponse, error) {
+	var filters []*search.Filter
+	if searchRequest.Type != "" {
+		filters = append(filters, search.TermFilter("type", searchRequest.Type))
+	}
+	if searchRequest.ProductCode != "" {
+		filters = append(filters, search.TermFilter("product_code", searchRequest.ProductCode))
+	}
+	if searchRequest.SupplierCode != "" {
+		filters = append(filters, search.TermFilter("supplier_code", searchRequest.SupplierCode))
+	}
+	if searchRequest.CustomerCode != "" {
+		filters = append(filters, search.TermFilter("customer_code", searchRequest.CustomerCode))
+	}
+	if searchRequest.From != "" || searchRequest.To != "" {
+		filters = append(filters, search.DateRangeFilter("created_at", searchRequest.From, searchRequest.To))
+	}
+
+	return service.search(ctx, model.SearchIndexTransactions, &search.Query{
+		Text:    searchRequest.Query,
+		Fields:  []string{"code^3", "product_name^2", "quality", "supplier_name", "customer_name", "description"},
+		Filters: filters,
+		Facets: []*search.Facet{
+			search.TermsFacet("type", "type"),
+			search.TermsFacet("product_code", "product_code"),
+			search.TermsFacet("supplier_code", "supplier_code"),
+			search.TermsFacet("customer_code", "customer_code"),
+			search.MonthFacet("month", "created_at"),
+		},
+		DefaultSort: "created_at",
+	}, offset, limit)
+}
+
+func (service *SearchService) SearchSuppliers(ctx context.Context, searchRequest *request.SupplierSearchRequest, offset int, limit int) (*response.SearchResponse, error) {
+	var filters []*search.Filter
+	if searchRequest.Phone != "" {
+		filters = append(filters, search.TermFilter("phone", searchRequest.Phone))
+	}
+
+	return service.search(ctx, model.SearchIndexSuppliers, &search.Query{
+		Text:    searchRequest.Query,
+		Fields:  []string{"name^3", "code", "address", "phone"},
+		Filters: filters,
+		Facets: []*search.Facet{
+			search.MonthFacet("created_month", "created_at"),
+		},
+		DefaultSort: "created_at",
+	}, offset, limit)
+}
+
+func (service *SearchService) SearchCustomers(ctx context.Context, searchRequest *request.CustomerSearchRequest, offset int, limit int) (*response.SearchResponse, error) {
+	return service.search(ctx, model.SearchIndexCustomers, &search.Query{
+		Text:   searchRequest.Query,
+		Fields: []string{"name^3", "code"},
+		Facets: []*search.Facet{
+			search.MonthFacet("created_month", "created_at"),
+		},
+		DefaultSort: "created_at",
+	}, offset, limit)
+}
+
+// Suggest looks up what a clerk is typing: products and qualities by a partial or misspelled name,
+// one of its synonyms or a prefix of the code, and suppliers and customers by a prefix of the code.
+func (service *SearchService) Suggest(ctx context.Context, suggestRequest *request.SuggestRequest) (*response.SuggestResponse, error) {
+	limit := suggestRequest.Limit
+	if limit == 0 {
+		limit = searchSuggestLimit
+	}
+
+	synonyms, err := service.SearchSynonymRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	expanded := expandSynonyms(suggestRequest.Query, synonyms)
+
+	products, err := service.SearchEngine.Search(ctx, model.SearchIndexProducts, &search.Query{
+		Text:         suggestRequest.Query,
+		Fields:       []string{"name^3", "qualities"},
+		Synonyms:     expanded,
+		PrefixFields: []string{"code"},
+	}, 0, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	productQualities, err := service.SearchEngine.Search(ctx, model.SearchIndexProductQualities, &search.Query{
+		Text:         suggestRequest.Query,
+		Fields:       []string{"product_name^3", "quality^2"},
+		Synonyms:     expanded,
+		PrefixFields: []string{"product_code"},
+	}, 0, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	suppliers, err := service.SearchEngine.Search(ctx, model.SearchIndexSuppliers, &search.Query{
+		Text:         suggestRequest.Query,
+		PrefixFields: []string{"code"},
+		Sort:         []*search.Sort{{Field: "code"}},
+	}, 0, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	customers, err := service.SearchEngine.Search(ctx, model.SearchIndexCustomers, &search.Query{
+		Text:         suggestRequest.Query,
+		PrefixFields: []string{"code"},
+		Sort:         []*search.Sort{{Field: "code"}},
+	}, 0, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.SuggestResponse{
+		Products:         toSuggestions(products.Hits, "code", "name"),
+		ProductQualities: toSuggestions(productQualities.Hits, "product_code", "product_name", "quality"),
+		Suppliers:        toSuggestions(suppliers.Hits, "code", "name"),
+		Customers:        toSuggestions(customers.Hits, "code", "name"),
+	}, nil
+}
+
+func (service *SearchService) search(ctx context.Context, index string, query *search.Query, offset int, limit int) (*response.SearchResponse, error) {
+	result, err := service.SearchEngine.Search(ctx, index, query, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSearchResponse(result), nil
+}
+
+func toSearchResponse(result *search.Result) *response.SearchResponse {
+	searchResponse := &response.SearchResponse{
+		Total:  result.Total,
+		Hits:   result.Hits,
+		Facets: map[string][]*response.FacetBucketResponse{},
+	}
+	for name, buckets := range result.Facets {
+		searchResponse.Facets[name] = []*response.FacetBucketResponse{}
+		for _, bucket := range buckets {
+			searchResponse.Facets[name] = append(searchResponse.Facets[name], &response.FacetBucketResponse{
+				Value: bucket.Value,
+				Count: bucket.Count,
+			})
+		}
+	}
+
+	return searchResponse
+}
+
+// expandSynonyms returns the text with each word or phrase of a synonym group it contains replaced
+// by the other ones of the group, e.g. "water spinach 1kg" also as "kangkung 1kg".
+func expandSynonyms(text string, synonyms []*model.SearchSynonym) []string {
+	normalized := " " + strings.Join(strings.Fields(strings.ToLower(text)), " ") + " "
+	seen := map[string]bool{normalized: true}
+
+	var expanded []string
+	for _, synonym := range synonyms {
+		words := synonym.WordList()
+		for _, word := range words {
+			if !strings.Contains(normalized, " "+word+" ") {
+				continue
+			}
+
+			for _, other := range words {
+				replaced := strings.Replace(normalized, " "+word+" ", " "+other+" ", 1)
+				if !seen[replaced] {
+					seen[replaced] = true
+					expanded = append(expanded, strings.TrimSpace(replaced))
+				}
+			}
+		}
+	}
+
+	return expanded
+}
+
+// toSuggestions turns the hits into suggestions with the code field and the text fields joined.
+func toSuggestions(hits []map[string]interface{}, codeField string, textFields ...string) []*response.SuggestionResponse {
+	suggestions := []*response.SuggestionResponse{}
+	for _, hit := range hits {
+		var texts []string
+		for _, field := range textFields {
+			if text, ok := hit[field].(string); ok && text != "" {
+				texts = append(texts, text)
+			}
+		}
+
+		id, _ := hit["id"].(float64)
+		code, _ := hit[codeField].(string)
+		suggestions = append(suggestions, &response.SuggestionResponse{
+			ID:   int64(id),
+			Code: code,
+			Text: strings.Join(texts, " "),
+		})
+	}
+
+	return suggestions
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	repository "inventory-management/backend/internal/repository/mock"
+	search "inventory-management/backend/internal/third_party/search"
+	"testing"
+)
+
+func TestSearchService_IndexSupplier(t *testing.T) {
+	supplier := &model.Supplier{
+		ID:   1,
+		Code: "WDWDARFSYH",
+		Name: "Widdy Arfiansyah",
+	}
+
+	testCases := []struct {
+		name                                string
+		expectedSupplierRepoFindByCode      *model.Supplier
+		expectedSupplierRepoFindByCodeError error
+		expectedEngineIndexNumCalls         int
+		expectedSvcError                    error
+	}{
+		{
+			name:                           "Index supplier",
+			expectedSupplierRepoFindByCode: supplier,
+			expectedEngineIndexNumCalls:    1,
+		},
+		{
+			name:                                "Supplier not found",
+			expectedSupplierRepoFindByCodeError: errors.New(response.ErrorNotFound),
+			expectedSvcError:                    errors.New(response.ErrorNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var repoS repository.SupplierRepositoryMock
+			repoS.On("FindByCode", ctx, "WDWDARFSYH").Return(tc.expectedSupplierRepoFindByCode, tc.expectedSupplierRepoFindByCodeError)
+			var engine search.SearchEngineMock
+			engine.On("Index", ctx, model.SearchIndexSuppliers, int64(1), supplier.ToSearchDocument()).Return(nil)
+			svc := NewSearchService(&engine, nil, nil, nil, &repoS, nil, nil, nil)
+
+			err := svc.IndexSupplier(ctx, "WDWDARFSYH")
+			if tc.expectedSvcError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			engine.AssertNumberOfCalls(t, "Index", tc.expectedEngineIndexNumCalls)
+		})
+	}
+}
+
+func TestSearchService_SearchProducts(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		expectedEngineSearch *search.Result
+		expectedEngineError  error
+		expectedSvc          *response.SearchResponse
+		expectedSvcError     error
+	}{
+		{
+			name: "Hits and facets",
+			expectedEngineSearch: &search.Result{
+				Total: 1,
+				Hits: []map[string]interface{}{
+					{"code": "ABCDEFGHIJ", "name": "Kangkung"},
+				},
+				Facets: map[string][]*search.Bucket{
+					"unit_mass_acronym": {{Value: "kg", Count: 1}},
+				},
+			},
+			expectedSvc: &response.SearchResponse{
+				Total: 1,
+				Hits: []map[string]interface{}{
+					{"code": "ABCDEFGHIJ", "name": "Kangkung"},
+				},
+				Facets: map[string][]*response.FacetBucketResponse{
+					"unit_mass_acronym": {{Value: "kg", Count: 1}},
+				},
+			},
+		},
+		{
+			name:                "Search engine getting an error",
+			expectedEngineError: errors.New("connection refused"),
+			expectedSvcError:    errors.New("connection refused"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var engine search.SearchEngineMock
+			engine.On("Search", ctx, model.SearchIndexProducts, &search.Query{
+				Text:    "kangkung",
+				Fields:  []string{"name^3", "qualities", "code", "unit_mass_description"},
+				Filters: []*search.Filter{search.TermFilter("unit_mass_acronym", "kg"), search.RangeFilter("min_price", search.FilterLTE, 5000)},
+				Facets: []*search.Facet{
+					search.TermsFacet("unit_mass_acronym", "unit_mass_acronym"),
+					search.TermsFacet("quality_type", "quality_types"),
+				},
+				DefaultSort: "created_at",
+			}, 0, 10).Return(tc.expectedEngineSearch, tc.expectedEngineError)
+			svc := NewSearchService(&engine, nil, nil, nil, nil, nil, nil, nil)
+			result, err := svc.SearchProducts(ctx, &request.ProductSearchRequest{Query: "kangkung", UnitMassAcronym: "kg", MaxPrice: 5000}, 0, 10)
+			if tc.expectedSvcError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+			}
+
+			assert.Equal(t, tc.expectedSvc, result)
+		})
+	}
+}
+
+func TestSearchService_Reindex(t *testing.T) {
+	suppliers := []*model.Supplier{
+		{ID: 1, Code: "WDWDARFSYH", Name: "Widdy Arfiansyah"},
+		{ID: 2, Code: "ABCDEFGHIJ", Name: "Angga Saputra"},
+	}
+
+	testCases := []struct {
+		name                       string
+		index                      string
+		expectedSupplierRepoError  error
+		expectedEngineRebuild      *search.RebuildResult
+		expectedEngineRebuildError error
+		expectedSvc                *response.ReindexResponse
+		expectedSvcError           error
+	}{
+		{
+			name:  "Reindex suppliers",
+			index: model.SearchIndexSuppliers,
+			expectedEngineRebuild: &search.RebuildResult{
+				Alias:          "suppliers",
+				Index:          "suppliers_v20230601120000",
+				Documents:      2,
+				DeletedIndices: []string{"suppliers_v1"},
+			},
+			expectedSvc: &response.ReindexResponse{
+				Alias:          "suppliers",
+				Index:          "suppliers_v20230601120000",
+				Documents:      2,
+				DeletedIndices: []string{"suppliers_v1"},
+			},
+		},
+		{
+			name:                       "Document count does not match",
+			index:                      model.SearchIndexSuppliers,
+			expectedEngineRebuildError: errors.New(search.ErrorRebuildCountMismatch),
+			expectedSvcError:           errors.New(response.ErrorReindexCountMismatch),
+		},
+		{
+			name:                      "Loading the suppliers fails",
+			index:                     model.SearchIndexSuppliers,
+			expectedSupplierRepoError: errors.New("connection refused"),
+			expectedSvcError:          errors.New("connection refused"),
+		},
+		{
+			name:             "Unknown index",
+			index:            "warehouses",
+			expectedSvcError: errors.New(response.ErrorSearchIndexUnknown),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var repoS repository.SupplierRepositoryMock
+			repoS.On("FindAllAfterID", ctx, int64(0), searchReindexBatchSize).Return(suppliers, tc.expectedSupplierRepoError)
+			repoS.On("FindAllAfterID", ctx, int64(2), searchReindexBatchSize).Return([]*model.Supplier{}, nil)
+			var engine search.SearchEngineMock
+			engine.On("Rebuild", ctx, tc.index).Return(tc.expectedEngineRebuild, tc.expectedEngineRebuildError)
+			svc := NewSearchService(&engine, nil, nil, nil, &repoS, nil, nil, nil)
+
+			result, err := svc.Reindex(ctx, tc.index)
+			if tc.expectedSvcError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+			}
+
+			assert.Equal(t, tc.expectedSvc, result)
+		})
+	}
+}
+
+func TestSearchService_Suggest(t *testing.T) {
+	synonyms := []*model.SearchSynonym{
+		{ID: 1, Words: "kangkung, water spinach"},
+		{ID: 2, Words: "bayam, spinach"},
+	}
+
+	testCases := []struct {
+		name                  string
+		query                 string
+		expectedSynonyms      []string
+		expectedProducts      *search.Result
+		expectedEngineError   error
+		expectedSynonymsError error
+		expectedSvc           *response.SuggestResponse
+		expectedSvcError      error
+	}{
+		{
+			name:             "Suggestions of every kind",
+			query:            "kangk",
+			expectedProducts: &search.Result{Hits: []map[string]interface{}{{"id": float64(1), "code": "ABCDEFGHIJ", "name": "Kangkung"}}},
+			expectedSvc: &response.SuggestResponse{
+				Products:         []*response.SuggestionResponse{{ID: 1, Code: "ABCDEFGHIJ", Text: "Kangkung"}},
+				ProductQualities: []*response.SuggestionResponse{{ID: 2, Code: "ABCDEFGHIJ", Text: "Kangkung Fresh"}},
+				Suppliers:        []*response.SuggestionResponse{},
+				Customers:        []*response.SuggestionResponse{},
+			},
+		},
+		{
+			name:             "A phrase of a synonym group is searched as the other ones",
+			query:            "Water  Spinach",
+			expectedSynonyms: []string{"kangkung", "water bayam"},
+			expectedProducts: &search.Result{Hits: []map[string]interface{}{}},
+			expectedSvc: &response.SuggestResponse{
+				Products:         []*response.SuggestionResponse{},
+				ProductQualities: []*response.SuggestionResponse{{ID: 2, Code: "ABCDEFGHIJ", Text: "Kangkung Fresh"}},
+				Suppliers:        []*response.SuggestionResponse{},
+				Customers:        []*response.SuggestionResponse{},
+			},
+		},
+		{
+			name:                  "Synonyms cannot be read",
+			query:                 "kangk",
+			expectedSynonymsError: errors.New("connection refused"),
+			expectedSvcError:      errors.New("connection refused"),
+		},
+		{
+			name:                "Search engine getting an error",
+			query:               "kangk",
+			expectedEngineError: errors.New("connection refused"),
+			expectedSvcError:    errors.New("connection refused"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var repoSS repository.SearchSynonymRepositoryMock
+			repoSS.On("FindAll", ctx).Return(synonyms, tc.expectedSynonymsError)
+			var engine search.SearchEngineMock
+			engine.On("Search", ctx, model.SearchIndexProducts, &search.Query{
+				Text:         tc.query,
+				Fields:       []string{"name^3", "qualities"},
+				Synonyms:     tc.expectedSynonyms,
+				PrefixFields: []string{"code"},
+			}, 0, searchSuggestLimit).Return(tc.expectedProducts, tc.expectedEngineError)
+			engine.On("Search", ctx, model.SearchIndexProductQualities, &search.Query{
+				Text:         tc.query,
+				Fields:       []string{"product_name^3", "quality^2"},
+				Synonyms:     tc.expectedSynonyms,
+				PrefixFields: []string{"product_code"},
+			}, 0, searchSuggestLimit).Return(&search.Result{Hits: []map[string]interface{}{{"id": float64(2), "product_code": "ABCDEFGHIJ", "product_name": "Kangkung", "quality": "Fresh"}}}, nil)
+			engine.On("Search", ctx, model.SearchIndexSuppliers, &search.Query{
+				Text:         tc.query,
+				PrefixFields: []string{"code"},
+				Sort:         []*search.Sort{{Field: "code"}},
+			}, 0, searchSuggestLimit).Return(&search.Result{}, nil)
+			engine.On("Search", ctx, model.SearchIndexCustomers, &search.Query{
+				Text:         tc.query,
+				PrefixFields: []string{"code"},
+				Sort:         []*search.Sort{{Field: "code"}},
+			}, 0, searchSuggestLimit).Return(&search.Result{}, nil)
+			svc := NewSearchService(&engine, nil, nil, nil, nil, nil, nil, &repoSS)
+
+			result, err := svc.Suggest(ctx, &request.SuggestRequest{Query: tc.query})
+			if tc.expectedSvcError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+			}
+
+			assert.Equal(t, tc.expectedSvc, result)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/internal/repository"
+	"strconv"
+)
+
+type SearchSynonymService struct {
+	SearchSynonymRepository repository.SearchSynonymRepositoryContract
+	Transactor              repository.TransactorContract
+	AuditService            AuditServiceContract
+}
+
+func NewSearchSynonymService(searchSynonymRepository repository.SearchSynonymRepositoryContract, transactor repository.TransactorContract, auditService AuditServiceContract) SearchSynonymServiceContract {
+	return &SearchSynonymService{
+		SearchSynonymRepository: searchSynonymRepository,
+		Transactor:              transactor,
+		AuditService:            auditService,
+	}
+}
+
+func (service *SearchSynonymService) FindAll(ctx context.Context) ([]*response.SearchSynonymResponse, error) {
+	synonyms, err := service.SearchSynonymRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	synonymResponses := []*response.SearchSynonymResponse{}
+	for _, synonym := range synonyms {
+		synonymResponses = append(synonymResponses, synonym.ToResponse())
+	}
+
+	return synonymResponses, nil
+}
+
+func (service *SearchSynonymService) Create(ctx context.Context, request *request.CreateSearchSynonymRequest) (*response.SearchSynonymResponse, error) {
+	var synonymRequest model.SearchSynonym
+	synonymRequest.SetWords(request.Words)
+
+	var synonym *model.SearchSynonym
+	err := service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		synonym, err = service.SearchSynonymRepository.Create(ctx, &synonymRequest)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntitySearchSynonym, strconv.FormatInt(synonym.ID, 10), model.AuditActionCreate, nil, synonym.ToResponse())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return synonym.ToResponse(), nil
+}
+
+func (service *SearchSynonymService) Update(ctx context.Context, request *request.UpdateSearchSynonymRequest) (*response.SearchSynonymResponse, error) {
+	checkSynonym, err := service.SearchSynonymRepository.FindByID(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := checkSynonym.ToResponse()
+
+	checkSynonym.SetWords(request.Words)
+	var synonym *model.SearchSynonym
+	err = service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		synonym, err = service.SearchSynonymRepository.Update(ctx, checkSynonym)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntitySearchSynonym, strconv.FormatInt(synonym.ID, 10), model.AuditActionUpdate, before, synonym.ToResponse())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return synonym.ToResponse(), nil
+}
+
+func (service *SearchSynonymService) Delete(ctx context.Context, id int64) error {
+	checkSynonym, err := service.SearchSynonymRepository.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		err := service.SearchSynonymRepository.Delete(ctx, checkSynonym.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntitySearchSynonym, strconv.FormatInt(checkSynonym.ID, 10), model.AuditActionDelete, checkSynonym.ToResponse(), nil)
+	})
+}
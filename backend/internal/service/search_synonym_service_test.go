@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/request"
+	response "inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	repository "inventory-management/backend/internal/repository/mock"
+	service "inventory-management/backend/internal/service/mock"
+	"testing"
+)
+
+func TestSearchSynonymService_Create(t *testing.T) {
+	testCases := []struct {
+		name                      string
+		request                   *request.CreateSearchSynonymRequest
+		expectedWords             string
+		expectedRepoCreate        *model.SearchSynonym
+		expectedRepoCreateError   error
+		expectedSvc               *response.SearchSynonymResponse
+		expectedSvcError          error
+		expectedAuditRecordNumber int
+	}{
+		{
+			name:               "Words are stored lowercase and trimmed",
+			request:            &request.CreateSearchSynonymRequest{Words: []string{" Kangkung", "Water  Spinach "}},
+			expectedWords:      "kangkung, water spinach",
+			expectedRepoCreate: &model.SearchSynonym{ID: 1, Words: "kangkung, water spinach"},
+			expectedSvc: &response.SearchSynonymResponse{
+				ID:        1,
+				Words:     []string{"kangkung", "water spinach"},
+				CreatedAt: "0001-01-01 07:00:00 +0700 +07",
+				UpdatedAt: "0001-01-01 07:00:00 +0700 +07",
+			},
+			expectedAuditRecordNumber: 1,
+		},
+		{
+			name:                    "Repository getting an error",
+			request:                 &request.CreateSearchSynonymRequest{Words: []string{"bayam", "spinach"}},
+			expectedWords:           "bayam, spinach",
+			expectedRepoCreateError: errors.New("getting an error"),
+			expectedSvcError:        errors.New("getting an error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var repo repository.SearchSynonymRepositoryMock
+			repo.On("Create", ctx, &model.SearchSynonym{Words: tc.expectedWords}).Return(tc.expectedRepoCreate, tc.expectedRepoCreateError)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, model.AuditEntitySearchSynonym, "1", model.AuditActionCreate, mock.Anything, mock.Anything).Return(nil)
+			svc := NewSearchSynonymService(&repo, &repository.TransactorMock{}, &audit)
+
+			result, err := svc.Create(ctx, tc.request)
+			if tc.expectedSvcError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+			}
+
+			assert.Equal(t, tc.expectedSvc, result)
+			audit.AssertNumberOfCalls(t, "Record", tc.expectedAuditRecordNumber)
+		})
+	}
+}
+
+func TestSearchSynonymService_Update(t *testing.T) {
+	testCases := []struct {
+		name                      string
+		request                   *request.UpdateSearchSynonymRequest
+		expectedRepoFindByID      *model.SearchSynonym
+		expectedRepoFindByIDError error
+		expectedSvc               *response.SearchSynonymResponse
+		expectedSvcError          error
+	}{
+		{
+			name:                 "Replace the words of a group",
+			request:              &request.UpdateSearchSynonymRequest{ID: 1, Words: []string{"kangkung", "ipomoea"}},
+			expectedRepoFindByID: &model.SearchSynonym{ID: 1, Words: "kangkung, water spinach"},
+			expectedSvc: &response.SearchSynonymResponse{
+				ID:        1,
+				Words:     []string{"kangkung", "ipomoea"},
+				CreatedAt: "0001-01-01 07:00:00 +0700 +07",
+				UpdatedAt: "0001-01-01 07:00:00 +0700 +07",
+			},
+		},
+		{
+			name:                      "Synonym group does not exist",
+			request:                   &request.UpdateSearchSynonymRequest{ID: 1, Words: []string{"kangkung", "ipomoea"}},
+			expectedRepoFindByIDError: errors.New(response.ErrorNotFound),
+			expectedSvcError:          errors.New(response.ErrorNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var repo repository.SearchSynonymRepositoryMock
+			repo.On("FindByID", ctx, int64(1)).Return(tc.expectedRepoFindByID, tc.expectedRepoFindByIDError)
+			repo.On("Update", ctx, &model.SearchSynonym{ID: 1, Words: "kangkung, ipomoea"}).Return(&model.SearchSynonym{ID: 1, Words: "kangkung, ipomoea"}, nil)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, model.AuditEntitySearchSynonym, "1", model.AuditActionUpdate, mock.Anything, mock.Anything).Return(nil)
+			svc := NewSearchSynonymService(&repo, &repository.TransactorMock{}, &audit)
+
+			result, err := svc.Update(ctx, tc.request)
+			if tc.expectedSvcError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+			}
+
+			assert.Equal(t, tc.expectedSvc, result)
+		})
+	}
+}
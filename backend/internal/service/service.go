@@ -1,62 +1,194 @@
 package service
 
 import (
-	"bytes"
 	"context"
 	request "inventory-management/backend/internal/http/request"
 	response "inventory-management/backend/internal/http/response"
+	"inventory-management/backend/util"
+	"io"
+	"net/url"
 )
 
 type (
-	UserServiceContract interface {
-		Search(ctx context.Context, data bytes.Buffer, offset int, limit int, totalRecord chan<- int64) (map[string]interface{}, error)
-		FindAll(ctx context.Context, offset int, limit int) ([]*response.UserResponse, error)
+	TenantServiceContract interface {
+		FindAll(ctx context.Context, offset int, limit int) ([]*response.TenantResponse, error)
 		CountAll(ctx context.Context) (int64, error)
+		FindByID(ctx context.Context, id int64) (*response.TenantResponse, error)
+		Create(ctx context.Context, request *request.CreateTenantRequest) (*response.TenantResponse, error)
+		Update(ctx context.Context, request *request.UpdateTenantRequest) (*response.TenantResponse, error)
+		RotateApiKey(ctx context.Context, id int64) (*response.TenantResponse, error)
+		ResolveApiKey(ctx context.Context, apiKey string) (*response.TenantResponse, error)
+	}
+	UserServiceContract interface {
+		Search(ctx context.Context, request *request.UserSearchRequest, offset int, limit int) (*response.UserSearchResponse, error)
+		FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*response.UserResponse, error)
+		FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*response.UserResponse, *util.CursorPage, error)
+		CountAll(ctx context.Context, query *util.ListQuery) (int64, error)
 		FindByID(ctx context.Context, id int64) (*response.UserResponse, error)
 		VerifyLogin(ctx context.Context, request *request.LoginUserRequest) (*response.UserLoginResponse, error)
+		VerifyTokenVersion(ctx context.Context, username string, tokenVersion int64) error
 		Create(ctx context.Context, request *request.CreateUserRequest) (*response.UserResponse, error)
 		Update(ctx context.Context, request *request.UpdateUserRequest) (*response.UserResponse, error)
 		Delete(ctx context.Context, id int64) error
+		Restore(ctx context.Context, id int64) (*response.UserResponse, error)
+		Purge(ctx context.Context, id int64) error
+	}
+	AccountServiceContract interface {
+		Me(ctx context.Context, username string) (*response.UserResponse, error)
+		UpdateMe(ctx context.Context, request *request.UpdateMeRequest) (*response.UserResponse, error)
+		ChangePassword(ctx context.Context, request *request.ChangePasswordRequest) error
+		CreateInvitation(ctx context.Context, request *request.CreateInvitationRequest) (*response.InvitationResponse, error)
+		Register(ctx context.Context, request *request.RegisterUserRequest) (*response.UserResponse, error)
+		ForgotPassword(ctx context.Context, request *request.ForgotPasswordRequest) error
+		ResetPassword(ctx context.Context, request *request.ResetPasswordRequest) error
+	}
+	OidcServiceContract interface {
+		LoginURL(ctx context.Context) (*response.OidcLoginResponse, error)
+		LinkURL(ctx context.Context, username string) (*response.OidcLoginResponse, error)
+		Callback(ctx context.Context, request *request.OidcCallbackRequest) (*response.UserLoginResponse, error)
+		Run(ctx context.Context)
+	}
+	AuditServiceContract interface {
+		FindAll(ctx context.Context, filter *request.AuditLogFilterRequest, offset int, limit int) ([]*response.AuditLogResponse, error)
+		CountAll(ctx context.Context, filter *request.AuditLogFilterRequest) (int64, error)
+		Record(ctx context.Context, entity string, entityID string, action string, before interface{}, after interface{}) error
 	}
 	ProductServiceContract interface {
-		FindAll(ctx context.Context, offset int, limit int) ([]*response.ProductResponse, error)
-		CountAll(ctx context.Context) (int64, error)
+		FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*response.ProductResponse, error)
+		FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*response.ProductResponse, *util.CursorPage, error)
+		CountAll(ctx context.Context, query *util.ListQuery) (int64, error)
 		FindByCode(ctx context.Context, code string) (*response.ProductResponse, error)
 		Create(ctx context.Context, request *request.CreateProductRequest) (*response.ProductResponse, error)
 		Update(ctx context.Context, request *request.UpdateProductRequest) (*response.ProductResponse, error)
 		Delete(ctx context.Context, code string) error
+		Restore(ctx context.Context, code string) (*response.ProductResponse, error)
+		Purge(ctx context.Context, code string) error
 	}
 	ProductQualityServiceContract interface {
 		FindAll(ctx context.Context) ([]*response.ProductQualityResponse, error)
 		FindAllByProductCode(ctx context.Context, productCode string) (*response.ProductQualityWithOwnProductResponse, error)
 		FindByID(ctx context.Context, id int64) (*response.ProductQualityResponse, error)
 		Delete(ctx context.Context, id int64) error
+		Restore(ctx context.Context, id int64) (*response.ProductQualityResponse, error)
+		Purge(ctx context.Context, id int64) error
 	}
 	SupplierServiceContract interface {
-		FindAll(ctx context.Context, offset int, limit int) ([]*response.SupplierResponse, error)
-		CountAll(ctx context.Context) (int64, error)
+		FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*response.SupplierResponse, error)
+		FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*response.SupplierResponse, *util.CursorPage, error)
+		CountAll(ctx context.Context, query *util.ListQuery) (int64, error)
 		FindByCode(ctx context.Context, code string) (*response.SupplierResponse, error)
 		Create(ctx context.Context, request *request.CreateSupplierRequest) (*response.SupplierResponse, error)
 		Update(ctx context.Context, request *request.UpdateSupplierRequest) (*response.SupplierResponse, error)
 		Delete(ctx context.Context, code string) error
+		Restore(ctx context.Context, code string) (*response.SupplierResponse, error)
+		Purge(ctx context.Context, code string) error
 	}
 	CustomerServiceContract interface {
-		FindAll(ctx context.Context, offset int, limit int) ([]*response.CustomerResponse, error)
-		CountAll(ctx context.Context) (int64, error)
+		FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*response.CustomerResponse, error)
+		FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*response.CustomerResponse, *util.CursorPage, error)
+		CountAll(ctx context.Context, query *util.ListQuery) (int64, error)
 		FindByCode(ctx context.Context, code string) (*response.CustomerResponse, error)
 		Create(ctx context.Context, request *request.CreateCustomerRequest) (*response.CustomerResponse, error)
 		Update(ctx context.Context, request *request.UpdateCustomerRequest) (*response.CustomerResponse, error)
 		Delete(ctx context.Context, code string) error
+		Restore(ctx context.Context, code string) (*response.CustomerResponse, error)
+		Purge(ctx context.Context, code string) error
+	}
+	SearchServiceContract interface {
+		IndexProduct(ctx context.Context, code string) error
+		IndexProductQuality(ctx context.Context, id int64) error
+		IndexTransaction(ctx context.Context, code string) error
+		IndexSupplier(ctx context.Context, code string) error
+		IndexCustomer(ctx context.Context, code string) error
+		IndexUser(ctx context.Context, id int64) error
+		Remove(ctx context.Context, index string, id int64) error
+		Reindex(ctx context.Context, index string) (*response.ReindexResponse, error)
+		SearchProducts(ctx context.Context, request *request.ProductSearchRequest, offset int, limit int) (*response.SearchResponse, error)
+		SearchProductQualities(ctx context.Context, request *request.ProductQualitySearchRequest, offset int, limit int) (*response.SearchResponse, error)
+		SearchTransactions(ctx context.Context, request *request.TransactionSearchRequest, offset int, limit int) (*response.SearchResponse, error)
+		SearchSuppliers(ctx context.Context, request *request.SupplierSearchRequest, offset int, limit int) (*response.SearchResponse, error)
+		SearchCustomers(ctx context.Context, request *request.CustomerSearchRequest, offset int, limit int) (*response.SearchResponse, error)
+		Suggest(ctx context.Context, request *request.SuggestRequest) (*response.SuggestResponse, error)
+	}
+	SearchSynonymServiceContract interface {
+		FindAll(ctx context.Context) ([]*response.SearchSynonymResponse, error)
+		Create(ctx context.Context, request *request.CreateSearchSynonymRequest) (*response.SearchSynonymResponse, error)
+		Update(ctx context.Context, request *request.UpdateSearchSynonymRequest) (*response.SearchSynonymResponse, error)
+		Delete(ctx context.Context, id int64) error
+	}
+	AnalyticsServiceContract interface {
+		Volume(ctx context.Context, request *request.AnalyticsRequest) ([]*response.VolumeResponse, error)
+		TopCustomers(ctx context.Context, request *request.AnalyticsRequest) ([]*response.PartnerVolumeResponse, error)
+		TopSuppliers(ctx context.Context, request *request.AnalyticsRequest) ([]*response.PartnerVolumeResponse, error)
+		StockByType(ctx context.Context, request *request.AnalyticsRequest) ([]*response.StockByTypeResponse, error)
+		Transfers(ctx context.Context, request *request.AnalyticsRequest) ([]*response.TransferVolumeResponse, error)
+	}
+	IdempotencyServiceContract interface {
+		Begin(ctx context.Context, key string, fingerprint string) (*response.IdempotentResponse, error)
+		Complete(ctx context.Context, key string, fingerprint string, idempotentResponse *response.IdempotentResponse) error
+		Release(ctx context.Context, key string, fingerprint string) error
+		Run(ctx context.Context)
+	}
+	SearchOutboxServiceContract interface {
+		Run(ctx context.Context)
+		Relay(ctx context.Context) (int, error)
+		Stats(ctx context.Context) (*response.SearchOutboxStatsResponse, error)
+	}
+	LedgerServiceContract interface {
+		Verify(ctx context.Context) (*response.LedgerVerificationResponse, error)
+		Backfill(ctx context.Context) (int64, error)
+		CreateCheckpoint(ctx context.Context) (*response.LedgerCheckpointResponse, error)
+		ExportCheckpoints(ctx context.Context) (*response.LedgerCheckpointExportResponse, error)
+	}
+	ImportServiceContract interface {
+		ImportProducts(ctx context.Context, rows []*util.SheetRow, dryRun bool) (*response.ImportResponse, error)
+		ImportSuppliers(ctx context.Context, rows []*util.SheetRow, dryRun bool) (*response.ImportResponse, error)
+		ImportCustomers(ctx context.Context, rows []*util.SheetRow, dryRun bool) (*response.ImportResponse, error)
+		ImportOpeningStock(ctx context.Context, rows []*util.SheetRow, dryRun bool) (*response.ImportResponse, error)
+	}
+	ExportServiceContract interface {
+		Background(ctx context.Context, entity string, query *util.ListQuery) (bool, error)
+		Export(ctx context.Context, entity string, query *util.ListQuery, format string, w io.Writer) (int64, error)
+		CreateJob(ctx context.Context, entity string, format string, values url.Values) (*response.ExportJobResponse, error)
+		FindAllJobs(ctx context.Context, offset int, limit int) ([]*response.ExportJobResponse, error)
+		CountAllJobs(ctx context.Context) (int64, error)
+		FindJobByCode(ctx context.Context, code string) (*response.ExportJobResponse, error)
+		JobFile(ctx context.Context, code string) (*response.ExportJobResponse, string, error)
+		Run(ctx context.Context)
+		RunNext(ctx context.Context) (bool, error)
+		PurgeExpired(ctx context.Context) (int, error)
+	}
+	SettingServiceContract interface {
+		FindBranding(ctx context.Context) (*response.BrandingSettingResponse, error)
+		UpdateBranding(ctx context.Context, request *request.UpdateBrandingSettingRequest) (*response.BrandingSettingResponse, error)
+		FindAllLabelTemplates(ctx context.Context) ([]*response.LabelTemplateResponse, error)
+		UpdateLabelTemplate(ctx context.Context, request *request.UpdateLabelTemplateRequest) (*response.LabelTemplateResponse, error)
+		FindAllNumberingSequences(ctx context.Context) ([]*response.NumberingSequenceResponse, error)
+		UpdateNumberingSequence(ctx context.Context, request *request.UpdateNumberingSequenceRequest) (*response.NumberingSequenceResponse, error)
+	}
+	DocumentServiceContract interface {
+		DeliveryNote(ctx context.Context, code string) ([]byte, error)
+		GoodsReceipt(ctx context.Context, code string) ([]byte, error)
+		TransferSlip(ctx context.Context, code string) ([]byte, error)
+		StockReport(ctx context.Context) ([]byte, error)
+	}
+	LabelServiceContract interface {
+		ProductQuality(ctx context.Context, id int64, request *request.LabelRequest) ([]byte, error)
+		Transaction(ctx context.Context, code string, request *request.LabelRequest) ([]byte, error)
+	}
+	ScanServiceContract interface {
+		Scan(ctx context.Context, value string) (*response.ScanResponse, error)
 	}
 	TransactionServiceContract interface {
-		FindAll(ctx context.Context, offset int, limit int) ([]*response.TransactionResponse, error)
-		CountAll(ctx context.Context) (int64, error)
-		FindAllBySupplierCode(ctx context.Context, supplierCode string) ([]*response.TransactionResponse, error)
-		FindAllByCustomerCode(ctx context.Context, customerCode string) ([]*response.TransactionResponse, error)
+		FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*response.TransactionResponse, error)
+		FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*response.TransactionResponse, *util.CursorPage, error)
+		CountAll(ctx context.Context, query *util.ListQuery) (int64, error)
+		Summary(ctx context.Context, query *util.ListQuery) (*response.TransactionSummaryResponse, error)
 		FindByCode(ctx context.Context, code string) (*response.TransactionResponse, error)
 		Create(ctx context.Context, request *request.CreateTransactionRequest) (*response.TransactionResponse, error)
 		Update(ctx context.Context, request *request.UpdateTransactionRequest) (*response.TransactionResponse, error)
 		TransferStock(ctx context.Context, request *request.TransferStockTransactionRequest) (*response.TransactionResponse, error)
+		CreateBatch(ctx context.Context, request *request.CreateTransactionBatchRequest) (*response.TransactionBatchResponse, error)
 		Delete(ctx context.Context, code string) error
 	}
 )
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/internal/repository"
+	"inventory-management/backend/util"
+	"net/http"
+	"text/template"
+	"time"
+)
+
+// The entity ids settings are audited with, a tenant having one branding, one template per
+// type of label and one numbering sequence per entity and type of transaction.
+const (
+	settingCodeBranding          = "branding"
+	settingCodeLabelTemplate     = "label_template."
+	settingCodeNumberingSequence = "numbering_sequence."
+)
+
+type SettingService struct {
+	SettingRepository repository.SettingRepositoryContract
+	TenantRepository  repository.TenantRepositoryContract
+	Transactor        repository.TransactorContract
+	AuditService      AuditServiceContract
+}
+
+func NewSettingService(settingRepository repository.SettingRepositoryContract, tenantRepository repository.TenantRepositoryContract, transactor repository.TransactorContract, auditService AuditServiceContract) SettingServiceContract {
+	return &SettingService{
+		SettingRepository: settingRepository,
+		TenantRepository:  tenantRepository,
+		Transactor:        transactor,
+		AuditService:      auditService,
+	}
+}
+
+func (service *SettingService) FindBranding(ctx context.Context) (*response.BrandingSettingResponse, error) {
+	branding, err := findBranding(ctx, service.SettingRepository, service.TenantRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	return branding.ToResponse(), nil
+}
+
+func (service *SettingService) UpdateBranding(ctx context.Context, request *request.UpdateBrandingSettingRequest) (*response.BrandingSettingResponse, error) {
+	before, err := findBranding(ctx, service.SettingRepository, service.TenantRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	branding := &model.BrandingSetting{
+		CompanyName: request.CompanyName,
+		Address:     request.Address,
+		Phone:       request.Phone,
+		Email:       request.Email,
+		Footer:      request.Footer,
+	}
+	if request.Logo != nil && *request.Logo != "" {
+		branding.Logo, branding.LogoType, err = decodeLogo(*request.Logo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		branding, err = service.SettingRepository.SaveBranding(ctx, branding)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntitySetting, settingCodeBranding, model.AuditActionUpdate, before.ToResponse(), branding.ToResponse())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return branding.ToResponse(), nil
+}
+
+// findBranding returns the branding of the tenant. Until one is saved, documents are issued in
+// the name of the tenant.
+func findBranding(ctx context.Context, settingRepository repository.SettingRepositoryContract, tenantRepository repository.TenantRepositoryContract) (*model.BrandingSetting, error) {
+	branding, err := settingRepository.FindBranding(ctx)
+	if err == nil {
+		return branding, nil
+	}
+	if err.Error() != response.ErrorNotFound {
+		return nil, err
+	}
+
+	tenantID, ok := util.TenantFromContext(ctx)
+	if !ok {
+		tenantID = util.DefaultTenantID
+	}
+	tenant, err := tenantRepository.FindByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.BrandingSetting{TenantID: tenant.ID, CompanyName: tenant.Name}, nil
+}
+
+// decodeLogo decodes a base64 logo and tells its image type from its content, the declared
+// one is not trusted.
+func decodeLogo(encoded string) ([]byte, string, error) {
+	logo, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, "", errors.New(response.ErrorLogoImageType)
+	}
+
+	switch http.DetectContentType(logo) {
+	case "image/png":
+		return logo, "PNG", nil
+	case "image/jpeg":
+		return logo, "JPG", nil
+	default:
+		return nil, "", errors.New(response.ErrorLogoImageType)
+	}
+}
+
+// FindAllLabelTemplates returns the template of every type of label, the default one for the
+// types the tenant has not saved one for.
+func (service *SettingService) FindAllLabelTemplates(ctx context.Context) ([]*response.LabelTemplateResponse, error) {
+	labelTemplates, err := service.SettingRepository.FindAllLabelTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	saved := map[string]*model.LabelTemplate{}
+	for _, labelTemplate := range labelTemplates {
+		saved[labelTemplate.LabelType] = labelTemplate
+	}
+
+	var labelTemplateResponses []*response.LabelTemplateResponse
+	for _, labelType := range model.LabelTypes {
+		labelTemplate, ok := saved[labelType]
+		if !ok {
+			labelTemplate = model.DefaultLabelTemplate(labelType)
+		}
+		labelTemplateResponses = append(labelTemplateResponses, labelTemplate.ToResponse())
+	}
+
+	return labelTemplateResponses, nil
+}
+
+func (service *SettingService) UpdateLabelTemplate(ctx context.Context, request *request.UpdateLabelTemplateRequest) (*response.LabelTemplateResponse, error) {
+	before, err := findLabelTemplate(ctx, service.SettingRepository, request.LabelType)
+	if err != nil {
+		return nil, err
+	}
+
+	// A template that cannot print a label is refused now rather than at the printer
+	_, err = executeLabelTemplate(request.Zpl, &model.LabelData{Title: "Title", Subtitle: "Subtitle", Text: "Text", Value: "VALUE", Barcode: "^FDVALUE", Copies: 1})
+	if err != nil {
+		return nil, err
+	}
+
+	var labelTemplate *model.LabelTemplate
+	err = service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		labelTemplate, err = service.SettingRepository.SaveLabelTemplate(ctx, &model.LabelTemplate{
+			LabelType: request.LabelType,
+			Symbology: request.Symbology,
+			Zpl:       request.Zpl,
+		})
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntitySetting, settingCodeLabelTemplate+request.LabelType, model.AuditActionUpdate, before.ToResponse(), labelTemplate.ToResponse())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return labelTemplate.ToResponse(), nil
+}
+
+// findLabelTemplate returns the template of a type of label, the default one until the tenant
+// saves its own. Unknown types are not found.
+func findLabelTemplate(ctx context.Context, settingRepository repository.SettingRepositoryContract, labelType string) (*model.LabelTemplate, error) {
+	known := false
+	for _, knownType := range model.LabelTypes {
+		known = known || knownType == labelType
+	}
+	if !known {
+		return nil, errors.New(response.ErrorNotFound)
+	}
+
+	labelTemplate, err := settingRepository.FindLabelTemplate(ctx, labelType)
+	if err == nil {
+		return labelTemplate, nil
+	}
+	if err.Error() != response.ErrorNotFound {
+		return nil, err
+	}
+
+	return model.DefaultLabelTemplate(labelType), nil
+}
+
+func executeLabelTemplate(zpl string, data *model.LabelData) ([]byte, error) {
+	labelTemplate, err := template.New("label").Option("missingkey=error").Parse(zpl)
+	if err != nil {
+		return nil, errors.New(response.ErrorLabelTemplateInvalid)
+	}
+
+	var label bytes.Buffer
+	if err = labelTemplate.Execute(&label, data); err != nil {
+		return nil, errors.New(response.ErrorLabelTemplateInvalid)
+	}
+
+	return label.Bytes(), nil
+}
+
+// FindAllNumberingSequences returns the sequences the tenant numbers entities with, and the
+// default random codes of the entities it numbers no transaction type of.
+func (service *SettingService) FindAllNumberingSequences(ctx context.Context) ([]*response.NumberingSequenceResponse, error) {
+	sequences, err := service.SettingRepository.FindAllNumberingSequences(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	saved := map[string][]*model.NumberingSequence{}
+	for _, sequence := range sequences {
+		saved[sequence.Entity] = append(saved[sequence.Entity], sequence)
+	}
+
+	var sequenceResponses []*response.NumberingSequenceResponse
+	for _, entity := range []string{model.NumberingEntityProduct, model.NumberingEntitySupplier, model.NumberingEntityCustomer, model.NumberingEntityTransaction} {
+		if len(saved[entity]) == 0 || saved[entity][0].TransactionType != "" {
+			sequenceResponses = append(sequenceResponses, model.DefaultNumberingSequence(entity, "").ToResponse())
+		}
+		for _, sequence := range saved[entity] {
+			sequenceResponses = append(sequenceResponses, sequence.ToResponse())
+		}
+	}
+
+	return sequenceResponses, nil
+}
+
+func (service *SettingService) UpdateNumberingSequence(ctx context.Context, request *request.UpdateNumberingSequenceRequest) (*response.NumberingSequenceResponse, error) {
+	if _, ok := model.NumberingEntities[request.Entity]; !ok {
+		return nil, errors.New(response.ErrorNotFound)
+	}
+	if request.TransactionType != "" && request.Entity != model.NumberingEntityTransaction {
+		return nil, errors.New(response.ErrorNumberingSequenceInvalid)
+	}
+
+	reset := request.Reset
+	if reset == "" {
+		reset = util.NumberingResetNever
+	}
+	if request.Strategy == util.NumberingStrategySequence && !util.ValidNumberingPattern(request.Pattern, reset, request.TransactionType != "") {
+		return nil, errors.New(response.ErrorNumberingSequenceInvalid)
+	}
+
+	before, err := service.SettingRepository.FindNumberingSequence(ctx, request.Entity, request.TransactionType)
+	if err != nil {
+		if err.Error() != response.ErrorNotFound {
+			return nil, err
+		}
+		before = model.DefaultNumberingSequence(request.Entity, request.TransactionType)
+	}
+
+	sequence := &model.NumberingSequence{
+		Entity:          request.Entity,
+		TransactionType: request.TransactionType,
+		Strategy:        request.Strategy,
+		Pattern:         request.Pattern,
+		Padding:         request.Padding,
+		Reset:           reset,
+		NextValue:       before.NextValue,
+		Period:          before.Period,
+	}
+
+	// The count restarts in the current period when asked to, or when it is reset differently,
+	// rather than at once from 1 with the codes of the period it was counted in
+	restart := request.NextValue != nil || before.ID == 0 || before.Reset != reset
+	if restart {
+		if request.NextValue != nil {
+			sequence.NextValue = *request.NextValue
+		}
+		sequence.Period = util.NumberingPeriod(reset, time.Now())
+	}
+
+	// A sequence restarted below a number in use, or given back a pattern used before, would
+	// only give codes that are taken
+	if sequence.Strategy == util.NumberingStrategySequence {
+		taken, err := service.SettingRepository.CodeTaken(ctx, sequence.Entity, sequence.Number(sequence.TransactionType, time.Now()))
+		if err != nil {
+			return nil, err
+		}
+		if taken {
+			return nil, errors.New(response.ErrorNumberingSequenceTaken)
+		}
+	}
+
+	entityID := settingCodeNumberingSequence + request.Entity
+	if request.TransactionType != "" {
+		entityID += "." + request.TransactionType
+	}
+	err = service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		sequence, err = service.SettingRepository.SaveNumberingSequence(ctx, sequence, restart)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntitySetting, entityID, model.AuditActionUpdate, before.ToResponse(), sequence.ToResponse())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sequence.ToResponse(), nil
+}
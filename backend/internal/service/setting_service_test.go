@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/request"
+	response "inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	repository "inventory-management/backend/internal/repository/mock"
+	service "inventory-management/backend/internal/service/mock"
+	"inventory-management/backend/util"
+	"testing"
+	"time"
+)
+
+func TestSettingService_UpdateBranding(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	pngLogo := base64.StdEncoding.EncodeToString(png)
+	textLogo := base64.StdEncoding.EncodeToString([]byte("not an image"))
+	invalidLogo := "%%%"
+
+	testCases := []struct {
+		name             string
+		request          *request.UpdateBrandingSettingRequest
+		expectedSaved    *model.BrandingSetting
+		expectedSvcError error
+	}{
+		{
+			name:          "Branding without a logo",
+			request:       &request.UpdateBrandingSettingRequest{CompanyName: "Widdy Farm", Footer: "Thank you"},
+			expectedSaved: &model.BrandingSetting{CompanyName: "Widdy Farm", Footer: "Thank you"},
+		},
+		{
+			name:          "Branding with a PNG logo",
+			request:       &request.UpdateBrandingSettingRequest{CompanyName: "Widdy Farm", Logo: &pngLogo},
+			expectedSaved: &model.BrandingSetting{CompanyName: "Widdy Farm", Logo: png, LogoType: "PNG"},
+		},
+		{
+			name:             "[invalid] Logo that is not an image",
+			request:          &request.UpdateBrandingSettingRequest{CompanyName: "Widdy Farm", Logo: &textLogo},
+			expectedSvcError: errors.New(response.ErrorLogoImageType),
+		},
+		{
+			name:             "[invalid] Logo that is not base64",
+			request:          &request.UpdateBrandingSettingRequest{CompanyName: "Widdy Farm", Logo: &invalidLogo},
+			expectedSvcError: errors.New(response.ErrorLogoImageType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var settingRepo repository.SettingRepositoryMock
+			settingRepo.On("FindBranding", ctx).Return(&model.BrandingSetting{CompanyName: "Widdy"}, nil)
+			settingRepo.On("SaveBranding", ctx, tc.expectedSaved).Return(tc.expectedSaved, nil)
+
+			var auditSvc service.AuditServiceMock
+			auditSvc.On("Record", ctx, model.AuditEntitySetting, "branding", model.AuditActionUpdate, mock.Anything, mock.Anything).Return(nil)
+
+			svc := NewSettingService(&settingRepo, nil, &repository.TransactorMock{}, &auditSvc)
+			branding, err := svc.UpdateBranding(ctx, tc.request)
+
+			assert.Equal(t, tc.expectedSvcError, err)
+			if tc.expectedSvcError == nil {
+				assert.Equal(t, tc.expectedSaved.ToResponse(), branding)
+				auditSvc.AssertNumberOfCalls(t, "Record", 1)
+			} else {
+				settingRepo.AssertNotCalled(t, "SaveBranding", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestSettingService_UpdateLabelTemplate(t *testing.T) {
+	testCases := []struct {
+		name             string
+		request          *request.UpdateLabelTemplateRequest
+		expectedSaved    *model.LabelTemplate
+		expectedSvcError error
+	}{
+		{
+			name:          "Template of a label type",
+			request:       &request.UpdateLabelTemplateRequest{LabelType: model.LabelTypeTransaction, Symbology: "qr", Zpl: "^XA^FO10,10{{.Barcode}}^FS^XZ"},
+			expectedSaved: &model.LabelTemplate{LabelType: model.LabelTypeTransaction, Symbology: "qr", Zpl: "^XA^FO10,10{{.Barcode}}^FS^XZ"},
+		},
+		{
+			name:             "[invalid] Template that does not parse",
+			request:          &request.UpdateLabelTemplateRequest{LabelType: model.LabelTypeTransaction, Symbology: "qr", Zpl: "^XA{{.Barcode}^XZ"},
+			expectedSvcError: errors.New(response.ErrorLabelTemplateInvalid),
+		},
+		{
+			name:             "[invalid] Template of a field label data lacks",
+			request:          &request.UpdateLabelTemplateRequest{LabelType: model.LabelTypeTransaction, Symbology: "qr", Zpl: "^XA{{.Weight}}^XZ"},
+			expectedSvcError: errors.New(response.ErrorLabelTemplateInvalid),
+		},
+		{
+			name:             "[invalid] Unknown label type",
+			request:          &request.UpdateLabelTemplateRequest{LabelType: "lot", Symbology: "qr", Zpl: "^XA^XZ"},
+			expectedSvcError: errors.New(response.ErrorNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var settingRepo repository.SettingRepositoryMock
+			settingRepo.On("FindLabelTemplate", ctx, model.LabelTypeTransaction).Return(nil, errors.New(response.ErrorNotFound))
+			settingRepo.On("SaveLabelTemplate", ctx, tc.expectedSaved).Return(tc.expectedSaved, nil)
+
+			var auditSvc service.AuditServiceMock
+			auditSvc.On("Record", ctx, model.AuditEntitySetting, "label_template.transaction", model.AuditActionUpdate, mock.Anything, mock.Anything).Return(nil)
+
+			svc := NewSettingService(&settingRepo, nil, &repository.TransactorMock{}, &auditSvc)
+			labelTemplate, err := svc.UpdateLabelTemplate(ctx, tc.request)
+
+			assert.Equal(t, tc.expectedSvcError, err)
+			if tc.expectedSvcError == nil {
+				assert.Equal(t, tc.expectedSaved.ToResponse(), labelTemplate)
+			} else {
+				settingRepo.AssertNotCalled(t, "SaveLabelTemplate", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestSettingService_UpdateNumberingSequence(t *testing.T) {
+	nextValue := int64(42)
+	year := util.NumberingPeriod(util.NumberingResetYearly, time.Now())
+	supplierSequence := &model.NumberingSequence{ID: 1, Entity: "supplier", Strategy: "sequence", Pattern: "SUP-{SEQ}", Padding: 4, Reset: "never", NextValue: 7}
+
+	testCases := []struct {
+		name             string
+		request          *request.UpdateNumberingSequenceRequest
+		before           *model.NumberingSequence
+		expectedSaved    *model.NumberingSequence
+		taken            bool
+		expectedRestart  bool
+		expectedSvcError error
+	}{
+		{
+			name:            "Yearly sequence of a transaction type",
+			request:         &request.UpdateNumberingSequenceRequest{Entity: "transaction", TransactionType: "IN", Strategy: "sequence", Pattern: "{TYPE}-{YYYY}-{SEQ}", Padding: 6, Reset: "yearly"},
+			expectedSaved:   &model.NumberingSequence{Entity: "transaction", TransactionType: "IN", Strategy: "sequence", Pattern: "{TYPE}-{YYYY}-{SEQ}", Padding: 6, Reset: "yearly", NextValue: 1, Period: year},
+			expectedRestart: true,
+		},
+		{
+			name:          "Pattern of a sequence goes on counting",
+			request:       &request.UpdateNumberingSequenceRequest{Entity: "supplier", Strategy: "sequence", Pattern: "S-{SEQ}", Padding: 5},
+			before:        supplierSequence,
+			expectedSaved: &model.NumberingSequence{Entity: "supplier", Strategy: "sequence", Pattern: "S-{SEQ}", Padding: 5, Reset: "never", NextValue: 7},
+		},
+		{
+			name:            "Sequence restarted from a number",
+			request:         &request.UpdateNumberingSequenceRequest{Entity: "supplier", Strategy: "sequence", Pattern: "SUP-{SEQ}", Padding: 4, NextValue: &nextValue},
+			before:          supplierSequence,
+			expectedSaved:   &model.NumberingSequence{Entity: "supplier", Strategy: "sequence", Pattern: "SUP-{SEQ}", Padding: 4, Reset: "never", NextValue: 42},
+			expectedRestart: true,
+		},
+		{
+			name:            "Random codes",
+			request:         &request.UpdateNumberingSequenceRequest{Entity: "customer", Strategy: "random"},
+			expectedSaved:   &model.NumberingSequence{Entity: "customer", Strategy: "random", Reset: "never", NextValue: 1},
+			expectedRestart: true,
+		},
+		{
+			name:             "[invalid] Yearly sequence without the year",
+			request:          &request.UpdateNumberingSequenceRequest{Entity: "transaction", Strategy: "sequence", Pattern: "TRX-{SEQ}", Reset: "yearly"},
+			expectedSvcError: errors.New(response.ErrorNumberingSequenceInvalid),
+		},
+		{
+			name:             "[invalid] Sequence of a transaction type without the type",
+			request:          &request.UpdateNumberingSequenceRequest{Entity: "transaction", TransactionType: "IN", Strategy: "sequence", Pattern: "TRX-{SEQ}"},
+			expectedSvcError: errors.New(response.ErrorNumberingSequenceInvalid),
+		},
+		{
+			name:             "[invalid] Sequence restarted below a code in use",
+			request:          &request.UpdateNumberingSequenceRequest{Entity: "supplier", Strategy: "sequence", Pattern: "SUP-{SEQ}", Padding: 4, NextValue: &nextValue},
+			before:           supplierSequence,
+			taken:            true,
+			expectedSvcError: errors.New(response.ErrorNumberingSequenceTaken),
+		},
+		{
+			name:             "[invalid] Transaction type of a supplier",
+			request:          &request.UpdateNumberingSequenceRequest{Entity: "supplier", TransactionType: "IN", Strategy: "random"},
+			expectedSvcError: errors.New(response.ErrorNumberingSequenceInvalid),
+		},
+		{
+			name:             "[invalid] Unknown entity",
+			request:          &request.UpdateNumberingSequenceRequest{Entity: "user", Strategy: "random"},
+			expectedSvcError: errors.New(response.ErrorNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var settingRepo repository.SettingRepositoryMock
+			if tc.before != nil {
+				settingRepo.On("FindNumberingSequence", ctx, tc.request.Entity, tc.request.TransactionType).Return(tc.before, nil)
+			} else {
+				settingRepo.On("FindNumberingSequence", ctx, tc.request.Entity, tc.request.TransactionType).Return(nil, errors.New(response.ErrorNotFound))
+			}
+			settingRepo.On("CodeTaken", ctx, tc.request.Entity, mock.Anything).Return(tc.taken, nil)
+			settingRepo.On("SaveNumberingSequence", ctx, tc.expectedSaved, tc.expectedRestart).Return(tc.expectedSaved, nil)
+
+			var auditSvc service.AuditServiceMock
+			auditSvc.On("Record", ctx, model.AuditEntitySetting, mock.Anything, model.AuditActionUpdate, mock.Anything, mock.Anything).Return(nil)
+
+			svc := NewSettingService(&settingRepo, nil, &repository.TransactorMock{}, &auditSvc)
+			sequence, err := svc.UpdateNumberingSequence(ctx, tc.request)
+
+			assert.Equal(t, tc.expectedSvcError, err)
+			if tc.expectedSvcError == nil {
+				assert.Equal(t, tc.expectedSaved.ToResponse(), sequence)
+			} else {
+				settingRepo.AssertNotCalled(t, "SaveNumberingSequence", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
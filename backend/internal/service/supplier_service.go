@@ -6,34 +6,58 @@ import (
 	"inventory-management/backend/internal/http/response"
 	"inventory-management/backend/internal/model"
 	"inventory-management/backend/internal/repository"
+	"inventory-management/backend/util"
 )
 
 type SupplierService struct {
 	SupplierRepository repository.SupplierRepositoryContract
+	Transactor         repository.TransactorContract
+	AuditService       AuditServiceContract
 }
 
-func NewSupplierService(supplierRepository repository.SupplierRepositoryContract) SupplierServiceContract {
+func NewSupplierService(supplierRepository repository.SupplierRepositoryContract, transactor repository.TransactorContract, auditService AuditServiceContract) SupplierServiceContract {
 	return &SupplierService{
 		SupplierRepository: supplierRepository,
+		Transactor:         transactor,
+		AuditService:       auditService,
 	}
 }
 
-func (service *SupplierService) FindAll(ctx context.Context, offset int, limit int) ([]*response.SupplierResponse, error) {
-	suppliers, err := service.SupplierRepository.FindAll(ctx, offset, limit)
+func (service *SupplierService) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*response.SupplierResponse, error) {
+	suppliers, err := service.SupplierRepository.FindAll(ctx, query, offset, limit)
 	if err != nil {
 		return nil, err
 	}
 
+	return toSupplierResponses(suppliers, query), nil
+}
+
+func (service *SupplierService) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*response.SupplierResponse, *util.CursorPage, error) {
+	suppliers, page, err := service.SupplierRepository.FindAllByCursor(ctx, query, cursor, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return toSupplierResponses(suppliers, query), page, nil
+}
+
+func toSupplierResponses(suppliers []*model.Supplier, query *util.ListQuery) []*response.SupplierResponse {
 	var supplierResponses []*response.SupplierResponse
 	for _, supplier := range suppliers {
+		// Included associations are the only ones loaded
+		if query != nil && len(query.Include) > 0 {
+			supplierResponses = append(supplierResponses, supplier.ToResponseWithAssociations())
+			continue
+		}
+
 		supplierResponses = append(supplierResponses, supplier.ToResponse())
 	}
 
-	return supplierResponses, nil
+	return supplierResponses
 }
 
-func (service *SupplierService) CountAll(ctx context.Context) (int64, error) {
-	count, err := service.SupplierRepository.CountAll(ctx)
+func (service *SupplierService) CountAll(ctx context.Context, query *util.ListQuery) (int64, error) {
+	count, err := service.SupplierRepository.CountAll(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -56,7 +80,16 @@ func (service *SupplierService) Create(ctx context.Context, request *request.Cre
 	supplierRequest.Address = request.Address
 	supplierRequest.Phone = request.Phone
 
-	supplier, err := service.SupplierRepository.Create(ctx, &supplierRequest)
+	var supplier *model.Supplier
+	err := service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		supplier, err = service.SupplierRepository.Create(ctx, &supplierRequest)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntitySupplier, supplier.Code, model.AuditActionCreate, nil, supplier.ToResponse())
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -70,11 +103,27 @@ func (service *SupplierService) Update(ctx context.Context, request *request.Upd
 		return nil, err
 	}
 
+	err = checkVersion(ctx, checkSupplier.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	before := checkSupplier.ToResponse()
+
 	checkSupplier.Name = request.Name
 	checkSupplier.Address = request.Address
 	checkSupplier.Phone = request.Phone
 
-	supplier, err := service.SupplierRepository.Update(ctx, checkSupplier)
+	var supplier *model.Supplier
+	err = service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		supplier, err = service.SupplierRepository.Update(ctx, checkSupplier)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntitySupplier, supplier.Code, model.AuditActionUpdate, before, supplier.ToResponse())
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -88,10 +137,52 @@ func (service *SupplierService) Delete(ctx context.Context, code string) error {
 		return err
 	}
 
-	err = service.SupplierRepository.Delete(ctx, checkSupplier.Code)
+	err = checkVersion(ctx, checkSupplier.Version)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		err := service.SupplierRepository.Delete(ctx, checkSupplier.Code)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntitySupplier, checkSupplier.Code, model.AuditActionDelete, checkSupplier.ToResponse(), nil)
+	})
+}
+
+func (service *SupplierService) Restore(ctx context.Context, code string) (*response.SupplierResponse, error) {
+	var supplier *model.Supplier
+	err := service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		supplier, err = service.SupplierRepository.Restore(ctx, code)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntitySupplier, supplier.Code, model.AuditActionRestore, nil, supplier.ToResponse())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return supplier.ToResponse(), nil
+}
+
+// Purge deletes the supplier for good, which is refused while transactions reference it.
+func (service *SupplierService) Purge(ctx context.Context, code string) error {
+	checkSupplier, err := service.SupplierRepository.FindByCode(util.WithDeleted(ctx), code)
+	if err != nil {
+		return err
+	}
+
+	return service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		err := service.SupplierRepository.Purge(ctx, checkSupplier.Code)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntitySupplier, checkSupplier.Code, model.AuditActionPurge, checkSupplier.ToResponse(), nil)
+	})
 }
@@ -9,6 +9,8 @@ import (
 	response "inventory-management/backend/internal/http/response"
 	"inventory-management/backend/internal/model"
 	repository "inventory-management/backend/internal/repository/mock"
+	service "inventory-management/backend/internal/service/mock"
+	"inventory-management/backend/util"
 	"testing"
 )
 
@@ -75,9 +77,10 @@ func TestSupplierService_FindAll(t *testing.T) {
 			ctx := context.Background()
 
 			var repo repository.SupplierRepositoryMock
-			repo.On("FindAll", ctx, 0, 10).Return(tc.expectedSupplierRepoFindAll, tc.expectedSupplierRepoFindAllError)
-			svc := NewSupplierService(&repo)
-			result, err := svc.FindAll(ctx, 0, 10)
+			repo.On("FindAll", ctx, (*util.ListQuery)(nil), 0, 10).Return(tc.expectedSupplierRepoFindAll, tc.expectedSupplierRepoFindAllError)
+			var audit service.AuditServiceMock
+			svc := NewSupplierService(&repo, &repository.TransactorMock{}, &audit)
+			result, err := svc.FindAll(ctx, nil, 0, 10)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
@@ -136,7 +139,8 @@ func TestSupplierService_FindByCode(t *testing.T) {
 
 			var repo repository.SupplierRepositoryMock
 			repo.On("FindByCodeWithAssociations", ctx, tc.request).Return(tc.expectedSupplierRepoFindByCode, tc.expectedSupplierRepoFindByCodeError)
-			svc := NewSupplierService(&repo)
+			var audit service.AuditServiceMock
+			svc := NewSupplierService(&repo, &repository.TransactorMock{}, &audit)
 			result, err := svc.FindByCode(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -155,6 +159,7 @@ func TestSupplierService_Create(t *testing.T) {
 		request                         *request.CreateSupplierRequest
 		expectedSupplierRepoCreate      *model.Supplier
 		expectedSupplierRepoCreateError error
+		expectedAuditRecordError        error
 		expectedSvc                     *response.SupplierResponse
 		expectedSvcError                error
 	}{
@@ -184,6 +189,22 @@ func TestSupplierService_Create(t *testing.T) {
 			expectedSupplierRepoCreateError: nil,
 			expectedSvcError:                nil,
 		},
+		{
+			name: "Supplier is not created without its audit entry",
+			request: &request.CreateSupplierRequest{
+				Name:    "Widdy Arfiansyah",
+				Address: "Sukabumi",
+				Phone:   "082291832488",
+			},
+			expectedSupplierRepoCreate: &model.Supplier{
+				ID:   1,
+				Code: "WDWDARFSYH",
+				Name: "Widdy Arfiansyah",
+			},
+			expectedAuditRecordError: errors.New("connection reset"),
+			expectedSvc:              nil,
+			expectedSvcError:         errors.New("connection reset"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -192,11 +213,16 @@ func TestSupplierService_Create(t *testing.T) {
 
 			var repo repository.SupplierRepositoryMock
 			repo.On("Create", ctx, mock.Anything).Return(tc.expectedSupplierRepoCreate, tc.expectedSupplierRepoCreateError)
-			svc := NewSupplierService(&repo)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.expectedAuditRecordError)
+			var transactor repository.TransactorMock
+			svc := NewSupplierService(&repo, &transactor, &audit)
 			result, err := svc.Create(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+				// The audit entry is recorded in the transaction creating the supplier
+				assert.Equal(t, []error{tc.expectedSvcError}, transactor.RolledBack)
 			}
 
 			assert.Equal(t, tc.expectedSvc, result)
@@ -277,7 +303,9 @@ func TestSupplierService_Update(t *testing.T) {
 			var repo repository.SupplierRepositoryMock
 			repo.On("FindByCode", ctx, tc.requestSupplierRepoFindByCode).Return(tc.expectedSupplierRepoFindByCode, tc.expectedSupplierRepoFindByCodeError)
 			repo.On("Update", ctx, mock.Anything).Return(tc.expectedSupplierRepoUpdate, tc.expectedSupplierRepoUpdateError)
-			svc := NewSupplierService(&repo)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			svc := NewSupplierService(&repo, &repository.TransactorMock{}, &audit)
 			result, err := svc.Update(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -334,7 +362,9 @@ func TestSupplierService_Delete(t *testing.T) {
 			var repo repository.SupplierRepositoryMock
 			repo.On("FindByCode", ctx, tc.request).Return(tc.expectedSupplierRepoFindByCode, tc.expectedSupplierRepoFindByCodeError)
 			repo.On("Delete", ctx, tc.request).Return(tc.expectedSupplierRepoDeleteError)
-			svc := NewSupplierService(&repo)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			svc := NewSupplierService(&repo, &repository.TransactorMock{}, &audit)
 			err := svc.Delete(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -343,3 +373,125 @@ func TestSupplierService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestSupplierService_Restore(t *testing.T) {
+	testCases := []struct {
+		name                           string
+		request                        string
+		expectedSupplierRepoRestore    *model.Supplier
+		expectedSupplierRepoRestoreErr error
+		expectedSvc                    *response.SupplierResponse
+		expectedSvcError               error
+	}{
+		{
+			name:    "Deleted supplier is restored",
+			request: "WDWDARFSYH",
+			expectedSupplierRepoRestore: &model.Supplier{
+				ID:      1,
+				Code:    "WDWDARFSYH",
+				Name:    "Widdy Arfiansyah",
+				Address: "Sukabumi",
+				Phone:   "082291832488",
+				Version: 1,
+			},
+			expectedSvc: &response.SupplierResponse{
+				ID:        1,
+				Code:      "WDWDARFSYH",
+				Name:      "Widdy Arfiansyah",
+				Address:   "Sukabumi",
+				Phone:     "082291832488",
+				Version:   1,
+				CreatedAt: "0001-01-01 07:00:00 +0700 +07",
+				UpdatedAt: "0001-01-01 07:00:00 +0700 +07",
+			},
+		},
+		{
+			name:                           "No deleted supplier with given Code",
+			request:                        "WDWDARFSYH",
+			expectedSupplierRepoRestoreErr: errors.New(response.ErrorNotFound),
+			expectedSvcError:               errors.New(response.ErrorNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var repo repository.SupplierRepositoryMock
+			repo.On("Restore", ctx, tc.request).Return(tc.expectedSupplierRepoRestore, tc.expectedSupplierRepoRestoreErr)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, model.AuditEntitySupplier, tc.request, model.AuditActionRestore, nil, mock.Anything).Return(nil)
+			svc := NewSupplierService(&repo, &repository.TransactorMock{}, &audit)
+			supplier, err := svc.Restore(ctx, tc.request)
+			assert.Equal(t, tc.expectedSvc, supplier)
+			if tc.expectedSvcError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				audit.AssertCalled(t, "Record", ctx, model.AuditEntitySupplier, tc.request, model.AuditActionRestore, nil, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestSupplierService_Purge(t *testing.T) {
+	testCases := []struct {
+		name                                string
+		request                             string
+		expectedSupplierRepoFindByCode      *model.Supplier
+		expectedSupplierRepoFindByCodeError error
+		expectedSupplierRepoPurgeError      error
+		expectedSvcError                    error
+	}{
+		{
+			name:    "Deleted supplier without transactions is purged",
+			request: "WDWDARFSYH",
+			expectedSupplierRepoFindByCode: &model.Supplier{
+				ID:   1,
+				Code: "WDWDARFSYH",
+				Name: "Widdy Arfiansyah",
+			},
+		},
+		{
+			name:    "Supplier referenced by transactions is kept",
+			request: "WDWDARFSYH",
+			expectedSupplierRepoFindByCode: &model.Supplier{
+				ID:   1,
+				Code: "WDWDARFSYH",
+				Name: "Widdy Arfiansyah",
+			},
+			expectedSupplierRepoPurgeError: errors.New(response.ErrorRecordReferenced),
+			expectedSvcError:               errors.New(response.ErrorRecordReferenced),
+		},
+		{
+			name:                                "Supplier doesnt exists with given Code",
+			request:                             "WDWDARFSYH",
+			expectedSupplierRepoFindByCodeError: errors.New(response.ErrorNotFound),
+			expectedSvcError:                    errors.New(response.ErrorNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var repo repository.SupplierRepositoryMock
+			// The supplier to purge may be soft deleted already
+			repo.On("FindByCode", util.WithDeleted(ctx), tc.request).Return(tc.expectedSupplierRepoFindByCode, tc.expectedSupplierRepoFindByCodeError)
+			repo.On("Purge", ctx, tc.request).Return(tc.expectedSupplierRepoPurgeError)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, model.AuditEntitySupplier, tc.request, model.AuditActionPurge, mock.Anything, nil).Return(nil)
+			svc := NewSupplierService(&repo, &repository.TransactorMock{}, &audit)
+			err := svc.Purge(ctx, tc.request)
+			if tc.expectedSvcError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+				audit.AssertNotCalled(t, "Record", ctx, model.AuditEntitySupplier, tc.request, model.AuditActionPurge, mock.Anything, nil)
+			} else {
+				assert.NoError(t, err)
+				audit.AssertCalled(t, "Record", ctx, model.AuditEntitySupplier, tc.request, model.AuditActionPurge, mock.Anything, nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"inventory-management/backend/internal/http/request"
+	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	"inventory-management/backend/internal/repository"
+	"inventory-management/backend/util"
+	"strconv"
+)
+
+type TenantService struct {
+	TenantRepository repository.TenantRepositoryContract
+	UserRepository   repository.UserRepositoryContract
+	UserService      UserServiceContract
+	Transactor       repository.TransactorContract
+	AuditService     AuditServiceContract
+}
+
+func NewTenantService(tenantRepository repository.TenantRepositoryContract, userRepository repository.UserRepositoryContract, userService UserServiceContract, transactor repository.TransactorContract, auditService AuditServiceContract) TenantServiceContract {
+	return &TenantService{
+		TenantRepository: tenantRepository,
+		UserRepository:   userRepository,
+		UserService:      userService,
+		Transactor:       transactor,
+		AuditService:     auditService,
+	}
+}
+
+func (service *TenantService) FindAll(ctx context.Context, offset int, limit int) ([]*response.TenantResponse, error) {
+	tenants, err := service.TenantRepository.FindAll(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var tenantResponses []*response.TenantResponse
+	for _, tenant := range tenants {
+		tenantResponses = append(tenantResponses, tenant.ToResponse())
+	}
+
+	return tenantResponses, nil
+}
+
+func (service *TenantService) CountAll(ctx context.Context) (int64, error) {
+	count, err := service.TenantRepository.CountAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (service *TenantService) FindByID(ctx context.Context, id int64) (*response.TenantResponse, error) {
+	tenant, err := service.TenantRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return tenant.ToResponse(), nil
+}
+
+// Create provisions a tenant with its own API key and first administrator. The API key is only
+// returned here, the tenant stores its digest.
+func (service *TenantService) Create(ctx context.Context, tenantRequest *request.CreateTenantRequest) (*response.TenantResponse, error) {
+	_, err := service.TenantRepository.FindByCode(ctx, tenantRequest.Code)
+	if err == nil {
+		return nil, errors.New(response.ErrorTenantCodeExists)
+	}
+
+	_, err = service.UserRepository.FindByUsername(util.WithDeleted(util.WithoutTenant(ctx)), tenantRequest.AdminUsername)
+	if err == nil {
+		return nil, errors.New(response.ErrorUsernameExists)
+	}
+
+	apiKey, err := util.GenerateRandomString(48)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeyHash := util.HashToken(apiKey)
+
+	var newTenant model.Tenant
+	newTenant.Code = tenantRequest.Code
+	newTenant.Name = tenantRequest.Name
+	newTenant.ApiKeyHash = &apiKeyHash
+
+	// The tenant is not created without its administrator
+	var tenant *model.Tenant
+	var admin *response.UserResponse
+	err = service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		tenant, err = service.TenantRepository.Create(ctx, &newTenant)
+		if err != nil {
+			return err
+		}
+
+		err = service.AuditService.Record(ctx, model.AuditEntityTenant, strconv.FormatInt(tenant.ID, 10), model.AuditActionCreate, nil, tenant.ToResponse())
+		if err != nil {
+			return err
+		}
+
+		admin, err = service.UserService.Create(util.WithTenant(ctx, tenant.ID), &request.CreateUserRequest{
+			Name:     tenantRequest.AdminName,
+			Username: tenantRequest.AdminUsername,
+			Password: tenantRequest.AdminPassword,
+			Email:    tenantRequest.AdminEmail,
+			Role:     model.RoleAdmin,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tenantResponse := tenant.ToResponse()
+	tenantResponse.ApiKey = apiKey
+	tenantResponse.Admin = admin
+
+	return tenantResponse, nil
+}
+
+func (service *TenantService) Update(ctx context.Context, tenantRequest *request.UpdateTenantRequest) (*response.TenantResponse, error) {
+	checkTenant, err := service.TenantRepository.FindByID(ctx, tenantRequest.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := checkTenant.ToResponse()
+
+	checkTenant.Name = tenantRequest.Name
+	var tenant *model.Tenant
+	err = service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		tenant, err = service.TenantRepository.Update(ctx, checkTenant)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityTenant, strconv.FormatInt(tenant.ID, 10), model.AuditActionUpdate, before, tenant.ToResponse())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tenant.ToResponse(), nil
+}
+
+// RotateApiKey replaces the API key of the tenant, the previous key stops working immediately.
+func (service *TenantService) RotateApiKey(ctx context.Context, id int64) (*response.TenantResponse, error) {
+	tenant, err := service.TenantRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := util.GenerateRandomString(48)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeyHash := util.HashToken(apiKey)
+	tenant.ApiKeyHash = &apiKeyHash
+	tenant, err = service.TenantRepository.Update(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantResponse := tenant.ToResponse()
+	tenantResponse.ApiKey = apiKey
+
+	return tenantResponse, nil
+}
+
+func (service *TenantService) ResolveApiKey(ctx context.Context, apiKey string) (*response.TenantResponse, error) {
+	tenant, err := service.TenantRepository.FindByApiKeyHash(ctx, util.HashToken(apiKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return tenant.ToResponse(), nil
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"inventory-management/backend/internal/http/request"
+	response "inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
+	repository "inventory-management/backend/internal/repository/mock"
+	service "inventory-management/backend/internal/service/mock"
+	"inventory-management/backend/util"
+	"testing"
+)
+
+func TestTenantService_Create(t *testing.T) {
+	tenantRequest := &request.CreateTenantRequest{
+		Code:          "widdy",
+		Name:          "Widdy Company",
+		AdminName:     "Widdy Arfiansyah",
+		AdminUsername: "wdyarfn",
+		AdminPassword: "12345678910",
+	}
+
+	testCases := []struct {
+		name                    string
+		expectedTenantByCode    *model.Tenant
+		expectedUserByUsername  *model.User
+		expectedSvcError        error
+		expectedTenantProvision bool
+	}{
+		{
+			name:                    "Create tenant with its first administrator",
+			expectedTenantProvision: true,
+		},
+		{
+			name:                 "Create tenant with an existing code",
+			expectedTenantByCode: &model.Tenant{ID: 2, Code: "widdy"},
+			expectedSvcError:     errors.New(response.ErrorTenantCodeExists),
+		},
+		{
+			name:                   "Create tenant with an existing administrator username",
+			expectedUserByUsername: &model.User{ID: 1, Username: "wdyarfn"},
+			expectedSvcError:       errors.New(response.ErrorUsernameExists),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var tenantRepo repository.TenantRepositoryMock
+			var userRepo repository.UserRepositoryMock
+			var userSvc service.UserServiceMock
+			var audit service.AuditServiceMock
+
+			tenantByCodeError := errors.New(response.ErrorNotFound)
+			if tc.expectedTenantByCode != nil {
+				tenantByCodeError = nil
+			}
+			userByUsernameError := errors.New(response.ErrorNotFound)
+			if tc.expectedUserByUsername != nil {
+				userByUsernameError = nil
+			}
+
+			var storedTenant *model.Tenant
+			tenantRepo.On("FindByCode", ctx, "widdy").Return(tc.expectedTenantByCode, tenantByCodeError)
+			userRepo.On("FindByUsername", util.WithDeleted(util.WithoutTenant(ctx)), "wdyarfn").Return(tc.expectedUserByUsername, userByUsernameError)
+			tenantRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+				storedTenant = args.Get(1).(*model.Tenant)
+				storedTenant.ID = 2
+			}).Return(&model.Tenant{ID: 2, Code: "widdy", Name: "Widdy Company"}, nil)
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			userSvc.On("Create", util.WithTenant(ctx, 2), &request.CreateUserRequest{
+				Name:     "Widdy Arfiansyah",
+				Username: "wdyarfn",
+				Password: "12345678910",
+				Role:     model.RoleAdmin,
+			}).Return(&response.UserResponse{ID: 1, Username: "wdyarfn", Role: model.RoleAdmin}, nil)
+
+			svc := NewTenantService(&tenantRepo, &userRepo, &userSvc, &repository.TransactorMock{}, &audit)
+			result, err := svc.Create(ctx, tenantRequest)
+			if tc.expectedSvcError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+				tenantRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, util.HashToken(result.ApiKey), *storedTenant.ApiKeyHash)
+			assert.Equal(t, model.RoleAdmin, result.Admin.Role)
+			userSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTenantService_RotateApiKey(t *testing.T) {
+	ctx := context.Background()
+	previousHash := util.HashToken("previous")
+
+	var tenantRepo repository.TenantRepositoryMock
+	var userRepo repository.UserRepositoryMock
+	var userSvc service.UserServiceMock
+	var audit service.AuditServiceMock
+	tenant := &model.Tenant{ID: 2, Code: "widdy", ApiKeyHash: &previousHash}
+	tenantRepo.On("FindByID", ctx, int64(2)).Return(tenant, nil)
+	tenantRepo.On("Update", ctx, tenant).Return(tenant, nil)
+
+	svc := NewTenantService(&tenantRepo, &userRepo, &userSvc, &repository.TransactorMock{}, &audit)
+	result, err := svc.RotateApiKey(ctx, 2)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, result.ApiKey)
+
+	assert.Equal(t, util.HashToken(result.ApiKey), *tenant.ApiKeyHash)
+	assert.NotEqual(t, previousHash, *tenant.ApiKeyHash)
+}
+
+func TestTenantService_ResolveApiKey(t *testing.T) {
+	ctx := context.Background()
+
+	var tenantRepo repository.TenantRepositoryMock
+	var userRepo repository.UserRepositoryMock
+	var userSvc service.UserServiceMock
+	var audit service.AuditServiceMock
+	tenantRepo.On("FindByApiKeyHash", ctx, util.HashToken("key")).Return(&model.Tenant{ID: 2, Code: "widdy"}, nil)
+	tenantRepo.On("FindByApiKeyHash", ctx, mock.Anything).Return(nil, errors.New(response.ErrorNotFound))
+
+	svc := NewTenantService(&tenantRepo, &userRepo, &userSvc, &repository.TransactorMock{}, &audit)
+	tenant, err := svc.ResolveApiKey(ctx, "key")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), tenant.ID)
+
+	_, err = svc.ResolveApiKey(ctx, "unknown")
+	assert.Equal(t, response.ErrorNotFound, err.Error())
+}
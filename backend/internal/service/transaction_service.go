@@ -2,41 +2,70 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"inventory-management/backend/internal/http/request"
 	"inventory-management/backend/internal/http/response"
+	"inventory-management/backend/internal/model"
 	"inventory-management/backend/internal/repository"
+	"inventory-management/backend/util"
 )
 
+// transactionSummaryUnitMassAcronym is the base unit of mass the summaries are totalled in.
+const transactionSummaryUnitMassAcronym = "kg"
+
 type TransactionService struct {
 	TransactionRepository    repository.TransactionRepositoryContract
 	ProductQualityRepository repository.ProductQualityRepositoryContract
 	TxTransactionRepository  repository.TxTransactionRepositoryContract
+	Transactor               repository.TransactorContract
+	AuditService             AuditServiceContract
 }
 
-func NewTransactionService(transactionRepository repository.TransactionRepositoryContract, productQualityRepository repository.ProductQualityRepositoryContract, txTransactionRepository repository.TxTransactionRepositoryContract) TransactionServiceContract {
+func NewTransactionService(transactionRepository repository.TransactionRepositoryContract, productQualityRepository repository.ProductQualityRepositoryContract, txTransactionRepository repository.TxTransactionRepositoryContract, transactor repository.TransactorContract, auditService AuditServiceContract) TransactionServiceContract {
 	return &TransactionService{
 		TransactionRepository:    transactionRepository,
 		ProductQualityRepository: productQualityRepository,
 		TxTransactionRepository:  txTransactionRepository,
+		Transactor:               transactor,
+		AuditService:             auditService,
 	}
 }
 
-func (service *TransactionService) FindAll(ctx context.Context, offset int, limit int) ([]*response.TransactionResponse, error) {
-	transactions, err := service.TransactionRepository.FindAll(ctx, offset, limit, nil)
+func (service *TransactionService) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*response.TransactionResponse, error) {
+	transactions, err := service.TransactionRepository.FindAll(ctx, query, offset, limit, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	return toTransactionResponses(transactions, query), nil
+}
+
+func (service *TransactionService) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*response.TransactionResponse, *util.CursorPage, error) {
+	transactions, page, err := service.TransactionRepository.FindAllByCursor(ctx, query, cursor, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return toTransactionResponses(transactions, query), page, nil
+}
+
+func toTransactionResponses(transactions []*model.Transaction, query *util.ListQuery) []*response.TransactionResponse {
 	var transactionResponses []*response.TransactionResponse
 	for _, transaction := range transactions {
+		// Included associations are the only ones loaded
+		if query != nil && len(query.Include) > 0 {
+			transactionResponses = append(transactionResponses, transaction.ToResponseWithAssociations())
+			continue
+		}
+
 		transactionResponses = append(transactionResponses, transaction.ToResponse())
 	}
 
-	return transactionResponses, nil
+	return transactionResponses
 }
 
-func (service *TransactionService) CountAll(ctx context.Context) (int64, error) {
-	count, err := service.TransactionRepository.CountAll(ctx, nil)
+func (service *TransactionService) CountAll(ctx context.Context, query *util.ListQuery) (int64, error) {
+	count, err := service.TransactionRepository.CountAll(ctx, query, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -44,32 +73,33 @@ func (service *TransactionService) CountAll(ctx context.Context) (int64, error)
 	return count, nil
 }
 
-func (service *TransactionService) FindAllBySupplierCode(ctx context.Context, supplierCode string) ([]*response.TransactionResponse, error) {
-	transactions, err := service.TransactionRepository.FindAllBySupplierCode(ctx, supplierCode, nil)
+// Summary totals the IN and OUT quantities of the transactions of the list query in kilograms,
+// whatever unit each transaction was recorded in.
+func (service *TransactionService) Summary(ctx context.Context, query *util.ListQuery) (*response.TransactionSummaryResponse, error) {
+	totals, err := service.TransactionRepository.TotalByType(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	var transactionResponses []*response.TransactionResponse
-	for _, transaction := range transactions {
-		transactionResponses = append(transactionResponses, transaction.ToResponse())
-	}
+	summary := &response.TransactionSummaryResponse{UnitMassAcronym: transactionSummaryUnitMassAcronym}
+	for _, total := range totals {
+		if total.Type != "IN" && total.Type != "OUT" {
+			continue
+		}
 
-	return transactionResponses, nil
-}
+		quantity, err := util.CalculateUnitOfMass(summary.UnitMassAcronym, total.UnitMassAcronym, total.Quantity)
+		if err != nil {
+			return nil, err
+		}
 
-func (service *TransactionService) FindAllByCustomerCode(ctx context.Context, customerCode string) ([]*response.TransactionResponse, error) {
-	transactions, err := service.TransactionRepository.FindAllByCustomerCode(ctx, customerCode, nil)
-	if err != nil {
-		return nil, err
+		if total.Type == "IN" {
+			summary.In += quantity
+		} else {
+			summary.Out += quantity
+		}
 	}
 
-	var transactionResponses []*response.TransactionResponse
-	for _, transaction := range transactions {
-		transactionResponses = append(transactionResponses, transaction.ToResponse())
-	}
-
-	return transactionResponses, nil
+	return summary, nil
 }
 
 func (service *TransactionService) FindByCode(ctx context.Context, code string) (*response.TransactionResponse, error) {
@@ -82,7 +112,16 @@ func (service *TransactionService) FindByCode(ctx context.Context, code string)
 }
 
 func (service *TransactionService) Create(ctx context.Context, request *request.CreateTransactionRequest) (*response.TransactionResponse, error) {
-	transaction, err := service.TxTransactionRepository.Create(ctx, request)
+	var transaction *model.Transaction
+	err := service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		transaction, err = service.TxTransactionRepository.Create(ctx, request)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityTransaction, transaction.Code, model.AuditActionCreate, nil, transaction.ToResponse())
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +130,27 @@ func (service *TransactionService) Create(ctx context.Context, request *request.
 }
 
 func (service *TransactionService) Update(ctx context.Context, request *request.UpdateTransactionRequest) (*response.TransactionResponse, error) {
-	transaction, err := service.TxTransactionRepository.Update(ctx, request)
+	checkTransaction, err := service.TransactionRepository.FindByCode(ctx, request.Code, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkVersion(ctx, checkTransaction.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Version = checkTransaction.Version
+	var transaction *model.Transaction
+	err = service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		transaction, err = service.TxTransactionRepository.Update(ctx, request)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityTransaction, transaction.Code, model.AuditActionUpdate, checkTransaction.ToResponse(), transaction.ToResponse())
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +159,16 @@ func (service *TransactionService) Update(ctx context.Context, request *request.
 }
 
 func (service *TransactionService) TransferStock(ctx context.Context, request *request.TransferStockTransactionRequest) (*response.TransactionResponse, error) {
-	transaction, err := service.TxTransactionRepository.TransferStock(ctx, request)
+	var transaction *model.Transaction
+	err := service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		transaction, err = service.TxTransactionRepository.TransferStock(ctx, request)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityTransaction, transaction.Code, model.AuditActionCreate, nil, transaction.ToResponse())
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -108,11 +176,150 @@ func (service *TransactionService) TransferStock(ctx context.Context, request *r
 	return transaction.ToResponse(), nil
 }
 
+// CreateBatch posts the lines of a stock movement at once. Every line is validated first, with
+// the stock the lines before it leave, and the lines are only posted when all of them are valid.
+func (service *TransactionService) CreateBatch(ctx context.Context, request *request.CreateTransactionBatchRequest) (*response.TransactionBatchResponse, error) {
+	result := &response.TransactionBatchResponse{}
+
+	productQualities := map[int64]*model.ProductQuality{}
+	stock := map[int64]float64{}
+	for i, line := range request.Lines {
+		if line == nil {
+			result.Errors = append(result.Errors, &response.TransactionBatchLineErrorResponse{Line: i + 1, Errors: batchLineErrors("Lines", "required")})
+			continue
+		}
+
+		errValidate := util.ValidateStruct(line)
+		if len(errValidate) == 0 {
+			var err error
+			errValidate, err = service.validateBatchLine(ctx, line, productQualities, stock)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if len(errValidate) > 0 {
+			result.Errors = append(result.Errors, &response.TransactionBatchLineErrorResponse{Line: i + 1, Errors: errValidate})
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	var transactions []*model.Transaction
+	err := service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		transactions, err = service.TxTransactionRepository.CreateBatch(ctx, request)
+		if err != nil {
+			return err
+		}
+
+		for _, transaction := range transactions {
+			err = service.AuditService.Record(ctx, model.AuditEntityTransaction, transaction.Code, model.AuditActionCreate, nil, transaction.ToResponse())
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, transaction := range transactions {
+		result.Transactions = append(result.Transactions, transaction.ToResponse())
+	}
+
+	return result, nil
+}
+
+// validateBatchLine checks a line against the product qualities it moves stock of, and applies it
+// to the stock the next lines are checked with.
+func (service *TransactionService) validateBatchLine(ctx context.Context, line *request.TransactionBatchLineRequest, productQualities map[int64]*model.ProductQuality, stock map[int64]float64) ([]*response.ErrorResponse, error) {
+	fromQuality, err := service.batchProductQuality(ctx, line.ProductQualityID, productQualities, stock)
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return batchLineErrors("ProductQualityID", "exists"), nil
+		}
+		return nil, err
+	}
+
+	quantity, err := util.CalculateUnitOfMass(fromQuality.Product.UnitMassAcronym, line.UnitMassAcronym, line.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	switch line.Type {
+	case "IN":
+		stock[fromQuality.ID] += quantity
+	case "OUT":
+		stock[fromQuality.ID] -= quantity
+	case "TRANSFER":
+		toQuality, err := service.batchProductQuality(ctx, line.ProductQualityIDTransferred, productQualities, stock)
+		if err != nil {
+			if err.Error() == response.ErrorNotFound {
+				return batchLineErrors("ProductQualityIDTransferred", "exists"), nil
+			}
+			return nil, err
+		}
+
+		if toQuality.ProductCode != fromQuality.ProductCode {
+			return batchLineErrors("ProductQualityIDTransferred", "same_product"), nil
+		}
+		if stock[fromQuality.ID] < quantity {
+			return batchLineErrors("Quantity", "stock"), nil
+		}
+
+		stock[fromQuality.ID] -= quantity
+		stock[toQuality.ID] += quantity
+	}
+
+	return nil, nil
+}
+
+// batchProductQuality finds a product quality of a batch once, starting its stock from the one
+// it has now.
+func (service *TransactionService) batchProductQuality(ctx context.Context, id int64, productQualities map[int64]*model.ProductQuality, stock map[int64]float64) (*model.ProductQuality, error) {
+	if productQuality, ok := productQualities[id]; ok {
+		return productQuality, nil
+	}
+
+	productQuality, err := service.ProductQualityRepository.FindByIDWithAssociations(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	productQualities[id] = productQuality
+	stock[id] = productQuality.Quantity
+	return productQuality, nil
+}
+
+func batchLineErrors(failedField string, tag string) []*response.ErrorResponse {
+	return []*response.ErrorResponse{{
+		FailedField: failedField,
+		Tag:         tag,
+		Value:       fmt.Sprintf("Error validation '%s' for '%s' field", tag, failedField),
+	}}
+}
+
 func (service *TransactionService) Delete(ctx context.Context, code string) error {
-	err := service.TxTransactionRepository.Delete(ctx, code)
+	checkTransaction, err := service.TransactionRepository.FindByCode(ctx, code, nil)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	err = checkVersion(ctx, checkTransaction.Version)
+	if err != nil {
+		return err
+	}
+
+	return service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		err := service.TxTransactionRepository.Delete(ctx, code)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityTransaction, checkTransaction.Code, model.AuditActionDelete, checkTransaction.ToResponse(), nil)
+	})
 }
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"inventory-management/backend/internal/http/request"
 	"inventory-management/backend/internal/http/response"
 	"inventory-management/backend/internal/model"
 	repository "inventory-management/backend/internal/repository/mock"
+	service "inventory-management/backend/internal/service/mock"
 	"inventory-management/backend/util"
 	"testing"
 )
@@ -15,6 +17,7 @@ import (
 func TestTransactionService_FindAll(t *testing.T) {
 	testCases := []struct {
 		name                                string
+		listQuery                           *util.ListQuery
 		expectedTransactionRepoFindAll      []*model.Transaction
 		expectedTransactionRepoFindAllError error
 		expectedSvc                         []*response.TransactionResponse
@@ -80,62 +83,20 @@ func TestTransactionService_FindAll(t *testing.T) {
 			expectedTransactionRepoFindAllError: nil,
 			expectedSvcError:                    nil,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			ctx := context.Background()
-
-			var repoT repository.TransactionRepositoryMock
-			var repoPQ repository.ProductQualityRepositoryMock
-			var repoTx repository.TxTransactionRepositoryMock
-			repoT.On("FindAll", ctx, 0, 10).Return(tc.expectedTransactionRepoFindAll, tc.expectedTransactionRepoFindAllError)
-			svc := NewTransactionService(&repoT, &repoPQ, &repoTx)
-			result, err := svc.FindAll(ctx, 0, 10)
-			if tc.expectedSvcError != nil {
-				assert.Error(t, err)
-				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
-			}
-
-			assert.Equal(t, tc.expectedSvc, result)
-			assert.Equal(t, len(tc.expectedSvc), len(result))
-		})
-	}
-}
-
-func TestTransactionService_FindAllBySupplierCode(t *testing.T) {
-	testCases := []struct {
-		name                                              string
-		request                                           string
-		expectedTransactionRepoFindAllBySupplierCode      []*model.Transaction
-		expectedTransactionRepoFindAllBySupplierCodeError error
-		expectedSvc                                       []*response.TransactionResponse
-		expectedSvcError                                  error
-	}{
 		{
-			name:    "Number of transactions more than 1",
-			request: "SUP001",
-			expectedTransactionRepoFindAllBySupplierCode: []*model.Transaction{
+			name:      "Only the included associations",
+			listQuery: &util.ListQuery{Include: []string{"supplier"}},
+			expectedTransactionRepoFindAll: []*model.Transaction{
 				{
 					ID:               1,
 					Code:             "WDWDARFSYH",
 					ProductQualityID: 1,
 					SupplierCode:     util.ToPointerString("SUP001"),
-					Description:      util.ToPointerString("Pembelian barang dari supplier"),
+					Supplier:         &model.Supplier{ID: 1, Code: "SUP001", Name: "Widdy Arfiansyah"},
 					Quantity:         23,
 					Type:             "IN",
 					UnitMassAcronym:  "kg",
 				},
-				{
-					ID:               2,
-					Code:             "ARFNSYH",
-					ProductQualityID: 1,
-					SupplierCode:     util.ToPointerString("SUP001"),
-					Description:      util.ToPointerString("Dapet barang dari supplier"),
-					Quantity:         3,
-					Type:             "OUT",
-					UnitMassAcronym:  "kg",
-				},
 			},
 			expectedSvc: []*response.TransactionResponse{
 				{
@@ -143,36 +104,20 @@ func TestTransactionService_FindAllBySupplierCode(t *testing.T) {
 					Code:             "WDWDARFSYH",
 					ProductQualityID: 1,
 					SupplierCode:     util.ToPointerString("SUP001"),
-					Description:      util.ToPointerString("Pembelian barang dari supplier"),
-					Quantity:         23,
-					Type:             "IN",
-					UnitMassAcronym:  "kg",
-					CreatedAt:        "0001-01-01 07:00:00 +0700 +07",
-					UpdatedAt:        "0001-01-01 07:00:00 +0700 +07",
-				},
-				{
-					ID:               2,
-					Code:             "ARFNSYH",
-					ProductQualityID: 1,
-					SupplierCode:     util.ToPointerString("SUP001"),
-					Description:      util.ToPointerString("Dapet barang dari supplier"),
-					Quantity:         3,
-					Type:             "OUT",
-					UnitMassAcronym:  "kg",
-					CreatedAt:        "0001-01-01 07:00:00 +0700 +07",
-					UpdatedAt:        "0001-01-01 07:00:00 +0700 +07",
+					Supplier: &response.SupplierResponse{
+						ID:        1,
+						Code:      "SUP001",
+						Name:      "Widdy Arfiansyah",
+						CreatedAt: "0001-01-01 07:00:00 +0700 +07",
+						UpdatedAt: "0001-01-01 07:00:00 +0700 +07",
+					},
+					Quantity:        23,
+					Type:            "IN",
+					UnitMassAcronym: "kg",
+					CreatedAt:       "0001-01-01 07:00:00 +0700 +07",
+					UpdatedAt:       "0001-01-01 07:00:00 +0700 +07",
 				},
 			},
-			expectedTransactionRepoFindAllBySupplierCodeError: nil,
-			expectedSvcError: nil,
-		},
-		{
-			name:    "Number of transactions is 0 or null",
-			request: "SUP001",
-			expectedTransactionRepoFindAllBySupplierCode: nil,
-			expectedSvc: nil,
-			expectedTransactionRepoFindAllBySupplierCodeError: nil,
-			expectedSvcError: nil,
 		},
 	}
 
@@ -183,9 +128,10 @@ func TestTransactionService_FindAllBySupplierCode(t *testing.T) {
 			var repoT repository.TransactionRepositoryMock
 			var repoPQ repository.ProductQualityRepositoryMock
 			var repoTx repository.TxTransactionRepositoryMock
-			repoT.On("FindAllBySupplierCode", ctx, tc.request).Return(tc.expectedTransactionRepoFindAllBySupplierCode, tc.expectedTransactionRepoFindAllBySupplierCodeError)
-			svc := NewTransactionService(&repoT, &repoPQ, &repoTx)
-			result, err := svc.FindAllBySupplierCode(ctx, tc.request)
+			repoT.On("FindAll", ctx, tc.listQuery, 0, 10).Return(tc.expectedTransactionRepoFindAll, tc.expectedTransactionRepoFindAllError)
+			var audit service.AuditServiceMock
+			svc := NewTransactionService(&repoT, &repoPQ, &repoTx, &repository.TransactorMock{}, &audit)
+			result, err := svc.FindAll(ctx, tc.listQuery, 0, 10)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
@@ -197,96 +143,56 @@ func TestTransactionService_FindAllBySupplierCode(t *testing.T) {
 	}
 }
 
-func TestTransactionService_FindAllByCustomerCode(t *testing.T) {
+func TestTransactionService_Summary(t *testing.T) {
 	testCases := []struct {
-		name                                              string
-		request                                           string
-		expectedTransactionRepoFindAllByCustomerCode      []*model.Transaction
-		expectedTransactionRepoFindAllByCustomerCodeError error
-		expectedSvc                                       []*response.TransactionResponse
-		expectedSvcError                                  error
+		name            string
+		totals          []*model.TransactionTotal
+		repoError       error
+		expectedSummary *response.TransactionSummaryResponse
+		expectedError   error
 	}{
 		{
-			name:    "Number of transactions more than 1",
-			request: "CUS001",
-			expectedTransactionRepoFindAllByCustomerCode: []*model.Transaction{
-				{
-					ID:               1,
-					Code:             "WDWDARFSYH",
-					ProductQualityID: 1,
-					CustomerCode:     util.ToPointerString("CUS001"),
-					Description:      util.ToPointerString("Pembelian barang dari customer"),
-					Quantity:         23,
-					Type:             "IN",
-					UnitMassAcronym:  "kg",
-				},
-				{
-					ID:               2,
-					Code:             "ARFNSYH",
-					ProductQualityID: 1,
-					CustomerCode:     util.ToPointerString("CUS001"),
-					Description:      util.ToPointerString("Dapet barang dari customer"),
-					Quantity:         3,
-					Type:             "OUT",
-					UnitMassAcronym:  "kg",
-				},
-			},
-			expectedSvc: []*response.TransactionResponse{
-				{
-					ID:               1,
-					Code:             "WDWDARFSYH",
-					ProductQualityID: 1,
-					CustomerCode:     util.ToPointerString("CUS001"),
-					Description:      util.ToPointerString("Pembelian barang dari customer"),
-					Quantity:         23,
-					Type:             "IN",
-					UnitMassAcronym:  "kg",
-					CreatedAt:        "0001-01-01 07:00:00 +0700 +07",
-					UpdatedAt:        "0001-01-01 07:00:00 +0700 +07",
-				},
-				{
-					ID:               2,
-					Code:             "ARFNSYH",
-					ProductQualityID: 1,
-					CustomerCode:     util.ToPointerString("CUS001"),
-					Description:      util.ToPointerString("Dapet barang dari customer"),
-					Quantity:         3,
-					Type:             "OUT",
-					UnitMassAcronym:  "kg",
-					CreatedAt:        "0001-01-01 07:00:00 +0700 +07",
-					UpdatedAt:        "0001-01-01 07:00:00 +0700 +07",
-				},
-			},
-			expectedTransactionRepoFindAllByCustomerCodeError: nil,
-			expectedSvcError: nil,
+			name: "Totals of several units in kilograms",
+			totals: []*model.TransactionTotal{
+				{Type: "IN", UnitMassAcronym: "kg", Quantity: 23},
+				{Type: "IN", UnitMassAcronym: "g", Quantity: 500},
+				{Type: "OUT", UnitMassAcronym: "ton", Quantity: 0.003},
+				{Type: "TRANSFER", UnitMassAcronym: "kg", Quantity: 4},
+			},
+			expectedSummary: &response.TransactionSummaryResponse{In: 23.5, Out: 3, UnitMassAcronym: "kg"},
 		},
 		{
-			name:    "Number of transactions is 0 or null",
-			request: "CUS001",
-			expectedTransactionRepoFindAllByCustomerCode: nil,
-			expectedSvc: nil,
-			expectedTransactionRepoFindAllByCustomerCodeError: nil,
-			expectedSvcError: nil,
+			name:            "No transactions",
+			expectedSummary: &response.TransactionSummaryResponse{UnitMassAcronym: "kg"},
+		},
+		{
+			name:          "Repository getting an error",
+			repoError:     errors.New("getting an error"),
+			expectedError: errors.New("getting an error"),
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
+			query := &util.ListQuery{Filters: []*util.ListFilter{{Field: "supplier_code", Operator: util.ListOperatorEq, Values: []interface{}{"SUP001"}}}}
 
 			var repoT repository.TransactionRepositoryMock
 			var repoPQ repository.ProductQualityRepositoryMock
 			var repoTx repository.TxTransactionRepositoryMock
-			repoT.On("FindAllByCustomerCode", ctx, tc.request).Return(tc.expectedTransactionRepoFindAllByCustomerCode, tc.expectedTransactionRepoFindAllByCustomerCodeError)
-			svc := NewTransactionService(&repoT, &repoPQ, &repoTx)
-			result, err := svc.FindAllByCustomerCode(ctx, tc.request)
-			if tc.expectedSvcError != nil {
-				assert.Error(t, err)
-				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+			repoT.On("TotalByType", ctx, query).Return(tc.totals, tc.repoError)
+			var audit service.AuditServiceMock
+			svc := NewTransactionService(&repoT, &repoPQ, &repoTx, &repository.TransactorMock{}, &audit)
+			result, err := svc.Summary(ctx, query)
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
 			}
 
-			assert.Equal(t, tc.expectedSvc, result)
-			assert.Equal(t, len(tc.expectedSvc), len(result))
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedSummary.UnitMassAcronym, result.UnitMassAcronym)
+			assert.InDelta(t, tc.expectedSummary.In, result.In, 1e-9)
+			assert.InDelta(t, tc.expectedSummary.Out, result.Out, 1e-9)
 		})
 	}
 }
@@ -558,7 +464,8 @@ func TestTransactionService_FindByCode(t *testing.T) {
 			var repoPQ repository.ProductQualityRepositoryMock
 			var repoTx repository.TxTransactionRepositoryMock
 			repoT.On("FindByCodeWithAssociations", ctx, tc.request).Return(tc.expectedTransactionRepoFindByCode, tc.expectedTransactionRepoFindByCodeError)
-			svc := NewTransactionService(&repoT, &repoPQ, &repoTx)
+			var audit service.AuditServiceMock
+			svc := NewTransactionService(&repoT, &repoPQ, &repoTx, &repository.TransactorMock{}, &audit)
 			result, err := svc.FindByCode(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -689,7 +596,9 @@ func TestTransactionService_Create(t *testing.T) {
 			var repoPQ repository.ProductQualityRepositoryMock
 			var repoTx repository.TxTransactionRepositoryMock
 			repoTx.On("Create", ctx, tc.request).Return(tc.expectedTransactionRepoCreate, tc.expectedTransactionRepoCreateError)
-			svc := NewTransactionService(&repoT, &repoPQ, &repoTx)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			svc := NewTransactionService(&repoT, &repoPQ, &repoTx, &repository.TransactorMock{}, &audit)
 			result, err := svc.Create(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -800,8 +709,11 @@ func TestTransactionService_Update(t *testing.T) {
 			var repoT repository.TransactionRepositoryMock
 			var repoPQ repository.ProductQualityRepositoryMock
 			var repoTx repository.TxTransactionRepositoryMock
+			repoT.On("FindByCode", ctx, tc.request.Code, mock.Anything).Return(&model.Transaction{ID: 1, Code: tc.request.Code, Version: 2}, nil)
 			repoTx.On("Update", ctx, tc.request).Return(tc.expectedTransactionRepoUpdate, tc.expectedTransactionRepoUpdateError)
-			svc := NewTransactionService(&repoT, &repoPQ, &repoTx)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			svc := NewTransactionService(&repoT, &repoPQ, &repoTx, &repository.TransactorMock{}, &audit)
 			result, err := svc.Update(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -809,6 +721,8 @@ func TestTransactionService_Update(t *testing.T) {
 			}
 
 			assert.Equal(t, tc.expectedSvc, result)
+			// The transaction is updated at the version it was read at
+			assert.Equal(t, int64(2), tc.request.Version)
 		})
 	}
 }
@@ -887,7 +801,9 @@ func TestTransactionService_TransferStock(t *testing.T) {
 			var repoPQ repository.ProductQualityRepositoryMock
 			var repoTx repository.TxTransactionRepositoryMock
 			repoTx.On("TransferStock", ctx, tc.request).Return(tc.expectedTransactionRepoTransferStock, tc.expectedTransactionRepoTransferStockError)
-			svc := NewTransactionService(&repoT, &repoPQ, &repoTx)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			svc := NewTransactionService(&repoT, &repoPQ, &repoTx, &repository.TransactorMock{}, &audit)
 			result, err := svc.TransferStock(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -899,6 +815,110 @@ func TestTransactionService_TransferStock(t *testing.T) {
 	}
 }
 
+func TestTransactionService_CreateBatch(t *testing.T) {
+	product := &model.Product{Code: "PRD001", UnitMassAcronym: "kg"}
+	productQualities := map[int64]*model.ProductQuality{
+		1: {ID: 1, ProductCode: "PRD001", Product: product, Quantity: 10},
+		2: {ID: 2, ProductCode: "PRD001", Product: product},
+		3: {ID: 3, ProductCode: "PRD002", Product: &model.Product{Code: "PRD002", UnitMassAcronym: "kg"}},
+	}
+
+	testCases := []struct {
+		name           string
+		lines          []*request.TransactionBatchLineRequest
+		expectedErrors []*response.TransactionBatchLineErrorResponse
+	}{
+		{
+			name: "Lines moving the stock left by the lines before them",
+			lines: []*request.TransactionBatchLineRequest{
+				{Type: "OUT", ProductQualityID: 1, Quantity: 4000, UnitMassAcronym: "g"},
+				{Type: "TRANSFER", ProductQualityID: 1, ProductQualityIDTransferred: 2, Quantity: 6, UnitMassAcronym: "kg"},
+				{Type: "IN", ProductQualityID: 3, Quantity: 1, UnitMassAcronym: "ton"},
+			},
+		},
+		{
+			name: "[invalid] Transfer of more than the lines before it left",
+			lines: []*request.TransactionBatchLineRequest{
+				{Type: "OUT", ProductQualityID: 1, Quantity: 5, UnitMassAcronym: "kg"},
+				{Type: "TRANSFER", ProductQualityID: 1, ProductQualityIDTransferred: 2, Quantity: 6, UnitMassAcronym: "kg"},
+			},
+			expectedErrors: []*response.TransactionBatchLineErrorResponse{
+				{Line: 2, Errors: batchLineErrors("Quantity", "stock")},
+			},
+		},
+		{
+			name: "[invalid] Every invalid line is reported",
+			lines: []*request.TransactionBatchLineRequest{
+				{Type: "TRANSFER", ProductQualityID: 1, ProductQualityIDTransferred: 3, Quantity: 1, UnitMassAcronym: "kg"},
+				{Type: "IN", ProductQualityID: 9, Quantity: 1, UnitMassAcronym: "kg"},
+				{Type: "IN", ProductQualityID: 1, Quantity: 1, UnitMassAcronym: "kg"},
+				{Type: "MOVE", ProductQualityID: 1, Quantity: 1, UnitMassAcronym: "kg"},
+			},
+			expectedErrors: []*response.TransactionBatchLineErrorResponse{
+				{Line: 1, Errors: batchLineErrors("ProductQualityIDTransferred", "same_product")},
+				{Line: 2, Errors: batchLineErrors("ProductQualityID", "exists")},
+				{Line: 4, Errors: []*response.ErrorResponse{{FailedField: "Type", Tag: "oneof", Value: "Error validation 'oneof' for 'Type' field"}}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			batchRequest := &request.CreateTransactionBatchRequest{Reference: util.ToPointerString("DN-0042"), Lines: tc.lines}
+			transactions := []*model.Transaction{{Code: "IN-0001", Type: "IN", Reference: util.ToPointerString("DN-0042")}}
+
+			var repoT repository.TransactionRepositoryMock
+			var repoPQ repository.ProductQualityRepositoryMock
+			for id, productQuality := range productQualities {
+				repoPQ.On("FindByIDWithAssociations", ctx, id).Return(productQuality, nil)
+			}
+			repoPQ.On("FindByIDWithAssociations", ctx, int64(9)).Return(nil, errors.New(response.ErrorNotFound))
+			var repoTx repository.TxTransactionRepositoryMock
+			repoTx.On("CreateBatch", ctx, batchRequest).Return(transactions, nil)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, model.AuditEntityTransaction, "IN-0001", model.AuditActionCreate, mock.Anything, mock.Anything).Return(nil)
+
+			svc := NewTransactionService(&repoT, &repoPQ, &repoTx, &repository.TransactorMock{}, &audit)
+			result, err := svc.CreateBatch(ctx, batchRequest)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.expectedErrors, result.Errors)
+			if tc.expectedErrors == nil {
+				assert.Equal(t, []*response.TransactionResponse{transactions[0].ToResponse()}, result.Transactions)
+			} else {
+				repoTx.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+				assert.Nil(t, result.Transactions)
+			}
+		})
+	}
+}
+
+func TestTransactionService_CreateBatch_AuditFailure(t *testing.T) {
+	ctx := context.Background()
+	product := &model.Product{Code: "PRD001", UnitMassAcronym: "kg"}
+	batchRequest := &request.CreateTransactionBatchRequest{Lines: []*request.TransactionBatchLineRequest{
+		{Type: "IN", ProductQualityID: 1, Quantity: 1, UnitMassAcronym: "kg"},
+	}}
+
+	var repoT repository.TransactionRepositoryMock
+	var repoPQ repository.ProductQualityRepositoryMock
+	repoPQ.On("FindByIDWithAssociations", ctx, int64(1)).Return(&model.ProductQuality{ID: 1, ProductCode: "PRD001", Product: product}, nil)
+	var repoTx repository.TxTransactionRepositoryMock
+	repoTx.On("CreateBatch", ctx, batchRequest).Return([]*model.Transaction{{Code: "IN-0001", Type: "IN"}}, nil)
+	var audit service.AuditServiceMock
+	audit.On("Record", ctx, model.AuditEntityTransaction, "IN-0001", model.AuditActionCreate, mock.Anything, mock.Anything).Return(errors.New("connection reset"))
+	var transactor repository.TransactorMock
+
+	svc := NewTransactionService(&repoT, &repoPQ, &repoTx, &transactor, &audit)
+	result, err := svc.CreateBatch(ctx, batchRequest)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+
+	// The stock movement is rolled back together with its audit entries
+	assert.Equal(t, []error{errors.New("connection reset")}, transactor.RolledBack)
+}
+
 func TestTransactionService_Delete(t *testing.T) {
 	testCases := []struct {
 		name                               string
@@ -927,8 +947,11 @@ func TestTransactionService_Delete(t *testing.T) {
 			var repoT repository.TransactionRepositoryMock
 			var repoPQ repository.ProductQualityRepositoryMock
 			var repoTx repository.TxTransactionRepositoryMock
+			repoT.On("FindByCode", ctx, tc.request, mock.Anything).Return(&model.Transaction{ID: 1, Code: tc.request}, nil)
 			repoTx.On("Delete", ctx, tc.request).Return(tc.expectedTransactionRepoDeleteError)
-			svc := NewTransactionService(&repoT, &repoPQ, &repoTx)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			svc := NewTransactionService(&repoT, &repoPQ, &repoTx, &repository.TransactorMock{}, &audit)
 			err := svc.Delete(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -1,61 +1,115 @@
 package service
 
 import (
-	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"inventory-management/backend/internal/http/request"
 	response "inventory-management/backend/internal/http/response"
 	"inventory-management/backend/internal/model"
 	"inventory-management/backend/internal/repository"
-	third_party "inventory-management/backend/internal/third_party/elasticsearch"
+	search "inventory-management/backend/internal/third_party/search"
+	"inventory-management/backend/util"
+	"strconv"
 )
 
 type UserService struct {
-	UserRepository     repository.UserRepositoryContract
-	Elasticsearch      third_party.ElasticsearchContract
-	IndexElasticsearch string
+	UserRepository repository.UserRepositoryContract
+	SearchEngine   search.SearchEngineContract
+	Transactor     repository.TransactorContract
+	AuditService   AuditServiceContract
 }
 
-func NewUserService(userRepository repository.UserRepositoryContract, elasticsearch third_party.ElasticsearchContract) UserServiceContract {
+func NewUserService(userRepository repository.UserRepositoryContract, searchEngine search.SearchEngineContract, transactor repository.TransactorContract, auditService AuditServiceContract) UserServiceContract {
 	return &UserService{
-		UserRepository:     userRepository,
-		Elasticsearch:      elasticsearch,
-		IndexElasticsearch: "users",
+		UserRepository: userRepository,
+		SearchEngine:   searchEngine,
+		Transactor:     transactor,
+		AuditService:   auditService,
 	}
 }
 
-func (service *UserService) Search(ctx context.Context, data bytes.Buffer, offset int, limit int, totalRecord chan<- int64) (map[string]interface{}, error) {
-	totalRecords, err := service.Elasticsearch.CountAll(ctx, service.IndexElasticsearch, data)
+var userSearchSorts = map[string]string{
+	"name":       "name",
+	"username":   "username",
+	"created_at": "created_at",
+}
+
+var userSearchFacets = []*search.Facet{
+	search.TermsFacet("role", "role"),
+	search.MonthFacet("created_month", "created_at"),
+}
+
+func (service *UserService) Search(ctx context.Context, searchRequest *request.UserSearchRequest, offset int, limit int) (*response.UserSearchResponse, error) {
+	var filters []*search.Filter
+	if searchRequest.Role != "" {
+		filters = append(filters, search.TermFilter("role", searchRequest.Role))
+	}
+	if searchRequest.CreatedFrom != "" || searchRequest.CreatedTo != "" {
+		filters = append(filters, search.DateRangeFilter("created_at", searchRequest.CreatedFrom, searchRequest.CreatedTo))
+	}
+
+	result, err := service.SearchEngine.Search(ctx, model.SearchIndexUsers, &search.Query{
+		Text:        searchRequest.Query,
+		Fields:      []string{"name^3", "username^2", "email"},
+		Filters:     filters,
+		Facets:      search.PickFacets(searchRequest.Facets, userSearchFacets),
+		Sort:        search.ParseSort(searchRequest.Sort, userSearchSorts),
+		DefaultSort: "created_at",
+	}, offset, limit)
 	if err != nil {
 		return nil, err
 	}
+	searchResponse := toSearchResponse(result)
 
-	totalRecord <- totalRecords
-	searchResponse, err := service.Elasticsearch.Search(ctx, service.IndexElasticsearch, data, offset, limit)
+	// The hits are decoded into documents, so fields that are not part of a user never leak out
+	hits, err := json.Marshal(searchResponse.Hits)
 	if err != nil {
 		return nil, err
 	}
 
-	return searchResponse, nil
+	userSearchResponse := &response.UserSearchResponse{
+		Total:  searchResponse.Total,
+		Hits:   []*response.UserDocument{},
+		Facets: searchResponse.Facets,
+	}
+	err = json.Unmarshal(hits, &userSearchResponse.Hits)
+	if err != nil {
+		return nil, err
+	}
+
+	return userSearchResponse, nil
 }
 
-func (service *UserService) FindAll(ctx context.Context, offset int, limit int) ([]*response.UserResponse, error) {
-	users, err := service.UserRepository.FindAll(ctx, offset, limit)
+func (service *UserService) FindAll(ctx context.Context, query *util.ListQuery, offset int, limit int) ([]*response.UserResponse, error) {
+	users, err := service.UserRepository.FindAll(ctx, query, offset, limit)
 	if err != nil {
 		return nil, err
 	}
 
+	return toUserResponses(users), nil
+}
+
+func (service *UserService) FindAllByCursor(ctx context.Context, query *util.ListQuery, cursor string, limit int) ([]*response.UserResponse, *util.CursorPage, error) {
+	users, page, err := service.UserRepository.FindAllByCursor(ctx, query, cursor, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return toUserResponses(users), page, nil
+}
+
+func toUserResponses(users []*model.User) []*response.UserResponse {
 	var userResponses []*response.UserResponse
 	for _, user := range users {
 		userResponses = append(userResponses, user.ToResponse())
 	}
 
-	return userResponses, nil
+	return userResponses
 }
 
-func (service *UserService) CountAll(ctx context.Context) (int64, error) {
-	count, err := service.UserRepository.CountAll(ctx)
+func (service *UserService) CountAll(ctx context.Context, query *util.ListQuery) (int64, error) {
+	count, err := service.UserRepository.CountAll(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -93,24 +147,54 @@ func (service *UserService) VerifyLogin(ctx context.Context, request *request.Lo
 	}, nil
 }
 
+// VerifyTokenVersion refuses the token of a user whose password changed since it was issued, or
+// who no longer exists.
+func (service *UserService) VerifyTokenVersion(ctx context.Context, username string, tokenVersion int64) error {
+	user, err := service.UserRepository.FindByUsername(ctx, username)
+	if err != nil {
+		if err.Error() == response.ErrorNotFound {
+			return errors.New(response.ErrorInvalidToken)
+		}
+		return err
+	}
+
+	if user.TokenVersion != tokenVersion {
+		return errors.New(response.ErrorInvalidToken)
+	}
+
+	return nil
+}
+
 func (service *UserService) Create(ctx context.Context, request *request.CreateUserRequest) (*response.UserResponse, error) {
-	_, err := service.UserRepository.FindByUsername(ctx, request.Username)
+	// Usernames are unique across tenants, since logging in does not name the tenant, and
+	// deleted users keep theirs in case they are restored
+	_, err := service.UserRepository.FindByUsername(util.WithDeleted(util.WithoutTenant(ctx)), request.Username)
 	if err == nil {
 		return nil, errors.New(response.ErrorUsernameExists)
 	}
+	if err.Error() != response.ErrorNotFound {
+		return nil, err
+	}
 
 	var userRequest model.User
 	userRequest.Name = request.Name
 	userRequest.Username = request.Username
 	userRequest.Password = request.Password
-
-	user, err := service.UserRepository.Create(ctx, &userRequest)
-	if err != nil {
-		return nil, err
+	userRequest.Role = request.Role
+	if request.Email != "" {
+		userRequest.Email = &request.Email
 	}
 
-	// Insert to Elasticsearch
-	err = service.Elasticsearch.Create(ctx, service.IndexElasticsearch, user.ToResponse(), user.ID)
+	var user *model.User
+	err = service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = service.UserRepository.Create(ctx, &userRequest)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityUser, strconv.FormatInt(user.ID, 10), model.AuditActionCreate, nil, user.ToResponse())
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -124,6 +208,8 @@ func (service *UserService) Update(ctx context.Context, request *request.UpdateU
 		return nil, err
 	}
 
+	before := checkUser.ToResponse()
+
 	newPassword := checkUser.Password
 	if request.Password != "" {
 		passwordHashed, err := checkUser.HashPassword(request.Password)
@@ -131,18 +217,26 @@ func (service *UserService) Update(ctx context.Context, request *request.UpdateU
 			return nil, err
 		}
 		newPassword = passwordHashed
+		// Tokens issued with the old password stop working
+		checkUser.TokenVersion++
 	}
 
 	checkUser.ID = request.ID
 	checkUser.Name = request.Name
 	checkUser.Password = newPassword
-	user, err := service.UserRepository.Update(ctx, checkUser)
-	if err != nil {
-		return nil, err
+	if request.Email != nil {
+		checkUser.Email = request.Email
 	}
+	var user *model.User
+	err = service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = service.UserRepository.Update(ctx, checkUser)
+		if err != nil {
+			return err
+		}
 
-	// Update to Elasticsearch
-	err = service.Elasticsearch.Update(ctx, service.IndexElasticsearch, user.ToResponse(), user.ID)
+		return service.AuditService.Record(ctx, model.AuditEntityUser, strconv.FormatInt(user.ID, 10), model.AuditActionUpdate, before, user.ToResponse())
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -151,21 +245,53 @@ func (service *UserService) Update(ctx context.Context, request *request.UpdateU
 }
 
 func (service *UserService) Delete(ctx context.Context, id int64) error {
-	_, err := service.UserRepository.FindByID(ctx, id)
+	checkUser, err := service.UserRepository.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	err = service.UserRepository.Delete(ctx, id)
+	return service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		err := service.UserRepository.Delete(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityUser, strconv.FormatInt(checkUser.ID, 10), model.AuditActionDelete, checkUser.ToResponse(), nil)
+	})
+}
+
+func (service *UserService) Restore(ctx context.Context, id int64) (*response.UserResponse, error) {
+	var user *model.User
+	err := service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = service.UserRepository.Restore(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityUser, strconv.FormatInt(user.ID, 10), model.AuditActionRestore, nil, user.ToResponse())
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Delete from Elasticsearch
-	err = service.Elasticsearch.Delete(ctx, service.IndexElasticsearch, id)
+	return user.ToResponse(), nil
+}
+
+// Purge deletes the user for good, which is refused while the audit log names the user as the
+// actor of changes.
+func (service *UserService) Purge(ctx context.Context, id int64) error {
+	checkUser, err := service.UserRepository.FindByID(util.WithDeleted(ctx), id)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return service.Transactor.Transaction(ctx, func(ctx context.Context) error {
+		err := service.UserRepository.Purge(ctx, checkUser.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.AuditService.Record(ctx, model.AuditEntityUser, strconv.FormatInt(checkUser.ID, 10), model.AuditActionPurge, checkUser.ToResponse(), nil)
+	})
 }
@@ -10,10 +10,116 @@ import (
 	response "inventory-management/backend/internal/http/response"
 	"inventory-management/backend/internal/model"
 	repository "inventory-management/backend/internal/repository/mock"
-	third_party "inventory-management/backend/internal/third_party/elasticsearch"
+	service "inventory-management/backend/internal/service/mock"
+	search "inventory-management/backend/internal/third_party/search"
+	"inventory-management/backend/util"
 	"testing"
+	"time"
 )
 
+func TestUserService_Search(t *testing.T) {
+	email := "widdy@example.com"
+	testCases := []struct {
+		name                 string
+		request              *request.UserSearchRequest
+		expectedQuery        *search.Query
+		expectedEngineSearch *search.Result
+		expectedEngineError  error
+		expectedSvc          *response.UserSearchResponse
+		expectedSvcError     error
+	}{
+		{
+			name:    "Users matching the query, sorted by name",
+			request: &request.UserSearchRequest{Query: "widdy", Role: "admin", Sort: "-name", Facets: []string{"role"}},
+			expectedQuery: &search.Query{
+				Text:        "widdy",
+				Fields:      []string{"name^3", "username^2", "email"},
+				Filters:     []*search.Filter{search.TermFilter("role", "admin")},
+				Facets:      []*search.Facet{search.TermsFacet("role", "role")},
+				Sort:        []*search.Sort{{Field: "name", Descending: true}},
+				DefaultSort: "created_at",
+			},
+			expectedEngineSearch: &search.Result{
+				Total: 1,
+				Hits: []map[string]interface{}{
+					{
+						"id":         float64(1),
+						"name":       "Widdy Arfiansyah",
+						"username":   "wdyarfn",
+						"email":      email,
+						"role":       "admin",
+						"password":   "must never be returned",
+						"created_at": "2021-01-01T00:00:00Z",
+						"updated_at": "2021-01-01T00:00:00Z",
+					},
+				},
+				Facets: map[string][]*search.Bucket{
+					"role": {{Value: "admin", Count: 1}},
+				},
+			},
+			expectedSvc: &response.UserSearchResponse{
+				Total: 1,
+				Hits: []*response.UserDocument{
+					{
+						ID:        1,
+						Name:      "Widdy Arfiansyah",
+						Username:  "wdyarfn",
+						Email:     &email,
+						Role:      "admin",
+						CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+						UpdatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				Facets: map[string][]*response.FacetBucketResponse{
+					"role": {{Value: "admin", Count: 1}},
+				},
+			},
+		},
+		{
+			name:    "Newest users first without a query",
+			request: &request.UserSearchRequest{},
+			expectedQuery: &search.Query{
+				Fields:      []string{"name^3", "username^2", "email"},
+				DefaultSort: "created_at",
+			},
+			expectedEngineSearch: &search.Result{
+				Hits:   []map[string]interface{}{},
+				Facets: map[string][]*search.Bucket{},
+			},
+			expectedSvc: &response.UserSearchResponse{
+				Hits:   []*response.UserDocument{},
+				Facets: map[string][]*response.FacetBucketResponse{},
+			},
+		},
+		{
+			name:                "Search engine getting an error",
+			request:             &request.UserSearchRequest{},
+			expectedQuery:       &search.Query{Fields: []string{"name^3", "username^2", "email"}, DefaultSort: "created_at"},
+			expectedEngineError: errors.New("connection refused"),
+			expectedSvcError:    errors.New("connection refused"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var repo repository.UserRepositoryMock
+			var engine search.SearchEngineMock
+			engine.On("Search", ctx, "users", tc.expectedQuery, 0, 10).Return(tc.expectedEngineSearch, tc.expectedEngineError)
+			var audit service.AuditServiceMock
+			svc := NewUserService(&repo, &engine, &repository.TransactorMock{}, &audit)
+			result, err := svc.Search(ctx, tc.request, 0, 10)
+			if tc.expectedSvcError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+			}
+
+			assert.Equal(t, tc.expectedSvc, result)
+		})
+	}
+}
+
 func TestUserService_FindAll(t *testing.T) {
 	testCases := []struct {
 		name                         string
@@ -71,10 +177,11 @@ func TestUserService_FindAll(t *testing.T) {
 			ctx := context.Background()
 
 			var repo repository.UserRepositoryMock
-			var es third_party.ElasticsearchMock
-			repo.On("FindAll", ctx, 0, 10).Return(tc.expectedUserRepoFindAll, tc.expectedUserRepoFindAllError)
-			svc := NewUserService(&repo, &es)
-			result, err := svc.FindAll(ctx, 0, 10)
+			var engine search.SearchEngineMock
+			repo.On("FindAll", ctx, (*util.ListQuery)(nil), 0, 10).Return(tc.expectedUserRepoFindAll, tc.expectedUserRepoFindAllError)
+			var audit service.AuditServiceMock
+			svc := NewUserService(&repo, &engine, &repository.TransactorMock{}, &audit)
+			result, err := svc.FindAll(ctx, nil, 0, 10)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
@@ -129,9 +236,10 @@ func TestUserService_FindByID(t *testing.T) {
 			ctx := context.Background()
 
 			var repo repository.UserRepositoryMock
-			var es third_party.ElasticsearchMock
+			var engine search.SearchEngineMock
 			repo.On("FindByID", ctx, tc.request).Return(tc.expectedUserRepoFindByID, tc.expectedUserRepoFindByIDError)
-			svc := NewUserService(&repo, &es)
+			var audit service.AuditServiceMock
+			svc := NewUserService(&repo, &engine, &repository.TransactorMock{}, &audit)
 			result, err := svc.FindByID(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -205,9 +313,10 @@ func TestUserService_VerifyLogin(t *testing.T) {
 			ctx := context.Background()
 
 			var repo repository.UserRepositoryMock
-			var es third_party.ElasticsearchMock
+			var engine search.SearchEngineMock
 			repo.On("FindByUsername", ctx, tc.request.Username).Return(tc.expectedUserRepoFindByUsername, tc.expectedUserRepoFindByUsernameError)
-			svc := NewUserService(&repo, &es)
+			var audit service.AuditServiceMock
+			svc := NewUserService(&repo, &engine, &repository.TransactorMock{}, &audit)
 			result, err := svc.VerifyLogin(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -224,6 +333,54 @@ func TestUserService_VerifyLogin(t *testing.T) {
 	}
 }
 
+func TestUserService_VerifyTokenVersion(t *testing.T) {
+	testCases := []struct {
+		name                                string
+		tokenVersion                        int64
+		expectedUserRepoFindByUsername      *model.User
+		expectedUserRepoFindByUsernameError error
+		expectedSvcError                    error
+	}{
+		{
+			name:                           "Token issued with the current password",
+			tokenVersion:                   2,
+			expectedUserRepoFindByUsername: &model.User{ID: 1, Username: "wdyarfn", TokenVersion: 2},
+		},
+		{
+			name:                           "Token issued before the password changed",
+			tokenVersion:                   1,
+			expectedUserRepoFindByUsername: &model.User{ID: 1, Username: "wdyarfn", TokenVersion: 2},
+			expectedSvcError:               errors.New(response.ErrorInvalidToken),
+		},
+		{
+			name:                                "Token of a deleted user",
+			tokenVersion:                        2,
+			expectedUserRepoFindByUsernameError: errors.New(response.ErrorNotFound),
+			expectedSvcError:                    errors.New(response.ErrorInvalidToken),
+		},
+		{
+			name:                                "User cannot be found",
+			tokenVersion:                        2,
+			expectedUserRepoFindByUsernameError: errors.New("connection refused"),
+			expectedSvcError:                    errors.New("connection refused"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var repo repository.UserRepositoryMock
+			var engine search.SearchEngineMock
+			repo.On("FindByUsername", ctx, "wdyarfn").Return(tc.expectedUserRepoFindByUsername, tc.expectedUserRepoFindByUsernameError)
+			svc := NewUserService(&repo, &engine, &repository.TransactorMock{}, &service.AuditServiceMock{})
+			err := svc.VerifyTokenVersion(ctx, "wdyarfn", tc.tokenVersion)
+
+			assert.Equal(t, tc.expectedSvcError, err)
+		})
+	}
+}
+
 func TestUserService_Create(t *testing.T) {
 	password, _ := bcrypt.GenerateFromPassword([]byte("1234567"), bcrypt.DefaultCost)
 	testCases := []struct {
@@ -235,9 +392,7 @@ func TestUserService_Create(t *testing.T) {
 		expectedUserRepoCreate              *model.User
 		expectedUserRepoCreateError         error
 		expectedSvc                         *response.UserResponse
-		expected3rdParty                    *response.UserResponse
 		expectedSvcError                    error
-		expected3rdPartyError               error
 	}{
 		{
 			name: "Create user with required fields",
@@ -265,17 +420,9 @@ func TestUserService_Create(t *testing.T) {
 				CreatedAt: "0001-01-01 07:00:00 +0700 +07",
 				UpdatedAt: "0001-01-01 07:00:00 +0700 +07",
 			},
-			expected3rdParty: &response.UserResponse{
-				ID:        1,
-				Name:      "Widdy Arfiansyah",
-				Username:  "wdyarfn",
-				CreatedAt: "0001-01-01 07:00:00 +0700 +07",
-				UpdatedAt: "0001-01-01 07:00:00 +0700 +07",
-			},
 			expectedUserRepoCreateError:         nil,
 			expectedSvcError:                    nil,
 			expectedUserRepoFindByUsernameError: errors.New(response.ErrorNotFound),
-			expected3rdPartyError:               nil,
 		},
 		{
 			name: "Create user with given the exists username",
@@ -295,19 +442,30 @@ func TestUserService_Create(t *testing.T) {
 				Username: "wdyarfn",
 				Password: string(password),
 			},
-			expected3rdParty: &response.UserResponse{
-				ID:        1,
-				Name:      "Widdy Arfiansyah",
-				Username:  "wdyarfn",
-				CreatedAt: "0001-01-01 07:00:00 +0700 +07",
-				UpdatedAt: "0001-01-01 07:00:00 +0700 +07",
-			},
 			expectedUserRepoCreate:              nil,
 			expectedSvc:                         nil,
 			expectedUserRepoCreateError:         errors.New("getting an error"),
 			expectedSvcError:                    errors.New(response.ErrorUsernameExists),
 			expectedUserRepoFindByUsernameError: nil,
-			expected3rdPartyError:               errors.New("getting an error"),
+		},
+		{
+			name: "Create user when the username cannot be checked",
+			request: &request.CreateUserRequest{
+				Name:     "Widdy Arfian",
+				Username: "wdyarfn",
+				Password: "1234567",
+			},
+			requestRepo: &model.User{
+				Name:     "Widdy Arfian",
+				Username: "wdyarfn",
+				Password: string(password),
+			},
+			expectedUserRepoFindByUsername:      nil,
+			expectedUserRepoCreate:              nil,
+			expectedSvc:                         nil,
+			expectedUserRepoCreateError:         nil,
+			expectedSvcError:                    errors.New("connection refused"),
+			expectedUserRepoFindByUsernameError: errors.New("connection refused"),
 		},
 	}
 
@@ -316,15 +474,17 @@ func TestUserService_Create(t *testing.T) {
 			ctx := context.Background()
 
 			var repo repository.UserRepositoryMock
-			var es third_party.ElasticsearchMock
-			repo.On("FindByUsername", ctx, tc.requestRepo.Username).Return(tc.expectedUserRepoFindByUsername, tc.expectedUserRepoFindByUsernameError)
+			var engine search.SearchEngineMock
+			repo.On("FindByUsername", util.WithDeleted(util.WithoutTenant(ctx)), tc.requestRepo.Username).Return(tc.expectedUserRepoFindByUsername, tc.expectedUserRepoFindByUsernameError)
 			repo.On("Create", ctx, mock.Anything).Return(tc.expectedUserRepoCreate, tc.expectedUserRepoCreateError)
-			es.On("Create", ctx, "users", tc.expected3rdParty, tc.expected3rdParty.ID).Return(tc.expected3rdPartyError)
-			svc := NewUserService(&repo, &es)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			svc := NewUserService(&repo, &engine, &repository.TransactorMock{}, &audit)
 			result, err := svc.Create(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tc.expectedSvcError.Error(), err.Error())
+				repo.AssertNotCalled(t, "Create", ctx, mock.Anything)
 			}
 
 			if err == nil {
@@ -346,9 +506,7 @@ func TestUserService_Update(t *testing.T) {
 		expectedUserRepoUpdate        *model.User
 		expectedUserRepoUpdateError   error
 		expectedSvc                   *response.UserResponse
-		expected3rdParty              *response.UserResponse
 		expectedSvcError              error
-		expected3rdPartyError         error
 	}{
 		{
 			name: "Update user with required fields",
@@ -376,17 +534,9 @@ func TestUserService_Update(t *testing.T) {
 				CreatedAt: "0001-01-01 07:00:00 +0700 +07",
 				UpdatedAt: "0001-01-01 07:00:00 +0700 +07",
 			},
-			expected3rdParty: &response.UserResponse{
-				ID:        1,
-				Name:      "Arfian",
-				Username:  "wdyarfn",
-				CreatedAt: "0001-01-01 07:00:00 +0700 +07",
-				UpdatedAt: "0001-01-01 07:00:00 +0700 +07",
-			},
 			expectedUserRepoUpdateError:   nil,
 			expectedSvcError:              nil,
 			expectedUserRepoFindByIDError: nil,
-			expected3rdPartyError:         nil,
 		},
 		{
 			name: "User doesnt exists with given ID when updating data",
@@ -395,13 +545,6 @@ func TestUserService_Update(t *testing.T) {
 				Name:     "Arfian",
 				Password: "7654321",
 			},
-			expected3rdParty: &response.UserResponse{
-				ID:        1,
-				Name:      "Arfian",
-				Username:  "wdyarfn",
-				CreatedAt: "0001-01-01 07:00:00 +0700 +07",
-				UpdatedAt: "0001-01-01 07:00:00 +0700 +07",
-			},
 			requestUserRepoFindByID:       1,
 			expectedUserRepoFindByID:      nil,
 			expectedUserRepoUpdate:        nil,
@@ -409,7 +552,6 @@ func TestUserService_Update(t *testing.T) {
 			expectedUserRepoUpdateError:   errors.New("getting an error"),
 			expectedSvcError:              errors.New(response.ErrorNotFound),
 			expectedUserRepoFindByIDError: errors.New(response.ErrorNotFound),
-			expected3rdPartyError:         errors.New("getting an error"),
 		},
 	}
 
@@ -418,11 +560,12 @@ func TestUserService_Update(t *testing.T) {
 			ctx := context.Background()
 
 			var repo repository.UserRepositoryMock
-			var es third_party.ElasticsearchMock
+			var engine search.SearchEngineMock
 			repo.On("FindByID", ctx, tc.requestUserRepoFindByID).Return(tc.expectedUserRepoFindByID, tc.expectedUserRepoFindByIDError)
 			repo.On("Update", ctx, mock.Anything).Return(tc.expectedUserRepoUpdate, tc.expectedUserRepoUpdateError)
-			es.On("Update", ctx, "users", tc.expected3rdParty, tc.expected3rdParty.ID).Return(tc.expected3rdPartyError)
-			svc := NewUserService(&repo, &es)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			svc := NewUserService(&repo, &engine, &repository.TransactorMock{}, &audit)
 			result, err := svc.Update(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -434,6 +577,8 @@ func TestUserService_Update(t *testing.T) {
 			// really changed
 			if err == nil {
 				assert.Equal(t, tc.expectedUserRepoFindByID.Name, result.Name)
+				// the tokens issued with the old password are revoked
+				assert.Equal(t, int64(1), tc.expectedUserRepoFindByID.TokenVersion)
 			}
 		})
 	}
@@ -447,7 +592,6 @@ func TestUserService_Delete(t *testing.T) {
 		expectedUserRepoFindByIDError error
 		expectedUserRepoDeleteError   error
 		expectedSvcError              error
-		expected3rdPartyError         error
 	}{
 		{
 			name:    "User exists with given ID",
@@ -459,7 +603,6 @@ func TestUserService_Delete(t *testing.T) {
 			expectedUserRepoDeleteError:   nil,
 			expectedSvcError:              nil,
 			expectedUserRepoFindByIDError: nil,
-			expected3rdPartyError:         nil,
 		},
 		{
 			name:                          "User doesnt exists with given ID when deleting data",
@@ -468,7 +611,6 @@ func TestUserService_Delete(t *testing.T) {
 			expectedUserRepoDeleteError:   errors.New("getting an error"),
 			expectedSvcError:              errors.New(response.ErrorNotFound),
 			expectedUserRepoFindByIDError: errors.New(response.ErrorNotFound),
-			expected3rdPartyError:         errors.New("getting an error"),
 		},
 	}
 
@@ -477,11 +619,12 @@ func TestUserService_Delete(t *testing.T) {
 			ctx := context.Background()
 
 			var repo repository.UserRepositoryMock
-			var es third_party.ElasticsearchMock
+			var engine search.SearchEngineMock
 			repo.On("FindByID", ctx, tc.request).Return(tc.expectedUserRepoFindByID, tc.expectedUserRepoFindByIDError)
 			repo.On("Delete", ctx, tc.request).Return(tc.expectedUserRepoDeleteError)
-			es.On("Delete", ctx, "users", tc.request).Return(tc.expected3rdPartyError)
-			svc := NewUserService(&repo, &es)
+			var audit service.AuditServiceMock
+			audit.On("Record", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			svc := NewUserService(&repo, &engine, &repository.TransactorMock{}, &audit)
 			err := svc.Delete(ctx, tc.request)
 			if tc.expectedSvcError != nil {
 				assert.Error(t, err)
@@ -6,9 +6,24 @@ import (
 )
 
 type ElasticsearchContract interface {
+	CreateIndex(ctx context.Context, index string, mapping string) error
+	IndexExists(ctx context.Context, index string) (bool, error)
+	DeleteIndex(ctx context.Context, index string) error
+	Refresh(ctx context.Context, index string) error
 	CountAll(ctx context.Context, index string, data bytes.Buffer) (int64, error)
 	Search(ctx context.Context, index string, data bytes.Buffer, offset int, limit int) (map[string]interface{}, error)
 	Create(ctx context.Context, index string, request interface{}, id int64) error
 	Update(ctx context.Context, index string, request interface{}, id int64) error
 	Delete(ctx context.Context, index string, id int64) error
+	BulkCreate(ctx context.Context, index string, documents []*Document) error
+	AliasIndices(ctx context.Context, alias string) ([]string, error)
+	AddAlias(ctx context.Context, index string, alias string) error
+	RemoveAlias(ctx context.Context, index string, alias string) error
+	SwapAlias(ctx context.Context, alias string, index string) ([]string, error)
+}
+
+// Document is a document to write in bulk.
+type Document struct {
+	ID   int64
+	Body interface{}
 }
@@ -5,9 +5,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/elastic/go-elasticsearch/v7"
+	"net/http"
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 type Elasticsearch struct {
@@ -20,6 +24,39 @@ func NewElasticsearch(elasticsearch *elasticsearch.Client) ElasticsearchContract
 	}
 }
 
+// CreateIndex creates the index with the given settings and mappings unless it already exists.
+func (service *Elasticsearch) CreateIndex(ctx context.Context, index string, mapping string) error {
+	exists, err := service.Elasticsearch.Indices.Exists(
+		[]string{index},
+		service.Elasticsearch.Indices.Exists.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer exists.Body.Close()
+
+	if exists.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	res, err := service.Elasticsearch.Indices.Create(
+		index,
+		service.Elasticsearch.Indices.Create.WithContext(ctx),
+		service.Elasticsearch.Indices.Create.WithBody(strings.NewReader(mapping)),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// Another instance may have created the index in the meantime
+	if res.IsError() && !strings.Contains(res.String(), "resource_already_exists_exception") {
+		return errors.New(res.String())
+	}
+
+	return nil
+}
+
 func (service *Elasticsearch) CountAll(ctx context.Context, index string, data bytes.Buffer) (int64, error) {
 	count, err := service.Elasticsearch.Count(
 		service.Elasticsearch.Count.WithContext(ctx),
@@ -69,7 +106,7 @@ func (service *Elasticsearch) Search(ctx context.Context, index string, data byt
 }
 
 func (service *Elasticsearch) Create(ctx context.Context, index string, request interface{}, id int64) error {
-	if reflect.TypeOf(request).Kind() != reflect.Struct {
+	if reflect.Indirect(reflect.ValueOf(request)).Kind() != reflect.Struct {
 		return errors.New("the request is not a struct type")
 	}
 
@@ -95,20 +132,36 @@ func (service *Elasticsearch) Create(ctx context.Context, index string, request
 	return nil
 }
 
+// Update merges the fields of the request into the document, the document is created when it
+// does not exist yet.
 func (service *Elasticsearch) Update(ctx context.Context, index string, request interface{}, id int64) error {
-	if reflect.TypeOf(request).Kind() != reflect.Struct {
+	if reflect.Indirect(reflect.ValueOf(request)).Kind() != reflect.Struct {
 		return errors.New("the request is not a struct type")
 	}
 
-	err := service.Delete(ctx, index, id)
+	data, err := json.Marshal(map[string]interface{}{
+		"doc":           request,
+		"doc_as_upsert": true,
+	})
 	if err != nil {
 		return err
 	}
 
-	err = service.Create(ctx, index, request, id)
+	idString := strconv.FormatInt(id, 10)
+	res, err := service.Elasticsearch.Update(
+		index,
+		idString,
+		bytes.NewReader(data),
+		service.Elasticsearch.Update.WithContext(ctx),
+	)
 	if err != nil {
 		return err
 	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.New(res.String())
+	}
 
 	return nil
 }
@@ -127,3 +180,209 @@ func (service *Elasticsearch) Delete(ctx context.Context, index string, id int64
 
 	return nil
 }
+
+func (service *Elasticsearch) IndexExists(ctx context.Context, index string) (bool, error) {
+	res, err := service.Elasticsearch.Indices.Exists(
+		[]string{index},
+		service.Elasticsearch.Indices.Exists.WithContext(ctx),
+	)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	return res.StatusCode == http.StatusOK, nil
+}
+
+func (service *Elasticsearch) DeleteIndex(ctx context.Context, index string) error {
+	res, err := service.Elasticsearch.Indices.Delete(
+		[]string{index},
+		service.Elasticsearch.Indices.Delete.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		return errors.New(res.String())
+	}
+
+	return nil
+}
+
+// Refresh makes every document written to the index visible to searches and counts.
+func (service *Elasticsearch) Refresh(ctx context.Context, index string) error {
+	res, err := service.Elasticsearch.Indices.Refresh(
+		service.Elasticsearch.Indices.Refresh.WithIndex(index),
+		service.Elasticsearch.Indices.Refresh.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.New(res.String())
+	}
+
+	return nil
+}
+
+// BulkCreate creates the documents that do not exist in the index yet. Documents that already
+// exist are skipped, since they were written after they were read.
+func (service *Elasticsearch) BulkCreate(ctx context.Context, index string, documents []*Document) error {
+	if len(documents) == 0 {
+		return nil
+	}
+
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+	for _, document := range documents {
+		err := encoder.Encode(map[string]interface{}{
+			"create": map[string]interface{}{"_id": strconv.FormatInt(document.ID, 10)},
+		})
+		if err != nil {
+			return err
+		}
+
+		err = encoder.Encode(document.Body)
+		if err != nil {
+			return err
+		}
+	}
+
+	res, err := service.Elasticsearch.Bulk(
+		&data,
+		service.Elasticsearch.Bulk.WithIndex(index),
+		service.Elasticsearch.Bulk.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.New(res.String())
+	}
+
+	var bulkResponse struct {
+		Items []map[string]struct {
+			Status int                    `json:"status"`
+			Error  map[string]interface{} `json:"error"`
+		} `json:"items"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&bulkResponse)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range bulkResponse.Items {
+		result := item["create"]
+		if result.Status != http.StatusCreated && result.Status != http.StatusConflict {
+			return fmt.Errorf("cannot create a document in %s: %v", index, result.Error["reason"])
+		}
+	}
+
+	return nil
+}
+
+// AliasIndices returns the indices the alias points to.
+func (service *Elasticsearch) AliasIndices(ctx context.Context, alias string) ([]string, error) {
+	res, err := service.Elasticsearch.Indices.GetAlias(
+		service.Elasticsearch.Indices.GetAlias.WithName(alias),
+		service.Elasticsearch.Indices.GetAlias.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, errors.New(res.String())
+	}
+
+	var aliasResponse map[string]interface{}
+	err = json.NewDecoder(res.Body).Decode(&aliasResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	var indices []string
+	for index := range aliasResponse {
+		indices = append(indices, index)
+	}
+	sort.Strings(indices)
+
+	return indices, nil
+}
+
+// AddAlias points the alias at the index, next to the indices it already points to.
+func (service *Elasticsearch) AddAlias(ctx context.Context, index string, alias string) error {
+	return service.updateAliases(ctx, []map[string]interface{}{
+		{"add": map[string]interface{}{"index": index, "alias": alias}},
+	})
+}
+
+func (service *Elasticsearch) RemoveAlias(ctx context.Context, index string, alias string) error {
+	return service.updateAliases(ctx, []map[string]interface{}{
+		{"remove": map[string]interface{}{"index": index, "alias": alias}},
+	})
+}
+
+// SwapAlias atomically points the alias at the index alone and returns the indices it pointed to
+// before. An index named like the alias, from before indices were versioned, is deleted in the
+// same step, since an alias cannot share its name with an index.
+func (service *Elasticsearch) SwapAlias(ctx context.Context, alias string, index string) ([]string, error) {
+	previous, err := service.AliasIndices(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []map[string]interface{}
+	if len(previous) == 0 {
+		exists, err := service.IndexExists(ctx, alias)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			actions = append(actions, map[string]interface{}{"remove_index": map[string]interface{}{"index": alias}})
+		}
+	}
+	for _, previousIndex := range previous {
+		actions = append(actions, map[string]interface{}{"remove": map[string]interface{}{"index": previousIndex, "alias": alias}})
+	}
+	actions = append(actions, map[string]interface{}{"add": map[string]interface{}{"index": index, "alias": alias}})
+
+	err = service.updateAliases(ctx, actions)
+	if err != nil {
+		return nil, err
+	}
+
+	return previous, nil
+}
+
+func (service *Elasticsearch) updateAliases(ctx context.Context, actions []map[string]interface{}) error {
+	data, err := json.Marshal(map[string]interface{}{"actions": actions})
+	if err != nil {
+		return err
+	}
+
+	res, err := service.Elasticsearch.Indices.UpdateAliases(
+		bytes.NewReader(data),
+		service.Elasticsearch.Indices.UpdateAliases.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.New(res.String())
+	}
+
+	return nil
+}
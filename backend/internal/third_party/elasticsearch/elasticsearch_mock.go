@@ -10,6 +10,11 @@ type ElasticsearchMock struct {
 	mock.Mock
 }
 
+func (service *ElasticsearchMock) CreateIndex(ctx context.Context, index string, mapping string) error {
+	args := service.Called(ctx, index, mapping)
+	return args.Error(0)
+}
+
 func (service *ElasticsearchMock) CountAll(ctx context.Context, index string, data bytes.Buffer) (int64, error) {
 	args := service.Called(ctx, index, data)
 	return args.Get(0).(int64), args.Error(1)
@@ -37,3 +42,49 @@ func (service *ElasticsearchMock) Delete(ctx context.Context, index string, id i
 	args := service.Called(ctx, index, id)
 	return args.Error(0)
 }
+
+func (service *ElasticsearchMock) IndexExists(ctx context.Context, index string) (bool, error) {
+	args := service.Called(ctx, index)
+	return args.Bool(0), args.Error(1)
+}
+
+func (service *ElasticsearchMock) DeleteIndex(ctx context.Context, index string) error {
+	args := service.Called(ctx, index)
+	return args.Error(0)
+}
+
+func (service *ElasticsearchMock) Refresh(ctx context.Context, index string) error {
+	args := service.Called(ctx, index)
+	return args.Error(0)
+}
+
+func (service *ElasticsearchMock) BulkCreate(ctx context.Context, index string, documents []*Document) error {
+	args := service.Called(ctx, index, documents)
+	return args.Error(0)
+}
+
+func (service *ElasticsearchMock) AliasIndices(ctx context.Context, alias string) ([]string, error) {
+	args := service.Called(ctx, alias)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (service *ElasticsearchMock) AddAlias(ctx context.Context, index string, alias string) error {
+	args := service.Called(ctx, index, alias)
+	return args.Error(0)
+}
+
+func (service *ElasticsearchMock) RemoveAlias(ctx context.Context, index string, alias string) error {
+	args := service.Called(ctx, index, alias)
+	return args.Error(0)
+}
+
+func (service *ElasticsearchMock) SwapAlias(ctx context.Context, alias string, index string) ([]string, error) {
+	args := service.Called(ctx, alias, index)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
@@ -0,0 +1,15 @@
+package third_party
+
+import (
+	"context"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type NotifierContract interface {
+	Send(ctx context.Context, message *Message) error
+}
@@ -0,0 +1,22 @@
+package third_party
+
+import (
+	"context"
+	"log"
+)
+
+type LogNotifier struct {
+	Logger *log.Logger
+}
+
+func NewLogNotifier(logger *log.Logger) NotifierContract {
+	return &LogNotifier{
+		Logger: logger,
+	}
+}
+
+func (notifier *LogNotifier) Send(ctx context.Context, message *Message) error {
+	notifier.Logger.Printf("notification to=%q subject=%q body=%q", message.To, message.Subject, message.Body)
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package third_party
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+type NotifierMock struct {
+	mock.Mock
+}
+
+func (service *NotifierMock) Send(ctx context.Context, message *Message) error {
+	args := service.Called(ctx, message)
+	return args.Error(0)
+}
@@ -0,0 +1,55 @@
+package third_party
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"net/smtp"
+	"strings"
+	"testing"
+)
+
+func TestSmtpNotifier_Send(t *testing.T) {
+	var sentAddr string
+	var sentTo []string
+	var sentMessage string
+
+	notifier := &SmtpNotifier{
+		Config: SmtpConfig{
+			Host: "localhost",
+			Port: "1025",
+			From: "inventory@example.com",
+		},
+		SendMail: func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+			sentAddr = addr
+			sentTo = to
+			sentMessage = string(msg)
+			return nil
+		},
+	}
+
+	err := notifier.Send(context.Background(), &Message{
+		To:      "wdyarfn@example.com",
+		Subject: "Reset your password",
+		Body:    "token",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "localhost:1025", sentAddr)
+	assert.Equal(t, []string{"wdyarfn@example.com"}, sentTo)
+	assert.True(t, strings.HasPrefix(sentMessage, "From: inventory@example.com\r\nTo: wdyarfn@example.com\r\nSubject: Reset your password\r\n"))
+	assert.True(t, strings.HasSuffix(sentMessage, "\r\n\r\ntoken"))
+}
+
+func TestLogNotifier_Send(t *testing.T) {
+	var buffer bytes.Buffer
+	notifier := NewLogNotifier(log.New(&buffer, "", 0))
+
+	err := notifier.Send(context.Background(), &Message{
+		To:      "wdyarfn@example.com",
+		Subject: "Reset your password",
+		Body:    "token",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "notification to=\"wdyarfn@example.com\" subject=\"Reset your password\" body=\"token\"\n", buffer.String())
+}
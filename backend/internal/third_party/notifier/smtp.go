@@ -0,0 +1,51 @@
+package third_party
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+type SmtpConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type SmtpNotifier struct {
+	Config   SmtpConfig
+	SendMail func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewSmtpNotifier(config SmtpConfig) NotifierContract {
+	return &SmtpNotifier{
+		Config:   config,
+		SendMail: smtp.SendMail,
+	}
+}
+
+func (notifier *SmtpNotifier) Send(ctx context.Context, message *Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if notifier.Config.Username != "" {
+		auth = smtp.PlainAuth("", notifier.Config.Username, notifier.Config.Password, notifier.Config.Host)
+	}
+
+	var body strings.Builder
+	body.WriteString(fmt.Sprintf("From: %s\r\n", notifier.Config.From))
+	body.WriteString(fmt.Sprintf("To: %s\r\n", message.To))
+	body.WriteString(fmt.Sprintf("Subject: %s\r\n", message.Subject))
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	body.WriteString("\r\n")
+	body.WriteString(message.Body)
+
+	addr := fmt.Sprintf("%s:%s", notifier.Config.Host, notifier.Config.Port)
+	return notifier.SendMail(addr, auth, notifier.Config.From, []string{message.To}, []byte(body.String()))
+}
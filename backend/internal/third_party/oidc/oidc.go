@@ -0,0 +1,318 @@
+package third_party
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v4"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+type OidcConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	GroupsClaim  string
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+}
+
+type oidcJwks struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+type oidcTokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type Oidc struct {
+	Config     OidcConfig
+	HTTPClient *http.Client
+	mutex      sync.Mutex
+	discovery  *oidcDiscovery
+	keys       map[string]*rsa.PublicKey
+}
+
+func NewOidc(config OidcConfig, httpClient *http.Client) OidcContract {
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "profile", "email"}
+	}
+
+	if config.GroupsClaim == "" {
+		config.GroupsClaim = "groups"
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Oidc{
+		Config:     config,
+		HTTPClient: httpClient,
+		keys:       map[string]*rsa.PublicKey{},
+	}
+}
+
+// GeneratePkceVerifier returns a high-entropy code verifier as described in RFC 7636.
+func GeneratePkceVerifier() (string, error) {
+	randomBytes := make([]byte, 32)
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(randomBytes), nil
+}
+
+// PkceChallenge derives the S256 code challenge sent with the authorization request.
+func PkceChallenge(verifier string) string {
+	hash := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func (service *Oidc) AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	discovery, err := service.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", service.Config.ClientID)
+	query.Set("redirect_uri", service.Config.RedirectURL)
+	query.Set("scope", strings.Join(service.Config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+func (service *Oidc) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*OidcClaims, error) {
+	discovery, err := service.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", service.Config.RedirectURL)
+	form.Set("client_id", service.Config.ClientID)
+	form.Set("code_verifier", codeVerifier)
+	if service.Config.ClientSecret != "" {
+		form.Set("client_secret", service.Config.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := service.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var tokenResponse oidcTokenResponse
+	if err := json.NewDecoder(res.Body).Decode(&tokenResponse); err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK || tokenResponse.Error != "" {
+		return nil, fmt.Errorf("oidc token exchange failed: %s %s", tokenResponse.Error, tokenResponse.ErrorDescription)
+	}
+
+	if tokenResponse.IDToken == "" {
+		return nil, errors.New("oidc token response does not contain an id_token")
+	}
+
+	return service.verifyIDToken(ctx, tokenResponse.IDToken, nonce)
+}
+
+func (service *Oidc) verifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*OidcClaims, error) {
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{"RS256"}))
+	token, err := parser.Parse(rawIDToken, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return service.getKey(ctx, kid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("oidc id_token has unexpected claims")
+	}
+
+	if !claims.VerifyIssuer(service.Config.Issuer, true) {
+		return nil, errors.New("oidc id_token issuer mismatch")
+	}
+
+	if !claims.VerifyAudience(service.Config.ClientID, true) {
+		return nil, errors.New("oidc id_token audience mismatch")
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return nil, errors.New("oidc id_token nonce mismatch")
+	}
+
+	var oidcClaims OidcClaims
+	oidcClaims.Issuer, _ = claims["iss"].(string)
+	oidcClaims.Subject, _ = claims["sub"].(string)
+	oidcClaims.Email, _ = claims["email"].(string)
+	// Some providers send email_verified as a string
+	switch emailVerified := claims["email_verified"].(type) {
+	case bool:
+		oidcClaims.EmailVerified = emailVerified
+	case string:
+		oidcClaims.EmailVerified = emailVerified == "true"
+	}
+	oidcClaims.Name, _ = claims["name"].(string)
+	oidcClaims.PreferredUsername, _ = claims["preferred_username"].(string)
+
+	switch groups := claims[service.Config.GroupsClaim].(type) {
+	case string:
+		oidcClaims.Groups = []string{groups}
+	case []interface{}:
+		for _, group := range groups {
+			if groupString, ok := group.(string); ok {
+				oidcClaims.Groups = append(oidcClaims.Groups, groupString)
+			}
+		}
+	}
+
+	if oidcClaims.Subject == "" {
+		return nil, errors.New("oidc id_token does not contain a subject")
+	}
+
+	return &oidcClaims, nil
+}
+
+func (service *Oidc) getDiscovery(ctx context.Context) (*oidcDiscovery, error) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	if service.discovery != nil {
+		return service.discovery, nil
+	}
+
+	var discovery oidcDiscovery
+	wellKnown := strings.TrimSuffix(service.Config.Issuer, "/") + "/.well-known/openid-configuration"
+	err := service.getJSON(ctx, wellKnown, &discovery)
+	if err != nil {
+		return nil, err
+	}
+
+	if discovery.Issuer != service.Config.Issuer {
+		return nil, fmt.Errorf("oidc issuer mismatch: expected %q got %q", service.Config.Issuer, discovery.Issuer)
+	}
+
+	service.discovery = &discovery
+
+	return service.discovery, nil
+}
+
+func (service *Oidc) getKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	discovery, err := service.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	if key, ok := service.keys[kid]; ok {
+		return key, nil
+	}
+
+	// Unknown key ID, the provider may have rotated its keys
+	var jwks oidcJwks
+	err = service.getJSON(ctx, discovery.JwksURI, &jwks)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" {
+			continue
+		}
+
+		modulus, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+
+		exponent, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+
+		keys[jwk.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(modulus),
+			E: int(new(big.Int).SetBytes(exponent).Int64()),
+		}
+	}
+	service.keys = keys
+
+	key, ok := service.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("oidc signing key %q not found", kid)
+	}
+
+	return key, nil
+}
+
+func (service *Oidc) getJSON(ctx context.Context, endpoint string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := service.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("oidc request to %s failed with status %d", endpoint, res.StatusCode)
+	}
+
+	return json.NewDecoder(res.Body).Decode(target)
+}
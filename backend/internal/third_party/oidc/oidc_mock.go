@@ -0,0 +1,23 @@
+package third_party
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+type OidcMock struct {
+	mock.Mock
+}
+
+func (service *OidcMock) AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	args := service.Called(ctx, state, nonce, codeChallenge)
+	return args.String(0), args.Error(1)
+}
+
+func (service *OidcMock) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*OidcClaims, error) {
+	args := service.Called(ctx, code, codeVerifier, nonce)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*OidcClaims), args.Error(1)
+}
@@ -0,0 +1,172 @@
+package third_party
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// mockOidcProvider is a minimal OpenID Connect provider serving discovery, JWKS and
+// token endpoints. It issues an id_token for a single authorization code.
+type mockOidcProvider struct {
+	server        *httptest.Server
+	key           *rsa.PrivateKey
+	code          string
+	codeChallenge string
+	nonce         string
+	clientID      string
+	groups        []string
+}
+
+func newMockOidcProvider(t *testing.T) *mockOidcProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+
+	provider := &mockOidcProvider{
+		key:      key,
+		clientID: "inventory-management",
+		groups:   []string{"warehouse-admins"},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 provider.server.URL,
+			"authorization_endpoint": provider.server.URL + "/authorize",
+			"token_endpoint":         provider.server.URL + "/token",
+			"jwks_uri":               provider.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{
+				{
+					"kty": "RSA",
+					"kid": "test-key",
+					"n":   base64.RawURLEncoding.EncodeToString(provider.key.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(provider.key.E)).Bytes()),
+				},
+			},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.PostForm.Get("code") != provider.code || PkceChallenge(r.PostForm.Get("code_verifier")) != provider.codeChallenge {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":                provider.server.URL,
+			"sub":                "user-123",
+			"aud":                provider.clientID,
+			"exp":                time.Now().Add(time.Minute).Unix(),
+			"nonce":              provider.nonce,
+			"email":              "wdyarfn@example.com",
+			"email_verified":     true,
+			"name":               "Widdy Arfiansyah",
+			"preferred_username": "wdyarfn",
+			"groups":             provider.groups,
+		})
+		token.Header["kid"] = "test-key"
+		idToken, _ := token.SignedString(provider.key)
+
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": idToken})
+	})
+	provider.server = httptest.NewServer(mux)
+	t.Cleanup(provider.server.Close)
+
+	return provider
+}
+
+func TestOidc_AuthCodeURL(t *testing.T) {
+	provider := newMockOidcProvider(t)
+	client := NewOidc(OidcConfig{
+		Issuer:      provider.server.URL,
+		ClientID:    provider.clientID,
+		RedirectURL: "http://localhost:5173/callback",
+	}, nil)
+
+	authURL, err := client.AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+	assert.Nil(t, err)
+
+	parsedURL, err := url.Parse(authURL)
+	assert.Nil(t, err)
+	assert.Equal(t, "/authorize", parsedURL.Path)
+	assert.Equal(t, "code", parsedURL.Query().Get("response_type"))
+	assert.Equal(t, "state", parsedURL.Query().Get("state"))
+	assert.Equal(t, "nonce", parsedURL.Query().Get("nonce"))
+	assert.Equal(t, "challenge", parsedURL.Query().Get("code_challenge"))
+	assert.Equal(t, "S256", parsedURL.Query().Get("code_challenge_method"))
+	assert.Equal(t, "openid profile email", parsedURL.Query().Get("scope"))
+}
+
+func TestOidc_Exchange(t *testing.T) {
+	verifier, err := GeneratePkceVerifier()
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		name          string
+		verifier      string
+		nonce         string
+		expectedError bool
+	}{
+		{
+			name:          "Exchange code with valid verifier and nonce",
+			verifier:      verifier,
+			nonce:         "nonce",
+			expectedError: false,
+		},
+		{
+			name:          "Exchange code with wrong verifier",
+			verifier:      "wrong-verifier",
+			nonce:         "nonce",
+			expectedError: true,
+		},
+		{
+			name:          "Exchange code with mismatched nonce",
+			verifier:      verifier,
+			nonce:         "another-nonce",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := newMockOidcProvider(t)
+			provider.code = "authorization-code"
+			provider.codeChallenge = PkceChallenge(verifier)
+			provider.nonce = "nonce"
+
+			client := NewOidc(OidcConfig{
+				Issuer:   provider.server.URL,
+				ClientID: provider.clientID,
+			}, nil)
+
+			claims, err := client.Exchange(context.Background(), "authorization-code", tc.verifier, tc.nonce)
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, claims)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, provider.server.URL, claims.Issuer)
+			assert.Equal(t, "user-123", claims.Subject)
+			assert.Equal(t, "wdyarfn", claims.PreferredUsername)
+			assert.True(t, claims.EmailVerified)
+			assert.Equal(t, []string{"warehouse-admins"}, claims.Groups)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package third_party
+
+import (
+	"time"
+)
+
+// Branding is the company a document is issued by, printed in its header and footer.
+type Branding struct {
+	CompanyName string
+	Address     string
+	Phone       string
+	Email       string
+	Footer      string
+	// Logo is a PNG or JPEG image, LogoType names which one
+	Logo     []byte
+	LogoType string
+}
+
+type Field struct {
+	Label string
+	Value string
+}
+
+type Column struct {
+	Title string
+	// Width is in millimeters, the columns of a table fill the 180 millimeters of an A4 page
+	Width float64
+	// Align is L, C or R
+	Align string
+}
+
+type Table struct {
+	Columns []Column
+	Rows    [][]string
+}
+
+// Document fills the template every document is rendered with: the branding and the title
+// head the page, the fields describe what the document is about, the table lists its lines,
+// and the signature boxes close it.
+type Document struct {
+	Title    string
+	Number   string
+	Date     time.Time
+	Branding Branding
+	Fields   []Field
+	Table    Table
+	Notes    string
+	// Barcode is printed as a Code 128 barcode next to the title when it is set
+	Barcode    string
+	Signatures []string
+}
+
+type RendererContract interface {
+	Render(document *Document) ([]byte, error)
+}
@@ -0,0 +1,211 @@
+package third_party
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/go-pdf/fpdf"
+	"inventory-management/backend/util"
+	"strings"
+)
+
+const (
+	pageMargin  = 15.0
+	lineHeight  = 5.0
+	rowHeight   = 7.0
+	footerSpace = 20.0
+)
+
+// FpdfRenderer renders documents on A4 pages with the core fonts of PDF, so no font has to be
+// shipped with the server.
+type FpdfRenderer struct{}
+
+func NewFpdfRenderer() RendererContract {
+	return &FpdfRenderer{}
+}
+
+func (renderer *FpdfRenderer) Render(document *Document) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pageMargin, pageMargin, pageMargin)
+	pdf.SetAutoPageBreak(true, footerSpace)
+	pdf.AliasNbPages("")
+	pdf.SetTitle(document.Title+" "+document.Number, true)
+	pdf.SetCreator(document.Branding.CompanyName, true)
+
+	// The core fonts are encoded in cp1252, not UTF-8
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-footerSpace + 5)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.SetTextColor(110, 110, 110)
+		if document.Branding.Footer != "" {
+			pdf.CellFormat(0, lineHeight, tr(document.Branding.Footer), "", 1, "C", false, 0, "")
+		}
+		pdf.CellFormat(0, lineHeight, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	pdf.AddPage()
+	if err := renderHeader(pdf, tr, document); err != nil {
+		return nil, err
+	}
+	renderFields(pdf, tr, document.Fields)
+	renderTable(pdf, tr, document.Table)
+
+	if document.Notes != "" {
+		pdf.Ln(lineHeight)
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.MultiCell(0, lineHeight, tr(document.Notes), "", "L", false)
+	}
+	renderSignatures(pdf, tr, document.Signatures)
+
+	var output bytes.Buffer
+	if err := pdf.Output(&output); err != nil {
+		return nil, err
+	}
+
+	return output.Bytes(), nil
+}
+
+// renderHeader prints the logo and the company on the left and the title, the number, the date
+// and the barcode on the right, above a rule.
+func renderHeader(pdf *fpdf.Fpdf, tr func(string) string, document *Document) error {
+	top := pdf.GetY()
+	left := pageMargin
+	branding := document.Branding
+
+	if len(branding.Logo) > 0 {
+		options := fpdf.ImageOptions{ImageType: branding.LogoType, ReadDpi: true}
+		pdf.RegisterImageOptionsReader("logo", options, bytes.NewReader(branding.Logo))
+		pdf.ImageOptions("logo", left, top, 0, 18, false, options, 0, "")
+		if pdf.Err() {
+			return pdf.Error()
+		}
+
+		info := pdf.GetImageInfo("logo")
+		left += info.Width()*18/info.Height() + 4
+	}
+
+	pdf.SetXY(left, top)
+	pdf.SetFont("Helvetica", "B", 13)
+	pdf.CellFormat(90, 7, tr(branding.CompanyName), "", 2, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 8)
+	for _, line := range []string{branding.Address, branding.Phone, branding.Email} {
+		if line != "" {
+			pdf.CellFormat(90, 4, tr(line), "", 2, "L", false, 0, "")
+		}
+	}
+	bottom := pdf.GetY()
+
+	pdf.SetXY(120, top)
+	pdf.SetFont("Helvetica", "B", 15)
+	pdf.CellFormat(75, 7, tr(strings.ToUpper(document.Title)), "", 2, "R", false, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	if document.Number != "" {
+		pdf.CellFormat(75, lineHeight, tr("No. "+document.Number), "", 2, "R", false, 0, "")
+	}
+	pdf.CellFormat(75, lineHeight, document.Date.Local().Format("2 January 2006 15:04"), "", 2, "R", false, 0, "")
+
+	if document.Barcode != "" {
+		image, err := barcodeImage(document.Barcode)
+		if err != nil {
+			return err
+		}
+
+		options := fpdf.ImageOptions{ImageType: "PNG"}
+		pdf.RegisterImageOptionsReader("barcode", options, bytes.NewReader(image))
+		pdf.ImageOptions("barcode", 145, pdf.GetY()+1, 50, 12, false, options, 0, "")
+		pdf.SetXY(145, pdf.GetY()+13)
+		pdf.SetFont("Courier", "", 8)
+		pdf.CellFormat(50, 4, document.Barcode, "", 2, "C", false, 0, "")
+	}
+
+	if pdf.GetY() > bottom {
+		bottom = pdf.GetY()
+	}
+	pdf.SetY(bottom + 3)
+	pdf.SetDrawColor(160, 160, 160)
+	pdf.Line(pageMargin, pdf.GetY(), 210-pageMargin, pdf.GetY())
+	pdf.Ln(4)
+
+	return pdf.Error()
+}
+
+func renderFields(pdf *fpdf.Fpdf, tr func(string) string, fields []Field) {
+	for _, field := range fields {
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.CellFormat(40, lineHeight+1, tr(field.Label), "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.MultiCell(0, lineHeight+1, tr(field.Value), "", "L", false)
+	}
+	if len(fields) > 0 {
+		pdf.Ln(3)
+	}
+}
+
+// renderTable prints the table with its header repeated on every page it runs over.
+func renderTable(pdf *fpdf.Fpdf, tr func(string) string, table Table) {
+	if len(table.Columns) == 0 {
+		return
+	}
+
+	header := func() {
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.SetFillColor(230, 230, 230)
+		for _, column := range table.Columns {
+			pdf.CellFormat(column.Width, rowHeight, tr(column.Title), "1", 0, column.Align, true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetFont("Helvetica", "", 9)
+	}
+
+	header()
+	_, pageHeight := pdf.GetPageSize()
+	for _, row := range table.Rows {
+		if pdf.GetY()+rowHeight > pageHeight-footerSpace {
+			pdf.AddPage()
+			header()
+		}
+
+		for i, column := range table.Columns {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			pdf.CellFormat(column.Width, rowHeight, tr(cell), "1", 0, column.Align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+}
+
+// renderSignatures prints a box for each party that signs the document, side by side.
+func renderSignatures(pdf *fpdf.Fpdf, tr func(string) string, signatures []string) {
+	if len(signatures) == 0 {
+		return
+	}
+
+	_, pageHeight := pdf.GetPageSize()
+	if pdf.GetY()+35 > pageHeight-footerSpace {
+		pdf.AddPage()
+	}
+
+	pdf.Ln(10)
+	width := (210 - 2*pageMargin) / float64(len(signatures))
+	top := pdf.GetY()
+	pdf.SetFont("Helvetica", "", 9)
+	for i, signature := range signatures {
+		x := pageMargin + float64(i)*width
+		pdf.SetXY(x, top)
+		pdf.CellFormat(width, lineHeight, tr(signature), "", 0, "C", false, 0, "")
+		pdf.Line(x+10, top+25, x+width-10, top+25)
+	}
+	pdf.SetY(top + 30)
+}
+
+func barcodeImage(value string) ([]byte, error) {
+	code, err := util.EncodeBarcode(util.BarcodeCode128, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return util.BarcodePNG(code, 4, 120)
+}
@@ -0,0 +1,73 @@
+package third_party
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func TestFpdfRenderer_Render(t *testing.T) {
+	var logo bytes.Buffer
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	img.Set(1, 1, color.Black)
+	assert.Nil(t, png.Encode(&logo, img))
+
+	document := func(rows int) *Document {
+		tableRows := make([][]string, rows)
+		for i := range tableRows {
+			tableRows[i] = []string{fmt.Sprint(i + 1), "Kangkung – Original", "2.5 kg"}
+		}
+
+		return &Document{
+			Title:  "Delivery note",
+			Number: "KKSJIDNA",
+			Date:   time.Date(2021, 1, 1, 7, 0, 0, 0, time.UTC),
+			Branding: Branding{
+				CompanyName: "Toko Sayur Widdy",
+				Address:     "Sukabumi",
+				Footer:      "Thank you",
+				Logo:        logo.Bytes(),
+				LogoType:    "PNG",
+			},
+			Fields: []Field{{Label: "Customer", Value: "Arfiansyah"}},
+			Table: Table{
+				Columns: []Column{{Title: "No", Width: 15, Align: "C"}, {Title: "Product", Width: 125, Align: "L"}, {Title: "Quantity", Width: 40, Align: "R"}},
+				Rows:    tableRows,
+			},
+			Barcode:    "KKSJIDNA",
+			Signatures: []string{"Delivered by", "Received by"},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		document      *Document
+		expectedPages int
+	}{
+		{name: "Document on one page", document: document(3), expectedPages: 1},
+		{name: "Table running over the next pages", document: document(80), expectedPages: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := NewFpdfRenderer().Render(tc.document)
+
+			assert.Nil(t, err)
+			assert.True(t, bytes.HasPrefix(output, []byte("%PDF-")))
+			assert.Regexp(t, fmt.Sprintf(`/Type /Pages\s+/Kids \[[^\]]*\]\s+/Count %d\s`, tc.expectedPages), string(output))
+		})
+	}
+
+	t.Run("Logo that is not an image", func(t *testing.T) {
+		invalid := document(1)
+		invalid.Branding.Logo = []byte("not an image")
+
+		_, err := NewFpdfRenderer().Render(invalid)
+		assert.NotNil(t, err)
+	})
+}
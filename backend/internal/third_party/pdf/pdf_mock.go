@@ -0,0 +1,18 @@
+package third_party
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type RendererMock struct {
+	mock.Mock
+}
+
+func (renderer *RendererMock) Render(document *Document) ([]byte, error) {
+	args := renderer.Called(document)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]byte), args.Error(1)
+}
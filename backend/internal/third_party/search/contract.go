@@ -0,0 +1,211 @@
+package third_party
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// SearchEngineContract stores search documents per index and searches them. Every engine scopes the
+// indices to the tenant of the context.
+type SearchEngineContract interface {
+	Index(ctx context.Context, index string, id int64, document interface{}) error
+	Remove(ctx context.Context, index string, id int64) error
+	Search(ctx context.Context, index string, query *Query, offset int, limit int) (*Result, error)
+	Rebuild(ctx context.Context, index string, load DocumentLoader) (*RebuildResult, error)
+	Aggregate(ctx context.Context, index string, aggregation *Aggregation) ([]*AggregationBucket, error)
+}
+
+const (
+	FilterTerm      = "term"
+	FilterGT        = "gt"
+	FilterGTE       = "gte"
+	FilterLTE       = "lte"
+	FilterDateRange = "date_range"
+)
+
+// ErrorRebuildCountMismatch is returned when a rebuilt index does not hold every loaded document,
+// the index is left as it was.
+const ErrorRebuildCountMismatch = "rebuilt index does not match the loaded documents"
+
+const ErrorAggregationWithoutGroup = "an aggregation groups by at least one field"
+
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+const facetSize = 20
+
+// MaxResultWindow is the deepest a search pages, offset plus limit, as Elasticsearch refuses to
+// page past index.max_result_window.
+const MaxResultWindow = 10000
+
+// aggregationSize is the most groups an aggregation returns per date.
+const aggregationSize = 1000
+
+// Query is a search independent of the engine running it. Field names are the fields of the
+// documents, they come from the services and never from a request.
+type Query struct {
+	// Text is matched against Fields, a boost such as "name^3" ranks a match in that field higher
+	Text   string
+	Fields []string
+	// Synonyms are other texts matched like Text, a document matching any of them matches
+	Synonyms []string
+	// PrefixFields are matched when they start with the text, ignoring case, such as codes
+	PrefixFields []string
+	Filters      []*Filter
+	Facets       []*Facet
+	Sort         []*Sort
+	// DefaultSort orders the hits by this field, newest first, when there is neither a text to
+	// rank them nor a requested sort
+	DefaultSort string
+}
+
+type Filter struct {
+	Field    string
+	Operator string
+	Value    interface{}
+	// From and To bound a date range filter, both are days and inclusive
+	From string
+	To   string
+}
+
+type Facet struct {
+	Name  string
+	Field string
+	// Month buckets a date field by month instead of by value
+	Month bool
+}
+
+type Sort struct {
+	Field      string
+	Descending bool
+}
+
+type Result struct {
+	Total  int64
+	Hits   []map[string]interface{}
+	Facets map[string][]*Bucket
+}
+
+type Bucket struct {
+	Value string
+	Count int64
+}
+
+// Aggregation counts the documents matching the filters and sums one of their fields per group.
+type Aggregation struct {
+	Filters []*Filter
+	// Interval buckets DateField by day, week or month in the server time zone before grouping, a
+	// week starts on Monday
+	DateField string
+	Interval  string
+	// GroupBy are the fields grouped by, documents missing one of them are left out
+	GroupBy []string
+	// Labels are fields returned with every group, taken from any of its documents, e.g. the name
+	// of a customer grouped by code
+	Labels []string
+	// Sum is the numeric field summed, the groups are ordered by their count without one
+	Sum string
+	// Size keeps the largest groups, it is ignored when bucketing by an interval
+	Size int
+}
+
+// AggregationBucket is a group of an aggregation, Keys and Labels follow the order of GroupBy and
+// Labels. Buckets are ordered by date, then by the largest sum and count.
+type AggregationBucket struct {
+	// Date is the first day of the interval, as 2006-01-02
+	Date   string
+	Keys   []string
+	Labels []string
+	Count  int64
+	Sum    float64
+}
+
+// Document is a document to write in bulk.
+type Document struct {
+	ID   int64
+	Body interface{}
+}
+
+// DocumentLoader returns the next documents of an index with an ID greater than afterID, in ID
+// order, and none once every document was returned.
+type DocumentLoader func(ctx context.Context, afterID int64) ([]*Document, error)
+
+type RebuildResult struct {
+	// Alias is the name the index is searched by and Index the one holding the documents
+	Alias          string
+	Index          string
+	Documents      int64
+	DeletedIndices []string
+}
+
+func TermFilter(field string, value interface{}) *Filter {
+	return &Filter{Field: field, Operator: FilterTerm, Value: value}
+}
+
+// RangeFilter compares a numeric field with the value, operator is one of FilterGT, FilterGTE or
+// FilterLTE.
+func RangeFilter(field string, operator string, value float64) *Filter {
+	return &Filter{Field: field, Operator: operator, Value: value}
+}
+
+// DateRangeFilter matches the days between from and to in the server time zone, either may be empty.
+func DateRangeFilter(field string, from string, to string) *Filter {
+	return &Filter{Field: field, Operator: FilterDateRange, From: from, To: to}
+}
+
+func TermsFacet(name string, field string) *Facet {
+	return &Facet{Name: name, Field: field}
+}
+
+func MonthFacet(name string, field string) *Facet {
+	return &Facet{Name: name, Field: field, Month: true}
+}
+
+// ParseSort turns a sort such as "-created_at" into a sort, a leading minus sorts descending.
+// fields maps the sortable names to the document fields, the caller validates the name.
+func ParseSort(sort string, fields map[string]string) []*Sort {
+	if sort == "" {
+		return nil
+	}
+
+	descending := strings.HasPrefix(sort, "-")
+	field, ok := fields[strings.TrimPrefix(sort, "-")]
+	if !ok {
+		return nil
+	}
+
+	return []*Sort{{Field: field, Descending: descending}}
+}
+
+// PickFacets picks the requested facets out of the ones a search offers.
+func PickFacets(requested []string, facets []*Facet) []*Facet {
+	var picked []*Facet
+	for _, name := range requested {
+		for _, facet := range facets {
+			if facet.Name == name {
+				picked = append(picked, facet)
+			}
+		}
+	}
+
+	return picked
+}
+
+// fieldBoost splits a field such as "name^3" into the field and its boost.
+func fieldBoost(field string) (string, float64) {
+	name, boost, found := strings.Cut(field, "^")
+	if !found {
+		return field, 1
+	}
+
+	value, err := strconv.ParseFloat(boost, 64)
+	if err != nil {
+		return name, 1
+	}
+
+	return name, value
+}
@@ -0,0 +1,594 @@
+package third_party
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	elasticsearch "inventory-management/backend/internal/third_party/elasticsearch"
+	"inventory-management/backend/util"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ElasticsearchEngine keeps every index of a tenant as versions behind an alias, so a rebuild can
+// fill a new version and swap it in. Until then the new version receives every write as well.
+type ElasticsearchEngine struct {
+	Elasticsearch elasticsearch.ElasticsearchContract
+	// Mappings holds the settings and mappings every index is created with
+	Mappings        map[string]string
+	keywordFields   map[string]map[string]bool
+	preparedIndices sync.Map
+}
+
+func NewElasticsearchEngine(es elasticsearch.ElasticsearchContract, mappings map[string]string) SearchEngineContract {
+	return &ElasticsearchEngine{
+		Elasticsearch: es,
+		Mappings:      mappings,
+		keywordFields: keywordFields(mappings),
+	}
+}
+
+// Index writes the document to the index of the context tenant, creating the index with its
+// mapping the first time the tenant writes to it.
+func (engine *ElasticsearchEngine) Index(ctx context.Context, index string, id int64, document interface{}) error {
+	alias := util.TenantIndex(ctx, index)
+	err := engine.prepare(ctx, index, alias)
+	if err != nil {
+		return err
+	}
+
+	err = engine.Elasticsearch.Update(ctx, alias, document, id)
+	if err != nil {
+		return err
+	}
+
+	reindexing, err := engine.Elasticsearch.AliasIndices(ctx, reindexAlias(alias))
+	if err != nil {
+		return err
+	}
+	for _, version := range reindexing {
+		err = engine.Elasticsearch.Update(ctx, version, document, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (engine *ElasticsearchEngine) Remove(ctx context.Context, index string, id int64) error {
+	alias := util.TenantIndex(ctx, index)
+	err := engine.Elasticsearch.Delete(ctx, alias, id)
+	if err != nil {
+		return err
+	}
+
+	reindexing, err := engine.Elasticsearch.AliasIndices(ctx, reindexAlias(alias))
+	if err != nil {
+		return err
+	}
+	for _, version := range reindexing {
+		err = engine.Elasticsearch.Delete(ctx, version, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (engine *ElasticsearchEngine) Search(ctx context.Context, index string, query *Query, offset int, limit int) (*Result, error) {
+	must := []interface{}{}
+	if query.Text != "" {
+		must = append(must, elasticsearchText(query))
+	}
+
+	filter := []interface{}{}
+	for _, queryFilter := range query.Filters {
+		filter = append(filter, elasticsearchFilter(queryFilter))
+	}
+
+	aggregations := map[string]interface{}{}
+	for _, facet := range query.Facets {
+		aggregations[facet.Name] = elasticsearchFacet(facet)
+	}
+
+	body := map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must":   must,
+				"filter": filter,
+			},
+		},
+		"aggs":             aggregations,
+		"track_total_hits": true,
+	}
+
+	var sort []interface{}
+	for _, querySort := range query.Sort {
+		order := "asc"
+		if querySort.Descending {
+			order = "desc"
+		}
+		sort = append(sort, map[string]interface{}{engine.sortField(index, querySort.Field): order})
+	}
+	if len(sort) > 0 {
+		body["sort"] = sort
+	} else if query.Text == "" && query.DefaultSort != "" {
+		body["sort"] = []interface{}{map[string]interface{}{query.DefaultSort: "desc"}}
+	}
+
+	var data bytes.Buffer
+	err := json.NewEncoder(&data).Encode(body)
+	if err != nil {
+		return nil, err
+	}
+
+	searchResponse, err := engine.Elasticsearch.Search(ctx, util.TenantIndex(ctx, index), data, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSearchResponse(searchResponse)
+}
+
+func (engine *ElasticsearchEngine) Aggregate(ctx context.Context, index string, aggregation *Aggregation) ([]*AggregationBucket, error) {
+	if len(aggregation.GroupBy) == 0 {
+		return nil, errors.New(ErrorAggregationWithoutGroup)
+	}
+
+	filter := []interface{}{}
+	for _, aggregationFilter := range aggregation.Filters {
+		filter = append(filter, elasticsearchFilter(aggregationFilter))
+	}
+
+	groups := elasticsearchGroups(aggregation)
+	aggregations := map[string]interface{}{"groups": groups}
+	if aggregation.Interval != "" {
+		aggregations = map[string]interface{}{
+			"dates": map[string]interface{}{
+				"date_histogram": map[string]interface{}{
+					"field":             aggregation.DateField,
+					"calendar_interval": aggregation.Interval,
+					"time_zone":         time.Now().Format("-07:00"),
+					"format":            "yyyy-MM-dd",
+					"min_doc_count":     1,
+				},
+				"aggs": aggregations,
+			},
+		}
+	}
+
+	body := map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": filter,
+			},
+		},
+		"aggs": aggregations,
+	}
+
+	var data bytes.Buffer
+	err := json.NewEncoder(&data).Encode(body)
+	if err != nil {
+		return nil, err
+	}
+
+	searchResponse, err := engine.Elasticsearch.Search(ctx, util.TenantIndex(ctx, index), data, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAggregationResponse(searchResponse, aggregation)
+}
+
+// Rebuild fills a new version of the index of the context tenant and swaps the alias over to it
+// once its document count matches what was loaded. Changes made meanwhile reach the new version
+// through its reindex alias, and the bulk load never overwrites them.
+func (engine *ElasticsearchEngine) Rebuild(ctx context.Context, index string, load DocumentLoader) (*RebuildResult, error) {
+	alias := util.TenantIndex(ctx, index)
+	version := indexVersion(alias, time.Now().UTC().Format("20060102150405"))
+	err := engine.Elasticsearch.CreateIndex(ctx, version, engine.indexBody(index, reindexAlias(alias)))
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := engine.load(ctx, version, load)
+	if err != nil {
+		engine.discard(ctx, version)
+		return nil, err
+	}
+
+	err = engine.Elasticsearch.Refresh(ctx, version)
+	if err != nil {
+		engine.discard(ctx, version)
+		return nil, err
+	}
+
+	count, err := engine.Elasticsearch.CountAll(ctx, version, bytes.Buffer{})
+	if err != nil {
+		engine.discard(ctx, version)
+		return nil, err
+	}
+	if count != documents {
+		log.Printf("Rebuilding %s loaded %d documents but %s has %d\n", alias, documents, version, count)
+		engine.discard(ctx, version)
+		return nil, errors.New(ErrorRebuildCountMismatch)
+	}
+
+	previous, err := engine.Elasticsearch.SwapAlias(ctx, alias, version)
+	if err != nil {
+		engine.discard(ctx, version)
+		return nil, err
+	}
+
+	err = engine.Elasticsearch.RemoveAlias(ctx, version, reindexAlias(alias))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, previousVersion := range previous {
+		err = engine.Elasticsearch.DeleteIndex(ctx, previousVersion)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &RebuildResult{
+		Alias:          alias,
+		Index:          version,
+		Documents:      documents,
+		DeletedIndices: previous,
+	}, nil
+}
+
+// load copies every loaded document into the version and returns how many it loaded.
+func (engine *ElasticsearchEngine) load(ctx context.Context, version string, load DocumentLoader) (int64, error) {
+	var loaded int64
+	var afterID int64
+	for {
+		documents, err := load(ctx, afterID)
+		if err != nil {
+			return loaded, err
+		}
+		if len(documents) == 0 {
+			return loaded, nil
+		}
+
+		var batch []*elasticsearch.Document
+		for _, document := range documents {
+			batch = append(batch, &elasticsearch.Document{ID: document.ID, Body: document.Body})
+		}
+
+		err = engine.Elasticsearch.BulkCreate(ctx, version, batch)
+		if err != nil {
+			return loaded, err
+		}
+
+		loaded += int64(len(documents))
+		afterID = documents[len(documents)-1].ID
+	}
+}
+
+// discard deletes a version that will not be swapped in, the alias keeps pointing to the old one.
+func (engine *ElasticsearchEngine) discard(ctx context.Context, version string) {
+	err := engine.Elasticsearch.DeleteIndex(ctx, version)
+	if err != nil {
+		log.Printf("Cannot delete the search index %s: %v\n", version, err)
+	}
+}
+
+func (engine *ElasticsearchEngine) prepare(ctx context.Context, index string, alias string) error {
+	if _, ok := engine.preparedIndices.Load(alias); ok {
+		return nil
+	}
+
+	exists, err := engine.Elasticsearch.IndexExists(ctx, alias)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		err = engine.Elasticsearch.CreateIndex(ctx, indexVersion(alias, "1"), engine.indexBody(index, alias))
+		if err != nil {
+			return err
+		}
+	}
+
+	engine.preparedIndices.Store(alias, true)
+	return nil
+}
+
+// indexBody returns the settings and mappings of the index with the alias attached.
+func (engine *ElasticsearchEngine) indexBody(index string, alias string) string {
+	mapping := strings.TrimSpace(engine.Mappings[index])
+	return `{"aliases": {` + strconv.Quote(alias) + `: {}}, ` + strings.TrimPrefix(mapping, "{")
+}
+
+// sortField sorts text fields by their keyword sub-field, a text field cannot be sorted on.
+func (engine *ElasticsearchEngine) sortField(index string, field string) string {
+	if engine.keywordFields[index][field] {
+		return field + ".keyword"
+	}
+
+	return field
+}
+
+// indexVersion names a version of the index behind the alias.
+func indexVersion(alias string, version string) string {
+	return alias + "_v" + version
+}
+
+// reindexAlias names the alias of the version a rebuild is filling.
+func reindexAlias(alias string) string {
+	return alias + "_reindex"
+}
+
+// keywordFields finds the text fields of every mapping that have a keyword sub-field.
+func keywordFields(mappings map[string]string) map[string]map[string]bool {
+	fields := map[string]map[string]bool{}
+	for index, mapping := range mappings {
+		var parsed struct {
+			Mappings struct {
+				Properties map[string]struct {
+					Type   string                 `json:"type"`
+					Fields map[string]interface{} `json:"fields"`
+				} `json:"properties"`
+			} `json:"mappings"`
+		}
+		err := json.Unmarshal([]byte(mapping), &parsed)
+		if err != nil {
+			log.Fatalln("Invalid search mapping of", index, err)
+		}
+
+		fields[index] = map[string]bool{}
+		for name, property := range parsed.Mappings.Properties {
+			if _, ok := property.Fields["keyword"]; ok && property.Type == "text" {
+				fields[index][name] = true
+			}
+		}
+	}
+
+	return fields
+}
+
+// elasticsearchText matches the text and its synonyms against the fields, and the fields matched by
+// prefix with the text.
+func elasticsearchText(query *Query) map[string]interface{} {
+	var should []interface{}
+	if len(query.Fields) > 0 {
+		for _, text := range append([]string{query.Text}, query.Synonyms...) {
+			should = append(should, map[string]interface{}{
+				"multi_match": map[string]interface{}{
+					"query":     text,
+					"fields":    query.Fields,
+					"fuzziness": "AUTO",
+					"lenient":   true,
+				},
+			})
+		}
+	}
+	for _, field := range query.PrefixFields {
+		should = append(should, map[string]interface{}{
+			"prefix": map[string]interface{}{
+				field: map[string]interface{}{"value": query.Text, "case_insensitive": true},
+			},
+		})
+	}
+
+	if len(should) == 1 {
+		return should[0].(map[string]interface{})
+	}
+
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should":               should,
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+// elasticsearchGroups groups by a single field with a terms aggregation and by several with
+// multi_terms, summing and picking the labels in every group.
+func elasticsearchGroups(aggregation *Aggregation) map[string]interface{} {
+	size := aggregation.Size
+	if size == 0 || aggregation.Interval != "" {
+		size = aggregationSize
+	}
+
+	subAggregations := map[string]interface{}{}
+	order := map[string]interface{}{"_count": "desc"}
+	if aggregation.Sum != "" {
+		subAggregations["sum"] = map[string]interface{}{"sum": map[string]interface{}{"field": aggregation.Sum}}
+		order = map[string]interface{}{"sum": "desc"}
+	}
+	if len(aggregation.Labels) > 0 {
+		subAggregations["labels"] = map[string]interface{}{
+			"top_hits": map[string]interface{}{"size": 1, "_source": map[string]interface{}{"includes": aggregation.Labels}},
+		}
+	}
+
+	if len(aggregation.GroupBy) == 1 {
+		return map[string]interface{}{
+			"terms": map[string]interface{}{"field": aggregation.GroupBy[0], "size": size, "order": order},
+			"aggs":  subAggregations,
+		}
+	}
+
+	var terms []interface{}
+	for _, field := range aggregation.GroupBy {
+		terms = append(terms, map[string]interface{}{"field": field})
+	}
+
+	return map[string]interface{}{
+		"multi_terms": map[string]interface{}{"terms": terms, "size": size, "order": order},
+		"aggs":        subAggregations,
+	}
+}
+
+func elasticsearchFilter(filter *Filter) map[string]interface{} {
+	switch filter.Operator {
+	case FilterTerm:
+		return map[string]interface{}{"term": map[string]interface{}{filter.Field: filter.Value}}
+	case FilterDateRange:
+		bounds := map[string]interface{}{
+			"format":    "yyyy-MM-dd",
+			"time_zone": time.Now().Format("-07:00"),
+		}
+		if filter.From != "" {
+			bounds["gte"] = filter.From
+		}
+		if filter.To != "" {
+			// Rounded up, so the whole last day is included
+			bounds["lte"] = filter.To + "||/d"
+		}
+		return map[string]interface{}{"range": map[string]interface{}{filter.Field: bounds}}
+	default:
+		return map[string]interface{}{"range": map[string]interface{}{filter.Field: map[string]interface{}{filter.Operator: filter.Value}}}
+	}
+}
+
+func elasticsearchFacet(facet *Facet) map[string]interface{} {
+	if facet.Month {
+		return map[string]interface{}{"date_histogram": map[string]interface{}{"field": facet.Field, "calendar_interval": "month", "format": "yyyy-MM"}}
+	}
+
+	return map[string]interface{}{"terms": map[string]interface{}{"field": facet.Field, "size": facetSize}}
+}
+
+func parseSearchResponse(searchResponse map[string]interface{}) (*Result, error) {
+	result := &Result{
+		Hits:   []map[string]interface{}{},
+		Facets: map[string][]*Bucket{},
+	}
+
+	if searchError, ok := searchResponse["error"].(map[string]interface{}); ok {
+		// Indices are created on the first write, a tenant without documents has none yet
+		if searchError["type"] == "index_not_found_exception" {
+			return result, nil
+		}
+		return nil, errors.New(fmt.Sprint(searchError["reason"]))
+	}
+
+	hits, _ := searchResponse["hits"].(map[string]interface{})
+	if total, ok := hits["total"].(map[string]interface{}); ok {
+		value, _ := total["value"].(float64)
+		result.Total = int64(value)
+	}
+
+	hitList, _ := hits["hits"].([]interface{})
+	for _, hit := range hitList {
+		hitMap, _ := hit.(map[string]interface{})
+		if source, ok := hitMap["_source"].(map[string]interface{}); ok {
+			result.Hits = append(result.Hits, source)
+		}
+	}
+
+	aggregations, _ := searchResponse["aggregations"].(map[string]interface{})
+	for name, aggregation := range aggregations {
+		aggregationMap, _ := aggregation.(map[string]interface{})
+		buckets, _ := aggregationMap["buckets"].([]interface{})
+
+		result.Facets[name] = []*Bucket{}
+		for _, bucket := range buckets {
+			bucketMap, _ := bucket.(map[string]interface{})
+			count, _ := bucketMap["doc_count"].(float64)
+			result.Facets[name] = append(result.Facets[name], &Bucket{
+				Value: bucketValue(bucketMap),
+				Count: int64(count),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func bucketValue(bucket map[string]interface{}) string {
+	if keyAsString, ok := bucket["key_as_string"].(string); ok {
+		return keyAsString
+	}
+
+	switch key := bucket["key"].(type) {
+	case string:
+		return key
+	case float64:
+		return strconv.FormatFloat(key, 'f', -1, 64)
+	default:
+		return fmt.Sprint(key)
+	}
+}
+
+func parseAggregationResponse(searchResponse map[string]interface{}, aggregation *Aggregation) ([]*AggregationBucket, error) {
+	buckets := []*AggregationBucket{}
+	if searchError, ok := searchResponse["error"].(map[string]interface{}); ok {
+		if searchError["type"] == "index_not_found_exception" {
+			return buckets, nil
+		}
+		return nil, errors.New(fmt.Sprint(searchError["reason"]))
+	}
+
+	aggregations, _ := searchResponse["aggregations"].(map[string]interface{})
+	if aggregation.Interval == "" {
+		return appendAggregationBuckets(buckets, "", aggregations["groups"], aggregation), nil
+	}
+
+	dates, _ := aggregations["dates"].(map[string]interface{})
+	dateBuckets, _ := dates["buckets"].([]interface{})
+	for _, dateBucket := range dateBuckets {
+		dateBucketMap, _ := dateBucket.(map[string]interface{})
+		date, _ := dateBucketMap["key_as_string"].(string)
+		buckets = appendAggregationBuckets(buckets, date, dateBucketMap["groups"], aggregation)
+	}
+
+	return buckets, nil
+}
+
+func appendAggregationBuckets(buckets []*AggregationBucket, date string, groups interface{}, aggregation *Aggregation) []*AggregationBucket {
+	groupsMap, _ := groups.(map[string]interface{})
+	groupBuckets, _ := groupsMap["buckets"].([]interface{})
+	for _, groupBucket := range groupBuckets {
+		groupBucketMap, _ := groupBucket.(map[string]interface{})
+		count, _ := groupBucketMap["doc_count"].(float64)
+		bucket := &AggregationBucket{
+			Date:  date,
+			Count: int64(count),
+		}
+
+		// A multi_terms key is the list of the values of its fields
+		if keys, ok := groupBucketMap["key"].([]interface{}); ok {
+			for _, key := range keys {
+				bucket.Keys = append(bucket.Keys, bucketValue(map[string]interface{}{"key": key}))
+			}
+		} else {
+			bucket.Keys = []string{bucketValue(map[string]interface{}{"key": groupBucketMap["key"]})}
+		}
+
+		if sum, ok := groupBucketMap["sum"].(map[string]interface{}); ok {
+			bucket.Sum, _ = sum["value"].(float64)
+		}
+
+		labels, _ := groupBucketMap["labels"].(map[string]interface{})
+		labelHits, _ := labels["hits"].(map[string]interface{})
+		labelHitList, _ := labelHits["hits"].([]interface{})
+		var source map[string]interface{}
+		if len(labelHitList) > 0 {
+			labelHit, _ := labelHitList[0].(map[string]interface{})
+			source, _ = labelHit["_source"].(map[string]interface{})
+		}
+		for _, field := range aggregation.Labels {
+			label := ""
+			if source[field] != nil {
+				label = fmt.Sprint(source[field])
+			}
+			bucket.Labels = append(bucket.Labels, label)
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets
+}
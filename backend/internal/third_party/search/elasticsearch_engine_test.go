@@ -0,0 +1,434 @@
+package third_party
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	elasticsearch "inventory-management/backend/internal/third_party/elasticsearch"
+	"inventory-management/backend/util"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testMappings = map[string]string{
+	"suppliers": `{
+  "settings": {"number_of_shards": 1},
+  "mappings": {
+    "properties": {
+      "id": {"type": "long"},
+      "name": {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
+      "phone": {"type": "keyword"}
+    }
+  }
+}`,
+	"users": `{
+  "mappings": {
+    "properties": {
+      "name": {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
+      "role": {"type": "keyword"},
+      "created_at": {"type": "date"}
+    }
+  }
+}`,
+}
+
+func TestElasticsearchEngine_Index(t *testing.T) {
+	testCases := []struct {
+		name               string
+		ctx                context.Context
+		expectedAlias      string
+		expectedReindexing []string
+	}{
+		{
+			name:          "Index a document of the default tenant",
+			ctx:           context.Background(),
+			expectedAlias: "suppliers",
+		},
+		{
+			name:          "Index a document of another tenant",
+			ctx:           util.WithTenant(context.Background(), 2),
+			expectedAlias: "tenant-2-suppliers",
+		},
+		{
+			name:               "Index a document while it is being reindexed",
+			ctx:                context.Background(),
+			expectedAlias:      "suppliers",
+			expectedReindexing: []string{"suppliers_v20230601120000"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			document := map[string]interface{}{"id": 1, "name": "Widdy Arfiansyah"}
+
+			var es elasticsearch.ElasticsearchMock
+			es.On("IndexExists", tc.ctx, tc.expectedAlias).Return(false, nil)
+			es.On("CreateIndex", tc.ctx, tc.expectedAlias+"_v1", mock.Anything).Return(nil)
+			es.On("Update", tc.ctx, tc.expectedAlias, document, int64(1)).Return(nil)
+			es.On("AliasIndices", tc.ctx, tc.expectedAlias+"_reindex").Return(tc.expectedReindexing, nil)
+			for _, version := range tc.expectedReindexing {
+				es.On("Update", tc.ctx, version, document, int64(1)).Return(nil)
+			}
+			engine := NewElasticsearchEngine(&es, testMappings)
+
+			// The index is only created on the first write
+			for i := 0; i < 2; i++ {
+				err := engine.Index(tc.ctx, "suppliers", 1, document)
+				assert.NoError(t, err)
+			}
+
+			es.AssertNumberOfCalls(t, "IndexExists", 1)
+			es.AssertNumberOfCalls(t, "CreateIndex", 1)
+			es.AssertNumberOfCalls(t, "Update", 2*(1+len(tc.expectedReindexing)))
+		})
+	}
+}
+
+func TestElasticsearchEngine_Search(t *testing.T) {
+	testCases := []struct {
+		name             string
+		query            *Query
+		expectedBody     string
+		expectedEsSearch map[string]interface{}
+		expectedEsError  error
+		expectedResult   *Result
+		expectedError    error
+	}{
+		{
+			name: "Hits and facets of a query sorted by a text field",
+			query: &Query{
+				Text:    "widdy",
+				Fields:  []string{"name^3", "username^2", "email"},
+				Filters: []*Filter{TermFilter("role", "admin")},
+				Facets:  []*Facet{TermsFacet("role", "role")},
+				Sort:    []*Sort{{Field: "name", Descending: true}},
+			},
+			expectedBody: `{"aggs":{"role":{"terms":{"field":"role","size":20}}},"query":{"bool":{"filter":[{"term":{"role":"admin"}}],"must":[{"multi_match":{"fields":["name^3","username^2","email"],"fuzziness":"AUTO","lenient":true,"query":"widdy"}}]}},"sort":[{"name.keyword":"desc"}],"track_total_hits":true}`,
+			expectedEsSearch: map[string]interface{}{
+				"hits": map[string]interface{}{
+					"total": map[string]interface{}{"value": float64(1)},
+					"hits": []interface{}{
+						map[string]interface{}{"_source": map[string]interface{}{"name": "Widdy Arfiansyah", "role": "admin"}},
+					},
+				},
+				"aggregations": map[string]interface{}{
+					"role": map[string]interface{}{
+						"buckets": []interface{}{
+							map[string]interface{}{"key": "admin", "doc_count": float64(1)},
+						},
+					},
+				},
+			},
+			expectedResult: &Result{
+				Total: 1,
+				Hits: []map[string]interface{}{
+					{"name": "Widdy Arfiansyah", "role": "admin"},
+				},
+				Facets: map[string][]*Bucket{
+					"role": {{Value: "admin", Count: 1}},
+				},
+			},
+		},
+		{
+			name:         "Newest documents first without a query",
+			query:        &Query{Facets: []*Facet{MonthFacet("created_month", "created_at")}, DefaultSort: "created_at"},
+			expectedBody: `{"aggs":{"created_month":{"date_histogram":{"calendar_interval":"month","field":"created_at","format":"yyyy-MM"}}},"query":{"bool":{"filter":[],"must":[]}},"sort":[{"created_at":"desc"}],"track_total_hits":true}`,
+			expectedEsSearch: map[string]interface{}{
+				"hits": map[string]interface{}{
+					"total": map[string]interface{}{"value": float64(0)},
+					"hits":  []interface{}{},
+				},
+				"aggregations": map[string]interface{}{
+					"created_month": map[string]interface{}{
+						"buckets": []interface{}{
+							map[string]interface{}{"key_as_string": "2021-01", "key": float64(1609459200000), "doc_count": float64(0)},
+						},
+					},
+				},
+			},
+			expectedResult: &Result{
+				Hits: []map[string]interface{}{},
+				Facets: map[string][]*Bucket{
+					"created_month": {{Value: "2021-01", Count: 0}},
+				},
+			},
+		},
+		{
+			name: "Text with synonyms or a prefix of a code",
+			query: &Query{
+				Text:         "widy",
+				Fields:       []string{"name^3"},
+				Synonyms:     []string{"arfiansyah"},
+				PrefixFields: []string{"username"},
+			},
+			expectedBody: `{"aggs":{},"query":{"bool":{"filter":[],"must":[{"bool":{"minimum_should_match":1,"should":[{"multi_match":{"fields":["name^3"],"fuzziness":"AUTO","lenient":true,"query":"widy"}},{"multi_match":{"fields":["name^3"],"fuzziness":"AUTO","lenient":true,"query":"arfiansyah"}},{"prefix":{"username":{"case_insensitive":true,"value":"widy"}}}]}}]}},"track_total_hits":true}`,
+			expectedEsSearch: map[string]interface{}{
+				"hits": map[string]interface{}{
+					"total": map[string]interface{}{"value": float64(0)},
+					"hits":  []interface{}{},
+				},
+			},
+			expectedResult: &Result{
+				Hits:   []map[string]interface{}{},
+				Facets: map[string][]*Bucket{},
+			},
+		},
+		{
+			name: "Tenant has no index yet",
+			query: &Query{
+				Filters: []*Filter{RangeFilter("id", FilterGT, 0)},
+			},
+			expectedBody: `{"aggs":{},"query":{"bool":{"filter":[{"range":{"id":{"gt":0}}}],"must":[]}},"track_total_hits":true}`,
+			expectedEsSearch: map[string]interface{}{
+				"error": map[string]interface{}{"type": "index_not_found_exception", "reason": "no such index [users]"},
+			},
+			expectedResult: &Result{
+				Hits:   []map[string]interface{}{},
+				Facets: map[string][]*Bucket{},
+			},
+		},
+		{
+			name:         "Malformed query",
+			query:        &Query{},
+			expectedBody: `{"aggs":{},"query":{"bool":{"filter":[],"must":[]}},"track_total_hits":true}`,
+			expectedEsSearch: map[string]interface{}{
+				"error": map[string]interface{}{"type": "parsing_exception", "reason": "unknown query"},
+			},
+			expectedError: errors.New("unknown query"),
+		},
+		{
+			name:            "Elasticsearch is unreachable",
+			query:           &Query{},
+			expectedBody:    `{"aggs":{},"query":{"bool":{"filter":[],"must":[]}},"track_total_hits":true}`,
+			expectedEsError: errors.New("connection refused"),
+			expectedError:   errors.New("connection refused"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var es elasticsearch.ElasticsearchMock
+			es.On("Search", ctx, "users", mock.MatchedBy(func(data bytes.Buffer) bool {
+				return strings.TrimSpace(data.String()) == tc.expectedBody
+			}), 0, 10).Return(tc.expectedEsSearch, tc.expectedEsError)
+			engine := NewElasticsearchEngine(&es, testMappings)
+
+			result, err := engine.Search(ctx, "users", tc.query, 0, 10)
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			}
+
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestElasticsearchEngine_Aggregate(t *testing.T) {
+	timeZone := time.Now().Format("-07:00")
+
+	testCases := []struct {
+		name             string
+		aggregation      *Aggregation
+		expectedBody     string
+		expectedEsSearch map[string]interface{}
+		expectedEsError  error
+		expectedBuckets  []*AggregationBucket
+		expectedError    error
+	}{
+		{
+			name:         "Largest groups with their labels",
+			aggregation:  &Aggregation{Filters: []*Filter{TermFilter("type", "OUT")}, GroupBy: []string{"customer_code"}, Labels: []string{"customer_name"}, Sum: "quantity", Size: 5},
+			expectedBody: `{"aggs":{"groups":{"aggs":{"labels":{"top_hits":{"_source":{"includes":["customer_name"]},"size":1}},"sum":{"sum":{"field":"quantity"}}},"terms":{"field":"customer_code","order":{"sum":"desc"},"size":5}}},"query":{"bool":{"filter":[{"term":{"type":"OUT"}}]}}}`,
+			expectedEsSearch: map[string]interface{}{
+				"aggregations": map[string]interface{}{
+					"groups": map[string]interface{}{
+						"buckets": []interface{}{
+							map[string]interface{}{
+								"key":       "CUSTOMER01",
+								"doc_count": float64(2),
+								"sum":       map[string]interface{}{"value": float64(5)},
+								"labels": map[string]interface{}{
+									"hits": map[string]interface{}{
+										"hits": []interface{}{
+											map[string]interface{}{"_source": map[string]interface{}{"customer_name": "Widdy"}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedBuckets: []*AggregationBucket{
+				{Keys: []string{"CUSTOMER01"}, Labels: []string{"Widdy"}, Count: 2, Sum: 5},
+			},
+		},
+		{
+			name:         "Several fields grouped per week",
+			aggregation:  &Aggregation{DateField: "created_at", Interval: IntervalWeek, GroupBy: []string{"type", "product_quality_id"}},
+			expectedBody: `{"aggs":{"dates":{"aggs":{"groups":{"aggs":{},"multi_terms":{"order":{"_count":"desc"},"size":1000,"terms":[{"field":"type"},{"field":"product_quality_id"}]}}},"date_histogram":{"calendar_interval":"week","field":"created_at","format":"yyyy-MM-dd","min_doc_count":1,"time_zone":"` + timeZone + `"}}},"query":{"bool":{"filter":[]}}}`,
+			expectedEsSearch: map[string]interface{}{
+				"aggregations": map[string]interface{}{
+					"dates": map[string]interface{}{
+						"buckets": []interface{}{
+							map[string]interface{}{
+								"key_as_string": "2021-01-04",
+								"groups": map[string]interface{}{
+									"buckets": []interface{}{
+										map[string]interface{}{"key": []interface{}{"OUT", float64(2)}, "doc_count": float64(3)},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedBuckets: []*AggregationBucket{
+				{Date: "2021-01-04", Keys: []string{"OUT", "2"}, Count: 3},
+			},
+		},
+		{
+			name:         "Tenant has no index yet",
+			aggregation:  &Aggregation{GroupBy: []string{"type"}},
+			expectedBody: `{"aggs":{"groups":{"aggs":{},"terms":{"field":"type","order":{"_count":"desc"},"size":1000}}},"query":{"bool":{"filter":[]}}}`,
+			expectedEsSearch: map[string]interface{}{
+				"error": map[string]interface{}{"type": "index_not_found_exception", "reason": "no such index [transactions]"},
+			},
+			expectedBuckets: []*AggregationBucket{},
+		},
+		{
+			name:            "Elasticsearch is unreachable",
+			aggregation:     &Aggregation{GroupBy: []string{"type"}},
+			expectedBody:    `{"aggs":{"groups":{"aggs":{},"terms":{"field":"type","order":{"_count":"desc"},"size":1000}}},"query":{"bool":{"filter":[]}}}`,
+			expectedEsError: errors.New("connection refused"),
+			expectedError:   errors.New("connection refused"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var es elasticsearch.ElasticsearchMock
+			es.On("Search", ctx, "transactions", mock.MatchedBy(func(data bytes.Buffer) bool {
+				return strings.TrimSpace(data.String()) == tc.expectedBody
+			}), 0, 0).Return(tc.expectedEsSearch, tc.expectedEsError)
+			engine := NewElasticsearchEngine(&es, testMappings)
+
+			buckets, err := engine.Aggregate(ctx, "transactions", tc.aggregation)
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			}
+
+			assert.Equal(t, tc.expectedBuckets, buckets)
+		})
+	}
+}
+
+func TestElasticsearchEngine_Rebuild(t *testing.T) {
+	documents := []*Document{
+		{ID: 1, Body: map[string]interface{}{"name": "Widdy Arfiansyah"}},
+		{ID: 2, Body: map[string]interface{}{"name": "Angga Saputra"}},
+	}
+
+	testCases := []struct {
+		name                     string
+		expectedLoadError        error
+		expectedEsCount          int64
+		expectedEsAliasIndices   []string
+		expectedResult           *RebuildResult
+		expectedError            error
+		expectedVersionDiscarded bool
+		expectedEsSwapNumCalls   int
+	}{
+		{
+			name:                   "Rebuild an index",
+			expectedEsCount:        2,
+			expectedEsAliasIndices: []string{"suppliers_v1"},
+			expectedResult: &RebuildResult{
+				Alias:          "suppliers",
+				Documents:      2,
+				DeletedIndices: []string{"suppliers_v1"},
+			},
+			expectedEsSwapNumCalls: 1,
+		},
+		{
+			name:                     "Document count does not match",
+			expectedEsCount:          3,
+			expectedError:            errors.New(ErrorRebuildCountMismatch),
+			expectedVersionDiscarded: true,
+		},
+		{
+			name:                     "Loading the documents fails",
+			expectedLoadError:        errors.New("connection refused"),
+			expectedError:            errors.New("connection refused"),
+			expectedVersionDiscarded: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			version := mock.MatchedBy(func(index string) bool {
+				return strings.HasPrefix(index, "suppliers_v2")
+			})
+
+			var es elasticsearch.ElasticsearchMock
+			es.On("CreateIndex", ctx, version, mock.Anything).Return(nil)
+			es.On("BulkCreate", ctx, version, mock.Anything).Return(nil)
+			es.On("Refresh", ctx, version).Return(nil)
+			es.On("CountAll", ctx, version, mock.Anything).Return(tc.expectedEsCount, nil)
+			es.On("SwapAlias", ctx, "suppliers", version).Return(tc.expectedEsAliasIndices, nil)
+			es.On("RemoveAlias", ctx, version, "suppliers_reindex").Return(nil)
+			es.On("DeleteIndex", ctx, mock.Anything).Return(nil)
+			engine := NewElasticsearchEngine(&es, testMappings)
+
+			result, err := engine.Rebuild(ctx, "suppliers", func(ctx context.Context, afterID int64) ([]*Document, error) {
+				if afterID > 0 {
+					return nil, nil
+				}
+				return documents, tc.expectedLoadError
+			})
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, strings.HasPrefix(result.Index, "suppliers_v2"))
+				tc.expectedResult.Index = result.Index
+				assert.Equal(t, tc.expectedResult, result)
+				es.AssertCalled(t, "BulkCreate", ctx, result.Index, []*elasticsearch.Document{
+					{ID: 1, Body: documents[0].Body},
+					{ID: 2, Body: documents[1].Body},
+				})
+				es.AssertCalled(t, "DeleteIndex", ctx, "suppliers_v1")
+			}
+
+			if tc.expectedVersionDiscarded {
+				es.AssertCalled(t, "DeleteIndex", ctx, version)
+			}
+			es.AssertNumberOfCalls(t, "SwapAlias", tc.expectedEsSwapNumCalls)
+		})
+	}
+}
+
+func TestElasticsearchEngine_IndexBody(t *testing.T) {
+	engine := NewElasticsearchEngine(nil, testMappings).(*ElasticsearchEngine)
+	body := engine.indexBody("suppliers", "tenant-2-suppliers_reindex")
+
+	var index map[string]interface{}
+	err := json.Unmarshal([]byte(body), &index)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"tenant-2-suppliers_reindex": map[string]interface{}{}}, index["aliases"])
+	assert.Contains(t, index, "settings")
+	assert.Contains(t, index, "mappings")
+}
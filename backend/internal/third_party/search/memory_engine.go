@@ -0,0 +1,510 @@
+package third_party
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"inventory-management/backend/util"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// MemoryEngine keeps the documents in memory, for tests and development without a search server.
+// Every word of a text has to start a word of one of the fields, hits are ranked by the boosts of
+// the fields they match.
+type MemoryEngine struct {
+	mutex   sync.RWMutex
+	indices map[string]map[int64]map[string]interface{}
+}
+
+func NewMemoryEngine() SearchEngineContract {
+	return &MemoryEngine{
+		indices: map[string]map[int64]map[string]interface{}{},
+	}
+}
+
+func (engine *MemoryEngine) Index(ctx context.Context, index string, id int64, document interface{}) error {
+	fields, err := memoryDocument(document)
+	if err != nil {
+		return err
+	}
+
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	tenantIndex := util.TenantIndex(ctx, index)
+	if engine.indices[tenantIndex] == nil {
+		engine.indices[tenantIndex] = map[int64]map[string]interface{}{}
+	}
+	engine.indices[tenantIndex][id] = fields
+
+	return nil
+}
+
+func (engine *MemoryEngine) Remove(ctx context.Context, index string, id int64) error {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	delete(engine.indices[util.TenantIndex(ctx, index)], id)
+	return nil
+}
+
+func (engine *MemoryEngine) Search(ctx context.Context, index string, query *Query, offset int, limit int) (*Result, error) {
+	engine.mutex.RLock()
+	defer engine.mutex.RUnlock()
+
+	type hit struct {
+		id       int64
+		score    float64
+		document map[string]interface{}
+	}
+
+	var hits []*hit
+	for id, document := range engine.indices[util.TenantIndex(ctx, index)] {
+		matched, err := memoryFilter(document, query.Filters)
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			continue
+		}
+
+		score := 0.0
+		if query.Text != "" {
+			score = memoryTextScore(document, query)
+			if score == 0 {
+				continue
+			}
+		}
+
+		hits = append(hits, &hit{id: id, score: score, document: document})
+	}
+
+	sorts := query.Sort
+	if len(sorts) == 0 && query.Text == "" && query.DefaultSort != "" {
+		sorts = []*Sort{{Field: query.DefaultSort, Descending: true}}
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		for _, hitSort := range sorts {
+			compared := memoryCompare(hits[i].document[hitSort.Field], hits[j].document[hitSort.Field])
+			if compared != 0 {
+				return (compared > 0) == hitSort.Descending
+			}
+		}
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return hits[i].id > hits[j].id
+	})
+
+	result := &Result{
+		Total:  int64(len(hits)),
+		Hits:   []map[string]interface{}{},
+		Facets: map[string][]*Bucket{},
+	}
+
+	for i := offset; i < len(hits) && i < offset+limit; i++ {
+		result.Hits = append(result.Hits, hits[i].document)
+	}
+
+	for _, facet := range query.Facets {
+		counts := map[string]int64{}
+		for _, matched := range hits {
+			for _, value := range memoryFacetValues(matched.document[facet.Field], facet.Month) {
+				counts[value]++
+			}
+		}
+
+		buckets := []*Bucket{}
+		for value, count := range counts {
+			buckets = append(buckets, &Bucket{Value: value, Count: count})
+		}
+		sort.Slice(buckets, func(i, j int) bool {
+			if !facet.Month && buckets[i].Count != buckets[j].Count {
+				return buckets[i].Count > buckets[j].Count
+			}
+			return buckets[i].Value < buckets[j].Value
+		})
+		if !facet.Month && len(buckets) > facetSize {
+			buckets = buckets[:facetSize]
+		}
+		result.Facets[facet.Name] = buckets
+	}
+
+	return result, nil
+}
+
+func (engine *MemoryEngine) Rebuild(ctx context.Context, index string, load DocumentLoader) (*RebuildResult, error) {
+	documents := map[int64]map[string]interface{}{}
+	var afterID int64
+	for {
+		batch, err := load(ctx, afterID)
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		for _, document := range batch {
+			documents[document.ID], err = memoryDocument(document.Body)
+			if err != nil {
+				return nil, err
+			}
+		}
+		afterID = batch[len(batch)-1].ID
+	}
+
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	tenantIndex := util.TenantIndex(ctx, index)
+	engine.indices[tenantIndex] = documents
+
+	return &RebuildResult{
+		Alias:     tenantIndex,
+		Index:     tenantIndex,
+		Documents: int64(len(documents)),
+	}, nil
+}
+
+// memoryDocument stores a document the way a search server returns it, as decoded JSON.
+func memoryDocument(document interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func memoryFilter(document map[string]interface{}, filters []*Filter) (bool, error) {
+	for _, filter := range filters {
+		value := document[filter.Field]
+		switch filter.Operator {
+		case FilterTerm:
+			matched := false
+			for _, element := range memoryValues(value) {
+				matched = matched || fmt.Sprint(element) == fmt.Sprint(filter.Value)
+			}
+			if !matched {
+				return false, nil
+			}
+		case FilterGT, FilterGTE, FilterLTE:
+			number, ok := value.(float64)
+			bound, _ := filter.Value.(float64)
+			if !ok ||
+				(filter.Operator == FilterGT && number <= bound) ||
+				(filter.Operator == FilterGTE && number < bound) ||
+				(filter.Operator == FilterLTE && number > bound) {
+				return false, nil
+			}
+		case FilterDateRange:
+			from, to, err := dateRange(filter.From, filter.To)
+			if err != nil {
+				return false, err
+			}
+			date, err := time.Parse(time.RFC3339Nano, fmt.Sprint(value))
+			if err != nil || (!from.IsZero() && date.Before(from)) || (!to.IsZero() && !date.Before(to)) {
+				return false, nil
+			}
+		default:
+			return false, errors.New("unknown search filter " + filter.Operator)
+		}
+	}
+
+	return true, nil
+}
+
+// memoryTextScore scores the best of the text and its synonyms, and adds one for every prefix
+// field the text starts.
+func memoryTextScore(document map[string]interface{}, query *Query) float64 {
+	score := 0.0
+	if len(query.Fields) > 0 {
+		for _, text := range append([]string{query.Text}, query.Synonyms...) {
+			score = math.Max(score, memoryScore(document, text, query.Fields))
+		}
+	}
+
+	for _, field := range query.PrefixFields {
+		for _, value := range memoryValues(document[field]) {
+			if strings.HasPrefix(strings.ToLower(fmt.Sprint(value)), strings.ToLower(query.Text)) {
+				score++
+			}
+		}
+	}
+
+	return score
+}
+
+// memoryScore sums the boosts of the fields the text matches, or returns 0 when a word of the text
+// matches none of them.
+func memoryScore(document map[string]interface{}, text string, fields []string) float64 {
+	score := 0.0
+	for _, word := range memoryWords(text) {
+		wordScore := 0.0
+		for _, field := range fields {
+			name, boost := fieldBoost(field)
+			for _, value := range memoryValues(document[name]) {
+				for _, fieldWord := range memoryWords(fmt.Sprint(value)) {
+					if memoryWordMatch(fieldWord, word) {
+						wordScore += boost
+					}
+				}
+			}
+		}
+		if wordScore == 0 {
+			return 0
+		}
+		score += wordScore
+	}
+
+	return score
+}
+
+// memoryWordMatch matches a word that starts the field word, allowing the edits Elasticsearch allows
+// with its AUTO fuzziness: none up to 2 letters, one up to 5 and two beyond.
+func memoryWordMatch(fieldWord string, word string) bool {
+	if strings.HasPrefix(fieldWord, word) {
+		return true
+	}
+
+	length := len([]rune(word))
+	edits := 0
+	switch {
+	case length > 5:
+		edits = 2
+	case length > 2:
+		edits = 1
+	default:
+		return false
+	}
+
+	fieldRunes := []rune(fieldWord)
+	if len(fieldRunes) > length && editDistance(fieldRunes[:length], []rune(word)) <= edits {
+		return true
+	}
+
+	return editDistance(fieldRunes, []rune(word)) <= edits
+}
+
+// editDistance counts the insertions, deletions and substitutions turning a into b.
+func editDistance(a []rune, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func memoryWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// memoryValues returns the elements of an array field, or the field itself.
+func memoryValues(value interface{}) []interface{} {
+	switch values := value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return values
+	default:
+		return []interface{}{values}
+	}
+}
+
+func memoryFacetValues(value interface{}, month bool) []string {
+	var values []string
+	for _, element := range memoryValues(value) {
+		if !month {
+			if number, ok := element.(float64); ok {
+				values = append(values, strconv.FormatFloat(number, 'f', -1, 64))
+			} else {
+				values = append(values, fmt.Sprint(element))
+			}
+			continue
+		}
+
+		date, err := time.Parse(time.RFC3339Nano, fmt.Sprint(element))
+		if err == nil {
+			values = append(values, date.UTC().Format("2006-01"))
+		}
+	}
+
+	return values
+}
+
+// memoryCompare orders numbers and dates by value and everything else as text, missing values
+// first.
+func memoryCompare(a interface{}, b interface{}) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		default:
+			return 1
+		}
+	}
+
+	numberA, okA := a.(float64)
+	numberB, okB := b.(float64)
+	if okA && okB {
+		switch {
+		case numberA < numberB:
+			return -1
+		case numberA > numberB:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	dateA, errA := time.Parse(time.RFC3339Nano, fmt.Sprint(a))
+	dateB, errB := time.Parse(time.RFC3339Nano, fmt.Sprint(b))
+	if errA == nil && errB == nil {
+		return dateA.Compare(dateB)
+	}
+
+	return strings.Compare(strings.ToLower(fmt.Sprint(a)), strings.ToLower(fmt.Sprint(b)))
+}
+
+func (engine *MemoryEngine) Aggregate(ctx context.Context, index string, aggregation *Aggregation) ([]*AggregationBucket, error) {
+	if len(aggregation.GroupBy) == 0 {
+		return nil, errors.New(ErrorAggregationWithoutGroup)
+	}
+
+	engine.mutex.RLock()
+	defer engine.mutex.RUnlock()
+
+	groups := map[string]*AggregationBucket{}
+	var buckets []*AggregationBucket
+	for _, document := range engine.indices[util.TenantIndex(ctx, index)] {
+		matched, err := memoryFilter(document, aggregation.Filters)
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			continue
+		}
+
+		bucket := &AggregationBucket{}
+		if aggregation.Interval != "" {
+			date, err := time.Parse(time.RFC3339Nano, fmt.Sprint(document[aggregation.DateField]))
+			if err != nil {
+				continue
+			}
+			bucket.Date, err = memoryInterval(date, aggregation.Interval)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		complete := true
+		for _, field := range aggregation.GroupBy {
+			values := memoryFacetValues(document[field], false)
+			if len(values) == 0 {
+				complete = false
+				break
+			}
+			bucket.Keys = append(bucket.Keys, values[0])
+		}
+		if !complete {
+			continue
+		}
+
+		key := bucket.Date + "\x00" + strings.Join(bucket.Keys, "\x00")
+		if groups[key] == nil {
+			for _, field := range aggregation.Labels {
+				bucket.Labels = append(bucket.Labels, memoryLabel(document[field]))
+			}
+			groups[key] = bucket
+			buckets = append(buckets, bucket)
+		}
+
+		groups[key].Count++
+		if number, ok := document[aggregation.Sum].(float64); ok {
+			groups[key].Sum += number
+		}
+	}
+
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].Date != buckets[j].Date {
+			return buckets[i].Date < buckets[j].Date
+		}
+		if buckets[i].Sum != buckets[j].Sum {
+			return buckets[i].Sum > buckets[j].Sum
+		}
+		if buckets[i].Count != buckets[j].Count {
+			return buckets[i].Count > buckets[j].Count
+		}
+		return strings.Join(buckets[i].Keys, "\x00") < strings.Join(buckets[j].Keys, "\x00")
+	})
+
+	if aggregation.Interval == "" && aggregation.Size > 0 && len(buckets) > aggregation.Size {
+		buckets = buckets[:aggregation.Size]
+	}
+
+	return buckets, nil
+}
+
+// memoryInterval returns the first day of the interval holding the date, in the server time zone.
+func memoryInterval(date time.Time, interval string) (string, error) {
+	year, month, day := date.In(time.Local).Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	switch interval {
+	case IntervalDay:
+	case IntervalWeek:
+		start = start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+	case IntervalMonth:
+		start = start.AddDate(0, 0, 1-day)
+	default:
+		return "", errors.New("unknown aggregation interval " + interval)
+	}
+
+	return start.Format("2006-01-02"), nil
+}
+
+func memoryLabel(value interface{}) string {
+	values := memoryFacetValues(value, false)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
@@ -0,0 +1,247 @@
+package third_party
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/util"
+	"testing"
+	"time"
+)
+
+type testProduct struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	Code         string    `json:"code"`
+	QualityTypes []string  `json:"quality_types"`
+	MinPrice     int64     `json:"min_price"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func newTestMemoryEngine(t *testing.T) SearchEngineContract {
+	ctx := context.Background()
+	engine := NewMemoryEngine()
+	products := []*testProduct{
+		{ID: 1, Name: "Kangkung", Code: "KANGKUNG01", QualityTypes: []string{"fresh"}, MinPrice: 3000, CreatedAt: time.Date(2021, 1, 10, 0, 0, 0, 0, time.Local)},
+		{ID: 2, Name: "Kangkung Organik", Code: "KANGKUNG02", QualityTypes: []string{"fresh", "organic"}, MinPrice: 8000, CreatedAt: time.Date(2021, 2, 10, 0, 0, 0, 0, time.Local)},
+		{ID: 3, Name: "Bayam", Code: "BAYAM00001", QualityTypes: []string{"fresh"}, MinPrice: 2000, CreatedAt: time.Date(2021, 2, 20, 0, 0, 0, 0, time.Local)},
+	}
+	for _, product := range products {
+		assert.NoError(t, engine.Index(ctx, "products", product.ID, product))
+	}
+
+	// Another tenant never sees these
+	other := &testProduct{ID: 4, Name: "Kangkung Impor", Code: "KANGKUNG03"}
+	assert.NoError(t, engine.Index(util.WithTenant(ctx, 2), "products", other.ID, other))
+
+	return engine
+}
+
+func TestMemoryEngine_Search(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          *Query
+		offset         int
+		limit          int
+		expectedTotal  int64
+		expectedNames  []string
+		expectedFacets map[string][]*Bucket
+	}{
+		{
+			name:          "Partial words match",
+			query:         &Query{Text: "kang", Fields: []string{"name^3", "code"}},
+			limit:         10,
+			expectedTotal: 2,
+			expectedNames: []string{"Kangkung Organik", "Kangkung"},
+		},
+		{
+			name:          "Every word has to match",
+			query:         &Query{Text: "kangkung organik", Fields: []string{"name^3", "code"}},
+			limit:         10,
+			expectedTotal: 1,
+			expectedNames: []string{"Kangkung Organik"},
+		},
+		{
+			name:          "Misspelled words match",
+			query:         &Query{Text: "kangkong organk", Fields: []string{"name^3"}},
+			limit:         10,
+			expectedTotal: 1,
+			expectedNames: []string{"Kangkung Organik"},
+		},
+		{
+			name:          "Synonyms of the text match",
+			query:         &Query{Text: "spinach", Fields: []string{"name^3"}, Synonyms: []string{"bayam"}},
+			limit:         10,
+			expectedTotal: 1,
+			expectedNames: []string{"Bayam"},
+		},
+		{
+			name:          "Prefix fields match from their start",
+			query:         &Query{Text: "kangkung0", PrefixFields: []string{"code"}, Sort: []*Sort{{Field: "code"}}},
+			limit:         10,
+			expectedTotal: 2,
+			expectedNames: []string{"Kangkung", "Kangkung Organik"},
+		},
+		{
+			name:          "Newest first without a text",
+			query:         &Query{DefaultSort: "created_at"},
+			limit:         10,
+			expectedTotal: 3,
+			expectedNames: []string{"Bayam", "Kangkung Organik", "Kangkung"},
+		},
+		{
+			name:          "Term filter on an array field and a range filter",
+			query:         &Query{Filters: []*Filter{TermFilter("quality_types", "fresh"), RangeFilter("min_price", FilterLTE, 3000)}, Sort: []*Sort{{Field: "name"}}},
+			limit:         10,
+			expectedTotal: 2,
+			expectedNames: []string{"Bayam", "Kangkung"},
+		},
+		{
+			name:          "Date range filter includes the last day",
+			query:         &Query{Filters: []*Filter{DateRangeFilter("created_at", "2021-02-01", "2021-02-20")}, Sort: []*Sort{{Field: "created_at"}}},
+			limit:         10,
+			expectedTotal: 2,
+			expectedNames: []string{"Kangkung Organik", "Bayam"},
+		},
+		{
+			name:          "Paginated by offset and limit",
+			query:         &Query{Sort: []*Sort{{Field: "min_price", Descending: true}}},
+			offset:        1,
+			limit:         1,
+			expectedTotal: 3,
+			expectedNames: []string{"Kangkung"},
+		},
+		{
+			name:          "Facets count every matching document",
+			query:         &Query{Facets: []*Facet{TermsFacet("quality_type", "quality_types"), MonthFacet("created_month", "created_at")}, DefaultSort: "created_at"},
+			limit:         0,
+			expectedTotal: 3,
+			expectedNames: []string{},
+			expectedFacets: map[string][]*Bucket{
+				"quality_type":  {{Value: "fresh", Count: 3}, {Value: "organic", Count: 1}},
+				"created_month": {{Value: "2021-01", Count: 1}, {Value: "2021-02", Count: 2}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			engine := newTestMemoryEngine(t)
+
+			result, err := engine.Search(context.Background(), "products", tc.query, tc.offset, tc.limit)
+			assert.NoError(t, err)
+
+			names := []string{}
+			for _, hit := range result.Hits {
+				names = append(names, hit["name"].(string))
+			}
+			assert.Equal(t, tc.expectedTotal, result.Total)
+			assert.Equal(t, tc.expectedNames, names)
+			if tc.expectedFacets != nil {
+				assert.Equal(t, tc.expectedFacets, result.Facets)
+			}
+		})
+	}
+}
+
+func TestMemoryEngine_RemoveAndRebuild(t *testing.T) {
+	ctx := context.Background()
+	engine := newTestMemoryEngine(t)
+
+	err := engine.Remove(ctx, "products", 3)
+	assert.NoError(t, err)
+	result, err := engine.Search(ctx, "products", &Query{}, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), result.Total)
+
+	rebuild, err := engine.Rebuild(ctx, "products", func(ctx context.Context, afterID int64) ([]*Document, error) {
+		if afterID > 0 {
+			return nil, nil
+		}
+		return []*Document{{ID: 5, Body: &testProduct{ID: 5, Name: "Sawi"}}}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &RebuildResult{Alias: "products", Index: "products", Documents: 1}, rebuild)
+
+	result, err = engine.Search(ctx, "products", &Query{}, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{"id": float64(5), "name": "Sawi", "code": "", "quality_types": nil, "min_price": float64(0), "created_at": "0001-01-01T00:00:00Z"}}, result.Hits)
+
+	// The other tenant keeps its documents
+	result, err = engine.Search(util.WithTenant(ctx, 2), "products", &Query{Text: "kangkung", Fields: []string{"name"}}, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.Total)
+}
+
+type testTransaction struct {
+	ID           int64     `json:"id"`
+	Type         string    `json:"type"`
+	Quantity     float64   `json:"quantity"`
+	CustomerCode string    `json:"customer_code,omitempty"`
+	CustomerName string    `json:"customer_name,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func TestMemoryEngine_Aggregate(t *testing.T) {
+	ctx := context.Background()
+	engine := NewMemoryEngine()
+	transactions := []*testTransaction{
+		{ID: 1, Type: "IN", Quantity: 10, CreatedAt: time.Date(2021, 1, 4, 8, 0, 0, 0, time.Local)},
+		{ID: 2, Type: "OUT", Quantity: 3, CustomerCode: "CUSTOMER01", CustomerName: "Widdy", CreatedAt: time.Date(2021, 1, 4, 23, 0, 0, 0, time.Local)},
+		{ID: 3, Type: "OUT", Quantity: 4, CustomerCode: "CUSTOMER02", CustomerName: "Angga", CreatedAt: time.Date(2021, 1, 6, 8, 0, 0, 0, time.Local)},
+		{ID: 4, Type: "OUT", Quantity: 2, CustomerCode: "CUSTOMER01", CustomerName: "Widdy", CreatedAt: time.Date(2021, 1, 11, 8, 0, 0, 0, time.Local)},
+	}
+	for _, transaction := range transactions {
+		assert.NoError(t, engine.Index(ctx, "transactions", transaction.ID, transaction))
+	}
+
+	testCases := []struct {
+		name            string
+		aggregation     *Aggregation
+		expectedBuckets []*AggregationBucket
+		expectedError   error
+	}{
+		{
+			name:        "Sums per day and type",
+			aggregation: &Aggregation{DateField: "created_at", Interval: IntervalDay, GroupBy: []string{"type"}, Sum: "quantity", Filters: []*Filter{DateRangeFilter("created_at", "2021-01-04", "2021-01-06")}},
+			expectedBuckets: []*AggregationBucket{
+				{Date: "2021-01-04", Keys: []string{"IN"}, Count: 1, Sum: 10},
+				{Date: "2021-01-04", Keys: []string{"OUT"}, Count: 1, Sum: 3},
+				{Date: "2021-01-06", Keys: []string{"OUT"}, Count: 1, Sum: 4},
+			},
+		},
+		{
+			name:        "Weeks start on Monday",
+			aggregation: &Aggregation{DateField: "created_at", Interval: IntervalWeek, GroupBy: []string{"type"}},
+			expectedBuckets: []*AggregationBucket{
+				{Date: "2021-01-04", Keys: []string{"OUT"}, Count: 2},
+				{Date: "2021-01-04", Keys: []string{"IN"}, Count: 1},
+				{Date: "2021-01-11", Keys: []string{"OUT"}, Count: 1},
+			},
+		},
+		{
+			name:        "Largest groups with their labels, leaving out documents without the field",
+			aggregation: &Aggregation{GroupBy: []string{"customer_code"}, Labels: []string{"customer_name"}, Sum: "quantity", Size: 1},
+			expectedBuckets: []*AggregationBucket{
+				{Keys: []string{"CUSTOMER01"}, Labels: []string{"Widdy"}, Count: 2, Sum: 5},
+			},
+		},
+		{
+			name:          "Aggregation without a group",
+			aggregation:   &Aggregation{Sum: "quantity"},
+			expectedError: errors.New(ErrorAggregationWithoutGroup),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buckets, err := engine.Aggregate(ctx, "transactions", tc.aggregation)
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			}
+
+			assert.Equal(t, tc.expectedBuckets, buckets)
+		})
+	}
+}
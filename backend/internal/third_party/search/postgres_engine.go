@@ -0,0 +1,444 @@
+package third_party
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// postgresSimilarity is the minimum trigram word similarity for a misspelled text to match.
+const postgresSimilarity = 0.4
+
+var postgresField = regexp.MustCompile(`^[a-z_]+$`)
+
+var postgresIntervals = map[string]bool{IntervalDay: true, IntervalWeek: true, IntervalMonth: true}
+
+// postgresWeights maps the boost of a field to the tsvector weight of its words.
+var postgresWeights = map[float64]string{3: "A", 2: "B"}
+
+// PostgresEngine keeps the documents as JSON in the search_documents table, so small installs can
+// search without running Elasticsearch. Words are matched with a prefix tsquery, so a partial word
+// matches while typing, and misspelled ones by trigram similarity. The table is scoped to tenants
+// by the tenant plugin of the database.
+type PostgresEngine struct {
+	DB *gorm.DB
+}
+
+type searchDocument struct {
+	ID         int64
+	TenantID   int64 `gorm:"default:1"`
+	IndexName  string
+	DocumentID int64
+	Document   string `gorm:"type:jsonb"`
+	UpdatedAt  time.Time
+}
+
+func (searchDocument) TableName() string {
+	return "search_documents"
+}
+
+func NewPostgresEngine(db *gorm.DB) SearchEngineContract {
+	return &PostgresEngine{
+		DB: db,
+	}
+}
+
+func (engine *PostgresEngine) Index(ctx context.Context, index string, id int64, document interface{}) error {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+
+	return engine.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "index_name"}, {Name: "document_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"document", "updated_at"}),
+	}).Create(&searchDocument{IndexName: index, DocumentID: id, Document: string(data)}).Error
+}
+
+func (engine *PostgresEngine) Remove(ctx context.Context, index string, id int64) error {
+	return engine.DB.WithContext(ctx).Where("index_name = ? AND document_id = ?", index, id).Delete(&searchDocument{}).Error
+}
+
+func (engine *PostgresEngine) Search(ctx context.Context, index string, query *Query, offset int, limit int) (*Result, error) {
+	conditions, err := postgresConditions(index, query)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Hits:   []map[string]interface{}{},
+		Facets: map[string][]*Bucket{},
+	}
+
+	err = engine.DB.WithContext(ctx).Model(&searchDocument{}).Scopes(conditions).Count(&result.Total).Error
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := postgresOrder(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var documents []*searchDocument
+	err = engine.DB.WithContext(ctx).Scopes(conditions).Clauses(order).Offset(offset).Limit(limit).Find(&documents).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, document := range documents {
+		var hit map[string]interface{}
+		err = json.Unmarshal([]byte(document.Document), &hit)
+		if err != nil {
+			return nil, err
+		}
+		result.Hits = append(result.Hits, hit)
+	}
+
+	for _, facet := range query.Facets {
+		result.Facets[facet.Name], err = engine.facet(ctx, conditions, facet)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// Rebuild replaces the documents of the index of the context tenant in one database transaction.
+// A document written meanwhile is kept rather than the loaded one, it is the newer of the two.
+func (engine *PostgresEngine) Rebuild(ctx context.Context, index string, load DocumentLoader) (*RebuildResult, error) {
+	var loaded int64
+	err := engine.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.WithContext(ctx).Where("index_name = ?", index).Delete(&searchDocument{}).Error
+		if err != nil {
+			return err
+		}
+
+		var afterID int64
+		for {
+			documents, err := load(ctx, afterID)
+			if err != nil {
+				return err
+			}
+			if len(documents) == 0 {
+				return nil
+			}
+
+			var rows []*searchDocument
+			for _, document := range documents {
+				data, err := json.Marshal(document.Body)
+				if err != nil {
+					return err
+				}
+				rows = append(rows, &searchDocument{IndexName: index, DocumentID: document.ID, Document: string(data)})
+			}
+
+			err = tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+			if err != nil {
+				return err
+			}
+
+			loaded += int64(len(documents))
+			afterID = documents[len(documents)-1].ID
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RebuildResult{
+		Alias:     index,
+		Index:     searchDocument{}.TableName(),
+		Documents: loaded,
+	}, nil
+}
+
+func (engine *PostgresEngine) Aggregate(ctx context.Context, index string, aggregation *Aggregation) ([]*AggregationBucket, error) {
+	if len(aggregation.GroupBy) == 0 {
+		return nil, errors.New(ErrorAggregationWithoutGroup)
+	}
+
+	conditions, err := postgresConditions(index, &Query{Filters: aggregation.Filters})
+	if err != nil {
+		return nil, err
+	}
+
+	db := engine.DB.WithContext(ctx).Model(&searchDocument{}).Scopes(conditions)
+
+	var selects []string
+	var vars []interface{}
+	var groups []string
+	var orders []string
+	if aggregation.Interval != "" {
+		if !postgresField.MatchString(aggregation.DateField) || !postgresIntervals[aggregation.Interval] {
+			return nil, fmt.Errorf("invalid aggregation interval %q of %q", aggregation.Interval, aggregation.DateField)
+		}
+		// An interval offset is east of UTC, unlike a time zone name such as '+07:00'
+		selects = append(selects, fmt.Sprintf("to_char(date_trunc('%s', (document->>'%s')::timestamptz AT TIME ZONE ?::interval), 'YYYY-MM-DD') AS date", aggregation.Interval, aggregation.DateField))
+		vars = append(vars, time.Now().Format("-07:00"))
+		groups = append(groups, "date")
+		orders = append(orders, "date")
+	}
+
+	for i, field := range aggregation.GroupBy {
+		if !postgresField.MatchString(field) {
+			return nil, fmt.Errorf("invalid search field %q", field)
+		}
+		selects = append(selects, fmt.Sprintf("document->>'%s' AS key_%d", field, i))
+		groups = append(groups, fmt.Sprintf("key_%d", i))
+		db = db.Where(fmt.Sprintf("document->>'%s' IS NOT NULL", field))
+	}
+
+	for i, field := range aggregation.Labels {
+		if !postgresField.MatchString(field) {
+			return nil, fmt.Errorf("invalid search field %q", field)
+		}
+		selects = append(selects, fmt.Sprintf("min(document->>'%s') AS label_%d", field, i))
+	}
+
+	selects = append(selects, "count(*) AS count")
+	if aggregation.Sum != "" {
+		if !postgresField.MatchString(aggregation.Sum) {
+			return nil, fmt.Errorf("invalid search field %q", aggregation.Sum)
+		}
+		selects = append(selects, fmt.Sprintf("coalesce(sum((document->>'%s')::numeric), 0)::float8 AS sum", aggregation.Sum))
+		orders = append(orders, "sum DESC")
+	}
+	orders = append(orders, "count DESC")
+	for i := range aggregation.GroupBy {
+		orders = append(orders, fmt.Sprintf("key_%d", i))
+	}
+
+	db = db.Select(strings.Join(selects, ", "), vars...).Group(strings.Join(groups, ", ")).Order(strings.Join(orders, ", "))
+	if aggregation.Interval == "" && aggregation.Size > 0 {
+		db = db.Limit(aggregation.Size)
+	}
+
+	var rows []map[string]interface{}
+	err = db.Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := []*AggregationBucket{}
+	for _, row := range rows {
+		bucket := &AggregationBucket{}
+		bucket.Date, _ = row["date"].(string)
+		for i := range aggregation.GroupBy {
+			bucket.Keys = append(bucket.Keys, fmt.Sprint(row[fmt.Sprintf("key_%d", i)]))
+		}
+		for i := range aggregation.Labels {
+			label, _ := row[fmt.Sprintf("label_%d", i)].(string)
+			bucket.Labels = append(bucket.Labels, label)
+		}
+		bucket.Count, _ = row["count"].(int64)
+		bucket.Sum, _ = row["sum"].(float64)
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, nil
+}
+
+func (engine *PostgresEngine) facet(ctx context.Context, conditions func(*gorm.DB) *gorm.DB, facet *Facet) ([]*Bucket, error) {
+	if !postgresField.MatchString(facet.Field) {
+		return nil, fmt.Errorf("invalid search field %q", facet.Field)
+	}
+
+	db := engine.DB.WithContext(ctx).Model(&searchDocument{}).Scopes(conditions)
+	if facet.Month {
+		db = db.Select(fmt.Sprintf("to_char((document->>'%s')::timestamptz AT TIME ZONE 'UTC', 'YYYY-MM') AS value, count(*) AS count", facet.Field)).
+			Where(fmt.Sprintf("document->>'%s' IS NOT NULL", facet.Field)).
+			Group("value").
+			Order("value")
+	} else {
+		// Array fields count every element, like a keyword field holding several values
+		db = db.Joins(fmt.Sprintf("CROSS JOIN LATERAL jsonb_array_elements_text(CASE jsonb_typeof(document->'%[1]s') WHEN 'array' THEN document->'%[1]s' ELSE jsonb_build_array(document->'%[1]s') END) AS facet(value)", facet.Field)).
+			Select("facet.value AS value, count(*) AS count").
+			Where("facet.value IS NOT NULL").
+			Group("facet.value").
+			Order("count DESC, value").
+			Limit(facetSize)
+	}
+
+	buckets := []*Bucket{}
+	err := db.Find(&buckets).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return buckets, nil
+}
+
+func postgresConditions(index string, query *Query) (func(*gorm.DB) *gorm.DB, error) {
+	var conditions []clause.Expression
+	conditions = append(conditions, clause.Expr{SQL: "index_name = ?", Vars: []interface{}{index}})
+
+	if query.Text != "" {
+		var matches []clause.Expression
+		if len(query.Fields) > 0 {
+			vector, content, err := postgresText(query.Fields)
+			if err != nil {
+				return nil, err
+			}
+			// The indexed search_vector and trigrams of the whole document find the candidates, which
+			// are then matched against the searched fields only
+			for _, text := range append([]string{query.Text}, query.Synonyms...) {
+				tsquery := postgresTsquery(text)
+				matches = append(matches, clause.Expr{
+					SQL:  fmt.Sprintf("((search_vector @@ to_tsquery('simple', ?) AND %s @@ to_tsquery('simple', ?)) OR (? <%% document::text AND word_similarity(?, %s) >= ?))", vector, content),
+					Vars: []interface{}{tsquery, tsquery, text, text, postgresSimilarity},
+				})
+			}
+		}
+		for _, field := range query.PrefixFields {
+			if !postgresField.MatchString(field) {
+				return nil, fmt.Errorf("invalid search field %q", field)
+			}
+			matches = append(matches, clause.Expr{SQL: fmt.Sprintf("document->>'%s' ILIKE ?", field), Vars: []interface{}{postgresLikePrefix(query.Text)}})
+		}
+		// gorm joins a single OR expression to the previous condition with OR, so only several are
+		// wrapped
+		if len(matches) == 1 {
+			conditions = append(conditions, matches[0])
+		} else {
+			conditions = append(conditions, clause.Or(matches...))
+		}
+	}
+
+	for _, filter := range query.Filters {
+		if !postgresField.MatchString(filter.Field) {
+			return nil, fmt.Errorf("invalid search field %q", filter.Field)
+		}
+
+		switch filter.Operator {
+		case FilterTerm:
+			// An array contains a value as well, so a term matches any element of an array field
+			value, err := json.Marshal(filter.Value)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, clause.Expr{SQL: fmt.Sprintf("document->'%s' @> ?::jsonb", filter.Field), Vars: []interface{}{string(value)}})
+		case FilterGT, FilterGTE, FilterLTE:
+			operator := map[string]string{FilterGT: ">", FilterGTE: ">=", FilterLTE: "<="}[filter.Operator]
+			conditions = append(conditions, clause.Expr{SQL: fmt.Sprintf("(document->>'%s')::numeric %s ?", filter.Field, operator), Vars: []interface{}{filter.Value}})
+		case FilterDateRange:
+			from, to, err := dateRange(filter.From, filter.To)
+			if err != nil {
+				return nil, err
+			}
+			if !from.IsZero() {
+				conditions = append(conditions, clause.Expr{SQL: fmt.Sprintf("(document->>'%s')::timestamptz >= ?", filter.Field), Vars: []interface{}{from}})
+			}
+			if !to.IsZero() {
+				conditions = append(conditions, clause.Expr{SQL: fmt.Sprintf("(document->>'%s')::timestamptz < ?", filter.Field), Vars: []interface{}{to}})
+			}
+		default:
+			return nil, errors.New("unknown search filter " + filter.Operator)
+		}
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(clause.And(conditions...))
+	}, nil
+}
+
+func postgresOrder(query *Query) (clause.OrderBy, error) {
+	var columns []clause.OrderByColumn
+	for _, sort := range query.Sort {
+		if !postgresField.MatchString(sort.Field) {
+			return clause.OrderBy{}, fmt.Errorf("invalid search field %q", sort.Field)
+		}
+		columns = append(columns, clause.OrderByColumn{Column: clause.Column{Name: fmt.Sprintf("document->'%s'", sort.Field), Raw: true}, Desc: sort.Descending})
+	}
+
+	if len(columns) == 0 && query.Text != "" && len(query.Fields) > 0 {
+		vector, content, err := postgresText(query.Fields)
+		if err != nil {
+			return clause.OrderBy{}, err
+		}
+		return clause.OrderBy{Expression: clause.Expr{
+			SQL:                fmt.Sprintf("ts_rank(%s, to_tsquery('simple', ?)) + word_similarity(?, %s) DESC, document_id DESC", vector, content),
+			Vars:               []interface{}{postgresTsquery(query.Text), query.Text},
+			WithoutParentheses: true,
+		}}, nil
+	}
+
+	if len(columns) == 0 && query.DefaultSort != "" {
+		if !postgresField.MatchString(query.DefaultSort) {
+			return clause.OrderBy{}, fmt.Errorf("invalid search field %q", query.DefaultSort)
+		}
+		columns = append(columns, clause.OrderByColumn{Column: clause.Column{Name: fmt.Sprintf("document->'%s'", query.DefaultSort), Raw: true}, Desc: true})
+	}
+
+	columns = append(columns, clause.OrderByColumn{Column: clause.Column{Name: "document_id"}, Desc: true})
+	return clause.OrderBy{Columns: columns}, nil
+}
+
+// postgresText returns the weighted tsvector and the plain text of the fields, a field boosted to 3
+// or 2 weighs like A or B.
+func postgresText(fields []string) (string, string, error) {
+	var vectors []string
+	var contents []string
+	for _, field := range fields {
+		name, boost := fieldBoost(field)
+		if !postgresField.MatchString(name) {
+			return "", "", fmt.Errorf("invalid search field %q", name)
+		}
+
+		weight, ok := postgresWeights[boost]
+		if !ok {
+			weight = "D"
+		}
+		vectors = append(vectors, fmt.Sprintf("setweight(to_tsvector('simple', coalesce(document->>'%s', '')), '%s')", name, weight))
+		contents = append(contents, fmt.Sprintf("document->>'%s'", name))
+	}
+
+	return "(" + strings.Join(vectors, " || ") + ")", "concat_ws(' ', " + strings.Join(contents, ", ") + ")", nil
+}
+
+// postgresTsquery matches every word of the text as a prefix, e.g. "kang bay" as "kang:* & bay:*".
+func postgresTsquery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
+
+// postgresLikePrefix escapes the wildcards of the text and matches anything after it.
+func postgresLikePrefix(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text) + "%"
+}
+
+// dateRange returns the start of the from day and the start of the day after to, in the server
+// time zone. An empty day gives a zero time.
+func dateRange(from string, to string) (time.Time, time.Time, error) {
+	var start, end time.Time
+	var err error
+	if from != "" {
+		start, err = time.ParseInLocation("2006-01-02", from, time.Local)
+		if err != nil {
+			return start, end, err
+		}
+	}
+	if to != "" {
+		end, err = time.ParseInLocation("2006-01-02", to, time.Local)
+		if err != nil {
+			return start, end, err
+		}
+		end = end.AddDate(0, 0, 1)
+	}
+
+	return start, end, nil
+}
@@ -0,0 +1,110 @@
+package third_party
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"inventory-management/backend/internal/repository"
+	"inventory-management/backend/util"
+	"testing"
+	"time"
+)
+
+// newDryRunEngine returns an engine on a database that only builds statements, and the statements
+// it built.
+func newDryRunEngine(t *testing.T) (SearchEngineContract, *[]string) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	assert.Nil(t, err)
+
+	err = db.Use(repository.NewTenantPlugin())
+	assert.Nil(t, err)
+
+	var statements []string
+	capture := func(db *gorm.DB) {
+		statements = append(statements, db.Dialector.Explain(db.Statement.SQL.String(), db.Statement.Vars...))
+	}
+	assert.Nil(t, db.Callback().Query().After("gorm:query").Register("test:capture", capture))
+	assert.Nil(t, db.Callback().Row().After("gorm:row").Register("test:capture", capture))
+	assert.Nil(t, db.Callback().Create().After("gorm:create").Register("test:capture", capture))
+
+	return NewPostgresEngine(db), &statements
+}
+
+func TestPostgresEngine_Search(t *testing.T) {
+	engine, statements := newDryRunEngine(t)
+
+	_, err := engine.Search(util.WithTenant(context.Background(), 2), "products", &Query{
+		Text:    "kang",
+		Fields:  []string{"name^3", "code"},
+		Filters: []*Filter{TermFilter("quality_types", "fresh"), RangeFilter("min_price", FilterLTE, 3000)},
+		Facets:  []*Facet{TermsFacet("quality_type", "quality_types"), MonthFacet("created_month", "created_at")},
+	}, 0, 10)
+	assert.NoError(t, err)
+
+	match := `index_name = 'products' AND (((search_vector @@ to_tsquery('simple', 'kang:*') AND (setweight(to_tsvector('simple', coalesce(document->>'name', '')), 'A') || setweight(to_tsvector('simple', coalesce(document->>'code', '')), 'D')) @@ to_tsquery('simple', 'kang:*')) OR ('kang' <% document::text AND word_similarity('kang', concat_ws(' ', document->>'name', document->>'code')) >= 0.400000))) AND document->'quality_types' @> '"fresh"'::jsonb AND (document->>'min_price')::numeric <= 3000.000000`
+	assert.Equal(t, []string{
+		`SELECT count(*) FROM "search_documents" WHERE (` + match + `) AND "search_documents"."tenant_id" = 2`,
+		`SELECT * FROM "search_documents" WHERE (` + match + `) AND "search_documents"."tenant_id" = 2 ORDER BY ts_rank((setweight(to_tsvector('simple', coalesce(document->>'name', '')), 'A') || setweight(to_tsvector('simple', coalesce(document->>'code', '')), 'D')), to_tsquery('simple', 'kang:*')) + word_similarity('kang', concat_ws(' ', document->>'name', document->>'code')) DESC, document_id DESC LIMIT 10`,
+		`SELECT facet.value AS value, count(*) AS count FROM "search_documents" CROSS JOIN LATERAL jsonb_array_elements_text(CASE jsonb_typeof(document->'quality_types') WHEN 'array' THEN document->'quality_types' ELSE jsonb_build_array(document->'quality_types') END) AS facet(value) WHERE facet.value IS NOT NULL AND (` + match + `) AND "search_documents"."tenant_id" = 2 GROUP BY "facet"."value" ORDER BY count DESC, value LIMIT 20`,
+		`SELECT to_char((document->>'created_at')::timestamptz AT TIME ZONE 'UTC', 'YYYY-MM') AS value, count(*) AS count FROM "search_documents" WHERE document->>'created_at' IS NOT NULL AND (` + match + `) AND "search_documents"."tenant_id" = 2 GROUP BY "value" ORDER BY value`,
+	}, *statements)
+}
+
+func TestPostgresEngine_Index(t *testing.T) {
+	engine, statements := newDryRunEngine(t)
+
+	err := engine.Index(util.WithTenant(context.Background(), 2), "suppliers", 1, map[string]interface{}{"name": "Widdy"})
+	assert.NoError(t, err)
+	assert.Len(t, *statements, 1)
+	assert.Contains(t, (*statements)[0], `INSERT INTO "search_documents" ("tenant_id","index_name","document_id","document","updated_at") VALUES (2,'suppliers',1,'{"name":"Widdy"}',`)
+	assert.Contains(t, (*statements)[0], `ON CONFLICT ("tenant_id","index_name","document_id") DO UPDATE SET "document"="excluded"."document","updated_at"="excluded"."updated_at"`)
+}
+
+func TestPostgresEngine_SearchSynonymsAndPrefix(t *testing.T) {
+	engine, statements := newDryRunEngine(t)
+
+	_, err := engine.Search(util.WithoutTenant(context.Background()), "products", &Query{
+		Text:         "kang_",
+		Fields:       []string{"name"},
+		Synonyms:     []string{"water spinach"},
+		PrefixFields: []string{"code"},
+		Sort:         []*Sort{{Field: "code"}},
+	}, 0, 10)
+	assert.NoError(t, err)
+
+	match := `index_name = 'products' AND ((((search_vector @@ to_tsquery('simple', 'kang:*') AND (setweight(to_tsvector('simple', coalesce(document->>'name', '')), 'D')) @@ to_tsquery('simple', 'kang:*')) OR ('kang_' <% document::text AND word_similarity('kang_', concat_ws(' ', document->>'name')) >= 0.400000))) OR (((search_vector @@ to_tsquery('simple', 'water:* & spinach:*') AND (setweight(to_tsvector('simple', coalesce(document->>'name', '')), 'D')) @@ to_tsquery('simple', 'water:* & spinach:*')) OR ('water spinach' <% document::text AND word_similarity('water spinach', concat_ws(' ', document->>'name')) >= 0.400000))) OR document->>'code' ILIKE 'kang\_%')`
+	assert.Equal(t, []string{
+		`SELECT count(*) FROM "search_documents" WHERE (` + match + `)`,
+		`SELECT * FROM "search_documents" WHERE (` + match + `) ORDER BY document->'code',"document_id" DESC LIMIT 10`,
+	}, *statements)
+}
+
+func TestPostgresEngine_Aggregate(t *testing.T) {
+	engine, statements := newDryRunEngine(t)
+
+	_, err := engine.Aggregate(util.WithTenant(context.Background(), 2), "transactions", &Aggregation{
+		Filters:   []*Filter{TermFilter("type", "OUT")},
+		DateField: "created_at",
+		Interval:  IntervalWeek,
+		GroupBy:   []string{"customer_code"},
+		Labels:    []string{"customer_name"},
+		Sum:       "quantity",
+	})
+	assert.NoError(t, err)
+	_, err = engine.Aggregate(util.WithoutTenant(context.Background()), "transactions", &Aggregation{
+		GroupBy: []string{"product_quality_id", "product_quality_id_transferred"},
+		Sum:     "quantity",
+		Size:    10,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`SELECT to_char(date_trunc('week', (document->>'created_at')::timestamptz AT TIME ZONE '` + time.Now().Format("-07:00") + `'::interval), 'YYYY-MM-DD') AS date, document->>'customer_code' AS key_0, min(document->>'customer_name') AS label_0, count(*) AS count, coalesce(sum((document->>'quantity')::numeric), 0)::float8 AS sum FROM "search_documents" WHERE document->>'customer_code' IS NOT NULL AND (index_name = 'transactions' AND document->'type' @> '"OUT"'::jsonb) AND "search_documents"."tenant_id" = 2 GROUP BY date, key_0 ORDER BY date, sum DESC, count DESC, key_0`,
+		`SELECT document->>'product_quality_id' AS key_0, document->>'product_quality_id_transferred' AS key_1, count(*) AS count, coalesce(sum((document->>'quantity')::numeric), 0)::float8 AS sum FROM "search_documents" WHERE document->>'product_quality_id' IS NOT NULL AND document->>'product_quality_id_transferred' IS NOT NULL AND index_name = 'transactions' GROUP BY key_0, key_1 ORDER BY sum DESC, count DESC, key_0, key_1 LIMIT 10`,
+	}, *statements)
+}
@@ -0,0 +1,58 @@
+package third_party
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+type SearchEngineMock struct {
+	mock.Mock
+}
+
+func (engine *SearchEngineMock) Index(ctx context.Context, index string, id int64, document interface{}) error {
+	args := engine.Called(ctx, index, id, document)
+	return args.Error(0)
+}
+
+func (engine *SearchEngineMock) Remove(ctx context.Context, index string, id int64) error {
+	args := engine.Called(ctx, index, id)
+	return args.Error(0)
+}
+
+func (engine *SearchEngineMock) Search(ctx context.Context, index string, query *Query, offset int, limit int) (*Result, error) {
+	args := engine.Called(ctx, index, query, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Result), args.Error(1)
+}
+
+// Rebuild calls the loader until it returns no documents, so the caller's loader is exercised,
+// and then returns what the mock was told to.
+func (engine *SearchEngineMock) Rebuild(ctx context.Context, index string, load DocumentLoader) (*RebuildResult, error) {
+	args := engine.Called(ctx, index)
+	var afterID int64
+	for {
+		documents, err := load(ctx, afterID)
+		if err != nil {
+			return nil, err
+		}
+		if len(documents) == 0 {
+			break
+		}
+		afterID = documents[len(documents)-1].ID
+	}
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*RebuildResult), args.Error(1)
+}
+
+func (engine *SearchEngineMock) Aggregate(ctx context.Context, index string, aggregation *Aggregation) ([]*AggregationBucket, error) {
+	args := engine.Called(ctx, index, aggregation)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*AggregationBucket), args.Error(1)
+}
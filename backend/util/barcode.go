@@ -0,0 +1,256 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"regexp"
+	"strings"
+)
+
+const (
+	BarcodeCode128 = "code128"
+	BarcodeQR      = "qr"
+	BarcodeGS1128  = "gs1-128"
+)
+
+var gs1ElementPattern = regexp.MustCompile(`\((\d{2,4})\)([^()]+)`)
+
+// GS1Element is an application identifier with its data, e.g. 240 with a product code.
+type GS1Element struct {
+	AI   string
+	Data string
+}
+
+// GS1Text is the human readable form of the elements, each AI between parentheses. It is the
+// value a GS1-128 barcode is encoded from.
+func GS1Text(elements []GS1Element) string {
+	var text strings.Builder
+	for _, element := range elements {
+		text.WriteString("(" + element.AI + ")" + element.Data)
+	}
+
+	return text.String()
+}
+
+// EncodeBarcode encodes the value in the symbology. GS1-128 values are the human readable form
+// GS1Text returns.
+func EncodeBarcode(symbology string, value string) (barcode.Barcode, error) {
+	switch symbology {
+	case BarcodeCode128:
+		return code128.Encode(value)
+	case BarcodeQR:
+		return qr.Encode(value, qr.M, qr.Auto)
+	case BarcodeGS1128:
+		content, err := gs1Content(value)
+		if err != nil {
+			return nil, err
+		}
+		return code128.Encode(content)
+	default:
+		return nil, fmt.Errorf("unknown barcode symbology %q", symbology)
+	}
+}
+
+// BarcodePNG draws the barcode with the quiet zone scanners need around it, every module being
+// scale pixels wide. Linear barcodes are height pixels high.
+func BarcodePNG(code barcode.Barcode, scale int, height int) ([]byte, error) {
+	modules := code.Bounds()
+	quietZone := barcodeQuietZone(code)
+
+	width := (modules.Dx() + 2*quietZone) * scale
+	imageHeight := height + 2*quietZone*scale
+	if code.Metadata().Dimensions == 2 {
+		imageHeight = (modules.Dy() + 2*quietZone) * scale
+	}
+
+	img := image.NewGray(image.Rect(0, 0, width, imageHeight))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	for _, bar := range barcodeBars(code) {
+		rect := image.Rect((bar.Min.X+quietZone)*scale, quietZone*scale, (bar.Max.X+quietZone)*scale, quietZone*scale+height)
+		if code.Metadata().Dimensions == 2 {
+			rect = image.Rect((bar.Min.X+quietZone)*scale, (bar.Min.Y+quietZone)*scale, (bar.Max.X+quietZone)*scale, (bar.Max.Y+quietZone)*scale)
+		}
+		draw.Draw(img, rect, image.Black, image.Point{}, draw.Src)
+	}
+
+	var output bytes.Buffer
+	if err := png.Encode(&output, img); err != nil {
+		return nil, err
+	}
+
+	return output.Bytes(), nil
+}
+
+// BarcodeSVG draws the barcode as an SVG of a module per unit, linear barcodes being height
+// units high, so it scales to any size.
+func BarcodeSVG(code barcode.Barcode, height int) []byte {
+	modules := code.Bounds()
+	quietZone := barcodeQuietZone(code)
+
+	width := modules.Dx() + 2*quietZone
+	svgHeight := height + 2*quietZone
+	if code.Metadata().Dimensions == 2 {
+		svgHeight = modules.Dy() + 2*quietZone
+	}
+
+	var path strings.Builder
+	for _, bar := range barcodeBars(code) {
+		barHeight := height
+		if code.Metadata().Dimensions == 2 {
+			barHeight = bar.Dy()
+		}
+		fmt.Fprintf(&path, "M%d %dh%dv%dh-%dz", bar.Min.X+quietZone, bar.Min.Y+quietZone, bar.Dx(), barHeight, bar.Dx())
+	}
+
+	var svg bytes.Buffer
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, width, svgHeight)
+	fmt.Fprintf(&svg, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="%s"/></svg>`, width, svgHeight, path.String())
+
+	return svg.Bytes()
+}
+
+// barcodeBars are the runs of dark modules of each row, linear barcodes having a single row.
+func barcodeBars(code barcode.Barcode) []image.Rectangle {
+	modules := code.Bounds()
+	rows := modules.Dy()
+	if code.Metadata().Dimensions == 1 {
+		rows = 1
+	}
+
+	var bars []image.Rectangle
+	for y := 0; y < rows; y++ {
+		start := -1
+		for x := 0; x <= modules.Dx(); x++ {
+			dark := x < modules.Dx() && isDark(code.At(modules.Min.X+x, modules.Min.Y+y))
+			if dark && start < 0 {
+				start = x
+			}
+			if !dark && start >= 0 {
+				bars = append(bars, image.Rect(start, y, x, y+1))
+				start = -1
+			}
+		}
+	}
+
+	return bars
+}
+
+// barcodeQuietZone is the blank margin, in modules, the symbology needs around the barcode.
+func barcodeQuietZone(code barcode.Barcode) int {
+	if code.Metadata().Dimensions == 2 {
+		return 4
+	}
+
+	return 10
+}
+
+func isDark(c color.Color) bool {
+	gray := color.GrayModel.Convert(c).(color.Gray)
+	return gray.Y < 128
+}
+
+// gs1Content turns the human readable form of GS1 elements into the content of a GS1-128
+// barcode: FNC1 first, then the elements, with an FNC1 ending every variable length one but
+// the last.
+func gs1Content(value string) (string, error) {
+	elements, ok := ParseGS1(value)
+	if !ok || !strings.HasPrefix(value, "(") {
+		return "", fmt.Errorf("GS1-128 value %q is not made of (AI)data elements", value)
+	}
+
+	content := string(code128.FNC1)
+	for i, element := range elements {
+		content += element.AI + element.Data
+		if _, fixed := gs1DataLengths[element.AI[:2]]; i < len(elements)-1 && !fixed {
+			content += string(code128.FNC1)
+		}
+	}
+
+	return content, nil
+}
+
+// gs1GroupSeparator ends variable length elements in what scanners send for the FNC1 of a
+// GS1-128 barcode.
+const gs1GroupSeparator = "\x1d"
+
+// gs1DataLengths are the data lengths of the fixed length application identifiers, by their
+// first two digits. The others run until the next FNC1, or the end.
+var gs1DataLengths = map[string]int{
+	"00": 18, "01": 14, "02": 14, "03": 14, "04": 16,
+	"11": 6, "12": 6, "13": 6, "14": 6, "15": 6, "16": 6, "17": 6, "18": 6, "19": 6,
+	"20": 2, "31": 6, "32": 6, "33": 6, "34": 6, "35": 6, "36": 6, "41": 13,
+}
+
+// ParseGS1 reads the elements of a scanned GS1 element string, either in the human readable
+// form GS1Text returns or as scanners send GS1-128 barcodes: optionally prefixed with the ]C1
+// symbology identifier, the elements running into each other with a group separator ending the
+// variable length ones. It reports false for values that are not GS1 element strings.
+func ParseGS1(value string) ([]GS1Element, bool) {
+	if strings.HasPrefix(value, "(") {
+		matches := gs1ElementPattern.FindAllStringSubmatch(value, -1)
+		if len(matches) == 0 || len(gs1ElementPattern.ReplaceAllString(value, "")) > 0 {
+			return nil, false
+		}
+
+		elements := make([]GS1Element, len(matches))
+		for i, match := range matches {
+			elements[i] = GS1Element{AI: match[1], Data: match[2]}
+		}
+		return elements, true
+	}
+
+	if !strings.HasPrefix(value, "]C1") && !strings.Contains(value, gs1GroupSeparator) {
+		return nil, false
+	}
+
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "]C1"), gs1GroupSeparator)
+	var elements []GS1Element
+	for value != "" {
+		aiLength := gs1AILength(value)
+		if aiLength == 0 || len(value) < aiLength {
+			return nil, false
+		}
+		ai := value[:aiLength]
+		value = value[aiLength:]
+
+		dataLength, fixed := gs1DataLengths[ai[:2]]
+		if !fixed {
+			dataLength = strings.Index(value, gs1GroupSeparator)
+			if dataLength < 0 {
+				dataLength = len(value)
+			}
+		}
+		if dataLength == 0 || len(value) < dataLength {
+			return nil, false
+		}
+
+		elements = append(elements, GS1Element{AI: ai, Data: value[:dataLength]})
+		value = strings.TrimPrefix(value[dataLength:], gs1GroupSeparator)
+	}
+
+	return elements, len(elements) > 0
+}
+
+// gs1AILength is how many digits the application identifier the value starts with has, told by
+// its first two digits, or 0 when it does not start with one.
+func gs1AILength(value string) int {
+	if len(value) < 2 || value[0] < '0' || value[0] > '9' || value[1] < '0' || value[1] > '9' {
+		return 0
+	}
+
+	switch prefix := value[:2]; {
+	case prefix <= "22", prefix == "30", prefix == "37", prefix >= "90":
+		return 2
+	case prefix >= "31" && prefix <= "36", prefix == "39", prefix == "43", prefix >= "70":
+		return 4
+	default:
+		return 3
+	}
+}
@@ -0,0 +1,108 @@
+package util
+
+import (
+	"bytes"
+	"github.com/boombuler/barcode/code128"
+	"github.com/stretchr/testify/assert"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestEncodeBarcode(t *testing.T) {
+	testCases := []struct {
+		name          string
+		symbology     string
+		value         string
+		expectedError bool
+	}{
+		{name: "Code 128", symbology: BarcodeCode128, value: "AbCdEfGhIj"},
+		{name: "QR code", symbology: BarcodeQR, value: "AbCdEfGhIj"},
+		{name: "GS1-128", symbology: BarcodeGS1128, value: "(240)AbCdEfGhIj(91)12"},
+		{name: "[invalid] GS1-128 without application identifiers", symbology: BarcodeGS1128, value: "AbCdEfGhIj", expectedError: true},
+		{name: "[invalid] Unknown symbology", symbology: "ean13", value: "AbCdEfGhIj", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, err := EncodeBarcode(tc.symbology, tc.value)
+			if tc.expectedError {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+
+			image, err := BarcodePNG(code, 2, 50)
+			assert.Nil(t, err)
+			decoded, err := png.Decode(bytes.NewReader(image))
+			assert.Nil(t, err)
+			assert.Equal(t, (code.Bounds().Dx()+2*barcodeQuietZone(code))*2, decoded.Bounds().Dx())
+
+			svg := string(BarcodeSVG(code, 50))
+			assert.True(t, strings.HasPrefix(svg, "<svg "))
+			assert.Equal(t, len(barcodeBars(code)), strings.Count(svg, "z"))
+		})
+	}
+}
+
+func TestGS1Content(t *testing.T) {
+	fnc1 := string(code128.FNC1)
+
+	testCases := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "Variable length elements are separated", value: "(240)PRDCT(10)TRX12", expected: fnc1 + "240PRDCT" + fnc1 + "10TRX12"},
+		{name: "Fixed length elements are not", value: "(01)09501101530003(10)TRX12", expected: fnc1 + "0109501101530003" + "10TRX12"},
+		{name: "Last element is not", value: GS1Text([]GS1Element{{AI: "91", Data: "12"}}), expected: fnc1 + "9112"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content, err := gs1Content(tc.value)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, content)
+		})
+	}
+}
+
+func TestParseGS1(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		expected []GS1Element
+		valid    bool
+	}{
+		{
+			name:     "Human readable elements",
+			value:    "(240)PRDCT(91)7",
+			expected: []GS1Element{{AI: "240", Data: "PRDCT"}, {AI: "91", Data: "7"}},
+			valid:    true,
+		},
+		{
+			name:     "Scanned with the symbology identifier",
+			value:    "]C10109501101530003" + "10TRX12",
+			expected: []GS1Element{{AI: "01", Data: "09501101530003"}, {AI: "10", Data: "TRX12"}},
+			valid:    true,
+		},
+		{
+			name:     "Scanned with group separators",
+			value:    "240PRDCT\x1d917",
+			expected: []GS1Element{{AI: "240", Data: "PRDCT"}, {AI: "91", Data: "7"}},
+			valid:    true,
+		},
+		{name: "[invalid] Plain code", value: "PRDCT12345"},
+		{name: "[invalid] Truncated fixed length element", value: "]C101095011"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			elements, ok := ParseGS1(tc.value)
+			assert.Equal(t, tc.valid, ok)
+			if tc.valid {
+				assert.Equal(t, tc.expected, elements)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+type cacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// Cache keeps values in memory for a fixed time to live. Expired entries are dropped when they
+// are read or when a value is stored.
+type Cache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	mutex   sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]*cacheEntry{},
+	}
+}
+
+// Get returns the value stored under the key, if it has not expired yet.
+func (cache *Cache) Get(key string) (interface{}, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entry, ok := cache.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if !cache.now().Before(entry.expiresAt) {
+		delete(cache.entries, key)
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+// Set stores the value under the key. Nothing is stored when the time to live is not positive.
+func (cache *Cache) Set(key string, value interface{}) {
+	if cache.ttl <= 0 {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	now := cache.now()
+	for entryKey, entry := range cache.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(cache.entries, entryKey)
+		}
+	}
+
+	cache.entries[key] = &cacheEntry{value: value, expiresAt: now.Add(cache.ttl)}
+}
@@ -0,0 +1,52 @@
+package util
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		ttl           time.Duration
+		elapsed       time.Duration
+		expectedValue interface{}
+		expectedOk    bool
+	}{
+		{
+			name:          "Value before it expires",
+			ttl:           time.Minute,
+			elapsed:       59 * time.Second,
+			expectedValue: "dashboard",
+			expectedOk:    true,
+		},
+		{
+			name:    "Value after it expires",
+			ttl:     time.Minute,
+			elapsed: time.Minute,
+		},
+		{
+			name: "Nothing is cached without a time to live",
+			ttl:  0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cache := NewCache(tc.ttl)
+			cache.now = func() time.Time { return now }
+			cache.Set("key", "dashboard")
+
+			cache.now = func() time.Time { return now.Add(tc.elapsed) }
+			value, ok := cache.Get("key")
+			assert.Equal(t, tc.expectedValue, value)
+			assert.Equal(t, tc.expectedOk, ok)
+
+			_, ok = cache.Get("other")
+			assert.False(t, ok)
+		})
+	}
+}
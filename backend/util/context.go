@@ -0,0 +1,98 @@
+package util
+
+import (
+	"context"
+	"fmt"
+)
+
+type contextKey string
+
+const (
+	actorContextKey     contextKey = "actor"
+	requestIDContextKey contextKey = "request_id"
+	tenantContextKey    contextKey = "tenant"
+	versionContextKey   contextKey = "version"
+	deletedContextKey   contextKey = "deleted"
+)
+
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey, actor)
+}
+
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorContextKey).(string)
+	return actor
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, requestID)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey).(string)
+	return requestID
+}
+
+// DefaultTenantID owns the data created before multi-tenancy and operates the deployment,
+// its administrators are the only ones allowed to provision tenants.
+const DefaultTenantID int64 = 1
+
+func WithTenant(ctx context.Context, tenantID int64) context.Context {
+	return context.WithValue(ctx, tenantContextKey, tenantID)
+}
+
+// WithoutTenant marks the context as not scoped to a tenant, e.g. to check that a
+// username is unique across all tenants.
+func WithoutTenant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tenantContextKey, int64(0))
+}
+
+// WithoutTenantFromContext reports whether the context was marked with WithoutTenant, rather
+// than just not scoped to a tenant.
+func WithoutTenantFromContext(ctx context.Context) bool {
+	tenantID, ok := ctx.Value(tenantContextKey).(int64)
+	return ok && tenantID == 0
+}
+
+// TenantFromContext returns the tenant the context is scoped to, if any.
+func TenantFromContext(ctx context.Context) (int64, bool) {
+	tenantID, _ := ctx.Value(tenantContextKey).(int64)
+	return tenantID, tenantID != 0
+}
+
+// TenantIndex returns the name of the Elasticsearch index holding the documents of the
+// context tenant, so tenants never share an index. The default tenant keeps the unprefixed
+// index, which also receives documents written outside of a tenant context.
+func TenantIndex(ctx context.Context, index string) string {
+	tenantID, ok := TenantFromContext(ctx)
+	if !ok || tenantID == DefaultTenantID {
+		return index
+	}
+
+	return fmt.Sprintf("tenant-%d-%s", tenantID, index)
+}
+
+// WithExpectedVersion records the version of the record the request was made against, the one
+// of the ETag the client sent in If-Match. A change of the record fails when it is no longer at
+// that version.
+func WithExpectedVersion(ctx context.Context, version int64) context.Context {
+	return context.WithValue(ctx, versionContextKey, version)
+}
+
+// ExpectedVersionFromContext returns the version the record has to be at, if the request said.
+func ExpectedVersionFromContext(ctx context.Context) (int64, bool) {
+	version, ok := ctx.Value(versionContextKey).(int64)
+	return version, ok
+}
+
+// WithDeleted lets reads of the context see soft deleted records as well, e.g. to check that a
+// username is not taken by a deleted user, who could be restored.
+func WithDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, deletedContextKey, true)
+}
+
+// DeletedFromContext tells whether reads of the context see soft deleted records.
+func DeletedFromContext(ctx context.Context) bool {
+	deleted, _ := ctx.Value(deletedContextKey).(bool)
+	return deleted
+}
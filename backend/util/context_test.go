@@ -0,0 +1,100 @@
+package util
+
+import (
+	"context"
+	"testing"
+)
+
+func TestActorFromContext(t *testing.T) {
+	t.Run("Actor stored in context", func(t *testing.T) {
+		ctx := WithActor(context.Background(), "wdyarfn")
+		if ActorFromContext(ctx) != "wdyarfn" {
+			t.Errorf("The actor is not 'wdyarfn'")
+		}
+	})
+
+	t.Run("Context without actor", func(t *testing.T) {
+		if ActorFromContext(context.Background()) != "" {
+			t.Errorf("The actor is not empty")
+		}
+	})
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	t.Run("Request ID stored in context", func(t *testing.T) {
+		ctx := WithRequestID(context.Background(), "request-id")
+		if RequestIDFromContext(ctx) != "request-id" {
+			t.Errorf("The request ID is not 'request-id'")
+		}
+	})
+
+	t.Run("Context without request ID", func(t *testing.T) {
+		if RequestIDFromContext(context.Background()) != "" {
+			t.Errorf("The request ID is not empty")
+		}
+	})
+}
+
+func TestTenantFromContext(t *testing.T) {
+	t.Run("Tenant stored in context", func(t *testing.T) {
+		tenantID, ok := TenantFromContext(WithTenant(context.Background(), 2))
+		if !ok || tenantID != 2 {
+			t.Errorf("The tenant is not 2")
+		}
+	})
+
+	t.Run("Context without tenant", func(t *testing.T) {
+		if _, ok := TenantFromContext(context.Background()); ok {
+			t.Errorf("The context is scoped to a tenant")
+		}
+	})
+
+	t.Run("Tenant removed from context", func(t *testing.T) {
+		if _, ok := TenantFromContext(WithoutTenant(WithTenant(context.Background(), 2))); ok {
+			t.Errorf("The context is scoped to a tenant")
+		}
+	})
+}
+
+func TestTenantIndex(t *testing.T) {
+	if TenantIndex(context.Background(), "users") != "users" {
+		t.Errorf("The index is not 'users'")
+	}
+
+	if TenantIndex(WithTenant(context.Background(), DefaultTenantID), "users") != "users" {
+		t.Errorf("The index is not 'users'")
+	}
+
+	if TenantIndex(WithTenant(context.Background(), 2), "users") != "tenant-2-users" {
+		t.Errorf("The index is not 'tenant-2-users'")
+	}
+}
+
+func TestExpectedVersionFromContext(t *testing.T) {
+	t.Run("Version stored in context", func(t *testing.T) {
+		version, ok := ExpectedVersionFromContext(WithExpectedVersion(context.Background(), 3))
+		if !ok || version != 3 {
+			t.Errorf("The version is not 3")
+		}
+	})
+
+	t.Run("Context without version", func(t *testing.T) {
+		if _, ok := ExpectedVersionFromContext(context.Background()); ok {
+			t.Errorf("The context has a version")
+		}
+	})
+}
+
+func TestDeletedFromContext(t *testing.T) {
+	t.Run("Deleted records included by the context", func(t *testing.T) {
+		if !DeletedFromContext(WithDeleted(context.Background())) {
+			t.Errorf("The context does not include deleted records")
+		}
+	})
+
+	t.Run("Context without deleted records", func(t *testing.T) {
+		if DeletedFromContext(context.Background()) {
+			t.Errorf("The context includes deleted records")
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package util
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"inventory-management/backend/internal/http/response"
+	"net/url"
+)
+
+const (
+	CursorNext = "next"
+	CursorPrev = "prev"
+)
+
+// Cursor points at the record a page starts after, or ends before when it goes to the previous
+// page. It is handed out as an opaque token.
+type Cursor struct {
+	// Sort is the order the cursor was created for, such as -created_at,-id
+	Sort      string        `json:"s"`
+	Values    []interface{} `json:"v"`
+	Direction string        `json:"d"`
+}
+
+// CursorPage holds the tokens of the pages around a page, empty when there is none.
+type CursorPage struct {
+	Next string
+	Prev string
+}
+
+func EncodeCursor(cursor *Cursor) (string, error) {
+	encoded, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(encoded), nil
+}
+
+// DecodeCursor reads a token of EncodeCursor. Numbers are kept as json.Number, so the reader
+// decides on their type.
+func DecodeCursor(token string) (*Cursor, error) {
+	encoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New(response.ErrorInvalidCursor)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+
+	var cursor Cursor
+	if err := decoder.Decode(&cursor); err != nil {
+		return nil, errors.New(response.ErrorInvalidCursor)
+	}
+	if cursor.Direction != CursorNext && cursor.Direction != CursorPrev {
+		return nil, errors.New(response.ErrorInvalidCursor)
+	}
+
+	return &cursor, nil
+}
+
+// CreateCursorPagination links the pages around a page with the query of the request, so the
+// filters and the sort carry over. The total is only set when it was counted.
+func CreateCursorPagination(path string, query url.Values, page *CursorPage, totalRecords *int64) (pagination response.CursorPagination) {
+	pagination.NextCursor = page.Next
+	pagination.PrevCursor = page.Prev
+	pagination.Next = cursorLink(path, query, page.Next)
+	pagination.Prev = cursorLink(path, query, page.Prev)
+	if totalRecords != nil {
+		total := int(*totalRecords)
+		pagination.TotalRecords = &total
+	}
+
+	return pagination
+}
+
+func cursorLink(path string, query url.Values, token string) string {
+	if token == "" {
+		return ""
+	}
+
+	linkQuery := url.Values{}
+	for key, values := range query {
+		linkQuery[key] = values
+	}
+	linkQuery.Set("cursor", token)
+
+	return path + "?" + linkQuery.Encode()
+}
@@ -0,0 +1,66 @@
+package util
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/http/response"
+	"net/url"
+	"testing"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	token, err := EncodeCursor(&Cursor{Sort: "-created_at,-id", Values: []interface{}{"2021-01-31T08:30:00Z", 7}, Direction: CursorPrev})
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		name           string
+		token          string
+		expectedCursor *Cursor
+		expectedError  string
+	}{
+		{
+			name:           "Token of an encoded cursor",
+			token:          token,
+			expectedCursor: &Cursor{Sort: "-created_at,-id", Values: []interface{}{"2021-01-31T08:30:00Z", json.Number("7")}, Direction: CursorPrev},
+		},
+		{
+			name:          "Token out of base64",
+			token:         "not a cursor",
+			expectedError: response.ErrorInvalidCursor,
+		},
+		{
+			name:          "Token without a direction",
+			token:         "eyJzIjoiaWQiLCJ2IjpbN119",
+			expectedError: response.ErrorInvalidCursor,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cursor, err := DecodeCursor(tc.token)
+			if tc.expectedError != "" {
+				assert.Equal(t, tc.expectedError, err.Error())
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedCursor, cursor)
+		})
+	}
+}
+
+func TestCreateCursorPagination(t *testing.T) {
+	query := url.Values{"cursor": {"current"}, "limit": {"5"}, "sort": {"name"}}
+	totalRecords := int64(12)
+
+	pagination := CreateCursorPagination("/api/suppliers", query, &CursorPage{Next: "next"}, &totalRecords)
+	assert.Equal(t, "/api/suppliers?cursor=next&limit=5&sort=name", pagination.Next)
+	assert.Empty(t, pagination.Prev)
+	assert.Equal(t, "next", pagination.NextCursor)
+	assert.Equal(t, 12, *pagination.TotalRecords)
+	assert.Equal(t, []string{"current"}, query["cursor"])
+
+	pagination = CreateCursorPagination("/api/suppliers", query, &CursorPage{Prev: "prev"}, nil)
+	assert.Equal(t, "/api/suppliers?cursor=prev&limit=5&sort=name", pagination.Prev)
+	assert.Nil(t, pagination.TotalRecords)
+}
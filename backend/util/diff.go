@@ -0,0 +1,61 @@
+package util
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// DiffJSON compares the JSON representation of two values field by field and returns
+// the fields that differ. A nil value is treated as an empty object, so creates and
+// deletes report every field.
+func DiffJSON(before interface{}, after interface{}) (map[string]*FieldChange, error) {
+	beforeFields, err := toJSONFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := toJSONFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]*FieldChange{}
+	for field, beforeValue := range beforeFields {
+		afterValue, ok := afterFields[field]
+		if !ok || !reflect.DeepEqual(beforeValue, afterValue) {
+			changes[field] = &FieldChange{Before: beforeValue, After: afterValue}
+		}
+	}
+
+	for field, afterValue := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			changes[field] = &FieldChange{Before: nil, After: afterValue}
+		}
+	}
+
+	return changes, nil
+}
+
+func toJSONFields(value interface{}) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if value == nil || (reflect.ValueOf(value).Kind() == reflect.Ptr && reflect.ValueOf(value).IsNil()) {
+		return fields, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,48 @@
+package util
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type diffTestEntity struct {
+	Code string  `json:"code"`
+	Name string  `json:"name"`
+	Note *string `json:"note,omitempty"`
+}
+
+func TestDiffJSON(t *testing.T) {
+	t.Run("Update reports only changed fields", func(t *testing.T) {
+		changes, err := DiffJSON(&diffTestEntity{Code: "A", Name: "Rice"}, &diffTestEntity{Code: "A", Name: "Sugar"})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]*FieldChange{
+			"name": {Before: "Rice", After: "Sugar"},
+		}, changes)
+	})
+
+	t.Run("Create reports every field", func(t *testing.T) {
+		var before *diffTestEntity
+		changes, err := DiffJSON(before, &diffTestEntity{Code: "A", Name: "Rice"})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]*FieldChange{
+			"code": {Before: nil, After: "A"},
+			"name": {Before: nil, After: "Rice"},
+		}, changes)
+	})
+
+	t.Run("Delete reports every field", func(t *testing.T) {
+		changes, err := DiffJSON(&diffTestEntity{Code: "A", Name: "Rice", Note: ToPointerString("dry")}, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]*FieldChange{
+			"code": {Before: "A", After: nil},
+			"name": {Before: "Rice", After: nil},
+			"note": {Before: "dry", After: nil},
+		}, changes)
+	})
+
+	t.Run("No changes", func(t *testing.T) {
+		changes, err := DiffJSON(&diffTestEntity{Code: "A"}, &diffTestEntity{Code: "A"})
+		assert.Nil(t, err)
+		assert.Empty(t, changes)
+	})
+}
@@ -0,0 +1,28 @@
+package util
+
+import (
+	"strconv"
+	"strings"
+)
+
+// VersionETag is the entity tag of a record at a version, which clients send back in If-Match
+// to change the record.
+func VersionETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ParseVersionETag returns the version of an entity tag made by VersionETag. If-Match compares
+// tags strongly, so weak tags are not versions.
+func ParseVersionETag(etag string) (int64, bool) {
+	etag = strings.TrimSpace(etag)
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		return 0, false
+	}
+
+	version, err := strconv.ParseInt(etag[1:len(etag)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+
+	return version, true
+}
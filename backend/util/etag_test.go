@@ -0,0 +1,31 @@
+package util
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseVersionETag(t *testing.T) {
+	testCases := []struct {
+		name     string
+		etag     string
+		expected int64
+		valid    bool
+	}{
+		{name: "Version tag", etag: VersionETag(3), expected: 3, valid: true},
+		{name: "Surrounding spaces", etag: ` "12" `, expected: 12, valid: true},
+		{name: "[invalid] Weak tag", etag: `W/"3"`},
+		{name: "[invalid] Not quoted", etag: "3"},
+		{name: "[invalid] Not a number", etag: `"abc"`},
+		{name: "[invalid] List of tags", etag: `"3", "4"`},
+		{name: "[invalid] Zero", etag: `"0"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			version, ok := ParseVersionETag(tc.etag)
+			assert.Equal(t, tc.valid, ok)
+			assert.Equal(t, tc.expected, version)
+		})
+	}
+}
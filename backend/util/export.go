@@ -0,0 +1,216 @@
+package util
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"io"
+	"net/url"
+	"strings"
+)
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatXLSX   = "xlsx"
+	ExportFormatNDJSON = "ndjson"
+)
+
+// ExportContentTypes maps the export formats to the content types they are served and negotiated
+// with.
+var ExportContentTypes = map[string]string{
+	ExportFormatCSV:    "text/csv",
+	ExportFormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	ExportFormatNDJSON: "application/x-ndjson",
+}
+
+// ExportWriter writes the records of an export one at a time. Close must be called once every
+// record is written, a sheet is only complete after it.
+type ExportWriter interface {
+	Write(record map[string]interface{}) error
+	Close() error
+}
+
+// NewExportWriter writes records in the format to w. CSV and XLSX have a column per given column
+// name, in order, and cells holding objects or lists get them as JSON. NDJSON writes every
+// record as it is.
+func NewExportWriter(format string, w io.Writer, columns []string) (ExportWriter, error) {
+	switch format {
+	case ExportFormatCSV:
+		writer := &csvExportWriter{writer: csv.NewWriter(w), columns: columns}
+		return writer, writer.writer.Write(columns)
+	case ExportFormatXLSX:
+		return newXLSXExportWriter(w, columns)
+	case ExportFormatNDJSON:
+		return &ndjsonExportWriter{encoder: json.NewEncoder(w)}, nil
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// ExportRecord turns a response into the record an ExportWriter writes, keeping only the fields
+// given when there are any.
+func ExportRecord(data interface{}, fields []string) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+
+	var record map[string]interface{}
+	if err = decoder.Decode(&record); err != nil {
+		return nil, err
+	}
+
+	if len(fields) > 0 {
+		for key := range record {
+			if !containsString(fields, key) {
+				delete(record, key)
+			}
+		}
+	}
+
+	return record, nil
+}
+
+// ListQueryValues keeps the parameters of a query string ParseListQuery reads, the ones that
+// decide which records a list has.
+func ListQueryValues(values url.Values) url.Values {
+	listValues := url.Values{}
+	for key, value := range values {
+		if strings.HasPrefix(key, "filter") || key == "sort" || key == "fields" || key == "include" {
+			listValues[key] = value
+		}
+	}
+
+	return listValues
+}
+
+type csvExportWriter struct {
+	writer  *csv.Writer
+	columns []string
+}
+
+func (w *csvExportWriter) Write(record map[string]interface{}) error {
+	cells := make([]string, len(w.columns))
+	for i, column := range w.columns {
+		cell, err := exportCell(record[column])
+		if err != nil {
+			return err
+		}
+		cells[i] = fmt.Sprint(cell)
+	}
+
+	return w.writer.Write(cells)
+}
+
+func (w *csvExportWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type ndjsonExportWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonExportWriter) Write(record map[string]interface{}) error {
+	return w.encoder.Encode(record)
+}
+
+func (w *ndjsonExportWriter) Close() error {
+	return nil
+}
+
+// xlsxExportWriter streams the rows into the sheet, which excelize keeps on disk once it grows
+// large. The workbook can only be written out whole, when it is closed.
+type xlsxExportWriter struct {
+	output  io.Writer
+	file    *excelize.File
+	stream  *excelize.StreamWriter
+	columns []string
+	row     int
+}
+
+func newXLSXExportWriter(output io.Writer, columns []string) (*xlsxExportWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter(file.GetSheetName(0))
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	w := &xlsxExportWriter{output: output, file: file, stream: stream, columns: columns}
+	header := make([]interface{}, len(columns))
+	for i, column := range columns {
+		header[i] = column
+	}
+
+	return w, w.writeRow(header)
+}
+
+func (w *xlsxExportWriter) Write(record map[string]interface{}) error {
+	cells := make([]interface{}, len(w.columns))
+	for i, column := range w.columns {
+		cell, err := exportCell(record[column])
+		if err != nil {
+			return err
+		}
+		// Numbers stay numbers, so the sheet can sum them
+		if number, ok := cell.(json.Number); ok {
+			if value, err := number.Float64(); err == nil {
+				cell = value
+			}
+		}
+		cells[i] = cell
+	}
+
+	return w.writeRow(cells)
+}
+
+func (w *xlsxExportWriter) writeRow(cells []interface{}) error {
+	w.row++
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+
+	return w.stream.SetRow(cell, cells)
+}
+
+func (w *xlsxExportWriter) Close() error {
+	defer w.file.Close()
+
+	if err := w.stream.Flush(); err != nil {
+		return err
+	}
+
+	return w.file.Write(w.output)
+}
+
+// formulaPrefixes start the text a spreadsheet reads as a formula.
+const formulaPrefixes = "=+-@\t\r"
+
+// exportCell is the value of a sheet cell, empty for null and JSON for objects and lists. Text
+// starting like a formula is prefixed with ', so a spreadsheet shows it instead of running it.
+func exportCell(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case map[string]interface{}, []interface{}:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		return string(encoded), nil
+	case string:
+		if value != "" && strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+			return "'" + value, nil
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,110 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"net/url"
+	"testing"
+)
+
+func TestExportWriter(t *testing.T) {
+	columns := []string{"code", "name", "quantity", "product_qualities"}
+	records := []map[string]interface{}{
+		{"code": "KGKG", "name": "Kangkung, fresh", "quantity": 2.5, "product_qualities": []interface{}{map[string]interface{}{"quality": "Original"}}},
+		{"code": "BYAM", "name": "Bayam", "quantity": 10, "product_qualities": nil},
+	}
+
+	write := func(t *testing.T, format string) []byte {
+		var output bytes.Buffer
+		writer, err := NewExportWriter(format, &output, columns)
+		assert.Nil(t, err)
+		for _, record := range records {
+			assert.Nil(t, writer.Write(record))
+		}
+		assert.Nil(t, writer.Close())
+
+		return output.Bytes()
+	}
+
+	t.Run("CSV with a column per field", func(t *testing.T) {
+		assert.Equal(t, "code,name,quantity,product_qualities\n"+
+			"KGKG,\"Kangkung, fresh\",2.5,\"[{\"\"quality\"\":\"\"Original\"\"}]\"\n"+
+			"BYAM,Bayam,10,\n", string(write(t, ExportFormatCSV)))
+	})
+
+	t.Run("NDJSON with a record per line", func(t *testing.T) {
+		assert.Equal(t, `{"code":"KGKG","name":"Kangkung, fresh","product_qualities":[{"quality":"Original"}],"quantity":2.5}`+"\n"+
+			`{"code":"BYAM","name":"Bayam","product_qualities":null,"quantity":10}`+"\n", string(write(t, ExportFormatNDJSON)))
+	})
+
+	t.Run("XLSX with a header row", func(t *testing.T) {
+		workbook, err := excelize.OpenReader(bytes.NewReader(write(t, ExportFormatXLSX)))
+		assert.Nil(t, err)
+		defer workbook.Close()
+
+		rows, err := workbook.GetRows(workbook.GetSheetName(0))
+		assert.Nil(t, err)
+		assert.Equal(t, [][]string{
+			{"code", "name", "quantity", "product_qualities"},
+			{"KGKG", "Kangkung, fresh", "2.5", `[{"quality":"Original"}]`},
+			{"BYAM", "Bayam", "10"},
+		}, rows)
+	})
+
+	t.Run("Text starting like a formula is quoted", func(t *testing.T) {
+		var output bytes.Buffer
+		writer, err := NewExportWriter(ExportFormatCSV, &output, []string{"code", "name", "quantity"})
+		assert.Nil(t, err)
+		for _, name := range []string{"=HYPERLINK(\"http://evil\")", "+1", "-1+2", "@SUM(A1)", "\tTab", "\rReturn", "Kangkung -1"} {
+			assert.Nil(t, writer.Write(map[string]interface{}{"code": "KGKG", "name": name, "quantity": json.Number("-2.5")}))
+		}
+		assert.Nil(t, writer.Close())
+
+		assert.Equal(t, "code,name,quantity\n"+
+			"KGKG,\"'=HYPERLINK(\"\"http://evil\"\")\",-2.5\n"+
+			"KGKG,'+1,-2.5\n"+
+			"KGKG,'-1+2,-2.5\n"+
+			"KGKG,'@SUM(A1),-2.5\n"+
+			"KGKG,'\tTab,-2.5\n"+
+			"KGKG,\"'\rReturn\",-2.5\n"+
+			"KGKG,Kangkung -1,-2.5\n", output.String())
+	})
+
+	t.Run("Unknown format", func(t *testing.T) {
+		_, err := NewExportWriter("pdf", &bytes.Buffer{}, columns)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestExportRecord(t *testing.T) {
+	type productResponse struct {
+		Code     string  `json:"code"`
+		Name     string  `json:"name"`
+		Quantity float64 `json:"quantity"`
+	}
+
+	record, err := ExportRecord(&productResponse{Code: "KGKG", Name: "Kangkung", Quantity: 2.5}, []string{"code", "quantity"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"code": "KGKG", "quantity": json.Number("2.5")}, record)
+}
+
+func TestListQueryValues(t *testing.T) {
+	values := url.Values{
+		"filter[type]": {"IN"},
+		"sort":         {"-created_at"},
+		"fields":       {"code"},
+		"include":      {"product_quality"},
+		"format":       {"csv"},
+		"async":        {"true"},
+		"page":         {"2"},
+	}
+
+	assert.Equal(t, url.Values{
+		"filter[type]": {"IN"},
+		"sort":         {"-created_at"},
+		"fields":       {"code"},
+		"include":      {"product_quality"},
+	}, ListQueryValues(values))
+}
@@ -0,0 +1,53 @@
+package util
+
+import (
+	"strings"
+)
+
+// NormalizeGTIN returns the GTIN-14 of a GTIN-8, UPC-A (GTIN-12), EAN-13 or GTIN-14, zero padded
+// on the left as GS1 stores them, and whether the value is a GTIN with a valid check digit.
+func NormalizeGTIN(value string) (string, bool) {
+	switch len(value) {
+	case 8, 12, 13, 14:
+	default:
+		return "", false
+	}
+
+	for _, digit := range value {
+		if digit < '0' || digit > '9' {
+			return "", false
+		}
+	}
+
+	gtin := strings.Repeat("0", 14-len(value)) + value
+
+	// Weights alternate 3 and 1 from the digit left of the check digit
+	sum := 0
+	for i, digit := range gtin[:13] {
+		weight := 1
+		if i%2 == 0 {
+			weight = 3
+		}
+		sum += int(digit-'0') * weight
+	}
+	if (10-sum%10)%10 != int(gtin[13]-'0') {
+		return "", false
+	}
+
+	return gtin, true
+}
+
+// NormalizeGTINPointer normalizes an optional GTIN, empty ones being none. Invalid GTINs are
+// returned as they are, for validation to refuse them.
+func NormalizeGTINPointer(value *string) *string {
+	if value == nil || *value == "" {
+		return nil
+	}
+
+	gtin, ok := NormalizeGTIN(*value)
+	if !ok {
+		return value
+	}
+
+	return &gtin
+}
@@ -0,0 +1,31 @@
+package util
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNormalizeGTIN(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		expected string
+		valid    bool
+	}{
+		{name: "GTIN-8", value: "96385074", expected: "00000096385074", valid: true},
+		{name: "UPC-A", value: "036000291452", expected: "00036000291452", valid: true},
+		{name: "EAN-13", value: "4006381333931", expected: "04006381333931", valid: true},
+		{name: "GTIN-14", value: "10012345678902", expected: "10012345678902", valid: true},
+		{name: "[invalid] Check digit", value: "4006381333932"},
+		{name: "[invalid] Length", value: "400638133393"},
+		{name: "[invalid] Not digits", value: "ABCDEFGHIJ12"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gtin, ok := NormalizeGTIN(tc.value)
+			assert.Equal(t, tc.valid, ok)
+			assert.Equal(t, tc.expected, gtin)
+		})
+	}
+}
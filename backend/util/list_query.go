@@ -0,0 +1,257 @@
+package util
+
+import (
+	"fmt"
+	"inventory-management/backend/internal/http/response"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ListOperatorEq   = "eq"
+	ListOperatorNe   = "ne"
+	ListOperatorGt   = "gt"
+	ListOperatorGte  = "gte"
+	ListOperatorLt   = "lt"
+	ListOperatorLte  = "lte"
+	ListOperatorLike = "like"
+	ListOperatorIn   = "in"
+)
+
+// The type of a filterable field decides the operators it accepts and how its values are parsed.
+const (
+	ListFieldText   = "text"
+	ListFieldNumber = "number"
+	ListFieldTime   = "time"
+)
+
+var listOperators = map[string][]string{
+	ListFieldText:   {ListOperatorEq, ListOperatorNe, ListOperatorLike, ListOperatorIn},
+	ListFieldNumber: {ListOperatorEq, ListOperatorNe, ListOperatorGt, ListOperatorGte, ListOperatorLt, ListOperatorLte, ListOperatorIn},
+	ListFieldTime:   {ListOperatorEq, ListOperatorGt, ListOperatorGte, ListOperatorLt, ListOperatorLte},
+}
+
+// Soft deleted records are hidden from lists unless deleted=include or deleted=only asks for them.
+const (
+	ListDeletedInclude = "include"
+	ListDeletedOnly    = "only"
+)
+
+var listFilterKey = regexp.MustCompile(`^filter\[([a-z_]+)\](?:\[([a-z]+)\])?$`)
+
+// ListSchema whitelists what the list endpoint of an entity can be filtered, sorted, selected
+// and included by. Field names are the columns of the entity, which are also the keys of its
+// response.
+type ListSchema struct {
+	// Filters maps a column to its type
+	Filters map[string]string
+	Sorts   []string
+	Fields  []string
+	// Includes maps an association of the response to the associations to preload for it
+	Includes map[string][]string
+	// Expressions maps a filter that is not a column of the entity to the SQL it stands for
+	Expressions map[string]string
+	// SoftDelete is set for entities whose deleted records are kept
+	SoftDelete bool
+}
+
+// ListQuery is a list request parsed against a ListSchema, so every field in it is whitelisted.
+type ListQuery struct {
+	Filters []*ListFilter
+	Sort    []*ListSort
+	Fields  []string
+	Include []string
+	// Deleted is empty, ListDeletedInclude or ListDeletedOnly
+	Deleted string
+}
+
+type ListFilter struct {
+	Field    string
+	Operator string
+	// Values holds one value, or several for the in operator, parsed to the type of the field
+	Values []interface{}
+	// Day is set when a time is given as a date, which then stands for the whole day
+	Day bool
+	// Expression is the SQL of a field that is not a column
+	Expression string
+}
+
+type ListSort struct {
+	Field      string
+	Descending bool
+}
+
+// ParseListQuery reads filter[field][op]=value, sort=-created_at,name, fields=id,name,
+// include=association and deleted=include|only from the query string. The operator defaults to
+// eq, and values of the in operator are separated by commas. Included associations are always
+// part of the fields.
+func ParseListQuery(values url.Values, schema *ListSchema) (*ListQuery, []*response.ErrorResponse) {
+	query := &ListQuery{}
+	var errors []*response.ErrorResponse
+
+	// Query parameters have no order, the filters get the one of their keys
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !strings.HasPrefix(key, "filter") {
+			continue
+		}
+
+		match := listFilterKey.FindStringSubmatch(key)
+		if match == nil {
+			errors = append(errors, listQueryError(key, "format"))
+			continue
+		}
+
+		field, operator := match[1], match[2]
+		if operator == "" {
+			operator = ListOperatorEq
+		}
+
+		fieldType, ok := schema.Filters[field]
+		if !ok || !containsString(listOperators[fieldType], operator) {
+			errors = append(errors, listQueryError(key, "oneof"))
+			continue
+		}
+
+		for _, value := range values[key] {
+			filter, tag := parseListFilter(field, fieldType, operator, value)
+			if tag != "" {
+				errors = append(errors, listQueryError(key, tag))
+				continue
+			}
+
+			filter.Expression = schema.Expressions[field]
+			query.Filters = append(query.Filters, filter)
+		}
+	}
+
+	for _, field := range listValues(values.Get("sort")) {
+		descending := strings.HasPrefix(field, "-")
+		field = strings.TrimPrefix(field, "-")
+		if !containsString(schema.Sorts, field) {
+			errors = append(errors, listQueryError("sort", "oneof"))
+			continue
+		}
+
+		query.Sort = append(query.Sort, &ListSort{Field: field, Descending: descending})
+	}
+
+	for _, include := range listValues(values.Get("include")) {
+		if _, ok := schema.Includes[include]; !ok {
+			errors = append(errors, listQueryError("include", "oneof"))
+			continue
+		}
+
+		query.Include = append(query.Include, include)
+	}
+
+	for _, field := range listValues(values.Get("fields")) {
+		_, included := schema.Includes[field]
+		if !containsString(schema.Fields, field) && !included {
+			errors = append(errors, listQueryError("fields", "oneof"))
+			continue
+		}
+
+		query.Fields = append(query.Fields, field)
+	}
+	if len(query.Fields) > 0 {
+		for _, include := range query.Include {
+			if !containsString(query.Fields, include) {
+				query.Fields = append(query.Fields, include)
+			}
+		}
+	}
+
+	if deleted := values.Get("deleted"); deleted != "" {
+		if !schema.SoftDelete || (deleted != ListDeletedInclude && deleted != ListDeletedOnly) {
+			errors = append(errors, listQueryError("deleted", "oneof"))
+		} else {
+			query.Deleted = deleted
+		}
+	}
+
+	if len(errors) > 0 {
+		return nil, errors
+	}
+
+	return query, nil
+}
+
+// parseListFilter returns the filter, or the tag of the validation that failed on the value.
+func parseListFilter(field string, fieldType string, operator string, value string) (*ListFilter, string) {
+	filter := &ListFilter{Field: field, Operator: operator}
+
+	rawValues := []string{value}
+	if operator == ListOperatorIn {
+		rawValues = listValues(value)
+	}
+	if len(rawValues) == 0 {
+		return nil, "required"
+	}
+
+	for _, rawValue := range rawValues {
+		switch fieldType {
+		case ListFieldNumber:
+			number, err := strconv.ParseFloat(rawValue, 64)
+			if err != nil {
+				return nil, "number"
+			}
+			filter.Values = append(filter.Values, number)
+		case ListFieldTime:
+			if day, err := time.ParseInLocation("2006-01-02", rawValue, time.Local); err == nil {
+				filter.Values = append(filter.Values, day)
+				filter.Day = true
+				continue
+			}
+
+			moment, err := time.Parse(time.RFC3339, rawValue)
+			if err != nil {
+				return nil, "datetime"
+			}
+			filter.Values = append(filter.Values, moment)
+		default:
+			filter.Values = append(filter.Values, rawValue)
+		}
+	}
+
+	return filter, ""
+}
+
+func listValues(value string) []string {
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			values = append(values, item)
+		}
+	}
+
+	return values
+}
+
+func listQueryError(field string, tag string) *response.ErrorResponse {
+	return &response.ErrorResponse{
+		FailedField: field,
+		Tag:         tag,
+		Value:       fmt.Sprintf("Error validation '%s' for '%s' field", tag, field),
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,112 @@
+package util
+
+import (
+	"github.com/stretchr/testify/assert"
+	"inventory-management/backend/internal/http/response"
+	"net/url"
+	"testing"
+	"time"
+)
+
+var testListSchema = &ListSchema{
+	Filters: map[string]string{
+		"name":         ListFieldText,
+		"quantity":     ListFieldNumber,
+		"created_at":   ListFieldTime,
+		"product_code": ListFieldText,
+	},
+	Sorts:  []string{"name", "created_at"},
+	Fields: []string{"code", "name"},
+	Includes: map[string][]string{
+		"transactions": {"Transactions"},
+	},
+	Expressions: map[string]string{
+		"product_code": "(SELECT code FROM products)",
+	},
+	SoftDelete: true,
+}
+
+func TestParseListQuery(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		expectedQuery  *ListQuery
+		expectedErrors []*response.ErrorResponse
+	}{
+		{
+			name:          "Nothing but the page",
+			query:         "page=2&limit=10",
+			expectedQuery: &ListQuery{},
+		},
+		{
+			name:  "Filters of every type",
+			query: "filter[name]=kangkung&filter[quantity][in]=1,2.5&filter[created_at][lte]=2021-01-31&filter[created_at][gt]=2021-01-01T08:00:00Z",
+			expectedQuery: &ListQuery{
+				Filters: []*ListFilter{
+					{Field: "created_at", Operator: ListOperatorGt, Values: []interface{}{time.Date(2021, 1, 1, 8, 0, 0, 0, time.UTC)}},
+					{Field: "created_at", Operator: ListOperatorLte, Values: []interface{}{time.Date(2021, 1, 31, 0, 0, 0, 0, time.Local)}, Day: true},
+					{Field: "name", Operator: ListOperatorEq, Values: []interface{}{"kangkung"}},
+					{Field: "quantity", Operator: ListOperatorIn, Values: []interface{}{float64(1), 2.5}},
+				},
+			},
+		},
+		{
+			name:  "Filter on an expression instead of a column",
+			query: "filter[product_code]=PRD001",
+			expectedQuery: &ListQuery{
+				Filters: []*ListFilter{{Field: "product_code", Operator: ListOperatorEq, Values: []interface{}{"PRD001"}, Expression: "(SELECT code FROM products)"}},
+			},
+		},
+		{
+			name:  "Sort on several fields, with the included associations kept in the fields",
+			query: "sort=-created_at,name&fields=code&include=transactions",
+			expectedQuery: &ListQuery{
+				Sort:    []*ListSort{{Field: "created_at", Descending: true}, {Field: "name"}},
+				Fields:  []string{"code", "transactions"},
+				Include: []string{"transactions"},
+			},
+		},
+		{
+			name:          "Deleted records only",
+			query:         "deleted=only",
+			expectedQuery: &ListQuery{Deleted: ListDeletedOnly},
+		},
+		{
+			name:           "Deleted records neither included nor only",
+			query:          "deleted=all",
+			expectedErrors: []*response.ErrorResponse{listQueryError("deleted", "oneof")},
+		},
+		{
+			name:  "Fields and operators out of the whitelist",
+			query: "filter[password]=secret&filter[name][gt]=a&sort=password&fields=password&include=users&filter[name",
+			expectedErrors: []*response.ErrorResponse{
+				listQueryError("filter[name", "format"),
+				listQueryError("filter[name][gt]", "oneof"),
+				listQueryError("filter[password]", "oneof"),
+				listQueryError("sort", "oneof"),
+				listQueryError("include", "oneof"),
+				listQueryError("fields", "oneof"),
+			},
+		},
+		{
+			name:  "Values not matching the type of the field",
+			query: "filter[quantity][gte]=many&filter[created_at]=yesterday&filter[name][in]=,",
+			expectedErrors: []*response.ErrorResponse{
+				listQueryError("filter[created_at]", "datetime"),
+				listQueryError("filter[name][in]", "required"),
+				listQueryError("filter[quantity][gte]", "number"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tc.query)
+			assert.NoError(t, err)
+
+			query, errors := ParseListQuery(values, testListSchema)
+			assert.Equal(t, tc.expectedQuery, query)
+			assert.Equal(t, tc.expectedErrors, errors)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	NumberingStrategySequence = "sequence"
+	NumberingStrategyRandom   = "random"
+
+	NumberingResetNever   = "never"
+	NumberingResetYearly  = "yearly"
+	NumberingResetMonthly = "monthly"
+)
+
+var (
+	numberingTokenPattern   = regexp.MustCompile(`\{(TYPE|YYYY|YY|MM|DD|SEQ)\}`)
+	numberingLiteralPattern = regexp.MustCompile(`^[A-Za-z0-9._-]*$`)
+)
+
+// FormatNumber turns a numbering pattern into a code. {SEQ} is the number zero-padded to
+// padding digits, {TYPE} the transaction type, and {YYYY}, {YY}, {MM} and {DD} the date at.
+func FormatNumber(pattern string, number int64, padding int, transactionType string, at time.Time) string {
+	return strings.NewReplacer(
+		"{SEQ}", fmt.Sprintf("%0*d", padding, number),
+		"{TYPE}", transactionType,
+		"{YYYY}", at.Format("2006"),
+		"{YY}", at.Format("06"),
+		"{MM}", at.Format("01"),
+		"{DD}", at.Format("02"),
+	).Replace(pattern)
+}
+
+// NumberingPeriod is the period a number is counted in: the year or the month of at for
+// sequences reset yearly or monthly, empty for those never reset.
+func NumberingPeriod(reset string, at time.Time) string {
+	switch reset {
+	case NumberingResetYearly:
+		return at.Format("2006")
+	case NumberingResetMonthly:
+		return at.Format("2006-01")
+	default:
+		return ""
+	}
+}
+
+// ValidNumberingPattern reports whether the pattern makes codes that can be put in a URL and
+// that are distinct however the sequence is reset: it holds {SEQ}, the year when reset yearly
+// and the year and month when reset monthly. The sequence of one type of transaction counts
+// apart from the others, so its pattern holds {TYPE} as well.
+func ValidNumberingPattern(pattern string, reset string, perType bool) bool {
+	tokens := map[string]bool{}
+	for _, match := range numberingTokenPattern.FindAllStringSubmatch(pattern, -1) {
+		tokens[match[1]] = true
+	}
+	if !numberingLiteralPattern.MatchString(numberingTokenPattern.ReplaceAllString(pattern, "")) || !tokens["SEQ"] {
+		return false
+	}
+	if perType && !tokens["TYPE"] {
+		return false
+	}
+
+	year := tokens["YYYY"] || tokens["YY"]
+	switch reset {
+	case NumberingResetYearly:
+		return year
+	case NumberingResetMonthly:
+		return year && tokens["MM"]
+	default:
+		return true
+	}
+}
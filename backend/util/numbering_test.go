@@ -0,0 +1,65 @@
+package util
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFormatNumber(t *testing.T) {
+	at := time.Date(2026, time.March, 7, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		pattern  string
+		number   int64
+		padding  int
+		expected string
+	}{
+		{name: "Type and year", pattern: "{TYPE}-{YYYY}-{SEQ}", number: 123, padding: 6, expected: "IN-2026-000123"},
+		{name: "Prefix", pattern: "SUP-{SEQ}", number: 42, padding: 4, expected: "SUP-0042"},
+		{name: "Date", pattern: "{YY}{MM}{DD}.{SEQ}", number: 5, padding: 2, expected: "260307.05"},
+		{name: "Number longer than the padding", pattern: "{SEQ}", number: 12345, padding: 3, expected: "12345"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, FormatNumber(tc.pattern, tc.number, tc.padding, "IN", at))
+		})
+	}
+}
+
+func TestNumberingPeriod(t *testing.T) {
+	at := time.Date(2026, time.March, 7, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "", NumberingPeriod(NumberingResetNever, at))
+	assert.Equal(t, "2026", NumberingPeriod(NumberingResetYearly, at))
+	assert.Equal(t, "2026-03", NumberingPeriod(NumberingResetMonthly, at))
+}
+
+func TestValidNumberingPattern(t *testing.T) {
+	testCases := []struct {
+		name    string
+		pattern string
+		reset   string
+		perType bool
+		valid   bool
+	}{
+		{name: "Never reset", pattern: "SUP-{SEQ}", reset: NumberingResetNever, valid: true},
+		{name: "Yearly reset", pattern: "{TYPE}-{YY}-{SEQ}", reset: NumberingResetYearly, valid: true},
+		{name: "Monthly reset", pattern: "{YYYY}{MM}-{SEQ}", reset: NumberingResetMonthly, valid: true},
+		{name: "Sequence of a transaction type", pattern: "{TYPE}-{SEQ}", reset: NumberingResetNever, perType: true, valid: true},
+		{name: "[invalid] No sequence", pattern: "SUP-{YYYY}", reset: NumberingResetNever},
+		{name: "[invalid] Yearly reset without the year", pattern: "IN-{SEQ}", reset: NumberingResetYearly},
+		{name: "[invalid] Monthly reset without the month", pattern: "IN-{YYYY}-{SEQ}", reset: NumberingResetMonthly},
+		{name: "[invalid] Sequence of a transaction type without the type", pattern: "TRX-{SEQ}", reset: NumberingResetNever, perType: true},
+		{name: "[invalid] Unknown token", pattern: "{PREFIX}-{SEQ}", reset: NumberingResetNever},
+		{name: "[invalid] Not URL safe", pattern: "IN/{SEQ}", reset: NumberingResetNever},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.valid, ValidNumberingPattern(tc.pattern, tc.reset, tc.perType))
+		})
+	}
+}
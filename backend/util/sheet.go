@@ -0,0 +1,137 @@
+package util
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"inventory-management/backend/internal/http/response"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SheetRow is a row of an uploaded sheet keyed by the headers of its first row. Number is the
+// row as the spreadsheet shows it, the header being row 1.
+type SheetRow struct {
+	Number int
+	Values map[string]string
+}
+
+// ReadSheet reads the rows of a CSV file or of the first sheet of an XLSX workbook, picked by
+// the extension of the file name. Blank rows are skipped.
+func ReadSheet(filename string, file io.Reader) ([]*SheetRow, error) {
+	var records [][]string
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		reader := csv.NewReader(file)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+
+		var err error
+		records, err = reader.ReadAll()
+		if err != nil {
+			return nil, err
+		}
+	case ".xlsx":
+		workbook, err := excelize.OpenReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer workbook.Close()
+
+		records, err = workbook.GetRows(workbook.GetSheetName(0))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New(response.ErrorSheetFileType)
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New(response.ErrorSheetEmpty)
+	}
+
+	headers := make([]string, len(records[0]))
+	for i, header := range records[0] {
+		// Spreadsheets saved as CSV often start with a byte order mark
+		header = strings.TrimPrefix(header, "\ufeff")
+		headers[i] = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(header)), " ", "_")
+	}
+
+	var rows []*SheetRow
+	for i, record := range records[1:] {
+		row := &SheetRow{Number: i + 2, Values: map[string]string{}}
+		blank := true
+		for j, value := range record {
+			if j >= len(headers) || headers[j] == "" {
+				continue
+			}
+
+			value = strings.TrimSpace(value)
+			row.Values[headers[j]] = value
+			if value != "" {
+				blank = false
+			}
+		}
+
+		if !blank {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+// BindSheetRow sets the fields of dest from the columns named like their json tags, so a row
+// fills the same request a JSON body would. Cells that do not parse as numbers are reported like
+// ValidateStruct reports its errors.
+func BindSheetRow(row *SheetRow, dest interface{}) []*response.ErrorResponse {
+	var errs []*response.ErrorResponse
+
+	value := reflect.ValueOf(dest).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		cell, ok := row.Values[name]
+		if name == "" || !ok || cell == "" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		switch fieldValue.Kind() {
+		case reflect.String:
+			fieldValue.SetString(cell)
+		case reflect.Ptr:
+			if fieldValue.Type().Elem().Kind() == reflect.String {
+				fieldValue.Set(reflect.ValueOf(&cell))
+			}
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			number, err := strconv.ParseInt(cell, 10, 64)
+			if err != nil {
+				errs = append(errs, sheetRowError(field.Name))
+				continue
+			}
+			fieldValue.SetInt(number)
+		case reflect.Float32, reflect.Float64:
+			number, err := strconv.ParseFloat(cell, 64)
+			if err != nil {
+				errs = append(errs, sheetRowError(field.Name))
+				continue
+			}
+			fieldValue.SetFloat(number)
+		}
+	}
+
+	return errs
+}
+
+func sheetRowError(field string) *response.ErrorResponse {
+	return &response.ErrorResponse{
+		FailedField: field,
+		Tag:         "number",
+		Value:       fmt.Sprintf("Error validation '%s' for '%s' field", "number", field),
+	}
+}
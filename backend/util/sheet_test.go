@@ -0,0 +1,80 @@
+package util
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"inventory-management/backend/internal/http/response"
+	"strings"
+	"testing"
+)
+
+func TestReadSheet(t *testing.T) {
+	expectedRows := []*SheetRow{
+		{Number: 2, Values: map[string]string{"name": "Widdy", "phone": "089911182399"}},
+		{Number: 4, Values: map[string]string{"name": "Arfiansyah"}},
+	}
+
+	t.Run("CSV with a byte order mark and a blank row", func(t *testing.T) {
+		rows, err := ReadSheet("suppliers.CSV", strings.NewReader("\ufeffName, Phone\nWiddy,089911182399\n,\nArfiansyah\n"))
+		assert.Nil(t, err)
+		assert.Equal(t, expectedRows, rows)
+	})
+
+	t.Run("First sheet of an XLSX workbook", func(t *testing.T) {
+		workbook := excelize.NewFile()
+		sheet := workbook.GetSheetName(0)
+		assert.Nil(t, workbook.SetSheetRow(sheet, "A1", &[]interface{}{"Name", "Phone"}))
+		assert.Nil(t, workbook.SetSheetRow(sheet, "A2", &[]interface{}{"Widdy", "089911182399"}))
+		assert.Nil(t, workbook.SetSheetRow(sheet, "A4", &[]interface{}{"Arfiansyah"}))
+
+		var file bytes.Buffer
+		assert.Nil(t, workbook.Write(&file))
+
+		rows, err := ReadSheet("suppliers.xlsx", &file)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedRows, rows)
+	})
+
+	t.Run("File of another type", func(t *testing.T) {
+		_, err := ReadSheet("suppliers.json", strings.NewReader("[]"))
+		assert.Equal(t, response.ErrorSheetFileType, err.Error())
+	})
+
+	t.Run("Empty file", func(t *testing.T) {
+		_, err := ReadSheet("suppliers.csv", strings.NewReader(""))
+		assert.Equal(t, response.ErrorSheetEmpty, err.Error())
+	})
+}
+
+func TestBindSheetRow(t *testing.T) {
+	type sheetRequest struct {
+		Name        string  `json:"name"`
+		Price       int64   `json:"price"`
+		Quantity    float64 `json:"quantity"`
+		Description *string `json:"description"`
+	}
+
+	t.Run("Cells parsed to the types of the fields", func(t *testing.T) {
+		var request sheetRequest
+		errs := BindSheetRow(&SheetRow{Values: map[string]string{"name": "Kangkung", "price": "1500", "quantity": "2.5", "description": "Fresh"}}, &request)
+		assert.Nil(t, errs)
+		assert.Equal(t, sheetRequest{Name: "Kangkung", Price: 1500, Quantity: 2.5, Description: ToPointerString("Fresh")}, request)
+	})
+
+	t.Run("Empty cells leave the zero values", func(t *testing.T) {
+		var request sheetRequest
+		errs := BindSheetRow(&SheetRow{Values: map[string]string{"name": "Kangkung", "description": ""}}, &request)
+		assert.Nil(t, errs)
+		assert.Equal(t, sheetRequest{Name: "Kangkung"}, request)
+	})
+
+	t.Run("Cells that are not numbers", func(t *testing.T) {
+		var request sheetRequest
+		errs := BindSheetRow(&SheetRow{Values: map[string]string{"price": "cheap", "quantity": "1,5"}}, &request)
+		assert.Equal(t, []*response.ErrorResponse{
+			{FailedField: "Price", Tag: "number", Value: "Error validation 'number' for 'Price' field"},
+			{FailedField: "Quantity", Tag: "number", Value: "Error validation 'number' for 'Quantity' field"},
+		}, errs)
+	})
+}
@@ -0,0 +1,52 @@
+package util
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// ParseSigningKey decodes a base64 encoded 32 byte seed into an Ed25519 private key.
+func ParseSigningKey(value string) (ed25519.PrivateKey, error) {
+	seed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(seed) != ed25519.SeedSize {
+		return nil, errors.New("signing key must be a base64 encoded 32 byte seed")
+	}
+
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// SignValue appends an HMAC-SHA256 of the value with the key, so a value given back, such as by
+// a cookie, can be checked to be one the server gave.
+func SignValue(key []byte, value string) string {
+	return value + "." + base64.RawURLEncoding.EncodeToString(valueSignature(key, value))
+}
+
+// VerifySignedValue returns the value of a signed value, ok when its signature matches.
+func VerifySignedValue(key []byte, signed string) (string, bool) {
+	separator := strings.LastIndex(signed, ".")
+	if separator < 0 {
+		return "", false
+	}
+
+	value := signed[:separator]
+	signature, err := base64.RawURLEncoding.DecodeString(signed[separator+1:])
+	if err != nil || !hmac.Equal(signature, valueSignature(key, value)) {
+		return "", false
+	}
+
+	return value, true
+}
+
+func valueSignature(key []byte, value string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,74 @@
+package util
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestParseSigningKey(t *testing.T) {
+	testCases := []struct {
+		name          string
+		value         string
+		expectedError bool
+	}{
+		{
+			name:          "Valid seed",
+			value:         base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", 32))),
+			expectedError: false,
+		},
+		{
+			name:          "Seed with a wrong length",
+			value:         base64.StdEncoding.EncodeToString([]byte("short")),
+			expectedError: true,
+		},
+		{
+			name:          "Seed is not base64",
+			value:         "not base64!",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := ParseSigningKey(tc.value)
+			if tc.expectedError {
+				if err == nil {
+					t.Errorf("Expected an error for %q", tc.value)
+				}
+				return
+			}
+
+			if err != nil || len(key) == 0 {
+				t.Errorf("Expected a signing key, got error %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifySignedValue(t *testing.T) {
+	key := []byte("key")
+	signed := SignValue(key, "STATE")
+
+	testCases := []struct {
+		name          string
+		signed        string
+		expectedValue string
+		expectedOk    bool
+	}{
+		{name: "Value signed with the key", signed: signed, expectedValue: "STATE", expectedOk: true},
+		{name: "Value signed with another key", signed: SignValue([]byte("other"), "STATE")},
+		{name: "Value changed after it was signed", signed: "OTHER" + signed[len("STATE"):]},
+		{name: "Value without a signature", signed: "STATE"},
+		{name: "Signature is not base64", signed: "STATE.not base64!"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, ok := VerifySignedValue(key, tc.signed)
+			if ok != tc.expectedOk || value != tc.expectedValue {
+				t.Errorf("Expected %q, %v for %q, got %q, %v", tc.expectedValue, tc.expectedOk, tc.signed, value, ok)
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package util
 
-import "crypto/rand"
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
 
 func GenerateRandomString(length int) (string, error) {
 	const charset = "ABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890"
@@ -19,6 +24,33 @@ func GenerateRandomString(length int) (string, error) {
 	return string(randomBytes), nil
 }
 
+// HashToken returns the hex encoded SHA-256 digest of a one-time token, so only the digest is stored.
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
 func ToPointerString(value string) *string {
 	return &value
 }
+
+// ParseKeyValueList parses "key:value" pairs separated by commas, e.g. "admins:admin,clerks:staff".
+func ParseKeyValueList(value string) map[string]string {
+	pairs := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(pair, ":")
+		if !ok {
+			continue
+		}
+
+		key = strings.TrimSpace(key)
+		val = strings.TrimSpace(val)
+		if key == "" || val == "" {
+			continue
+		}
+
+		pairs[key] = val
+	}
+
+	return pairs
+}
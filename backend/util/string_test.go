@@ -41,3 +41,28 @@ func TestToPointerString(t *testing.T) {
 		}
 	})
 }
+
+func TestParseKeyValueList(t *testing.T) {
+	t.Run("Parse key value pairs", func(t *testing.T) {
+		pairs := ParseKeyValueList("warehouse-admins:admin, clerks : staff")
+		if len(pairs) != 2 || pairs["warehouse-admins"] != "admin" || pairs["clerks"] != "staff" {
+			t.Errorf("The parsed pairs are not as expected: %v", pairs)
+		}
+	})
+
+	t.Run("Skip malformed pairs", func(t *testing.T) {
+		pairs := ParseKeyValueList("admin,:staff,clerks:,")
+		if len(pairs) != 0 {
+			t.Errorf("The parsed pairs should be empty: %v", pairs)
+		}
+	})
+}
+
+func TestHashToken(t *testing.T) {
+	t.Run("Hash token", func(t *testing.T) {
+		hash := HashToken("token")
+		if hash != "3c469e9d6c5875d37a43f353d4f88e61fcf812c66eee3457465a40b0da4153e0" {
+			t.Errorf("The hash of token is not as expected: %s", hash)
+		}
+	})
+}
@@ -7,7 +7,19 @@ import (
 	"reflect"
 )
 
-var validate = validator.New()
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+
+	// gtin accepts a GTIN-8, GTIN-12, GTIN-13 or GTIN-14 with a valid check digit
+	_ = v.RegisterValidation("gtin", func(field validator.FieldLevel) bool {
+		_, ok := NormalizeGTIN(field.Field().String())
+		return ok
+	})
+
+	return v
+}
 
 func ValidateStruct(entity interface{}) []*response.ErrorResponse {
 	val := reflect.ValueOf(entity)
@@ -0,0 +1,51 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+// zplEscaper replaces the characters ZPL reads as commands within field data, ^ and ~ being
+// the command prefixes.
+var zplEscaper = strings.NewReplacer("^", " ", "~", " ")
+
+// ZPLText makes text safe to print as the data of a ZPL field.
+func ZPLText(text string) string {
+	return zplEscaper.Replace(text)
+}
+
+// ZPLBarcode is the ZPL barcode command with its field data for the value in the symbology,
+// to be placed after a field origin and closed with ^FS. The printer encodes the barcode itself,
+// GS1-128 in the UCC/EAN mode that reads the (AI)data form GS1Text returns.
+func ZPLBarcode(symbology string, value string, height int) (string, error) {
+	value = ZPLText(value)
+	switch symbology {
+	case BarcodeCode128:
+		// >: starts in subset B, so data holding > or digits is printed as it is
+		return fmt.Sprintf("^BCN,%d,Y,N,N^FD>:%s", height, strings.ReplaceAll(value, ">", "><")), nil
+	case BarcodeQR:
+		// Error correction M with automatic input mode
+		return fmt.Sprintf("^BQN,2,%d^FDMA,%s", qrMagnification(height), value), nil
+	case BarcodeGS1128:
+		if _, err := gs1Content(value); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("^BCN,%d,Y,N,N,D^FD%s", height, value), nil
+	default:
+		return "", fmt.Errorf("unknown barcode symbology %q", symbology)
+	}
+}
+
+// qrMagnification is the ZPL magnification, 1 to 10, that makes a small QR code about height
+// dots high.
+func qrMagnification(height int) int {
+	magnification := height / 25
+	if magnification < 1 {
+		return 1
+	}
+	if magnification > 10 {
+		return 10
+	}
+
+	return magnification
+}
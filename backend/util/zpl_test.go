@@ -0,0 +1,34 @@
+package util
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestZPLBarcode(t *testing.T) {
+	testCases := []struct {
+		name          string
+		symbology     string
+		value         string
+		expected      string
+		expectedError bool
+	}{
+		{name: "Code 128", symbology: BarcodeCode128, value: "AB>C^D", expected: "^BCN,100,Y,N,N^FD>:AB><C D"},
+		{name: "QR code", symbology: BarcodeQR, value: "ABCD", expected: "^BQN,2,4^FDMA,ABCD"},
+		{name: "GS1-128", symbology: BarcodeGS1128, value: "(240)ABCD(91)1", expected: "^BCN,100,Y,N,N,D^FD(240)ABCD(91)1"},
+		{name: "[invalid] GS1-128 without application identifiers", symbology: BarcodeGS1128, value: "ABCD", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			command, err := ZPLBarcode(tc.symbology, tc.value, 100)
+			if tc.expectedError {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, command)
+		})
+	}
+}